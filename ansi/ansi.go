@@ -0,0 +1,252 @@
+// Package ansi 终端颜色控制码的解析：把 Vite、zap 等输出中的 SGR 序列（ESC [ ... m）拆成带样式的文本片段，
+// 面板据此用主题颜色显示；其余控制序列（光标移动、清除行等）直接去掉
+package ansi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Color 前景色（只区分基本的 8 种颜色，亮色与对应的基本色相同，256 色和真彩色取最接近的基本色）
+type Color int
+
+const (
+	Default Color = iota
+	Black
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	Gray // 亮黑色（Vite 用于时间戳和次要信息）
+)
+
+// basicColors 颜色编号 0-7 对应的颜色
+var basicColors = [8]Color{Black, Red, Green, Yellow, Blue, Magenta, Cyan, White}
+
+// Style 文本样式
+type Style struct {
+	Color     Color
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+}
+
+// Segment 样式相同的一段文本
+type Segment struct {
+	Text  string
+	Style Style
+}
+
+// Parse 把一行输出拆成带样式的片段（每行从默认样式开始，相邻的同样式片段合并，不含空片段）；
+// 行中有回车时只保留最后一次回车之后的内容（与终端中看到的一致）
+func Parse(line string) []Segment {
+	line = lastCarriageReturn(line)
+	var segments []Segment
+	var style Style
+	var text strings.Builder
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		if n := len(segments); n > 0 && segments[n-1].Style == style {
+			segments[n-1].Text += text.String()
+		} else {
+			segments = append(segments, Segment{Text: text.String(), Style: style})
+		}
+		text.Reset()
+	}
+	for i := 0; i < len(line); {
+		if line[i] != '\x1b' {
+			next := strings.IndexByte(line[i:], '\x1b')
+			if next < 0 {
+				next = len(line) - i
+			}
+			text.WriteString(line[i : i+next])
+			i += next
+			continue
+		}
+		params, final, n := sequence(line[i:])
+		i += n
+		if final == 'm' {
+			flush()
+			style = apply(style, params)
+		}
+	}
+	flush()
+	return segments
+}
+
+// Strip 去掉文本中的所有控制序列（复制、搜索时使用）
+func Strip(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\x1b' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		_, _, n := sequence(s[i:])
+		i += n
+	}
+	return b.String()
+}
+
+// lastCarriageReturn 返回最后一个（不在行尾的）回车之后的内容
+func lastCarriageReturn(line string) string {
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		return line[i+1:]
+	}
+	return line
+}
+
+// sequence 解析 s 开头的控制序列，返回 CSI 序列的参数和结束字符（其他序列的结束字符为 0）以及序列的长度。
+// 支持 CSI（ESC [ ... 字母）、OSC（ESC ] ... BEL 或 ESC \，例如终端超链接）和两个字符的转义序列
+func sequence(s string) (params string, final byte, n int) {
+	if len(s) < 2 {
+		return "", 0, len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				return s[2:i], c, i + 1
+			}
+		}
+		return "", 0, len(s)
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return "", 0, i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return "", 0, i + 2
+			}
+		}
+		return "", 0, len(s)
+	default:
+		return "", 0, 2
+	}
+}
+
+// apply 在 style 上应用一个 SGR 序列的参数（空参数等同于 0，即恢复默认样式）
+func apply(style Style, params string) Style {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			style = Style{}
+		case code == 1:
+			style.Bold = true
+		case code == 2:
+			style.Dim = true
+		case code == 3:
+			style.Italic = true
+		case code == 4:
+			style.Underline = true
+		case code == 22:
+			style.Bold, style.Dim = false, false
+		case code == 23:
+			style.Italic = false
+		case code == 24:
+			style.Underline = false
+		case code >= 30 && code <= 37:
+			style.Color = basicColors[code-30]
+		case code == 39:
+			style.Color = Default
+		case code == 90:
+			style.Color = Gray
+		case code >= 91 && code <= 97:
+			style.Color = basicColors[code-90]
+		case code == 38 || code == 48:
+			// 扩展颜色：5;n（256 色）或 2;r;g;b（真彩色），背景色只跳过参数
+			color, used := extended(codes[i+1:])
+			if code == 38 && used > 0 {
+				style.Color = color
+			}
+			i += used
+		}
+	}
+	return style
+}
+
+// extended 解析 38 / 48 之后的扩展颜色参数，返回颜色和用掉的参数个数
+func extended(codes []string) (Color, int) {
+	if len(codes) == 0 {
+		return Default, 0
+	}
+	num := func(i int) int {
+		if i >= len(codes) {
+			return 0
+		}
+		n, _ := strconv.Atoi(codes[i])
+		return n
+	}
+	switch codes[0] {
+	case "5":
+		return color256(num(1)), min(2, len(codes))
+	case "2":
+		return nearest(num(1), num(2), num(3)), min(4, len(codes))
+	}
+	return Default, 1
+}
+
+// color256 256 色调色板中的颜色：0-15 为基本色和亮色，16-231 为 6×6×6 的色块，232-255 为灰阶
+func color256(n int) Color {
+	switch {
+	case n == 8:
+		return Gray
+	case n >= 0 && n < 16:
+		return basicColors[n%8]
+	case n >= 16 && n <= 231:
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		n -= 16
+		return nearest(level(n/36), level(n/6%6), level(n%6))
+	case n >= 232 && n <= 255:
+		return nearest(8+(n-232)*10, 8+(n-232)*10, 8+(n-232)*10)
+	}
+	return Default
+}
+
+// nearest 与 RGB 颜色最接近的基本色：饱和度低时按亮度取黑、灰、白，否则按较亮的通道组合取色相
+func nearest(r, g, b int) Color {
+	hi, lo := max(r, g, b), min(r, g, b)
+	if hi-lo < 48 {
+		switch {
+		case hi < 64:
+			return Black
+		case hi < 192:
+			return Gray
+		default:
+			return White
+		}
+	}
+	mid := (hi + lo) / 2
+	switch red, green, blue := r > mid, g > mid, b > mid; {
+	case red && green:
+		return Yellow
+	case red && blue:
+		return Magenta
+	case green && blue:
+		return Cyan
+	case red:
+		return Red
+	case green:
+		return Green
+	default:
+		return Blue
+	}
+}
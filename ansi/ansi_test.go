@@ -0,0 +1,72 @@
+package ansi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVite(t *testing.T) {
+	// Vite 的就绪行：绿色加粗的 VITE、灰色的版本号和耗时
+	line := "\x1b[32m\x1b[1mVITE\x1b[22m v5.4.2\x1b[39m  \x1b[2mready in \x1b[0m\x1b[1m812\x1b[22m\x1b[2m\x1b[0m ms\x1b[22m"
+	want := []Segment{
+		{"VITE", Style{Color: Green, Bold: true}},
+		{" v5.4.2", Style{Color: Green}},
+		{"  ", Style{}},
+		{"ready in ", Style{Dim: true}},
+		{"812", Style{Bold: true}},
+		{" ms", Style{}},
+	}
+	if got := Parse(line); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse = %+v", got)
+	}
+	if got := Strip(line); got != "VITE v5.4.2  ready in 812 ms" {
+		t.Errorf("Strip = %q", got)
+	}
+}
+
+func TestParseMergesAndDropsOtherSequences(t *testing.T) {
+	// 清除行、光标移动和超链接不产生片段；样式没有变化的相邻片段合并
+	line := "\x1b[2K\x1b[1G\x1b[33mwarn\x1b[33m:\x1b[0m \x1b]8;;http://localhost\x07link\x1b]8;;\x07"
+	want := []Segment{{"warn:", Style{Color: Yellow}}, {" link", Style{}}}
+	if got := Parse(line); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse = %+v", got)
+	}
+	if got := Parse("plain"); !reflect.DeepEqual(got, []Segment{{"plain", Style{}}}) {
+		t.Errorf("Parse = %+v", got)
+	}
+	if got := Parse("\x1b[31m"); got != nil {
+		t.Errorf("只有控制码时应没有片段: %+v", got)
+	}
+	if got := Parse("transforming (1)\rtransforming (42)\r"); !reflect.DeepEqual(got, []Segment{{"transforming (42)", Style{}}}) {
+		t.Errorf("回车前的内容应被覆盖: %+v", got)
+	}
+	if got := Strip("half\x1b["); got != "half" {
+		t.Errorf("不完整的序列应去掉: %q", got)
+	}
+}
+
+func TestColors(t *testing.T) {
+	cases := map[string]Color{
+		"31":               Red,
+		"91":               Red,
+		"90":               Gray,
+		"1;36":             Cyan,
+		"38;5;9":           Red,
+		"38;5;8":           Gray,
+		"38;5;214":         Yellow,
+		"38;5;240":         Gray,
+		"38;2;0;0;0":       Black,
+		"38;2;30;80;220":   Blue,
+		"38;2;152;195;121": Green,
+		"48;5;1;35":        Magenta,
+		"48;2;255;0;0":     Default,
+	}
+	for params, want := range cases {
+		if got := apply(Style{}, params).Color; got != want {
+			t.Errorf("%s: got %d, want %d", params, got, want)
+		}
+	}
+	if got := apply(Style{Color: Red, Bold: true}, ""); got != (Style{}) {
+		t.Errorf("空参数应恢复默认样式: %+v", got)
+	}
+}
@@ -0,0 +1,329 @@
+// Package apicontract 对比后端的 swagger 文档（swag init 生成的 server/docs/swagger.json）与前端 web/src/api 中的接口调用，
+// 找出前端调用了但后端没有提供的接口和后端提供了但前端没有调用的接口，发现代码生成或改名后前后端接口不一致
+package apicontract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"gva-launcher/apperr"
+)
+
+// SwaggerFiles swag 生成的文档（相对 server 目录，按顺序使用第一个存在的）
+var SwaggerFiles = []string{"docs/swagger.json", "docs/swagger.yaml"}
+
+// APIDirs 前端接口定义所在的目录（相对 web 目录，包括插件的 api 目录）
+var APIDirs = []string{"src/api", "src/plugin/*/api"}
+
+// methods swagger 中表示接口的方法
+var methods = []string{"get", "post", "put", "delete", "patch", "head", "options"}
+
+var (
+	// urlRe service({ url: '/user/getUserList', ... }) 中的地址
+	urlRe = regexp.MustCompile("\\burl:\\s*(?:'([^'\\n]*)'|\"([^\"\\n]*)\"|`([^`\\n]*)`)")
+	// methodRe 同一个请求中的方法
+	methodRe = regexp.MustCompile("\\bmethod:\\s*['\"`](\\w+)['\"`]")
+	// shortRe service.post('/user/getUserList', data) 写法
+	shortRe = regexp.MustCompile("\\bservice\\.(get|post|put|delete|patch)\\(\\s*(?:'([^'\\n]*)'|\"([^\"\\n]*)\"|`([^`\\n]*)`)")
+	// paramRe 路径中的参数：{id}、:id 和模板字符串的 ${id}
+	paramRe = regexp.MustCompile(`\$\{[^}]*\}|\{[^}]*\}|(^|/):[^/]+`)
+)
+
+// Endpoint 一个接口
+type Endpoint struct {
+	Method string // 大写，例如 POST
+	Path   string
+}
+
+// String 例如 POST /user/getUserList
+func (e Endpoint) String() string {
+	return e.Method + " " + e.Path
+}
+
+// key 比较用的键：路径参数统一为 {}，去掉查询参数和末尾的 /
+func (e Endpoint) key() string {
+	return e.Method + " " + normalizePath(e.Path)
+}
+
+// Call 前端的一次接口调用
+type Call struct {
+	Endpoint
+	File string // 相对 web 目录
+	Line int
+}
+
+// Unmatched 前端调用了、后端没有提供的接口
+type Unmatched struct {
+	Call
+	Methods []string // 后端同一路径提供的其他方法（只是方法不一致时不为空）
+}
+
+// Result 对比结果
+type Result struct {
+	Swagger   string     // 使用的 swagger 文档（相对项目根目录）
+	Backend   []Endpoint // 后端提供的接口
+	Calls     []Call     // 前端的接口调用
+	Unmatched []Unmatched
+	Unused    []Endpoint // 后端提供了、前端没有调用的接口
+	Warnings  []string
+}
+
+// Check 读取项目 root 的 swagger 文档和前端接口定义并对比
+func Check(root string) (Result, error) {
+	var r Result
+	serverDir, webDir := filepath.Join(root, "server"), filepath.Join(root, "web")
+	var swaggerPath string
+	for _, name := range SwaggerFiles {
+		if path := filepath.Join(serverDir, filepath.FromSlash(name)); fileExists(path) {
+			swaggerPath, r.Swagger = path, "server/"+name
+			break
+		}
+	}
+	if swaggerPath == "" {
+		return r, apperr.Errorf(apperr.SwaggerNotFound, "没有找到 swagger 文档（server/%s），请先在 server 目录执行 swag init", SwaggerFiles[0])
+	}
+	data, err := os.ReadFile(swaggerPath)
+	if err != nil {
+		return r, apperr.Errorf(apperr.SwaggerNotFound, "读取 %s 失败: %v", r.Swagger, err)
+	}
+	if r.Backend, err = ParseSwagger(data); err != nil {
+		return r, apperr.Errorf(apperr.SwaggerNotFound, "解析 %s 失败: %v", r.Swagger, err)
+	}
+	if info, err := os.Stat(swaggerPath); err == nil {
+		if newer := newerGoSource(serverDir, info.ModTime()); newer != "" {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("后端源码 %s 在 swagger 文档生成之后有修改，请重新执行 swag init 后再对比", newer))
+		}
+	}
+
+	if r.Calls, err = FindCalls(webDir); err != nil {
+		return r, err
+	}
+	r.Unmatched, r.Unused = Compare(r.Backend, r.Calls)
+	return r, nil
+}
+
+// ParseSwagger 读取 swagger 2.0 / OpenAPI 文档（JSON 或 YAML）中的接口，按路径和方法排序。
+// GVA 的 @Router 路径不含 router-prefix，与前端请求的地址（axios 的 baseURL 之后的部分）一致，不拼接 basePath
+func ParseSwagger(data []byte) ([]Endpoint, error) {
+	var doc struct {
+		Paths map[string]map[string]any `json:"paths" yaml:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		if yamlErr := yaml.Unmarshal(data, &doc); yamlErr != nil {
+			return nil, yamlErr
+		}
+	}
+	var endpoints []Endpoint
+	for path, ops := range doc.Paths {
+		for _, method := range methods {
+			if _, ok := ops[method]; ok {
+				endpoints = append(endpoints, Endpoint{Method: strings.ToUpper(method), Path: path})
+			}
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+	return endpoints, nil
+}
+
+// FindCalls 读取 web 目录中 APIDirs 下的 .js / .ts 文件，找出其中的接口调用
+func FindCalls(webDir string) ([]Call, error) {
+	var calls []Call
+	for _, pattern := range APIDirs {
+		dirs, _ := filepath.Glob(filepath.Join(webDir, filepath.FromSlash(pattern)))
+		for _, dir := range dirs {
+			err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if ext := filepath.Ext(path); d.IsDir() || (ext != ".js" && ext != ".ts") {
+					return nil
+				}
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, _ := filepath.Rel(webDir, path)
+				calls = append(calls, ParseCalls(filepath.ToSlash(rel), string(data))...)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return calls, nil
+}
+
+// ParseCalls 找出一个前端接口文件中的调用：service({ url, method }) 和 service.post(url) 两种写法，
+// 没有写 method 时按 axios 的默认值 GET
+func ParseCalls(file, src string) []Call {
+	var calls []Call
+	lineOf := func(pos int) int { return strings.Count(src[:pos], "\n") + 1 }
+
+	urls := urlRe.FindAllStringSubmatchIndex(src, -1)
+	for i, m := range urls {
+		// 方法在同一个 service(...) 调用中查找：不越过前后的 url 和 service
+		start, end := 0, len(src)
+		if i > 0 {
+			start = urls[i-1][1]
+		}
+		if j := strings.LastIndex(src[start:m[0]], "service"); j >= 0 {
+			start += j
+		}
+		if i+1 < len(urls) {
+			end = urls[i+1][0]
+		}
+		if j := strings.Index(src[m[1]:end], "service"); j >= 0 {
+			end = m[1] + j
+		}
+		method := "GET"
+		if mm := methodRe.FindStringSubmatch(src[start:end]); mm != nil {
+			method = strings.ToUpper(mm[1])
+		}
+		calls = append(calls, Call{Endpoint: Endpoint{Method: method, Path: submatch(src, m, 1)}, File: file, Line: lineOf(m[0])})
+	}
+	for _, m := range shortRe.FindAllStringSubmatchIndex(src, -1) {
+		method := strings.ToUpper(src[m[2]:m[3]])
+		calls = append(calls, Call{Endpoint: Endpoint{Method: method, Path: submatch(src, m, 2)}, File: file, Line: lineOf(m[0])})
+	}
+	sort.SliceStable(calls, func(i, j int) bool { return calls[i].Line < calls[j].Line })
+	return calls
+}
+
+// submatch 从第 first 个分组起取第一个匹配到的分组（单引号、双引号或模板字符串）
+func submatch(src string, m []int, first int) string {
+	for g := first; 2*g+1 < len(m); g++ {
+		if m[2*g] >= 0 {
+			return src[m[2*g]:m[2*g+1]]
+		}
+	}
+	return ""
+}
+
+// Compare 对比后端接口和前端调用，返回前端调用了但后端没有的接口（同一接口多次调用只列出第一次）和前端没有调用的后端接口
+func Compare(backend []Endpoint, calls []Call) ([]Unmatched, []Endpoint) {
+	provided := map[string]bool{}
+	byPath := map[string][]string{}
+	for _, e := range backend {
+		provided[e.key()] = true
+		path := normalizePath(e.Path)
+		byPath[path] = append(byPath[path], e.Method)
+	}
+
+	var unmatched []Unmatched
+	called, reported := map[string]bool{}, map[string]bool{}
+	for _, c := range calls {
+		key := c.key()
+		called[key] = true
+		if provided[key] || reported[key] {
+			continue
+		}
+		reported[key] = true
+		unmatched = append(unmatched, Unmatched{Call: c, Methods: byPath[normalizePath(c.Path)]})
+	}
+	var unused []Endpoint
+	for _, e := range backend {
+		if !called[e.key()] {
+			unused = append(unused, e)
+		}
+	}
+	return unmatched, unused
+}
+
+// normalizePath 比较用的路径：去掉查询参数和末尾的 /，路径参数统一为 {}
+func normalizePath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	path = paramRe.ReplaceAllString(path, "$1{}")
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// newerGoSource 返回 dir 中第一个修改时间晚于 generated 的 Go 源码文件（相对路径，跳过 swag 生成的 docs 目录），没有时返回空字符串
+func newerGoSource(dir string, generated time.Time) string {
+	var newer string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || newer != "" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != dir && (name == "docs" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(generated) {
+			newer, _ = filepath.Rel(dir, path)
+			newer = filepath.ToSlash(newer)
+		}
+		return nil
+	})
+	return newer
+}
+
+// fileExists 文件是否存在
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// Report 文本报告（每类最多列出 limit 项，limit <= 0 时全部列出）
+func Report(r Result, limit int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "swagger 文档: %s（%d 个接口）\n前端调用: %d 处\n", r.Swagger, len(r.Backend), len(r.Calls))
+	for _, w := range r.Warnings {
+		fmt.Fprintf(&b, "\n⚠️ %s\n", w)
+	}
+	list := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s（%d 个）:\n", title, len(lines))
+		for i, line := range lines {
+			if limit > 0 && i == limit {
+				fmt.Fprintf(&b, "  ... 还有 %d 个\n", len(lines)-limit)
+				break
+			}
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	var unmatched []string
+	for _, u := range r.Unmatched {
+		line := fmt.Sprintf("%s（%s:%d）", u.Endpoint, u.File, u.Line)
+		if len(u.Methods) > 0 {
+			line += "，后端该路径的方法为 " + strings.Join(u.Methods, "、")
+		}
+		unmatched = append(unmatched, line)
+	}
+	list("前端调用了但后端没有提供的接口", unmatched)
+	var unused []string
+	for _, e := range r.Unused {
+		unused = append(unused, e.String())
+	}
+	list("后端提供了但前端没有调用的接口（可能只供其他客户端使用）", unused)
+	if len(r.Unmatched) == 0 {
+		b.WriteString("\n✅ 前端调用的接口后端都有提供\n")
+	}
+	return b.String()
+}
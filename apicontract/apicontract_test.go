@@ -0,0 +1,141 @@
+package apicontract
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+const sampleSwagger = `{
+  "basePath": "/",
+  "paths": {
+    "/api/createApi": {"post": {"summary": "创建基础api"}},
+    "/api/getApiList": {"post": {}},
+    "/user/getUserInfo": {"get": {}},
+    "/user/{id}": {"delete": {}, "parameters": []},
+    "/health": {"get": {}}
+  }
+}`
+
+const sampleAPI = `import service from '@/utils/request'
+
+// @Tags SysApi
+export const createApi = (data) => {
+  return service({
+    url: '/api/createApi',
+    method: 'post',
+    data
+  })
+}
+
+export const getApiList = (data) => {
+  return service({
+    url: "/api/getApiList",
+    method: 'get',
+    data
+  })
+}
+
+export const getUserInfo = () => {
+  return service({
+    url: '/user/getUserInfo'
+  })
+}
+
+export const deleteUser = (id) => service({ url: ` + "`/user/${id}`" + `, method: 'delete' })
+
+export const setSelfInfo = (data) => service.put('/user/setSelfInfo?x=1', data)
+`
+
+func TestParseCalls(t *testing.T) {
+	calls := ParseCalls("src/api/api.js", sampleAPI)
+	var got []string
+	for _, c := range calls {
+		got = append(got, c.String())
+	}
+	want := []string{"POST /api/createApi", "GET /api/getApiList", "GET /user/getUserInfo", "DELETE /user/${id}", "PUT /user/setSelfInfo?x=1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if calls[0].Line != 6 || calls[4].Line != 28 {
+		t.Errorf("lines = %d %d", calls[0].Line, calls[4].Line)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"server/docs/swagger.json":              sampleSwagger,
+		"server/main.go":                        "package main",
+		"web/src/api/api.js":                    sampleAPI,
+		"web/src/plugin/email/api/email.js":     "service({ url: '/email/sendEmail', method: 'post' })",
+		"web/src/view/not-an-api-definition.js": "service({ url: '/ignored' })",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(root, "server", "main.go"), old, old)
+
+	r, err := Check(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Swagger != "server/docs/swagger.json" || len(r.Backend) != 5 || len(r.Calls) != 6 || len(r.Warnings) != 0 {
+		t.Fatalf("result = %+v", r)
+	}
+	var unmatched []string
+	for _, u := range r.Unmatched {
+		unmatched = append(unmatched, u.String()+" "+strings.Join(u.Methods, ","))
+	}
+	want := []string{"GET /api/getApiList POST", "PUT /user/setSelfInfo?x=1 ", "POST /email/sendEmail "}
+	if !reflect.DeepEqual(unmatched, want) {
+		t.Errorf("unmatched = %q", unmatched)
+	}
+	if len(r.Unused) != 2 || r.Unused[0] != (Endpoint{"POST", "/api/getApiList"}) || r.Unused[1] != (Endpoint{"GET", "/health"}) {
+		t.Errorf("unused = %+v", r.Unused)
+	}
+	if report := Report(r, 0); !strings.Contains(report, "GET /api/getApiList（src/api/api.js:14），后端该路径的方法为 POST") {
+		t.Errorf("report:\n%s", report)
+	}
+
+	// 生成文档之后修改了后端源码
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(root, "server", "main.go"), future, future)
+	if r, _ := Check(root); len(r.Warnings) != 1 || !strings.Contains(r.Warnings[0], "main.go") {
+		t.Errorf("warnings = %v", r.Warnings)
+	}
+}
+
+func TestCheckWithoutSwagger(t *testing.T) {
+	if _, err := Check(t.TempDir()); apperr.CodeOf(err) != apperr.SwaggerNotFound {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestParseSwaggerYAML(t *testing.T) {
+	endpoints, err := ParseSwagger([]byte("paths:\n  /base/login:\n    post:\n      summary: 登录\n"))
+	if err != nil || len(endpoints) != 1 || endpoints[0] != (Endpoint{"POST", "/base/login"}) {
+		t.Errorf("endpoints = %+v, err = %v", endpoints, err)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	for path, want := range map[string]string{
+		"/user/{id}":        "/user/{}",
+		"/user/${row.ID}":   "/user/{}",
+		"/user/:id/roles":   "/user/{}/roles",
+		"user/list/?page=1": "/user/list",
+		"/":                 "/",
+	} {
+		if got := normalizePath(path); got != want {
+			t.Errorf("%s: got %s, want %s", path, got, want)
+		}
+	}
+}
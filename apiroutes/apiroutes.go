@@ -0,0 +1,90 @@
+// Package apiroutes 列出 GVA 后端的 API：读取数据库中的 sys_apis 表（后端初始化数据库时写入，
+// 包含方法、路径、分组和中文说明），并按关键字筛选、生成 curl 命令，方便新成员了解有哪些接口
+package apiroutes
+
+import (
+	"fmt"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/dbclient"
+	"gva-launcher/internal/sysutil"
+)
+
+// apisQuery 查询未删除的 API（按分组和路径排序）
+const apisQuery = "SELECT method, path, api_group, description FROM sys_apis WHERE deleted_at IS NULL ORDER BY api_group, path"
+
+// TokenHeader GVA 鉴权使用的请求头
+const TokenHeader = "x-token"
+
+// Route 一个后端 API
+type Route struct {
+	Method      string
+	Path        string
+	Group       string
+	Description string
+}
+
+// Load 从项目 root 的数据库读取 API 列表
+func Load(root string, cfg *config.GVAConfig) ([]Route, error) {
+	rows, err := dbclient.Query(root, cfg, apisQuery)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.DBQueryFailed, "读取 sys_apis 失败: %v", err)
+	}
+	var routes []Route
+	for _, row := range rows {
+		if len(row) < 4 {
+			continue
+		}
+		routes = append(routes, Route{
+			Method:      strings.ToUpper(row[0]),
+			Path:        row[1],
+			Group:       row[2],
+			Description: row[3],
+		})
+	}
+	return routes, nil
+}
+
+// Match 方法、路径、分组或说明是否包含 keyword（不区分大小写，多个关键字以空格分隔时需全部包含）
+func (r Route) Match(keyword string) bool {
+	text := strings.ToLower(r.Method + " " + r.Path + " " + r.Group + " " + r.Description)
+	for _, word := range strings.Fields(strings.ToLower(keyword)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter 筛选出匹配 keyword 的 API（keyword 为空时返回全部）
+func Filter(routes []Route, keyword string) []Route {
+	var result []Route
+	for _, r := range routes {
+		if r.Match(keyword) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// BaseURL 后端 API 的根地址：http://127.0.0.1:端口 加上 config.yaml 的 router-prefix
+func BaseURL(port int, routerPrefix string) string {
+	base := fmt.Sprintf("http://127.0.0.1:%d", port)
+	if prefix := strings.Trim(routerPrefix, "/"); prefix != "" {
+		base += "/" + prefix
+	}
+	return base
+}
+
+// Curl 调用该 API 的 curl 命令：GET 以外的方法发送空的 JSON 请求体；
+// 需要登录的接口把 <token> 换成登录后得到的 token
+func (r Route) Curl(baseURL string) string {
+	target := strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(r.Path, "/")
+	parts := []string{"curl", "-X", r.Method, sysutil.ShellQuote(target)}
+	if r.Method != "GET" {
+		parts = append(parts, "-H", sysutil.ShellQuote("Content-Type: application/json"), "-d", sysutil.ShellQuote("{}"))
+	}
+	return strings.Join(append(parts, "-H", sysutil.ShellQuote(TokenHeader+": <token>")), " ")
+}
@@ -0,0 +1,66 @@
+package apiroutes
+
+import (
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestLoadMysql(t *testing.T) {
+	fake := sysutiltest.New(t)
+	cfg := &config.GVAConfig{}
+	cfg.Mysql = config.GVADBConfig{Path: "127.0.0.1", Port: "3306", Dbname: "gva", Username: "root", Password: "pwd"}
+	fake.Handle("mysql -h 127.0.0.1 -P 3306 -u root --batch --skip-column-names -e "+apisQuery+" gva",
+		"post\t/api/createApi\tapi\t创建api\r\nGET\t/user/getUserInfo\tsystem\t获取自身信息\n\nbroken\n", nil)
+
+	routes, err := Load("/work/gva", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 2 || routes[0] != (Route{"POST", "/api/createApi", "api", "创建api"}) || routes[1].Description != "获取自身信息" {
+		t.Errorf("routes = %+v", routes)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || len(calls[0].Env) != 1 || calls[0].Env[0] != "MYSQL_PWD=pwd" {
+		t.Errorf("密码应通过环境变量传递: %+v", calls)
+	}
+
+	cfg.System.DbType = "mssql"
+	if _, err := Load("/work/gva", cfg); apperr.CodeOf(err) != apperr.DBQueryFailed {
+		t.Errorf("不支持的数据库 err = %v", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	routes := []Route{
+		{"POST", "/api/createApi", "api", "创建api"},
+		{"GET", "/user/getUserInfo", "system", "获取自身信息"},
+		{"DELETE", "/user/deleteUser", "system", "删除用户"},
+	}
+	if got := Filter(routes, "user 删除"); len(got) != 1 || got[0].Path != "/user/deleteUser" {
+		t.Errorf("got %+v", got)
+	}
+	if got := Filter(routes, "delete"); len(got) != 1 {
+		t.Errorf("方法也参与匹配: %+v", got)
+	}
+	if got := Filter(routes, " "); len(got) != 3 {
+		t.Errorf("空关键字返回全部: %+v", got)
+	}
+}
+
+func TestCurl(t *testing.T) {
+	base := BaseURL(8888, "/v1/")
+	if base != "http://127.0.0.1:8888/v1" {
+		t.Fatalf("BaseURL = %s", base)
+	}
+	get := Route{Method: "GET", Path: "/user/getUserInfo"}.Curl(base)
+	if get != "curl -X GET 'http://127.0.0.1:8888/v1/user/getUserInfo' -H 'x-token: <token>'" {
+		t.Errorf("GET = %s", get)
+	}
+	post := Route{Method: "POST", Path: "/api/createApi"}.Curl(BaseURL(8888, ""))
+	if !strings.Contains(post, "'http://127.0.0.1:8888/api/createApi'") || !strings.Contains(post, "-d '{}'") {
+		t.Errorf("POST = %s", post)
+	}
+}
@@ -0,0 +1,229 @@
+// Package apperr 定义面板面向用户的错误码
+// 错误文本仍保持原有的中文描述，错误码用于界面显示本地化标题、链接排查说明，
+// 以及供命令行 / REST 接口返回结构化错误
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Code 机器可读的错误码（大写下划线格式，发布后不再修改）
+type Code string
+
+// 错误码列表（前缀表示所属模块）
+const (
+	Unknown Code = "UNKNOWN"
+
+	ProjectNotSet Code = "PROJECT_NOT_SET"
+	ProjectLocked Code = "PROJECT_LOCKED"
+
+	CfgReadFailed  Code = "CFG_READ_FAILED"
+	CfgYAMLParse   Code = "CFG_YAML_PARSE"
+	CfgWriteFailed Code = "CFG_WRITE_FAILED"
+
+	DepNpmInstallFailed Code = "DEP_NPM_INSTALL_FAILED"
+	DepGoModFailed      Code = "DEP_GO_MOD_FAILED"
+	DepMirrorFailed     Code = "DEP_MIRROR_FAILED"
+	DepCleanFailed      Code = "DEP_CLEAN_FAILED"
+	DepIntegrityFailed  Code = "DEP_INTEGRITY_FAILED"
+	TrashRestoreFailed  Code = "TRASH_RESTORE_FAILED"
+
+	PortInUse       Code = "PORT_IN_USE"
+	PortAllocFailed Code = "PORT_ALLOC_FAILED"
+
+	HTTPSCertFailed  Code = "HTTPS_CERT_FAILED"
+	HTTPSTrustFailed Code = "HTTPS_TRUST_FAILED"
+	HTTPSViteConfig  Code = "HTTPS_VITE_CONFIG"
+
+	HostsUpdateFailed Code = "HOSTS_UPDATE_FAILED"
+	CertInvalid       Code = "CERT_INVALID"
+	SSHKeyFailed      Code = "SSH_KEY_FAILED"
+
+	ToolMissing Code = "TOOL_MISSING"
+
+	SvcDirNotFound      Code = "SVC_DIR_NOT_FOUND"
+	SvcStartFailed      Code = "SVC_START_FAILED"
+	BuildFailed         Code = "BUILD_FAILED"
+	BuildEnvInvalid     Code = "BUILD_ENV_INVALID"
+	CDNUploadFailed     Code = "CDN_UPLOAD_FAILED"
+	DeployFailed        Code = "DEPLOY_FAILED"
+	DeployUnhealthy     Code = "DEPLOY_UNHEALTHY"
+	ImagePushFailed     Code = "IMAGE_PUSH_FAILED"
+	StaticRoutesMissing Code = "STATIC_ROUTES_MISSING"
+	ComposeFailed       Code = "COMPOSE_FAILED"
+	LicenseScanFailed   Code = "LICENSE_SCAN_FAILED"
+	SysServiceFailed    Code = "SYS_SERVICE_FAILED"
+	SBOMFailed          Code = "SBOM_FAILED"
+	SecretsFound        Code = "SECRETS_FOUND"
+	SwaggerNotFound     Code = "SWAGGER_NOT_FOUND"
+	MailSendFailed      Code = "MAIL_SEND_FAILED"
+	BackupFailed        Code = "BACKUP_FAILED"
+	DBSnapshotFailed    Code = "DB_SNAPSHOT_FAILED"
+	DBQueryFailed       Code = "DB_QUERY_FAILED"
+	RedisConnect        Code = "REDIS_CONNECT_FAILED"
+	RedisAuthFailed     Code = "REDIS_AUTH_FAILED"
+
+	UpdateCheckFailed    Code = "UPDATE_CHECK_FAILED"
+	UpdateNoAsset        Code = "UPDATE_NO_ASSET"
+	UpdateDownloadFailed Code = "UPDATE_DOWNLOAD_FAILED"
+	UpdateChecksum       Code = "UPDATE_CHECKSUM_MISMATCH"
+	UpdateReplaceFailed  Code = "UPDATE_REPLACE_FAILED"
+)
+
+// HelpBaseURL 排查说明页面（docs/troubleshooting.md），锚点为小写错误码
+const HelpBaseURL = "https://github.com/XiaoafengClub/GVAPanel/blob/main/docs/troubleshooting.md"
+
+// Error 带错误码的错误
+type Error struct {
+	Code Code
+	Err  error // 原始错误（Error() 返回它的文本）
+}
+
+// Errorf 创建带错误码的错误，格式与 fmt.Errorf 相同（支持 %w）
+func Errorf(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Error 返回原始的错误描述
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap 支持 errors.Is / errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON 以结构化形式输出（使用默认语言）
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Describe(e, DefaultLang()))
+}
+
+// CodeOf 取出错误链中第一个错误码，没有错误码时返回 Unknown
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return Unknown
+}
+
+// HelpURL 错误码对应的排查说明地址（Unknown 没有说明页）
+func HelpURL(code Code) string {
+	if code == Unknown || code == "" {
+		return ""
+	}
+	return HelpBaseURL + "#" + strings.ToLower(string(code))
+}
+
+// Detail 结构化的错误信息（界面显示和接口返回共用）
+type Detail struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`            // 本地化的简短说明
+	Detail  string `json:"detail"`             // 原始错误描述
+	HelpURL string `json:"help_url,omitempty"` // 排查说明地址
+}
+
+// Describe 把任意错误转换为结构化信息
+func Describe(err error, lang Lang) Detail {
+	code := CodeOf(err)
+	return Detail{
+		Code:    code,
+		Message: Message(code, lang),
+		Detail:  err.Error(),
+		HelpURL: HelpURL(code),
+	}
+}
+
+// ========================================
+// 本地化
+// ========================================
+
+// Lang 界面语言
+type Lang string
+
+// 支持的语言
+const (
+	LangZH Lang = "zh"
+	LangEN Lang = "en"
+)
+
+// DefaultLang 根据 GVA_LANG / LANG 环境变量选择语言，默认中文
+func DefaultLang() Lang {
+	for _, key := range []string{"GVA_LANG", "LANG"} {
+		if v := strings.ToLower(os.Getenv(key)); v != "" {
+			if strings.HasPrefix(v, "en") {
+				return LangEN
+			}
+			return LangZH
+		}
+	}
+	return LangZH
+}
+
+// messages 各错误码的本地化说明
+var messages = map[Code]map[Lang]string{
+	Unknown:              {LangZH: "操作失败", LangEN: "Operation failed"},
+	ProjectNotSet:        {LangZH: "未指定 GVA 根目录", LangEN: "GVA root directory is not set"},
+	ProjectLocked:        {LangZH: "项目正在被其他用户使用", LangEN: "Project is in use by another user"},
+	CfgReadFailed:        {LangZH: "读取配置文件失败", LangEN: "Failed to read configuration file"},
+	CfgYAMLParse:         {LangZH: "config.yaml 格式错误", LangEN: "config.yaml is malformed"},
+	CfgWriteFailed:       {LangZH: "写入配置文件失败", LangEN: "Failed to write configuration file"},
+	DepNpmInstallFailed:  {LangZH: "前端依赖安装失败", LangEN: "npm install failed"},
+	DepGoModFailed:       {LangZH: "后端依赖下载失败", LangEN: "go mod download failed"},
+	DepMirrorFailed:      {LangZH: "设置镜像源失败", LangEN: "Failed to set package mirror"},
+	DepCleanFailed:       {LangZH: "清理缓存失败", LangEN: "Failed to clean cache"},
+	DepIntegrityFailed:   {LangZH: "依赖完整性校验失败", LangEN: "Dependency integrity check failed"},
+	TrashRestoreFailed:   {LangZH: "从回收站恢复失败", LangEN: "Failed to restore from trash"},
+	PortInUse:            {LangZH: "端口已被占用", LangEN: "Port is already in use"},
+	PortAllocFailed:      {LangZH: "没有可分配的空闲端口", LangEN: "No free port available in the range"},
+	HTTPSCertFailed:      {LangZH: "生成 HTTPS 证书失败", LangEN: "Failed to generate HTTPS certificate"},
+	HTTPSTrustFailed:     {LangZH: "根证书加入系统信任失败", LangEN: "Failed to trust the local root certificate"},
+	HTTPSViteConfig:      {LangZH: "无法修改 vite 配置", LangEN: "Cannot update the Vite configuration"},
+	HostsUpdateFailed:    {LangZH: "修改 hosts 文件失败", LangEN: "Failed to update the hosts file"},
+	CertInvalid:          {LangZH: "证书或私钥无效", LangEN: "Invalid certificate or private key"},
+	SSHKeyFailed:         {LangZH: "SSH 密钥操作失败", LangEN: "SSH key operation failed"},
+	ToolMissing:          {LangZH: "未检测到 go 或 npm", LangEN: "go or npm was not found"},
+	SvcDirNotFound:       {LangZH: "服务目录不存在", LangEN: "Service directory not found"},
+	SvcStartFailed:       {LangZH: "服务启动失败", LangEN: "Failed to start service"},
+	BuildFailed:          {LangZH: "项目构建失败", LangEN: "Build failed"},
+	BuildEnvInvalid:      {LangZH: "前端生产环境的接口地址有误", LangEN: "Invalid production API address for the frontend"},
+	CDNUploadFailed:      {LangZH: "上传静态资源失败", LangEN: "Failed to upload static assets"},
+	DeployFailed:         {LangZH: "远程部署失败", LangEN: "Remote deployment failed"},
+	DeployUnhealthy:      {LangZH: "部署后健康检查没有通过", LangEN: "Deployment failed its health check"},
+	ImagePushFailed:      {LangZH: "构建或推送镜像失败", LangEN: "Failed to build or push the image"},
+	StaticRoutesMissing:  {LangZH: "后端没有可启用的静态页面路由", LangEN: "Backend has no static page routes to enable"},
+	ComposeFailed:        {LangZH: "本地依赖容器操作失败", LangEN: "Failed to manage local dependency containers"},
+	LicenseScanFailed:    {LangZH: "扫描依赖许可证失败", LangEN: "Failed to scan dependency licenses"},
+	SysServiceFailed:     {LangZH: "注册系统服务失败", LangEN: "Failed to install the system service"},
+	SBOMFailed:           {LangZH: "生成软件物料清单失败", LangEN: "Failed to generate the SBOM"},
+	SecretsFound:         {LangZH: "发布内容中有疑似密钥", LangEN: "Possible secrets found in release artifacts"},
+	SwaggerNotFound:      {LangZH: "没有可用的 swagger 文档", LangEN: "No usable swagger document"},
+	MailSendFailed:       {LangZH: "发送邮件失败", LangEN: "Failed to send the email"},
+	BackupFailed:         {LangZH: "配置备份失败", LangEN: "Backup failed"},
+	DBSnapshotFailed:     {LangZH: "数据库快照操作失败", LangEN: "Database snapshot failed"},
+	DBQueryFailed:        {LangZH: "查询数据库失败", LangEN: "Database query failed"},
+	RedisConnect:         {LangZH: "无法连接 Redis", LangEN: "Cannot connect to Redis"},
+	RedisAuthFailed:      {LangZH: "Redis 认证失败", LangEN: "Redis authentication failed"},
+	UpdateCheckFailed:    {LangZH: "检查更新失败", LangEN: "Failed to check for updates"},
+	UpdateNoAsset:        {LangZH: "没有适用于当前系统的安装包", LangEN: "No package for this platform"},
+	UpdateDownloadFailed: {LangZH: "下载更新失败", LangEN: "Failed to download update"},
+	UpdateChecksum:       {LangZH: "更新包校验失败", LangEN: "Update checksum mismatch"},
+	UpdateReplaceFailed:  {LangZH: "替换程序失败", LangEN: "Failed to replace executable"},
+}
+
+// Message 错误码的本地化说明（缺少对应语言时回退到中文）
+func Message(code Code, lang Lang) string {
+	m, ok := messages[code]
+	if !ok {
+		m = messages[Unknown]
+	}
+	if text, ok := m[lang]; ok {
+		return text
+	}
+	return m[LangZH]
+}
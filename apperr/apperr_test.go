@@ -0,0 +1,60 @@
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	err := Errorf(DepNpmInstallFailed, "npm install 失败: %v", errors.New("exit status 1"))
+	if err.Error() != "npm install 失败: exit status 1" {
+		t.Errorf("Error() 应保持原始描述, got %q", err.Error())
+	}
+
+	wrapped := fmt.Errorf("安装依赖: %w", err)
+	if got := CodeOf(wrapped); got != DepNpmInstallFailed {
+		t.Errorf("CodeOf(wrapped) = %s", got)
+	}
+	if got := CodeOf(errors.New("其他错误")); got != Unknown {
+		t.Errorf("普通错误应返回 Unknown, got %s", got)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	d := Describe(Errorf(CfgYAMLParse, "解析配置文件失败: %v", "yaml: line 3"), LangEN)
+	if d.Code != CfgYAMLParse || d.Message != "config.yaml is malformed" {
+		t.Errorf("Describe = %+v", d)
+	}
+	if !strings.HasSuffix(d.HelpURL, "#cfg_yaml_parse") {
+		t.Errorf("HelpURL = %s", d.HelpURL)
+	}
+	if Describe(errors.New("x"), LangZH).HelpURL != "" {
+		t.Error("Unknown 不应有排查说明地址")
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	t.Setenv("GVA_LANG", "zh")
+	data, err := json.Marshal(Errorf(PortInUse, "端口 %d 已被占用", 8888))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d Detail
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Code != PortInUse || d.Message != "端口已被占用" || d.Detail != "端口 8888 已被占用" {
+		t.Errorf("JSON = %s", data)
+	}
+}
+
+func TestMessagesComplete(t *testing.T) {
+	for code, m := range messages {
+		if m[LangZH] == "" || m[LangEN] == "" {
+			t.Errorf("%s 缺少中文或英文说明", code)
+		}
+	}
+}
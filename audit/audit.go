@@ -0,0 +1,212 @@
+// Package audit 记录通过面板修改配置的审计日志：谁（用户@主机）、什么时间、哪个文件的哪个键、从什么值改为什么值。
+// 日志为只追加的 JSON Lines 文件，与配置备份（整个文件的副本）分开保存；密码、令牌等敏感值只记录是否修改
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Redacted 敏感值在日志中的替代文字
+const Redacted = "******"
+
+// sensitiveWords 键名（不区分大小写）包含这些词时不记录具体值
+var sensitiveWords = []string{"password", "token", "secret", "signing-key", "access-key", "passphrase"}
+
+// Entry 一条配置修改记录
+type Entry struct {
+	Time time.Time `json:"time"`
+	User string    `json:"user"` // 用户@主机
+	File string    `json:"file"` // 配置文件，例如 server/config.yaml
+	Key  string    `json:"key"`  // 以点分隔的键路径，例如 system.addr
+	Old  string    `json:"old"`  // 修改前的值（新增时为空）
+	New  string    `json:"new"`  // 修改后的值（删除时为空）
+}
+
+// Change 一个键的值变化
+type Change struct {
+	Key      string
+	Old, New string
+}
+
+// Actor 当前用户描述，例如 alice@devbox
+func Actor() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
+
+// Sensitive 键是否为敏感配置（只记录是否修改，不记录值）
+func Sensitive(key string) bool {
+	lower := strings.ToLower(key)
+	for _, word := range sensitiveWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff 比较两份配置（解析后的 map，嵌套 map 展开为点分隔的键），按键名排序返回变化的键
+func Diff(old, new map[string]interface{}) []Change {
+	before := map[string]string{}
+	after := map[string]string{}
+	flatten("", old, before)
+	flatten("", new, after)
+
+	var changes []Change
+	for key, value := range after {
+		if before[key] != value {
+			changes = append(changes, Change{Key: key, Old: before[key], New: value})
+		}
+	}
+	for key, value := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, Change{Key: key, Old: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// flatten 展开嵌套 map，叶子值（包括列表）格式化为字符串
+func flatten(prefix string, value interface{}, out map[string]string) {
+	if m, ok := value.(map[string]interface{}); ok {
+		for key, child := range m {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, child, out)
+		}
+		return
+	}
+	if prefix != "" {
+		out[prefix] = format(value)
+	}
+}
+
+// format 配置值的文字形式（字符串原样输出，其他值使用 JSON）
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// Record 把 file 中的变化以当前用户追加到 path（没有变化时不写入），敏感值替换为 Redacted
+func Record(path, file string, changes []Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	now := time.Now()
+	actor := Actor()
+	entries := make([]Entry, 0, len(changes))
+	for _, c := range changes {
+		if Sensitive(c.Key) {
+			c.Old, c.New = redact(c.Old), redact(c.New)
+		}
+		entries = append(entries, Entry{Time: now, User: actor, File: file, Key: c.Key, Old: c.Old, New: c.New})
+	}
+	return Append(path, entries...)
+}
+
+// redact 非空的敏感值替换为 Redacted
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// Append 以追加方式写入记录（每条一行 JSON）
+func Append(path string, entries ...Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	var buf strings.Builder
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	// 一次写入，多个面板同时追加时记录不会交错
+	if _, err := file.WriteString(buf.String()); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Read 读取日志中的全部记录（文件不存在时返回空列表，跳过无法解析的行）
+func Read(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Merge 合并多个日志的记录，按时间从新到旧排序
+func Merge(lists ...[]Entry) []Entry {
+	var all []Entry
+	for _, list := range lists {
+		all = append(all, list...)
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Time.After(all[j].Time) })
+	return all
+}
+
+// Match 记录是否包含关键字（匹配用户、文件、键和值，不区分大小写）
+func (e Entry) Match(keyword string) bool {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if keyword == "" {
+		return true
+	}
+	for _, field := range []string{e.User, e.File, e.Key, e.Old, e.New} {
+		if strings.Contains(strings.ToLower(field), keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	old := map[string]interface{}{
+		"system": map[string]interface{}{"addr": 8888, "db-type": "mysql"},
+		"hosts":  []interface{}{"a"},
+		"gone":   true,
+	}
+	new := map[string]interface{}{
+		"system": map[string]interface{}{"addr": 8001, "db-type": "mysql", "use-redis": false},
+		"hosts":  []interface{}{"a", "b"},
+	}
+	got := Diff(old, new)
+	want := []Change{
+		{Key: "gone", Old: "true"},
+		{Key: "hosts", Old: `["a"]`, New: `["a","b"]`},
+		{Key: "system.addr", Old: "8888", New: "8001"},
+		{Key: "system.use-redis", New: "false"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRecordRedactsAndAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	if err := Record(path, "server/config.yaml", []Change{{Key: "mysql.password", Old: "old", New: "new"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(path, "web/.env.development", []Change{{Key: "VITE_CLI_PORT", Old: "8080", New: "8081"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(path, "gva-launcher.json", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), `"old"`+":"+`"old"`) || strings.Count(string(data), "\n") != 2 {
+		t.Fatalf("log = %s", data)
+	}
+	entries, err := Read(path)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("entries = %+v, %v", entries, err)
+	}
+	if e := entries[0]; e.Old != Redacted || e.New != Redacted || e.User == "" {
+		t.Errorf("敏感值应被替换: %+v", e)
+	}
+	if e := entries[1]; e.File != "web/.env.development" || e.Old != "8080" || e.New != "8081" {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestReadMissingAndMerge(t *testing.T) {
+	entries, err := Read(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || entries != nil {
+		t.Fatalf("entries = %v, err = %v", entries, err)
+	}
+
+	now := time.Now()
+	merged := Merge(
+		[]Entry{{Time: now.Add(-2 * time.Hour), Key: "a"}, {Time: now, Key: "c"}},
+		[]Entry{{Time: now.Add(-time.Hour), Key: "b", User: "bob@box"}},
+	)
+	if merged[0].Key != "c" || merged[1].Key != "b" || merged[2].Key != "a" {
+		t.Errorf("merged = %+v", merged)
+	}
+	if !merged[1].Match("BOB") || merged[0].Match("bob") || !merged[0].Match(" ") {
+		t.Error("Match 结果不正确")
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	for _, key := range []string{"mysql.password", "proxy.token", "jwt.signing-key", "Redis.Password"} {
+		if !Sensitive(key) {
+			t.Errorf("%s 应为敏感配置", key)
+		}
+	}
+	for _, key := range []string{"system.addr", "remote_log.key", "VITE_CLI_PORT"} {
+		if Sensitive(key) {
+			t.Errorf("%s 不应为敏感配置", key)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package autostart 管理登录时自动启动面板的系统入口：
+//   - Windows: 启动文件夹中的 GVAPanel.vbs（隐藏窗口运行）
+//   - macOS:   ~/Library/LaunchAgents/com.xiaoafengclub.gvapanel.plist（launchd）
+//   - Linux:   $XDG_CONFIG_HOME/autostart/gvapanel.desktop（XDG autostart）
+//
+// 入口文件只在当前用户目录下创建，不需要管理员权限
+package autostart
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// Label launchd 任务名
+const Label = "com.xiaoafengclub.gvapanel"
+
+// Path 当前系统的自启动入口文件路径
+func Path() (string, error) {
+	return pathFor(runtime.GOOS)
+}
+
+// pathFor 指定系统的自启动入口文件路径
+func pathFor(goos string) (string, error) {
+	switch goos {
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return "", fmt.Errorf("无法获取 APPDATA 目录")
+		}
+		return filepath.Join(appData, "Microsoft", "Windows", "Start Menu", "Programs", "Startup", "GVAPanel.vbs"), nil
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("无法获取用户目录: %v", err)
+		}
+		return filepath.Join(home, "Library", "LaunchAgents", Label+".plist"), nil
+	default:
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("无法获取用户配置目录: %v", err)
+		}
+		return filepath.Join(configDir, "autostart", "gvapanel.desktop"), nil
+	}
+}
+
+// Enabled 是否已注册登录自启动
+func Enabled() bool {
+	path, err := Path()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Enable 注册登录时运行 exe args...（已存在时覆盖，保证程序路径是最新的）
+func Enable(exe string, args ...string) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建自启动目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(entry(runtime.GOOS, exe, args)), 0644); err != nil {
+		return fmt.Errorf("写入自启动入口失败: %v", err)
+	}
+	return nil
+}
+
+// Disable 取消登录自启动（入口不存在时视为成功）
+func Disable() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除自启动入口失败: %v", err)
+	}
+	return nil
+}
+
+// entry 生成指定系统的自启动入口文件内容
+func entry(goos, exe string, args []string) string {
+	switch goos {
+	case "windows":
+		return windowsScript(exe, args)
+	case "darwin":
+		return launchAgent(exe, args)
+	default:
+		return desktopEntry(exe, args)
+	}
+}
+
+// windowsScript 启动文件夹中的 VBScript：以隐藏窗口运行命令，不等待结束
+func windowsScript(exe string, args []string) string {
+	parts := []string{`"` + exe + `"`}
+	for _, arg := range args {
+		parts = append(parts, `"`+arg+`"`)
+	}
+	// VBScript 字符串中的双引号需要写成两个
+	command := strings.ReplaceAll(strings.Join(parts, " "), `"`, `""`)
+	return "' GVAPanel 登录自启动（由面板生成，可在面板中关闭）\r\n" +
+		`CreateObject("WScript.Shell").Run "` + command + `", 0, False` + "\r\n"
+}
+
+// launchAgent launchd 的 LaunchAgent 配置：登录时运行一次
+func launchAgent(exe string, args []string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	b.WriteString("\t<key>Label</key>\n\t<string>" + Label + "</string>\n")
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append([]string{exe}, args...) {
+		b.WriteString("\t\t<string>" + sysutil.XMLEscape(arg) + "</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+// desktopEntry XDG autostart 的 .desktop 文件
+func desktopEntry(exe string, args []string) string {
+	var exec []string
+	for _, arg := range append([]string{exe}, args...) {
+		exec = append(exec, desktopQuote(arg))
+	}
+	return "[Desktop Entry]\n" +
+		"Type=Application\n" +
+		"Name=GVAPanel\n" +
+		"Comment=GVAPanel 看守模式（由面板生成，可在面板中关闭）\n" +
+		"Exec=" + strings.Join(exec, " ") + "\n" +
+		"Terminal=false\n" +
+		"X-GNOME-Autostart-enabled=true\n"
+}
+
+// desktopQuote 按 Desktop Entry 规范给 Exec 参数加引号（引号内的 " ` $ \ 需要转义）；
+// % 在 Exec 中表示 %f、%u 等字段代码，路径中的 % 写成 %%
+func desktopQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if s != "" && !strings.ContainsAny(s, " \t\"'`$\\<>|&;()*?#") {
+		return s
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", "$", `\$`).Replace(s)
+	return `"` + escaped + `"`
+}
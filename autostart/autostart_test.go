@@ -0,0 +1,84 @@
+package autostart
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWindowsScriptQuotesArguments(t *testing.T) {
+	got := windowsScript(`C:\Program Files\GVAPanel\GVAPanel.exe`, []string{"--watchdog"})
+	want := `CreateObject("WScript.Shell").Run """C:\Program Files\GVAPanel\GVAPanel.exe"" ""--watchdog""", 0, False`
+	if !strings.Contains(got, want) {
+		t.Fatalf("脚本内容不正确:\n%s", got)
+	}
+}
+
+func TestLaunchAgentEscapesArguments(t *testing.T) {
+	got := launchAgent("/Applications/A&B.app/Contents/MacOS/GVAPanel", []string{"--watchdog"})
+	for _, want := range []string{
+		"<string>" + Label + "</string>",
+		"<string>/Applications/A&amp;B.app/Contents/MacOS/GVAPanel</string>",
+		"<string>--watchdog</string>",
+		"<key>RunAtLoad</key>\n\t<true/>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("plist 缺少 %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDesktopEntryQuotesExec(t *testing.T) {
+	got := desktopEntry("/opt/GVA Panel/gvapanel", []string{"--watchdog", "--portable"})
+	want := `Exec="/opt/GVA Panel/gvapanel" --watchdog --portable`
+	if !strings.Contains(got, want+"\n") {
+		t.Fatalf("Exec 行不正确:\n%s", got)
+	}
+	if q := desktopQuote(`a"$b`); q != `"a\"\$b"` {
+		t.Errorf("desktopQuote = %s", q)
+	}
+	if q := desktopQuote("/opt/100%/gvapanel"); q != "/opt/100%%/gvapanel" {
+		t.Errorf("desktopQuote = %s", q)
+	}
+	if q := desktopQuote("/home/me/50% off/gvapanel"); q != `"/home/me/50%% off/gvapanel"` {
+		t.Errorf("desktopQuote = %s", q)
+	}
+}
+
+func TestEnableDisable(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("只在 Linux 上验证 XDG autostart 目录")
+	}
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if Enabled() {
+		t.Fatal("初始状态不应启用")
+	}
+	if err := Enable("/usr/local/bin/gvapanel", "--watchdog"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "autostart", "gvapanel.desktop")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Exec=/usr/local/bin/gvapanel --watchdog") {
+		t.Errorf("入口内容不正确:\n%s", data)
+	}
+	if !Enabled() {
+		t.Error("Enable 后应为已启用")
+	}
+
+	if err := Disable(); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled() {
+		t.Error("Disable 后应为未启用")
+	}
+	if err := Disable(); err != nil {
+		t.Errorf("重复 Disable 不应报错: %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+// Package cdnupload 把前端构建产物（web/dist）上传到对象存储，供 CDN 分发静态资源。
+// 凭据复用项目 config.yaml 中后端的存储配置（aws-s3、aliyun-oss、tencent-cos、minio），
+// 统一通过 S3 兼容接口（AWS 签名 V4）上传，不依赖各家的 SDK
+package cdnupload
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+)
+
+// Storages 支持的存储（与 config.yaml 中的配置段同名）
+var Storages = []string{"aws-s3", "aliyun-oss", "tencent-cos", "minio"}
+
+// StorageLabel 存储的中文名称
+func StorageLabel(storage string) string {
+	switch storage {
+	case "aws-s3":
+		return "AWS S3"
+	case "aliyun-oss":
+		return "阿里云 OSS"
+	case "tencent-cos":
+		return "腾讯云 COS"
+	case "minio":
+		return "MinIO"
+	default:
+		return storage
+	}
+}
+
+// DefaultPrefix 存储中的默认路径前缀
+const DefaultPrefix = "gva-web"
+
+// Target 上传目标
+type Target struct {
+	Storage   string
+	Endpoint  string // 接口地址（含协议），例如 https://s3.ap-shanghai.amazonaws.com
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool   // 路径形式（endpoint/bucket/key），否则为虚拟主机形式（bucket.endpoint/key）
+	PublicURL string // 存储或 CDN 的访问地址（为空时使用接口地址）
+}
+
+// TargetFrom 从 config.yaml 的存储配置生成上传目标；配置缺失或仍是上游示例值时返回 CDN_UPLOAD_FAILED 错误
+func TargetFrom(cfg *config.GVAConfig, storage string) (Target, error) {
+	var t Target
+	switch storage {
+	case "aws-s3":
+		s := cfg.AwsS3
+		endpoint := s.Endpoint
+		if endpoint == "" {
+			endpoint = "s3." + s.Region + ".amazonaws.com"
+		}
+		t = Target{Endpoint: withScheme(endpoint, !s.DisableSSL), Region: s.Region, Bucket: s.Bucket,
+			AccessKey: s.SecretID, SecretKey: s.SecretKey, PathStyle: s.ForcePathStyle, PublicURL: s.BaseURL}
+	case "aliyun-oss":
+		s := cfg.AliyunOSS
+		endpoint := withScheme(s.Endpoint, true)
+		// S3 兼容接口的地域为 endpoint 的第一段，例如 oss-cn-hangzhou.aliyuncs.com 的 oss-cn-hangzhou
+		region, _, _ := strings.Cut(hostOf(endpoint), ".")
+		t = Target{Endpoint: endpoint, Region: region, Bucket: s.BucketName,
+			AccessKey: s.AccessKeyID, SecretKey: s.AccessKeySecret, PublicURL: s.BucketURL}
+	case "tencent-cos":
+		s := cfg.TencentCOS
+		t = Target{Endpoint: "https://cos." + s.Region + ".myqcloud.com", Region: s.Region, Bucket: s.Bucket,
+			AccessKey: s.SecretID, SecretKey: s.SecretKey, PublicURL: s.BaseURL}
+	case "minio":
+		s := cfg.Minio
+		t = Target{Endpoint: withScheme(s.Endpoint, s.UseSSL), Region: "us-east-1", Bucket: s.BucketName,
+			AccessKey: s.AccessKeyID, SecretKey: s.AccessKeySecret, PathStyle: true, PublicURL: s.BucketURL}
+	default:
+		return t, apperr.Errorf(apperr.CDNUploadFailed, "不支持的存储: %s", storage)
+	}
+	t.Storage = storage
+
+	for _, f := range []struct{ name, value string }{
+		{"bucket", t.Bucket}, {"密钥", t.AccessKey}, {"密钥", t.SecretKey}, {"地域", t.Region}, {"地址", hostOf(t.Endpoint)},
+	} {
+		if f.value == "" || strings.HasPrefix(strings.ToLower(f.value), "your") {
+			return t, apperr.Errorf(apperr.CDNUploadFailed, "config.yaml 中 %s 的%s未填写（或仍是示例值）", storage, f.name)
+		}
+	}
+	return t, nil
+}
+
+// withScheme 补全地址的协议
+func withScheme(endpoint string, https bool) string {
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	if endpoint == "" || strings.Contains(endpoint, "://") {
+		return endpoint
+	}
+	if https {
+		return "https://" + endpoint
+	}
+	return "http://" + endpoint
+}
+
+// hostOf 地址中的主机部分
+func hostOf(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+// ObjectURL 对象的上传地址
+func (t Target) ObjectURL(key string) string {
+	u, _ := url.Parse(t.Endpoint)
+	if t.PathStyle {
+		return fmt.Sprintf("%s://%s/%s/%s", u.Scheme, u.Host, t.Bucket, key)
+	}
+	return fmt.Sprintf("%s://%s.%s/%s", u.Scheme, t.Bucket, u.Host, key)
+}
+
+// PublicBase 上传后静态资源的访问地址前缀（以 / 结尾），用作 vite build --base
+func (t Target) PublicBase(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	base := strings.TrimRight(t.PublicURL, "/")
+	if base == "" {
+		base = strings.TrimSuffix(t.ObjectURL(""), "/")
+	}
+	if prefix == "" {
+		return base + "/"
+	}
+	return base + "/" + prefix + "/"
+}
+
+// Summary 上传结果
+type Summary struct {
+	Files int   // 上传的文件数
+	Bytes int64 // 上传的总大小
+}
+
+// Upload 把 distDir 中除入口 index.html 以外的文件上传到 prefix 下（index.html 仍部署在站点上），
+// progress 在每个文件上传后调用（可为 nil）
+func Upload(ctx context.Context, client *http.Client, t Target, distDir, prefix string, progress func(done, total int)) (Summary, error) {
+	var summary Summary
+	var files []string
+	err := filepath.WalkDir(distDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(distDir, p)
+		if rel != "index.html" {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return summary, apperr.Errorf(apperr.CDNUploadFailed, "读取构建产物失败: %v", err)
+	}
+	sort.Strings(files)
+
+	for i, rel := range files {
+		data, err := os.ReadFile(filepath.Join(distDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return summary, apperr.Errorf(apperr.CDNUploadFailed, "读取 %s 失败: %v", rel, err)
+		}
+		key := path.Join(strings.Trim(prefix, "/"), rel)
+		if err := put(ctx, client, t, key, data); err != nil {
+			return summary, apperr.Errorf(apperr.CDNUploadFailed, "上传 %s 失败: %w", rel, err)
+		}
+		summary.Files++
+		summary.Bytes += int64(len(data))
+		if progress != nil {
+			progress(i+1, len(files))
+		}
+	}
+	return summary, nil
+}
+
+// put 上传一个对象（文件名带哈希的 assets 下的文件长期缓存）
+func put(ctx context.Context, client *http.Client, t Target, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, t.ObjectURL(escapePath(key)), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if strings.Contains("/"+key, "/assets/") {
+		req.Header.Set("Cache-Control", "public, max-age=31536000, immutable")
+	}
+	sum := sha256.Sum256(data)
+	sign(req, t, hex.EncodeToString(sum[:]), time.Now())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// escapePath 按 S3 的规则编码对象路径（保留 /）
+func escapePath(key string) string {
+	parts := strings.Split(key, "/")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(url.PathEscape(p), "+", "%2B")
+	}
+	return strings.Join(parts, "/")
+}
+
+// sign 按 AWS 签名 V4 为请求签名（签名请求中的所有头和 host）
+func sign(req *http.Request, t Target, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + t.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+t.SecretKey), date)
+	for _, part := range []string{t.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		t.AccessKey, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,186 @@
+// Package certstore 保存部署使用的 TLS 证书和私钥（每个证书一个子目录），
+// 导入时校验证书与私钥是否匹配、是否已过期，并提供到期提醒
+package certstore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+// 证书子目录中的文件名（与 certbot 的命名一致，便于直接用于 nginx 配置）
+const (
+	CertFile = "fullchain.pem"
+	KeyFile  = "privkey.pem"
+)
+
+// WarnBefore 到期前多久开始提醒
+const WarnBefore = 30 * 24 * time.Hour
+
+// namePattern 证书名称（作为子目录名）
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Info 证书信息
+type Info struct {
+	Name      string    // 名称（子目录名）
+	CertPath  string    // 证书链文件路径
+	KeyPath   string    // 私钥文件路径
+	Subject   string    // 证书主题（CN）
+	Issuer    string    // 签发者
+	DNSNames  []string  // 证书包含的域名和 IP
+	NotBefore time.Time // 生效时间
+	NotAfter  time.Time // 到期时间
+}
+
+// DaysLeft 距离到期的天数（已过期时为负数）
+func (i Info) DaysLeft(now time.Time) int {
+	return int(i.NotAfter.Sub(now).Hours() / 24)
+}
+
+// Expiring 是否已过期或将在 WarnBefore 内到期
+func (i Info) Expiring(now time.Time) bool {
+	return i.NotAfter.Sub(now) < WarnBefore
+}
+
+// Validate 校验 PEM 格式的证书链和私钥：私钥与证书匹配且证书尚未过期
+func Validate(certPEM, keyPEM []byte, now time.Time) (*Info, error) {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "证书与私钥不匹配或格式错误: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "解析证书失败: %v", err)
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, apperr.Errorf(apperr.CertInvalid, "证书已于 %s 过期", leaf.NotAfter.Local().Format("2006-01-02"))
+	}
+	if now.Before(leaf.NotBefore) {
+		return nil, apperr.Errorf(apperr.CertInvalid, "证书要到 %s 才生效", leaf.NotBefore.Local().Format("2006-01-02"))
+	}
+	return infoOf(leaf), nil
+}
+
+// Inspect 读取证书文件的信息（不需要私钥，用于检查已部署证书的有效期）
+func Inspect(certPath string) (*Info, error) {
+	data, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := parseLeaf(data)
+	if err != nil {
+		return nil, err
+	}
+	info := infoOf(leaf)
+	info.CertPath = certPath
+	return info, nil
+}
+
+// Import 校验后把证书和私钥保存到 dir/name（同名证书会被替换）
+func Import(dir, name, certPath, keyPath string) (*Info, error) {
+	if !namePattern.MatchString(name) {
+		return nil, apperr.Errorf(apperr.CertInvalid, "证书名称 %q 只能包含字母、数字、点、下划线和连字符", name)
+	}
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "读取证书文件失败: %v", err)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "读取私钥文件失败: %v", err)
+	}
+	info, err := Validate(certPEM, keyPEM, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(dir, name)
+	if err := os.MkdirAll(target, 0700); err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "创建证书目录失败: %v", err)
+	}
+	info.Name = name
+	info.CertPath = filepath.Join(target, CertFile)
+	info.KeyPath = filepath.Join(target, KeyFile)
+	if err := os.WriteFile(info.KeyPath, keyPEM, 0600); err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "保存私钥失败: %v", err)
+	}
+	if err := os.WriteFile(info.CertPath, certPEM, 0644); err != nil {
+		return nil, apperr.Errorf(apperr.CertInvalid, "保存证书失败: %v", err)
+	}
+	return info, nil
+}
+
+// List 列出 dir 中保存的证书（按到期时间排序，无法解析的子目录跳过）
+func List(dir string) []Info {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var infos []Info
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		info, err := Inspect(filepath.Join(dir, e.Name(), CertFile))
+		if err != nil {
+			continue
+		}
+		info.Name = e.Name()
+		info.KeyPath = filepath.Join(dir, e.Name(), KeyFile)
+		infos = append(infos, *info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].NotAfter.Before(infos[j].NotAfter) })
+	return infos
+}
+
+// Remove 删除保存的证书
+func Remove(dir, name string) error {
+	if !namePattern.MatchString(name) {
+		return apperr.Errorf(apperr.CertInvalid, "证书名称 %q 无效", name)
+	}
+	return os.RemoveAll(filepath.Join(dir, name))
+}
+
+// parseLeaf 解析 PEM 证书链中的第一张证书（站点证书）
+func parseLeaf(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, apperr.Errorf(apperr.CertInvalid, "文件中没有 PEM 格式的证书")
+		}
+		if block.Type == "CERTIFICATE" {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, apperr.Errorf(apperr.CertInvalid, "解析证书失败: %v", err)
+			}
+			return cert, nil
+		}
+	}
+}
+
+// infoOf 提取证书信息
+func infoOf(cert *x509.Certificate) *Info {
+	info := &Info{
+		Subject:   cert.Subject.CommonName,
+		Issuer:    cert.Issuer.CommonName,
+		DNSNames:  append([]string(nil), cert.DNSNames...),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}
+	for _, ip := range cert.IPAddresses {
+		info.DNSNames = append(info.DNSNames, ip.String())
+	}
+	if info.Issuer == "" && len(cert.Issuer.Organization) > 0 {
+		info.Issuer = strings.Join(cert.Issuer.Organization, ", ")
+	}
+	return info
+}
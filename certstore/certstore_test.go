@@ -0,0 +1,130 @@
+package certstore
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+// newPair 生成自签名证书和私钥（PEM），有效期到 notAfter
+func newPair(t *testing.T, host string, notAfter time.Time) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-100 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// writePair 把证书和私钥写入临时目录，返回路径
+func writePair(t *testing.T, certPEM, keyPEM []byte) (certPath, keyPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	certPath, keyPath = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	certPEM, keyPEM := newPair(t, "gva.example.com", now.Add(60*24*time.Hour))
+
+	info, err := Validate(certPEM, keyPEM, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Subject != "gva.example.com" || len(info.DNSNames) != 1 || info.Expiring(now) {
+		t.Errorf("info = %+v", info)
+	}
+	if days := info.DaysLeft(now); days < 59 || days > 60 {
+		t.Errorf("DaysLeft = %d", days)
+	}
+
+	// 私钥与证书不匹配
+	_, otherKey := newPair(t, "other.example.com", now.Add(time.Hour))
+	if _, err := Validate(certPEM, otherKey, now); apperr.CodeOf(err) != apperr.CertInvalid {
+		t.Errorf("私钥不匹配应返回 CERT_INVALID, got %v", err)
+	}
+
+	// 已过期
+	expiredCert, expiredKey := newPair(t, "old.example.com", now.Add(-time.Hour))
+	if _, err := Validate(expiredCert, expiredKey, now); apperr.CodeOf(err) != apperr.CertInvalid {
+		t.Errorf("过期证书应返回 CERT_INVALID, got %v", err)
+	}
+}
+
+func TestImportListRemove(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	soon, soonKey := newPair(t, "soon.example.com", now.Add(10*24*time.Hour))
+	later, laterKey := newPair(t, "later.example.com", now.Add(200*24*time.Hour))
+	for name, pair := range map[string][2][]byte{"later": {later, laterKey}, "soon": {soon, soonKey}} {
+		certPath, keyPath := writePair(t, pair[0], pair[1])
+		if _, err := Import(dir, name, certPath, keyPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	infos := List(dir)
+	if len(infos) != 2 || infos[0].Name != "soon" || infos[1].Name != "later" {
+		t.Fatalf("List = %+v", infos)
+	}
+	if !infos[0].Expiring(now) || infos[1].Expiring(now) {
+		t.Error("只有 soon 应在提醒期内")
+	}
+	if infos[0].KeyPath != filepath.Join(dir, "soon", KeyFile) {
+		t.Errorf("KeyPath = %s", infos[0].KeyPath)
+	}
+
+	if err := Remove(dir, "soon"); err != nil {
+		t.Fatal(err)
+	}
+	if infos := List(dir); len(infos) != 1 || infos[0].Name != "later" {
+		t.Errorf("删除后 List = %+v", infos)
+	}
+}
+
+func TestImportRejectsBadName(t *testing.T) {
+	certPEM, keyPEM := newPair(t, "gva.example.com", time.Now().Add(time.Hour))
+	certPath, keyPath := writePair(t, certPEM, keyPEM)
+	for _, name := range []string{"", "../escape", "a b"} {
+		if _, err := Import(t.TempDir(), name, certPath, keyPath); apperr.CodeOf(err) != apperr.CertInvalid {
+			t.Errorf("%q: err = %v", name, err)
+		}
+	}
+	if err := Remove(t.TempDir(), ".."); err == nil {
+		t.Error("Remove 应拒绝无效名称")
+	}
+}
@@ -0,0 +1,380 @@
+// Package cleanup 可回收空间的扫描：面板管理的项目中长期没有更新的构建产物（web/dist 等）、
+// 已从面板中移除的项目遗留的 node_modules 和构建产物、面板缓存目录中已删除的项目的编译产物，
+// 以及模块缓存中没有项目使用的 Go 工具链，
+// 生成一份清理报告，确认后一键清理（默认移入回收站）
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/deps"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/launcher"
+)
+
+const (
+	ActionID      = "cleanup-scan"      // 定时任务的动作 ID
+	WeeklyCron    = "0 10 * * 1"        // 默认每周一 10:00 扫描
+	DefaultMaxAge = 30 * 24 * time.Hour // 超过这么久没有更新的构建产物和依赖才算可以清理
+)
+
+// Kind 可清理内容的类别
+type Kind string
+
+const (
+	OldBuild        Kind = "old-build"        // 长期没有更新的构建产物
+	StaleModules    Kind = "stale-modules"    // 已从面板中移除的项目的 node_modules
+	OrphanBinary    Kind = "orphan-binary"    // 面板缓存目录中已删除的项目的后端编译产物
+	UnusedToolchain Kind = "unused-toolchain" // 没有项目使用的 Go 工具链
+)
+
+// Kinds 报告中类别的顺序
+var Kinds = []Kind{OldBuild, StaleModules, OrphanBinary, UnusedToolchain}
+
+// Label 类别名称
+func (k Kind) Label() string {
+	switch k {
+	case OldBuild:
+		return "旧的构建产物"
+	case StaleModules:
+		return "已移除的项目的 node_modules"
+	case OrphanBinary:
+		return "已删除的项目的编译缓存"
+	case UnusedToolchain:
+		return "未使用的 Go 工具链"
+	}
+	return string(k)
+}
+
+// Item 一项可以清理的内容
+type Item struct {
+	Kind      Kind
+	Paths     []string // 要删除的文件或目录（第一个为主要路径）
+	Volume    string   // 与 Paths 位于同一磁盘的目录（移入回收站时使用）
+	Size      int64
+	Reason    string
+	Suggested bool // 默认勾选：只有面板自己的缓存、且对应的项目目录已不存在时才默认勾选
+}
+
+// Options 扫描范围
+type Options struct {
+	Active      []string      // 正在管理的项目根目录（当前项目和多实例）
+	Removed     []string      // 用户从面板中移除的项目根目录（目录仍在时只列出、不默认勾选）
+	Known       []string      // 端口登记表中的项目根目录（编译缓存中属于这些项目的保留）
+	BinCacheDir string        // 编译模式的缓存目录（见 config.BinCacheDir）
+	ModCache    string        // Go 模块缓存目录（为空时不检查工具链）
+	MaxAge      time.Duration // 为 0 时使用 DefaultMaxAge
+	Now         time.Time     // 为零值时使用当前时间
+}
+
+// Report 一次扫描的结果
+type Report struct {
+	Generated time.Time
+	Items     []Item
+}
+
+// Total 可回收的总大小
+func (r Report) Total() int64 {
+	var total int64
+	for _, item := range r.Items {
+		total += item.Size
+	}
+	return total
+}
+
+// Summary 一句话的结果（用于提醒和通知）
+func (r Report) Summary() string {
+	if len(r.Items) == 0 {
+		return "没有发现可以清理的内容"
+	}
+	return fmt.Sprintf("可回收 %s（%d 项）", sysutil.FormatSize(r.Total()), len(r.Items))
+}
+
+// Text 按类别列出的完整报告
+func (r Report) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "空间清理报告（%s）\n%s\n", r.Generated.Format(time.DateTime), r.Summary())
+	for _, kind := range Kinds {
+		var items []Item
+		var size int64
+		for _, item := range r.Items {
+			if item.Kind == kind {
+				items = append(items, item)
+				size += item.Size
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s（%s）:\n", kind.Label(), sysutil.FormatSize(size))
+		for _, item := range items {
+			fmt.Fprintf(&b, "  %-10s %s\n", sysutil.FormatSize(item.Size), item.Paths[0])
+			fmt.Fprintf(&b, "             %s\n", item.Reason)
+		}
+	}
+	return b.String()
+}
+
+// Scan 扫描可以清理的内容（统计大小需要遍历目录，在后台调用）；ctx 取消时返回已扫描的部分
+func Scan(ctx context.Context, o Options) Report {
+	if o.MaxAge <= 0 {
+		o.MaxAge = DefaultMaxAge
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	s := &scanner{ctx: ctx, o: o, r: Report{Generated: o.Now}}
+	active, removed := uniqueRoots(o.Active, nil), uniqueRoots(o.Removed, o.Active)
+	for _, root := range active {
+		s.project(root, false)
+	}
+	for _, root := range removed {
+		s.project(root, true)
+	}
+	all := append(active, uniqueRoots(append(o.Known, o.Removed...), nil)...)
+	s.binCache(all)
+	s.toolchains(all)
+	return s.r
+}
+
+// scanner 一次扫描的状态
+type scanner struct {
+	ctx context.Context
+	o   Options
+	r   Report
+}
+
+// old 路径存在且超过 MaxAge 没有修改
+func (s *scanner) old(path string) (time.Time, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), s.o.Now.Sub(info.ModTime()) > s.o.MaxAge
+}
+
+// add 统计大小后加入报告（大小为 0 或扫描已取消时不加入）
+func (s *scanner) add(item Item) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	for _, path := range item.Paths {
+		item.Size += dirSize(s.ctx, path)
+	}
+	if item.Size == 0 {
+		return
+	}
+	s.r.Items = append(s.r.Items, item)
+}
+
+// project 检查一个项目：旧的 web/dist；已移除的项目另外检查 server/dist、后端编译产物和 node_modules。
+// 正在管理的项目的 server/dist 和 server/gva-server 可能正在使用（生产模式、低资源模式），不清理。
+// 项目目录仍然存在，都不默认勾选
+func (s *scanner) project(root string, removed bool) {
+	p := launcher.NewProject(root)
+	paths := []string{filepath.Join(p.WebDir(), "dist")}
+	if removed {
+		paths = append(paths, filepath.Join(p.ServerDir(), "dist"), p.BackendBinary())
+	}
+	for _, path := range paths {
+		if modified, ok := s.old(path); ok {
+			reason := fmt.Sprintf("%s 构建，%d 天没有更新", modified.Format(time.DateOnly), days(s.o.Now.Sub(modified)))
+			s.add(Item{Kind: OldBuild, Paths: []string{path}, Volume: root, Reason: reason})
+		}
+	}
+	if !removed {
+		return
+	}
+	modules := filepath.Join(p.WebDir(), "node_modules")
+	if modified, ok := s.old(modules); ok {
+		reason := fmt.Sprintf("项目已从面板中移除，%d 天没有安装依赖（需要时重新安装即可）", days(s.o.Now.Sub(modified)))
+		s.add(Item{Kind: StaleModules, Paths: []string{modules}, Volume: root, Reason: reason})
+	}
+}
+
+// binCache 检查编译模式的缓存目录：keep 中仍然存在的项目的编译产物保留。
+// 端口登记表会去掉已不存在的根目录，不属于其中任何项目的缓存对应的项目目录已被删除，默认勾选
+func (s *scanner) binCache(keep []string) {
+	if s.o.BinCacheDir == "" {
+		return
+	}
+	entries, err := os.ReadDir(s.o.BinCacheDir)
+	if err != nil {
+		return
+	}
+	var names []string
+	for _, root := range keep {
+		names = append(names, filepath.Base(filepath.Dir(launcher.NewProject(root).CompiledBackend())))
+	}
+	for _, e := range entries {
+		if !e.IsDir() || slices.Contains(names, e.Name()) {
+			continue
+		}
+		s.add(Item{
+			Kind:      OrphanBinary,
+			Paths:     []string{filepath.Join(s.o.BinCacheDir, e.Name())},
+			Volume:    s.o.BinCacheDir,
+			Reason:    "对应的项目目录已不存在（重新打开项目时会自动重新编译）",
+			Suggested: true,
+		})
+	}
+}
+
+// toolchainDirPattern 模块缓存中 Go 自动下载的工具链目录，例如 toolchain@v0.0.1-go1.22.3.linux-amd64
+var toolchainDirPattern = regexp.MustCompile(`^toolchain@v0\.0\.1-(go[0-9.a-z]+?)\.([a-z0-9]+-[a-z0-9]+)$`)
+
+// languageVersion 只有主次版本号的 go 指令（go 1.22 需要的工具链为 go1.22.0）
+var languageVersion = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+// toolchains 检查 Go 自动下载的工具链（GOTOOLCHAIN=auto 时按 go.mod / go.work 的 go、toolchain 指令下载）：
+// 只列出 ModCache/golang.org 下的工具链目录，roots 中任何项目的 server/go.mod 和生效的 go.work
+// 引用的版本以及 GOTOOLCHAIN 指定的版本保留。面板之外的项目可能仍在使用，都不默认勾选
+func (s *scanner) toolchains(roots []string) {
+	if s.o.ModCache == "" {
+		return
+	}
+	dir := filepath.Join(s.o.ModCache, "golang.org")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	used := goModToolchains("toolchain " + strings.TrimSuffix(os.Getenv("GOTOOLCHAIN"), "+auto"))
+	for _, root := range roots {
+		serverDir := launcher.NewProject(root).ServerDir()
+		for _, path := range []string{filepath.Join(serverDir, "go.mod"), deps.FindGoWork(serverDir)} {
+			if data, err := os.ReadFile(path); err == nil {
+				used = append(used, goModToolchains(string(data))...)
+			}
+		}
+	}
+	for _, e := range entries {
+		m := toolchainDirPattern.FindStringSubmatch(e.Name())
+		if m == nil || !e.IsDir() || slices.Contains(used, m[1]) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		modified, ok := s.old(path)
+		if !ok {
+			continue
+		}
+		// 下载缓存中的 .zip / .mod / .info / .ziphash
+		downloads, _ := filepath.Glob(filepath.Join(s.o.ModCache, "cache", "download", "golang.org", "toolchain", "@v", "v0.0.1-"+m[1]+"."+m[2]+".*"))
+		s.add(Item{
+			Kind:   UnusedToolchain,
+			Paths:  append([]string{path}, downloads...),
+			Volume: s.o.ModCache,
+			Reason: fmt.Sprintf("%s 下载，面板中的项目没有 go.mod / go.work 需要 %s（之后需要时 go 会重新下载）", modified.Format(time.DateOnly), m[1]),
+		})
+	}
+}
+
+// goModToolchains go.mod / go.work 的 go 和 toolchain 指令对应的工具链版本（go 1.22 即 go1.22.0）
+func goModToolchains(content string) []string {
+	var versions []string
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			version := "go" + fields[1]
+			if languageVersion.MatchString(fields[1]) {
+				version += ".0"
+			}
+			versions = append(versions, version)
+		case "toolchain":
+			versions = append(versions, fields[1])
+		}
+	}
+	return versions
+}
+
+// uniqueRoots 去重、去掉空值和 exclude 中的目录，跳过已不存在的目录
+func uniqueRoots(roots, exclude []string) []string {
+	var result []string
+	for _, root := range roots {
+		if root == "" || slices.ContainsFunc(exclude, func(other string) bool { return config.SameRoot(root, other) }) ||
+			slices.ContainsFunc(result, func(other string) bool { return config.SameRoot(root, other) }) {
+			continue
+		}
+		root = filepath.Clean(root)
+		if info, err := os.Stat(root); err == nil && info.IsDir() {
+			result = append(result, root)
+		}
+	}
+	return result
+}
+
+// dirSize 文件或目录的总大小（不跟随符号链接）
+func dirSize(ctx context.Context, path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// days 以天为单位的时长
+func days(d time.Duration) int {
+	return int(d.Hours() / 24)
+}
+
+// RemoveFunc 清理时删除一个路径的方式（例如移入回收站），volumeDir 为与 path 位于同一磁盘的目录
+type RemoveFunc func(path, volumeDir string) error
+
+// Clean 清理选中的内容（remove 为 nil 时直接删除），返回清理掉的大小和失败的原因
+func Clean(items []Item, remove RemoveFunc) (int64, []string) {
+	if remove == nil {
+		remove = removeAll
+	}
+	var freed int64
+	var errs []string
+	for _, item := range items {
+		failed := false
+		for _, path := range item.Paths {
+			if err := remove(path, item.Volume); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", path, err))
+				failed = true
+			}
+		}
+		if !failed {
+			freed += item.Size
+		}
+	}
+	return freed, errs
+}
+
+// removeAll 直接删除（Go 模块缓存中的目录是只读的，先加上写权限）
+func removeAll(path, _ string) error {
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			if info, err := d.Info(); err == nil && info.Mode().Perm()&0200 == 0 {
+				os.Chmod(p, info.Mode().Perm()|0200)
+			}
+		}
+		return nil
+	})
+	return os.RemoveAll(path)
+}
@@ -0,0 +1,147 @@
+package cleanup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/launcher"
+)
+
+// writeOld 写入文件，并把文件和 dirs 层上级目录的修改时间设为 age 之前
+func writeOld(t *testing.T, path string, size int, age time.Duration, dirs ...int) {
+	t.Helper()
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	when := time.Now().Add(-age)
+	levels := 1
+	if len(dirs) > 0 {
+		levels = dirs[0]
+	}
+	for i := 0; i <= levels; i++ {
+		os.Chtimes(path, when, when)
+		path = filepath.Dir(path)
+	}
+}
+
+func TestScan(t *testing.T) {
+	const month = 40 * 24 * time.Hour
+	base := t.TempDir()
+	current, removed, other := filepath.Join(base, "gva"), filepath.Join(base, "old-gva"), filepath.Join(base, "other-gva")
+	binCache, modCache := filepath.Join(base, "bin"), filepath.Join(base, "mod")
+	t.Setenv("GOWORK", "")
+	t.Setenv("GOTOOLCHAIN", "go1.20.3+auto")
+
+	// 当前项目：旧的 web/dist 列出，server/dist 和 node_modules 保留
+	writeOld(t, filepath.Join(current, "web", "dist", "index.html"), 100, month)
+	writeOld(t, filepath.Join(current, "server", "dist", "index.html"), 100, month)
+	writeOld(t, filepath.Join(current, "web", "node_modules", "vue", "index.js"), 100, month, 2)
+	// 从面板中移除的项目：node_modules、server/dist 列出，最近构建的 web/dist 保留
+	writeOld(t, filepath.Join(removed, "web", "node_modules", "vue", "index.js"), 300, month, 2)
+	writeOld(t, filepath.Join(removed, "server", "dist", "index.html"), 50, month)
+	writeOld(t, filepath.Join(removed, "web", "dist", "index.html"), 100, time.Hour)
+	// 只是切换过根目录的项目：目录仍在，什么都不列出
+	writeOld(t, filepath.Join(other, "web", "node_modules", "vue", "index.js"), 300, month, 2)
+	writeOld(t, filepath.Join(other, "server", "dist", "index.html"), 50, month)
+
+	// 编译缓存：仍然存在的项目保留，不属于任何项目的清理
+	for _, root := range []string{current, removed, other} {
+		writeOld(t, filepath.Join(binCache, filepath.Base(filepath.Dir(launcher.NewProject(root).CompiledBackend())), "gva-server"), 100, month)
+	}
+	writeOld(t, filepath.Join(binCache, "deleted-1234abcd", "gva-server"), 200, month)
+
+	// 工具链：任何项目的 go.mod / go.work 引用的、GOTOOLCHAIN 指定的和最近下载的保留
+	os.WriteFile(filepath.Join(current, "server", "go.mod"), []byte("module server\n\ngo 1.22 // 注释\n\ntoolchain go1.23.1\n"), 0644)
+	os.WriteFile(filepath.Join(other, "server", "go.mod"), []byte("module server\n\ngo 1.21.5\n"), 0644)
+	os.WriteFile(filepath.Join(removed, "server", "go.work"), []byte("go 1.24.0\n\nuse .\n"), 0644)
+	toolchain := func(version string, age time.Duration) {
+		writeOld(t, filepath.Join(modCache, "golang.org", "toolchain@v0.0.1-"+version+".linux-amd64", "bin", "go"), 1000, age, 2)
+	}
+	for _, version := range []string{"go1.22.0", "go1.23.1", "go1.21.5", "go1.24.0", "go1.20.3", "go1.19.1"} {
+		toolchain(version, month)
+	}
+	toolchain("go1.25.0", time.Hour)
+	writeOld(t, filepath.Join(modCache, "cache", "download", "golang.org", "toolchain", "@v", "v0.0.1-go1.19.1.linux-amd64.zip"), 500, month)
+
+	r := Scan(context.Background(), Options{
+		Active:      []string{current},
+		Removed:     []string{removed, current, filepath.Join(base, "missing")},
+		Known:       []string{current, other},
+		BinCacheDir: binCache,
+		ModCache:    modCache,
+	})
+	got := make(map[string]int64)
+	var suggested []string
+	for _, item := range r.Items {
+		rel, _ := filepath.Rel(base, item.Paths[0])
+		rel = filepath.ToSlash(rel)
+		got[string(item.Kind)+" "+rel] = item.Size
+		if item.Suggested {
+			suggested = append(suggested, rel)
+		}
+	}
+	want := map[string]int64{
+		"old-build gva/web/dist":                                                100,
+		"old-build old-gva/server/dist":                                         50,
+		"stale-modules old-gva/web/node_modules":                                300,
+		"orphan-binary bin/deleted-1234abcd":                                    200,
+		"unused-toolchain mod/golang.org/toolchain@v0.0.1-go1.19.1.linux-amd64": 1500,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v", got)
+	}
+	// 只有已删除的项目的缓存默认勾选
+	if !reflect.DeepEqual(suggested, []string{"bin/deleted-1234abcd"}) {
+		t.Errorf("suggested = %v", suggested)
+	}
+	if r.Total() != 2150 || !strings.Contains(r.Summary(), "5 项") {
+		t.Errorf("total = %d, summary = %s", r.Total(), r.Summary())
+	}
+	if text := r.Text(); !strings.Contains(text, "已移除的项目的 node_modules") || !strings.Contains(text, "未使用的 Go 工具链") {
+		t.Errorf("text:\n%s", text)
+	}
+}
+
+func TestClean(t *testing.T) {
+	dir := t.TempDir()
+	// 模块缓存中的工具链目录是只读的
+	readOnly := filepath.Join(dir, "toolchain", "bin")
+	writeOld(t, filepath.Join(readOnly, "go"), 10, 0)
+	os.Chmod(readOnly, 0555)
+	zip := filepath.Join(dir, "toolchain.zip")
+	writeOld(t, zip, 5, 0)
+
+	items := []Item{{Kind: UnusedToolchain, Paths: []string{filepath.Join(dir, "toolchain"), zip}, Volume: dir, Size: 15}}
+	freed, errs := Clean(items, nil)
+	if freed != 15 || len(errs) != 0 {
+		t.Errorf("freed = %d, errs = %v", freed, errs)
+	}
+	if _, err := os.Stat(zip); !os.IsNotExist(err) {
+		t.Error("应删除所有路径")
+	}
+
+	var moved []string
+	freed, _ = Clean([]Item{{Paths: []string{"a", "b"}, Volume: "v", Size: 1}}, func(path, volume string) error {
+		moved = append(moved, volume+":"+path)
+		return nil
+	})
+	if freed != 1 || strings.Join(moved, " ") != "v:a v:b" {
+		t.Errorf("moved = %v", moved)
+	}
+}
+
+func TestGoModToolchains(t *testing.T) {
+	got := goModToolchains("module x\n\ngo 1.21\n\ntoolchain go1.22.3 // 本地\n")
+	if !reflect.DeepEqual(got, []string{"go1.21.0", "go1.22.3"}) {
+		t.Errorf("got %v", got)
+	}
+	if got := goModToolchains("go 1.23rc1\n"); !reflect.DeepEqual(got, []string{"go1.23rc1"}) {
+		t.Errorf("got %v", got)
+	}
+}
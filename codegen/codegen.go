@@ -0,0 +1,157 @@
+// Package codegen 后端代码生成命令的识别：根据 server 目录中的源码判断项目用到了哪些生成命令
+// （go generate ./...、wire、swag init），拉取代码后一键重新生成，不用再记「拉完代码要执行哪些命令」
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Generator 识别到的一个生成命令
+type Generator struct {
+	Name    string // 按钮名称
+	Command string // 在 server 目录中通过系统 shell 执行的命令行
+	Reason  string // 识别依据（相对 server 目录的文件）
+	Tool    string // 需要的命令行工具
+	Install string // 工具没有安装时的安装命令（go 自带的命令为空）
+}
+
+// skipDirs 识别时跳过的目录（依赖、前端和上传的文件）
+var skipDirs = []string{"vendor", "node_modules", "uploads", "testdata"}
+
+// Detect 识别 serverDir 中用到的生成命令，按建议的执行顺序返回：
+// 先 go generate（可能生成 wire、swag 需要的代码），再 wire，最后 swag init（文档反映最终的代码）
+func Detect(serverDir string) ([]Generator, error) {
+	var generate, wire []string
+	var swagMain string
+	err := filepath.WalkDir(serverDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != serverDir && (strings.HasPrefix(d.Name(), ".") || slices.Contains(skipDirs, d.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(serverDir, path)
+		rel = filepath.ToSlash(rel)
+		f := scan(data)
+		if f.generate {
+			generate = append(generate, rel)
+		}
+		if f.wireinject {
+			wire = append(wire, rel)
+		}
+		if f.swag && swagMain == "" {
+			swagMain = rel
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var gens []Generator
+	if len(generate) > 0 {
+		gens = append(gens, Generator{
+			Name:    "go generate",
+			Command: "go generate ./...",
+			Reason:  reason(generate, "//go:generate"),
+			Tool:    "go",
+		})
+	}
+	if len(wire) > 0 {
+		gens = append(gens, Generator{
+			Name:    "wire",
+			Command: "wire gen " + strings.Join(packages(wire), " "),
+			Reason:  reason(wire, "wireinject"),
+			Tool:    "wire",
+			Install: "go install github.com/google/wire/cmd/wire@latest",
+		})
+	}
+	if swagMain != "" {
+		command := "swag init"
+		if dir := filepath.ToSlash(filepath.Dir(swagMain)); dir != "." {
+			// 注释不在 server/main.go 中时指定入口文件
+			command += " -g " + swagMain
+		}
+		gens = append(gens, Generator{
+			Name:    "swag init",
+			Command: command,
+			Reason:  swagMain + " 中的 swag 注释（@title）",
+			Tool:    "swag",
+			Install: "go install github.com/swaggo/swag/cmd/swag@latest",
+		})
+	}
+	return gens, nil
+}
+
+// features 一个源码文件中与生成命令有关的内容
+type features struct {
+	generate   bool // 有 //go:generate 指令
+	wireinject bool // wire 的注入器定义（wireinject 构建标签）
+	swag       bool // 有 swag 的接口文档总体注释（// @title）
+}
+
+// scan 逐行查找文件中的生成指令和注释（只看行首的注释）
+func scan(data []byte) features {
+	var f features
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if !strings.HasPrefix(line, "//") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "//go:generate "):
+			f.generate = true
+		case strings.HasPrefix(line, "//go:build ") || strings.HasPrefix(line, "// +build "):
+			if slices.Contains(strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(line)), "wireinject") {
+				f.wireinject = true
+			}
+		case strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(line, "//")), "@title "):
+			f.swag = true
+		}
+	}
+	return f
+}
+
+// packages wire 注入器文件所在的包（./ 开头的相对路径，去重并排序）
+func packages(files []string) []string {
+	var pkgs []string
+	for _, file := range files {
+		pkg := "./" + filepath.ToSlash(filepath.Dir(file))
+		if pkg == "./." {
+			pkg = "."
+		}
+		if !slices.Contains(pkgs, pkg) {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	slices.Sort(pkgs)
+	return pkgs
+}
+
+// reason 识别依据：第一个文件，多个文件时附上数量
+func reason(files []string, what string) string {
+	text := files[0] + " 中的 " + what
+	if len(files) > 1 {
+		text += fmt.Sprintf("（共 %d 个文件）", len(files))
+	}
+	return text
+}
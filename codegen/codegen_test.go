@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	server := t.TempDir()
+	writeFile(t, filepath.Join(server, "main.go"), "package main\n\n// @title                       Gin-Vue-Admin Swagger API接口文档\n// @version                     v2.7.0\nfunc main() {}\n")
+	writeFile(t, filepath.Join(server, "internal", "di", "wire.go"), "//go:build wireinject\n// +build wireinject\n\npackage di\n")
+	writeFile(t, filepath.Join(server, "internal", "di", "wire_gen.go"), "// Code generated by Wire. DO NOT EDIT.\n\n//go:generate go run -mod=mod github.com/google/wire/cmd/wire\n//go:build !wireinject\n\npackage di\n")
+	writeFile(t, filepath.Join(server, "model", "enum.go"), "package model\n\n//go:generate stringer -type=Status\ntype Status int\n")
+	// 依赖目录中的指令不算
+	writeFile(t, filepath.Join(server, "vendor", "x", "x.go"), "//go:build wireinject\npackage x\n")
+	writeFile(t, filepath.Join(server, "README.md"), "//go:generate nothing\n")
+
+	gens, err := Detect(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gens) != 3 {
+		t.Fatalf("gens = %+v", gens)
+	}
+	if gens[0].Command != "go generate ./..." || gens[0].Reason != "internal/di/wire_gen.go 中的 //go:generate（共 2 个文件）" {
+		t.Errorf("go generate = %+v", gens[0])
+	}
+	if gens[1].Command != "wire gen ./internal/di" || gens[1].Install == "" {
+		t.Errorf("wire = %+v", gens[1])
+	}
+	if gens[2].Command != "swag init" || gens[2].Tool != "swag" {
+		t.Errorf("swag = %+v", gens[2])
+	}
+}
+
+func TestDetectSwagEntry(t *testing.T) {
+	server := t.TempDir()
+	writeFile(t, filepath.Join(server, "cmd", "api", "main.go"), "package main\n\n// @title API\nfunc main() {}\n")
+	writeFile(t, filepath.Join(server, "service", "user.go"), "package service\n\n// 注释中提到 @title 不算\nfunc F() {}\n")
+	gens, err := Detect(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gens) != 1 || gens[0].Command != "swag init -g cmd/api/main.go" {
+		t.Errorf("gens = %+v", gens)
+	}
+
+	empty := t.TempDir()
+	writeFile(t, filepath.Join(empty, "main.go"), "package main\n")
+	if gens, err := Detect(empty); err != nil || len(gens) != 0 {
+		t.Errorf("gens = %+v, err = %v", gens, err)
+	}
+	if _, err := Detect(filepath.Join(empty, "missing")); err == nil {
+		t.Error("目录不存在时应报错")
+	}
+}
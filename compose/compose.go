@@ -0,0 +1,228 @@
+// Package compose 用 docker compose 只运行 GVA 的本地依赖（MySQL、Redis），后端和前端仍由面板在本机启动。
+// 依赖的 compose 文件由面板生成（与上游 deploy/docker-compose 中的完整部署文件分开），容器端口只映射到 127.0.0.1，
+// 启动后把连接信息写入 server/config.yaml
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+)
+
+// 默认设置（端口避开本机可能已安装的 MySQL、Redis）
+const (
+	DefaultMySQLPort     = 13306
+	DefaultRedisPort     = 16379
+	DefaultMySQLPassword = "gva123456"
+	DefaultDatabase      = "gva"
+	MySQLImage           = "mysql:8.0"
+	RedisImage           = "redis:7-alpine"
+)
+
+// mysqlParams 写入 config.yaml 的 MySQL 连接参数（与上游默认配置一致）
+const mysqlParams = "charset=utf8mb4&parseTime=True&loc=Local"
+
+// FilePath 生成的 compose 文件在项目中的位置
+func FilePath(root string) string {
+	return filepath.Join(root, "deploy", "docker-compose", "docker-compose.deps.yaml")
+}
+
+// invalidName compose 项目名中不允许的字符
+var invalidName = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// ProjectName compose 项目名：gva-deps-<项目目录名>，不同项目的依赖容器和数据卷互不影响
+func ProjectName(root string) string {
+	name := strings.Trim(invalidName.ReplaceAllString(strings.ToLower(filepath.Base(root)), "-"), "-_")
+	if name == "" {
+		return "gva-deps"
+	}
+	return "gva-deps-" + name
+}
+
+// Spec 依赖容器的设置
+type Spec struct {
+	MySQLPort     int    // 映射到本机的 MySQL 端口
+	RedisPort     int    // 映射到本机的 Redis 端口
+	MySQLPassword string // MySQL root 密码
+	Database      string // 自动创建的数据库
+}
+
+// Validate 检查设置
+func (s Spec) Validate() error {
+	for _, port := range []int{s.MySQLPort, s.RedisPort} {
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("端口无效: %d", port)
+		}
+	}
+	switch {
+	case s.MySQLPort == s.RedisPort:
+		return fmt.Errorf("MySQL 和 Redis 不能使用同一个端口")
+	case s.MySQLPassword == "":
+		return fmt.Errorf("请填写 MySQL 的 root 密码")
+	case s.Database == "":
+		return fmt.Errorf("请填写数据库名")
+	}
+	return nil
+}
+
+// service compose 文件中的一个服务
+type service struct {
+	Image       string            `yaml:"image"`
+	Restart     string            `yaml:"restart"`
+	Command     []string          `yaml:"command,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+	Ports       []string          `yaml:"ports"`
+	Volumes     []string          `yaml:"volumes"`
+	Healthcheck healthcheck       `yaml:"healthcheck"`
+}
+
+// healthcheck 容器健康检查（up --wait 等待健康后才返回）
+type healthcheck struct {
+	Test     []string `yaml:"test"`
+	Interval string   `yaml:"interval"`
+	Timeout  string   `yaml:"timeout"`
+	Retries  int      `yaml:"retries"`
+}
+
+// File 生成 compose 文件的内容：mysql 和 redis 两个服务，数据保存在命名卷中（down 时不删除）
+func File(s Spec) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	file := struct {
+		Services map[string]service  `yaml:"services"`
+		Volumes  map[string]struct{} `yaml:"volumes"`
+	}{
+		Services: map[string]service{
+			"mysql": {
+				Image:   MySQLImage,
+				Restart: "unless-stopped",
+				Command: []string{"--character-set-server=utf8mb4", "--collation-server=utf8mb4_unicode_ci"},
+				Environment: map[string]string{
+					"MYSQL_ROOT_PASSWORD": s.MySQLPassword,
+					"MYSQL_DATABASE":      s.Database,
+				},
+				Ports:   []string{"127.0.0.1:" + strconv.Itoa(s.MySQLPort) + ":3306"},
+				Volumes: []string{"mysql-data:/var/lib/mysql"},
+				Healthcheck: healthcheck{
+					Test:     []string{"CMD-SHELL", "mysqladmin ping -h 127.0.0.1 -uroot -p$$MYSQL_ROOT_PASSWORD --silent"},
+					Interval: "5s", Timeout: "5s", Retries: 30,
+				},
+			},
+			"redis": {
+				Image:   RedisImage,
+				Restart: "unless-stopped",
+				Ports:   []string{"127.0.0.1:" + strconv.Itoa(s.RedisPort) + ":6379"},
+				Volumes: []string{"redis-data:/data"},
+				Healthcheck: healthcheck{
+					Test:     []string{"CMD", "redis-cli", "ping"},
+					Interval: "5s", Timeout: "3s", Retries: 10,
+				},
+			},
+		},
+		Volumes: map[string]struct{}{"mysql-data": {}, "redis-data": {}},
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# 由 GVAPanel 生成：只运行本地开发需要的 MySQL 和 Redis，后端和前端在本机运行\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), enc.Close()
+}
+
+// MySQL 写入 config.yaml 的 MySQL 连接配置
+func (s Spec) MySQL() config.GVADBConfig {
+	return config.GVADBConfig{
+		Path:     "127.0.0.1",
+		Port:     strconv.Itoa(s.MySQLPort),
+		Config:   mysqlParams,
+		Dbname:   s.Database,
+		Username: "root",
+		Password: s.MySQLPassword,
+	}
+}
+
+// RedisAddr 写入 config.yaml 的 Redis 地址
+func (s Spec) RedisAddr() string {
+	return "127.0.0.1:" + strconv.Itoa(s.RedisPort)
+}
+
+// Up 生成 compose 文件并启动依赖容器，等待健康检查通过（docker compose up -d --wait）
+func Up(ctx context.Context, root string, s Spec, w io.Writer) error {
+	data, err := File(s)
+	if err != nil {
+		return apperr.Errorf(apperr.ComposeFailed, "%v", err)
+	}
+	path := FilePath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return apperr.Errorf(apperr.ComposeFailed, "创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return apperr.Errorf(apperr.ComposeFailed, "写入 compose 文件失败: %v", err)
+	}
+	return run(ctx, root, w, "up", "-d", "--wait")
+}
+
+// Down 停止并删除依赖容器（保留数据卷，下次启动时数据仍在）
+func Down(ctx context.Context, root string, w io.Writer) error {
+	if !sysutil.FileExists(FilePath(root)) {
+		return apperr.Errorf(apperr.ComposeFailed, "还没有启动过本地依赖")
+	}
+	return run(ctx, root, w, "down")
+}
+
+// Apply 把依赖的连接信息写入 config.yaml：启用 Redis 并写入地址，writeMySQL 为 true 时同时写入 MySQL 连接
+func Apply(root string, s Spec, writeMySQL bool) error {
+	if err := config.WriteRedis(root, true, s.RedisAddr(), "", 0); err != nil {
+		return err
+	}
+	if writeMySQL {
+		return config.WriteMysql(root, s.MySQL())
+	}
+	return nil
+}
+
+// run 执行 docker compose -f <文件> -p <项目名> args...
+func run(ctx context.Context, root string, w io.Writer, args ...string) error {
+	args = append([]string{"compose", "-f", FilePath(root), "-p", ProjectName(root)}, args...)
+	fmt.Fprintf(w, "$ docker %s\n", strings.Join(args, " "))
+	var output strings.Builder
+	if err := sysutil.RunOutputContext(ctx, filepath.Dir(FilePath(root)), io.MultiWriter(w, &output), "docker", args...); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return apperr.Errorf(apperr.ComposeFailed, "docker compose %s 失败: %v%s", args[5], err, composeHint(output.String()))
+	}
+	return nil
+}
+
+// composeHint 根据 docker compose 的输出给出常见原因
+func composeHint(output string) string {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "is not a docker command") || strings.Contains(lower, "unknown command"):
+		return "（需要 Docker Compose V2，即 docker compose 子命令）"
+	case strings.Contains(lower, "port is already allocated") || strings.Contains(lower, "address already in use"):
+		return "（端口已被占用，请换一个端口）"
+	case strings.Contains(lower, "cannot connect to the docker daemon") || strings.Contains(lower, "docker_engine"):
+		return "（Docker 没有启动）"
+	case strings.Contains(lower, "unhealthy"):
+		return "（容器没有通过健康检查，请查看容器日志）"
+	}
+	return ""
+}
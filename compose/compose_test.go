@@ -0,0 +1,148 @@
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func testSpec() Spec {
+	return Spec{MySQLPort: DefaultMySQLPort, RedisPort: DefaultRedisPort, MySQLPassword: "dev", Database: DefaultDatabase}
+}
+
+func TestFile(t *testing.T) {
+	data, err := File(testSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Services map[string]struct {
+			Image       string
+			Ports       []string
+			Environment map[string]string
+		}
+		Volumes map[string]any
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	mysql, redis := file.Services["mysql"], file.Services["redis"]
+	if len(file.Services) != 2 || mysql.Image != MySQLImage || redis.Image != RedisImage {
+		t.Fatalf("services = %+v", file.Services)
+	}
+	// 端口只映射到本机
+	if mysql.Ports[0] != "127.0.0.1:13306:3306" || redis.Ports[0] != "127.0.0.1:16379:6379" {
+		t.Errorf("ports = %v %v", mysql.Ports, redis.Ports)
+	}
+	if mysql.Environment["MYSQL_ROOT_PASSWORD"] != "dev" || mysql.Environment["MYSQL_DATABASE"] != "gva" {
+		t.Errorf("environment = %v", mysql.Environment)
+	}
+	if _, ok := file.Volumes["mysql-data"]; !ok {
+		t.Errorf("volumes = %v", file.Volumes)
+	}
+	// 健康检查中的 $ 需要转义，交给容器内的 shell 展开
+	if !strings.Contains(string(data), "$$MYSQL_ROOT_PASSWORD") {
+		t.Errorf("健康检查没有转义 $:\n%s", data)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, mutate := range []func(*Spec){
+		func(s *Spec) { s.MySQLPort = 0 },
+		func(s *Spec) { s.RedisPort = 70000 },
+		func(s *Spec) { s.RedisPort = s.MySQLPort },
+		func(s *Spec) { s.MySQLPassword = "" },
+		func(s *Spec) { s.Database = "" },
+	} {
+		s := testSpec()
+		mutate(&s)
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v 应报错", s)
+		}
+	}
+}
+
+func TestProjectName(t *testing.T) {
+	cases := map[string]string{
+		"/home/me/gin-vue-admin": "gva-deps-gin-vue-admin",
+		"/home/me/My Project":    "gva-deps-my-project",
+		"/home/me/项目":            "gva-deps",
+	}
+	for root, want := range cases {
+		if got := ProjectName(root); got != want {
+			t.Errorf("%s: got %q, want %q", root, got, want)
+		}
+	}
+}
+
+func TestUpWritesFileAndRunsCompose(t *testing.T) {
+	root := t.TempDir()
+	runner := sysutiltest.New(t)
+	up := "docker compose -f " + FilePath(root) + " -p " + ProjectName(root) + " up -d --wait"
+	runner.Handle(up, "Container gva-deps-mysql-1  Healthy\n", nil)
+
+	var log strings.Builder
+	if err := Up(context.Background(), root, testSpec(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(FilePath(root)); err != nil {
+		t.Errorf("compose 文件没有生成: %v", err)
+	}
+	if calls := runner.Calls(); len(calls) != 1 || calls[0].Command != up {
+		t.Errorf("calls = %+v", runner.Calls())
+	}
+	if !strings.Contains(log.String(), "Healthy") {
+		t.Errorf("输出应写入 w: %q", log.String())
+	}
+
+	// 未预设的命令执行失败
+	if err := Down(context.Background(), root, &log); apperr.CodeOf(err) != apperr.ComposeFailed {
+		t.Errorf("err = %v", err)
+	}
+	if err := Down(context.Background(), t.TempDir(), &log); apperr.CodeOf(err) != apperr.ComposeFailed {
+		t.Errorf("没有 compose 文件时应报错, err = %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "server"), 0755)
+	os.WriteFile(config.GVAConfigPath(root), []byte("system:\n  db-type: mysql\nmysql:\n  path: \"\"\n"), 0644)
+
+	s := testSpec()
+	if err := Apply(root, s, false); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.System.UseRedis || cfg.Redis.Addr != "127.0.0.1:16379" || cfg.Mysql.Path != "" {
+		t.Errorf("只写入 Redis: %+v", cfg)
+	}
+
+	if err := Apply(root, s, true); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = config.ReadGVAConfig(root)
+	if cfg.Mysql.Path != "127.0.0.1" || cfg.Mysql.Port != "13306" || cfg.Mysql.Config != mysqlParams {
+		t.Errorf("mysql = %+v", cfg.Mysql)
+	}
+}
+
+func TestComposeHint(t *testing.T) {
+	if !strings.Contains(composeHint("Bind for 127.0.0.1:13306 failed: port is already allocated"), "端口") {
+		t.Error("port")
+	}
+	if composeHint("ok") != "" {
+		t.Error("没有识别到原因时应为空")
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"gva-launcher/audit"
+)
+
+// PanelConfigFile 面板配置在审计日志中的文件名
+const PanelConfigFile = "gva-launcher.json"
+
+// ProjectAuditFile 项目配置审计日志的文件名（保存在 GVA 根目录，不提交到仓库）
+const ProjectAuditFile = ".gvapanel-audit.jsonl"
+
+// AuditLogPath 获取面板配置的审计日志路径
+func AuditLogPath() string {
+	return dataPath("gva-launcher-audit.jsonl", "audit.jsonl")
+}
+
+// ProjectAuditPath 获取项目配置的审计日志路径（保存在 GVA 根目录，共用项目的用户写入同一文件）
+func ProjectAuditPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, ProjectAuditFile)
+}
+
+// recordFile 记录项目配置文件（server/ 或 web/ 下）从 old 到 new 的修改，写入审计日志失败不影响配置修改。
+// config.yaml 按 YAML 键比较，.env 文件按变量比较，vite 配置只记录面板写入的 https 设置
+func recordFile(path string, old, new []byte) {
+	var changes []audit.Change
+	name := filepath.Base(path)
+	switch {
+	case name == "config.yaml":
+		var before, after map[string]interface{}
+		if yaml.Unmarshal(old, &before) != nil || yaml.Unmarshal(new, &after) != nil {
+			return
+		}
+		changes = audit.Diff(before, after)
+	case strings.HasPrefix(name, ".env"):
+		changes = audit.Diff(parseEnv(old), parseEnv(new))
+	case strings.HasPrefix(name, "vite.config."):
+		before := strconv.FormatBool(strings.Contains(string(old), viteHTTPSMarker))
+		after := strconv.FormatBool(strings.Contains(string(new), viteHTTPSMarker))
+		if before != after {
+			changes = []audit.Change{{Key: "server.https", Old: before, New: after}}
+		}
+	}
+
+	root := filepath.Dir(filepath.Dir(path))
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = name
+	}
+	if len(changes) > 0 {
+		ExcludeFromGit(root, ProjectAuditFile)
+	}
+	audit.Record(ProjectAuditPath(root), filepath.ToSlash(rel), changes)
+}
+
+// parseEnv 解析 env 文件用于 audit.Diff
+func parseEnv(data []byte) map[string]interface{} {
+	vars := map[string]interface{}{}
+	for key, value := range ParseEnv(data) {
+		vars[key] = value
+	}
+	return vars
+}
+
+// recordPanel 记录面板配置的修改（old 为空表示首次创建配置文件，不记录）
+func recordPanel(old, new []byte) {
+	if len(old) == 0 {
+		return
+	}
+	var before, after map[string]interface{}
+	if json.Unmarshal(old, &before) != nil || json.Unmarshal(new, &after) != nil {
+		return
+	}
+	audit.Record(AuditLogPath(), PanelConfigFile, audit.Diff(before, after))
+}
+
+// writeProjectFile 写入项目配置文件并记录修改
+func writeProjectFile(path string, data []byte) error {
+	old, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	recordFile(path, old, data)
+	return nil
+}
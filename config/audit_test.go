@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"gva-launcher/audit"
+)
+
+func TestWritePortsRecordsAudit(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+	writeFile(t, EnvDevPath(root), "# dev\nVITE_CLI_PORT=8080\nVITE_SERVER_PORT=8888\n")
+
+	if err := WritePorts(root, 8001, 8002); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := audit.Read(ProjectAuditPath(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]audit.Entry{}
+	for _, e := range entries {
+		got[e.File+" "+e.Key] = e
+	}
+	if len(got) != 3 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if e := got["server/config.yaml system.addr"]; e.Old != "8888" || e.New != "8001" {
+		t.Errorf("system.addr = %+v", e)
+	}
+	if e := got["web/.env.development VITE_SERVER_PORT"]; e.Old != "8888" || e.New != "8001" {
+		t.Errorf("VITE_SERVER_PORT = %+v", e)
+	}
+	if e := got["web/.env.development VITE_CLI_PORT"]; e.Old != "8080" || e.New != "8002" {
+		t.Errorf("VITE_CLI_PORT = %+v", e)
+	}
+}
+
+func TestWritePortsRollbackRecordsAudit(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+	writeFrontendPort = func(string, int) error { return errors.New("disk full") }
+	defer func() { writeFrontendPort = WriteFrontendPort }()
+
+	if err := WritePorts(root, 8001, 8002); err == nil {
+		t.Fatal("写入前端端口失败时应返回错误")
+	}
+	entries, _ := audit.Read(ProjectAuditPath(root))
+	var addr []string
+	for _, e := range entries {
+		if e.Key == "system.addr" {
+			addr = append(addr, e.Old+"→"+e.New)
+		}
+	}
+	// 写入和回滚各记录一次
+	if len(addr) != 2 || addr[0] != "8888→8001" || addr[1] != "8001→8888" {
+		t.Errorf("system.addr = %v", addr)
+	}
+}
+
+func TestSaveRecordsPanelChanges(t *testing.T) {
+	useDirs(t, t.TempDir())
+
+	cfg := Default()
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Proxy = Proxy{Addr: "0.0.0.0:8800", Auth: "basic", Password: "hunter2"}
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := audit.Read(AuditLogPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 首次创建配置文件不记录
+	if len(entries) != 3 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	for _, e := range entries {
+		if e.File != PanelConfigFile || e.Old != "" {
+			t.Errorf("entry = %+v", e)
+		}
+		if e.Key == "proxy.password" && e.New != audit.Redacted {
+			t.Errorf("密码不应写入审计日志: %+v", e)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/apperr"
+)
+
+// ProjectCommandsFile 项目自定义命令的文件名（保存在 GVA 根目录，可提交到仓库与团队共享）
+const ProjectCommandsFile = ".gvapanel-commands.json"
+
+// 自定义命令的执行目录
+const (
+	CommandDirServer = "server"
+	CommandDirWeb    = "web"
+)
+
+// ProjectCommand 项目自定义命令：面板上的一个按钮，在 server/ 或 web/ 下执行命令并记录输出，
+// 例如「生成代码」「同步字典」「重建索引」
+type ProjectCommand struct {
+	Name    string `json:"name"`    // 按钮名称（唯一）
+	Dir     string `json:"dir"`     // 执行目录：server 或 web
+	Command string `json:"command"` // 命令行（交给系统 shell 执行）
+}
+
+// Validate 检查名称、目录和命令
+func (c ProjectCommand) Validate() error {
+	switch {
+	case strings.TrimSpace(c.Name) == "":
+		return fmt.Errorf("命令名称不能为空")
+	case c.Dir != CommandDirServer && c.Dir != CommandDirWeb:
+		return fmt.Errorf("命令「%s」的执行目录必须是 server 或 web", c.Name)
+	case strings.TrimSpace(c.Command) == "":
+		return fmt.Errorf("命令「%s」的命令行不能为空", c.Name)
+	}
+	return nil
+}
+
+// ProjectCommandsPath 获取项目自定义命令文件的路径
+func ProjectCommandsPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, ProjectCommandsFile)
+}
+
+// LoadProjectCommands 读取项目的自定义命令（文件不存在时返回空列表）
+func LoadProjectCommands(root string) ([]ProjectCommand, error) {
+	data, err := os.ReadFile(ProjectCommandsPath(root))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CfgReadFailed, "读取 %s 失败: %w", ProjectCommandsFile, err)
+	}
+	var commands []ProjectCommand
+	if err := json.Unmarshal(data, &commands); err != nil {
+		return nil, apperr.Errorf(apperr.CfgReadFailed, "%s 格式错误: %v", ProjectCommandsFile, err)
+	}
+	return commands, nil
+}
+
+// SaveProjectCommands 校验并保存项目的自定义命令（名称不能重复；列表为空时删除文件）
+func SaveProjectCommands(root string, commands []ProjectCommand) error {
+	seen := make(map[string]bool)
+	for _, c := range commands {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("命令名称「%s」重复", c.Name)
+		}
+		seen[c.Name] = true
+	}
+
+	path := ProjectCommandsPath(root)
+	if len(commands) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return apperr.Errorf(apperr.CfgWriteFailed, "删除 %s 失败: %w", ProjectCommandsFile, err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(commands, "", "  ")
+	if err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "序列化自定义命令失败: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入 %s 失败: %w", ProjectCommandsFile, err)
+	}
+	return nil
+}
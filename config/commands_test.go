@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestProjectCommands(t *testing.T) {
+	root := t.TempDir()
+	if commands, err := LoadProjectCommands(root); err != nil || len(commands) != 0 {
+		t.Fatalf("没有文件时应返回空列表, got %v, %v", commands, err)
+	}
+
+	commands := []ProjectCommand{
+		{Name: "生成代码", Dir: CommandDirServer, Command: "go generate ./..."},
+		{Name: "同步字典", Dir: CommandDirWeb, Command: "npm run sync-dict"},
+	}
+	if err := SaveProjectCommands(root, commands); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadProjectCommands(root)
+	if err != nil || !reflect.DeepEqual(got, commands) {
+		t.Errorf("got %v, %v", got, err)
+	}
+
+	invalid := [][]ProjectCommand{
+		{{Name: "", Dir: CommandDirServer, Command: "make"}},
+		{{Name: "构建", Dir: "root", Command: "make"}},
+		{{Name: "构建", Dir: CommandDirWeb, Command: " "}},
+		{commands[0], commands[0]},
+	}
+	for _, list := range invalid {
+		if err := SaveProjectCommands(root, list); err == nil {
+			t.Errorf("%v 应校验失败", list)
+		}
+	}
+
+	if err := SaveProjectCommands(root, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(ProjectCommandsPath(root)); !os.IsNotExist(err) {
+		t.Error("清空后应删除文件")
+	}
+}
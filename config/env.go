@@ -0,0 +1,262 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// 前端默认端口
+const DefaultFrontendPort = 8080
+
+// EnvDevPath 获取前端 .env.development 文件路径
+func EnvDevPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, "web", ".env.development")
+}
+
+// updateEnvFile 更新 env 文件中第一个匹配 keys 的行（保留原键名），没有匹配则追加 keys[0]
+func updateEnvFile(envPath string, value string, keys ...string) error {
+	data, err := os.ReadFile(envPath)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgReadFailed, "读取 %s 文件失败: %v", filepath.Base(envPath), err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	updated := false
+
+	// 更新现有的配置行
+	for i, line := range lines {
+		for _, key := range keys {
+			if strings.HasPrefix(strings.TrimSpace(line), key+"=") {
+				lines[i] = key + "=" + value
+				updated = true
+				break
+			}
+		}
+		if updated {
+			break
+		}
+	}
+
+	// 如果没有找到现有的配置，添加新的
+	if !updated {
+		lines = append(lines, keys[0]+"="+value)
+	}
+
+	// 写回文件
+	return writeProjectFile(envPath, []byte(strings.Join(lines, "\n")))
+}
+
+// writeDefaultEnvDev 创建新的 .env.development 文件
+func writeDefaultEnvDev(envPath string, frontendPort, backendPort int) error {
+	envContent := fmt.Sprintf(`# 前端开发环境配置
+VITE_CLI_PORT=%d
+VITE_SERVER_PORT=%d
+VITE_BASE_PATH=http://127.0.0.1
+VITE_BASE_API=/api
+`, frontendPort, backendPort)
+	return writeProjectFile(envPath, []byte(envContent))
+}
+
+// WriteFrontendPort 写入前端配置文件的端口（同时更新环境配置）
+func WriteFrontendPort(root string, frontendPort int) error {
+	if root == "" {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+
+	// 1. 更新 .env 文件（如果存在）
+	envPath := filepath.Join(root, "web", ".env")
+	if sysutil.FileExists(envPath) {
+		if err := updateEnvFile(envPath, strconv.Itoa(frontendPort), "PORT", "VUE_APP_PORT"); err != nil {
+			return err
+		}
+	}
+
+	// 2. 更新或创建 .env.development 文件
+	envDevPath := EnvDevPath(root)
+	var err error
+	if sysutil.FileExists(envDevPath) {
+		err = updateEnvFile(envDevPath, strconv.Itoa(frontendPort), "VITE_CLI_PORT")
+	} else {
+		err = writeDefaultEnvDev(envDevPath, frontendPort, 8888)
+	}
+	if err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "更新 .env.development 文件失败: %v", err)
+	}
+
+	return nil
+}
+
+// WriteFrontendBackendPort 写入前端环境配置文件的后端端口
+func WriteFrontendBackendPort(root string, backendPort int) error {
+	if root == "" {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+
+	// 1. 优先尝试写入 .env.development 文件
+	envDevPath := EnvDevPath(root)
+	if sysutil.FileExists(envDevPath) {
+		return updateEnvFile(envDevPath, strconv.Itoa(backendPort), "VITE_SERVER_PORT")
+	}
+
+	// 2. 如果 .env.development 不存在，创建新的文件
+	return writeDefaultEnvDev(envDevPath, DefaultFrontendPort, backendPort)
+}
+
+// ReadFrontendBackendPort 读取前端开发服务器代理到的后端端口（.env.development 的 VITE_SERVER_PORT，读取不到时返回 0）
+func ReadFrontendBackendPort(root string) int {
+	data, err := os.ReadFile(EnvDevPath(root))
+	if err != nil {
+		return 0
+	}
+	return findEnvPort(string(data), "VITE_SERVER_PORT")
+}
+
+// ReadFrontendPort 从前端配置文件读取端口（读取不到时返回默认端口 8080）
+func ReadFrontendPort(root string) int {
+	if root == "" {
+		return DefaultFrontendPort
+	}
+
+	webPath := filepath.Join(root, "web")
+
+	// 1. 优先从 .env.development 文件读取 VITE_CLI_PORT（这是我们修改的主要配置）
+	if data, err := os.ReadFile(EnvDevPath(root)); err == nil {
+		if port := findEnvPort(string(data), "VITE_CLI_PORT"); port > 0 {
+			return port
+		}
+	}
+
+	// 2. 尝试从 .env 文件读取 PORT 或 VUE_APP_PORT
+	if data, err := os.ReadFile(filepath.Join(webPath, ".env")); err == nil {
+		if port := findEnvPort(string(data), "PORT", "VUE_APP_PORT"); port > 0 {
+			return port
+		}
+	}
+
+	// 3. 尝试从 vue.config.js 读取 devServer.port
+	if data, err := os.ReadFile(filepath.Join(webPath, "vue.config.js")); err == nil {
+		if port := findVueConfigPort(string(data)); port > 0 {
+			return port
+		}
+	}
+
+	// 4. 尝试从 package.json 的 scripts.serve 读取 --port 参数
+	if data, err := os.ReadFile(filepath.Join(webPath, "package.json")); err == nil {
+		if port := findServeScriptPort(data); port > 0 {
+			return port
+		}
+	}
+
+	return DefaultFrontendPort
+}
+
+// DefaultBaseAPI 前端请求后端 API 的默认路径前缀
+const DefaultBaseAPI = "/api"
+
+// ReadBaseAPI 从 .env.development 读取 VITE_BASE_API（前端代理到后端的路径前缀），读取不到时返回 /api
+func ReadBaseAPI(root string) string {
+	data, err := os.ReadFile(EnvDevPath(root))
+	if err != nil {
+		return DefaultBaseAPI
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if value, ok := strings.CutPrefix(line, "VITE_BASE_API="); ok {
+			if value = strings.Trim(strings.TrimSpace(value), `"'`); strings.HasPrefix(value, "/") {
+				return value
+			}
+		}
+	}
+	return DefaultBaseAPI
+}
+
+// ParseEnv 解析 env 文件中的 KEY=VALUE 行（忽略空行和 #、// 开头的注释，值保留原样）
+func ParseEnv(data []byte) map[string]string {
+	vars := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			vars[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return vars
+}
+
+// findEnvPort 按行查找第一个匹配 keys 的有效端口
+func findEnvPort(content string, keys ...string) int {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		for _, key := range keys {
+			if strings.HasPrefix(line, key+"=") {
+				if port, err := strconv.Atoi(strings.TrimPrefix(line, key+"=")); err == nil && port > 0 {
+					return port
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// findVueConfigPort 从 vue.config.js 中查找 port: 数字
+func findVueConfigPort(content string) int {
+	// 简单匹配查找 port: 数字
+	if !strings.Contains(content, "devServer") || !strings.Contains(content, "port") {
+		return 0
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "port") && strings.Contains(line, ":") {
+			// 提取端口号 (简单匹配，如: port: 8080, 或 port:8080)
+			parts := strings.Split(line, ":")
+			if len(parts) >= 2 {
+				portStr := strings.TrimSpace(parts[1])
+				portStr = strings.TrimSuffix(portStr, ",")
+				portStr = strings.TrimSpace(portStr)
+				if port, err := strconv.Atoi(portStr); err == nil && port > 0 {
+					return port
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// findServeScriptPort 从 package.json 的 scripts.serve 中查找 --port 参数
+func findServeScriptPort(data []byte) int {
+	var pkg map[string]interface{}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return 0
+	}
+	scripts, ok := pkg["scripts"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	serve, ok := scripts["serve"].(string)
+	if !ok {
+		return 0
+	}
+
+	parts := strings.Fields(serve)
+	for i, part := range parts {
+		if part == "--port" && i+1 < len(parts) {
+			if port, err := strconv.Atoi(parts[i+1]); err == nil && port > 0 {
+				return port
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newProject 在临时目录中创建带 server/web 子目录的 GVA 项目
+func newProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{"server", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// writeFile 写入测试文件
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readFile 读取测试文件
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUpdateEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		keys    []string
+		want    string
+	}{
+		{"替换已有键", "A=1\nPORT=8080\nB=2", []string{"PORT"}, "A=1\nPORT=9090\nB=2"},
+		{"保留匹配到的备用键名", "VUE_APP_PORT=8080", []string{"PORT", "VUE_APP_PORT"}, "VUE_APP_PORT=9090"},
+		{"只替换第一处", "PORT=1\nPORT=2", []string{"PORT"}, "PORT=9090\nPORT=2"},
+		{"没有匹配时追加", "A=1", []string{"PORT", "VUE_APP_PORT"}, "A=1\nPORT=9090"},
+		{"忽略前导空白", "  PORT=8080", []string{"PORT"}, "PORT=9090"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			writeFile(t, path, tt.content)
+			if err := updateEnvFile(path, "9090", tt.keys...); err != nil {
+				t.Fatal(err)
+			}
+			if got := readFile(t, path); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateEnvFileMissing(t *testing.T) {
+	if err := updateEnvFile(filepath.Join(t.TempDir(), ".env"), "1", "PORT"); err == nil {
+		t.Fatal("文件不存在时应返回错误")
+	}
+}
+
+func TestWriteFrontendPort(t *testing.T) {
+	root := newProject(t)
+	envPath := filepath.Join(root, "web", ".env")
+	writeFile(t, envPath, "VUE_APP_PORT=8080\nOTHER=x\n")
+	writeFile(t, EnvDevPath(root), "VITE_CLI_PORT=8080\nVITE_SERVER_PORT=8888\n")
+
+	if err := WriteFrontendPort(root, 9090); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, envPath); got != "VUE_APP_PORT=9090\nOTHER=x\n" {
+		t.Errorf(".env = %q", got)
+	}
+	if got := readFile(t, EnvDevPath(root)); got != "VITE_CLI_PORT=9090\nVITE_SERVER_PORT=8888\n" {
+		t.Errorf(".env.development = %q", got)
+	}
+	if got := ReadFrontendPort(root); got != 9090 {
+		t.Errorf("ReadFrontendPort = %d, want 9090", got)
+	}
+}
+
+func TestWriteFrontendPortCreatesEnvDev(t *testing.T) {
+	root := newProject(t)
+
+	if err := WriteFrontendPort(root, 9090); err != nil {
+		t.Fatal(err)
+	}
+
+	content := readFile(t, EnvDevPath(root))
+	if findEnvPort(content, "VITE_CLI_PORT") != 9090 || findEnvPort(content, "VITE_SERVER_PORT") != 8888 {
+		t.Errorf("新建的 .env.development 内容不正确: %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(root, "web", ".env")); !os.IsNotExist(err) {
+		t.Error(".env 不存在时不应创建")
+	}
+}
+
+func TestWriteFrontendPortWithoutRoot(t *testing.T) {
+	if err := WriteFrontendPort("", 9090); err == nil {
+		t.Fatal("根目录为空时应返回错误")
+	}
+	if err := WriteFrontendBackendPort("", 9090); err == nil {
+		t.Fatal("根目录为空时应返回错误")
+	}
+}
+
+func TestWriteFrontendBackendPort(t *testing.T) {
+	root := newProject(t)
+
+	// 文件不存在时使用默认前端端口创建
+	if err := WriteFrontendBackendPort(root, 9999); err != nil {
+		t.Fatal(err)
+	}
+	content := readFile(t, EnvDevPath(root))
+	if findEnvPort(content, "VITE_SERVER_PORT") != 9999 || findEnvPort(content, "VITE_CLI_PORT") != DefaultFrontendPort {
+		t.Errorf("新建的 .env.development 内容不正确: %q", content)
+	}
+
+	// 文件存在时只更新 VITE_SERVER_PORT
+	if err := WriteFrontendBackendPort(root, 7777); err != nil {
+		t.Fatal(err)
+	}
+	content = readFile(t, EnvDevPath(root))
+	if findEnvPort(content, "VITE_SERVER_PORT") != 7777 || !strings.Contains(content, "VITE_BASE_API=/api") {
+		t.Errorf("更新后的 .env.development 内容不正确: %q", content)
+	}
+	if got := ReadFrontendBackendPort(root); got != 7777 {
+		t.Errorf("ReadFrontendBackendPort = %d, want 7777", got)
+	}
+}
+
+func TestReadFrontendPortPriority(t *testing.T) {
+	root := newProject(t)
+	web := filepath.Join(root, "web")
+
+	if got := ReadFrontendPort(root); got != DefaultFrontendPort {
+		t.Errorf("没有任何配置时应返回默认端口, got %d", got)
+	}
+
+	writeFile(t, filepath.Join(web, "package.json"), `{"scripts":{"serve":"vue-cli-service serve --port 8004"}}`)
+	if got := ReadFrontendPort(root); got != 8004 {
+		t.Errorf("package.json: got %d, want 8004", got)
+	}
+
+	writeFile(t, filepath.Join(web, "vue.config.js"), "module.exports = {\n  devServer: {\n    port: 8003,\n  }\n}")
+	if got := ReadFrontendPort(root); got != 8003 {
+		t.Errorf("vue.config.js: got %d, want 8003", got)
+	}
+
+	writeFile(t, filepath.Join(web, ".env"), "PORT=8002")
+	if got := ReadFrontendPort(root); got != 8002 {
+		t.Errorf(".env: got %d, want 8002", got)
+	}
+
+	writeFile(t, EnvDevPath(root), "VITE_CLI_PORT=8001")
+	if got := ReadFrontendPort(root); got != 8001 {
+		t.Errorf(".env.development: got %d, want 8001", got)
+	}
+
+	// 无效端口时回退到下一优先级
+	writeFile(t, EnvDevPath(root), "VITE_CLI_PORT=abc")
+	if got := ReadFrontendPort(root); got != 8002 {
+		t.Errorf("无效 VITE_CLI_PORT: got %d, want 8002", got)
+	}
+}
+
+func TestReadBaseAPI(t *testing.T) {
+	root := newProject(t)
+	if got := ReadBaseAPI(root); got != "/api" {
+		t.Errorf("缺少 .env.development: %q", got)
+	}
+
+	writeFile(t, EnvDevPath(root), "VITE_CLI_PORT=8080\nVITE_BASE_API = x\nVITE_BASE_API='/gva-api'\n")
+	if got := ReadBaseAPI(root); got != "/gva-api" {
+		t.Errorf("got %q, want /gva-api", got)
+	}
+}
+
+func TestFindVueConfigPort(t *testing.T) {
+	tests := []struct {
+		content string
+		want    int
+	}{
+		{"devServer: {\n port: 8080,\n}", 8080},
+		{"devServer:{\nport:3000\n}", 3000},
+		{"port: 8080", 0}, // 没有 devServer
+		{"devServer: {\n port: process.env.PORT,\n}", 0},
+	}
+	for _, tt := range tests {
+		if got := findVueConfigPort(tt.content); got != tt.want {
+			t.Errorf("findVueConfigPort(%q) = %d, want %d", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestFindServeScriptPort(t *testing.T) {
+	tests := []struct {
+		content string
+		want    int
+	}{
+		{`{"scripts":{"serve":"vite --port 8080"}}`, 8080},
+		{`{"scripts":{"serve":"vite"}}`, 0},
+		{`{"scripts":{"dev":"vite --port 8080"}}`, 0},
+		{`{"scripts":{"serve":"vite --port"}}`, 0},
+		{`not json`, 0},
+	}
+	for _, tt := range tests {
+		if got := findServeScriptPort([]byte(tt.content)); got != tt.want {
+			t.Errorf("findServeScriptPort(%s) = %d, want %d", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	content := "ENV = 'development'\n# 注释\n// VITE_EDITOR = webstorm\nVITE_CLI_PORT=8080\n\nVITE_BASE_API = /api\r\n"
+	want := map[string]string{"ENV": "'development'", "VITE_CLI_PORT": "8080", "VITE_BASE_API": "/api"}
+	if got := ParseEnv([]byte(content)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v", got)
+	}
+}
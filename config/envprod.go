@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/apperr"
+)
+
+// EnvProdPath 获取前端 .env.production 文件路径（npm run build 时读取）
+func EnvProdPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, "web", ".env.production")
+}
+
+// ProductionEnv 前端生产构建中决定接口地址的变量（.env.production）
+type ProductionEnv struct {
+	BasePath string // VITE_BASE_PATH 部署后的站点地址，例如 https://admin.example.com
+	BaseAPI  string // VITE_BASE_API 接口前缀（/api，由 nginx 转发到后端）或完整的接口地址
+	FileAPI  string // VITE_FILE_API 文件访问前缀（为空时与 BaseAPI 相同）
+}
+
+// ReadProductionEnv 读取 .env.production（文件不存在时返回默认的接口前缀 /api）
+func ReadProductionEnv(root string) ProductionEnv {
+	env := ProductionEnv{BaseAPI: DefaultBaseAPI}
+	data, err := os.ReadFile(EnvProdPath(root))
+	if err != nil {
+		return env
+	}
+	vars := ParseEnv(data)
+	unquote := func(key string) string { return strings.Trim(vars[key], `"'`) }
+	env.BasePath = unquote("VITE_BASE_PATH")
+	if v := unquote("VITE_BASE_API"); v != "" {
+		env.BaseAPI = v
+	}
+	env.FileAPI = unquote("VITE_FILE_API")
+	return env
+}
+
+// APIURL 部署后浏览器请求的接口地址：BaseAPI 为完整地址时直接使用，否则拼在站点地址之后
+func (e ProductionEnv) APIURL() string {
+	if strings.Contains(e.BaseAPI, "://") {
+		return strings.TrimRight(e.BaseAPI, "/")
+	}
+	return strings.TrimRight(e.BasePath, "/") + e.BaseAPI
+}
+
+// Validate 检查接口地址：站点地址需为 http(s) 地址，接口前缀需以 / 开头或为完整地址，
+// 且都不能指向 localhost、127.0.0.1 等本机地址（部署后浏览器会请求访问者自己的电脑）
+func (e ProductionEnv) Validate() error {
+	if e.BasePath == "" {
+		return apperr.Errorf(apperr.BuildEnvInvalid, "请填写部署后的站点地址（VITE_BASE_PATH）")
+	}
+	if err := checkDeployURL("VITE_BASE_PATH", e.BasePath); err != nil {
+		return err
+	}
+	for _, v := range []struct{ key, value string }{{"VITE_BASE_API", e.BaseAPI}, {"VITE_FILE_API", e.FileAPI}} {
+		switch {
+		case v.value == "" && v.key == "VITE_FILE_API":
+		case strings.HasPrefix(v.value, "/"):
+		case strings.Contains(v.value, "://"):
+			if err := checkDeployURL(v.key, v.value); err != nil {
+				return err
+			}
+		default:
+			return apperr.Errorf(apperr.BuildEnvInvalid, "%s 需以 / 开头（例如 /api），或填写完整的地址（例如 https://api.example.com）", v.key)
+		}
+	}
+	return nil
+}
+
+// checkDeployURL 检查部署用的地址：http(s) 协议，主机名不能是本机地址
+func checkDeployURL(key, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperr.Errorf(apperr.BuildEnvInvalid, "%s 不是有效的 http(s) 地址: %s", key, value)
+	}
+	if IsLoopbackHost(u.Hostname()) {
+		return apperr.Errorf(apperr.BuildEnvInvalid, "%s 指向本机地址 %s，部署后浏览器会请求访问者自己的电脑，请填写部署后的域名或服务器地址", key, value)
+	}
+	return nil
+}
+
+// IsLoopbackHost 主机名是否为本机地址（localhost、127.0.0.0/8、::1、0.0.0.0）
+func IsLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsUnspecified())
+}
+
+// WriteProductionEnv 检查后写入 .env.production：已有的变量保留原来的写法（包括 KEY = value 的空格）只替换值，
+// 缺少的追加到末尾；文件不存在时新建。FileAPI 为空时写入与 BaseAPI 相同的值
+func WriteProductionEnv(root string, env ProductionEnv) error {
+	if root == "" {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+	if err := env.Validate(); err != nil {
+		return err
+	}
+	if env.FileAPI == "" {
+		env.FileAPI = env.BaseAPI
+	}
+
+	path := EnvProdPath(root)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data = []byte("ENV = 'production'\n")
+	} else if err != nil {
+		return apperr.Errorf(apperr.CfgReadFailed, "读取 .env.production 文件失败: %v", err)
+	}
+	content := setEnvValue(string(data), "VITE_BASE_API", env.BaseAPI)
+	content = setEnvValue(content, "VITE_FILE_API", env.FileAPI)
+	content = setEnvValue(content, "VITE_BASE_PATH", env.BasePath)
+	if err := writeProjectFile(path, []byte(content)); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入 .env.production 文件失败: %v", err)
+	}
+	return nil
+}
+
+// setEnvValue 替换 env 内容中 key 的值（保留等号两侧的空格和换行符），没有时追加
+func setEnvValue(content, key, value string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		name, _, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(name) != key || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		cr := ""
+		if strings.HasSuffix(line, "\r") {
+			cr = "\r"
+		}
+		sep := "="
+		if strings.HasSuffix(name, " ") {
+			sep = "= "
+		}
+		lines[i] = name + sep + value + cr
+		return strings.Join(lines, "\n")
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content + fmt.Sprintf("%s = %s\n", key, value)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProductionEnvValidate(t *testing.T) {
+	cases := []struct {
+		env ProductionEnv
+		ok  bool
+	}{
+		{ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "/api"}, true},
+		{ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "https://api.example.com/api", FileAPI: "/api"}, true},
+		{ProductionEnv{BasePath: "http://127.0.0.1", BaseAPI: "/api"}, false},
+		{ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "http://localhost:8888"}, false},
+		{ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "http://[::1]:8888/api"}, false},
+		{ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "api"}, false},
+		{ProductionEnv{BasePath: "admin.example.com", BaseAPI: "/api"}, false},
+		{ProductionEnv{BaseAPI: "/api"}, false},
+	}
+	for _, c := range cases {
+		if err := c.env.Validate(); (err == nil) != c.ok {
+			t.Errorf("Validate(%+v) = %v, want ok=%v", c.env, err, c.ok)
+		}
+	}
+}
+
+func TestProductionEnvAPIURL(t *testing.T) {
+	if got := (ProductionEnv{BasePath: "https://admin.example.com/", BaseAPI: "/api"}).APIURL(); got != "https://admin.example.com/api" {
+		t.Errorf("APIURL = %q", got)
+	}
+	if got := (ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "https://api.example.com/"}).APIURL(); got != "https://api.example.com" {
+		t.Errorf("APIURL = %q", got)
+	}
+}
+
+func TestWriteProductionEnv(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "web"), 0755)
+	path := EnvProdPath(root)
+	// 上游的写法：等号两侧有空格，值带引号，CRLF 换行
+	upstream := "ENV = 'production'\r\n\r\n#下方修改为你的线上ip\r\nVITE_CLI_PORT = 8080\r\nVITE_BASE_API = /api\r\nVITE_BASE_PATH = https://demo.gin-vue-admin.com\r\n"
+	os.WriteFile(path, []byte(upstream), 0644)
+
+	env := ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "/prod-api"}
+	if err := WriteProductionEnv(root, env); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "ENV = 'production'\r\n\r\n#下方修改为你的线上ip\r\nVITE_CLI_PORT = 8080\r\nVITE_BASE_API = /prod-api\r\nVITE_BASE_PATH = https://admin.example.com\r\nVITE_FILE_API = /prod-api\n"
+	if string(data) != want {
+		t.Errorf(".env.production = %q", data)
+	}
+	if got := ReadProductionEnv(root); got.BasePath != env.BasePath || got.BaseAPI != "/prod-api" || got.FileAPI != "/prod-api" {
+		t.Errorf("ReadProductionEnv = %+v", got)
+	}
+
+	if err := WriteProductionEnv(root, ProductionEnv{BasePath: "http://localhost:8080", BaseAPI: "/api"}); err == nil {
+		t.Error("本机地址应返回错误")
+	}
+	if data2, _ := os.ReadFile(path); string(data2) != want {
+		t.Error("检查失败时不应修改文件")
+	}
+}
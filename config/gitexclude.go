@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ExcludeFromGit 把面板写在项目中、只属于本机的文件（项目锁、审计日志）加入所在 git 仓库的 .git/info/exclude，
+// 避免出现在 git status 中或被 git add 提交；不修改仓库中的 .gitignore。root 不在 git 仓库中时什么也不做
+func ExcludeFromGit(root, name string) error {
+	top, gitDir := findGitDir(root)
+	if gitDir == "" {
+		return nil
+	}
+	rel, err := filepath.Rel(top, filepath.Join(root, name))
+	if err != nil {
+		return err
+	}
+	pattern := "/" + filepath.ToSlash(rel)
+
+	path := filepath.Join(gitDir, "info", "exclude")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if slices.Contains(lines, pattern) {
+		return nil
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		pattern = "\n" + pattern
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(pattern + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// findGitDir 从 dir 向上查找 git 仓库，返回工作区根目录和存放 info/exclude 的目录（找不到时都为空）。
+// .git 是文件时（git worktree、子模块）按其中的 gitdir 查找，worktree 共用主仓库的 info/exclude
+func findGitDir(dir string) (top, gitDir string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return dir, dotGit
+			}
+			return dir, linkedGitDir(dotGit)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// linkedGitDir 解析 .git 文件中的 gitdir，有 commondir 时（worktree）返回主仓库的目录
+func linkedGitDir(dotGit string) string {
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(dotGit), gitDir)
+	}
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		dir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(gitDir, dir)
+		}
+		return filepath.Clean(dir)
+	}
+	return gitDir
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExcludeFromGit(t *testing.T) {
+	repo := t.TempDir()
+	root := filepath.Join(repo, "gva")
+	os.MkdirAll(filepath.Join(repo, ".git", "info"), 0755)
+	os.MkdirAll(root, 0755)
+	exclude := filepath.Join(repo, ".git", "info", "exclude")
+	writeFile(t, exclude, "# git ls-files --others --exclude-from=.git/info/exclude\n*.log")
+
+	for i := 0; i < 2; i++ {
+		if err := ExcludeFromGit(root, ".gvapanel.lock"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "# git ls-files --others --exclude-from=.git/info/exclude\n*.log\n/gva/.gvapanel.lock\n"
+	if got := readFile(t, exclude); got != want {
+		t.Errorf("exclude = %q, want %q", got, want)
+	}
+}
+
+func TestExcludeFromGitWorktree(t *testing.T) {
+	main := t.TempDir()
+	worktreeGit := filepath.Join(main, ".git", "worktrees", "feature")
+	os.MkdirAll(worktreeGit, 0755)
+	writeFile(t, filepath.Join(worktreeGit, "commondir"), "../..\n")
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".git"), "gitdir: "+worktreeGit+"\n")
+
+	if err := ExcludeFromGit(root, ".gvapanel.lock"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(main, ".git", "info", "exclude")); got != "/.gvapanel.lock\n" {
+		t.Errorf("exclude = %q", got)
+	}
+}
+
+func TestExcludeFromGitOutsideRepo(t *testing.T) {
+	root := t.TempDir()
+	if err := ExcludeFromGit(root, ".gvapanel.lock"); err != nil {
+		t.Errorf("不在仓库中时不应报错: %v", err)
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("不在仓库中时不应写入文件: %v", entries)
+	}
+}
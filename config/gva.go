@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"gva-launcher/apperr"
+)
+
+// GVAConfig GVA的config.yaml结构
+type GVAConfig struct {
+	System struct {
+		Addr          int    `yaml:"addr"`
+		UseRedis      bool   `yaml:"use-redis"`
+		DbType        string `yaml:"db-type"`
+		RouterPrefix  string `yaml:"router-prefix"`
+		UseMultipoint bool   `yaml:"use-multipoint"`
+	} `yaml:"system"`
+	JWT struct {
+		ExpiresTime string `yaml:"expires-time"` // 令牌有效期，例如 7d
+		BufferTime  string `yaml:"buffer-time"`  // 剩余有效期小于该值时自动续期，例如 1d
+	} `yaml:"jwt"`
+	Redis struct {
+		Addr     string `yaml:"addr"`
+		Password string `yaml:"password"`
+		DB       int    `yaml:"db"`
+	} `yaml:"redis"`
+	Mysql  GVADBConfig `yaml:"mysql"`
+	Pgsql  GVADBConfig `yaml:"pgsql"`
+	Sqlite GVADBConfig `yaml:"sqlite"`
+	Zap    struct {
+		Director string `yaml:"director"`
+	} `yaml:"zap"`
+	// 对象存储配置（后端上传文件使用，面板上传前端静态资源时复用其中的凭据）
+	AwsS3 struct {
+		Bucket         string `yaml:"bucket"`
+		Region         string `yaml:"region"`
+		Endpoint       string `yaml:"endpoint"`
+		SecretID       string `yaml:"secret-id"`
+		SecretKey      string `yaml:"secret-key"`
+		BaseURL        string `yaml:"base-url"`
+		ForcePathStyle bool   `yaml:"s3-force-path-style"`
+		DisableSSL     bool   `yaml:"disable-ssl"`
+	} `yaml:"aws-s3"`
+	AliyunOSS struct {
+		Endpoint        string `yaml:"endpoint"`
+		AccessKeyID     string `yaml:"access-key-id"`
+		AccessKeySecret string `yaml:"access-key-secret"`
+		BucketName      string `yaml:"bucket-name"`
+		BucketURL       string `yaml:"bucket-url"`
+	} `yaml:"aliyun-oss"`
+	TencentCOS struct {
+		Bucket    string `yaml:"bucket"`
+		Region    string `yaml:"region"`
+		SecretID  string `yaml:"secret-id"`
+		SecretKey string `yaml:"secret-key"`
+		BaseURL   string `yaml:"base-url"`
+	} `yaml:"tencent-cos"`
+	Minio struct {
+		Endpoint        string `yaml:"endpoint"`
+		AccessKeyID     string `yaml:"access-key-id"`
+		AccessKeySecret string `yaml:"access-key-secret"`
+		BucketName      string `yaml:"bucket-name"`
+		UseSSL          bool   `yaml:"use-ssl"`
+		BucketURL       string `yaml:"bucket-url"`
+	} `yaml:"minio"`
+}
+
+// GVADBConfig GVA的数据库配置（mysql/pgsql/sqlite 结构相同，sqlite 只使用 path 和 db-name）
+type GVADBConfig struct {
+	Path     string `yaml:"path"`
+	Port     string `yaml:"port"`
+	Config   string `yaml:"config"`
+	Dbname   string `yaml:"db-name"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// GVAConfigPath 获取GVA配置文件路径
+func GVAConfigPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, "server", "config.yaml")
+}
+
+// ReadGVAConfig 读取GVA的配置文件
+func ReadGVAConfig(root string) (*GVAConfig, error) {
+	configPath := GVAConfigPath(root)
+	if configPath == "" {
+		return nil, apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CfgReadFailed, "读取配置文件失败: %w", err)
+	}
+
+	var gvaConfig GVAConfig
+	if err := yaml.Unmarshal(data, &gvaConfig); err != nil {
+		return nil, apperr.Errorf(apperr.CfgYAMLParse, "解析配置文件失败: %v", err)
+	}
+
+	return &gvaConfig, nil
+}
+
+// updateGVAConfig 以 map 形式读取 config.yaml，交给 fn 修改后写回（保留未知字段）
+func updateGVAConfig(root string, fn func(gvaConfig map[string]interface{})) error {
+	configPath := GVAConfigPath(root)
+	if configPath == "" {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgReadFailed, "读取配置文件失败: %v", err)
+	}
+
+	var gvaConfig map[string]interface{}
+	if err := yaml.Unmarshal(data, &gvaConfig); err != nil {
+		return apperr.Errorf(apperr.CfgYAMLParse, "解析配置文件失败: %v", err)
+	}
+	if gvaConfig == nil {
+		gvaConfig = map[string]interface{}{}
+	}
+
+	fn(gvaConfig)
+
+	newData, err := yaml.Marshal(gvaConfig)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "序列化配置失败: %v", err)
+	}
+
+	if err := writeProjectFile(configPath, newData); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入配置文件失败: %v", err)
+	}
+	return nil
+}
+
+// WriteBackendPort 写入GVA配置文件的端口（同时更新前端环境配置）
+func WriteBackendPort(root string, backendPort int) error {
+	// 1. 更新后端配置文件
+	err := updateGVAConfig(root, func(gvaConfig map[string]interface{}) {
+		if system, ok := gvaConfig["system"].(map[string]interface{}); ok {
+			system["addr"] = backendPort
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2. 更新前端环境配置文件
+	if err := WriteFrontendBackendPort(root, backendPort); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "更新前端环境配置失败: %v", err)
+	}
+
+	return nil
+}
+
+// WriteUseRedis 只写 system.use-redis 字段
+func WriteUseRedis(root string, useRedis bool) error {
+	return updateGVAConfig(root, func(gvaConfig map[string]interface{}) {
+		if system, ok := gvaConfig["system"].(map[string]interface{}); ok {
+			system["use-redis"] = useRedis
+		} else {
+			// 如果 system 不存在，创建它
+			gvaConfig["system"] = map[string]interface{}{
+				"use-redis": useRedis,
+			}
+		}
+	})
+}
+
+// WriteRedis 写入 Redis 开关与连接配置
+func WriteRedis(root string, useRedis bool, addr, password string, db int) error {
+	return updateGVAConfig(root, func(gvaConfig map[string]interface{}) {
+		// 更新 system.use-redis
+		if system, ok := gvaConfig["system"].(map[string]interface{}); ok {
+			system["use-redis"] = useRedis
+		}
+
+		// 更新 redis 配置
+		if redis, ok := gvaConfig["redis"].(map[string]interface{}); ok {
+			redis["addr"] = addr
+			redis["password"] = password
+			redis["db"] = db
+		} else {
+			// 如果 redis 配置不存在，创建新的
+			gvaConfig["redis"] = map[string]interface{}{
+				"addr":     addr,
+				"password": password,
+				"db":       db,
+			}
+		}
+	})
+}
+
+// WriteMysql 把 system.db-type 设为 mysql 并写入 mysql 连接配置（db.Config 为空时保留原有的连接参数）
+func WriteMysql(root string, db GVADBConfig) error {
+	return updateGVAConfig(root, func(gvaConfig map[string]interface{}) {
+		if system, ok := gvaConfig["system"].(map[string]interface{}); ok {
+			system["db-type"] = "mysql"
+		} else {
+			gvaConfig["system"] = map[string]interface{}{"db-type": "mysql"}
+		}
+
+		mysql, ok := gvaConfig["mysql"].(map[string]interface{})
+		if !ok {
+			mysql = map[string]interface{}{}
+			gvaConfig["mysql"] = mysql
+		}
+		mysql["path"] = db.Path
+		mysql["port"] = db.Port
+		mysql["db-name"] = db.Dbname
+		mysql["username"] = db.Username
+		mysql["password"] = db.Password
+		if db.Config != "" {
+			mysql["config"] = db.Config
+		}
+	})
+}
+
+// DatabaseDSN 根据 db-type 生成数据库连接串
+func (c *GVAConfig) DatabaseDSN() (string, error) {
+	switch c.System.DbType {
+	case "", "mysql":
+		db := c.Mysql
+		if db.Path == "" {
+			return "", fmt.Errorf("config.yaml 中未配置 mysql 连接信息")
+		}
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", db.Username, db.Password, db.Path, db.Port, db.Dbname)
+		if db.Config != "" {
+			dsn += "?" + db.Config
+		}
+		return dsn, nil
+	case "pgsql":
+		db := c.Pgsql
+		if db.Path == "" {
+			return "", fmt.Errorf("config.yaml 中未配置 pgsql 连接信息")
+		}
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s", db.Path, db.Username, db.Password, db.Dbname, db.Port)
+		if db.Config != "" {
+			dsn += " " + db.Config
+		}
+		return dsn, nil
+	default:
+		return "", fmt.Errorf("暂不支持复制 %s 类型的数据库连接串", c.System.DbType)
+	}
+}
+
+// BackendLogDir 获取后端日志目录（zap.director，默认 log）
+func BackendLogDir(root string) string {
+	if root == "" {
+		return ""
+	}
+
+	director := "log"
+	if gvaConfig, err := ReadGVAConfig(root); err == nil && gvaConfig.Zap.Director != "" {
+		director = gvaConfig.Zap.Director
+	}
+
+	if filepath.IsAbs(director) {
+		return director
+	}
+	return filepath.Join(root, "server", director)
+}
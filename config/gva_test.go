@@ -0,0 +1,168 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleGVAConfig = `system:
+  addr: 8888
+  db-type: mysql
+  use-redis: false
+  router-prefix: ""
+redis:
+  addr: 127.0.0.1:6379
+  password: ""
+  db: 0
+mysql:
+  path: 127.0.0.1
+  port: "3306"
+  config: charset=utf8mb4&parseTime=True
+  db-name: gva
+  username: root
+  password: secret
+zap:
+  director: log
+jwt:
+  signing-key: keep-me
+`
+
+func TestReadGVAConfig(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.System.Addr != 8888 || cfg.System.DbType != "mysql" || cfg.Redis.Addr != "127.0.0.1:6379" || cfg.Mysql.Dbname != "gva" {
+		t.Errorf("解析结果不正确: %+v", cfg)
+	}
+
+	if _, err := ReadGVAConfig(""); err == nil {
+		t.Error("根目录为空时应返回错误")
+	}
+	if _, err := ReadGVAConfig(t.TempDir()); err == nil {
+		t.Error("配置文件不存在时应返回错误")
+	}
+}
+
+func TestWriteBackendPort(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+
+	if err := WriteBackendPort(root, 9999); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.System.Addr != 9999 {
+		t.Errorf("system.addr = %d, want 9999", cfg.System.Addr)
+	}
+
+	// 未知字段需要保留
+	if content := readFile(t, GVAConfigPath(root)); !strings.Contains(content, "signing-key: keep-me") {
+		t.Errorf("未知字段丢失:\n%s", content)
+	}
+
+	// 同时写入前端的后端端口
+	if got := findEnvPort(readFile(t, EnvDevPath(root)), "VITE_SERVER_PORT"); got != 9999 {
+		t.Errorf("VITE_SERVER_PORT = %d, want 9999", got)
+	}
+}
+
+func TestWriteRedis(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+
+	if err := WriteRedis(root, true, "10.0.0.1:6380", "pwd", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.System.UseRedis || cfg.Redis.Addr != "10.0.0.1:6380" || cfg.Redis.Password != "pwd" || cfg.Redis.DB != 3 {
+		t.Errorf("写入结果不正确: %+v", cfg.Redis)
+	}
+}
+
+func TestWriteUseRedisCreatesSystem(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), "redis:\n  addr: 127.0.0.1:6379\n")
+
+	if err := WriteUseRedis(root, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.System.UseRedis || cfg.Redis.Addr != "127.0.0.1:6379" {
+		t.Errorf("写入结果不正确: %+v", cfg)
+	}
+}
+
+func TestWriteMysql(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), strings.Replace(sampleGVAConfig, "db-type: mysql", "db-type: sqlite", 1))
+
+	if err := WriteMysql(root, GVADBConfig{Path: "127.0.0.1", Port: "13306", Dbname: "gva", Username: "root", Password: "dev"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.System.DbType != "mysql" || cfg.Mysql.Port != "13306" || cfg.Mysql.Password != "dev" {
+		t.Errorf("写入结果不正确: %+v", cfg.Mysql)
+	}
+	// 未指定连接参数时保留原有的
+	if cfg.Mysql.Config != "charset=utf8mb4&parseTime=True" {
+		t.Errorf("config = %q", cfg.Mysql.Config)
+	}
+}
+
+func TestDatabaseDSN(t *testing.T) {
+	var cfg GVAConfig
+	if _, err := cfg.DatabaseDSN(); err == nil {
+		t.Error("未配置 mysql 时应返回错误")
+	}
+
+	cfg.Mysql = GVADBConfig{Path: "127.0.0.1", Port: "3306", Config: "charset=utf8mb4", Dbname: "gva", Username: "root", Password: "pwd"}
+	dsn, err := cfg.DatabaseDSN()
+	if err != nil || dsn != "root:pwd@tcp(127.0.0.1:3306)/gva?charset=utf8mb4" {
+		t.Errorf("mysql dsn = %q, err = %v", dsn, err)
+	}
+
+	cfg.System.DbType = "pgsql"
+	cfg.Pgsql = GVADBConfig{Path: "db", Port: "5432", Dbname: "gva", Username: "postgres", Password: "pwd"}
+	dsn, err = cfg.DatabaseDSN()
+	if err != nil || dsn != "host=db user=postgres password=pwd dbname=gva port=5432" {
+		t.Errorf("pgsql dsn = %q, err = %v", dsn, err)
+	}
+
+	cfg.System.DbType = "sqlite"
+	if _, err := cfg.DatabaseDSN(); err == nil {
+		t.Error("不支持的数据库类型应返回错误")
+	}
+}
+
+func TestBackendLogDir(t *testing.T) {
+	root := newProject(t)
+	if got, want := BackendLogDir(root), filepath.Join(root, "server", "log"); got != want {
+		t.Errorf("默认日志目录 = %q, want %q", got, want)
+	}
+
+	writeFile(t, GVAConfigPath(root), "zap:\n  director: logs/app\n")
+	if got, want := BackendLogDir(root), filepath.Join(root, "server", "logs", "app"); got != want {
+		t.Errorf("自定义日志目录 = %q, want %q", got, want)
+	}
+}
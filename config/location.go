@@ -0,0 +1,210 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// 面板数据（配置、日志、备份、锁文件）默认保存在用户配置目录：
+//   - Windows: %AppData%\GVAPanel
+//   - macOS:   ~/Library/Application Support/GVAPanel
+//   - Linux:   $XDG_CONFIG_HOME/GVAPanel（默认 ~/.config/GVAPanel）
+//
+// 便携模式（--portable）沿用旧版行为，全部保存在可执行文件所在目录，适合放在 U 盘中使用。
+// 安装到 Program Files 或 /usr/local/bin 时程序目录通常不可写，因此默认不再使用程序目录。
+
+// appDirName 用户配置目录下的子目录名
+const appDirName = "GVAPanel"
+
+var (
+	locationMu sync.RWMutex
+	portable   bool
+	baseDir    string // 为空时按模式计算；测试中可通过 SetBaseDir 指定
+)
+
+// SetPortable 设置是否为便携模式（需在 Load 之前调用）
+func SetPortable(enabled bool) {
+	locationMu.Lock()
+	defer locationMu.Unlock()
+	portable = enabled
+}
+
+// Portable 是否为便携模式
+func Portable() bool {
+	locationMu.RLock()
+	defer locationMu.RUnlock()
+	return portable
+}
+
+// SetBaseDir 指定面板数据目录（为空时恢复默认位置）
+func SetBaseDir(dir string) {
+	locationMu.Lock()
+	defer locationMu.Unlock()
+	baseDir = dir
+}
+
+// Dir 获取面板数据目录
+func Dir() string {
+	locationMu.RLock()
+	dir, isPortable := baseDir, portable
+	locationMu.RUnlock()
+
+	if dir != "" {
+		return dir
+	}
+	if isPortable {
+		return getExeDir()
+	}
+	if userDir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(userDir, appDirName)
+	}
+	// 无法获取用户配置目录时回退到程序目录
+	return getExeDir()
+}
+
+// dataPath 便携模式沿用旧版文件名，其他情况使用用户配置目录下的新文件名
+func dataPath(portableName, name string) string {
+	if Portable() {
+		return filepath.Join(Dir(), portableName)
+	}
+	return filepath.Join(Dir(), name)
+}
+
+// getExeDir 获取可执行文件所在目录
+func getExeDir() string {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "."
+	}
+	return filepath.Dir(exePath)
+}
+
+// Path 获取配置文件路径
+func Path() string {
+	return dataPath(".gva-launcher.json", "gva-launcher.json")
+}
+
+// LockPath 获取单实例锁文件路径
+func LockPath() string {
+	return dataPath(".gva-launcher.lock", "gva-launcher.lock")
+}
+
+// WatchdogLockPath 获取看守模式的单实例锁文件路径（与面板窗口的锁互不影响）
+func WatchdogLockPath() string {
+	return dataPath(".gva-launcher-watchdog.lock", "watchdog.lock")
+}
+
+// PIDPath 获取后台运行的服务（backend / frontend）的 PID 文件路径，与配置文件放在同一目录
+func PIDPath(service string) string {
+	return dataPath(".gva-launcher-"+service+".pid", service+".pid")
+}
+
+// DetachedLogPath 获取后台运行的服务的输出文件路径（面板退出后服务继续写入）
+func DetachedLogPath(service string) string {
+	return filepath.Join(LogDir(), service+"-detached.log")
+}
+
+// RenderStatePath 获取窗口渲染状态文件路径（记录上次启动的渲染初始化是否成功、是否改用软件渲染）
+func RenderStatePath() string {
+	return dataPath(".gva-launcher-render", "render")
+}
+
+// LogDir 获取面板自身的日志目录（任务、钩子执行日志）
+func LogDir() string {
+	return dataPath("gva-launcher-logs", "logs")
+}
+
+// CachePath 获取环境信息缓存文件路径（npm 镜像源、GOPROXY、屏幕分辨率等）
+func CachePath() string {
+	return dataPath(".gva-launcher-cache.json", "cache.json")
+}
+
+// DurationsPath 获取任务耗时记录文件路径（估计安装依赖、清理缓存等任务的剩余时间）
+func DurationsPath() string {
+	return dataPath(".gva-launcher-durations.json", "durations.json")
+}
+
+// CrashDir 获取崩溃报告目录
+func CrashDir() string {
+	return dataPath("gva-launcher-crashes", "crashes")
+}
+
+// BackupDir 获取配置备份目录
+func BackupDir() string {
+	return dataPath("gva-launcher-backups", "backups")
+}
+
+// DeployCertDir 获取部署证书目录（导入的 TLS 证书和私钥，每个证书一个子目录）
+func DeployCertDir() string {
+	return dataPath("gva-launcher-deploy-certs", "deploy-certs")
+}
+
+// RemoteLogDir 获取远程日志缓存目录（每个服务器日志文件一个 gzip 文件）
+func RemoteLogDir() string {
+	return dataPath("gva-launcher-remote-logs", "remote-logs")
+}
+
+// SSHKeyDir 获取 SSH 密钥目录（每个密钥一个子目录，私钥加密保存）
+func SSHKeyDir() string {
+	return dataPath("gva-launcher-ssh-keys", "ssh-keys")
+}
+
+// DBSnapshotDir 获取演示模式数据库快照目录（每个项目一个子目录）
+func DBSnapshotDir() string {
+	return dataPath("gva-launcher-db-snapshots", "db-snapshots")
+}
+
+// TrashDir 获取回收站索引目录（清理缓存时移入回收站的内容保存在原位置所在磁盘上，见 trash 包）
+func TrashDir() string {
+	return dataPath("gva-launcher-trash", "trash")
+}
+
+// FingerprintDir 获取导出的环境指纹目录
+func FingerprintDir() string {
+	return dataPath("gva-launcher-fingerprints", "fingerprints")
+}
+
+// BinCacheDir 获取编译模式下后端编译产物的缓存目录（每个项目一个子目录）
+func BinCacheDir() string {
+	return dataPath("gva-launcher-bin", "bin")
+}
+
+// CertDir 获取本地 HTTPS 证书目录（根证书和签发的站点证书）
+func CertDir() string {
+	return dataPath("gva-launcher-certs", "certs")
+}
+
+// legacyPath 旧版（程序目录）配置文件路径（测试中可替换）
+var legacyPath = func() string {
+	return filepath.Join(getExeDir(), ".gva-launcher.json")
+}
+
+// migrateLegacyConfig 把程序目录中的旧配置迁移到用户配置目录
+// 只在新位置还没有配置时迁移；迁移后旧文件改名为 .migrated，避免重复迁移
+func migrateLegacyConfig() {
+	if Portable() {
+		return
+	}
+
+	oldPath, newPath := legacyPath(), Path()
+	if oldPath == newPath {
+		return
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return
+	}
+
+	data, err := os.ReadFile(oldPath)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return
+	}
+	if err := os.WriteFile(newPath, data, 0644); err != nil {
+		return
+	}
+	// 程序目录不可写时改名失败也无妨，新位置已存在配置，不会再次迁移
+	os.Rename(oldPath, oldPath+".migrated")
+}
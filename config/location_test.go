@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useDirs 让配置保存到临时目录，旧版配置位于 legacyDir
+func useDirs(t *testing.T, legacyDir string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "GVAPanel")
+	SetBaseDir(dir)
+	originalLegacy := legacyPath
+	legacyPath = func() string { return filepath.Join(legacyDir, ".gva-launcher.json") }
+	t.Cleanup(func() {
+		SetBaseDir("")
+		SetPortable(false)
+		legacyPath = originalLegacy
+	})
+	return dir
+}
+
+func TestLoadMigratesLegacyConfig(t *testing.T) {
+	legacyDir := t.TempDir()
+	dir := useDirs(t, legacyDir)
+	writeFile(t, filepath.Join(legacyDir, ".gva-launcher.json"), `{"gva_root_path":"D:/gva"}`)
+
+	cfg := Load()
+	if cfg.GVARootPath != "D:/gva" {
+		t.Errorf("迁移后 GVARootPath = %q", cfg.GVARootPath)
+	}
+	if Path() != filepath.Join(dir, "gva-launcher.json") {
+		t.Errorf("Path = %q", Path())
+	}
+	if _, err := os.Stat(Path()); err != nil {
+		t.Errorf("新位置应存在配置文件: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(legacyDir, ".gva-launcher.json.migrated")); err != nil {
+		t.Errorf("旧配置应改名为 .migrated: %v", err)
+	}
+}
+
+func TestLoadKeepsExistingConfig(t *testing.T) {
+	legacyDir := t.TempDir()
+	useDirs(t, legacyDir)
+	writeFile(t, filepath.Join(legacyDir, ".gva-launcher.json"), `{"gva_root_path":"old"}`)
+	if err := Save(Config{GVARootPath: "new"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg := Load(); cfg.GVARootPath != "new" {
+		t.Errorf("新位置已有配置时不应迁移, got %q", cfg.GVARootPath)
+	}
+}
+
+func TestPortableLayout(t *testing.T) {
+	dir := useDirs(t, t.TempDir())
+	SetPortable(true)
+
+	if got := Path(); got != filepath.Join(dir, ".gva-launcher.json") {
+		t.Errorf("Path = %q", got)
+	}
+	if got := LogDir(); got != filepath.Join(dir, "gva-launcher-logs") {
+		t.Errorf("LogDir = %q", got)
+	}
+
+	SetPortable(false)
+	if got := LogDir(); got != filepath.Join(dir, "logs") {
+		t.Errorf("LogDir = %q", got)
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import "time"
+
+// 低资源模式（树莓派等 ARM 单板机）：
+//   - 所有等待时间与超时延长为 LowResourceFactor 倍（go run 编译、Vite 启动都慢得多）
+//   - 看守模式降低检查频率
+//   - 存在预编译的后端（「构建」生成的 server/gva-server）且比源码新时直接运行，不再 go run
+//   - 安装前端依赖前检查内存，不足时提示
+const LowResourceFactor = 3
+
+// Scaled 按倍数延长所有等待时间（未设置的项在默认值的基础上延长）
+func (t Timeouts) Scaled(factor int) Timeouts {
+	ms := func(d time.Duration) int { return int(d.Milliseconds()) * factor }
+	return Timeouts{
+		StartReadyMs:     ms(t.StartReady()),
+		VueRestartWaitMs: ms(t.VueRestartWait()),
+		StopWaitMs:       ms(t.StopWait()),
+		MonitorWindowMs:  ms(t.MonitorWindow()),
+		RedisDialMs:      ms(t.RedisDial()),
+		SmokeTestMs:      ms(t.SmokeTest()),
+	}
+}
+
+// EffectiveTimeouts 实际使用的等待时间（低资源模式下延长，配置文件中保存的仍是原值）
+func (c Config) EffectiveTimeouts() Timeouts {
+	if c.LowResource {
+		return c.Timeouts.Scaled(LowResourceFactor)
+	}
+	return c.Timeouts
+}
@@ -0,0 +1,275 @@
+// Package config 负责面板自身配置（gva-launcher.json）以及 GVA 项目配置文件的读写
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"gva-launcher/apperr"
+)
+
+// Config 配置结构（简化版）
+type Config struct {
+	GVARootPath    string          `json:"gva_root_path"`       // GVA 安装目录
+	Hooks          []Hook          `json:"hooks,omitempty"`     // 事件钩子脚本
+	Schedules      []ScheduledTask `json:"schedules,omitempty"` // 定时任务
+	Timeouts       Timeouts        `json:"timeouts"`            // 等待时间与超时
+	Watchdog       Watchdog        `json:"watchdog"`            // 看守模式
+	AutoRestart    bool            `json:"auto_restart"`        // 面板运行时服务意外退出后自动重启（指数退避）
+	Detached       bool            `json:"detached"`            // 服务脱离面板运行（退出面板后继续运行，下次启动时重新连接）
+	Metrics        Metrics         `json:"metrics"`             // 状态导出接口
+	Proxy          Proxy           `json:"proxy"`               // 单端口访问代理
+	Tunnel         Tunnel          `json:"tunnel"`              // 外网穿透
+	Projects       []ProjectPorts  `json:"projects,omitempty"`  // 管理过的项目及其端口（发现项目之间的端口冲突）
+	PortRange      PortRange       `json:"port_range"`          // 自动分配端口的扫描范围
+	Hostname       string          `json:"hostname,omitempty"`  // 局域网访问使用的主机名（已写入 hosts，例如 gva.local）
+	Servers        []Server        `json:"servers,omitempty"`   // 登记的远程服务器
+	RemoteLog      RemoteLog       `json:"remote_log"`          // 远程日志查看
+	Network        Network         `json:"network"`             // 面板发起的下载使用的代理和镜像
+	MDNS           MDNS            `json:"mdns"`                // 通过 mDNS 在局域网中广播前端地址
+	GVARelease     GVARelease      `json:"gva_release"`         // 上游 GVA 新版本提醒
+	DepReport      DepReport       `json:"dep_report"`          // 依赖周报
+	SMTP           SMTP            `json:"smtp"`                // 发送通知邮件的 SMTP 服务器
+	SmokeTest      SmokeTest       `json:"smoke_test"`          // 启动后的冒烟测试
+	LowResource    bool            `json:"low_resource"`        // 低资源模式（树莓派等 ARM 单板机，见 lowresource.go）
+	CompiledRun    bool            `json:"compiled_run"`        // 后端先编译到缓存目录再运行（代替 go run）
+	ProductionMode bool            `json:"production_mode"`     // 生产模式：前端构建到 server/dist 后只启动后端，由后端提供页面
+	CDN            CDN             `json:"cdn"`                 // 生产构建后上传静态资源
+	Deploy         Deploy          `json:"deploy"`              // 部署到远程服务器（版本目录与回滚）
+	Registry       Registry        `json:"registry"`            // 构建并推送项目镜像的镜像仓库
+	Kube           Kube            `json:"kube"`                // 生成 Kubernetes 清单 / Helm values 的设置
+	Compose        Compose         `json:"compose"`             // 用 docker compose 运行的本地依赖（MySQL、Redis）
+	HighContrast   bool            `json:"high_contrast"`       // 高对比度主题，状态用形状符号和文字徽标表示（不依赖颜色区分）
+	Priority       Priority        `json:"priority"`            // 服务进程和构建命令的优先级
+	AutoStart      bool            `json:"auto_start"`          // 打开面板时检测依赖并自动启动前后端服务（演示机器）
+	IdleMinutes    int             `json:"idle_minutes"`        // 连续多少分钟没有访问时自动停止服务（0 为不停止）
+	Instances      []string        `json:"instances,omitempty"` // 同时管理的其他 GVA 项目的根目录（多实例）
+	Removed        []string        `json:"removed,omitempty"`   // 从多实例中移除的项目根目录（空间清理时列出遗留的依赖和构建产物）
+	SysService     SysService      `json:"sys_service"`         // 把后端注册为系统服务时使用的服务名和运行用户
+	LogLines       int             `json:"log_lines,omitempty"` // 服务输出面板保留的行数（0 表示 DefaultLogLines）
+}
+
+// DefaultLogLines 服务输出面板默认保留的行数
+const DefaultLogLines = 2000
+
+// EffectiveLogLines 服务输出面板实际保留的行数
+func (c Config) EffectiveLogLines() int {
+	if c.LogLines <= 0 {
+		return DefaultLogLines
+	}
+	return c.LogLines
+}
+
+// Hook 绑定到事件的用户脚本（Command 交给系统 shell 执行）
+type Hook struct {
+	Event   string `json:"event"`   // 事件名，例如 before-start
+	Command string `json:"command"` // 命令行，例如 scripts/notify.sh
+}
+
+// CDN 生产构建后把前端静态资源上传到对象存储（凭据使用项目 config.yaml 中后端的存储配置）
+type CDN struct {
+	Enabled bool   `json:"enabled"`           // 生产构建后上传，并按存储的访问地址构建（vite build --base）
+	Storage string `json:"storage,omitempty"` // 使用的存储：aws-s3、aliyun-oss、tencent-cos、minio
+	Prefix  string `json:"prefix,omitempty"`  // 存储中的路径前缀（为空时为 gva-web）
+}
+
+// Deploy 把生产构建的产物部署到登记的远程服务器（每次部署一个版本目录，current 链接指向当前版本）
+type Deploy struct {
+	Server  string `json:"server,omitempty"`  // 登记的远程服务器名称
+	Dir     string `json:"dir,omitempty"`     // 服务器上的部署目录，例如 /opt/gva
+	Arch    string `json:"arch,omitempty"`    // 服务器的 CPU 架构（GOARCH，为空时为 amd64）
+	Restart string `json:"restart,omitempty"` // 切换版本后在服务器上执行的命令，例如 systemctl restart gva
+	Keep    int    `json:"keep,omitempty"`    // 保留的版本数（0 表示 5 个）
+
+	HealthURL     string `json:"health_url,omitempty"`     // 部署后的健康检查地址（为空时不检查）
+	HealthRemote  bool   `json:"health_remote,omitempty"`  // 在服务器上通过 ssh 执行 curl 检查
+	HealthTimeout int    `json:"health_timeout,omitempty"` // 等待健康的秒数（0 表示 60 秒）
+	AutoRollback  bool   `json:"auto_rollback,omitempty"`  // 不健康时自动回滚到上一个版本
+}
+
+// Registry 推送项目镜像的镜像仓库（密码与单端口代理的密码一样保存在面板配置中）
+type Registry struct {
+	Kind      string   `json:"kind,omitempty"`      // dockerhub、aliyun-acr 或 harbor
+	Server    string   `json:"server,omitempty"`    // 仓库地址（Docker Hub 和阿里云 ACR 可留空）
+	Namespace string   `json:"namespace,omitempty"` // 命名空间 / 项目
+	Username  string   `json:"username,omitempty"`
+	Password  string   `json:"password,omitempty"`
+	Skip      []string `json:"skip,omitempty"`     // 不推送的镜像，例如 gva-web
+	LastTag   string   `json:"last_tag,omitempty"` // 上次推送的标签
+}
+
+// Kube 生成 Kubernetes 清单或 Helm values.yaml 的设置（端口和镜像从项目配置与镜像仓库设置中读取）
+type Kube struct {
+	Namespace string `json:"namespace,omitempty"`  // 命名空间
+	Host      string `json:"host,omitempty"`       // Ingress 域名（为空时不生成 Ingress）
+	TLSSecret string `json:"tls_secret,omitempty"` // Ingress 使用的证书 Secret
+	Replicas  int    `json:"replicas,omitempty"`   // 副本数（0 表示 1）
+	Env       string `json:"env,omitempty"`        // 后端容器的环境变量（每行一个 KEY=VALUE）
+	Helm      bool   `json:"helm,omitempty"`       // 生成 Helm values.yaml 而不是清单
+}
+
+// Compose 用 docker compose 只运行本地依赖（MySQL、Redis）的设置（0 / 空表示使用默认值）
+type Compose struct {
+	MySQLPort     int    `json:"mysql_port,omitempty"`     // 映射到本机的 MySQL 端口
+	RedisPort     int    `json:"redis_port,omitempty"`     // 映射到本机的 Redis 端口
+	MySQLPassword string `json:"mysql_password,omitempty"` // MySQL root 密码
+	Database      string `json:"database,omitempty"`       // 自动创建的数据库
+	SkipMySQL     bool   `json:"skip_mysql,omitempty"`     // 启动后不把 MySQL 连接写入 config.yaml（空库需要先在前端初始化）
+}
+
+// Priority 进程优先级（sysutil.Priority 的值，空表示正常优先级）
+type Priority struct {
+	Services string `json:"services,omitempty"` // 前后端服务进程
+	Builds   string `json:"builds,omitempty"`   // 构建命令（go build / npm run build，包括编译模式下编译后端）
+}
+
+// SysService 把构建好的后端注册为系统服务（systemd / launchd / Windows 服务）
+// 是否已注册以系统中的服务为准，不保存在配置中
+type SysService struct {
+	Name string `json:"name,omitempty"` // 服务名（为空时为 gva-<根目录名>）
+	User string `json:"user,omitempty"` // Linux / macOS 上运行服务的用户（为空时为当前用户）
+}
+
+// Watchdog 看守模式（--watchdog，无窗口）需要保持运行的服务
+// 登录自启动是否开启以系统中的自启动入口为准，不保存在配置中
+type Watchdog struct {
+	Backend  bool `json:"backend"`  // 保持后端运行
+	Frontend bool `json:"frontend"` // 保持前端运行
+}
+
+// Metrics 状态导出接口（Prometheus /metrics 与 JSON /status）
+type Metrics struct {
+	Addr string `json:"addr,omitempty"` // 监听地址，例如 127.0.0.1:9531（为空时不开启）
+}
+
+// Proxy 单端口访问代理（同一端口转发前端页面和后端 API）
+type Proxy struct {
+	Addr     string `json:"addr,omitempty"`     // 监听地址，例如 0.0.0.0:8800（为空时不开启）
+	Auth     string `json:"auth,omitempty"`     // 访问保护：空（不保护）、basic（用户名密码）或 token（访问令牌）
+	Username string `json:"username,omitempty"` // basic 的用户名
+	Password string `json:"password,omitempty"` // basic 的密码
+	Token    string `json:"token,omitempty"`    // token 的访问令牌（附加在分享链接中）
+}
+
+// Tunnel 外网穿透客户端设置
+type Tunnel struct {
+	Provider       string `json:"provider,omitempty"`   // cloudflared、ngrok、frp 或 custom
+	Command        string `json:"command,omitempty"`    // custom 时执行的命令行（{port}、{url} 替换为前端端口和地址）
+	FrpConfig      string `json:"frp_config,omitempty"` // frp 时 frpc 的配置文件路径
+	PublicURL      string `json:"public_url,omitempty"` // 客户端不输出公网地址时（frp 等）手动填写
+	FollowFrontend bool   `json:"follow_frontend"`      // 随前端服务启动和停止
+}
+
+// SmokeTest 启动后自动执行的冒烟测试（内置检查见 smoketest 包）
+type SmokeTest struct {
+	Disabled bool         `json:"disabled"`         // 启动后不自动执行
+	Skip     []string     `json:"skip,omitempty"`   // 跳过的内置检查，例如 websocket
+	Custom   []SmokeCheck `json:"custom,omitempty"` // 额外检查的地址
+}
+
+// SmokeCheck 自定义的冒烟测试项：请求 URL 并检查状态码
+type SmokeCheck struct {
+	Name   string `json:"name"`             // 显示名称
+	URL    string `json:"url"`              // 地址，{backend}、{frontend} 替换为后端 API 根地址和前端地址
+	Status int    `json:"status,omitempty"` // 期望的状态码（默认 200）
+}
+
+// GVARelease 上游 gin-vue-admin 新版本提醒
+type GVARelease struct {
+	Disabled  bool   `json:"disabled"`            // 不检查新版本
+	Dismissed string `json:"dismissed,omitempty"` // 不再提醒的版本（有更新的版本时重新提醒）
+}
+
+// DepReport 依赖周报（每周生成由「依赖周报」定时任务负责，见 depreport.ActionID）
+type DepReport struct {
+	Email      bool     `json:"email"`                 // 生成后通过 SMTP 发送邮件
+	KnownVulns []string `json:"known_vulns,omitempty"` // 上次报告中的漏洞（区分新出现的漏洞）
+}
+
+// SMTP 发送通知邮件的服务器（密码与镜像仓库的密码一样保存在面板配置中）
+type SMTP struct {
+	Host     string   `json:"host,omitempty"`
+	Port     int      `json:"port,omitempty"` // 0 表示 587；465 使用 SSL 直连
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"` // 密码或授权码
+	From     string   `json:"from,omitempty"`     // 发件人（为空时使用用户名）
+	To       []string `json:"to,omitempty"`       // 收件人
+}
+
+// MDNS 通过 mDNS（Bonjour）在局域网中广播前端地址
+type MDNS struct {
+	Enabled bool   `json:"enabled"`        // 是否广播
+	Name    string `json:"name,omitempty"` // 主机名（不含 .local，为空时为 gva-panel）
+}
+
+// Network 面板发起的下载（自更新等）使用的网络设置
+type Network struct {
+	HTTPProxy string   `json:"http_proxy,omitempty"` // HTTP 代理，例如 http://127.0.0.1:7890（为空时使用 HTTPS_PROXY / HTTP_PROXY 环境变量）
+	Mirrors   []string `json:"mirrors,omitempty"`    // GitHub 下载镜像前缀，例如 https://ghfast.top/（GitHub 访问失败时依次尝试）
+}
+
+// RemoteLog 远程服务器日志（通过 SSH 查看，需已配置密钥登录）
+type RemoteLog struct {
+	Host    string `json:"host,omitempty"`    // 服务器地址
+	Port    int    `json:"port,omitempty"`    // SSH 端口（0 表示 22）
+	User    string `json:"user,omitempty"`    // 用户名
+	Path    string `json:"path,omitempty"`    // 日志文件路径
+	Pattern string `json:"pattern,omitempty"` // 服务器端过滤的关键字（扩展正则）
+	Key     string `json:"key,omitempty"`     // 使用的面板 SSH 密钥名称（为空时使用 ssh 的默认密钥）
+}
+
+// ScheduledTask 定时任务（Cron 为 5 段 cron 表达式或 @daily 等快捷写法）
+type ScheduledTask struct {
+	Name    string `json:"name"`              // 任务名（唯一）
+	Cron    string `json:"cron"`              // 执行时间，例如 "0 2 * * *"
+	Action  string `json:"action"`            // 动作，例如 dep-audit、cache-prune、backup、build、command
+	Command string `json:"command,omitempty"` // action 为 command 时执行的命令行
+	Enabled bool   `json:"enabled"`
+}
+
+// Default 获取默认配置（仅在第一次启动或配置文件不存在时调用）
+func Default() Config {
+	return Config{
+		GVARootPath: "", // GVA 安装目录（用户选择后保存）
+	}
+}
+
+// Load 加载配置（配置文件不存在或解析失败时创建默认配置并立即保存）
+// 非便携模式下，首次加载时会把程序目录中的旧配置迁移到用户配置目录
+func Load() Config {
+	migrateLegacyConfig()
+
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		// 配置文件不存在，创建默认配置
+		cfg := Default()
+		Save(cfg) // 立即保存配置文件
+		return cfg
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		// JSON 解析失败，重新创建默认配置
+		cfg = Default()
+		Save(cfg) // 立即保存配置文件
+	}
+	return cfg
+}
+
+// Save 保存配置
+func Save(cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "序列化配置失败: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(Path()), 0755); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "创建配置目录失败: %w", err)
+	}
+	old, _ := os.ReadFile(Path())
+	if err := os.WriteFile(Path(), data, 0644); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入配置文件失败: %w", err)
+	}
+	recordPanel(old, data)
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gva-launcher/apperr"
+)
+
+// PortRange 自动分配端口时扫描的范围（0 表示使用默认值）
+type PortRange struct {
+	Start int `json:"start,omitempty"` // 起始端口（含）
+	End   int `json:"end,omitempty"`   // 结束端口（含）
+}
+
+// 自动分配端口的默认范围
+const (
+	DefaultPortRangeStart = 8000
+	DefaultPortRangeEnd   = 8999
+)
+
+// Bounds 扫描范围（未设置或不合法时返回默认范围）
+func (r PortRange) Bounds() (start, end int) {
+	if r.Start < 1 || r.End > 65535 || r.Start > r.End {
+		return DefaultPortRangeStart, DefaultPortRangeEnd
+	}
+	return r.Start, r.End
+}
+
+// writeFrontendPort 写入前端端口（测试中可替换）
+var writeFrontendPort = WriteFrontendPort
+
+// WritePorts 在一次事务中写入前后端端口（config.yaml、.env、.env.development）：
+// 写入前备份涉及的文件，任一步失败时全部还原，避免前后端配置不一致
+func WritePorts(root string, backendPort, frontendPort int) error {
+	if root == "" {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+
+	paths := []string{GVAConfigPath(root), filepath.Join(root, "web", ".env"), EnvDevPath(root)}
+	backup := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			backup[path] = data
+		} else if !os.IsNotExist(err) {
+			return apperr.Errorf(apperr.CfgReadFailed, "读取 %s 失败: %v", filepath.Base(path), err)
+		}
+	}
+
+	err := WriteBackendPort(root, backendPort)
+	if err == nil {
+		err = writeFrontendPort(root, frontendPort)
+	}
+	if err != nil {
+		restoreFiles(paths, backup)
+		return err
+	}
+	return nil
+}
+
+// restoreFiles 还原备份的文件（备份时不存在的文件删除）
+func restoreFiles(paths []string, backup map[string][]byte) {
+	for _, path := range paths {
+		if data, ok := backup[path]; ok {
+			writeProjectFile(path, data)
+		} else if old, err := os.ReadFile(path); err == nil {
+			os.Remove(path)
+			recordFile(path, old, nil)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestPortRangeBounds(t *testing.T) {
+	cases := []struct {
+		r          PortRange
+		start, end int
+	}{
+		{PortRange{}, DefaultPortRangeStart, DefaultPortRangeEnd},
+		{PortRange{Start: 10000, End: 10100}, 10000, 10100},
+		{PortRange{Start: 9000, End: 8000}, DefaultPortRangeStart, DefaultPortRangeEnd},
+		{PortRange{Start: 60000, End: 70000}, DefaultPortRangeStart, DefaultPortRangeEnd},
+	}
+	for _, c := range cases {
+		if start, end := c.r.Bounds(); start != c.start || end != c.end {
+			t.Errorf("%+v: got %d-%d, want %d-%d", c.r, start, end, c.start, c.end)
+		}
+	}
+}
+
+func TestWritePorts(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+
+	if err := WritePorts(root, 8001, 8002); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := readFile(t, EnvDevPath(root))
+	if cfg.System.Addr != 8001 || findEnvPort(env, "VITE_SERVER_PORT") != 8001 || ReadFrontendPort(root) != 8002 {
+		t.Errorf("addr = %d, env:\n%s", cfg.System.Addr, env)
+	}
+}
+
+func TestWritePortsRollback(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+	writeFile(t, EnvDevPath(root), "VITE_CLI_PORT=8080\nVITE_SERVER_PORT=8888\n")
+	// 后端端口已写入，写入前端端口时失败
+	writeFrontendPort = func(string, int) error { return errors.New("disk full") }
+	defer func() { writeFrontendPort = WriteFrontendPort }()
+
+	if err := WritePorts(root, 8001, 8002); err == nil {
+		t.Fatal("写入前端端口失败时应返回错误")
+	}
+	if content := readFile(t, GVAConfigPath(root)); content != sampleGVAConfig {
+		t.Errorf("config.yaml 未还原:\n%s", content)
+	}
+	if content := readFile(t, EnvDevPath(root)); content != "VITE_CLI_PORT=8080\nVITE_SERVER_PORT=8888\n" {
+		t.Errorf(".env.development 未还原:\n%s", content)
+	}
+}
+
+func TestWritePortsRollbackRemovesCreatedFiles(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+
+	writeFrontendPort = func(string, int) error { return errors.New("disk full") }
+	defer func() { writeFrontendPort = WriteFrontendPort }()
+
+	if err := WritePorts(root, 8001, 8002); err == nil {
+		t.Fatal("写入前端端口失败时应返回错误")
+	}
+	// 写入后端端口时新建的 .env.development 应被删除
+	if _, err := os.Stat(EnvDevPath(root)); !os.IsNotExist(err) {
+		t.Errorf(".env.development 应被删除, err = %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// ProjectPorts 面板管理过的项目及其端口（在选择项目、修改端口时登记），
+// 用于在启动之前发现不同项目之间的端口冲突
+type ProjectPorts struct {
+	Root         string `json:"root"`          // GVA 根目录
+	BackendPort  int    `json:"backend_port"`  // 后端端口
+	FrontendPort int    `json:"frontend_port"` // 前端端口
+}
+
+// Ports 项目使用的端口（未读取到的端口不返回）
+func (p ProjectPorts) Ports() []int {
+	var ports []int
+	for _, port := range []int{p.BackendPort, p.FrontendPort} {
+		if port > 0 {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// PortConflict 与其他项目冲突的端口
+type PortConflict struct {
+	Port    int          // 冲突的端口
+	Project ProjectPorts // 同样使用该端口的项目
+}
+
+// SameRoot 两个根目录是否是同一个项目（Windows 上不区分大小写）
+func SameRoot(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// RegisterProject 登记项目的端口（已登记时更新），同时移除根目录已不存在的项目；
+// changed 为 false 时登记表没有变化，无需保存
+func RegisterProject(list []ProjectPorts, p ProjectPorts) (updated []ProjectPorts, changed bool) {
+	p.Root = filepath.Clean(p.Root)
+	found := false
+	for _, existing := range list {
+		switch {
+		case SameRoot(existing.Root, p.Root):
+			found = true
+			changed = changed || existing != p
+			updated = append(updated, p)
+		case !sysutil.DirExists(existing.Root):
+			changed = true
+		default:
+			updated = append(updated, existing)
+		}
+	}
+	if !found {
+		updated = append(updated, p)
+		changed = true
+	}
+	return updated, changed
+}
+
+// PortConflicts 返回 p 与登记表中其他项目冲突的端口
+func PortConflicts(list []ProjectPorts, p ProjectPorts) []PortConflict {
+	var conflicts []PortConflict
+	for _, other := range list {
+		if SameRoot(other.Root, p.Root) {
+			continue
+		}
+		for _, port := range p.Ports() {
+			for _, otherPort := range other.Ports() {
+				if port == otherPort {
+					conflicts = append(conflicts, PortConflict{Port: port, Project: other})
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// OtherProjectPorts 登记表中除 root 之外的项目使用的所有端口（自动分配端口时避开）
+func OtherProjectPorts(list []ProjectPorts, root string) []int {
+	var ports []int
+	for _, other := range list {
+		if !SameRoot(other.Root, root) {
+			ports = append(ports, other.Ports()...)
+		}
+	}
+	return ports
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterProject(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	gone := filepath.Join(t.TempDir(), "deleted")
+
+	list, changed := RegisterProject(nil, ProjectPorts{Root: a, BackendPort: 8888, FrontendPort: 8080})
+	if !changed || len(list) != 1 {
+		t.Fatalf("list = %+v, changed = %v", list, changed)
+	}
+
+	// 相同端口再次登记时不需要保存
+	if _, changed := RegisterProject(list, ProjectPorts{Root: a + string(filepath.Separator), BackendPort: 8888, FrontendPort: 8080}); changed {
+		t.Error("登记内容没有变化时 changed 应为 false")
+	}
+
+	// 更新端口，并移除根目录已不存在的项目
+	list = append(list, ProjectPorts{Root: gone, BackendPort: 9000})
+	list, changed = RegisterProject(list, ProjectPorts{Root: b, BackendPort: 8889, FrontendPort: 8081})
+	list, _ = RegisterProject(list, ProjectPorts{Root: a, BackendPort: 8890, FrontendPort: 8080})
+	want := []ProjectPorts{
+		{Root: a, BackendPort: 8890, FrontendPort: 8080},
+		{Root: b, BackendPort: 8889, FrontendPort: 8081},
+	}
+	if !changed || !reflect.DeepEqual(list, want) {
+		t.Errorf("list = %+v, want %+v", list, want)
+	}
+}
+
+func TestPortConflicts(t *testing.T) {
+	list := []ProjectPorts{
+		{Root: "/gva/a", BackendPort: 8888, FrontendPort: 8080},
+		{Root: "/gva/b", BackendPort: 8889, FrontendPort: 8888},
+		{Root: "/gva/c", BackendPort: 9000, FrontendPort: 9001},
+	}
+
+	got := PortConflicts(list, list[0])
+	want := []PortConflict{{Port: 8888, Project: list[1]}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got := PortConflicts(list, list[2]); len(got) != 0 {
+		t.Errorf("没有冲突时应返回空列表, got %+v", got)
+	}
+
+	if got := OtherProjectPorts(list, "/gva/a"); !reflect.DeepEqual(got, []int{8889, 8888, 9000, 9001}) {
+		t.Errorf("OtherProjectPorts = %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Server 登记的远程服务器（远程日志、网络唤醒等功能使用）
+type Server struct {
+	Name      string `json:"name"`                // 名称（唯一）
+	Host      string `json:"host"`                // 地址或 ssh 配置中的主机别名
+	Port      int    `json:"port,omitempty"`      // SSH 端口（0 表示 22）
+	User      string `json:"user,omitempty"`      // 用户名
+	Key       string `json:"key,omitempty"`       // 使用的面板 SSH 密钥名称（为空时使用 ssh 的默认密钥）
+	MAC       string `json:"mac,omitempty"`       // 网卡 MAC 地址（为空时不支持网络唤醒）
+	Broadcast string `json:"broadcast,omitempty"` // 唤醒包的广播地址（为空时使用 255.255.255.255）
+}
+
+// SSHAddr SSH 端口的连接地址（host:port），用于检测服务器是否可连接
+func (s Server) SSHAddr() string {
+	port := s.Port
+	if port <= 0 {
+		port = 22
+	}
+	return net.JoinHostPort(strings.Trim(strings.TrimSpace(s.Host), "[]"), strconv.Itoa(port))
+}
+
+// FindServer 按名称查找登记的服务器
+func FindServer(servers []Server, name string) (Server, bool) {
+	for _, s := range servers {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Server{}, false
+}
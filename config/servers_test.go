@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestServerSSHAddr(t *testing.T) {
+	cases := []struct {
+		server Server
+		want   string
+	}{
+		{Server{Host: "192.168.1.20"}, "192.168.1.20:22"},
+		{Server{Host: " nas.local ", Port: 2222}, "nas.local:2222"},
+		{Server{Host: "[fd00::20]"}, "[fd00::20]:22"},
+	}
+	for _, c := range cases {
+		if got := c.server.SSHAddr(); got != c.want {
+			t.Errorf("%+v: got %q, want %q", c.server, got, c.want)
+		}
+	}
+}
+
+func TestFindServer(t *testing.T) {
+	servers := []Server{{Name: "nas", Host: "192.168.1.20"}, {Name: "prod", Host: "gva.example.com"}}
+	if s, ok := FindServer(servers, "prod"); !ok || s.Host != "gva.example.com" {
+		t.Errorf("FindServer(prod) = %+v, %v", s, ok)
+	}
+	if _, ok := FindServer(servers, "missing"); ok {
+		t.Error("不存在的服务器不应找到")
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gva-launcher/apperr"
+)
+
+// RouterPath 获取后端注册路由的文件路径（server/initialize/router.go）
+func RouterPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, "server", "initialize", "router.go")
+}
+
+// StaticDistDir 生产模式下前端构建产物的目录（server/dist，后端以工作目录下的 ./dist 提供页面）
+func StaticDistDir(root string) string {
+	return filepath.Join(root, "server", "dist")
+}
+
+// staticRoutePattern 上游 router.go 中注释掉的静态文件路由，例如
+// // Router.StaticFile("/", "./dist/index.html") // 前端网页入口页面
+// （LoadHTMLGlob 在 dist 不存在时会让后端启动失败，不在此列）
+var staticRoutePattern = regexp.MustCompile(`(?m)^([ \t]*)//[ \t]*(Router\.(?:Static|StaticFile)\("[^"]*",\s*"\./dist[^"]*"\).*)$`)
+
+// enabledStaticRoutePattern 已启用的首页路由
+var enabledStaticRoutePattern = regexp.MustCompile(`(?m)^[ \t]*Router\.StaticFile\("/",\s*"\./dist/index\.html"\)`)
+
+// StaticRoutesEnabled 后端是否已启用 dist 的静态文件路由（由后端直接提供前端页面）
+func StaticRoutesEnabled(root string) bool {
+	data, err := os.ReadFile(RouterPath(root))
+	return err == nil && enabledStaticRoutePattern.Match(data)
+}
+
+// EnableStaticRoutes 取消 router.go 中上游预留的静态文件路由的注释，让后端直接提供 server/dist 中的前端页面
+// （上游的做法，dist 不存在时这些路由返回 404，不影响开发模式）。已启用时不做修改
+func EnableStaticRoutes(root string) error {
+	path := RouterPath(root)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgReadFailed, "读取 server/initialize/router.go 失败: %v", err)
+	}
+	if enabledStaticRoutePattern.Match(data) {
+		return nil
+	}
+	content := staticRoutePattern.ReplaceAllString(string(data), "$1$2")
+	if !enabledStaticRoutePattern.MatchString(content) {
+		return apperr.Errorf(apperr.StaticRoutesMissing, "server/initialize/router.go 中没有找到上游预留的 Router.StaticFile(\"/\", \"./dist/index.html\") 注释")
+	}
+	if err := writeProjectFile(path, []byte(content)); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入 router.go 失败: %v", err)
+	}
+	return nil
+}
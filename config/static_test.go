@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+)
+
+const testRouter = `func Routers() *gin.Engine {
+	Router := gin.New()
+	// 如果想要不使用nginx代理前端网页，可以修改 web/.env.production 下的
+	// VUE_APP_BASE_API = /
+	// VUE_APP_BASE_PATH = http://localhost
+	// 然后执行打包命令 npm run build。在打开下面3行注释
+	// Router.StaticFile("/favicon.ico", "./dist/favicon.ico")
+	// Router.Static("/assets", "./dist/assets")   // dist里面的静态资源
+	// Router.StaticFile("/", "./dist/index.html") // 前端网页入口页面
+	// Router.LoadHTMLGlob("./dist/*.html")
+	return Router
+}
+`
+
+func TestEnableStaticRoutes(t *testing.T) {
+	root := newProject(t)
+	path := RouterPath(root)
+	os.MkdirAll(filepath.Dir(path), 0755)
+	writeFile(t, path, testRouter)
+
+	if StaticRoutesEnabled(root) {
+		t.Fatal("未开启时不应检测为已开启")
+	}
+	if err := EnableStaticRoutes(root); err != nil {
+		t.Fatal(err)
+	}
+	content := readFile(t, path)
+	for _, line := range []string{
+		"\tRouter.StaticFile(\"/favicon.ico\", \"./dist/favicon.ico\")\n",
+		"\tRouter.Static(\"/assets\", \"./dist/assets\")   // dist里面的静态资源\n",
+		"\tRouter.StaticFile(\"/\", \"./dist/index.html\") // 前端网页入口页面\n",
+		"\t// Router.LoadHTMLGlob(\"./dist/*.html\")\n",
+	} {
+		if !strings.Contains(content, line) {
+			t.Errorf("缺少 %q:\n%s", line, content)
+		}
+	}
+	if !StaticRoutesEnabled(root) {
+		t.Error("开启后应检测为已开启")
+	}
+	// 已开启时不重复修改
+	if err := EnableStaticRoutes(root); err != nil || readFile(t, path) != content {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestEnableStaticRoutesMissing(t *testing.T) {
+	root := newProject(t)
+	os.MkdirAll(filepath.Dir(RouterPath(root)), 0755)
+	writeFile(t, RouterPath(root), "package initialize\n")
+	if err := EnableStaticRoutes(root); apperr.CodeOf(err) != apperr.StaticRoutesMissing {
+		t.Errorf("err = %v", err)
+	}
+}
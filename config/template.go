@@ -0,0 +1,304 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// ConfigTemplateFile 团队共享的 config.yaml 模板（保存在 server/ 中，可提交到仓库）：
+// 必须本地填写的键的值被清空，其余与维护者的 config.yaml 相同
+const ConfigTemplateFile = "config.template.yaml"
+
+// configTemplateHeader 写在模板开头的说明
+const configTemplateHeader = "# 由 GVA 面板根据 config.yaml 生成的配置模板，下面 " + localKeyPrefix + "开头的行列出的键需要每个人在本地填写。\n" +
+	"# 在面板中打开项目时会提示填写这些值并生成 config.yaml，请不要把 config.yaml 提交到仓库。\n"
+
+// localKeyPrefix 模板开头列出必须本地填写的键的注释行前缀（键列表随模板提交，不在根目录另存文件）
+const localKeyPrefix = "# local-key: "
+
+// LocalKey 必须本地填写的配置键
+type LocalKey struct {
+	Key  string // config.yaml 中的键路径，例如 mysql.password
+	Note string // 给队友的说明，例如「本地 MySQL 的 root 密码」
+}
+
+// ConfigTemplatePath 获取配置模板的路径
+func ConfigTemplatePath(root string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, "server", ConfigTemplateFile)
+}
+
+// LoadLocalKeys 从模板开头的注释行读取必须本地填写的键（模板不存在时返回空列表）
+func LoadLocalKeys(root string) ([]LocalKey, error) {
+	data, err := os.ReadFile(ConfigTemplatePath(root))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CfgReadFailed, "读取 %s 失败: %w", ConfigTemplateFile, err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if rest, ok := strings.CutPrefix(line, localKeyPrefix); ok {
+			lines = append(lines, rest)
+		}
+	}
+	keys, err := ParseLocalKeys(strings.Join(lines, "\n"))
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CfgReadFailed, "%s 中的键列表格式错误: %v", ConfigTemplateFile, err)
+	}
+	return keys, nil
+}
+
+// NeedsLocalConfig 项目提供了配置模板但本地还没有 config.yaml（例如刚克隆项目的队友）
+func NeedsLocalConfig(root string) bool {
+	return root != "" && sysutil.FileExists(ConfigTemplatePath(root)) && !sysutil.FileExists(GVAConfigPath(root))
+}
+
+// ParseLocalKeys 解析每行一个的键列表：键路径后面可以跟说明（以空白分隔），空行和 # 开头的行忽略
+func ParseLocalKeys(text string) ([]LocalKey, error) {
+	var keys []LocalKey
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		key, note := fields[0], strings.Join(fields[1:], " ")
+		if strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") || strings.Contains(key, "..") {
+			return nil, fmt.Errorf("键「%s」格式错误，应为 mysql.password 这样的路径", key)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("键「%s」重复", key)
+		}
+		seen[key] = true
+		keys = append(keys, LocalKey{Key: key, Note: note})
+	}
+	return keys, nil
+}
+
+// FormatLocalKeys 把键列表格式化为 ParseLocalKeys 的格式
+func FormatLocalKeys(keys []LocalKey) string {
+	var lines []string
+	for _, k := range keys {
+		lines = append(lines, strings.TrimSpace(k.Key+" "+k.Note))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SuggestLocalKeys 从 config.yaml 中找出看起来是密码或密钥的键（键名包含 password、secret、key、token），
+// 作为第一次生成模板时的建议
+func SuggestLocalKeys(root string) []LocalKey {
+	gvaConfig, err := readConfigMap(GVAConfigPath(root))
+	if err != nil {
+		return nil
+	}
+	var keys []LocalKey
+	for _, key := range scalarKeys("", gvaConfig) {
+		name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+		for _, word := range []string{"password", "secret", "key", "token"} {
+			if strings.Contains(name, word) {
+				keys = append(keys, LocalKey{Key: key})
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// SaveConfigTemplate 根据当前的 config.yaml 生成模板：keys 中的键必须存在且不是分组，它们的值被清空
+// （字符串为空、数字为 0、布尔值为 false），键列表写在模板开头的注释中；keys 为空时删除模板
+func SaveConfigTemplate(root string, keys []LocalKey) error {
+	if len(keys) == 0 {
+		if err := os.Remove(ConfigTemplatePath(root)); err != nil && !os.IsNotExist(err) {
+			return apperr.Errorf(apperr.CfgWriteFailed, "删除 %s 失败: %w", ConfigTemplateFile, err)
+		}
+		return nil
+	}
+
+	gvaConfig, err := readConfigMap(GVAConfigPath(root))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		parent, name, ok := lookupKey(gvaConfig, k.Key)
+		if !ok {
+			return fmt.Errorf("config.yaml 中没有键「%s」", k.Key)
+		}
+		if _, group := parent[name].(map[string]interface{}); group {
+			return fmt.Errorf("「%s」是一组配置，请填写其中具体的键", k.Key)
+		}
+		parent[name] = blankValue(parent[name])
+	}
+
+	data, err := yaml.Marshal(gvaConfig)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "序列化配置模板失败: %v", err)
+	}
+	header := configTemplateHeader
+	for _, line := range strings.Split(FormatLocalKeys(keys), "\n") {
+		header += localKeyPrefix + line + "\n"
+	}
+	if err := os.WriteFile(ConfigTemplatePath(root), append([]byte(header), data...), 0644); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入 %s 失败: %w", ConfigTemplateFile, err)
+	}
+	return nil
+}
+
+// LocalValues 读取当前 config.yaml 中这些键的值（用于填写时预填，config.yaml 不存在或没有该键时为空）
+func LocalValues(root string, keys []LocalKey) map[string]string {
+	values := map[string]string{}
+	gvaConfig, err := readConfigMap(GVAConfigPath(root))
+	if err != nil {
+		return values
+	}
+	for _, k := range keys {
+		if parent, name, ok := lookupKey(gvaConfig, k.Key); ok && parent[name] != nil {
+			values[k.Key] = fmt.Sprint(parent[name])
+		}
+	}
+	return values
+}
+
+// WriteConfigFromTemplate 用模板和本地填写的值生成 config.yaml（已存在时覆盖）。
+// 值按模板中的类型写入：模板中为数字或布尔值的键需要填写对应类型的值
+func WriteConfigFromTemplate(root string, keys []LocalKey, values map[string]string) error {
+	gvaConfig, err := readConfigMap(ConfigTemplatePath(root))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		parent, name, ok := lookupKey(gvaConfig, k.Key)
+		if !ok {
+			return apperr.Errorf(apperr.CfgReadFailed, "%s 中没有键「%s」，请让维护者重新生成模板", ConfigTemplateFile, k.Key)
+		}
+		value, err := typedValue(parent[name], strings.TrimSpace(values[k.Key]))
+		if err != nil {
+			return fmt.Errorf("%s %v", k.Key, err)
+		}
+		parent[name] = value
+	}
+
+	data, err := yaml.Marshal(gvaConfig)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "序列化配置失败: %v", err)
+	}
+	if err := writeProjectFile(GVAConfigPath(root), data); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入配置文件失败: %v", err)
+	}
+	return nil
+}
+
+// readConfigMap 以 map 形式读取 YAML 配置文件
+func readConfigMap(path string) (map[string]interface{}, error) {
+	if path == "" {
+		return nil, apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.CfgReadFailed, "读取 %s 失败: %w", filepath.Base(path), err)
+	}
+	var gvaConfig map[string]interface{}
+	if err := yaml.Unmarshal(data, &gvaConfig); err != nil {
+		return nil, apperr.Errorf(apperr.CfgYAMLParse, "解析 %s 失败: %v", filepath.Base(path), err)
+	}
+	if gvaConfig == nil {
+		gvaConfig = map[string]interface{}{}
+	}
+	return gvaConfig, nil
+}
+
+// lookupKey 按点分隔的路径查找键，返回所在的分组和键名
+func lookupKey(gvaConfig map[string]interface{}, key string) (map[string]interface{}, string, bool) {
+	parts := strings.Split(key, ".")
+	parent := gvaConfig
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := parent[part].(map[string]interface{})
+		if !ok {
+			return nil, "", false
+		}
+		parent = child
+	}
+	name := parts[len(parts)-1]
+	_, ok := parent[name]
+	return parent, name, ok
+}
+
+// scalarKeys 列出所有不是分组的键（排序）
+func scalarKeys(prefix string, m map[string]interface{}) []string {
+	var keys []string
+	for name, value := range m {
+		if child, ok := value.(map[string]interface{}); ok {
+			keys = append(keys, scalarKeys(prefix+name+".", child)...)
+		} else {
+			keys = append(keys, prefix+name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// blankValue 清空后的值，保留类型
+func blankValue(value interface{}) interface{} {
+	switch value.(type) {
+	case int:
+		return 0
+	case float64:
+		return 0.0
+	case bool:
+		return false
+	default:
+		return ""
+	}
+}
+
+// typedValue 按模板中的类型转换填写的值
+func typedValue(template interface{}, value string) (interface{}, error) {
+	switch template.(type) {
+	case int:
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("需要是整数")
+		}
+		return n, nil
+	case float64:
+		if value == "" {
+			return 0.0, nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("需要是数字")
+		}
+		return f, nil
+	case bool:
+		if value == "" {
+			return false, nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("需要是 true 或 false")
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseLocalKeys(t *testing.T) {
+	keys, err := ParseLocalKeys("# 注释\nmysql.password  本地 MySQL 密码\n\n\tjwt.signing-key\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != (LocalKey{Key: "mysql.password", Note: "本地 MySQL 密码"}) || keys[1] != (LocalKey{Key: "jwt.signing-key"}) {
+		t.Errorf("keys = %+v", keys)
+	}
+	if FormatLocalKeys(keys) != "mysql.password 本地 MySQL 密码\njwt.signing-key" {
+		t.Errorf("FormatLocalKeys = %q", FormatLocalKeys(keys))
+	}
+	for _, text := range []string{"mysql..password", ".mysql", "a\na"} {
+		if _, err := ParseLocalKeys(text); err == nil {
+			t.Errorf("%q 应报错", text)
+		}
+	}
+}
+
+func TestSuggestLocalKeys(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+	var got []string
+	for _, k := range SuggestLocalKeys(root) {
+		got = append(got, k.Key)
+	}
+	if strings.Join(got, " ") != "jwt.signing-key mysql.password redis.password" {
+		t.Errorf("suggest = %v", got)
+	}
+}
+
+func TestConfigTemplate(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, GVAConfigPath(root), sampleGVAConfig)
+	keys := []LocalKey{{Key: "mysql.password", Note: "本地 MySQL 密码"}, {Key: "system.addr"}, {Key: "jwt.signing-key"}}
+	if err := SaveConfigTemplate(root, keys); err != nil {
+		t.Fatal(err)
+	}
+
+	template := readFile(t, ConfigTemplatePath(root))
+	if strings.Contains(template, "secret") || strings.Contains(template, "keep-me") || !strings.HasPrefix(template, "# ") {
+		t.Errorf("模板中不应包含本地填写的值:\n%s", template)
+	}
+	if !strings.Contains(template, "addr: 0") || !strings.Contains(template, "username: root") {
+		t.Errorf("模板应保留其他值，数字清空为 0:\n%s", template)
+	}
+	if !strings.Contains(template, localKeyPrefix+"mysql.password 本地 MySQL 密码\n") {
+		t.Errorf("键列表应写在模板开头:\n%s", template)
+	}
+	if loaded, err := LoadLocalKeys(root); err != nil || len(loaded) != 3 || loaded[0] != keys[0] {
+		t.Errorf("keys = %+v, err = %v", loaded, err)
+	}
+
+	// 队友克隆项目后没有 config.yaml
+	os.Remove(GVAConfigPath(root))
+	if !NeedsLocalConfig(root) {
+		t.Error("有模板但没有 config.yaml 时应提示填写")
+	}
+	if err := WriteConfigFromTemplate(root, keys, map[string]string{"mysql.password": "mine", "system.addr": "abc"}); err == nil {
+		t.Error("端口不是整数时应报错")
+	}
+	if err := WriteConfigFromTemplate(root, keys, map[string]string{"mysql.password": "mine", "system.addr": "8889", "jwt.signing-key": "k"}); err != nil {
+		t.Fatal(err)
+	}
+	if NeedsLocalConfig(root) {
+		t.Error("生成 config.yaml 后不再提示")
+	}
+	cfg, err := ReadGVAConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mysql.Password != "mine" || cfg.System.Addr != 8889 || cfg.Mysql.Username != "root" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	values := LocalValues(root, keys)
+	if values["mysql.password"] != "mine" || values["system.addr"] != "8889" {
+		t.Errorf("values = %v", values)
+	}
+
+	for _, bad := range []LocalKey{{Key: "mysql"}, {Key: "mysql.missing"}} {
+		if err := SaveConfigTemplate(root, []LocalKey{bad}); err == nil {
+			t.Errorf("%s 应报错", bad.Key)
+		}
+	}
+	if err := SaveConfigTemplate(root, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(ConfigTemplatePath(root)); !os.IsNotExist(err) {
+		t.Error("键列表为空时应删除模板")
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import "time"
+
+// Timeouts 面板内部的等待时间与超时（单位毫秒，0 或负数表示使用默认值）
+// 较慢的机器上启动和重启耗时更长，可在配置文件中适当调大
+type Timeouts struct {
+	StartReadyMs     int `json:"start_ready_ms,omitempty"`      // 启动服务后等待就绪（后端健康检查有响应、前端端口监听）的最长时间
+	VueRestartWaitMs int `json:"vue_restart_wait_ms,omitempty"` // 修改前端端口后等待 Vue 重启完成的时间
+	StopWaitMs       int `json:"stop_wait_ms,omitempty"`        // 停止服务后等待多久再刷新状态
+	MonitorWindowMs  int `json:"monitor_window_ms,omitempty"`   // 刚启动的服务端口尚未监听的宽限时长（看守模式在此期间不重复启动）
+	RedisDialMs      int `json:"redis_dial_ms,omitempty"`       // Redis 测试连接的 TCP 超时
+	SmokeTestMs      int `json:"smoke_test_ms,omitempty"`       // 冒烟测试等待服务就绪的最长时间
+}
+
+// 默认值（与早期版本写死的数值一致）
+const (
+	DefaultStartReady     = 3 * time.Minute
+	DefaultVueRestartWait = 4 * time.Second
+	DefaultStopWait       = 500 * time.Millisecond
+	DefaultMonitorWindow  = 30 * time.Second
+	DefaultRedisDial      = 3 * time.Second
+	DefaultSmokeTest      = 90 * time.Second
+)
+
+// StartReady 启动服务后等待就绪的最长时间（go run 首次编译较慢，后端就绪后才启动前端）
+func (t Timeouts) StartReady() time.Duration {
+	return msOrDefault(t.StartReadyMs, DefaultStartReady)
+}
+
+// VueRestartWait 修改前端端口后等待 Vue 重启完成的时间
+func (t Timeouts) VueRestartWait() time.Duration {
+	return msOrDefault(t.VueRestartWaitMs, DefaultVueRestartWait)
+}
+
+// StopWait 停止服务后等待多久再刷新状态
+func (t Timeouts) StopWait() time.Duration {
+	return msOrDefault(t.StopWaitMs, DefaultStopWait)
+}
+
+// MonitorWindow 刚启动的服务端口尚未监听的宽限时长（看守模式在此期间不重复启动）
+func (t Timeouts) MonitorWindow() time.Duration {
+	return msOrDefault(t.MonitorWindowMs, DefaultMonitorWindow)
+}
+
+// RedisDial Redis 测试连接的 TCP 超时
+func (t Timeouts) RedisDial() time.Duration {
+	return msOrDefault(t.RedisDialMs, DefaultRedisDial)
+}
+
+// SmokeTest 冒烟测试等待服务就绪的最长时间（go run 首次编译较慢）
+func (t Timeouts) SmokeTest() time.Duration {
+	return msOrDefault(t.SmokeTestMs, DefaultSmokeTest)
+}
+
+// msOrDefault 把毫秒数转换为时长，未设置时返回默认值
+func msOrDefault(ms int, def time.Duration) time.Duration {
+	if ms <= 0 {
+		return def
+	}
+	return time.Duration(ms) * time.Millisecond
+}
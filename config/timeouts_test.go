@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeoutsDefaults(t *testing.T) {
+	var zero Timeouts
+	if got := zero.StartReady(); got != DefaultStartReady {
+		t.Errorf("StartReady = %v, want %v", got, DefaultStartReady)
+	}
+	if got := (Timeouts{StopWaitMs: -1}).StopWait(); got != DefaultStopWait {
+		t.Errorf("负数应使用默认值, got %v", got)
+	}
+	if got := (Timeouts{RedisDialMs: 1500}).RedisDial(); got != 1500*time.Millisecond {
+		t.Errorf("RedisDial = %v, want 1.5s", got)
+	}
+}
+
+func TestTimeoutsJSON(t *testing.T) {
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"gva_root_path":"D:/gva","timeouts":{"monitor_window_ms":60000}}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Timeouts.MonitorWindow(); got != time.Minute {
+		t.Errorf("MonitorWindow = %v, want 1m", got)
+	}
+	if got := cfg.Timeouts.VueRestartWait(); got != DefaultVueRestartWait {
+		t.Errorf("未配置的项应使用默认值, got %v", got)
+	}
+}
+
+func TestEffectiveTimeouts(t *testing.T) {
+	cfg := Config{Timeouts: Timeouts{StopWaitMs: 1000}}
+	if got := cfg.EffectiveTimeouts(); got != cfg.Timeouts {
+		t.Errorf("未开启低资源模式时应原样返回, got %+v", got)
+	}
+
+	cfg.LowResource = true
+	got := cfg.EffectiveTimeouts()
+	if got.StopWait() != 3*time.Second {
+		t.Errorf("StopWait = %v, want 3s", got.StopWait())
+	}
+	if got.SmokeTest() != LowResourceFactor*DefaultSmokeTest {
+		t.Errorf("未设置的项应在默认值基础上延长, SmokeTest = %v", got.SmokeTest())
+	}
+	if cfg.Timeouts.StopWaitMs != 1000 {
+		t.Errorf("不应修改配置中的原值")
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// viteHTTPSMarker 面板写入 vite 配置的每一行都以此注释结尾，关闭时按它删除
+const viteHTTPSMarker = "// gvapanel-https"
+
+// viteServerPattern vite 配置中的 server: { 配置段
+var viteServerPattern = regexp.MustCompile(`(?m)^([ \t]*)server\s*:\s*\{[ \t]*\r?\n`)
+
+// ViteConfigPath 获取前端 vite 配置文件路径（不存在时返回空字符串）
+func ViteConfigPath(root string) string {
+	if root == "" {
+		return ""
+	}
+	for _, name := range []string{"vite.config.js", "vite.config.ts", "vite.config.mjs"} {
+		path := filepath.Join(root, "web", name)
+		if sysutil.FileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// ViteHTTPSEnabled vite 开发服务器是否已由面板开启 HTTPS
+func ViteHTTPSEnabled(root string) bool {
+	path := ViteConfigPath(root)
+	if path == "" {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), viteHTTPSMarker)
+}
+
+// EnableViteHTTPS 在 vite 配置的 server 段中加入 https 证书（已开启时替换为新的证书路径）
+func EnableViteHTTPS(root, certPath, keyPath string) error {
+	path := ViteConfigPath(root)
+	if path == "" {
+		return apperr.Errorf(apperr.HTTPSViteConfig, "未找到 web/vite.config.js")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgReadFailed, "读取 %s 失败: %v", filepath.Base(path), err)
+	}
+
+	content := removeMarkedLines(string(data))
+	match := viteServerPattern.FindStringSubmatchIndex(content)
+	if match == nil {
+		return apperr.Errorf(apperr.HTTPSViteConfig, "%s 中没有 server: { 配置段", filepath.Base(path))
+	}
+
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	indent := content[match[2]:match[3]] + "  "
+	httpsLine := indent + "https: { key: gvapanelReadFile(" + strconv.Quote(filepath.ToSlash(keyPath)) +
+		"), cert: gvapanelReadFile(" + strconv.Quote(filepath.ToSlash(certPath)) + ") }, " + viteHTTPSMarker + newline
+	importLine := "import { readFileSync as gvapanelReadFile } from 'node:fs' " + viteHTTPSMarker + newline
+
+	content = importLine + content[:match[1]] + httpsLine + content[match[1]:]
+	if err := writeProjectFile(path, []byte(content)); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入 %s 失败: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// DisableViteHTTPS 删除面板写入 vite 配置的 https 设置（未开启时不做修改）
+func DisableViteHTTPS(root string) error {
+	path := ViteConfigPath(root)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apperr.Errorf(apperr.CfgReadFailed, "读取 %s 失败: %v", filepath.Base(path), err)
+	}
+	if !strings.Contains(string(data), viteHTTPSMarker) {
+		return nil
+	}
+	if err := writeProjectFile(path, []byte(removeMarkedLines(string(data)))); err != nil {
+		return apperr.Errorf(apperr.CfgWriteFailed, "写入 %s 失败: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// removeMarkedLines 删除以 viteHTTPSMarker 结尾的行
+func removeMarkedLines(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasSuffix(strings.TrimRight(line, "\r\n"), viteHTTPSMarker) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "")
+}
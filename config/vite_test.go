@@ -0,0 +1,97 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+)
+
+const testViteConfig = `import * as path from 'path'
+
+export default ({ mode }) => {
+  const config = {
+    server: {
+      open: true,
+      port: process.env.VITE_CLI_PORT,
+    },
+  }
+  return config
+}
+`
+
+func TestEnableViteHTTPS(t *testing.T) {
+	root := newProject(t)
+	path := filepath.Join(root, "web", "vite.config.js")
+	writeFile(t, path, testViteConfig)
+
+	if ViteHTTPSEnabled(root) {
+		t.Fatal("未开启时不应检测为已开启")
+	}
+	if err := EnableViteHTTPS(root, "/certs/cert.pem", "/certs/key.pem"); err != nil {
+		t.Fatal(err)
+	}
+	content := readFile(t, path)
+	if !strings.HasPrefix(content, "import { readFileSync as gvapanelReadFile } from 'node:fs'") {
+		t.Errorf("应在文件开头导入 readFileSync:\n%s", content)
+	}
+	if !strings.Contains(content, "    server: {\n      https: { key: gvapanelReadFile(\"/certs/key.pem\"), cert: gvapanelReadFile(\"/certs/cert.pem\") },") {
+		t.Errorf("https 应写在 server 段的第一行:\n%s", content)
+	}
+	if !ViteHTTPSEnabled(root) {
+		t.Error("开启后应检测为已开启")
+	}
+
+	// 再次开启时替换证书路径，不重复写入
+	if err := EnableViteHTTPS(root, "/new/cert.pem", "/new/key.pem"); err != nil {
+		t.Fatal(err)
+	}
+	content = readFile(t, path)
+	if strings.Count(content, viteHTTPSMarker) != 2 || !strings.Contains(content, "/new/cert.pem") {
+		t.Errorf("重复开启应替换旧的设置:\n%s", content)
+	}
+
+	if err := DisableViteHTTPS(root); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != testViteConfig {
+		t.Errorf("关闭后应恢复原文件:\n%s", got)
+	}
+}
+
+func TestEnableViteHTTPSKeepsCRLF(t *testing.T) {
+	root := newProject(t)
+	path := filepath.Join(root, "web", "vite.config.ts")
+	original := strings.ReplaceAll(testViteConfig, "\n", "\r\n")
+	writeFile(t, path, original)
+
+	if err := EnableViteHTTPS(root, "C:\\certs\\cert.pem", "C:\\certs\\key.pem"); err != nil {
+		t.Fatal(err)
+	}
+	content := readFile(t, path)
+	if strings.Count(content, "\n") != strings.Count(content, "\r\n") {
+		t.Errorf("应保持 CRLF 换行:\n%q", content)
+	}
+	if err := DisableViteHTTPS(root); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != original {
+		t.Errorf("关闭后应恢复原文件:\n%q", got)
+	}
+}
+
+func TestEnableViteHTTPSErrors(t *testing.T) {
+	root := newProject(t)
+	if err := EnableViteHTTPS(root, "c", "k"); apperr.CodeOf(err) != apperr.HTTPSViteConfig {
+		t.Errorf("缺少 vite 配置: err = %v", err)
+	}
+
+	writeFile(t, filepath.Join(root, "web", "vite.config.js"), "export default {}\n")
+	if err := EnableViteHTTPS(root, "c", "k"); apperr.CodeOf(err) != apperr.HTTPSViteConfig {
+		t.Errorf("缺少 server 段: err = %v", err)
+	}
+	if err := DisableViteHTTPS(root); err != nil {
+		t.Errorf("未开启时关闭不应报错: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package console 面板内的简易终端：在项目目录中逐条执行命令行（交给系统 shell），
+// cd 由面板处理并在之后的命令中保持，环境变量附加面板的代理和镜像源设置。
+// 没有伪终端，需要交互输入的程序（vim、交互式 npm init 等）读到的是空输入
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/outputbuf"
+)
+
+// maxOutput 每个终端在内存中保留的输出
+const maxOutput = 1 << 20
+
+// maxHistory 每个终端保留的历史命令条数
+const maxHistory = 200
+
+// ErrBusy 上一条命令还没有结束
+var ErrBusy = errors.New("上一条命令仍在执行，可先结束它")
+
+// Session 一个终端：当前目录、历史命令和输出
+type Session struct {
+	Root   string            // 项目根目录（提示符中的路径相对于它显示）
+	Home   string            // 初始目录（不带参数的 cd 回到这里）
+	Env    []string          // 在面板的环境变量基础上追加的变量
+	Output *outputbuf.Buffer // 命令及其输出
+
+	mu      sync.Mutex
+	dir     string
+	history []string
+	cancel  context.CancelFunc // 正在执行的命令（没有时为 nil）
+}
+
+// New 创建在 home 目录中打开的终端
+func New(root, home string, env []string) *Session {
+	return &Session{Root: root, Home: home, Env: env, Output: outputbuf.New(maxOutput, 0, ""), dir: home}
+}
+
+// Dir 当前目录
+func (s *Session) Dir() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dir
+}
+
+// Prompt 提示符：项目内的目录显示为相对路径，例如 "server $"
+func (s *Session) Prompt() string {
+	dir := s.Dir()
+	if rel, err := filepath.Rel(s.Root, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		dir = filepath.ToSlash(rel)
+		if dir == "." {
+			dir = filepath.Base(s.Root)
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return dir + ">"
+	}
+	return dir + " $"
+}
+
+// Running 是否有命令正在执行
+func (s *Session) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cancel != nil
+}
+
+// History 历史命令（从旧到新）
+func (s *Session) History() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.history...)
+}
+
+// Interrupt 结束正在执行的命令（包括它启动的子进程）
+func (s *Session) Interrupt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Run 执行一行命令并等待结束（在后台协程中调用），命令和输出写入 Output。
+// cd、clear / cls 由面板处理，其余交给系统 shell；返回命令的错误，上一条命令未结束时返回 ErrBusy
+func (s *Session) Run(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return ErrBusy
+	}
+	if n := len(s.history); n == 0 || s.history[n-1] != line {
+		s.history = append(s.history, line)
+		if len(s.history) > maxHistory {
+			s.history = s.history[len(s.history)-maxHistory:]
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	dir := s.dir
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.cancel = nil
+		s.mu.Unlock()
+		cancel()
+	}()
+
+	if line == "clear" || line == "cls" {
+		return s.Output.Clear()
+	}
+	s.Output.Println(s.Prompt() + " " + line)
+	if target, ok := parseCd(line); ok {
+		return s.cd(dir, target)
+	}
+
+	name, args := sysutil.ShellCommand(line)
+	err := sysutil.RunOutputEnvContext(ctx, dir, s.Env, s.Output, name, args...)
+	switch {
+	case ctx.Err() != nil:
+		s.Output.Println("^C 已结束")
+	case err != nil:
+		s.Output.Println(fmt.Sprintf("[%v]", err))
+	}
+	return err
+}
+
+// cd 切换当前目录（target 为空时回到初始目录，相对路径按当前目录解析）
+func (s *Session) cd(dir, target string) error {
+	switch {
+	case target == "":
+		target = s.Home
+	case target == "~":
+		if home, err := os.UserHomeDir(); err == nil {
+			target = home
+		}
+	case !filepath.IsAbs(target):
+		target = filepath.Join(dir, target)
+	}
+	target = filepath.Clean(target)
+	if info, err := os.Stat(target); err != nil || !info.IsDir() {
+		err := fmt.Errorf("cd: 目录不存在: %s", target)
+		s.Output.Println(err.Error())
+		return err
+	}
+	s.mu.Lock()
+	s.dir = target
+	s.mu.Unlock()
+	return nil
+}
+
+// parseCd 识别单独的 cd 命令（cd、cd 目录、cd "带空格的目录"、Windows 的 cd /d 目录）；
+// 与其他命令组合（&&、; 等）时交给 shell，只在该命令中生效
+func parseCd(line string) (string, bool) {
+	if line != "cd" && line != "chdir" && !strings.HasPrefix(line, "cd ") && !strings.HasPrefix(line, "chdir ") {
+		return "", false
+	}
+	if strings.ContainsAny(line, "&;|<>`$") {
+		return "", false
+	}
+	_, target, _ := strings.Cut(line, " ")
+	target = strings.TrimSpace(target)
+	if rest, ok := strings.CutPrefix(target, "/d "); ok && runtime.GOOS == "windows" {
+		target = strings.TrimSpace(rest)
+	}
+	if len(target) >= 2 && (target[0] == '"' && target[len(target)-1] == '"' || target[0] == '\'' && target[len(target)-1] == '\'') {
+		target = target[1 : len(target)-1]
+	}
+	return target, true
+}
+
+// Env 终端追加的环境变量：面板的 HTTP 代理（为空时沿用系统环境变量）和当前的 npm、Go 镜像源
+func Env(httpProxy, npmRegistry, goProxy string) []string {
+	var env []string
+	if httpProxy != "" {
+		env = append(env, "HTTP_PROXY="+httpProxy, "HTTPS_PROXY="+httpProxy, "http_proxy="+httpProxy, "https_proxy="+httpProxy)
+	}
+	if npmRegistry != "" {
+		env = append(env, "npm_config_registry="+npmRegistry)
+	}
+	if goProxy != "" {
+		env = append(env, "GOPROXY="+goProxy)
+	}
+	return env
+}
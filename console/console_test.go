@@ -0,0 +1,98 @@
+package console
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestParseCd(t *testing.T) {
+	cases := map[string]struct {
+		target string
+		ok     bool
+	}{
+		"cd":                 {"", true},
+		"cd src":             {"src", true},
+		`cd "my dir"`:        {"my dir", true},
+		"cd .. && npm test":  {"", false},
+		"cdk deploy":         {"", false},
+		"cd $HOME":           {"", false},
+		"chdir ../web/src  ": {"../web/src", true},
+	}
+	for line, want := range cases {
+		target, ok := parseCd(strings.TrimSpace(line))
+		if target != want.target || ok != want.ok {
+			t.Errorf("%q: got %q %v", line, target, ok)
+		}
+	}
+}
+
+func TestRunAndCd(t *testing.T) {
+	root := t.TempDir()
+	server := filepath.Join(root, "server")
+	os.MkdirAll(filepath.Join(server, "config"), 0755)
+
+	env := Env("http://127.0.0.1:7890", "https://registry.npmmirror.com/", "")
+	s := New(root, server, env)
+	runner := sysutiltest.New(t)
+	name, args := sysutil.ShellCommand("go version")
+	runner.Handle(strings.Join(append([]string{name}, args...), " "), "go version go1.24.8\n", nil)
+
+	if err := s.Run("cd config"); err != nil || s.Dir() != filepath.Join(server, "config") {
+		t.Fatalf("cd: %v, dir = %s", err, s.Dir())
+	}
+	if !strings.HasPrefix(s.Prompt(), "server/config") {
+		t.Errorf("prompt = %q", s.Prompt())
+	}
+	if err := s.Run("cd missing"); err == nil || s.Dir() != filepath.Join(server, "config") {
+		t.Errorf("目录不存在时不切换: %v, dir = %s", err, s.Dir())
+	}
+	s.Run("cd")
+	if s.Dir() != server {
+		t.Errorf("不带参数的 cd 回到初始目录, dir = %s", s.Dir())
+	}
+
+	if err := s.Run("go version"); err != nil {
+		t.Fatal(err)
+	}
+	calls := runner.Calls()
+	if len(calls) != 1 || calls[0].Dir != server || !reflect.DeepEqual(calls[0].Env, env) {
+		t.Errorf("calls = %+v", calls)
+	}
+	lines := s.Output.Lines()
+	if len(lines) < 2 || lines[len(lines)-1] != "go version go1.24.8" || !strings.HasSuffix(lines[len(lines)-2], " go version") {
+		t.Errorf("output = %q", lines)
+	}
+
+	s.Run("go version")
+	if got := s.History(); !reflect.DeepEqual(got, []string{"cd config", "cd missing", "cd", "go version"}) {
+		t.Errorf("连续重复的命令只记一次, history = %q", got)
+	}
+	s.Run("clear")
+	if len(s.Output.Lines()) != 0 {
+		t.Errorf("clear 后 output = %q", s.Output.Lines())
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	s := New(t.TempDir(), t.TempDir(), nil)
+	runner := sysutiltest.New(t)
+	name, args := sysutil.ShellCommand("npm run lint")
+	runner.HandleExit(strings.Join(append([]string{name}, args...), " "), "error\n", errors.New("exit status 1"))
+
+	if err := s.Run("npm run lint"); err == nil {
+		t.Error("命令失败时应返回错误")
+	}
+	if lines := s.Output.Lines(); lines[len(lines)-1] != "[exit status 1]" {
+		t.Errorf("output = %q", lines)
+	}
+	if s.Running() {
+		t.Error("命令结束后不再处于执行中")
+	}
+}
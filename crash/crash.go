@@ -0,0 +1,127 @@
+// Package crash 在面板 panic 时把错误和调用栈写入本地崩溃报告，下次启动时由界面提示用户查看或提交
+// 报告只保存在面板数据目录，不会自动上传；提交 Issue 需要用户手动确认
+package crash
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gva-launcher/secretscan"
+)
+
+// IssueURL 提交崩溃报告的 Issue 页面
+const IssueURL = "https://github.com/XiaoafengClub/GVAPanel/issues/new"
+
+// maxIssueBody 预填到 Issue 正文的最大长度（避免 URL 过长被浏览器截断）
+const maxIssueBody = 6000
+
+var (
+	mu      sync.Mutex
+	dir     string // 报告目录（为空时不写报告）
+	version string // 面板版本号
+)
+
+// Setup 设置报告目录和面板版本号（需在启动工作协程之前调用）
+func Setup(reportDir, panelVersion string) {
+	mu.Lock()
+	defer mu.Unlock()
+	dir = reportDir
+	version = panelVersion
+}
+
+// Recover 捕获当前协程的 panic 并写入崩溃报告，然后继续 panic（程序按原样退出）
+// 用法: defer crash.Recover("主循环")
+func Recover(where string) {
+	if r := recover(); r != nil {
+		Report(where, r, debug.Stack())
+		panic(r)
+	}
+}
+
+// Report 写入一份崩溃报告，返回报告路径（未调用 Setup 时返回空路径）
+func Report(where string, recovered interface{}, stack []byte) (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if dir == "" {
+		return "", nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("创建崩溃报告目录失败: %v", err)
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	fmt.Fprintf(&b, "GVAPanel 崩溃报告\n\n")
+	fmt.Fprintf(&b, "时间: %s\n", now.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "版本: %s\n", version)
+	fmt.Fprintf(&b, "系统: %s/%s (%s)\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "位置: %s\n", where)
+	fmt.Fprintf(&b, "错误: %v\n\n", recovered)
+	fmt.Fprintf(&b, "调用栈:\n%s", stack)
+
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s-%d.txt", now.Format("20060102-150405"), now.Nanosecond()/1e6))
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return "", fmt.Errorf("写入崩溃报告失败: %v", err)
+	}
+	return path, nil
+}
+
+// Pending 尚未处理的崩溃报告路径（按时间从旧到新）
+func Pending() []string {
+	mu.Lock()
+	reportDir := dir
+	mu.Unlock()
+
+	if reportDir == "" {
+		return nil
+	}
+	paths, _ := filepath.Glob(filepath.Join(reportDir, "crash-*.txt"))
+	var pending []string
+	for _, p := range paths {
+		if !strings.HasSuffix(p, ".seen.txt") {
+			pending = append(pending, p)
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// Dismiss 标记报告已处理（改名为 .seen.txt，保留文件供以后查看）
+func Dismiss(path string) error {
+	return os.Rename(path, strings.TrimSuffix(path, ".txt")+".seen.txt")
+}
+
+// IssueLink 生成预填了标题和报告内容的 Issue 地址（内容过长时截断）；
+// 错误信息中的密码、密钥等疑似密钥替换为 ******，本地的报告文件不修改
+func IssueLink(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	body := secretscan.RedactText(string(data))
+	if len(body) > maxIssueBody {
+		body = strings.ToValidUTF8(body[:maxIssueBody], "") + "\n...（已截断，完整报告见 " + filepath.Base(path) + "）"
+	}
+
+	title := "崩溃报告"
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "错误: ") {
+			title += ": " + strings.TrimPrefix(line, "错误: ")
+			break
+		}
+	}
+
+	q := url.Values{}
+	q.Set("title", title)
+	q.Set("body", "```\n"+body+"\n```")
+	return IssueURL + "?" + q.Encode(), nil
+}
@@ -0,0 +1,104 @@
+package crash
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReportAndDismiss(t *testing.T) {
+	Setup(t.TempDir(), "v9.9.9")
+	defer Setup("", "")
+
+	path, err := Report("测试", errors.New("boom"), []byte("goroutine 1 [running]:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"版本: v9.9.9", "位置: 测试", "错误: boom", "goroutine 1"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("报告缺少 %q:\n%s", want, data)
+		}
+	}
+
+	if got := Pending(); len(got) != 1 || got[0] != path {
+		t.Fatalf("Pending = %v", got)
+	}
+	if err := Dismiss(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := Pending(); len(got) != 0 {
+		t.Errorf("Dismiss 后不应再有待处理报告, got %v", got)
+	}
+}
+
+func TestRecoverRepanics(t *testing.T) {
+	Setup(t.TempDir(), "v1")
+	defer Setup("", "")
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Recover 应继续 panic, got %v", r)
+			}
+		}()
+		defer Recover("测试")
+		panic("boom")
+	}()
+
+	if len(Pending()) != 1 {
+		t.Error("Recover 应写入崩溃报告")
+	}
+}
+
+func TestReportWithoutSetup(t *testing.T) {
+	Setup("", "")
+	if path, err := Report("测试", "boom", nil); path != "" || err != nil {
+		t.Errorf("未设置目录时不应写报告, got %q %v", path, err)
+	}
+}
+
+func TestIssueLink(t *testing.T) {
+	Setup(t.TempDir(), "v1")
+	defer Setup("", "")
+
+	path, _ := Report("测试", "nil map", []byte(strings.Repeat("栈", maxIssueBody)))
+	link, err := IssueLink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("title") != "崩溃报告: nil map" {
+		t.Errorf("title = %q", q.Get("title"))
+	}
+	if !strings.Contains(q.Get("body"), "已截断") {
+		t.Error("过长的报告应被截断")
+	}
+}
+
+func TestIssueLinkRedactsSecrets(t *testing.T) {
+	Setup(t.TempDir(), "v1")
+	defer Setup("", "")
+
+	path, _ := Report("连接数据库", "dial root:pa55word@tcp(127.0.0.1:3306)/gva", []byte("main.go:12"))
+	link, err := IssueLink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(link)
+	if q := u.Query(); strings.Contains(q.Get("title")+q.Get("body"), "pa55word") {
+		t.Errorf("提交的内容中不应包含密码: %v", q)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "pa55word") {
+		t.Error("本地报告不修改")
+	}
+}
@@ -0,0 +1,130 @@
+// Package dbclient 通过系统安装的数据库客户端（mysql / psql / sqlite3）访问 GVA 项目的数据库，
+// 连接信息来自 config.yaml 中当前 db-type 对应的配置；密码通过环境变量传递，不出现在命令行中
+package dbclient
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+)
+
+// Type 规范化的数据库类型（未设置时与 GVA 一致默认为 mysql）
+func Type(cfg *config.GVAConfig) string {
+	if cfg.System.DbType == "" {
+		return "mysql"
+	}
+	return cfg.System.DbType
+}
+
+// DB 当前 db-type 对应的连接配置（mysql / pgsql / sqlite 以外的类型返回 false）
+func DB(cfg *config.GVAConfig) (config.GVADBConfig, bool) {
+	switch Type(cfg) {
+	case "mysql":
+		return cfg.Mysql, true
+	case "pgsql":
+		return cfg.Pgsql, true
+	case "sqlite":
+		return cfg.Sqlite, true
+	}
+	return config.GVADBConfig{}, false
+}
+
+// SqlitePath SQLite 数据库文件路径（相对路径相对 server 目录，与 GVA 的 gorm 配置一致）
+func SqlitePath(root string, db config.GVADBConfig) string {
+	path := filepath.Join(db.Path, db.Dbname+".db")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, "server", path)
+	}
+	return path
+}
+
+// MysqlArgs mysql 客户端的连接参数（密码通过 MYSQL_PWD 传递）
+func MysqlArgs(db config.GVADBConfig) []string {
+	args := []string{"-h", db.Path}
+	if db.Port != "" {
+		args = append(args, "-P", db.Port)
+	}
+	if db.Username != "" {
+		args = append(args, "-u", db.Username)
+	}
+	return args
+}
+
+// PgsqlArgs PostgreSQL 客户端的连接参数（密码通过 PGPASSWORD 传递）
+func PgsqlArgs(db config.GVADBConfig) []string {
+	args := []string{"-h", db.Path}
+	if db.Port != "" {
+		args = append(args, "-p", db.Port)
+	}
+	if db.Username != "" {
+		args = append(args, "-U", db.Username)
+	}
+	return append(args, "-d", db.Dbname)
+}
+
+// PasswordEnv 传递密码的环境变量（密码为空时不设置）
+func PasswordEnv(key, password string) []string {
+	if password == "" {
+		return nil
+	}
+	return []string{key + "=" + password}
+}
+
+// Run 执行数据库客户端命令，失败时附带命令输出
+func Run(dir string, env []string, name string, args ...string) error {
+	_, err := output(dir, env, name, args...)
+	return err
+}
+
+// output 执行数据库客户端命令并返回输出，失败时错误附带命令输出
+func output(dir string, env []string, name string, args ...string) (string, error) {
+	out, err := sysutil.Runner.CombinedOutputEnv(dir, env, name, args...)
+	if err != nil {
+		if text := strings.TrimSpace(string(out)); text != "" {
+			return "", fmt.Errorf("%v\n%s", err, text)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Query 在项目 root 的数据库中执行查询，返回各行按制表符拆分的列（不含表头）。
+// 列值中不能包含制表符和换行
+func Query(root string, cfg *config.GVAConfig, query string) ([][]string, error) {
+	db, ok := DB(cfg)
+	if !ok {
+		return nil, fmt.Errorf("暂不支持查询 %s 类型的数据库", cfg.System.DbType)
+	}
+	if db.Dbname == "" {
+		return nil, fmt.Errorf("config.yaml 中未配置 %s 的 db-name", Type(cfg))
+	}
+
+	var out string
+	var err error
+	switch Type(cfg) {
+	case "mysql":
+		args := append(MysqlArgs(db), "--batch", "--skip-column-names", "-e", query, db.Dbname)
+		out, err = output(root, PasswordEnv("MYSQL_PWD", db.Password), "mysql", args...)
+	case "pgsql":
+		args := append(PgsqlArgs(db), "--no-align", "--tuples-only", "--field-separator=\t", "-c", query)
+		out, err = output(root, PasswordEnv("PGPASSWORD", db.Password), "psql", args...)
+	case "sqlite":
+		out, err = output(root, nil, "sqlite3", "-batch", "-separator", "\t", SqlitePath(root, db), query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	return rows, nil
+}
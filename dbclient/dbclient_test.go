@@ -0,0 +1,36 @@
+package dbclient
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestQueryPgsql(t *testing.T) {
+	fake := sysutiltest.New(t)
+	cfg := &config.GVAConfig{}
+	cfg.System.DbType = "pgsql"
+	cfg.Pgsql = config.GVADBConfig{Path: "db", Port: "5432", Dbname: "gva", Username: "postgres"}
+	fake.Handle("psql -h db -p 5432 -U postgres -d gva --no-align --tuples-only --field-separator=\t -c SELECT 1, 2", "1\t2\n", nil)
+
+	rows, err := Query("/work/gva", cfg, "SELECT 1, 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, [][]string{{"1", "2"}}) {
+		t.Errorf("rows = %v", rows)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Env != nil {
+		t.Errorf("没有密码时不设置 PGPASSWORD: %+v", calls)
+	}
+}
+
+func TestSqlitePath(t *testing.T) {
+	db := config.GVADBConfig{Path: "data", Dbname: "gva"}
+	if got := SqlitePath("/work/gva", db); got != filepath.Join("/work/gva", "server", "data", "gva.db") {
+		t.Errorf("got %s", got)
+	}
+}
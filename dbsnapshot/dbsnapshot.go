@@ -0,0 +1,180 @@
+// Package dbsnapshot 保存和还原 GVA 项目数据库的快照（演示模式使用）。
+// MySQL 使用 mysqldump / mysql，PostgreSQL 使用 pg_dump / psql，SQLite 直接复制数据库文件；
+// 每个项目的快照保存在独立目录中，只保留最近一次
+package dbsnapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/dbclient"
+)
+
+// 快照目录中的文件
+const (
+	InfoFile = "snapshot.json"
+	DumpFile = "snapshot.sql" // mysql / pgsql 的 SQL 导出
+	DBFile   = "snapshot.db"  // sqlite 数据库文件副本
+)
+
+// Info 快照信息
+type Info struct {
+	DbType  string    `json:"db_type"`
+	Dbname  string    `json:"db_name"`
+	Created time.Time `json:"created"`
+	Size    int64     `json:"size"`
+}
+
+// Dir 项目 root 的快照目录（base 下以根目录路径的哈希命名，避免不同项目互相覆盖）
+func Dir(base, root string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(root)))
+	return filepath.Join(base, filepath.Base(root)+"-"+hex.EncodeToString(sum[:4]))
+}
+
+// database 当前 db-type 对应的连接配置
+func database(cfg *config.GVAConfig) (config.GVADBConfig, error) {
+	db, ok := dbclient.DB(cfg)
+	if !ok {
+		return db, apperr.Errorf(apperr.DBSnapshotFailed, "暂不支持 %s 类型数据库的快照", cfg.System.DbType)
+	}
+	if db.Dbname == "" {
+		return db, apperr.Errorf(apperr.DBSnapshotFailed, "config.yaml 中未配置 %s 的 db-name", dbclient.Type(cfg))
+	}
+	return db, nil
+}
+
+// Load 读取 dir 中的快照信息（没有快照时返回 os.ErrNotExist）
+func Load(dir string) (Info, error) {
+	var info Info
+	data, err := os.ReadFile(filepath.Join(dir, InfoFile))
+	if err != nil {
+		return info, err
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return info, apperr.Errorf(apperr.DBSnapshotFailed, "快照信息损坏: %v", err)
+	}
+	return info, nil
+}
+
+// Take 导出项目 root 当前的数据库到 dir，覆盖原有快照（导出失败时保留原有快照）
+func Take(dir, root string, cfg *config.GVAConfig) (Info, error) {
+	db, err := database(cfg)
+	if err != nil {
+		return Info{}, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return Info{}, apperr.Errorf(apperr.DBSnapshotFailed, "创建快照目录失败: %v", err)
+	}
+
+	kind := dbclient.Type(cfg)
+	name := DumpFile
+	if kind == "sqlite" {
+		name = DBFile
+	}
+	dest := filepath.Join(dir, name)
+	tmp := dest + ".tmp"
+	defer os.Remove(tmp)
+
+	switch kind {
+	case "sqlite":
+		err = copyFile(dbclient.SqlitePath(root, db), tmp)
+	case "mysql":
+		args := append(dbclient.MysqlArgs(db), "--single-transaction", "--routines", "--triggers", "--add-drop-table", "-r", tmp, db.Dbname)
+		err = dbclient.Run(root, dbclient.PasswordEnv("MYSQL_PWD", db.Password), "mysqldump", args...)
+	case "pgsql":
+		args := append(dbclient.PgsqlArgs(db), "--clean", "--if-exists", "--no-owner", "-f", tmp)
+		err = dbclient.Run(root, dbclient.PasswordEnv("PGPASSWORD", db.Password), "pg_dump", args...)
+	}
+	if err != nil {
+		return Info{}, apperr.Errorf(apperr.DBSnapshotFailed, "导出数据库 %s 失败: %v", db.Dbname, err)
+	}
+
+	stat, err := os.Stat(tmp)
+	if err != nil {
+		return Info{}, apperr.Errorf(apperr.DBSnapshotFailed, "导出数据库 %s 失败: %v", db.Dbname, err)
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return Info{}, apperr.Errorf(apperr.DBSnapshotFailed, "保存快照失败: %v", err)
+	}
+
+	info := Info{DbType: kind, Dbname: db.Dbname, Created: time.Now(), Size: stat.Size()}
+	data, _ := json.MarshalIndent(info, "", "  ")
+	if err := os.WriteFile(filepath.Join(dir, InfoFile), data, 0644); err != nil {
+		return Info{}, apperr.Errorf(apperr.DBSnapshotFailed, "保存快照信息失败: %v", err)
+	}
+	return info, nil
+}
+
+// Restore 把项目 root 的数据库还原为 dir 中的快照（调用前应停止后端服务）
+func Restore(dir, root string, cfg *config.GVAConfig) error {
+	info, err := Load(dir)
+	if os.IsNotExist(err) {
+		return apperr.Errorf(apperr.DBSnapshotFailed, "还没有保存数据库快照")
+	}
+	if err != nil {
+		return err
+	}
+	db, err := database(cfg)
+	if err != nil {
+		return err
+	}
+	if kind := dbclient.Type(cfg); info.DbType != kind || info.Dbname != db.Dbname {
+		return apperr.Errorf(apperr.DBSnapshotFailed, "快照是 %s 数据库 %s，当前配置为 %s 数据库 %s，请重新保存快照",
+			info.DbType, info.Dbname, kind, db.Dbname)
+	}
+
+	switch info.DbType {
+	case "sqlite":
+		err = restoreSqlite(filepath.Join(dir, DBFile), dbclient.SqlitePath(root, db))
+	case "mysql":
+		args := append(dbclient.MysqlArgs(db), db.Dbname, "-e", "source "+filepath.Join(dir, DumpFile))
+		err = dbclient.Run(root, dbclient.PasswordEnv("MYSQL_PWD", db.Password), "mysql", args...)
+	case "pgsql":
+		args := append(dbclient.PgsqlArgs(db), "-v", "ON_ERROR_STOP=1", "-q", "-f", filepath.Join(dir, DumpFile))
+		err = dbclient.Run(root, dbclient.PasswordEnv("PGPASSWORD", db.Password), "psql", args...)
+	}
+	if err != nil {
+		return apperr.Errorf(apperr.DBSnapshotFailed, "还原数据库 %s 失败: %v", db.Dbname, err)
+	}
+	return nil
+}
+
+// restoreSqlite 先复制到临时文件再替换，避免复制中途失败留下损坏的数据库
+func restoreSqlite(src, dst string) error {
+	tmp := dst + ".restore"
+	if err := copyFile(src, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// copyFile 复制文件
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
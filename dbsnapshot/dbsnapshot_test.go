@@ -0,0 +1,118 @@
+package dbsnapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func mysqlConfig() *config.GVAConfig {
+	cfg := &config.GVAConfig{}
+	cfg.Mysql = config.GVADBConfig{Path: "127.0.0.1", Port: "3306", Dbname: "gva", Username: "root", Password: "pwd"}
+	return cfg
+}
+
+func TestDir(t *testing.T) {
+	a := Dir("/data", "/work/gva")
+	if !strings.HasPrefix(filepath.Base(a), "gva-") || filepath.Dir(a) != filepath.Clean("/data") {
+		t.Errorf("Dir = %s", a)
+	}
+	if b := Dir("/data", "/other/gva"); a == b {
+		t.Errorf("不同项目的快照目录不应相同: %s", a)
+	}
+}
+
+func TestTakeAndRestoreMysql(t *testing.T) {
+	fake := sysutiltest.New(t)
+	dir := t.TempDir()
+	tmp := filepath.Join(dir, DumpFile+".tmp")
+	fake.Handle("mysqldump -h 127.0.0.1 -P 3306 -u root --single-transaction --routines --triggers --add-drop-table -r "+tmp+" gva", "", nil)
+	fake.Handle("mysql -h 127.0.0.1 -P 3306 -u root gva -e source "+filepath.Join(dir, DumpFile), "", nil)
+
+	// 假执行器不会真正导出，预先写入导出结果
+	if err := os.WriteFile(tmp, []byte("-- dump"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := Take(dir, "/work/gva", mysqlConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.DbType != "mysql" || info.Dbname != "gva" || info.Size != int64(len("-- dump")) {
+		t.Errorf("info = %+v", info)
+	}
+	if loaded, err := Load(dir); err != nil || loaded.Dbname != "gva" {
+		t.Errorf("Load = %+v, %v", loaded, err)
+	}
+	for _, call := range fake.Calls() {
+		if len(call.Env) != 1 || call.Env[0] != "MYSQL_PWD=pwd" || strings.Contains(call.Command, "pwd") {
+			t.Errorf("密码应通过环境变量传递: %+v", call)
+		}
+	}
+
+	if err := Restore(dir, "/work/gva", mysqlConfig()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTakeFailureKeepsSnapshot(t *testing.T) {
+	fake := sysutiltest.New(t)
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, DumpFile), []byte("old"), 0644)
+	fake.Handle("pg_dump -h db -p 5432 -U postgres -d gva --clean --if-exists --no-owner -f "+filepath.Join(dir, DumpFile+".tmp"),
+		"connection refused", os.ErrPermission)
+
+	cfg := &config.GVAConfig{}
+	cfg.System.DbType = "pgsql"
+	cfg.Pgsql = config.GVADBConfig{Path: "db", Port: "5432", Dbname: "gva", Username: "postgres"}
+	_, err := Take(dir, "/work/gva", cfg)
+	if apperr.CodeOf(err) != apperr.DBSnapshotFailed || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("err = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, DumpFile)); string(data) != "old" {
+		t.Errorf("导出失败时不应覆盖原有快照: %q", data)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Env != nil {
+		t.Errorf("密码为空时不应设置 PGPASSWORD: %+v", calls)
+	}
+}
+
+func TestSqliteRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	dir := t.TempDir()
+	db := filepath.Join(root, "server", "gva.db")
+	os.MkdirAll(filepath.Dir(db), 0755)
+	os.WriteFile(db, []byte("snapshot"), 0644)
+
+	cfg := &config.GVAConfig{}
+	cfg.System.DbType = "sqlite"
+	cfg.Sqlite = config.GVADBConfig{Dbname: "gva"}
+	if _, err := Take(dir, root, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(db, []byte("changed during class"), 0644)
+	if err := Restore(dir, root, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(db); string(data) != "snapshot" {
+		t.Errorf("还原后 = %q", data)
+	}
+}
+
+func TestRestoreMismatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := Restore(dir, "/work/gva", mysqlConfig()); apperr.CodeOf(err) != apperr.DBSnapshotFailed {
+		t.Errorf("没有快照时 err = %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, InfoFile), []byte(`{"db_type":"mysql","db_name":"other"}`), 0644)
+	err := Restore(dir, "/work/gva", mysqlConfig())
+	if err == nil || !strings.Contains(err.Error(), "重新保存快照") {
+		t.Errorf("库名不一致时 err = %v", err)
+	}
+}
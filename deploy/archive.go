@@ -0,0 +1,81 @@
+package deploy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Pack 把后端可执行文件和前端 dist 打包为 tar.gz：可执行文件放在 server/gva-server，
+// dist 中的文件放在 web/dist/ 下（与项目目录结构一致，nginx 指向 current/web/dist 即可），
+// extra 中的文件（例如软件物料清单）按文件名放在根目录
+func Pack(w io.Writer, binary, distDir string, extra ...string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := addDir(tw, "server"); err != nil {
+		return err
+	}
+	if err := addFile(tw, binary, "server/gva-server", 0755); err != nil {
+		return err
+	}
+	if err := addDir(tw, "web"); err != nil {
+		return err
+	}
+	err := filepath.WalkDir(distDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(distDir, path)
+		if err != nil {
+			return err
+		}
+		name := "web/dist"
+		if rel != "." {
+			name += "/" + filepath.ToSlash(rel)
+		}
+		if d.IsDir() {
+			return addDir(tw, name)
+		}
+		return addFile(tw, path, name, 0644)
+	})
+	if err != nil {
+		return err
+	}
+	for _, path := range extra {
+		if err := addFile(tw, path, filepath.Base(path), 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addDir 写入目录条目
+func addDir(tw *tar.Writer, name string) error {
+	return tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0755})
+}
+
+// addFile 写入文件（权限固定为 mode，Windows 上构建的可执行文件在服务器上也可以执行）
+func addFile(tw *tar.Writer, path, name string, mode int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: mode, Size: info.Size(), ModTime: info.ModTime()}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
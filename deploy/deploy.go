@@ -0,0 +1,343 @@
+// Package deploy 通过系统的 ssh / scp 客户端把生产构建的产物部署到登记的远程服务器。
+// 每次部署上传到带时间戳的版本目录 releases/<时间>，解压完成后再把 current 符号链接切换过去
+// （先建临时链接再 mv -T 覆盖，切换是原子的，不会出现新旧文件混杂的状态）；
+// 回滚只需把 current 切回上一个版本目录。部署目录结构：
+//
+//	<Dir>/releases/20240501-103000/server/gva-server
+//	<Dir>/releases/20240501-103000/web/dist/...
+//	<Dir>/shared/config.yaml    # 各版本共用的文件，部署时链接到 server/ 下
+//	<Dir>/current -> releases/20240501-103000
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+const (
+	ReleasesDir = "releases" // 版本目录
+	SharedDir   = "shared"   // 各版本共用的文件（config.yaml 等），部署时链接到版本的 server/ 下
+	CurrentLink = "current"  // 指向当前版本的符号链接
+)
+
+// DefaultKeep 默认保留的版本数
+const DefaultKeep = 5
+
+// releaseLayout 版本目录名的时间格式（按字符串排序即按时间先后）
+const releaseLayout = "20060102-150405"
+
+// releasePattern 版本目录名
+var releasePattern = regexp.MustCompile(`^\d{8}-\d{6}$`)
+
+// Target 部署目标（需要已配置 SSH 密钥登录，面板不会输入密码）
+type Target struct {
+	Host     string // 服务器地址
+	Port     int    // SSH 端口（0 表示 22）
+	User     string // 用户名（为空时使用 ssh 配置中的默认用户）
+	Identity string // 私钥文件（为空时使用 ssh 的默认密钥和 ssh-agent）
+
+	Dir     string // 部署目录，例如 /opt/gva
+	Restart string // 切换版本后在服务器上执行的命令，例如 systemctl restart gva（为空时不执行）
+	Keep    int    // 保留的版本数（0 表示 DefaultKeep）
+}
+
+// Validate 检查必填项
+func (t Target) Validate() error {
+	dir := strings.TrimSpace(t.Dir)
+	switch {
+	case strings.TrimSpace(t.Host) == "":
+		return fmt.Errorf("请填写服务器地址")
+	case dir == "":
+		return fmt.Errorf("请填写部署目录")
+	case !strings.HasPrefix(dir, "/") && !strings.HasPrefix(dir, "~/"):
+		return fmt.Errorf("部署目录需要是绝对路径（例如 /opt/gva）")
+	case path.Clean(dir) == "/":
+		return fmt.Errorf("部署目录不能是根目录")
+	case t.Port < 0 || t.Port > 65535:
+		return fmt.Errorf("SSH 端口无效")
+	}
+	return nil
+}
+
+// keep 保留的版本数
+func (t Target) keep() int {
+	if t.Keep > 0 {
+		return t.Keep
+	}
+	return DefaultKeep
+}
+
+// ReleaseID 部署时间对应的版本目录名
+func ReleaseID(now time.Time) string {
+	return now.Format(releaseLayout)
+}
+
+// ReleaseTime 版本目录名对应的部署时间（本地时间）
+func ReleaseTime(id string) (time.Time, bool) {
+	t, err := time.ParseInLocation(releaseLayout, id, time.Local)
+	return t, err == nil
+}
+
+// sshOptions ssh 和 scp 共用的选项（BatchMode 避免等待密码输入）
+func sshOptions(t Target, portFlag string) []string {
+	args := []string{"-C", "-o", "BatchMode=yes", "-o", "ServerAliveInterval=30"}
+	if t.Port > 0 && t.Port != 22 {
+		args = append(args, portFlag, strconv.Itoa(t.Port))
+	}
+	if t.Identity != "" {
+		args = append(args, "-i", t.Identity, "-o", "IdentitiesOnly=yes")
+	}
+	return args
+}
+
+// host ssh 的目标主机（带用户名）
+func (t Target) host() string {
+	host := strings.TrimSpace(t.Host)
+	if user := strings.TrimSpace(t.User); user != "" {
+		host = user + "@" + host
+	}
+	return host
+}
+
+// SSH 在服务器上执行 remote 的 ssh 命令（选项以 -- 结束，以 - 开头的地址不会被当作选项）
+func SSH(t Target, remote string) (name string, args []string) {
+	return "ssh", append(sshOptions(t, "-p"), "--", t.host(), remote)
+}
+
+// SCP 把本地文件上传到服务器 remote 路径的 scp 命令
+func SCP(t Target, local, remote string) (name string, args []string) {
+	return "scp", append(sshOptions(t, "-P"), "--", local, t.host()+":"+remote)
+}
+
+// dir 部署目录（~/ 开头时交给服务器的 shell 展开）
+func (t Target) dir() string {
+	dir := path.Clean(strings.TrimSpace(t.Dir))
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		return `"$HOME"/` + sysutil.ShellQuote(rest)
+	}
+	return sysutil.ShellQuote(dir)
+}
+
+// ArchiveName 上传到服务器的压缩包名称（位于 releases/ 下，解压后删除）
+func ArchiveName(id string) string {
+	return id + ".tar.gz"
+}
+
+// UploadPath 压缩包在服务器上的路径（scp 的目标，~/ 开头时相对于用户主目录）
+func UploadPath(t Target, id string) string {
+	dir := path.Clean(strings.TrimSpace(t.Dir))
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		dir = rest
+	}
+	return path.Join(dir, ReleasesDir, ArchiveName(id))
+}
+
+// PrepareScript 上传前在服务器上创建部署目录
+func PrepareScript(t Target) string {
+	return "mkdir -p " + t.dir() + "/" + ReleasesDir + " " + t.dir() + "/" + SharedDir
+}
+
+// ActivateScript 上传后在服务器上执行：解压到版本目录，把 shared/ 中的文件链接到 server/ 下，
+// 再原子地切换 current，最后执行重启命令
+func ActivateScript(t Target, id string) string {
+	release := ReleasesDir + "/" + id
+	steps := []string{
+		"set -e",
+		"cd " + t.dir(),
+		"mkdir " + release,
+		"tar -xzf " + ReleasesDir + "/" + ArchiveName(id) + " -C " + release,
+		"rm -f " + ReleasesDir + "/" + ArchiveName(id),
+		`for f in ` + SharedDir + `/* ` + SharedDir + `/.[!.]*; do if [ -e "$f" ]; then ln -sfn "../../../$f" ` + release + `/server/; fi; done`,
+	}
+	return strings.Join(append(steps, switchSteps(t, id)...), "\n")
+}
+
+// SwitchScript 把 current 切换到已有的版本目录并执行重启命令（回滚时使用）
+func SwitchScript(t Target, id string) string {
+	steps := []string{
+		"set -e",
+		"cd " + t.dir(),
+		"test -d " + ReleasesDir + "/" + id,
+	}
+	return strings.Join(append(steps, switchSteps(t, id)...), "\n")
+}
+
+// switchSteps 原子地切换 current：先建临时链接，再用 mv -T 覆盖（不会出现 current 不存在的瞬间）
+func switchSteps(t Target, id string) []string {
+	steps := []string{
+		"ln -sfn " + ReleasesDir + "/" + id + " " + CurrentLink + ".tmp",
+		"mv -Tf " + CurrentLink + ".tmp " + CurrentLink,
+	}
+	if restart := strings.TrimSpace(t.Restart); restart != "" {
+		steps = append(steps, "cd "+CurrentLink+"/server", restart)
+	}
+	return steps
+}
+
+// ListScript 列出服务器上的版本：第一行为 current 指向的目录，之后每行一个版本目录
+func ListScript(t Target) string {
+	return "cd " + t.dir() + " 2>/dev/null || exit 0\n" +
+		`printf 'current=%s\n' "$(readlink ` + CurrentLink + ` 2>/dev/null)"` + "\n" +
+		"ls -1 " + ReleasesDir + " 2>/dev/null; true"
+}
+
+// PruneScript 删除旧的版本目录
+func PruneScript(t Target, ids []string) string {
+	var dirs []string
+	for _, id := range ids {
+		dirs = append(dirs, ReleasesDir+"/"+id)
+	}
+	return "cd " + t.dir() + " && rm -rf " + strings.Join(dirs, " ")
+}
+
+// Releases 服务器上的版本
+type Releases struct {
+	IDs     []string // 版本目录（从新到旧）
+	Current string   // current 指向的版本（为空时尚未部署或链接已损坏）
+}
+
+// ParseList 解析 ListScript 的输出（忽略未解压完的压缩包等非版本目录）
+func ParseList(output string) Releases {
+	var r Releases
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if target, ok := strings.CutPrefix(line, "current="); ok {
+			id := path.Base(strings.TrimRight(target, "/"))
+			if releasePattern.MatchString(id) {
+				r.Current = id
+			}
+			continue
+		}
+		if releasePattern.MatchString(line) {
+			r.IDs = append(r.IDs, line)
+		}
+	}
+	slices.Sort(r.IDs)
+	slices.Reverse(r.IDs)
+	return r
+}
+
+// Previous 当前版本之前最近的一个版本（回滚的目标）；current 未指向任何版本时为最新的版本
+func (r Releases) Previous() (string, bool) {
+	for _, id := range r.IDs {
+		if r.Current == "" || id < r.Current {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Prunable 超出保留数量、可以删除的版本（当前版本和它的上一个版本始终保留，保证可以回滚）
+func (r Releases) Prunable(keep int) []string {
+	previous, _ := r.Previous()
+	var prune []string
+	for i, id := range r.IDs {
+		if i < keep || id == r.Current || id == previous {
+			continue
+		}
+		prune = append(prune, id)
+	}
+	return prune
+}
+
+// run 执行 ssh / scp 命令，输出写入 w；ctx 取消时结束进程
+func run(ctx context.Context, w io.Writer, name string, args []string) error {
+	return sysutil.RunOutputContext(ctx, "", w, name, args...)
+}
+
+// List 读取服务器上的版本
+func List(ctx context.Context, t Target) (Releases, error) {
+	name, args := SSH(t, ListScript(t))
+	output, err := sysutil.CombinedOutputContext(ctx, "", name, args...)
+	if err != nil {
+		return Releases{}, apperr.Errorf(apperr.DeployFailed, "读取服务器上的版本失败: %v\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return ParseList(string(output)), nil
+}
+
+// Upload 把本地的压缩包（Pack 生成）部署为新版本：上传、解压、切换 current、执行重启命令，
+// 最后删除超出保留数量的旧版本。返回新版本的目录名
+func Upload(ctx context.Context, t Target, archive string, w io.Writer) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "%v", err)
+	}
+	id := ReleaseID(time.Now())
+
+	fmt.Fprintf(w, "创建部署目录 %s\n", t.Dir)
+	name, args := SSH(t, PrepareScript(t))
+	if err := run(ctx, w, name, args); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "连接服务器失败: %v", err)
+	}
+
+	fmt.Fprintf(w, "上传 %s\n", ArchiveName(id))
+	name, args = SCP(t, archive, UploadPath(t, id))
+	if err := run(ctx, w, name, args); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "上传失败: %v", err)
+	}
+
+	fmt.Fprintf(w, "解压到 %s/%s 并切换 %s\n", ReleasesDir, id, CurrentLink)
+	name, args = SSH(t, ActivateScript(t, id))
+	if err := run(ctx, w, name, args); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "切换到新版本失败: %v", err)
+	}
+
+	prune(ctx, t, w)
+	return id, nil
+}
+
+// Rollback 把 current 切回上一个版本并执行重启命令，返回切换到的版本
+func Rollback(ctx context.Context, t Target, w io.Writer) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "%v", err)
+	}
+	releases, err := List(ctx, t)
+	if err != nil {
+		return "", err
+	}
+	id, ok := releases.Previous()
+	if !ok {
+		return "", apperr.Errorf(apperr.DeployFailed, "服务器上没有可以回滚的上一个版本")
+	}
+	return id, Switch(ctx, t, id, w)
+}
+
+// Switch 把 current 切换到指定的版本并执行重启命令
+func Switch(ctx context.Context, t Target, id string, w io.Writer) error {
+	if !releasePattern.MatchString(id) {
+		return apperr.Errorf(apperr.DeployFailed, "版本目录名无效: %s", id)
+	}
+	fmt.Fprintf(w, "切换 %s 到 %s/%s\n", CurrentLink, ReleasesDir, id)
+	name, args := SSH(t, SwitchScript(t, id))
+	if err := run(ctx, w, name, args); err != nil {
+		return apperr.Errorf(apperr.DeployFailed, "切换版本失败: %v", err)
+	}
+	return nil
+}
+
+// prune 删除超出保留数量的旧版本（失败时只记录，不影响部署结果）
+func prune(ctx context.Context, t Target, w io.Writer) {
+	releases, err := List(ctx, t)
+	if err != nil {
+		fmt.Fprintf(w, "⚠️ 清理旧版本失败: %v\n", err)
+		return
+	}
+	ids := releases.Prunable(t.keep())
+	if len(ids) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "删除旧版本: %s\n", strings.Join(ids, " "))
+	name, args := SSH(t, PruneScript(t, ids))
+	if err := run(ctx, w, name, args); err != nil {
+		fmt.Fprintf(w, "⚠️ 清理旧版本失败: %v\n", err)
+	}
+}
@@ -0,0 +1,182 @@
+package deploy
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestCommands(t *testing.T) {
+	target := Target{Host: "10.0.0.5", Port: 2222, User: "deploy", Identity: "/tmp/keys/prod", Dir: "/opt/gva"}
+	name, args := SSH(target, "true")
+	got := strings.Join(append([]string{name}, args...), " ")
+	want := "ssh -C -o BatchMode=yes -o ServerAliveInterval=30 -p 2222 -i /tmp/keys/prod -o IdentitiesOnly=yes -- deploy@10.0.0.5 true"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// scp 的端口参数是大写的 -P
+	name, args = SCP(target, "/tmp/release.tar.gz", UploadPath(target, "20240501-103000"))
+	got = strings.Join(append([]string{name}, args...), " ")
+	if !strings.Contains(got, " -P 2222 ") || !strings.HasSuffix(got, " -- /tmp/release.tar.gz deploy@10.0.0.5:/opt/gva/releases/20240501-103000.tar.gz") {
+		t.Errorf("scp = %q", got)
+	}
+
+	home := Target{Host: "h", Dir: "~/apps/gva/"}
+	if got := UploadPath(home, "20240501-103000"); got != "apps/gva/releases/20240501-103000.tar.gz" {
+		t.Errorf("UploadPath = %q", got)
+	}
+	if got := PrepareScript(home); got != `mkdir -p "$HOME"/'apps/gva'/releases "$HOME"/'apps/gva'/shared` {
+		t.Errorf("PrepareScript = %q", got)
+	}
+}
+
+func TestActivateScript(t *testing.T) {
+	script := ActivateScript(Target{Host: "h", Dir: "/opt/gva", Restart: "sudo systemctl restart gva"}, "20240501-103000")
+	lines := strings.Split(script, "\n")
+	if lines[0] != "set -e" || lines[1] != "cd '/opt/gva'" {
+		t.Errorf("script = %q", script)
+	}
+	// 解压完成后才切换 current，切换使用临时链接加 mv -T
+	extract := slices.Index(lines, "tar -xzf releases/20240501-103000.tar.gz -C releases/20240501-103000")
+	link := slices.Index(lines, "ln -sfn releases/20240501-103000 current.tmp")
+	move := slices.Index(lines, "mv -Tf current.tmp current")
+	if extract < 0 || link < extract || move != link+1 {
+		t.Errorf("script = %q", script)
+	}
+	if lines[len(lines)-2] != "cd current/server" || lines[len(lines)-1] != "sudo systemctl restart gva" {
+		t.Errorf("重启命令应在切换后执行: %q", script)
+	}
+
+	if strings.Contains(SwitchScript(Target{Host: "h", Dir: "/opt/gva"}, "20240501-103000"), "cd current/server") {
+		t.Error("没有重启命令时不应执行")
+	}
+}
+
+func TestTargetValidate(t *testing.T) {
+	for _, target := range []Target{
+		{Dir: "/opt/gva"},
+		{Host: "h"},
+		{Host: "h", Dir: "opt/gva"},
+		{Host: "h", Dir: "/"},
+		{Host: "h", Dir: "/opt/gva", Port: 70000},
+	} {
+		if err := target.Validate(); err == nil {
+			t.Errorf("%+v 应报错", target)
+		}
+	}
+	if err := (Target{Host: "h", Dir: "~/gva"}).Validate(); err != nil {
+		t.Errorf("~/ 开头的目录应可用: %v", err)
+	}
+}
+
+func TestParseListAndPrune(t *testing.T) {
+	output := "current=releases/20240503-090000\n20240501-103000\n20240505-120000.tar.gz\n20240503-090000\n20240502-080000\n20240504-100000\nnotes\n"
+	r := ParseList(output)
+	if strings.Join(r.IDs, ",") != "20240504-100000,20240503-090000,20240502-080000,20240501-103000" || r.Current != "20240503-090000" {
+		t.Errorf("releases = %+v", r)
+	}
+	// 回滚到当前版本之前的版本，而不是更新的版本
+	if id, ok := r.Previous(); !ok || id != "20240502-080000" {
+		t.Errorf("Previous = %q, %v", id, ok)
+	}
+	// 只保留 1 个时，当前版本和上一个版本仍然保留
+	if got := r.Prunable(1); strings.Join(got, ",") != "20240501-103000" {
+		t.Errorf("Prunable = %v", got)
+	}
+	if got := r.Prunable(DefaultKeep); got != nil {
+		t.Errorf("Prunable = %v", got)
+	}
+
+	if _, ok := ParseList("current=releases/20240501-103000\n20240501-103000\n").Previous(); ok {
+		t.Error("只有一个版本时不能回滚")
+	}
+	if r := ParseList("current=\n"); r.Current != "" || len(r.IDs) != 0 {
+		t.Errorf("尚未部署: %+v", r)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	target := Target{Host: "h", Dir: "/opt/gva", Restart: "systemctl restart gva"}
+	runner := sysutiltest.New(t)
+	name, args := SSH(target, ListScript(target))
+	runner.Handle(strings.Join(append([]string{name}, args...), " "), "current=releases/20240502-080000\n20240501-103000\n20240502-080000\n", nil)
+	name, args = SSH(target, SwitchScript(target, "20240501-103000"))
+	switchCmd := strings.Join(append([]string{name}, args...), " ")
+	runner.Handle(switchCmd, "", nil)
+
+	var log bytes.Buffer
+	id, err := Rollback(context.Background(), target, &log)
+	if err != nil || id != "20240501-103000" {
+		t.Fatalf("Rollback = %q, %v", id, err)
+	}
+	if !runner.Called(switchCmd) {
+		t.Error("应切换到上一个版本")
+	}
+}
+
+func TestPack(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "gva-server")
+	os.WriteFile(binary, []byte("bin"), 0644)
+	dist := filepath.Join(dir, "dist")
+	os.MkdirAll(filepath.Join(dist, "assets"), 0755)
+	os.WriteFile(filepath.Join(dist, "index.html"), []byte("<html>"), 0644)
+	os.WriteFile(filepath.Join(dist, "assets", "app.js"), []byte("js"), 0644)
+
+	sbom := filepath.Join(dir, "sbom.cdx.json")
+	os.WriteFile(sbom, []byte("{}"), 0644)
+
+	var buf bytes.Buffer
+	if err := Pack(&buf, binary, dist, sbom); err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string]int64{}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[h.Name] = h.Mode
+	}
+	for name, mode := range map[string]int64{
+		"server/gva-server":      0755,
+		"web/dist/index.html":    0644,
+		"web/dist/assets/app.js": 0644,
+		"web/dist/assets/":       0755,
+		"sbom.cdx.json":          0644,
+	} {
+		if files[name] != mode {
+			t.Errorf("%s: mode = %o, want %o (files = %v)", name, files[name], mode, files)
+		}
+	}
+}
+
+func TestReleaseID(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.Local)
+	id := ReleaseID(now)
+	if id != "20240501-103000" {
+		t.Errorf("id = %q", id)
+	}
+	if got, ok := ReleaseTime(id); !ok || !got.Equal(now) {
+		t.Errorf("ReleaseTime = %v, %v", got, ok)
+	}
+}
@@ -0,0 +1,133 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/supervisor"
+)
+
+// DefaultHealthTimeout 部署后等待服务健康的默认时长
+const DefaultHealthTimeout = time.Minute
+
+// HealthInterval 两次健康探测之间的间隔（测试中可修改）
+var HealthInterval = 3 * time.Second
+
+// probeTimeout 单次探测的超时
+const probeTimeout = 5 * time.Second
+
+// Health 部署后的健康检查：切换版本后在超时时间内反复请求健康检查地址，返回 2xx 即视为健康
+type Health struct {
+	URL      string        // 健康检查地址，例如 https://admin.example.com/api/health（为空时不检查）
+	Remote   bool          // 在服务器上通过 ssh 执行 curl 请求（地址只能在服务器上访问时，例如 http://127.0.0.1:8888/health）
+	Timeout  time.Duration // 等待服务健康的时长（0 表示 DefaultHealthTimeout）
+	Rollback bool          // 超时仍不健康时自动回滚到上一个版本
+}
+
+// Enabled 是否需要健康检查
+func (h Health) Enabled() bool {
+	return strings.TrimSpace(h.URL) != ""
+}
+
+// Validate 检查健康检查地址（未填写时不检查）
+func (h Health) Validate() error {
+	if !h.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(strings.TrimSpace(h.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("健康检查地址需要是完整的 http(s) 地址，例如 https://admin.example.com/api/health")
+	}
+	return nil
+}
+
+// timeout 等待服务健康的时长
+func (h Health) timeout() time.Duration {
+	if h.Timeout > 0 {
+		return h.Timeout
+	}
+	return DefaultHealthTimeout
+}
+
+// HealthScript 在服务器上探测健康检查地址的命令（只输出 HTTP 状态码，连接失败时 curl 以非 0 退出）
+func HealthScript(rawURL string) string {
+	return fmt.Sprintf("curl -sS -o /dev/null -m %d -w '%%{http_code}' %s", int(probeTimeout.Seconds()), sysutil.ShellQuote(strings.TrimSpace(rawURL)))
+}
+
+// probe 探测一次，返回 nil 表示健康
+func probe(ctx context.Context, client *http.Client, t Target, h Health) error {
+	if h.Remote {
+		name, args := SSH(t, HealthScript(h.URL))
+		output, err := sysutil.CombinedOutputContext(ctx, "", name, args...)
+		code := strings.TrimSpace(string(output))
+		if err != nil {
+			return fmt.Errorf("%v %s", err, code)
+		}
+		if !strings.HasPrefix(code, "2") {
+			return fmt.Errorf("HTTP %s", code)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSpace(h.URL), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WaitHealthy 在超时时间内每隔 HealthInterval 探测一次，直到健康；超时时返回最后一次探测的错误
+func WaitHealthy(ctx context.Context, client *http.Client, t Target, h Health, w io.Writer) error {
+	deadline := time.Now().Add(h.timeout())
+	fmt.Fprintf(w, "等待服务健康（%s，最长 %d 秒）\n", strings.TrimSpace(h.URL), int(h.timeout().Seconds()))
+	for attempt := 1; ; attempt++ {
+		err := probe(ctx, client, t, h)
+		if err == nil {
+			fmt.Fprintf(w, "✅ 健康检查通过（第 %d 次探测）\n", attempt)
+			return nil
+		}
+		fmt.Fprintf(w, "第 %d 次探测: %v\n", attempt, err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if time.Now().Add(HealthInterval).After(deadline) {
+			return apperr.Errorf(apperr.DeployUnhealthy, "%d 秒内健康检查没有通过，最后一次: %v", int(h.timeout().Seconds()), err)
+		}
+		if !supervisor.Sleep(ctx, HealthInterval) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Gate 部署后的健康关卡：不健康且开启了自动回滚时切回上一个版本，返回回滚到的版本（没有回滚时为空）
+// 和健康检查的错误（健康时为 nil）
+func Gate(ctx context.Context, client *http.Client, t Target, h Health, w io.Writer) (string, error) {
+	err := WaitHealthy(ctx, client, t, h, w)
+	if err == nil || ctx.Err() != nil || !h.Rollback {
+		return "", err
+	}
+
+	fmt.Fprintln(w, "服务不健康，自动回滚到上一个版本")
+	id, rollbackErr := Rollback(ctx, t, w)
+	if rollbackErr != nil {
+		return "", apperr.Errorf(apperr.DeployUnhealthy, "%v；自动回滚也失败了: %v", err, rollbackErr)
+	}
+	return id, apperr.Errorf(apperr.DeployUnhealthy, "%v；已自动回滚到 %s", err, id)
+}
@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestWaitHealthy(t *testing.T) {
+	HealthInterval = 10 * time.Millisecond
+	defer func() { HealthInterval = 3 * time.Second }()
+
+	// 前两次返回 502（后端还在启动），之后健康
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	var log bytes.Buffer
+	h := Health{URL: srv.URL + "/health", Timeout: time.Second}
+	if err := WaitHealthy(context.Background(), srv.Client(), Target{}, h, &log); err != nil {
+		t.Fatalf("err = %v, log = %s", err, log.String())
+	}
+	if calls.Load() != 3 || !strings.Contains(log.String(), "第 2 次探测: HTTP 502") {
+		t.Errorf("calls = %d, log = %s", calls.Load(), log.String())
+	}
+}
+
+func TestGateRollsBack(t *testing.T) {
+	HealthInterval = 10 * time.Millisecond
+	defer func() { HealthInterval = 3 * time.Second }()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	target := Target{Host: "h", Dir: "/opt/gva"}
+	runner := sysutiltest.New(t)
+	name, args := SSH(target, ListScript(target))
+	runner.Handle(strings.Join(append([]string{name}, args...), " "), "current=releases/20240502-080000\n20240501-103000\n20240502-080000\n", nil)
+	name, args = SSH(target, SwitchScript(target, "20240501-103000"))
+	runner.Handle(strings.Join(append([]string{name}, args...), " "), "", nil)
+
+	var log bytes.Buffer
+	h := Health{URL: srv.URL, Timeout: 50 * time.Millisecond}
+	id, err := Gate(context.Background(), srv.Client(), target, h, &log)
+	if id != "" || apperr.CodeOf(err) != apperr.DeployUnhealthy {
+		t.Errorf("没有开启自动回滚: id = %q, err = %v", id, err)
+	}
+
+	h.Rollback = true
+	id, err = Gate(context.Background(), srv.Client(), target, h, &log)
+	if id != "20240501-103000" || apperr.CodeOf(err) != apperr.DeployUnhealthy || !strings.Contains(err.Error(), "已自动回滚到 20240501-103000") {
+		t.Errorf("id = %q, err = %v", id, err)
+	}
+}
+
+func TestRemoteProbe(t *testing.T) {
+	target := Target{Host: "h", Dir: "/opt/gva"}
+	h := Health{URL: "http://127.0.0.1:8888/health", Remote: true}
+	runner := sysutiltest.New(t)
+	name, args := SSH(target, HealthScript(h.URL))
+	command := strings.Join(append([]string{name}, args...), " ")
+	if !strings.HasSuffix(command, ` curl -sS -o /dev/null -m 5 -w '%{http_code}' 'http://127.0.0.1:8888/health'`) {
+		t.Errorf("command = %q", command)
+	}
+
+	runner.Handle(command, "503", nil)
+	if err := probe(context.Background(), nil, target, h); err == nil || err.Error() != "HTTP 503" {
+		t.Errorf("err = %v", err)
+	}
+	runner.Handle(command, "200", nil)
+	if err := probe(context.Background(), nil, target, h); err != nil {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestHealthValidate(t *testing.T) {
+	if err := (Health{}).Validate(); err != nil {
+		t.Errorf("未填写地址时不检查: %v", err)
+	}
+	for _, u := range []string{"admin.example.com/api/health", "ftp://h/health", "http://"} {
+		if err := (Health{URL: u}).Validate(); err == nil {
+			t.Errorf("%q 应报错", u)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// Package depreport 生成依赖周报：过期的前后端直接依赖、上次报告之后新出现的前端漏洞和上游 GVA 新版本，
+// 由定时任务每周生成，在面板中提醒并可通过邮件发送
+package depreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gva-launcher/gvarelease"
+	"gva-launcher/internal/sysutil"
+)
+
+// ActionID 定时任务中生成周报的动作名
+const ActionID = "dep-report"
+
+// WeeklyCron 每周生成的时间（周一 9:00）
+const WeeklyCron = "0 9 * * 1"
+
+// 依赖所属的生态
+const (
+	Go  = "go"
+	Npm = "npm"
+)
+
+// Outdated 有新版本的直接依赖
+type Outdated struct {
+	Ecosystem string // go 或 npm
+	Name      string
+	Current   string
+	Latest    string
+}
+
+// Vuln npm audit 报告的一个漏洞
+type Vuln struct {
+	Package  string
+	Severity string // low、moderate、high、critical
+	Title    string
+	URL      string
+}
+
+// Key 区分漏洞的标识（同一公告影响多个包时按包区分）
+func (v Vuln) Key() string {
+	id := v.URL
+	if id == "" {
+		id = v.Title
+	}
+	return v.Package + " " + id
+}
+
+// Report 一次生成的周报
+type Report struct {
+	Generated   time.Time
+	Outdated    []Outdated
+	Vulns       []Vuln   // 当前所有漏洞（按严重程度从高到低）
+	NewVulns    []Vuln   // 上次报告中没有的漏洞
+	GVAVersion  string   // 项目当前的 GVA 版本（读取不到时为空）
+	GVAReleases []string // 比项目新的上游版本（从新到旧）
+	Warnings    []string // 没有完成的检查及原因
+}
+
+// fetchReleases 查询上游发布（测试时替换）
+var fetchReleases = gvarelease.Fetch
+
+// Generate 检查项目 root 的依赖并生成周报；known 为上次报告的漏洞标识（见 Report.VulnKeys），
+// 为 nil 时所有漏洞都算新出现的。单项检查失败（没有网络、未安装依赖等）记入 Warnings，不中断其他检查
+func Generate(ctx context.Context, root string, known []string) Report {
+	r := Report{Generated: time.Now()}
+	serverDir, webDir := filepath.Join(root, "server"), filepath.Join(root, "web")
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"后端过期依赖", func() error {
+			outdated, err := OutdatedGo(serverDir)
+			r.Outdated = append(r.Outdated, outdated...)
+			return err
+		}},
+		{"前端过期依赖", func() error {
+			outdated, err := OutdatedNpm(webDir)
+			r.Outdated = append(r.Outdated, outdated...)
+			return err
+		}},
+		{"前端漏洞", func() (err error) {
+			r.Vulns, err = AuditNpm(webDir)
+			return err
+		}},
+		{"GVA 新版本", func() error {
+			releases, err := fetchReleases()
+			if err != nil {
+				return err
+			}
+			r.GVAVersion = gvarelease.ProjectVersion(root)
+			for _, rel := range gvarelease.Newer(releases, r.GVAVersion) {
+				r.GVAReleases = append(r.GVAReleases, rel.Tag)
+			}
+			return nil
+		}},
+	}
+	for _, step := range steps {
+		if ctx.Err() != nil {
+			r.Warnings = append(r.Warnings, step.name+": 已取消")
+			continue
+		}
+		if err := step.run(); err != nil {
+			r.Warnings = append(r.Warnings, step.name+": "+err.Error())
+		}
+	}
+
+	for _, v := range r.Vulns {
+		if !slices.Contains(known, v.Key()) {
+			r.NewVulns = append(r.NewVulns, v)
+		}
+	}
+	return r
+}
+
+// OutdatedGo 后端有新版本的直接依赖（go list -m -u，需要访问模块代理）
+func OutdatedGo(serverDir string) ([]Outdated, error) {
+	output, err := sysutil.Runner.Output(serverDir, "go", "list", "-m", "-u", "-json", "all")
+	if err != nil {
+		return nil, fmt.Errorf("go list -m -u 失败: %v", err)
+	}
+	return parseGoList(output)
+}
+
+// parseGoList 解析 go list -m -json 输出的连续 JSON 对象，只保留有更新的直接依赖
+func parseGoList(output []byte) ([]Outdated, error) {
+	var outdated []Outdated
+	dec := json.NewDecoder(bytes.NewReader(output))
+	for dec.More() {
+		var m struct {
+			Path     string
+			Version  string
+			Main     bool
+			Indirect bool
+			Update   *struct{ Version string }
+		}
+		if err := dec.Decode(&m); err != nil {
+			return outdated, fmt.Errorf("解析 go list 输出失败: %v", err)
+		}
+		if m.Main || m.Indirect || m.Update == nil {
+			continue
+		}
+		outdated = append(outdated, Outdated{Ecosystem: Go, Name: m.Path, Current: m.Version, Latest: m.Update.Version})
+	}
+	return outdated, nil
+}
+
+// OutdatedNpm 前端有新版本的直接依赖（npm outdated；有过期依赖时 npm 以非 0 状态退出，以输出为准）
+func OutdatedNpm(webDir string) ([]Outdated, error) {
+	output, err := sysutil.Runner.Output(webDir, "npm", "outdated", "--json")
+	outdated, parseErr := parseNpmOutdated(output)
+	if parseErr != nil || err != nil && len(bytes.TrimSpace(output)) == 0 {
+		if err != nil {
+			return nil, fmt.Errorf("npm outdated 失败: %v", err)
+		}
+		return nil, parseErr
+	}
+	return outdated, nil
+}
+
+// parseNpmOutdated 解析 npm outdated --json（包名 → 版本信息，按包名排序），跳过未安装的包
+func parseNpmOutdated(output []byte) ([]Outdated, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+	var packages map[string]struct {
+		Current string `json:"current"`
+		Latest  string `json:"latest"`
+	}
+	if err := json.Unmarshal(output, &packages); err != nil {
+		return nil, fmt.Errorf("解析 npm outdated 输出失败: %v", err)
+	}
+	var outdated []Outdated
+	for name, p := range packages {
+		if p.Current == "" || p.Latest == "" || p.Latest == p.Current {
+			continue
+		}
+		outdated = append(outdated, Outdated{Ecosystem: Npm, Name: name, Current: p.Current, Latest: p.Latest})
+	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].Name < outdated[j].Name })
+	return outdated, nil
+}
+
+// AuditNpm 前端依赖的漏洞（npm audit；有漏洞时 npm 以非 0 状态退出，以输出为准）
+func AuditNpm(webDir string) ([]Vuln, error) {
+	output, err := sysutil.Runner.Output(webDir, "npm", "audit", "--json")
+	vulns, parseErr := parseNpmAudit(output)
+	if parseErr != nil {
+		if err != nil {
+			return nil, fmt.Errorf("npm audit 失败: %v", err)
+		}
+		return nil, parseErr
+	}
+	return vulns, nil
+}
+
+// severityRank 严重程度的排序（越大越严重）
+var severityRank = map[string]int{"info": 0, "low": 1, "moderate": 2, "high": 3, "critical": 4}
+
+// parseNpmAudit 解析 npm 7 及以上版本 npm audit --json 的 vulnerabilities：
+// via 中的对象是公告，字符串只是指向其他有漏洞的包（间接受影响），不重复计入
+func parseNpmAudit(output []byte) ([]Vuln, error) {
+	var audit struct {
+		Vulnerabilities map[string]struct {
+			Via []json.RawMessage `json:"via"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(output, &audit); err != nil {
+		return nil, fmt.Errorf("解析 npm audit 输出失败: %v", err)
+	}
+	var vulns []Vuln
+	seen := make(map[string]bool)
+	for _, v := range audit.Vulnerabilities {
+		for _, via := range v.Via {
+			var advisory struct {
+				Name     string `json:"name"`
+				Severity string `json:"severity"`
+				Title    string `json:"title"`
+				URL      string `json:"url"`
+			}
+			if json.Unmarshal(via, &advisory) != nil || advisory.Name == "" {
+				continue
+			}
+			vuln := Vuln{Package: advisory.Name, Severity: advisory.Severity, Title: advisory.Title, URL: advisory.URL}
+			if !seen[vuln.Key()] {
+				seen[vuln.Key()] = true
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+	sort.Slice(vulns, func(i, j int) bool {
+		if a, b := severityRank[vulns[i].Severity], severityRank[vulns[j].Severity]; a != b {
+			return a > b
+		}
+		return vulns[i].Key() < vulns[j].Key()
+	})
+	return vulns, nil
+}
+
+// VulnKeys 本次报告的漏洞标识（保存后作为下次生成的 known）
+func (r Report) VulnKeys() []string {
+	keys := make([]string, 0, len(r.Vulns))
+	for _, v := range r.Vulns {
+		keys = append(keys, v.Key())
+	}
+	return keys
+}
+
+// HasNews 是否有需要关注的内容（过期依赖、新漏洞或 GVA 新版本）
+func (r Report) HasNews() bool {
+	return len(r.Outdated) > 0 || len(r.NewVulns) > 0 || len(r.GVAReleases) > 0
+}
+
+// Summary 一行摘要（用于通知）
+func (r Report) Summary() string {
+	var parts []string
+	if len(r.NewVulns) > 0 {
+		parts = append(parts, fmt.Sprintf("新漏洞 %d 个", len(r.NewVulns)))
+	}
+	if len(r.Outdated) > 0 {
+		parts = append(parts, fmt.Sprintf("过期依赖 %d 个", len(r.Outdated)))
+	}
+	if len(r.GVAReleases) > 0 {
+		parts = append(parts, fmt.Sprintf("GVA %s 已发布", r.GVAReleases[0]))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "依赖都是最新的，没有新漏洞")
+	}
+	if len(r.Warnings) > 0 {
+		parts = append(parts, fmt.Sprintf("%d 项检查未完成", len(r.Warnings)))
+	}
+	return strings.Join(parts, "，")
+}
+
+// ecosystemLabel 依赖所属的一端
+func ecosystemLabel(ecosystem string) string {
+	if ecosystem == Go {
+		return "后端"
+	}
+	return "前端"
+}
+
+// Text 周报正文（纯文本，面板显示和邮件共用）
+func (r Report) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "GVA 依赖周报（%s）\n%s\n", r.Generated.Format("2006-01-02 15:04"), r.Summary())
+
+	if len(r.NewVulns) > 0 {
+		fmt.Fprintf(&b, "\n■ 新漏洞（%d，当前共 %d 个）\n", len(r.NewVulns), len(r.Vulns))
+		for _, v := range r.NewVulns {
+			fmt.Fprintf(&b, "  [%s] %s: %s\n", v.Severity, v.Package, v.Title)
+			if v.URL != "" {
+				fmt.Fprintf(&b, "      %s\n", v.URL)
+			}
+		}
+		b.WriteString("  在 web 目录执行 npm audit fix 修复可自动升级的漏洞\n")
+	} else if len(r.Vulns) > 0 {
+		fmt.Fprintf(&b, "\n■ 漏洞: 没有新漏洞（仍有 %d 个未修复）\n", len(r.Vulns))
+	}
+
+	if len(r.Outdated) > 0 {
+		fmt.Fprintf(&b, "\n■ 过期依赖（%d）\n", len(r.Outdated))
+		for _, o := range r.Outdated {
+			fmt.Fprintf(&b, "  %s %s %s → %s\n", ecosystemLabel(o.Ecosystem), o.Name, o.Current, o.Latest)
+		}
+	}
+
+	if len(r.GVAReleases) > 0 {
+		current := r.GVAVersion
+		if current == "" {
+			current = "未知"
+		}
+		fmt.Fprintf(&b, "\n■ GVA 新版本\n  项目当前 %s，上游已发布 %s\n  %s\n", current, strings.Join(r.GVAReleases, "、"), gvarelease.PageURL)
+	}
+
+	if len(r.Warnings) > 0 {
+		b.WriteString("\n■ 未完成的检查\n")
+		for _, w := range r.Warnings {
+			fmt.Fprintf(&b, "  %s\n", w)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package depreport
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/gvarelease"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+const goList = `{
+	"Path": "github.com/flipped-aurora/gin-vue-admin/server",
+	"Main": true
+}
+{
+	"Path": "github.com/gin-gonic/gin",
+	"Version": "v1.9.1",
+	"Update": {"Path": "github.com/gin-gonic/gin", "Version": "v1.10.0"}
+}
+{
+	"Path": "golang.org/x/net",
+	"Version": "v0.17.0",
+	"Update": {"Path": "golang.org/x/net", "Version": "v0.30.0"},
+	"Indirect": true
+}
+{
+	"Path": "gorm.io/gorm",
+	"Version": "v1.25.12"
+}
+`
+
+const npmOutdated = `{
+  "vue": {"current": "3.3.4", "wanted": "3.3.13", "latest": "3.5.12", "location": "node_modules/vue"},
+  "axios": {"wanted": "1.7.7", "latest": "1.7.7", "location": ""}
+}`
+
+const npmAudit = `{
+  "auditReportVersion": 2,
+  "vulnerabilities": {
+    "axios": {
+      "name": "axios", "severity": "moderate",
+      "via": [{"source": 1097679, "name": "axios", "title": "Axios Cross-Site Request Forgery Vulnerability", "url": "https://github.com/advisories/GHSA-wf5p-g6vw-rhxx", "severity": "moderate"}]
+    },
+    "follow-redirects": {
+      "name": "follow-redirects", "severity": "high",
+      "via": [{"source": 1096856, "name": "follow-redirects", "title": "Improper Input Validation", "url": "https://github.com/advisories/GHSA-jchw-25xp-jwwc", "severity": "high"}]
+    },
+    "some-wrapper": {"name": "some-wrapper", "severity": "high", "via": ["follow-redirects"]}
+  }
+}`
+
+func TestParse(t *testing.T) {
+	outdated, err := parseGoList([]byte(goList))
+	if err != nil || len(outdated) != 1 || outdated[0] != (Outdated{Ecosystem: Go, Name: "github.com/gin-gonic/gin", Current: "v1.9.1", Latest: "v1.10.0"}) {
+		t.Errorf("go: %+v, err = %v", outdated, err)
+	}
+
+	outdated, err = parseNpmOutdated([]byte(npmOutdated))
+	if err != nil || len(outdated) != 1 || outdated[0].Name != "vue" || outdated[0].Latest != "3.5.12" {
+		t.Errorf("npm: %+v, err = %v", outdated, err)
+	}
+	if outdated, err := parseNpmOutdated([]byte("{}")); err != nil || outdated != nil {
+		t.Errorf("没有过期依赖: %+v, %v", outdated, err)
+	}
+
+	vulns, err := parseNpmAudit([]byte(npmAudit))
+	if err != nil || len(vulns) != 2 {
+		t.Fatalf("vulns = %+v, err = %v", vulns, err)
+	}
+	if vulns[0].Package != "follow-redirects" || vulns[0].Severity != "high" {
+		t.Errorf("应按严重程度排序: %+v", vulns)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "server", "global"), 0755)
+	os.WriteFile(filepath.Join(root, "server", "global", "version.go"), []byte(`package global
+
+const Version = "v2.7.0"
+`), 0644)
+	fetchReleases = func() ([]gvarelease.Release, error) {
+		return []gvarelease.Release{{Tag: "v2.8.0"}, {Tag: "v2.7.0"}}, nil
+	}
+	t.Cleanup(func() { fetchReleases = gvarelease.Fetch })
+
+	runner := sysutiltest.New(t)
+	runner.Handle("go list -m -u -json all", goList, nil)
+	runner.Handle("npm outdated --json", npmOutdated, errors.New("exit status 1"))
+	runner.Handle("npm audit --json", npmAudit, errors.New("exit status 1"))
+
+	known := []string{"axios https://github.com/advisories/GHSA-wf5p-g6vw-rhxx"}
+	r := Generate(context.Background(), root, known)
+	if len(r.Warnings) != 0 {
+		t.Fatalf("warnings = %v", r.Warnings)
+	}
+	if len(r.Outdated) != 2 || len(r.Vulns) != 2 || len(r.NewVulns) != 1 || r.NewVulns[0].Package != "follow-redirects" {
+		t.Errorf("report = %+v", r)
+	}
+	if r.GVAVersion != "v2.7.0" || strings.Join(r.GVAReleases, " ") != "v2.8.0" {
+		t.Errorf("gva = %s %v", r.GVAVersion, r.GVAReleases)
+	}
+	if r.Summary() != "新漏洞 1 个，过期依赖 2 个，GVA v2.8.0 已发布" {
+		t.Errorf("summary = %q", r.Summary())
+	}
+	text := r.Text()
+	for _, s := range []string{"[high] follow-redirects", "后端 github.com/gin-gonic/gin v1.9.1 → v1.10.0", "前端 vue 3.3.4 → 3.5.12", "项目当前 v2.7.0"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("周报中缺少 %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "GHSA-wf5p") {
+		t.Errorf("上次报告过的漏洞不再列出:\n%s", text)
+	}
+}
+
+func TestGenerateWarnings(t *testing.T) {
+	fetchReleases = func() ([]gvarelease.Release, error) { return nil, errors.New("查询 GVA 发布失败") }
+	t.Cleanup(func() { fetchReleases = gvarelease.Fetch })
+	sysutiltest.New(t)
+
+	r := Generate(context.Background(), t.TempDir(), nil)
+	if len(r.Warnings) != 4 || r.HasNews() {
+		t.Errorf("report = %+v", r)
+	}
+	if !strings.HasSuffix(r.Summary(), "4 项检查未完成") {
+		t.Errorf("summary = %q", r.Summary())
+	}
+}
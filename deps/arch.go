@@ -0,0 +1,158 @@
+package deps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// goCandidates / nodeCandidates macOS 上常见的 go、node 安装位置
+// （/opt/homebrew 为 Apple Silicon 的 Homebrew，/usr/local 为 Intel 的 Homebrew 或官方安装包）
+var (
+	goCandidates = func() []string {
+		return []string{"/opt/homebrew/bin/go", "/usr/local/go/bin/go", "/usr/local/bin/go"}
+	}
+	nodeCandidates = func() []string {
+		paths := []string{"/opt/homebrew/bin/node", "/usr/local/bin/node"}
+		if home, err := os.UserHomeDir(); err == nil {
+			nvm, _ := filepath.Glob(filepath.Join(home, ".nvm", "versions", "node", "*", "bin", "node"))
+			// 按目录名倒序，通常较新的版本在前
+			slices.Reverse(nvm)
+			paths = append(paths, nvm...)
+		}
+		return paths
+	}
+)
+
+// ToolArch 一个工具（go 或 node）的架构
+type ToolArch struct {
+	Path   string // 可执行文件路径
+	Arch   string // 统一为 arm64 / amd64
+	Native string // 当前使用的是 Rosetta 转译版本时，找到的原生（arm64）版本路径
+}
+
+// Translated 是否为 Rosetta 转译运行的 x86_64 版本
+func (t ToolArch) Translated() bool {
+	return t.Arch == "amd64"
+}
+
+// ArchReport Apple Silicon 上工具链架构的检测结果
+type ArchReport struct {
+	AppleSilicon bool
+	Go           ToolArch // 未安装时为零值
+	Node         ToolArch
+}
+
+// DetectArch 在 Apple Silicon 的 macOS 上检测当前使用的 go、node 是原生 arm64 还是 Rosetta 转译的 x86_64 版本，
+// 并查找已安装的原生版本；其他平台返回零值
+func DetectArch() ArchReport {
+	if runtime.GOOS != "darwin" {
+		return ArchReport{}
+	}
+	return detectArch()
+}
+
+// detectArch DetectArch 的平台无关部分（sysctl 在 Rosetta 下运行时同样报告 Apple Silicon）
+func detectArch() ArchReport {
+	var r ArchReport
+	output, err := sysutil.Runner.Output("", "sysctl", "-n", "hw.optional.arm64")
+	if err != nil || strings.TrimSpace(string(output)) != "1" {
+		return r
+	}
+	r.AppleSilicon = true
+
+	if path, arch, err := goArch("go"); err == nil {
+		r.Go = ToolArch{Path: path, Arch: arch}
+		if r.Go.Translated() {
+			r.Go.Native = findNative(goCandidates(), path, goArch)
+		}
+	}
+	if path, arch, err := nodeArch("node"); err == nil {
+		r.Node = ToolArch{Path: path, Arch: arch}
+		if r.Node.Translated() {
+			r.Node.Native = findNative(nodeCandidates(), path, nodeArch)
+		}
+	}
+	return r
+}
+
+// goArch go 可执行文件的路径和架构（GOHOSTARCH 即 go 命令本身的架构）
+func goArch(bin string) (path, arch string, err error) {
+	output, err := sysutil.Runner.Output("", bin, "env", "GOROOT", "GOHOSTARCH")
+	if err != nil {
+		return "", "", err
+	}
+	lines := strings.Fields(string(output))
+	if len(lines) != 2 {
+		return "", "", fmt.Errorf("无法识别的 go env 输出: %s", output)
+	}
+	return filepath.Join(lines[0], "bin", "go"), lines[1], nil
+}
+
+// nodeArch node 可执行文件的路径和架构（node 的 x64 统一为 amd64）
+func nodeArch(bin string) (path, arch string, err error) {
+	output, err := sysutil.Runner.Output("", bin, "-p", `process.execPath + "\n" + process.arch`)
+	if err != nil {
+		return "", "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		return "", "", fmt.Errorf("无法识别的 node 输出: %s", output)
+	}
+	arch = strings.TrimSpace(lines[1])
+	if arch == "x64" {
+		arch = "amd64"
+	}
+	return strings.TrimSpace(lines[0]), arch, nil
+}
+
+// findNative 在候选位置中查找原生 arm64 版本（跳过当前使用的 current）
+func findNative(candidates []string, current string, detect func(bin string) (string, string, error)) string {
+	for _, c := range candidates {
+		if c == current || !sysutil.FileExists(c) {
+			continue
+		}
+		if _, arch, err := detect(c); err == nil && arch == "arm64" {
+			return c
+		}
+	}
+	return ""
+}
+
+// Warnings 使用转译版本且没有找到原生版本时的提示（找到原生版本的由 PreferNative 切换）
+func (r ArchReport) Warnings() []string {
+	var warnings []string
+	if r.Node.Translated() && r.Node.Native == "" {
+		warnings = append(warnings, "Node（"+r.Node.Path+"）是 x86_64 版本，通过 Rosetta 转译运行，Vite 启动和热更新会明显变慢；"+
+			"建议安装 arm64 版本（在原生终端中通过 /opt/homebrew 下的 Homebrew 或 nvm 安装）")
+	}
+	if r.Go.Translated() && r.Go.Native == "" {
+		warnings = append(warnings, "Go（"+r.Go.Path+"）是 x86_64 版本，编译和运行后端都经过 Rosetta 转译；建议从 go.dev 下载 darwin-arm64 安装包")
+	}
+	return warnings
+}
+
+// PreferNative 已安装原生版本时把其所在目录放到 PATH 最前面，之后启动的 go / npm / node 都使用原生版本；
+// 返回切换说明（没有切换时为空）
+func (r ArchReport) PreferNative() []string {
+	var switched []string
+	for _, t := range []struct {
+		name string
+		arch ToolArch
+	}{{"Go", r.Go}, {"Node", r.Node}} {
+		if t.arch.Native == "" {
+			continue
+		}
+		dir := filepath.Dir(t.arch.Native)
+		if path := os.Getenv("PATH"); !strings.HasPrefix(path, dir+string(os.PathListSeparator)) {
+			os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+		}
+		switched = append(switched, fmt.Sprintf("%s 改用原生 arm64 版本 %s（原为 %s）", t.name, t.arch.Native, t.arch.Path))
+	}
+	return switched
+}
@@ -0,0 +1,86 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+// fakeCandidates 在临时目录中创建候选可执行文件，替换默认的候选位置
+func fakeCandidates(t *testing.T) (goBin, nodeBin string) {
+	dir := t.TempDir()
+	goBin = filepath.Join(dir, "homebrew", "go")
+	nodeBin = filepath.Join(dir, "homebrew", "node")
+	os.MkdirAll(filepath.Dir(goBin), 0755)
+	os.WriteFile(goBin, nil, 0755)
+	os.WriteFile(nodeBin, nil, 0755)
+
+	origGo, origNode := goCandidates, nodeCandidates
+	goCandidates = func() []string { return []string{"/missing/go", goBin} }
+	nodeCandidates = func() []string { return []string{nodeBin} }
+	t.Cleanup(func() { goCandidates, nodeCandidates = origGo, origNode })
+	return goBin, nodeBin
+}
+
+func TestDetectArchTranslatedWithNative(t *testing.T) {
+	goBin, nodeBin := fakeCandidates(t)
+	fake := sysutiltest.New(t)
+	fake.Handle("sysctl -n hw.optional.arm64", "1\n", nil)
+	fake.Handle("go env GOROOT GOHOSTARCH", "/usr/local/go\namd64\n", nil)
+	fake.Handle(goBin+" env GOROOT GOHOSTARCH", "/opt/homebrew/Cellar/go/1.22.3/libexec\narm64\n", nil)
+	fake.Handle(`node -p process.execPath + "\n" + process.arch`, "/usr/local/bin/node\nx64\n", nil)
+	fake.Handle(nodeBin+` -p process.execPath + "\n" + process.arch`, "/opt/homebrew/bin/node\narm64\n", nil)
+
+	r := detectArch()
+	want := ArchReport{
+		AppleSilicon: true,
+		Go:           ToolArch{Path: "/usr/local/go/bin/go", Arch: "amd64", Native: goBin},
+		Node:         ToolArch{Path: "/usr/local/bin/node", Arch: "amd64", Native: nodeBin},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Fatalf("report = %+v", r)
+	}
+	if len(r.Warnings()) != 0 {
+		t.Errorf("native versions found, Warnings = %v", r.Warnings())
+	}
+
+	t.Setenv("PATH", "/usr/local/bin")
+	switched := r.PreferNative()
+	if len(switched) != 2 || !strings.HasPrefix(os.Getenv("PATH"), filepath.Dir(nodeBin)+string(os.PathListSeparator)) {
+		t.Errorf("switched = %v, PATH = %s", switched, os.Getenv("PATH"))
+	}
+	// 再次调用不重复添加
+	r.PreferNative()
+	if strings.Count(os.Getenv("PATH"), filepath.Dir(nodeBin)) != 1 {
+		t.Errorf("PATH = %s", os.Getenv("PATH"))
+	}
+}
+
+func TestDetectArchTranslatedWithoutNative(t *testing.T) {
+	fakeCandidates(t)
+	fake := sysutiltest.New(t)
+	fake.Handle("sysctl -n hw.optional.arm64", "1\n", nil)
+	fake.Handle("go env GOROOT GOHOSTARCH", "/opt/homebrew/opt/go/libexec\narm64\n", nil)
+	fake.Handle(`node -p process.execPath + "\n" + process.arch`, "/usr/local/bin/node\nx64\n", nil)
+
+	r := detectArch()
+	if r.Go.Translated() || !r.Node.Translated() || r.Node.Native != "" {
+		t.Fatalf("report = %+v", r)
+	}
+	warnings := r.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Rosetta") {
+		t.Errorf("Warnings = %v", warnings)
+	}
+}
+
+func TestDetectArchIntel(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("sysctl -n hw.optional.arm64", "0\n", nil)
+	if r := detectArch(); r.AppleSilicon || len(fake.Calls()) != 1 {
+		t.Errorf("report = %+v, calls = %v", r, fake.Calls())
+	}
+}
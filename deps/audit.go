@@ -0,0 +1,34 @@
+package deps
+
+import (
+	"fmt"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// AuditFrontend 执行 npm audit 检查前端依赖的安全漏洞（存在 high 及以上漏洞时返回错误）
+func AuditFrontend(webDir string) (string, error) {
+	output, err := sysutil.Runner.CombinedOutput(webDir, "npm", "audit", "--audit-level=high")
+	if err != nil {
+		return string(output), fmt.Errorf("npm audit 发现高危漏洞或执行失败: %v", err)
+	}
+	return string(output), nil
+}
+
+// PruneFrontendCache 校验并回收 npm 全局缓存中的无用数据（不影响 node_modules）
+func PruneFrontendCache() (string, error) {
+	output, err := sysutil.Runner.CombinedOutput("", "npm", "cache", "verify")
+	if err != nil {
+		return string(output), fmt.Errorf("npm cache verify 失败: %v", err)
+	}
+	return string(output), nil
+}
+
+// PruneBackendCache 清理 Go 编译缓存（不影响模块缓存，依赖无需重新下载）
+func PruneBackendCache() (string, error) {
+	output, err := sysutil.Runner.CombinedOutput("", "go", "clean", "-cache")
+	if err != nil {
+		return string(output), fmt.Errorf("go clean -cache 失败: %v", err)
+	}
+	return string(output), nil
+}
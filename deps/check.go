@@ -0,0 +1,105 @@
+package deps
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// FrontendInstalled 检查前端依赖：package.json 配置文件存在 + node_modules 目录存在 + 验证依赖完整性
+func FrontendInstalled(webDir string) bool {
+	packageJsonPath := filepath.Join(webDir, "package.json")
+	nodeModulesPath := filepath.Join(webDir, "node_modules")
+	if !sysutil.FileExists(packageJsonPath) || !sysutil.DirExists(nodeModulesPath) {
+		return false
+	}
+
+	// 配置文件和 node_modules 都存在，验证依赖是否完整
+	// npm ls 返回 0 表示所有依赖都已安装
+	return sysutil.Runner.Run(webDir, "npm", "ls", "--depth=0") == nil
+}
+
+// BackendInstalled 统一的后端依赖检测函数
+func BackendInstalled(serverDir string) bool {
+	// 检查后端依赖：go.mod 和 go.sum 配置文件存在 + 缓存检测
+	goModPath := filepath.Join(serverDir, "go.mod")
+	goSumPath := filepath.Join(serverDir, "go.sum")
+	if !sysutil.FileExists(goModPath) || !sysutil.FileExists(goSumPath) {
+		return false
+	}
+
+	// 使用安全的方法检测依赖（不触发下载）
+	// 1. 获取 Go 模块缓存路径
+	modCache, err := GoModCache()
+	if err != nil {
+		return false
+	}
+
+	// 2. 从go.mod读取所有依赖
+	allDeps, err := ReadGoModDependencies(serverDir)
+	if err != nil {
+		return false
+	}
+
+	// 工作区中的模块和替换为本地路径的依赖由本地目录提供，不在模块缓存中
+	if workspace := DetectWorkspace(serverDir); len(workspace.Local) > 0 {
+		cached := allDeps[:0]
+		for _, dep := range allDeps {
+			path, _, _ := strings.Cut(dep, "@")
+			if !workspace.IsLocal(path) {
+				cached = append(cached, dep)
+			}
+		}
+		if len(cached) == 0 {
+			return true
+		}
+		allDeps = cached
+	}
+
+	// 3. 并发检查每个依赖包是否在缓存中存在（精确匹配 包名@版本号）
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	existCount := 0
+	totalCount := len(allDeps)
+
+	// 使用信号量限制并发数为20（避免打开过多文件句柄）
+	semaphore := make(chan struct{}, 20)
+
+	for _, fullModule := range allDeps {
+		wg.Add(1)
+		go func(module string) {
+			defer wg.Done()
+
+			// 获取信号量
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// 将完整模块标识转换为缓存路径（处理大小写编码）
+			// module 格式: github.com/gin-gonic/gin@v1.10.0
+			fullPath := filepath.Join(modCache, EncodeModulePath(module))
+
+			// 直接检查精确的 包名@版本号 路径是否存在
+			if sysutil.DirExists(fullPath) {
+				mu.Lock()
+				existCount++
+				mu.Unlock()
+			}
+		}(fullModule)
+	}
+
+	// 等待所有检查完成
+	wg.Wait()
+
+	return enoughCached(existCount, totalCount)
+}
+
+// enoughCached 判断依赖是否完整（90% 的依赖存在即认为已安装）
+func enoughCached(existCount, totalCount int) bool {
+	threshold := totalCount * 90 / 100
+	if threshold < 1 {
+		threshold = 1
+	}
+	return existCount >= threshold
+}
@@ -0,0 +1,105 @@
+package deps
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+// mkdirs 在 base 下创建目录
+func mkdirs(t *testing.T, base string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// touch 创建空文件
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnoughCached(t *testing.T) {
+	tests := []struct {
+		exist, total int
+		want         bool
+	}{
+		{9, 10, true},
+		{8, 10, false},
+		{0, 0, false},
+		{1, 1, true},
+		{90, 100, true},
+		{89, 100, false},
+	}
+	for _, tt := range tests {
+		if got := enoughCached(tt.exist, tt.total); got != tt.want {
+			t.Errorf("enoughCached(%d, %d) = %v, want %v", tt.exist, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestFrontendInstalled(t *testing.T) {
+	fake := sysutiltest.New(t)
+	webDir := t.TempDir()
+
+	// 缺少 package.json 和 node_modules 时不调用 npm
+	if FrontendInstalled(webDir) {
+		t.Error("空目录不应判定为已安装")
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("不应执行 npm 命令, calls = %v", fake.Calls())
+	}
+
+	touch(t, filepath.Join(webDir, "package.json"))
+	mkdirs(t, webDir, "node_modules")
+
+	fake.Handle("npm ls --depth=0", "", nil)
+	if !FrontendInstalled(webDir) {
+		t.Error("npm ls 成功时应判定为已安装")
+	}
+
+	fake.Handle("npm ls --depth=0", "", errors.New("exit status 1"))
+	if FrontendInstalled(webDir) {
+		t.Error("npm ls 失败时应判定为未安装")
+	}
+}
+
+func TestBackendInstalled(t *testing.T) {
+	fake := sysutiltest.New(t)
+	serverDir := t.TempDir()
+	modCache := t.TempDir()
+	fake.Handle("go env GOMODCACHE", modCache, nil)
+
+	if BackendInstalled(serverDir) {
+		t.Error("缺少 go.mod 时不应判定为已安装")
+	}
+
+	goMod := "module server\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.10.0\n\tgithub.com/Masterminds/semver v1.5.0\n\tgolang.org/x/sys v0.20.0\n)\n"
+	if err := os.WriteFile(filepath.Join(serverDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, filepath.Join(serverDir, "go.sum"))
+
+	mkdirs(t, modCache, "github.com/gin-gonic/gin@v1.10.0")
+	if BackendInstalled(serverDir) {
+		t.Error("只有三分之一依赖在缓存中时不应判定为已安装")
+	}
+
+	mkdirs(t, modCache, "github.com/!masterminds/semver@v1.5.0", "golang.org/x/sys@v0.20.0")
+	if !BackendInstalled(serverDir) {
+		t.Error("依赖全部在缓存中时应判定为已安装")
+	}
+
+	fake.Handle("go env GOMODCACHE", "", errors.New("go: not found"))
+	if BackendInstalled(serverDir) {
+		t.Error("无法获取缓存目录时不应判定为已安装")
+	}
+}
@@ -0,0 +1,81 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// RemoveFunc 清理时删除一个目录的方式（例如移入回收站），volumeDir 为与 path 位于同一磁盘的目录
+type RemoveFunc func(path, volumeDir string) error
+
+// removeAll 直接删除（RemoveFunc 为 nil 时使用）
+func removeAll(path, volumeDir string) error {
+	return os.RemoveAll(path)
+}
+
+// CleanFrontendCache 清理前端缓存（删除 node_modules，remove 为 nil 时直接删除）
+func CleanFrontendCache(webDir string, remove RemoveFunc) error {
+	nodeModulesPath := filepath.Join(webDir, "node_modules")
+
+	// 检查目录是否存在
+	if !sysutil.DirExists(nodeModulesPath) {
+		return nil // 目录不存在，无需清理
+	}
+
+	if remove == nil {
+		remove = removeAll
+	}
+
+	// 删除 node_modules 目录
+	if err := remove(nodeModulesPath, webDir); err != nil {
+		return apperr.Errorf(apperr.DepCleanFailed, "删除 node_modules 失败: %v", err)
+	}
+	return nil
+}
+
+// CleanBackendCache 清理后端缓存（循环删除 Go 模块，remove 为 nil 时直接删除）
+func CleanBackendCache(serverDir string, remove RemoveFunc, progressCallback func(current, total int, moduleName string)) (successCount, failCount int, err error) {
+	if remove == nil {
+		remove = removeAll
+	}
+
+	// 1. 获取 Go 缓存目录
+	modCache, err := GoModCache()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// 2. 读取后端依赖列表
+	modules, err := ListModules(serverDir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// 3. 循环删除每个模块
+	total := len(modules)
+	for i, moduleDir := range modules {
+		// 更新进度
+		if progressCallback != nil {
+			progressCallback(i+1, total, moduleDir)
+		}
+
+		// Go 模块缓存路径需要处理大小写转换
+		// 例如: github.com/Masterminds/semver/v3@v3.2.0
+		// 实际路径: github.com/!masterminds/semver/v3@v3.2.0
+		modulePath := filepath.Join(modCache, EncodeModulePath(moduleDir))
+
+		// 删除模块目录
+		if err := remove(modulePath, modCache); err != nil {
+			failCount++
+		} else {
+			successCount++
+		}
+	}
+
+	// 4. 不删除 go.sum 文件（Go 项目必需文件）
+	// 注意：go.sum 文件包含依赖包的校验和，删除会导致启动失败
+	return successCount, failCount, nil
+}
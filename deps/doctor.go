@@ -0,0 +1,324 @@
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// singletonPackages 同一项目中只能存在一个版本的前端包（出现多个版本会导致响应式失效、组件样式错乱等问题）
+var singletonPackages = []string{"vue", "element-plus", "vue-router", "pinia", "@vue/runtime-core", "@vue/runtime-dom"}
+
+// IssueKind 前端依赖问题类型
+type IssueKind string
+
+const (
+	IssueDuplicate  IssueKind = "duplicate"  // 同一个包安装了多个版本
+	IssuePeer       IssueKind = "peer"       // peerDependencies 不满足
+	IssueInvalid    IssueKind = "invalid"    // 安装的版本不满足 package.json 的要求
+	IssueMissing    IssueKind = "missing"    // 依赖未安装
+	IssueExtraneous IssueKind = "extraneous" // node_modules 中有 package.json 未声明的包
+	IssueCorrupted  IssueKind = "corrupted"  // node_modules 中的包不完整（安装中断等）
+)
+
+// Issue 一个前端依赖问题
+type Issue struct {
+	Kind    IssueKind
+	Package string // 包名
+	Detail  string // 说明
+}
+
+// FixAction 修复操作
+type FixAction string
+
+const (
+	FixReinstall FixAction = "reinstall" // 删除指定包后重新安装
+	FixDedupe    FixAction = "dedupe"    // npm dedupe
+	FixInstall   FixAction = "install"   // npm install
+	FixPrune     FixAction = "prune"     // npm prune
+)
+
+// Fix 建议的修复操作
+type Fix struct {
+	Action   FixAction
+	Packages []string // FixReinstall 时重新安装的包
+	Title    string
+}
+
+// Diagnosis 前端依赖体检结果
+type Diagnosis struct {
+	Issues []Issue
+}
+
+// npmNode npm ls --json 输出的依赖树节点（兼容 npm 6 与 npm 7+ 的字段）
+type npmNode struct {
+	Version      string             `json:"version"`
+	Invalid      json.RawMessage    `json:"invalid"`  // npm 7+ 为原因字符串，npm 6 为 true
+	Required     json.RawMessage    `json:"required"` // npm 7+ 为版本范围字符串，npm 6 为对象
+	Missing      bool               `json:"missing"`
+	Extraneous   bool               `json:"extraneous"`
+	PeerMissing  bool               `json:"peerMissing"`
+	Dependencies map[string]npmNode `json:"dependencies"`
+}
+
+// invalidFromPattern npm 7+ invalid 原因，例如 "^3.3" from node_modules/element-plus
+var invalidFromPattern = regexp.MustCompile(`^"?([^"]*)"? from (\S+)`)
+
+// Diagnose 检查前端依赖：多版本的 Vue / Element Plus 等、peer 依赖冲突、node_modules 损坏
+func Diagnose(webDir string) (Diagnosis, error) {
+	var d Diagnosis
+	if !sysutil.FileExists(filepath.Join(webDir, "package.json")) {
+		return d, apperr.Errorf(apperr.SvcDirNotFound, "未找到 web/package.json")
+	}
+	if !sysutil.DirExists(filepath.Join(webDir, "node_modules")) {
+		d.Issues = append(d.Issues, Issue{Kind: IssueMissing, Detail: "node_modules 不存在，请先安装依赖"})
+		return d, nil
+	}
+
+	d.Issues = append(d.Issues, corruptedPackages(webDir)...)
+
+	// npm ls 发现问题时以非 0 退出，但仍输出完整的 JSON
+	output, _ := sysutil.Runner.Output(webDir, "npm", "ls", "--all", "--json")
+	var root npmNode
+	if err := json.Unmarshal(output, &root); err != nil {
+		return d, apperr.Errorf(apperr.DepNpmInstallFailed, "解析 npm ls 输出失败: %v", err)
+	}
+	d.Issues = append(d.Issues, treeIssues(webDir, root)...)
+	return d, nil
+}
+
+// corruptedPackages 检查 package.json 中声明的包：目录存在但缺少有效的 package.json，
+// 或者留有 npm 安装中断时的临时目录（node_modules/.包名-随机后缀）
+func corruptedPackages(webDir string) []Issue {
+	var issues []Issue
+	nodeModules := filepath.Join(webDir, "node_modules")
+	for _, name := range declaredPackages(webDir) {
+		dir := filepath.Join(nodeModules, filepath.FromSlash(name))
+		if !sysutil.DirExists(dir) {
+			continue // 未安装由 npm ls 报告
+		}
+		var pkg struct {
+			Version string `json:"version"`
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		if err != nil || json.Unmarshal(data, &pkg) != nil || pkg.Version == "" {
+			issues = append(issues, Issue{Kind: IssueCorrupted, Package: name, Detail: "node_modules/" + name + " 缺少有效的 package.json"})
+		}
+	}
+
+	entries, _ := os.ReadDir(nodeModules)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, ".") || name == ".bin" || name == ".cache" || name == ".vite" {
+			continue
+		}
+		if pkg := stagingPackage(name); pkg != "" {
+			issues = append(issues, Issue{Kind: IssueCorrupted, Package: pkg, Detail: "存在安装中断留下的临时目录 node_modules/" + name})
+		}
+	}
+	return issues
+}
+
+// stagingPackage npm 安装时的临时目录名对应的包名（例如 .vue-Xy12abCd → vue），不是临时目录时返回空字符串
+func stagingPackage(dirName string) string {
+	name := strings.TrimPrefix(dirName, ".")
+	i := strings.LastIndex(name, "-")
+	if i <= 0 || len(name)-i-1 != 8 {
+		return ""
+	}
+	return name[:i]
+}
+
+// declaredPackages package.json 中声明的依赖（dependencies 和 devDependencies），按名称排序
+func declaredPackages(webDir string) []string {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	data, err := os.ReadFile(filepath.Join(webDir, "package.json"))
+	if err != nil || json.Unmarshal(data, &pkg) != nil {
+		return nil
+	}
+	var names []string
+	for name := range pkg.Dependencies {
+		names = append(names, name)
+	}
+	for name := range pkg.DevDependencies {
+		if _, ok := pkg.Dependencies[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// treeIssues 遍历 npm ls 依赖树，收集多版本、版本不满足、缺失和多余的包
+func treeIssues(webDir string, root npmNode) []Issue {
+	var issues []Issue
+	versions := map[string]map[string]bool{}
+
+	var walk func(path []string, deps map[string]npmNode)
+	walk = func(path []string, deps map[string]npmNode) {
+		names := make([]string, 0, len(deps))
+		for name := range deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			node := deps[name]
+			where := strings.Join(append(path, name), " > ")
+			switch {
+			case node.Missing || node.PeerMissing:
+				kind := IssueMissing
+				if node.PeerMissing {
+					kind = IssuePeer
+				}
+				detail := where + " 未安装"
+				if required := rawString(node.Required); required != "" {
+					detail = fmt.Sprintf("%s 未安装（需要 %s）", where, required)
+				}
+				issues = append(issues, Issue{Kind: kind, Package: name, Detail: detail})
+				continue
+			case node.Extraneous:
+				issues = append(issues, Issue{Kind: IssueExtraneous, Package: name, Detail: where + "@" + node.Version + " 未在 package.json 中声明"})
+			case len(node.Invalid) > 0 && string(node.Invalid) != "false":
+				issues = append(issues, invalidIssue(webDir, name, where, node))
+			}
+
+			if node.Version != "" {
+				if versions[name] == nil {
+					versions[name] = map[string]bool{}
+				}
+				versions[name][node.Version] = true
+			}
+			walk(append(path, name), node.Dependencies)
+		}
+	}
+	walk(nil, root.Dependencies)
+
+	for _, name := range singletonPackages {
+		if len(versions[name]) < 2 {
+			continue
+		}
+		var list []string
+		for v := range versions[name] {
+			list = append(list, v)
+		}
+		sort.Strings(list)
+		issues = append(issues, Issue{Kind: IssueDuplicate, Package: name,
+			Detail: fmt.Sprintf("%s 安装了 %d 个版本: %s", name, len(list), strings.Join(list, "、"))})
+	}
+	return issues
+}
+
+// invalidIssue 版本不满足要求的包：要求来自其他包的 peerDependencies 时为 peer 冲突
+func invalidIssue(webDir, name, where string, node npmNode) Issue {
+	reason := rawString(node.Invalid)
+	if m := invalidFromPattern.FindStringSubmatch(reason); m != nil && m[2] != "" {
+		from := strings.TrimPrefix(filepath.ToSlash(m[2]), "node_modules/")
+		if peerRange := peerRequirement(webDir, m[2], name); peerRange != "" {
+			return Issue{Kind: IssuePeer, Package: name,
+				Detail: fmt.Sprintf("%s 要求 %s@%s（peerDependencies），当前为 %s", from, name, peerRange, node.Version)}
+		}
+		return Issue{Kind: IssueInvalid, Package: name,
+			Detail: fmt.Sprintf("%s@%s 不满足 %s 要求的 %s", where, node.Version, from, m[1])}
+	}
+	return Issue{Kind: IssueInvalid, Package: name, Detail: fmt.Sprintf("%s@%s 版本不满足要求", where, node.Version)}
+}
+
+// peerRequirement 读取 webDir/from/package.json 中 name 的 peerDependencies 版本范围
+func peerRequirement(webDir, from, name string) string {
+	if from == "" || from == "." || from == "the root project" {
+		return ""
+	}
+	var pkg struct {
+		PeerDependencies map[string]string `json:"peerDependencies"`
+	}
+	data, err := os.ReadFile(filepath.Join(webDir, filepath.FromSlash(from), "package.json"))
+	if err != nil || json.Unmarshal(data, &pkg) != nil {
+		return ""
+	}
+	return pkg.PeerDependencies[name]
+}
+
+// rawString JSON 字符串值（不是字符串时返回空字符串）
+func rawString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		return ""
+	}
+	return s
+}
+
+// Fixes 按问题给出的修复建议（按建议执行的顺序排列）
+func (d Diagnosis) Fixes() []Fix {
+	var reinstall []string
+	seen := map[string]bool{}
+	has := map[IssueKind]bool{}
+	for _, issue := range d.Issues {
+		has[issue.Kind] = true
+		if (issue.Kind == IssueCorrupted || issue.Kind == IssueInvalid) && issue.Package != "" && !seen[issue.Package] {
+			seen[issue.Package] = true
+			reinstall = append(reinstall, issue.Package)
+		}
+	}
+
+	var fixes []Fix
+	if len(reinstall) > 0 {
+		fixes = append(fixes, Fix{Action: FixReinstall, Packages: reinstall, Title: "重新安装 " + strings.Join(reinstall, "、")})
+	}
+	if has[IssueMissing] {
+		fixes = append(fixes, Fix{Action: FixInstall, Title: "安装缺失的依赖（npm install）"})
+	}
+	if has[IssueDuplicate] || has[IssuePeer] {
+		fixes = append(fixes, Fix{Action: FixDedupe, Title: "合并重复的依赖版本（npm dedupe）"})
+	}
+	if has[IssueExtraneous] {
+		fixes = append(fixes, Fix{Action: FixPrune, Title: "删除未声明的包（npm prune）"})
+	}
+	return fixes
+}
+
+// ApplyFix 执行修复操作，命令输出写入 w
+func ApplyFix(webDir string, fix Fix, w io.Writer) error {
+	var args []string
+	switch fix.Action {
+	case FixReinstall:
+		// 删除包目录后 npm install 按 package-lock.json 重新安装
+		for _, name := range fix.Packages {
+			if name == "" || strings.Contains(name, "..") {
+				continue
+			}
+			fmt.Fprintf(w, "删除 node_modules/%s\n", name)
+			if err := os.RemoveAll(filepath.Join(webDir, "node_modules", filepath.FromSlash(name))); err != nil {
+				return apperr.Errorf(apperr.DepCleanFailed, "删除 node_modules/%s 失败: %v", name, err)
+			}
+		}
+		args = []string{"install"}
+	case FixDedupe:
+		args = []string{"dedupe"}
+	case FixInstall:
+		args = []string{"install"}
+	case FixPrune:
+		args = []string{"prune"}
+	default:
+		return fmt.Errorf("未知的修复操作: %s", fix.Action)
+	}
+
+	fmt.Fprintf(w, "$ npm %s\n", strings.Join(args, " "))
+	output, err := sysutil.Runner.CombinedOutput(webDir, "npm", args...)
+	w.Write(output)
+	if err != nil {
+		return apperr.Errorf(apperr.DepNpmInstallFailed, "npm %s 失败: %v", strings.Join(args, " "), err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package deps
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+const doctorPackageJSON = `{"dependencies": {"vue": "^3.3.0", "element-plus": "^2.4.0", "axios": "^1.6.0"}, "devDependencies": {"vite": "^5.0.0"}}`
+
+// npmLsOutput npm 9 的 npm ls --all --json 输出（element-plus 要求 vue ^3.3，项目装的是 3.2；另有一份嵌套的 vue）
+const npmLsOutput = `{
+  "name": "gin-vue-admin",
+  "dependencies": {
+    "axios": {"required": "^1.6.0", "missing": true},
+    "element-plus": {
+      "version": "2.4.0",
+      "dependencies": {
+        "vue": {"version": "3.4.21"}
+      }
+    },
+    "left-pad": {"version": "1.3.0", "extraneous": true},
+    "vite": {"version": "5.0.0"},
+    "vue": {"version": "3.2.0", "invalid": "\"^3.3.0\" from node_modules/element-plus"}
+  }
+}`
+
+// newWebDir 创建带 package.json 和 node_modules 的前端目录
+func newWebDir(t *testing.T) string {
+	t.Helper()
+	webDir := t.TempDir()
+	os.WriteFile(filepath.Join(webDir, "package.json"), []byte(doctorPackageJSON), 0644)
+	for name, pkg := range map[string]string{
+		"vue":          `{"version": "3.2.0"}`,
+		"element-plus": `{"version": "2.4.0", "peerDependencies": {"vue": "^3.3.0"}}`,
+		"vite":         `{"version": "5.0.0"}`,
+	} {
+		mkdirs(t, webDir, filepath.Join("node_modules", name))
+		os.WriteFile(filepath.Join(webDir, "node_modules", name, "package.json"), []byte(pkg), 0644)
+	}
+	return webDir
+}
+
+func TestDiagnose(t *testing.T) {
+	fake := sysutiltest.New(t)
+	webDir := newWebDir(t)
+	// vite 目录损坏，element-plus 安装中断留下临时目录
+	os.Remove(filepath.Join(webDir, "node_modules", "vite", "package.json"))
+	mkdirs(t, webDir, filepath.Join("node_modules", ".element-plus-Ab3dE9xZ"))
+	fake.Handle("npm ls --all --json", npmLsOutput, nil)
+
+	d, err := Diagnose(webDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[IssueKind][]string{}
+	for _, issue := range d.Issues {
+		got[issue.Kind] = append(got[issue.Kind], issue.Package)
+	}
+	want := map[IssueKind]string{
+		IssueCorrupted:  "vite element-plus",
+		IssueMissing:    "axios",
+		IssueExtraneous: "left-pad",
+		IssuePeer:       "vue",
+		IssueDuplicate:  "vue",
+	}
+	for kind, packages := range want {
+		if strings.Join(got[kind], " ") != packages {
+			t.Errorf("%s = %v, want %s", kind, got[kind], packages)
+		}
+	}
+	if len(got[IssueInvalid]) != 0 {
+		t.Errorf("peer 冲突不应报告为 invalid: %v", got[IssueInvalid])
+	}
+
+	var actions []string
+	for _, fix := range d.Fixes() {
+		actions = append(actions, string(fix.Action)+strings.Join(fix.Packages, ","))
+	}
+	if strings.Join(actions, " ") != "reinstallvite,element-plus install dedupe prune" {
+		t.Errorf("fixes = %v", actions)
+	}
+}
+
+func TestDiagnoseWithoutNodeModules(t *testing.T) {
+	sysutiltest.New(t)
+	webDir := t.TempDir()
+	os.WriteFile(filepath.Join(webDir, "package.json"), []byte(doctorPackageJSON), 0644)
+
+	d, err := Diagnose(webDir)
+	if err != nil || len(d.Issues) != 1 || d.Issues[0].Kind != IssueMissing {
+		t.Fatalf("d = %+v, err = %v", d, err)
+	}
+}
+
+func TestStagingPackage(t *testing.T) {
+	cases := map[string]string{
+		".vue-Ab3dE9xZ":          "vue",
+		".element-plus-Ab3dE9xZ": "element-plus",
+		".package-lock.json":     "",
+		".vue-short":             "",
+	}
+	for dir, want := range cases {
+		if got := stagingPackage(dir); got != want {
+			t.Errorf("stagingPackage(%q) = %q, want %q", dir, got, want)
+		}
+	}
+}
+
+func TestApplyFixReinstall(t *testing.T) {
+	fake := sysutiltest.New(t)
+	webDir := newWebDir(t)
+	fake.Handle("npm install", "added 1 package", nil)
+
+	var out bytes.Buffer
+	if err := ApplyFix(webDir, Fix{Action: FixReinstall, Packages: []string{"vue"}}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if sysutil.DirExists(filepath.Join(webDir, "node_modules", "vue")) {
+		t.Error("重新安装前应删除 node_modules/vue")
+	}
+	if !strings.Contains(out.String(), "added 1 package") {
+		t.Errorf("output = %s", out.String())
+	}
+}
@@ -0,0 +1,38 @@
+package deps
+
+import (
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil"
+)
+
+// Facts 环境信息缓存（为 nil 时每次都执行命令获取，界面启动时设置为持久化缓存）
+var Facts *envcache.Cache
+
+// 缓存键
+const (
+	factGoProxy    = "GOPROXY"
+	factGoModCache = "GOMODCACHE"
+)
+
+// toolFact go / npm 全局信息的缓存键（项目在 WSL 中时读取的是发行版中的工具链，按发行版区分）
+func toolFact(key string) string {
+	if sysutil.WSLDistro != "" {
+		return key + "@wsl:" + sysutil.WSLDistro
+	}
+	return key
+}
+
+// factNpmRegistry npm 镜像源的缓存键（项目目录下的 .npmrc 可能覆盖全局配置，按目录区分）
+func factNpmRegistry(webDir string) string {
+	return "npm-registry:" + webDir
+}
+
+// CachedNpmRegistry 上次读取到的前端镜像源（不执行命令，可能已过期）
+func CachedNpmRegistry(webDir string) (string, bool) {
+	return Facts.Peek(factNpmRegistry(webDir))
+}
+
+// CachedGoProxy 上次读取到的后端镜像源（不执行命令，可能已过期）
+func CachedGoProxy() (string, bool) {
+	return Facts.Peek(toolFact(factGoProxy))
+}
@@ -0,0 +1,38 @@
+package deps
+
+import (
+	"testing"
+
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestFactsCacheCommands(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go env GOPROXY", "https://goproxy.cn\n", nil)
+	fake.Handle("go env -w GOPROXY=https://goproxy.io", "", nil)
+
+	Facts = envcache.New("")
+	defer func() { Facts = nil }()
+
+	ReadGoProxy()
+	if got := ReadGoProxy(); got != "https://goproxy.cn" {
+		t.Fatalf("ReadGoProxy = %q", got)
+	}
+	count := 0
+	for _, c := range fake.Calls() {
+		if c.Command == "go env GOPROXY" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("缓存有效期内只应执行一次命令, got %d", count)
+	}
+
+	if err := SetGoProxy("https://goproxy.io"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := CachedGoProxy(); ok {
+		t.Error("修改镜像源后应清除缓存")
+	}
+}
@@ -0,0 +1,148 @@
+// Package deps 负责 GVA 前后端依赖的检测、安装、缓存清理以及镜像源配置
+package deps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil"
+)
+
+// ReadGoModDependencies 从go.mod文件中读取所有依赖（包名@版本号格式）
+func ReadGoModDependencies(serverDir string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(serverDir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("无法读取go.mod文件: %v", err)
+	}
+	return ParseGoModDependencies(string(content)), nil
+}
+
+// ParseGoModDependencies 解析 go.mod 内容中的依赖（包名@版本号格式，只解析第一个 require 块）
+func ParseGoModDependencies(content string) []string {
+	var dependencies []string
+	inRequireBlock := false
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		// 检查是否进入require块
+		if strings.HasPrefix(line, "require (") {
+			inRequireBlock = true
+			continue
+		}
+
+		// 检查是否退出require块
+		if inRequireBlock && line == ")" {
+			break
+		}
+
+		// 在require块中，解析依赖
+		if inRequireBlock && line != "" && !strings.HasPrefix(line, "//") {
+			// 保留包名和版本号，构建完整的模块标识
+			parts := strings.Fields(line)
+			if len(parts) >= 2 {
+				// 只过滤掉本地替换，保留所有依赖（包括indirect）
+				if dep, ok := moduleID(parts[0], parts[1]); ok {
+					dependencies = append(dependencies, dep)
+				}
+			}
+		}
+
+		// 处理单行require
+		if strings.HasPrefix(line, "require ") && !strings.Contains(line, "(") {
+			parts := strings.Fields(line)
+			if len(parts) >= 3 {
+				if dep, ok := moduleID(parts[1], parts[2]); ok {
+					dependencies = append(dependencies, dep)
+				}
+			}
+		}
+	}
+
+	return dependencies
+}
+
+// moduleID 构建完整的模块标识：包名@版本号（本地路径返回 false）
+func moduleID(packageName, version string) (string, bool) {
+	if strings.HasPrefix(packageName, "./") || strings.HasPrefix(packageName, "../") {
+		return "", false
+	}
+	return packageName + "@" + version, true
+}
+
+// EncodeModulePath 将模块路径编码为 Go 缓存的实际路径格式
+// Go 模块缓存中，大写字母会被转换为 !小写字母
+// 例如：github.com/Masterminds/semver -> github.com/!masterminds/semver
+func EncodeModulePath(modulePath string) string {
+	var result strings.Builder
+	for _, r := range modulePath {
+		if r >= 'A' && r <= 'Z' {
+			result.WriteRune('!')
+			result.WriteRune(r + 32) // 转换为小写
+		} else {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
+
+// GoModCache 获取 Go 模块缓存目录（结果缓存 envcache.ToolTTL）
+func GoModCache() (string, error) {
+	return Facts.Get(toolFact(factGoModCache), envcache.ToolTTL, func() (string, error) {
+		output, err := sysutil.Runner.Output("", "go", "env", "GOMODCACHE")
+		if err != nil {
+			return "", fmt.Errorf("获取 Go 缓存目录失败: %v", err)
+		}
+		dir := strings.TrimSpace(string(output))
+		// WSL 中的 go 返回的是 Linux 路径，面板需要通过 \\wsl$ 访问
+		if sysutil.WSLDistro != "" && strings.HasPrefix(dir, "/") {
+			dir = sysutil.WSLWindowsPath(sysutil.WSLDistro, dir)
+		}
+		return dir, nil
+	})
+}
+
+// ListModules 通过 go list -m all 列出所有依赖模块（模块名@版本号格式，跳过主模块）
+func ListModules(serverDir string) ([]string, error) {
+	args := append(append([]string{"list"}, GoBuildFlags(serverDir)...), "-m", "all")
+	output, err := sysutil.Runner.Output(serverDir, "go", args...)
+	if err != nil {
+		return nil, fmt.Errorf("读取依赖列表失败: %v", err)
+	}
+	return parseModuleList(string(output)), nil
+}
+
+// parseModuleList 解析 go list -m all 的输出（工作区中的模块没有版本号，替换为本地路径的模块不在缓存中，都跳过）
+func parseModuleList(output string) []string {
+	var modules []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// 跳过主模块（第一行）
+		if strings.HasPrefix(line, "github.com/flipped-aurora/gin-vue-admin/server") {
+			continue
+		}
+
+		// 格式: 模块名 版本号
+		// 例如: github.com/gin-gonic/gin v1.9.1
+		parts := strings.Fields(line)
+		if len(parts) >= 4 && parts[2] == "=>" {
+			// 替换: 模块名 版本号 => 替换模块 版本号，缓存中的是替换后的模块
+			if len(parts) >= 5 && !isLocalPath(parts[3]) {
+				modules = append(modules, parts[3]+"@"+parts[4])
+			}
+			continue
+		}
+		if len(parts) >= 2 {
+			// 构建目录名: 模块名@版本号
+			modules = append(modules, parts[0]+"@"+parts[1])
+		}
+	}
+	return modules
+}
@@ -0,0 +1,94 @@
+package deps
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestParseGoModDependencies(t *testing.T) {
+	content := `module github.com/flipped-aurora/gin-vue-admin/server
+
+go 1.22
+
+require github.com/single/dep v0.1.0
+
+require (
+	github.com/gin-gonic/gin v1.10.0
+	// 注释行
+	github.com/Masterminds/semver/v3 v3.2.1 // indirect
+
+	./local v0.0.0
+)
+
+require (
+	github.com/second/block v1.0.0
+)
+`
+	want := []string{
+		"github.com/single/dep@v0.1.0",
+		"github.com/gin-gonic/gin@v1.10.0",
+		"github.com/Masterminds/semver/v3@v3.2.1",
+	}
+	if got := ParseGoModDependencies(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEncodeModulePath(t *testing.T) {
+	tests := map[string]string{
+		"github.com/gin-gonic/gin@v1.10.0":     "github.com/gin-gonic/gin@v1.10.0",
+		"github.com/Masterminds/semver@v1.5.0": "github.com/!masterminds/semver@v1.5.0",
+		"github.com/BurntSushi/TOML@v1.0.0":    "github.com/!burnt!sushi/!t!o!m!l@v1.0.0",
+		"gopkg.in/yaml.v3@v3.0.1":              "gopkg.in/yaml.v3@v3.0.1",
+	}
+	for in, want := range tests {
+		if got := EncodeModulePath(in); got != want {
+			t.Errorf("EncodeModulePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestListModules(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go list -m all", "github.com/flipped-aurora/gin-vue-admin/server\ngithub.com/gin-gonic/gin v1.9.1\n\ngolang.org/x/sys v0.20.0\n", nil)
+
+	got, err := ListModules("/srv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"github.com/gin-gonic/gin@v1.9.1", "golang.org/x/sys@v0.20.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Dir != "/srv" {
+		t.Errorf("应在 server 目录执行 go list, calls = %v", calls)
+	}
+}
+
+func TestParseModuleListReplacements(t *testing.T) {
+	output := "github.com/flipped-aurora/gin-vue-admin/server\ngithub.com/gin-gonic/gin\n" +
+		"github.com/redis/go-redis/v9 v9.0.0 => ../go-redis\n" +
+		"golang.org/x/text v0.14.0 => golang.org/x/text v0.15.0\n"
+	want := []string{"golang.org/x/text@v0.15.0"}
+	if got := parseModuleList(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGoModCache(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go env GOMODCACHE", "/home/u/go/pkg/mod\n", nil)
+
+	got, err := GoModCache()
+	if err != nil || got != "/home/u/go/pkg/mod" {
+		t.Errorf("got %q, err = %v", got, err)
+	}
+
+	fake.Handle("go env GOMODCACHE", "", errors.New("go: not found"))
+	if _, err := GoModCache(); err == nil {
+		t.Error("go 命令失败时应返回错误")
+	}
+}
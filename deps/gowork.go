@@ -0,0 +1,208 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalModule 由本地目录提供的模块（工作区中的模块或替换为本地路径的依赖），不需要模块缓存
+type LocalModule struct {
+	Path   string // 模块路径，例如 github.com/flipped-aurora/gva-plugin
+	Dir    string // 本地目录（绝对路径）
+	Source string // 来源：go.work use、go.work replace 或 go.mod replace
+}
+
+// Workspace server 模块所在的 Go 工作区
+type Workspace struct {
+	GoWork string        // 生效的 go.work 路径（未使用工作区时为空）
+	Local  []LocalModule // 本地提供的模块
+}
+
+// Active 是否处于工作区模式
+func (w Workspace) Active() bool {
+	return w.GoWork != ""
+}
+
+// IsLocal 模块是否由本地目录提供
+func (w Workspace) IsLocal(modulePath string) bool {
+	for _, m := range w.Local {
+		if m.Path == modulePath {
+			return true
+		}
+	}
+	return false
+}
+
+// FindGoWork 按 go 命令的规则查找 dir 生效的 go.work：GOWORK=off 时不使用工作区，
+// GOWORK 为路径时使用该文件，否则从 dir 向上查找
+func FindGoWork(dir string) string {
+	switch env := os.Getenv("GOWORK"); {
+	case env == "off":
+		return ""
+	case env != "" && env != "auto":
+		return env
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, "go.work")
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// DetectWorkspace 检测 server 模块的工作区：go.work 中 use 的其他模块、go.work 和 go.mod 中替换为本地路径的依赖
+func DetectWorkspace(serverDir string) Workspace {
+	var w Workspace
+	serverAbs, _ := filepath.Abs(serverDir)
+
+	if goWork := FindGoWork(serverDir); goWork != "" {
+		if content, err := os.ReadFile(goWork); err == nil {
+			w.GoWork = goWork
+			base := filepath.Dir(goWork)
+			for _, dir := range ParseGoWorkUse(string(content)) {
+				dir = resolveDir(base, dir)
+				if dir == serverAbs {
+					continue
+				}
+				w.Local = append(w.Local, LocalModule{Path: readModulePath(dir), Dir: dir, Source: "go.work use"})
+			}
+			w.Local = append(w.Local, localReplaces(base, string(content), "go.work replace")...)
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(serverDir, "go.mod")); err == nil {
+		w.Local = append(w.Local, localReplaces(serverAbs, string(content), "go.mod replace")...)
+	}
+	return w
+}
+
+// GoBuildFlags go build / run / list 需要附加的参数：工作区模式只允许 -mod=readonly 或 vendor，
+// GOFLAGS 中设置了 -mod=mod 时显式指定 -mod=readonly（命令行参数优先于 GOFLAGS）
+func GoBuildFlags(serverDir string) []string {
+	if FindGoWork(serverDir) == "" {
+		return nil
+	}
+	for _, flag := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if flag == "-mod=mod" || flag == "--mod=mod" {
+			return []string{"-mod=readonly"}
+		}
+	}
+	return nil
+}
+
+// ParseGoWorkUse 解析 go.work 中 use 的目录（单行和块形式）
+func ParseGoWorkUse(content string) []string {
+	var dirs []string
+	for _, fields := range directives(content, "use") {
+		if len(fields) > 0 {
+			dirs = append(dirs, fields[0])
+		}
+	}
+	return dirs
+}
+
+// localReplaces 解析 go.work / go.mod 中替换为本地路径的 replace（相对路径相对 base）
+func localReplaces(base, content, source string) []LocalModule {
+	var modules []LocalModule
+	for _, r := range ParseReplaces(content) {
+		if r.Local() {
+			modules = append(modules, LocalModule{Path: r.Old, Dir: resolveDir(base, r.New), Source: source})
+		}
+	}
+	return modules
+}
+
+// directives 返回 go.mod / go.work 中 name 指令的参数（去掉注释，块形式展开为多条）
+func directives(content, name string) [][]string {
+	var result [][]string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := tokens(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if inBlock {
+			if fields[0] == ")" {
+				inBlock = false
+				continue
+			}
+			result = append(result, fields)
+			continue
+		}
+		if fields[0] != name {
+			continue
+		}
+		if len(fields) == 2 && fields[1] == "(" {
+			inBlock = true
+			continue
+		}
+		result = append(result, fields[1:])
+	}
+	return result
+}
+
+// readModulePath 读取目录中 go.mod 的模块路径（读取失败时返回目录名）
+func readModulePath(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err == nil {
+		for _, fields := range directives(string(content), "module") {
+			if len(fields) > 0 {
+				return fields[0]
+			}
+		}
+	}
+	return filepath.Base(dir)
+}
+
+// isLocalPath replace 的目标是否为本地路径（以 ./、../ 或 / 开头，或 Windows 盘符路径）
+func isLocalPath(path string) bool {
+	return strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") ||
+		strings.HasPrefix(path, ".\\") || strings.HasPrefix(path, "..\\") ||
+		filepath.IsAbs(path) || strings.HasPrefix(path, "/")
+}
+
+// resolveDir 把相对路径解析为相对 base 的绝对路径
+func resolveDir(base, dir string) string {
+	dir = filepath.FromSlash(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(base, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+// tokens 按空白拆分一行，带引号（"" 或 “）的路径作为一个整体并去掉引号
+func tokens(line string) []string {
+	var result []string
+	for {
+		line = strings.TrimLeft(line, " \t\r")
+		if line == "" {
+			return result
+		}
+		if quote := line[0]; quote == '"' || quote == '`' {
+			if end := strings.IndexByte(line[1:], quote); end >= 0 {
+				result = append(result, line[1:end+1])
+				line = line[end+2:]
+				continue
+			}
+		}
+		end := strings.IndexAny(line, " \t\r")
+		if end < 0 {
+			end = len(line)
+		}
+		result = append(result, line[:end])
+		line = line[end:]
+	}
+}
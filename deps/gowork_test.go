@@ -0,0 +1,114 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+const sampleGoWork = `go 1.22
+
+use (
+	./server
+	./forks/gin // 本地修改的 gin
+	"./forks/gva plugin"
+)
+
+use ./tools
+
+replace github.com/redis/go-redis/v9 => ../go-redis
+replace (
+	golang.org/x/text v0.14.0 => golang.org/x/text v0.15.0
+)
+`
+
+// newWorkspace 创建带 go.work 的 GVA 根目录，返回 server 目录
+func newWorkspace(t *testing.T) (root, serverDir string) {
+	t.Helper()
+	t.Setenv("GOWORK", "")
+	root = t.TempDir()
+	mkdirs(t, root, "server", "forks/gin", "forks/gva plugin", "tools")
+	os.WriteFile(filepath.Join(root, "go.work"), []byte(sampleGoWork), 0644)
+	os.WriteFile(filepath.Join(root, "forks", "gin", "go.mod"), []byte("module github.com/gin-gonic/gin\n\ngo 1.22\n"), 0644)
+	os.WriteFile(filepath.Join(root, "server", "go.mod"), []byte("module github.com/flipped-aurora/gin-vue-admin/server\n\nreplace github.com/casbin/casbin/v2 => ./third_party/casbin\n"), 0644)
+	return root, filepath.Join(root, "server")
+}
+
+func TestParseGoWorkUse(t *testing.T) {
+	want := []string{"./server", "./forks/gin", "./forks/gva plugin", "./tools"}
+	if got := ParseGoWorkUse(sampleGoWork); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDetectWorkspace(t *testing.T) {
+	root, serverDir := newWorkspace(t)
+
+	w := DetectWorkspace(serverDir)
+	if w.GoWork != filepath.Join(root, "go.work") {
+		t.Fatalf("GoWork = %q", w.GoWork)
+	}
+	got := map[string]string{}
+	for _, m := range w.Local {
+		got[m.Path] = m.Source + " " + m.Dir
+	}
+	want := map[string]string{
+		"github.com/gin-gonic/gin":     "go.work use " + filepath.Join(root, "forks", "gin"),
+		"gva plugin":                   "go.work use " + filepath.Join(root, "forks", "gva plugin"),
+		"tools":                        "go.work use " + filepath.Join(root, "tools"),
+		"github.com/redis/go-redis/v9": "go.work replace " + filepath.Join(filepath.Dir(root), "go-redis"),
+		"github.com/casbin/casbin/v2":  "go.mod replace " + filepath.Join(serverDir, "third_party", "casbin"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+	if !w.IsLocal("github.com/gin-gonic/gin") || w.IsLocal("golang.org/x/text") {
+		t.Error("IsLocal 结果不正确")
+	}
+
+	t.Setenv("GOWORK", "off")
+	if w := DetectWorkspace(serverDir); w.Active() || len(w.Local) != 1 {
+		t.Errorf("GOWORK=off 时只保留 go.mod 的本地替换: %+v", w)
+	}
+}
+
+func TestGoBuildFlags(t *testing.T) {
+	_, serverDir := newWorkspace(t)
+
+	t.Setenv("GOFLAGS", "-mod=mod -trimpath")
+	if got := GoBuildFlags(serverDir); !reflect.DeepEqual(got, []string{"-mod=readonly"}) {
+		t.Errorf("工作区模式下 -mod=mod 应改为 readonly: %v", got)
+	}
+	if got := GoBuildFlags(t.TempDir()); got != nil {
+		t.Errorf("没有 go.work 时不附加参数: %v", got)
+	}
+	t.Setenv("GOFLAGS", "")
+	if got := GoBuildFlags(serverDir); got != nil {
+		t.Errorf("GOFLAGS 未设置 -mod 时不附加参数: %v", got)
+	}
+}
+
+func TestBackendInstalledSkipsLocalModules(t *testing.T) {
+	fake := sysutiltest.New(t)
+	_, serverDir := newWorkspace(t)
+	cache := t.TempDir()
+	Facts = nil
+	fake.Handle("go env GOMODCACHE", cache, nil)
+	os.WriteFile(filepath.Join(serverDir, "go.mod"), []byte(`module github.com/flipped-aurora/gin-vue-admin/server
+
+require (
+	github.com/gin-gonic/gin v1.10.0
+	github.com/spf13/viper v1.18.0
+)
+`), 0644)
+	os.WriteFile(filepath.Join(serverDir, "go.sum"), nil, 0644)
+	mkdirs(t, cache, "github.com/spf13/viper@v1.18.0")
+
+	// gin 由工作区中的 forks/gin 提供，不需要在缓存中
+	if !BackendInstalled(serverDir) {
+		t.Error("工作区提供的模块不应计入缺失")
+	}
+}
@@ -0,0 +1,111 @@
+package deps
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// 网络不稳定时下载失败的重试策略（测试中可修改）
+var (
+	InstallAttempts = 3               // 最多执行的次数
+	RetryDelay      = 5 * time.Second // 第一次重试前的等待时间，之后每次加倍
+)
+
+// networkErrors npm / go 输出中表示网络问题（可以重试）的关键字
+var networkErrors = []string{
+	"ETIMEDOUT", "ECONNRESET", "ECONNREFUSED", "EAI_AGAIN", "ENOTFOUND",
+	"socket hang up", "network timeout", "i/o timeout", "connection reset",
+	"TLS handshake timeout", "unexpected EOF", "dial tcp",
+}
+
+// networkError 输出是否表示下载因网络问题中断
+func networkError(output string) bool {
+	for _, keyword := range networkErrors {
+		if strings.Contains(output, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// download 执行下载命令，因网络问题失败时等待后重试（retryArgs 为重试时使用的参数）；
+// ctx 取消（任务暂停或取消）时结束命令并返回 ctx.Err()
+func download(ctx context.Context, dir string, logf func(format string, args ...interface{}), retryNote string, name string, args, retryArgs []string) ([]byte, error) {
+	delay := RetryDelay
+	for attempt := 1; ; attempt++ {
+		output, err := sysutil.CombinedOutputContext(ctx, dir, name, args...)
+		if ctx.Err() != nil {
+			return output, ctx.Err()
+		}
+		if err == nil || attempt >= InstallAttempts || !networkError(string(output)) {
+			return output, err
+		}
+
+		if logf != nil {
+			logf("%s 网络中断（第 %d/%d 次），%d 秒后重试；%s", name, attempt, InstallAttempts, int(delay.Seconds()), retryNote)
+		}
+		select {
+		case <-ctx.Done():
+			return output, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		args = retryArgs
+	}
+}
+
+// InstallFrontend 安装前端依赖（mirrorURL 不为空时先设置 npm registry）；
+// 网络中断时重试，已下载的包保留在 npm 缓存中，重试时优先使用缓存（--prefer-offline）
+func InstallFrontend(ctx context.Context, webDir string, mirrorURL string, logf func(format string, args ...interface{})) error {
+	// 如果设置了镜像源，先设置 npm registry
+	if mirrorURL != "" {
+		if err := SetNpmRegistry(webDir, mirrorURL); err != nil {
+			return err
+		}
+	}
+
+	// 执行npm install
+	output, err := download(ctx, webDir, logf, "已下载的包保留在 npm 缓存中，不会重新下载",
+		"npm", []string{"install"}, []string{"install", "--prefer-offline"})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil && integrityError(string(output)) {
+		return apperr.Errorf(apperr.DepIntegrityFailed, "npm install 下载的包与校验和不一致: %v\n%s", err, string(output))
+	}
+	if err != nil {
+		return apperr.Errorf(apperr.DepNpmInstallFailed, "npm install 失败: %v\n%s", err, string(output))
+	}
+
+	return nil
+}
+
+// InstallBackend 安装后端依赖（proxyURL 不为空时先设置 GOPROXY）；
+// 网络中断时重试，已下载的模块保留在模块缓存中，重试时只下载剩余的模块
+func InstallBackend(ctx context.Context, serverDir string, proxyURL string, logf func(format string, args ...interface{})) error {
+	// 如果设置了代理，先设置 GOPROXY
+	if proxyURL != "" {
+		if err := SetGoProxy(proxyURL); err != nil {
+			return err
+		}
+	}
+
+	// 执行go mod download
+	args := []string{"mod", "download"}
+	output, err := download(ctx, serverDir, logf, "已下载的模块保留在模块缓存中，只下载剩余的模块", "go", args, args)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil && integrityError(string(output)) {
+		return apperr.Errorf(apperr.DepIntegrityFailed, "go mod download 下载的模块与 go.sum 中的校验和不一致: %v\n%s", err, string(output))
+	}
+	if err != nil {
+		return apperr.Errorf(apperr.DepGoModFailed, "go mod download 失败: %v\n%s", err, string(output))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package deps
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestInstallFrontendRetriesNetworkErrors(t *testing.T) {
+	fake := sysutiltest.New(t)
+	delay := RetryDelay
+	RetryDelay = 0
+	t.Cleanup(func() { RetryDelay = delay })
+
+	fake.HandleExit("npm install", "npm ERR! code ECONNRESET\nnpm ERR! network aborted", errors.New("exit status 1"))
+	fake.Handle("npm install --prefer-offline", "added 1200 packages", nil)
+
+	var logs []string
+	logf := func(format string, args ...interface{}) { logs = append(logs, format) }
+	if err := InstallFrontend(context.Background(), "/web", "", logf); err != nil {
+		t.Fatalf("重试后应成功, err = %v", err)
+	}
+	if !fake.Called("npm install --prefer-offline") || len(logs) != 1 {
+		t.Errorf("网络中断后应使用缓存重试一次, logs = %v", logs)
+	}
+}
+
+func TestInstallBackendGivesUp(t *testing.T) {
+	fake := sysutiltest.New(t)
+	delay := RetryDelay
+	RetryDelay = 0
+	t.Cleanup(func() { RetryDelay = delay })
+
+	fake.HandleExit("go mod download", "dial tcp: lookup proxy.golang.org: i/o timeout", errors.New("exit status 1"))
+	err := InstallBackend(context.Background(), "/server", "", nil)
+	if apperr.CodeOf(err) != apperr.DepGoModFailed {
+		t.Errorf("err = %v", err)
+	}
+	calls := 0
+	for _, c := range fake.Calls() {
+		if c.Command == "go mod download" {
+			calls++
+		}
+	}
+	if calls != InstallAttempts {
+		t.Errorf("应执行 %d 次, got %d", InstallAttempts, calls)
+	}
+
+	// 不是网络问题（例如 go.mod 有误）时不重试
+	fake = sysutiltest.New(t)
+	fake.HandleExit("go mod download", "go: errors parsing go.mod", errors.New("exit status 1"))
+	if err := InstallBackend(context.Background(), "/server", "", nil); err == nil || !strings.Contains(err.Error(), "parsing go.mod") {
+		t.Errorf("err = %v", err)
+	}
+	if len(fake.Calls()) != 1 {
+		t.Errorf("不应重试, calls = %v", fake.Calls())
+	}
+}
+
+func TestInstallCanceled(t *testing.T) {
+	sysutiltest.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := InstallFrontend(ctx, "/web", "", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("取消时应返回 ctx 的错误, got %v", err)
+	}
+}
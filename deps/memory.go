@@ -0,0 +1,112 @@
+package deps
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// meminfoPath / boardModelPath Linux 上的内存信息和设备树中的板卡型号（变量便于测试）
+var (
+	meminfoPath    = "/proc/meminfo"
+	boardModelPath = "/proc/device-tree/model"
+)
+
+// NpmInstallMinMemory npm install 需要的可用内存（包括空闲的交换空间），
+// GVA 前端依赖较多，低于该值时安装容易被 OOM 终止或长时间卡住
+const NpmInstallMinMemory = 1536 << 20
+
+// lowResourceMemory 总内存低于该值的 ARM Linux 设备建议开启低资源模式
+const lowResourceMemory = 4 << 30
+
+// Memory 内存情况（字节）；Total 为 0 表示无法获取（目前只支持 Linux）
+type Memory struct {
+	Total     uint64
+	Available uint64
+	SwapFree  uint64
+}
+
+// ReadMemory 读取当前的内存情况（非 Linux 系统返回零值）
+func ReadMemory() Memory {
+	data, err := os.ReadFile(meminfoPath)
+	if err != nil {
+		return Memory{}
+	}
+	return parseMeminfo(string(data))
+}
+
+// parseMeminfo 解析 /proc/meminfo（数值单位为 kB）
+func parseMeminfo(text string) Memory {
+	var m Memory
+	for _, line := range strings.Split(text, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "MemTotal":
+			m.Total = kb << 10
+		case "MemAvailable":
+			m.Available = kb << 10
+		case "SwapFree":
+			m.SwapFree = kb << 10
+		}
+	}
+	return m
+}
+
+// NpmInstallWarning 可用内存（包括空闲的交换空间）不足以执行 npm install 时的提示，足够或无法获取时为空
+func (m Memory) NpmInstallWarning() string {
+	if m.Total == 0 || m.Available+m.SwapFree >= NpmInstallMinMemory {
+		return ""
+	}
+	return fmt.Sprintf("当前可用内存 %s（交换空间 %s），npm install 建议至少 %s，可能被系统终止或长时间无响应。"+
+		"建议先关闭其他程序，或增加交换空间（树莓派可修改 /etc/dphys-swapfile 的 CONF_SWAPSIZE）",
+		formatGB(m.Available), formatGB(m.SwapFree), formatGB(NpmInstallMinMemory))
+}
+
+// BoardModel 单板机型号（设备树中的 model，例如 Raspberry Pi 4 Model B Rev 1.4），其他设备返回空字符串
+func BoardModel() string {
+	data, err := os.ReadFile(boardModelPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(data), "\x00"))
+}
+
+// LowResourceHint 当前设备是 ARM 单板机或内存较小的 ARM Linux 时返回设备说明（用于建议开启低资源模式），否则为空
+func LowResourceHint() string {
+	if runtime.GOOS != "linux" || (runtime.GOARCH != "arm64" && runtime.GOARCH != "arm") {
+		return ""
+	}
+	return lowResourceHint(BoardModel(), ReadMemory())
+}
+
+// lowResourceHint LowResourceHint 的平台无关部分
+func lowResourceHint(model string, m Memory) string {
+	if model == "" && (m.Total == 0 || m.Total >= lowResourceMemory) {
+		return ""
+	}
+	if model == "" {
+		model = "ARM 设备"
+	}
+	if m.Total > 0 {
+		model += "（内存 " + formatGB(m.Total) + "）"
+	}
+	return model
+}
+
+// formatGB 以 GB 为单位显示字节数
+func formatGB(bytes uint64) string {
+	return fmt.Sprintf("%.1f GB", float64(bytes)/(1<<30))
+}
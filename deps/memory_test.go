@@ -0,0 +1,60 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleMeminfo = `MemTotal:        3884532 kB
+MemFree:          201344 kB
+MemAvailable:     812420 kB
+Buffers:           40120 kB
+SwapTotal:        102396 kB
+SwapFree:         102396 kB
+`
+
+func TestParseMeminfo(t *testing.T) {
+	m := parseMeminfo(sampleMeminfo)
+	want := Memory{Total: 3884532 << 10, Available: 812420 << 10, SwapFree: 102396 << 10}
+	if m != want {
+		t.Errorf("parseMeminfo = %+v, want %+v", m, want)
+	}
+}
+
+func TestNpmInstallWarning(t *testing.T) {
+	if w := parseMeminfo(sampleMeminfo).NpmInstallWarning(); !strings.Contains(w, "0.8 GB") {
+		t.Errorf("可用内存不足时应提示, got %q", w)
+	}
+	if w := (Memory{Total: 8 << 30, Available: 4 << 30}).NpmInstallWarning(); w != "" {
+		t.Errorf("内存足够时不应提示, got %q", w)
+	}
+	if w := (Memory{}).NpmInstallWarning(); w != "" {
+		t.Errorf("无法获取内存时不应提示, got %q", w)
+	}
+}
+
+func TestLowResourceHint(t *testing.T) {
+	if got := lowResourceHint("Raspberry Pi 4 Model B Rev 1.4", Memory{Total: 8 << 30}); got != "Raspberry Pi 4 Model B Rev 1.4（内存 8.0 GB）" {
+		t.Errorf("单板机 got %q", got)
+	}
+	if got := lowResourceHint("", Memory{Total: 2 << 30}); got != "ARM 设备（内存 2.0 GB）" {
+		t.Errorf("内存较小 got %q", got)
+	}
+	if got := lowResourceHint("", Memory{Total: 16 << 30}); got != "" {
+		t.Errorf("内存充足的 ARM 设备不应提示, got %q", got)
+	}
+}
+
+func TestBoardModel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model")
+	os.WriteFile(path, []byte("Raspberry Pi 5 Model B Rev 1.0\x00"), 0644)
+	orig := boardModelPath
+	boardModelPath = path
+	t.Cleanup(func() { boardModelPath = orig })
+
+	if got := BoardModel(); got != "Raspberry Pi 5 Model B Rev 1.0" {
+		t.Errorf("BoardModel = %q", got)
+	}
+}
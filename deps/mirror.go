@@ -0,0 +1,65 @@
+package deps
+
+import (
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil"
+)
+
+// 官方默认源
+const (
+	DefaultNpmRegistry = "https://registry.npmjs.org/"
+	DefaultGoProxy     = "https://proxy.golang.org,direct"
+)
+
+// ReadNpmRegistry 读取前端镜像源（npm config get registry，结果缓存 envcache.ToolTTL）
+func ReadNpmRegistry(webDir string) string {
+	registry, _ := Facts.Get(factNpmRegistry(webDir), envcache.ToolTTL, func() (string, error) {
+		output, err := sysutil.Runner.CombinedOutput(webDir, "npm", "config", "get", "registry")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(output)), nil
+	})
+	return registry
+}
+
+// ReadGoProxy 读取后端镜像源（go env GOPROXY，结果缓存 envcache.ToolTTL）
+func ReadGoProxy() string {
+	proxy, _ := Facts.Get(toolFact(factGoProxy), envcache.ToolTTL, func() (string, error) {
+		output, err := sysutil.Runner.CombinedOutput("", "go", "env", "GOPROXY")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(output)), nil
+	})
+	return proxy
+}
+
+// SetNpmRegistry 更新前端镜像源（为空时恢复默认官方源）
+func SetNpmRegistry(webDir string, mirrorURL string) error {
+	if mirrorURL == "" {
+		mirrorURL = DefaultNpmRegistry
+	}
+
+	if err := sysutil.Runner.Run(webDir, "npm", "config", "set", "registry", mirrorURL); err != nil {
+		return apperr.Errorf(apperr.DepMirrorFailed, "设置 npm 镜像源失败: %v", err)
+	}
+	Facts.Invalidate(factNpmRegistry(webDir))
+	return nil
+}
+
+// SetGoProxy 更新后端镜像源（为空时恢复默认官方代理）
+func SetGoProxy(proxyURL string) error {
+	if proxyURL == "" {
+		proxyURL = DefaultGoProxy
+	}
+
+	if err := sysutil.Runner.Run("", "go", "env", "-w", "GOPROXY="+proxyURL); err != nil {
+		return apperr.Errorf(apperr.DepMirrorFailed, "设置 GOPROXY 失败: %v", err)
+	}
+	Facts.Invalidate(toolFact(factGoProxy))
+	return nil
+}
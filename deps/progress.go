@@ -0,0 +1,53 @@
+package deps
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InstalledPackages node_modules 中已安装的顶层包数量（@scope 下的包分别计数，.bin 等隐藏目录不计），
+// 安装依赖时定期读取以显示进度和速度
+func InstalledPackages(webDir string) int {
+	nodeModules := filepath.Join(webDir, "node_modules")
+	entries, err := os.ReadDir(nodeModules)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case strings.HasPrefix(name, "."):
+		case strings.HasPrefix(name, "@"):
+			scoped, _ := os.ReadDir(filepath.Join(nodeModules, name))
+			count += len(scoped)
+		case e.IsDir():
+			count++
+		}
+	}
+	return count
+}
+
+// ExpectedPackages package-lock.json 中安装到 node_modules 顶层的包数量（没有 lock 文件或无法解析时为 0）
+func ExpectedPackages(webDir string) int {
+	data, err := os.ReadFile(filepath.Join(webDir, "package-lock.json"))
+	if err != nil {
+		return 0
+	}
+	var lock struct {
+		Packages map[string]json.RawMessage `json:"packages"`
+	}
+	if json.Unmarshal(data, &lock) != nil {
+		return 0
+	}
+	count := 0
+	for path := range lock.Packages {
+		// 嵌套安装的包（node_modules/a/node_modules/b）不在顶层
+		if strings.HasPrefix(path, "node_modules/") && strings.Count(path, "node_modules/") == 1 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,40 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstalledPackages(t *testing.T) {
+	webDir := t.TempDir()
+	for _, dir := range []string{"vue", "axios", "@vue/shared", "@vue/reactivity", ".bin", "vue/node_modules/nested"} {
+		os.MkdirAll(filepath.Join(webDir, "node_modules", filepath.FromSlash(dir)), 0755)
+	}
+	os.WriteFile(filepath.Join(webDir, "node_modules", ".package-lock.json"), []byte("{}"), 0644)
+
+	if got := InstalledPackages(webDir); got != 4 {
+		t.Errorf("InstalledPackages = %d, want 4", got)
+	}
+	if got := InstalledPackages(t.TempDir()); got != 0 {
+		t.Errorf("没有 node_modules 时应为 0, got %d", got)
+	}
+}
+
+func TestExpectedPackages(t *testing.T) {
+	webDir := t.TempDir()
+	lock := `{"lockfileVersion":3,"packages":{
+		"":{"name":"gin-vue-admin"},
+		"node_modules/vue":{"version":"3.4.0"},
+		"node_modules/@vue/shared":{"version":"3.4.0"},
+		"node_modules/vue/node_modules/nested":{"version":"1.0.0"}
+	}}`
+	os.WriteFile(filepath.Join(webDir, "package-lock.json"), []byte(lock), 0644)
+
+	if got := ExpectedPackages(webDir); got != 2 {
+		t.Errorf("ExpectedPackages = %d, want 2", got)
+	}
+	if got := ExpectedPackages(t.TempDir()); got != 0 {
+		t.Errorf("没有 lock 文件时应为 0, got %d", got)
+	}
+}
@@ -0,0 +1,129 @@
+package deps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// Replace go.mod / go.work 中的一条 replace 指令
+type Replace struct {
+	Old        string // 被替换的模块路径
+	OldVersion string // 只替换该版本（为空时替换所有版本）
+	New        string // 替换为的模块路径或本地目录
+	NewVersion string // 替换为的版本（本地目录时为空）
+}
+
+// Local 是否替换为本地目录（本地目录在其他机器上通常不存在，容易导致构建失败）
+func (r Replace) Local() bool {
+	return isLocalPath(r.New)
+}
+
+// String replace 指令的文字形式，例如 github.com/a/b v1.0.0 => ../b
+func (r Replace) String() string {
+	left, right := r.Old, r.New
+	if r.OldVersion != "" {
+		left += " " + r.OldVersion
+	}
+	if r.NewVersion != "" {
+		right += " " + r.NewVersion
+	}
+	return left + " => " + right
+}
+
+// ParseReplaces 解析 go.mod / go.work 中的 replace 指令（单行和块形式）
+func ParseReplaces(content string) []Replace {
+	var replaces []Replace
+	for _, fields := range directives(content, "replace") {
+		var r Replace
+		switch {
+		case len(fields) >= 3 && fields[1] == "=>":
+			r = Replace{Old: fields[0], New: fields[2]}
+			if len(fields) >= 4 {
+				r.NewVersion = fields[3]
+			}
+		case len(fields) >= 4 && fields[2] == "=>":
+			r = Replace{Old: fields[0], OldVersion: fields[1], New: fields[3]}
+			if len(fields) >= 5 {
+				r.NewVersion = fields[4]
+			}
+		default:
+			continue
+		}
+		replaces = append(replaces, r)
+	}
+	return replaces
+}
+
+// ReadReplaces 读取 server/go.mod 中的 replace 指令
+func ReadReplaces(serverDir string) ([]Replace, error) {
+	content, err := os.ReadFile(filepath.Join(serverDir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("无法读取go.mod文件: %v", err)
+	}
+	return ParseReplaces(string(content)), nil
+}
+
+// AddLocalReplace 在 go.mod 中把模块替换为本地目录（go mod edit -replace）。
+// dir 中必须有声明同一模块路径的 go.mod；写入 go.mod 的路径尽量使用相对 server 目录的相对路径
+func AddLocalReplace(serverDir, module, dir string) (Replace, error) {
+	module = strings.TrimSpace(module)
+	if module == "" {
+		return Replace{}, apperr.Errorf(apperr.DepGoModFailed, "请选择要替换的模块")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil || !sysutil.DirExists(abs) {
+		return Replace{}, apperr.Errorf(apperr.DepGoModFailed, "本地目录不存在: %s", dir)
+	}
+	if !sysutil.FileExists(filepath.Join(abs, "go.mod")) {
+		return Replace{}, apperr.Errorf(apperr.DepGoModFailed, "%s 中没有 go.mod", dir)
+	}
+	if declared := readModulePath(abs); declared != module {
+		return Replace{}, apperr.Errorf(apperr.DepGoModFailed, "%s 的 go.mod 声明的模块是 %s，不是 %s", dir, declared, module)
+	}
+
+	r := Replace{Old: module, New: replacePath(serverDir, abs)}
+	if err := goModEdit(serverDir, "-replace="+module+"="+r.New); err != nil {
+		return Replace{}, err
+	}
+	return r, nil
+}
+
+// DropReplace 删除 go.mod 中 module 的 replace（go mod edit -dropreplace）
+func DropReplace(serverDir string, r Replace) error {
+	target := r.Old
+	if r.OldVersion != "" {
+		target += "@" + r.OldVersion
+	}
+	return goModEdit(serverDir, "-dropreplace="+target)
+}
+
+// replacePath 写入 go.mod 的本地路径：能表示为相对 server 目录的路径时使用 ./ 或 ../ 开头的相对路径
+func replacePath(serverDir, dir string) string {
+	base, err := filepath.Abs(serverDir)
+	if err != nil {
+		return dir
+	}
+	rel, err := filepath.Rel(base, dir)
+	if err != nil || filepath.IsAbs(rel) {
+		return dir
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") && rel != ".." {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// goModEdit 在 server 目录执行 go mod edit
+func goModEdit(serverDir string, args ...string) error {
+	output, err := sysutil.Runner.CombinedOutput(serverDir, "go", append([]string{"mod", "edit"}, args...)...)
+	if err != nil {
+		return apperr.Errorf(apperr.DepGoModFailed, "go mod edit 失败: %v\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
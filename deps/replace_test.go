@@ -0,0 +1,84 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestParseReplaces(t *testing.T) {
+	content := `module example.com/server
+
+replace github.com/a/b => ../b // 本地调试
+replace (
+	github.com/c/d v1.2.0 => github.com/fork/d v1.2.1
+	"github.com/e/f" => "/home/u/my forks/f"
+)
+`
+	want := []Replace{
+		{Old: "github.com/a/b", New: "../b"},
+		{Old: "github.com/c/d", OldVersion: "v1.2.0", New: "github.com/fork/d", NewVersion: "v1.2.1"},
+		{Old: "github.com/e/f", New: "/home/u/my forks/f"},
+	}
+	got := ParseReplaces(content)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v", got)
+	}
+	if !got[0].Local() || got[1].Local() || !got[2].Local() {
+		t.Error("Local 结果不正确")
+	}
+	if s := got[1].String(); s != "github.com/c/d v1.2.0 => github.com/fork/d v1.2.1" {
+		t.Errorf("String = %s", s)
+	}
+}
+
+func TestAddLocalReplace(t *testing.T) {
+	fake := sysutiltest.New(t)
+	root := t.TempDir()
+	mkdirs(t, root, "server", "forks/gin")
+	serverDir := filepath.Join(root, "server")
+	os.WriteFile(filepath.Join(root, "forks", "gin", "go.mod"), []byte("module github.com/gin-gonic/gin\n"), 0644)
+	fake.Handle("go mod edit -replace=github.com/gin-gonic/gin=../forks/gin", "", nil)
+
+	r, err := AddLocalReplace(serverDir, "github.com/gin-gonic/gin", filepath.Join(root, "forks", "gin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.New != "../forks/gin" {
+		t.Errorf("New = %s", r.New)
+	}
+
+	_, err = AddLocalReplace(serverDir, "github.com/other/mod", filepath.Join(root, "forks", "gin"))
+	if apperr.CodeOf(err) != apperr.DepGoModFailed || !strings.Contains(err.Error(), "github.com/gin-gonic/gin") {
+		t.Errorf("模块路径不一致时 err = %v", err)
+	}
+	if _, err := AddLocalReplace(serverDir, "github.com/gin-gonic/gin", filepath.Join(root, "missing")); err == nil {
+		t.Error("目录不存在时应返回错误")
+	}
+}
+
+func TestDropReplace(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go mod edit -dropreplace=github.com/c/d@v1.2.0", "", nil)
+	if err := DropReplace("/srv", Replace{Old: "github.com/c/d", OldVersion: "v1.2.0"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Dir != "/srv" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestReplacePath(t *testing.T) {
+	server := filepath.Join(t.TempDir(), "server")
+	if got := replacePath(server, filepath.Join(server, "third_party", "x")); got != "./third_party/x" {
+		t.Errorf("got %s", got)
+	}
+	if got := replacePath(server, filepath.Join(filepath.Dir(server), "x")); got != "../x" {
+		t.Errorf("got %s", got)
+	}
+}
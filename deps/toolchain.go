@@ -0,0 +1,84 @@
+package deps
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil"
+)
+
+// 工具链版本的缓存键（未安装时不缓存，安装后下次检测立即生效）
+const (
+	factGoVersion  = "go-version"
+	factNpmVersion = "npm-version"
+)
+
+// Toolchain 本机 go / npm 的检测结果（版本为空表示未安装或无法执行）
+type Toolchain struct {
+	GoVersion  string // 例如 go1.22.3
+	NpmVersion string // 例如 10.5.0
+}
+
+// HasGo go 是否可用（后端启动、后端依赖、GOPROXY 设置需要）
+func (t Toolchain) HasGo() bool {
+	return t.GoVersion != ""
+}
+
+// HasNpm npm 是否可用（前端启动、前端依赖、npm 镜像源设置需要）
+func (t Toolchain) HasNpm() bool {
+	return t.NpmVersion != ""
+}
+
+// Complete go 和 npm 是否都可用
+func (t Toolchain) Complete() bool {
+	return t.HasGo() && t.HasNpm()
+}
+
+// Missing 缺失的工具名（例如 ["go", "npm"]）
+func (t Toolchain) Missing() []string {
+	var missing []string
+	if !t.HasGo() {
+		missing = append(missing, "go")
+	}
+	if !t.HasNpm() {
+		missing = append(missing, "npm")
+	}
+	return missing
+}
+
+// DetectToolchain 并发检测 go 和 npm 的版本（结果按 envcache.ToolTTL 缓存）
+func DetectToolchain() Toolchain {
+	var t Toolchain
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		t.GoVersion, _ = Facts.Get(toolFact(factGoVersion), envcache.ToolTTL, func() (string, error) {
+			return toolVersion("go", "env", "GOVERSION")
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		t.NpmVersion, _ = Facts.Get(toolFact(factNpmVersion), envcache.ToolTTL, func() (string, error) {
+			return toolVersion("npm", "-v")
+		})
+	}()
+	wg.Wait()
+	return t
+}
+
+// toolVersion 执行版本命令，取输出的最后一个非空行
+func toolVersion(name string, args ...string) (string, error) {
+	output, err := sysutil.Runner.Output("", name, args...)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	version := strings.TrimSpace(lines[len(lines)-1])
+	if version == "" {
+		return "", fmt.Errorf("%s 没有输出版本号", name)
+	}
+	return version, nil
+}
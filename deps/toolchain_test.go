@@ -0,0 +1,34 @@
+package deps
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestDetectToolchain(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go env GOVERSION", "go1.22.3\n", nil)
+	fake.Handle("npm -v", "", errors.New("exec: \"npm\": executable file not found in $PATH"))
+
+	Facts = envcache.New("")
+	defer func() { Facts = nil }()
+
+	tc := DetectToolchain()
+	if tc.GoVersion != "go1.22.3" || tc.HasNpm() || tc.Complete() {
+		t.Fatalf("toolchain = %+v", tc)
+	}
+	if want := []string{"npm"}; !reflect.DeepEqual(tc.Missing(), want) {
+		t.Errorf("Missing = %v, want %v", tc.Missing(), want)
+	}
+
+	// 安装 npm 后再次检测立即生效（失败的结果不缓存）
+	fake.Handle("npm -v", "npm warn config\n10.5.0\n", nil)
+	tc = DetectToolchain()
+	if tc.NpmVersion != "10.5.0" || !tc.Complete() || len(tc.Missing()) != 0 {
+		t.Errorf("toolchain = %+v", tc)
+	}
+}
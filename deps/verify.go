@@ -0,0 +1,162 @@
+package deps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// integrityErrors npm / go 输出中表示下载内容与校验和不一致的关键字（镜像源返回了被篡改的内容或缓存损坏）
+var integrityErrors = []string{"EINTEGRITY", "checksum mismatch", "SECURITY ERROR"}
+
+// integrityError 输出是否表示校验和不一致
+func integrityError(output string) bool {
+	for _, keyword := range integrityErrors {
+		if strings.Contains(output, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyBackend 校验模块缓存中的后端依赖与 go.sum 一致（go mod verify）；
+// 不一致时返回 DepIntegrityFailed 错误并列出被修改的模块
+func VerifyBackend(ctx context.Context, serverDir string) error {
+	output, err := sysutil.CombinedOutputContext(ctx, serverDir, "go", "mod", "verify")
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		return nil
+	}
+	if modified := parseGoModVerify(string(output)); len(modified) > 0 {
+		return apperr.Errorf(apperr.DepIntegrityFailed, "%d 个 Go 模块与 go.sum 中的校验和不一致:\n%s",
+			len(modified), strings.Join(modified, "\n"))
+	}
+	return apperr.Errorf(apperr.DepGoModFailed, "go mod verify 失败: %v\n%s", err, string(output))
+}
+
+// parseGoModVerify 从 go mod verify 的输出中提取内容被修改的模块，
+// 格式示例：github.com/gin-gonic/gin v1.10.0: dir has been modified (/root/go/pkg/mod/...)
+func parseGoModVerify(output string) []string {
+	var modified []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.Contains(line, "has been modified") && !strings.Contains(line, "checksum mismatch") {
+			continue
+		}
+		module, _, _ := strings.Cut(line, ":")
+		modified = append(modified, module)
+	}
+	return modified
+}
+
+// lockPackage package-lock.json 中的一个包
+type lockPackage struct {
+	Version         string            `json:"version"`
+	Integrity       string            `json:"integrity"`
+	Link            bool              `json:"link"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// readLockPackages 读取 lock 文件中的 packages（lockfileVersion 2 及以上）
+func readLockPackages(path string) (map[string]lockPackage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lock struct {
+		Packages map[string]lockPackage `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("%s 格式错误: %w", filepath.Base(path), err)
+	}
+	return lock.Packages, nil
+}
+
+// VerifyFrontend 校验前端依赖与 package-lock.json 一致（类似 npm ci 的检查，不联网）：
+// package.json 声明的依赖需与 lock 文件一致，node_modules 中实际安装的包（npm 记录在 node_modules/.package-lock.json）
+// 的版本和校验和需与 lock 文件一致；不一致时返回 DepIntegrityFailed 错误。
+// 没有 package-lock.json 或 npm 版本较旧（没有 .package-lock.json）时跳过相应的检查
+func VerifyFrontend(webDir string) error {
+	locked, err := readLockPackages(filepath.Join(webDir, "package-lock.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return apperr.Errorf(apperr.DepIntegrityFailed, "%v", err)
+	}
+
+	var problems []string
+	if manifest, err := readPackageJSON(webDir); err == nil {
+		problems = append(problems, lockOutOfSync(manifest, locked[""])...)
+	}
+	if installed, err := readLockPackages(filepath.Join(webDir, "node_modules", ".package-lock.json")); err == nil {
+		problems = append(problems, installedMismatch(locked, installed)...)
+	}
+	if len(problems) > 0 {
+		return apperr.Errorf(apperr.DepIntegrityFailed, "前端依赖与 package-lock.json 不一致:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// packageManifest package.json 中声明的依赖
+type packageManifest struct {
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// readPackageJSON 读取 package.json
+func readPackageJSON(webDir string) (packageManifest, error) {
+	var manifest packageManifest
+	data, err := os.ReadFile(filepath.Join(webDir, "package.json"))
+	if err != nil {
+		return manifest, err
+	}
+	return manifest, json.Unmarshal(data, &manifest)
+}
+
+// lockOutOfSync package.json 与 lock 文件根项目（packages[""]）中声明的依赖不一致之处（npm ci 会因此失败）
+func lockOutOfSync(manifest packageManifest, root lockPackage) []string {
+	var problems []string
+	check := func(declared, locked map[string]string) {
+		for name, spec := range declared {
+			if locked[name] != spec {
+				problems = append(problems, fmt.Sprintf("• %s: package.json 要求 %s，package-lock.json 中为 %q（lock 文件未同步）", name, spec, locked[name]))
+			}
+		}
+	}
+	check(manifest.Dependencies, root.Dependencies)
+	check(manifest.DevDependencies, root.DevDependencies)
+	sort.Strings(problems)
+	return problems
+}
+
+// installedMismatch node_modules 中实际安装的包与 lock 文件不一致之处：同一版本的校验和不同说明下载的内容被篡改或缓存损坏
+// （未安装的包，例如其他平台的可选依赖，不在这里检查）
+func installedMismatch(locked, installed map[string]lockPackage) []string {
+	var problems []string
+	for path, want := range locked {
+		got, ok := installed[path]
+		if !strings.HasPrefix(path, "node_modules/") || !ok || want.Link || got.Link {
+			continue
+		}
+		name := strings.TrimPrefix(path[strings.LastIndex(path, "node_modules/"):], "node_modules/")
+		switch {
+		case want.Version != "" && got.Version != want.Version:
+			problems = append(problems, fmt.Sprintf("• %s: 已安装 %s，lock 文件要求 %s", name, got.Version, want.Version))
+		case want.Integrity != "" && got.Integrity != "" && got.Integrity != want.Integrity:
+			problems = append(problems, fmt.Sprintf("• %s@%s: 校验和与 lock 文件不一致", name, got.Version))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
@@ -0,0 +1,87 @@
+package deps
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestVerifyBackend(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go mod verify", "all modules verified\n", nil)
+	if err := VerifyBackend(context.Background(), "/server"); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	fake.HandleExit("go mod verify", "github.com/gin-gonic/gin v1.10.0: dir has been modified (/go/pkg/mod/github.com/gin-gonic/gin@v1.10.0)\n"+
+		"golang.org/x/net v0.25.0: zip has been modified (/go/pkg/mod/cache/download/golang.org/x/net/@v/v0.25.0.zip)\n", errors.New("exit status 1"))
+	err := VerifyBackend(context.Background(), "/server")
+	if apperr.CodeOf(err) != apperr.DepIntegrityFailed || !strings.Contains(err.Error(), "github.com/gin-gonic/gin v1.10.0\ngolang.org/x/net v0.25.0") {
+		t.Errorf("err = %v", err)
+	}
+
+	fake.HandleExit("go mod verify", "go: go.mod file not found", errors.New("exit status 1"))
+	if err := VerifyBackend(context.Background(), "/server"); apperr.CodeOf(err) != apperr.DepGoModFailed {
+		t.Errorf("不是校验和问题时不应报告完整性错误, err = %v", err)
+	}
+}
+
+func TestVerifyFrontend(t *testing.T) {
+	web := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(web, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := VerifyFrontend(web); err != nil {
+		t.Errorf("没有 lock 文件时应跳过, err = %v", err)
+	}
+
+	write("package.json", `{"dependencies": {"vue": "^3.4.0"}, "devDependencies": {"vite": "^5.0.0"}}`)
+	write("package-lock.json", `{"lockfileVersion": 3, "packages": {
+		"": {"dependencies": {"vue": "^3.4.0"}, "devDependencies": {"vite": "^5.0.0"}},
+		"node_modules/vue": {"version": "3.4.21", "integrity": "sha512-good"},
+		"node_modules/vite": {"version": "5.2.0", "integrity": "sha512-vite"},
+		"node_modules/fsevents": {"version": "2.3.3", "integrity": "sha512-mac", "optional": true}
+	}}`)
+	write("node_modules/.package-lock.json", `{"lockfileVersion": 3, "packages": {
+		"node_modules/vue": {"version": "3.4.21", "integrity": "sha512-good"},
+		"node_modules/vite": {"version": "5.2.0", "integrity": "sha512-vite"}
+	}}`)
+	if err := VerifyFrontend(web); err != nil {
+		t.Fatalf("一致时应通过（未安装的可选依赖不检查）, err = %v", err)
+	}
+
+	write("package.json", `{"dependencies": {"vue": "^3.5.0"}, "devDependencies": {"vite": "^5.0.0"}}`)
+	write("node_modules/.package-lock.json", `{"lockfileVersion": 3, "packages": {
+		"node_modules/vue": {"version": "3.4.21", "integrity": "sha512-evil"},
+		"node_modules/vite": {"version": "5.1.0", "integrity": "sha512-old"}
+	}}`)
+	err := VerifyFrontend(web)
+	if apperr.CodeOf(err) != apperr.DepIntegrityFailed {
+		t.Fatalf("err = %v", err)
+	}
+	for _, want := range []string{"vue: package.json 要求 ^3.5.0", "vue@3.4.21: 校验和与 lock 文件不一致", "vite: 已安装 5.1.0，lock 文件要求 5.2.0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("错误中应包含 %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestInstallIntegrityError(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.HandleExit("npm install", "npm ERR! code EINTEGRITY\nnpm ERR! sha512-abc integrity checksum failed", errors.New("exit status 1"))
+	if err := InstallFrontend(context.Background(), "/web", "", nil); apperr.CodeOf(err) != apperr.DepIntegrityFailed {
+		t.Errorf("err = %v", err)
+	}
+}
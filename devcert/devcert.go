@@ -0,0 +1,211 @@
+// Package devcert 为本地开发生成受信任的 HTTPS 证书（与 mkcert 的做法相同）：
+// 首次使用时创建本机专用的根证书并加入当前用户的系统信任列表，再用它签发 localhost 和局域网 IP 的证书。
+// 根证书私钥只保存在面板数据目录中，不会离开本机
+package devcert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// CAName 根证书名称（系统证书管理器中显示）
+const CAName = "GVAPanel Local CA"
+
+// 证书目录中的文件名
+const (
+	caCertFile  = "rootCA.pem"
+	caKeyFile   = "rootCA-key.pem"
+	trustedFile = "rootCA.trusted" // 根证书已加入系统信任的标记
+	CertFile    = "cert.pem"
+	KeyFile     = "key.pem"
+)
+
+// 有效期：根证书 10 年；站点证书 825 天（macOS / iOS 接受的最长期限）
+const (
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 825 * 24 * time.Hour
+)
+
+// CA 本机根证书
+type CA struct {
+	Cert     *x509.Certificate
+	Key      crypto.Signer
+	CertPath string // rootCA.pem 路径（加入系统信任时使用）
+}
+
+// LoadOrCreateCA 读取 dir 中的根证书，不存在时创建（created 为 true）
+func LoadOrCreateCA(dir string) (ca *CA, created bool, err error) {
+	certPath := filepath.Join(dir, caCertFile)
+	keyPath := filepath.Join(dir, caKeyFile)
+
+	if sysutil.FileExists(certPath) && sysutil.FileExists(keyPath) {
+		cert, key, err := loadPair(certPath, keyPath)
+		if err != nil {
+			return nil, false, apperr.Errorf(apperr.HTTPSCertFailed, "读取根证书失败: %v", err)
+		}
+		return &CA{Cert: cert, Key: key, CertPath: certPath}, false, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, false, apperr.Errorf(apperr.HTTPSCertFailed, "创建证书目录失败: %v", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, false, apperr.Errorf(apperr.HTTPSCertFailed, "生成根证书私钥失败: %v", err)
+	}
+
+	owner := "unknown"
+	if host, err := os.Hostname(); err == nil {
+		owner = host
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, false, apperr.Errorf(apperr.HTTPSCertFailed, "生成证书序列号失败: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:         CAName + " " + owner,
+			Organization:       []string{CAName},
+			OrganizationalUnit: []string{owner},
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, false, apperr.Errorf(apperr.HTTPSCertFailed, "生成根证书失败: %v", err)
+	}
+	if err := writePair(certPath, keyPath, der, key); err != nil {
+		return nil, false, err
+	}
+	// 新根证书需要重新加入系统信任
+	os.Remove(filepath.Join(dir, trustedFile))
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, false, apperr.Errorf(apperr.HTTPSCertFailed, "解析根证书失败: %v", err)
+	}
+	return &CA{Cert: cert, Key: key, CertPath: certPath}, true, nil
+}
+
+// Issue 签发包含 hosts（域名或 IP）的站点证书，写入 dir/cert.pem 和 dir/key.pem
+func (ca *CA) Issue(dir string, hosts []string) (certPath, keyPath string, err error) {
+	if len(hosts) == 0 {
+		return "", "", apperr.Errorf(apperr.HTTPSCertFailed, "没有需要签发的域名或 IP")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", apperr.Errorf(apperr.HTTPSCertFailed, "生成证书私钥失败: %v", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return "", "", apperr.Errorf(apperr.HTTPSCertFailed, "生成证书序列号失败: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   hosts[0],
+			Organization: []string{CAName + " development certificate"},
+		},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, &key.PublicKey, ca.Key)
+	if err != nil {
+		return "", "", apperr.Errorf(apperr.HTTPSCertFailed, "签发证书失败: %v", err)
+	}
+	certPath = filepath.Join(dir, CertFile)
+	keyPath = filepath.Join(dir, KeyFile)
+	if err := writePair(certPath, keyPath, der, key); err != nil {
+		return "", "", err
+	}
+	return certPath, keyPath, nil
+}
+
+// Trusted 根证书是否已成功加入系统信任（由 Install 记录）
+func Trusted(dir string) bool {
+	return sysutil.FileExists(filepath.Join(dir, trustedFile))
+}
+
+// randomSerial 随机的 128 位证书序列号
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// writePair 以 PEM 格式写入证书和私钥（私钥仅当前用户可读）
+func writePair(certPath, keyPath string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return apperr.Errorf(apperr.HTTPSCertFailed, "编码私钥失败: %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return apperr.Errorf(apperr.HTTPSCertFailed, "写入私钥失败: %v", err)
+	}
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		return apperr.Errorf(apperr.HTTPSCertFailed, "写入证书失败: %v", err)
+	}
+	return nil
+}
+
+// loadPair 读取 PEM 格式的证书和 PKCS#8 私钥
+func loadPair(certPath, keyPath string) (*x509.Certificate, crypto.Signer, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("不是有效的 PEM 文件")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("不支持的私钥类型")
+	}
+	return cert, key, nil
+}
@@ -0,0 +1,175 @@
+package devcert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestIssueSignedByCA(t *testing.T) {
+	dir := t.TempDir()
+	ca, created, err := LoadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created || !ca.Cert.IsCA {
+		t.Fatalf("应创建新的根证书, created = %v", created)
+	}
+
+	certPath, keyPath, err := ca.Issue(dir, []string{"localhost", "127.0.0.1", "192.168.1.10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("证书和私钥不匹配: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	for _, host := range []string{"localhost", "127.0.0.1", "192.168.1.10"} {
+		if _, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots}); err != nil {
+			t.Errorf("%s 验证失败: %v", host, err)
+		}
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: "example.com", Roots: roots}); err == nil {
+		t.Error("未签发的域名不应通过验证")
+	}
+}
+
+func TestLoadExistingCA(t *testing.T) {
+	dir := t.TempDir()
+	first, _, err := LoadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, created, err := LoadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || !second.Cert.Equal(first.Cert) {
+		t.Error("已有根证书时应直接读取")
+	}
+	if _, _, err := second.Issue(dir, nil); apperr.CodeOf(err) != apperr.HTTPSCertFailed {
+		t.Errorf("没有域名时应报错: %v", err)
+	}
+}
+
+func TestInstallRecordsTrust(t *testing.T) {
+	if runtime.GOOS == "linux" {
+		stubLookPath(t, "trust")
+	}
+	dir := t.TempDir()
+	ca, _, err := LoadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, err := installCommands(runtime.GOOS, ca.CertPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner := sysutiltest.New(t)
+	for _, args := range commands {
+		runner.Handle(strings.Join(args, " "), "", nil)
+	}
+	if Trusted(dir) {
+		t.Fatal("安装前不应标记为已信任")
+	}
+	if err := Install(ca); err != nil {
+		t.Fatal(err)
+	}
+	if !Trusted(dir) {
+		t.Error("安装成功后应标记为已信任")
+	}
+
+	// 重新生成根证书后需要再次信任
+	if err := removeCA(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadOrCreateCA(dir); err != nil {
+		t.Fatal(err)
+	}
+	if Trusted(dir) {
+		t.Error("新根证书不应沿用旧的信任标记")
+	}
+}
+
+func TestInstallFailure(t *testing.T) {
+	if runtime.GOOS == "linux" {
+		stubLookPath(t, "trust")
+	}
+	dir := t.TempDir()
+	ca, _, err := LoadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysutiltest.New(t) // 未预设的命令返回错误
+	if err := Install(ca); apperr.CodeOf(err) != apperr.HTTPSTrustFailed {
+		t.Errorf("err = %v", err)
+	}
+	if Trusted(dir) {
+		t.Error("失败时不应标记为已信任")
+	}
+}
+
+func TestInstallCommands(t *testing.T) {
+	windows, err := installCommands("windows", `C:\certs\rootCA.pem`)
+	if err != nil || len(windows) != 1 || windows[0][0] != "certutil" || windows[0][1] != "-user" {
+		t.Errorf("windows = %v, err = %v", windows, err)
+	}
+
+	darwin, err := installCommands("darwin", "/certs/rootCA.pem")
+	if err != nil || len(darwin) != 1 || darwin[0][0] != "security" || !strings.HasSuffix(darwin[0][5], "login.keychain-db") {
+		t.Errorf("darwin = %v, err = %v", darwin, err)
+	}
+
+	stubLookPath(t, "trust")
+	linux, err := installCommands("linux", "/certs/rootCA.pem")
+	if err != nil || len(linux) != 1 || strings.Join(linux[0], " ") != "pkexec trust anchor --store /certs/rootCA.pem" {
+		t.Errorf("linux = %v, err = %v", linux, err)
+	}
+
+	stubLookPath(t)
+	if _, err := installCommands("linux", "/certs/rootCA.pem"); apperr.CodeOf(err) != apperr.HTTPSTrustFailed {
+		t.Errorf("缺少 trust 命令时应报错: %v", err)
+	}
+}
+
+// stubLookPath 让 lookPath 只找到 names 中的命令
+func stubLookPath(t *testing.T, names ...string) {
+	t.Helper()
+	original := lookPath
+	lookPath = func(file string) (string, error) {
+		for _, name := range names {
+			if file == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", &exec.Error{Name: file, Err: errors.New("not found")}
+	}
+	t.Cleanup(func() { lookPath = original })
+}
+
+// removeCA 删除根证书文件（模拟用户清理证书目录）
+func removeCA(dir string) error {
+	for _, name := range []string{caCertFile, caKeyFile} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
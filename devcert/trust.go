@@ -0,0 +1,66 @@
+package devcert
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// lookPath 查找命令（测试中可替换）
+var lookPath = exec.LookPath
+
+// Install 把根证书加入当前用户的系统信任列表，成功后记录到证书目录
+// 系统可能弹出确认窗口（Windows 安全警告、macOS 钥匙串密码、Linux 管理员授权）
+func Install(ca *CA) error {
+	commands, err := installCommands(runtime.GOOS, ca.CertPath)
+	if err != nil {
+		return err
+	}
+	for _, args := range commands {
+		output, err := sysutil.Runner.CombinedOutput("", args[0], args[1:]...)
+		if err != nil {
+			return apperr.Errorf(apperr.HTTPSTrustFailed, "执行 %s 失败: %v\n%s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(ca.CertPath), trustedFile), []byte(CAName+"\n"), 0644); err != nil {
+		return apperr.Errorf(apperr.HTTPSTrustFailed, "记录信任状态失败: %v", err)
+	}
+	return nil
+}
+
+// installCommands 指定系统上加入信任列表需要执行的命令
+//   - Windows: certutil 写入当前用户的“受信任的根证书颁发机构”
+//   - macOS:   security 写入登录钥匙串
+//   - Linux:   pkexec trust anchor 写入系统信任（p11-kit）；有 NSS certutil 时同时写入浏览器使用的 ~/.pki/nssdb
+func installCommands(goos, caPath string) ([][]string, error) {
+	switch goos {
+	case "windows":
+		return [][]string{{"certutil", "-user", "-addstore", "-f", "Root", caPath}}, nil
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, apperr.Errorf(apperr.HTTPSTrustFailed, "无法获取用户目录: %v", err)
+		}
+		keychain := filepath.Join(home, "Library", "Keychains", "login.keychain-db")
+		return [][]string{{"security", "add-trusted-cert", "-r", "trustRoot", "-k", keychain, caPath}}, nil
+	default:
+		if _, err := lookPath("trust"); err != nil {
+			return nil, apperr.Errorf(apperr.HTTPSTrustFailed, "未找到 trust 命令（p11-kit），请手动把 %s 加入系统信任", caPath)
+		}
+		commands := [][]string{{"pkexec", "trust", "anchor", "--store", caPath}}
+		if _, err := lookPath("certutil"); err == nil {
+			if home, err := os.UserHomeDir(); err == nil {
+				nssDB := filepath.Join(home, ".pki", "nssdb")
+				if sysutil.DirExists(nssDB) {
+					commands = append(commands, []string{"certutil", "-d", "sql:" + nssDB, "-A", "-t", "C,,", "-n", CAName, "-i", caPath})
+				}
+			}
+		}
+		return commands, nil
+	}
+}
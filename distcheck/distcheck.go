@@ -0,0 +1,100 @@
+// Package distcheck 检查前端构建产物（web/dist）实际使用的接口地址：确认 .env.production 中的接口地址
+// 已写入构建出的脚本，并找出残留的带端口的本机地址，避免把「用 localhost 接口构建的前端」部署出去
+package distcheck
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+)
+
+// Result 检查结果
+type Result struct {
+	Files      int      // 检查的文件数（.js / .html）
+	Expected   string   // 期望出现的接口地址（VITE_BASE_API）
+	Referenced bool     // 是否找到了期望的接口地址
+	LocalRefs  []string // 找到的带端口的本机地址，例如 http://127.0.0.1:8888
+}
+
+// localPattern 带端口的本机地址（不带端口的 localhost 常见于第三方库，不作为问题）
+var localPattern = regexp.MustCompile(`https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0|\[::1\]):\d+`)
+
+// Scan 扫描 distDir 中的 .js 和 .html 文件（不含 source map）
+func Scan(distDir string, env config.ProductionEnv) (Result, error) {
+	result := Result{Expected: env.BaseAPI}
+	if info, err := os.Stat(distDir); err != nil || !info.IsDir() {
+		return result, apperr.Errorf(apperr.BuildEnvInvalid, "未找到构建产物目录 %s，请先构建前端", distDir)
+	}
+
+	// 相对前缀以字符串字面量的形式出现（"/api"），完整地址直接查找
+	var needles [][]byte
+	if strings.Contains(env.BaseAPI, "://") {
+		needles = [][]byte{[]byte(strings.TrimRight(env.BaseAPI, "/"))}
+	} else {
+		for _, quote := range []string{`"`, `'`, "`"} {
+			needles = append(needles, []byte(quote+env.BaseAPI+quote))
+		}
+	}
+
+	local := map[string]bool{}
+	err := filepath.WalkDir(distDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".js" && ext != ".mjs" && ext != ".html" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		result.Files++
+		for _, needle := range needles {
+			if bytes.Contains(data, needle) {
+				result.Referenced = true
+			}
+		}
+		for _, m := range localPattern.FindAll(data, -1) {
+			local[string(m)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	for ref := range local {
+		result.LocalRefs = append(result.LocalRefs, ref)
+	}
+	sort.Strings(result.LocalRefs)
+	return result, nil
+}
+
+// Err 结果有问题时返回 BUILD_ENV_INVALID 错误：没有找到期望的接口地址，或残留本机地址
+func (r Result) Err() error {
+	switch {
+	case r.Files == 0:
+		return apperr.Errorf(apperr.BuildEnvInvalid, "构建产物中没有 .js / .html 文件")
+	case len(r.LocalRefs) > 0:
+		return apperr.Errorf(apperr.BuildEnvInvalid, "构建产物中仍有本机地址 %s，部署后会请求访问者自己的电脑", strings.Join(r.LocalRefs, "、"))
+	case !r.Referenced:
+		return apperr.Errorf(apperr.BuildEnvInvalid, "构建产物中没有找到接口地址 %s，构建时可能没有读取 .env.production", r.Expected)
+	}
+	return nil
+}
+
+// Verify 扫描并检查构建产物
+func Verify(distDir string, env config.ProductionEnv) (Result, error) {
+	result, err := Scan(distDir, env)
+	if err != nil {
+		return result, err
+	}
+	return result, result.Err()
+}
@@ -0,0 +1,61 @@
+package distcheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gva-launcher/config"
+)
+
+// writeDist 创建构建产物目录
+func writeDist(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	return dir
+}
+
+func TestVerify(t *testing.T) {
+	env := config.ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "/api"}
+	cases := []struct {
+		name  string
+		files map[string]string
+		ok    bool
+	}{
+		{"使用了接口前缀", map[string]string{
+			"index.html":          `<html><script src="/assets/index.js"></script></html>`,
+			"assets/index.js":     `const s=e.create({baseURL:"/api",timeout:99999});fetch("http://localhost/docs")`,
+			"assets/index.js.map": `"http://127.0.0.1:8888"`,
+		}, true},
+		{"用开发环境的地址构建", map[string]string{
+			"assets/index.js": `const s=e.create({baseURL:"http://127.0.0.1:8888/api"})`,
+		}, false},
+		{"没有使用接口前缀", map[string]string{
+			"assets/index.js": `const s=e.create({baseURL:"/dev-api"})`,
+		}, false},
+		{"没有脚本", map[string]string{"favicon.ico": ""}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Verify(writeDist(t, c.files), env)
+			if (err == nil) != c.ok {
+				t.Errorf("Verify = %v, want ok=%v", err, c.ok)
+			}
+		})
+	}
+}
+
+func TestVerifyAbsoluteAPI(t *testing.T) {
+	env := config.ProductionEnv{BasePath: "https://admin.example.com", BaseAPI: "https://api.example.com/"}
+	dist := writeDist(t, map[string]string{"assets/index.js": `baseURL:"https://api.example.com/"`})
+	if result, err := Verify(dist, env); err != nil || !result.Referenced {
+		t.Errorf("Verify = %+v, %v", result, err)
+	}
+	if _, err := Verify(filepath.Join(dist, "missing"), env); err == nil {
+		t.Error("目录不存在时应返回错误")
+	}
+}
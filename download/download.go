@@ -0,0 +1,169 @@
+// Package download 面板发起的下载（自更新等）共用的 HTTP 客户端：使用配置的 HTTP 代理，
+// GitHub 地址访问失败时依次改用镜像前缀和 Gitee 上的同名发布附件
+package download
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gva-launcher/config"
+)
+
+// giteeHost Gitee 上与 GitHub 同名的仓库（发布附件路径相同）
+const giteeHost = "gitee.com"
+
+var (
+	mu       sync.RWMutex
+	settings config.Network
+	proxyURL *url.URL
+)
+
+// transport 共用的连接池，代理按当前设置选择
+var transport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = proxy
+	return t
+}()
+
+// Configure 应用网络设置（代理地址不合法时返回错误，设置不变）
+func Configure(cfg config.Network) error {
+	u, err := ParseProxy(cfg.HTTPProxy)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	settings = cfg
+	proxyURL = u
+	mu.Unlock()
+	transport.CloseIdleConnections()
+	return nil
+}
+
+// ParseProxy 解析代理地址（为空时返回 nil，表示使用系统环境变量）。
+// 支持 http、https 和 socks5，省略协议时视为 http
+func ParseProxy(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("代理地址 %q 无效，例如 http://127.0.0.1:7890", raw)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	}
+	return nil, fmt.Errorf("不支持的代理协议 %s，请使用 http、https 或 socks5", u.Scheme)
+}
+
+// proxy 请求使用的代理：配置了代理时使用配置，否则读取 HTTPS_PROXY / HTTP_PROXY 环境变量
+func proxy(req *http.Request) (*url.URL, error) {
+	mu.RLock()
+	u := proxyURL
+	mu.RUnlock()
+	if u != nil {
+		return u, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// Client 使用当前网络设置的 HTTP 客户端
+func Client(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// Candidates 下载 rawURL 时依次尝试的地址：原地址、各镜像前缀（仅 GitHub 地址），
+// 最后是 Gitee 上同名仓库的发布附件（仅 GitHub 发布附件）
+func Candidates(rawURL string) []string {
+	mu.RLock()
+	mirrors := settings.Mirrors
+	mu.RUnlock()
+	return candidates(rawURL, mirrors)
+}
+
+// candidates 按指定的镜像前缀生成候选地址
+func candidates(rawURL string, mirrors []string) []string {
+	urls := []string{rawURL}
+	u, err := url.Parse(rawURL)
+	if err != nil || !isGitHub(u.Host) {
+		return urls
+	}
+
+	for _, mirror := range mirrors {
+		if mirror = strings.TrimSpace(mirror); mirror != "" {
+			urls = append(urls, strings.TrimSuffix(mirror, "/")+"/"+rawURL)
+		}
+	}
+
+	if u.Host == "github.com" && strings.Contains(u.Path, "/releases/download/") {
+		gitee := *u
+		gitee.Host = giteeHost
+		urls = append(urls, gitee.String())
+	}
+	return urls
+}
+
+// isGitHub 是否为 GitHub 的下载地址
+func isGitHub(host string) bool {
+	return host == "github.com" || strings.HasSuffix(host, ".githubusercontent.com")
+}
+
+// Get 下载 rawURL，失败时依次尝试 Candidates 中的其他地址，返回第一个成功（HTTP 200）的响应
+func Get(rawURL string, timeout time.Duration) (*http.Response, error) {
+	return getFirst(Client(timeout), Candidates(rawURL))
+}
+
+// Probe 用 cfg（尚未应用的设置）请求 rawURL，返回耗时，用于在保存前测试代理和镜像
+func Probe(cfg config.Network, rawURL string) (time.Duration, error) {
+	u, err := ParseProxy(cfg.HTTPProxy)
+	if err != nil {
+		return 0, err
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if u != nil {
+		t.Proxy = http.ProxyURL(u)
+	}
+	defer t.CloseIdleConnections()
+
+	start := time.Now()
+	resp, err := getFirst(&http.Client{Transport: t, Timeout: 15 * time.Second}, candidates(rawURL, cfg.Mirrors))
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return time.Since(start), nil
+}
+
+// getFirst 依次请求 urls，返回第一个成功的响应
+func getFirst(client *http.Client, urls []string) (*http.Response, error) {
+	var errs []string
+	for _, u := range urls {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", u, err))
+			continue
+		}
+		req.Header.Set("User-Agent", "GVAPanel")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", u, err))
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			errs = append(errs, fmt.Sprintf("%s: HTTP %d", u, resp.StatusCode))
+			continue
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+}
@@ -0,0 +1,136 @@
+package download
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/config"
+)
+
+func TestParseProxy(t *testing.T) {
+	cases := map[string]string{
+		"":                          "",
+		"127.0.0.1:7890":            "http://127.0.0.1:7890",
+		"socks5://127.0.0.1:1080":   "socks5://127.0.0.1:1080",
+		"https://user:pw@proxy:443": "https://user:pw@proxy:443",
+	}
+	for raw, want := range cases {
+		u, err := ParseProxy(raw)
+		if err != nil {
+			t.Errorf("%q: %v", raw, err)
+			continue
+		}
+		got := ""
+		if u != nil {
+			got = u.String()
+		}
+		if got != want {
+			t.Errorf("%q: got %q, want %q", raw, got, want)
+		}
+	}
+	for _, raw := range []string{"ftp://proxy:21", "http://"} {
+		if _, err := ParseProxy(raw); err == nil {
+			t.Errorf("%q 应报错", raw)
+		}
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	if err := Configure(config.Network{Mirrors: []string{"https://mirror.example.com/", " "}}); err != nil {
+		t.Fatal(err)
+	}
+	defer Configure(config.Network{})
+
+	asset := "https://github.com/XiaoafengClub/GVAPanel/releases/download/v1.2.0/checksums.txt"
+	got := Candidates(asset)
+	want := []string{
+		asset,
+		"https://mirror.example.com/" + asset,
+		"https://gitee.com/XiaoafengClub/GVAPanel/releases/download/v1.2.0/checksums.txt",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	raw := "https://raw.githubusercontent.com/a/b/main/x"
+	if got := Candidates(raw); len(got) != 2 {
+		t.Errorf("raw: %v", got)
+	}
+	if got := Candidates("https://gitee.com/a/b/releases/download/v1/x"); len(got) != 1 {
+		t.Errorf("非 GitHub 地址不应改写: %v", got)
+	}
+}
+
+func TestConfigureRejectsInvalidProxy(t *testing.T) {
+	if err := Configure(config.Network{HTTPProxy: "ftp://proxy:21", Mirrors: []string{"https://m/"}}); err == nil {
+		t.Fatal("无效代理应报错")
+	}
+	if got := Candidates("https://github.com/a/b/archive/x.zip"); len(got) != 1 {
+		t.Errorf("设置不应改变: %v", got)
+	}
+}
+
+func TestGetFirstFallsBack(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blocked" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	resp, err := getFirst(Client(5*time.Second), []string{server.URL + "/blocked", server.URL + "/mirror"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" || resp.Request.URL.Path != "/mirror" {
+		t.Errorf("body = %q, path = %s", body, resp.Request.URL.Path)
+	}
+
+	if _, err := getFirst(Client(5*time.Second), []string{server.URL + "/blocked"}); err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestConfiguredProxyIsUsed(t *testing.T) {
+	var proxied string
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		io.WriteString(w, "via proxy")
+	}))
+	defer proxyServer.Close()
+
+	if err := Configure(config.Network{HTTPProxy: proxyServer.URL}); err != nil {
+		t.Fatal(err)
+	}
+	defer Configure(config.Network{})
+
+	resp, err := Get("http://downloads.invalid/file", 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if proxied != "http://downloads.invalid/file" {
+		t.Errorf("代理收到的请求 = %q", proxied)
+	}
+}
+
+func TestProbeUsesGivenSettings(t *testing.T) {
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer proxyServer.Close()
+
+	if _, err := Probe(config.Network{HTTPProxy: proxyServer.URL}, "http://downloads.invalid/"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Probe(config.Network{HTTPProxy: "ftp://proxy:21"}, "http://downloads.invalid/"); err == nil {
+		t.Error("无效代理应报错")
+	}
+}
@@ -0,0 +1,114 @@
+// Package envcache 缓存启动时需要执行外部命令才能获取的环境信息（npm 镜像源、GOPROXY、GOMODCACHE、屏幕分辨率等），
+// 缓存保存在面板数据目录，下次启动时可立即显示，过期后再在后台重新获取
+package envcache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 常用的有效期
+const (
+	ToolTTL   = time.Hour      // 镜像源、模块缓存目录等工具配置
+	ScreenTTL = 24 * time.Hour // 屏幕分辨率
+)
+
+// entry 一条缓存
+type entry struct {
+	Value string    `json:"value"`
+	Time  time.Time `json:"time"`
+}
+
+// Cache 带有效期的键值缓存（path 为空时只缓存在内存中）
+type Cache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// New 创建缓存并读取已保存的内容（文件不存在或损坏时从空缓存开始）
+func New(path string) *Cache {
+	c := &Cache{path: path, entries: make(map[string]entry)}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			json.Unmarshal(data, &c.entries)
+		}
+	}
+	return c
+}
+
+// Peek 读取缓存值（不论是否过期），没有缓存时返回 false
+func (c *Cache) Peek(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return e.Value, ok
+}
+
+// Get 返回未过期的缓存值；没有缓存或已过期时调用 load 获取并保存（load 失败时不缓存）
+// c 为 nil 时直接调用 load
+func (c *Cache) Get(key string, ttl time.Duration, load func() (string, error)) (string, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(e.Time) < ttl {
+		return e.Value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	c.Set(key, value)
+	return value, nil
+}
+
+// Set 写入缓存并保存到文件
+func (c *Cache) Set(key, value string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.entries[key] = entry{Value: value, Time: time.Now()}
+	c.mu.Unlock()
+	c.save()
+}
+
+// Invalidate 删除缓存（配置被修改后调用，下次读取时重新获取）
+func (c *Cache) Invalidate(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+	c.save()
+}
+
+// save 保存到文件（失败时忽略，下次启动重新获取即可）
+func (c *Cache) save() {
+	if c.path == "" {
+		return
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return
+	}
+	os.WriteFile(c.path, data, 0644)
+}
@@ -0,0 +1,66 @@
+package envcache
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetCachesUntilExpired(t *testing.T) {
+	c := New("")
+	calls := 0
+	load := func() (string, error) {
+		calls++
+		return "https://goproxy.cn", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if v, err := c.Get("GOPROXY", time.Hour, load); err != nil || v != "https://goproxy.cn" {
+			t.Fatalf("Get = %q, %v", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("未过期时不应重复获取, calls = %d", calls)
+	}
+
+	c.Get("GOPROXY", 0, load)
+	if calls != 2 {
+		t.Errorf("过期后应重新获取, calls = %d", calls)
+	}
+}
+
+func TestGetErrorNotCached(t *testing.T) {
+	c := New("")
+	if _, err := c.Get("k", time.Hour, func() (string, error) { return "", errors.New("boom") }); err == nil {
+		t.Fatal("应返回 load 的错误")
+	}
+	if _, ok := c.Peek("k"); ok {
+		t.Error("失败的结果不应缓存")
+	}
+}
+
+func TestPersistAndInvalidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	New(path).Set("GOMODCACHE", "/go/pkg/mod")
+
+	c := New(path)
+	if v, ok := c.Peek("GOMODCACHE"); !ok || v != "/go/pkg/mod" {
+		t.Fatalf("应读取已保存的缓存, got %q %v", v, ok)
+	}
+
+	c.Invalidate("GOMODCACHE")
+	if _, ok := New(path).Peek("GOMODCACHE"); ok {
+		t.Error("Invalidate 后不应再有缓存")
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var c *Cache
+	v, err := c.Get("k", time.Hour, func() (string, error) { return "v", nil })
+	if err != nil || v != "v" {
+		t.Errorf("nil 缓存应直接调用 load, got %q %v", v, err)
+	}
+	c.Set("k", "v")
+	c.Invalidate("k")
+}
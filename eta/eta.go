@@ -0,0 +1,179 @@
+// Package eta 估计耗时较长的操作（安装依赖、构建、清理缓存）的剩余时间和速度：
+// 按项目记录每种操作最近几次的耗时（保存在面板数据目录），执行时结合进度推算剩余时间，
+// 并根据最近一段时间内计数（已安装的包、已清理的模块）的增长计算速度
+package eta
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// keepRuns 每种操作保留的历史次数
+const keepRuns = 5
+
+// History 各项目每种操作最近几次成功执行的耗时（秒）
+type History struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string][]float64
+}
+
+// NewHistory 创建耗时记录并读取已保存的内容（path 为空时只保存在内存中，文件不存在或损坏时从空记录开始）
+func NewHistory(path string) *History {
+	h := &History{path: path, entries: make(map[string][]float64)}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			json.Unmarshal(data, &h.entries)
+		}
+	}
+	return h
+}
+
+// Key 记录的键：项目根目录和操作名称（例如任务名「安装依赖」）
+func Key(project, op string) string {
+	return filepath.Clean(project) + "|" + op
+}
+
+// Record 记录一次耗时并保存（h 为 nil 时忽略）
+func (h *History) Record(key string, d time.Duration) {
+	if h == nil || d <= 0 {
+		return
+	}
+	h.mu.Lock()
+	runs := append(h.entries[key], d.Seconds())
+	if len(runs) > keepRuns {
+		runs = runs[len(runs)-keepRuns:]
+	}
+	h.entries[key] = runs
+	h.mu.Unlock()
+	h.save()
+}
+
+// Typical 平时的耗时（最近几次的中位数），没有记录时返回 false
+func (h *History) Typical(key string) (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+	h.mu.Lock()
+	runs := slices.Clone(h.entries[key])
+	h.mu.Unlock()
+	if len(runs) == 0 {
+		return 0, false
+	}
+	slices.Sort(runs)
+	median := runs[len(runs)/2]
+	if len(runs)%2 == 0 {
+		median = (runs[len(runs)/2-1] + median) / 2
+	}
+	return time.Duration(median * float64(time.Second)), true
+}
+
+// save 保存到文件（失败时忽略，只影响下次的估计）
+func (h *History) save() {
+	if h.path == "" {
+		return
+	}
+	h.mu.Lock()
+	data, err := json.MarshalIndent(h.entries, "", "  ")
+	h.mu.Unlock()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return
+	}
+	os.WriteFile(h.path, data, 0644)
+}
+
+// Remaining 估计剩余时间：有进度（0~1）时按已用时间和进度推算，否则用平时的耗时减去已用时间
+// （已超过平时的耗时时返回 0）；都没有时返回 false
+func Remaining(elapsed time.Duration, progress float64, typical time.Duration, hasTypical bool) (time.Duration, bool) {
+	switch {
+	case progress > 0 && progress < 1:
+		return time.Duration(float64(elapsed) / progress * (1 - progress)), true
+	case progress >= 1:
+		return 0, true
+	case hasTypical:
+		return max(typical-elapsed, 0), true
+	default:
+		return 0, false
+	}
+}
+
+// sample 计数的一次采样
+type sample struct {
+	at    time.Time
+	count float64
+}
+
+// Meter 速度计：根据最近 window 内计数的增长计算每秒速度
+type Meter struct {
+	window  time.Duration
+	samples []sample
+}
+
+// NewMeter 创建速度计
+func NewMeter(window time.Duration) *Meter {
+	return &Meter{window: window}
+}
+
+// Observe 记录某一时刻的累计计数
+func (m *Meter) Observe(at time.Time, count float64) {
+	m.samples = append(m.samples, sample{at, count})
+	// 保留窗口内的采样，以及窗口开始前的最后一个（作为起点）
+	for len(m.samples) > 2 && at.Sub(m.samples[1].at) >= m.window {
+		m.samples = m.samples[1:]
+	}
+}
+
+// Rate 每秒速度（采样不足时为 0）
+func (m *Meter) Rate() float64 {
+	if len(m.samples) < 2 {
+		return 0
+	}
+	first, last := m.samples[0], m.samples[len(m.samples)-1]
+	seconds := last.at.Sub(first.at).Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	return (last.count - first.count) / seconds
+}
+
+// FormatDuration 以「1 分 20 秒」的形式显示时长（不足 1 秒显示为「不到 1 秒」）
+func FormatDuration(d time.Duration) string {
+	if d < time.Second {
+		return "不到 1 秒"
+	}
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%d 秒", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%d 分 %d 秒", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%d 小时 %d 分", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// Describe 进度说明，例如「已用 35 秒 · 预计还需 1 分 10 秒 · 12.5 个包/秒」；
+// 没有进度但超过平时的耗时时说明比平时慢
+func Describe(elapsed time.Duration, progress float64, typical time.Duration, hasTypical bool, rate float64, unit string) string {
+	text := "已用 " + FormatDuration(elapsed)
+	if remaining, ok := Remaining(elapsed, progress, typical, hasTypical); ok {
+		if remaining == 0 && progress < 0 {
+			text += " · 比平时（约 " + FormatDuration(typical) + "）慢，请耐心等待"
+		} else {
+			text += " · 预计还需 " + FormatDuration(remaining)
+		}
+	}
+	if rate > 0 && unit != "" {
+		text += fmt.Sprintf(" · %.1f %s/秒", rate, unit)
+	}
+	return text
+}
@@ -0,0 +1,84 @@
+package eta
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryTypical(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "durations.json")
+	h := NewHistory(path)
+	key := Key("/srv/gva", "安装依赖")
+	if _, ok := h.Typical(key); ok {
+		t.Fatal("没有记录时不应有估计")
+	}
+
+	for _, s := range []int{100, 20, 60, 40, 80, 300} {
+		h.Record(key, time.Duration(s)*time.Second)
+	}
+	// 只保留最近 5 次（20 60 40 80 300），中位数为 60 秒
+	if got, ok := NewHistory(path).Typical(key); !ok || got != time.Minute {
+		t.Errorf("Typical = %v, %v, want 1m", got, ok)
+	}
+
+	h.Record(Key("/srv/other", "安装依赖"), time.Second)
+	if got, _ := h.Typical(key); got != time.Minute {
+		t.Errorf("不同项目的记录应分开, got %v", got)
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	cases := []struct {
+		elapsed    time.Duration
+		progress   float64
+		typical    time.Duration
+		hasTypical bool
+		want       time.Duration
+		ok         bool
+	}{
+		{30 * time.Second, 0.25, 0, false, 90 * time.Second, true},
+		{30 * time.Second, -1, 2 * time.Minute, true, 90 * time.Second, true},
+		{3 * time.Minute, -1, 2 * time.Minute, true, 0, true},
+		{30 * time.Second, -1, 0, false, 0, false},
+	}
+	for _, c := range cases {
+		got, ok := Remaining(c.elapsed, c.progress, c.typical, c.hasTypical)
+		if got != c.want || ok != c.ok {
+			t.Errorf("Remaining(%v, %v, %v, %v) = %v, %v, want %v, %v", c.elapsed, c.progress, c.typical, c.hasTypical, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestMeter(t *testing.T) {
+	m := NewMeter(5 * time.Second)
+	start := time.Now()
+	if m.Rate() != 0 {
+		t.Error("没有采样时速度应为 0")
+	}
+	// 前 10 秒每秒 1 个，之后每秒 10 个，窗口内只反映最近的速度
+	count := 0.0
+	for i := 0; i <= 20; i++ {
+		if i > 10 {
+			count += 10
+		} else if i > 0 {
+			count++
+		}
+		m.Observe(start.Add(time.Duration(i)*time.Second), count)
+	}
+	if got := m.Rate(); got != 10 {
+		t.Errorf("Rate = %v, want 10", got)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	if got := Describe(35*time.Second, -1, time.Minute+45*time.Second, true, 12.5, "个包"); got != "已用 35 秒 · 预计还需 1 分 10 秒 · 12.5 个包/秒" {
+		t.Errorf("got %q", got)
+	}
+	if got := Describe(3*time.Minute, -1, time.Minute, true, 0, ""); got != "已用 3 分 0 秒 · 比平时（约 1 分 0 秒）慢，请耐心等待" {
+		t.Errorf("got %q", got)
+	}
+	if got := Describe(500*time.Millisecond, -1, 0, false, 0, ""); got != "已用 不到 1 秒" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Package events 是面板内部的消息总线：引擎（服务管理、任务队列、配置保存）发布事件，
+// 图形界面等前端订阅后自行更新显示，引擎不直接操作界面控件
+package events
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Topic 事件类型
+type Topic string
+
+const (
+	ServiceChanged Topic = "service-changed" // 前后端服务运行状态变化
+	JobProgress    Topic = "job-progress"    // 后台任务状态或进度变化
+	ConfigChanged  Topic = "config-changed"  // 面板配置已保存
+	ServiceExited  Topic = "service-exited"  // 服务进程意外退出（不是由停止操作结束）
+	ServicesIdle   Topic = "services-idle"   // 服务长时间没有访问，已自动停止
+
+	JobOutput       Topic = "job-output"       // 后台任务有新输出
+	OutputChanged   Topic = "output-changed"   // 服务、终端、npm 脚本或远程日志有新输出（短时间内的多次写入合并为一次）
+	ProjectChanged  Topic = "project-changed"  // 项目的配置文件在磁盘上被修改（端口、.env 等）
+	TunnelChanged   Topic = "tunnel-changed"   // 外网穿透拿到访问地址或已退出
+	ScheduleChanged Topic = "schedule-changed" // 定时任务状态变化
+	ShowRequested   Topic = "show-requested"   // 其他面板实例请求显示窗口
+	QuitRequested   Topic = "quit-requested"   // 其他面板实例请求接管，当前面板退出
+	Tick            Topic = "tick"             // 定时发布（见 Bus.Ticks），订阅者据此刷新已用时间、运行时长等随时间变化的显示
+	TaskDone        Topic = "task-done"        // 前端发起的后台操作已完成，发起方按 ID 取回结果
+)
+
+// ServiceState 服务状态快照（ServiceChanged 事件携带）
+type ServiceState struct {
+	BackendRunning  bool
+	FrontendRunning bool
+	BackendPort     int // 未配置时为 0
+	FrontendPort    int
+
+	BackendRestarts  int // 意外退出后自动重启的次数（手动启动或停止后清零）
+	FrontendRestarts int
+
+	BackendPriority  string // 运行中服务进程的优先级（sysutil.Priority，正常或未运行时为空）
+	FrontendPriority string
+
+	BackendFailure  string // 上次启动失败（就绪前退出）的原因摘要，再次启动后清空
+	FrontendFailure string
+
+	BackendStarted  time.Time // 运行中服务的启动时间（未运行时为零值），用于显示运行时长
+	FrontendStarted time.Time
+}
+
+// ExitState 服务意外退出的信息（ServiceExited 事件携带）
+type ExitState struct {
+	Service string // backend / frontend
+	Error   string // 进程退出的错误（正常退出时为空）
+
+	Starting bool     // 进程在就绪前退出（启动失败，例如 go run 编译失败、npm run serve 立即退出）
+	ExitCode int      // 启动失败时进程的退出码（没有启动或拿不到退出码时为 -1）
+	Lines    []string // 启动失败时本次启动输出中的报错行（没有识别出报错时为最后几行）
+}
+
+// JobState 任务状态快照（JobProgress 事件携带）
+type JobState struct {
+	ID       int
+	Name     string
+	Status   string  // 与 jobs.Status 相同
+	Progress float64 // 0~1，小于 0 表示无法估计进度
+}
+
+// OutputState 有新输出的来源（OutputChanged 事件携带）
+type OutputState struct {
+	Source string // backend / frontend 或界面中的面板名称
+	Exited bool   // 产生输出的进程已退出（远程日志）
+	Error  string // 进程退出的错误（正常退出时为空）
+}
+
+// TunnelState 外网穿透的变化（TunnelChanged 事件携带）
+type TunnelState struct {
+	URL    string // 新拿到的访问地址（退出时为空）
+	Exited bool
+	Error  string // 意外退出的原因（主动停止时为空）
+}
+
+// TaskState 已完成的后台操作（TaskDone 事件携带）
+type TaskState struct {
+	ID   int
+	Name string
+}
+
+// Event 总线上传递的事件（只有与 Topic 对应的字段有效）
+type Event struct {
+	Topic   Topic
+	Time    time.Time
+	Service ServiceState
+	Job     JobState
+	Exit    ExitState
+	Output  OutputState
+	Tunnel  TunnelState
+	Task    TaskState
+}
+
+// Handler 事件处理函数
+type Handler func(Event)
+
+// subscription 一个订阅（topics 为空表示订阅所有事件）
+type subscription struct {
+	topics  map[Topic]bool
+	handler Handler
+}
+
+// Bus 消息总线（nil 的 *Bus 可以安全调用 Publish，不做任何事）
+type Bus struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]subscription
+}
+
+// New 创建总线
+func New() *Bus {
+	return &Bus{subs: make(map[int]subscription)}
+}
+
+// Subscribe 订阅指定类型的事件（不指定时订阅所有事件），返回取消订阅的函数
+// handler 在发布事件的协程中同步调用，不能阻塞
+func (b *Bus) Subscribe(handler Handler, topics ...Topic) (unsubscribe func()) {
+	sub := subscription{handler: handler}
+	if len(topics) > 0 {
+		sub.topics = make(map[Topic]bool, len(topics))
+		for _, t := range topics {
+			sub.topics[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// SubscribeOn 与 Subscribe 相同，但通过 run 调用 handler（例如切换到界面主线程执行）
+func (b *Bus) SubscribeOn(run func(func()), handler Handler, topics ...Topic) (unsubscribe func()) {
+	return b.Subscribe(func(e Event) {
+		run(func() { handler(e) })
+	}, topics...)
+}
+
+// Publish 发布事件（未设置 Time 时使用当前时间），按订阅顺序调用订阅者
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	ids := make([]int, 0, len(b.subs))
+	for id := range b.subs {
+		ids = append(ids, id)
+	}
+	handlers := make([]Handler, 0, len(ids))
+	sort.Ints(ids)
+	for _, id := range ids {
+		sub := b.subs[id]
+		if sub.topics == nil || sub.topics[e.Topic] {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.Unlock()
+
+	// 在锁外调用，订阅者可以在处理函数中取消订阅或发布新事件
+	for _, h := range handlers {
+		h(e)
+	}
+}
+
+// Ticks 每隔 interval 发布一次 Tick 事件，阻塞到 ctx 取消（在单独的协程中调用）
+func (b *Bus) Ticks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			b.Publish(Event{Topic: Tick, Time: now})
+		}
+	}
+}
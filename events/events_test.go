@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPublishFiltersTopics(t *testing.T) {
+	b := New()
+	var services, all []Topic
+	b.Subscribe(func(e Event) { services = append(services, e.Topic) }, ServiceChanged)
+	b.Subscribe(func(e Event) { all = append(all, e.Topic) })
+
+	b.Publish(Event{Topic: ServiceChanged, Service: ServiceState{BackendRunning: true}})
+	b.Publish(Event{Topic: JobProgress})
+	b.Publish(Event{Topic: ConfigChanged})
+
+	if len(services) != 1 || services[0] != ServiceChanged {
+		t.Errorf("只订阅服务事件, got %v", services)
+	}
+	if len(all) != 3 {
+		t.Errorf("未指定类型时应收到所有事件, got %v", all)
+	}
+}
+
+func TestPublishSetsTimeAndOrder(t *testing.T) {
+	b := New()
+	var order []int
+	for i := 1; i <= 3; i++ {
+		i := i
+		b.Subscribe(func(e Event) {
+			if e.Time.IsZero() {
+				t.Error("Time 应自动填写")
+			}
+			order = append(order, i)
+		})
+	}
+	b.Publish(Event{Topic: JobProgress})
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Errorf("应按订阅顺序调用, got %v", order)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	b := New()
+	count := 0
+	unsubscribe := b.Subscribe(func(Event) { count++ })
+	b.Publish(Event{Topic: ConfigChanged})
+	unsubscribe()
+	unsubscribe() // 重复调用无影响
+	b.Publish(Event{Topic: ConfigChanged})
+	if count != 1 {
+		t.Errorf("取消订阅后不应再收到事件, count = %d", count)
+	}
+}
+
+func TestUnsubscribeInsideHandler(t *testing.T) {
+	b := New()
+	count := 0
+	var unsubscribe func()
+	unsubscribe = b.Subscribe(func(Event) {
+		count++
+		unsubscribe()
+	})
+	b.Publish(Event{Topic: JobProgress})
+	b.Publish(Event{Topic: JobProgress})
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+}
+
+func TestSubscribeOn(t *testing.T) {
+	b := New()
+	var queued []func()
+	got := 0
+	b.SubscribeOn(func(fn func()) { queued = append(queued, fn) }, func(e Event) {
+		got = e.Job.ID
+	}, JobProgress)
+
+	b.Publish(Event{Topic: JobProgress, Job: JobState{ID: 7}})
+	if got != 0 || len(queued) != 1 {
+		t.Fatalf("handler 应交给 run 执行, got = %d, queued = %d", got, len(queued))
+	}
+	queued[0]()
+	if got != 7 {
+		t.Errorf("got = %d, want 7", got)
+	}
+}
+
+func TestNilBusPublish(t *testing.T) {
+	var b *Bus
+	b.Publish(Event{Topic: ServiceChanged}) // 不应 panic
+}
+
+func TestTicks(t *testing.T) {
+	b := New()
+	ticks := make(chan Event, 10)
+	b.Subscribe(func(e Event) { ticks <- e }, Tick)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.Ticks(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case e := <-ticks:
+			if e.Time.IsZero() {
+				t.Error("Tick 事件应带有时间")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("没有收到 Tick 事件")
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ctx 取消后 Ticks 应返回")
+	}
+}
@@ -0,0 +1,66 @@
+// Package fswatch 监听文件变化（基于 fsnotify）：监听文件所在的目录，编辑器先写临时文件再改名替换时也能收到通知，
+// 短时间内的多次变化（保存时的截断、写入、改名）合并为一次回调
+package fswatch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Debounce 最后一次变化后等待多久再回调
+var Debounce = 300 * time.Millisecond
+
+// Watch 监听 files 的创建、修改、删除和改名，变化停止 Debounce 后调用 onChange，阻塞到 ctx 取消。
+// 所在目录不存在的文件不监听（之后创建目录也不会补上）
+func Watch(ctx context.Context, files []string, onChange func()) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	names := make(map[string]bool, len(files))
+	dirs := map[string]bool{}
+	for _, file := range files {
+		file = filepath.Clean(file)
+		names[file] = true
+		dir := filepath.Dir(file)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		if err := w.Add(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	var fire <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			// 只修改权限不算变化
+			if names[filepath.Clean(e.Name)] && !e.Has(fsnotify.Chmod) {
+				fire = time.After(Debounce)
+			}
+		case _, ok := <-w.Errors:
+			// 事件队列溢出等错误时按有变化处理，由回调重新读取
+			if !ok {
+				return nil
+			}
+			fire = time.After(Debounce)
+		case <-fire:
+			fire = nil
+			onChange()
+		}
+	}
+}
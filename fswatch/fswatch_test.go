@@ -0,0 +1,54 @@
+package fswatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	Debounce = 50 * time.Millisecond
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.yaml")
+	os.WriteFile(config, []byte("addr: 8888\n"), 0644)
+
+	changed := make(chan struct{}, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, []string{config, filepath.Join(dir, "missing", ".env")}, func() { changed <- struct{}{} })
+	}()
+	// 等待开始监听
+	time.Sleep(100 * time.Millisecond)
+
+	// 其他文件的变化不回调
+	os.WriteFile(filepath.Join(dir, "other.txt"), []byte("x"), 0644)
+	select {
+	case <-changed:
+		t.Fatal("其他文件变化时不应回调")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// 连续多次写入只回调一次；先写临时文件再改名替换同样能收到
+	os.WriteFile(config, []byte("addr: 8889\n"), 0644)
+	tmp := filepath.Join(dir, "config.yaml.tmp")
+	os.WriteFile(tmp, []byte("addr: 8890\n"), 0644)
+	os.Rename(tmp, config)
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("修改后应回调")
+	}
+	select {
+	case <-changed:
+		t.Error("短时间内的多次变化应合并为一次回调")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package gvarelease
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gva-launcher/config"
+	"gva-launcher/updater"
+)
+
+// 前端的环境配置文件（位于 web 目录）
+const (
+	EnvDevelopment = ".env.development"
+	EnvProduction  = ".env.production"
+)
+
+// EnvFiles 参与对比的环境配置文件
+var EnvFiles = []string{EnvDevelopment, EnvProduction}
+
+// envDefaults 上游 web 目录下 env 文件的默认值，按起始版本从新到旧排列：
+// v2.5.0 起前端由 Vue CLI 换成 Vite，变量前缀从 VUE_APP_ 改为 VITE_
+var envDefaults = []struct {
+	Since string
+	Files map[string]map[string]string
+}{
+	{"v2.6.0", map[string]map[string]string{
+		EnvDevelopment: {
+			"ENV":              "development",
+			"VITE_CLI_PORT":    "8080",
+			"VITE_SERVER_PORT": "8888",
+			"VITE_BASE_API":    "/api",
+			"VITE_FILE_API":    "/api",
+			"VITE_BASE_PATH":   "http://127.0.0.1",
+			"VITE_POSITION":    "close",
+			"VITE_EDITOR":      "code",
+		},
+		EnvProduction: {
+			"ENV":            "production",
+			"VITE_BASE_API":  "/api",
+			"VITE_FILE_API":  "/api",
+			"VITE_BASE_PATH": "https://demo.gin-vue-admin.com",
+		},
+	}},
+	{"v2.5.0", map[string]map[string]string{
+		EnvDevelopment: {
+			"ENV":              "development",
+			"VITE_CLI_PORT":    "8080",
+			"VITE_SERVER_PORT": "8888",
+			"VITE_BASE_API":    "/api",
+			"VITE_BASE_PATH":   "http://127.0.0.1",
+		},
+		EnvProduction: {
+			"ENV":            "production",
+			"VITE_BASE_API":  "/api",
+			"VITE_BASE_PATH": "https://demo.gin-vue-admin.com",
+		},
+	}},
+	{"", map[string]map[string]string{
+		EnvDevelopment: {
+			"ENV":                 "development",
+			"VUE_APP_CLI_PORT":    "8080",
+			"VUE_APP_SERVER_PORT": "8888",
+			"VUE_APP_BASE_API":    "/api",
+			"VUE_APP_BASE_PATH":   "http://127.0.0.1",
+		},
+		EnvProduction: {
+			"ENV":               "production",
+			"VUE_APP_BASE_API":  "/api",
+			"VUE_APP_BASE_PATH": "https://demo.gin-vue-admin.com",
+		},
+	}},
+}
+
+// apiKeys 决定前端能否访问后端 API 的变量
+var apiKeys = map[string]bool{
+	"VITE_SERVER_PORT": true, "VITE_BASE_API": true, "VITE_FILE_API": true, "VITE_BASE_PATH": true,
+	"VUE_APP_SERVER_PORT": true, "VUE_APP_BASE_API": true, "VUE_APP_BASE_PATH": true,
+}
+
+// EnvDefaults version 对应的上游 env 默认值（文件名 -> 变量 -> 值）；version 为空时按最新版本
+func EnvDefaults(version string) map[string]map[string]string {
+	if version == "" {
+		return envDefaults[0].Files
+	}
+	for _, d := range envDefaults {
+		if d.Since == "" || !updater.IsNewer(d.Since, version) {
+			return d.Files
+		}
+	}
+	return envDefaults[len(envDefaults)-1].Files
+}
+
+// DeviationKind env 变量与上游默认值的差异类型
+type DeviationKind string
+
+const (
+	DeviationChanged DeviationKind = "changed" // 值与默认值不同
+	DeviationMissing DeviationKind = "missing" // 缺少上游默认有的变量
+	DeviationExtra   DeviationKind = "extra"   // 上游默认没有的变量
+)
+
+// EnvDeviation 项目 env 文件中与上游默认值不同的一项
+type EnvDeviation struct {
+	File    string
+	Key     string
+	Kind    DeviationKind
+	Default string // 上游默认值（Extra 时为空）
+	Value   string // 项目中的值（Missing 时为空）
+	API     bool   // 是否可能导致前端访问不到后端 API
+	Hint    string
+}
+
+// DiffEnv 对比 web 目录下 env 文件与 version 对应的上游默认值。
+// backendPort、frontendPort 大于 0 时代替 .env.development 中端口的默认值（面板改过端口时与其一致不算差异）。
+// 结果中可能影响 API 访问的差异排在前面
+func DiffEnv(webDir, version string, backendPort, frontendPort int) []EnvDeviation {
+	defaults := EnvDefaults(version)
+	vite := version == "" || !updater.IsNewer("v2.5.0", version)
+	prefix := "VUE_APP_"
+	if vite {
+		prefix = "VITE_"
+	}
+	expected := map[string]string{}
+	if backendPort > 0 {
+		expected[prefix+"SERVER_PORT"] = strconv.Itoa(backendPort)
+	}
+	if frontendPort > 0 {
+		expected[prefix+"CLI_PORT"] = strconv.Itoa(frontendPort)
+	}
+
+	var result []EnvDeviation
+	for _, file := range EnvFiles {
+		want := map[string]string{}
+		for key, value := range defaults[file] {
+			want[key] = value
+		}
+		if file == EnvDevelopment {
+			for key, value := range expected {
+				want[key] = value
+			}
+		}
+		have := map[string]string{}
+		if data, err := os.ReadFile(filepath.Join(webDir, file)); err == nil {
+			have = config.ParseEnv(data)
+		}
+
+		for key, def := range want {
+			value, ok := have[key]
+			switch {
+			case !ok:
+				result = append(result, deviation(file, key, DeviationMissing, def, "", vite))
+			case unquote(value) != def:
+				result = append(result, deviation(file, key, DeviationChanged, def, value, vite))
+			}
+		}
+		for key, value := range have {
+			if _, ok := want[key]; !ok {
+				result = append(result, deviation(file, key, DeviationExtra, "", value, vite))
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.API != b.API {
+			return a.API
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Key < b.Key
+	})
+	return result
+}
+
+// deviation 生成一项差异并补充是否影响 API 访问和说明
+func deviation(file, key string, kind DeviationKind, def, value string, vite bool) EnvDeviation {
+	d := EnvDeviation{File: file, Key: key, Kind: kind, Default: def, Value: value}
+	switch {
+	case kind == DeviationExtra && vite && strings.HasPrefix(key, "VUE_APP_"):
+		d.Hint = "Vue CLI 时代的变量，Vite 前端不会读取，请改用对应的 VITE_ 变量"
+		d.API = apiKeys[key]
+	case kind == DeviationExtra && !vite && strings.HasPrefix(key, "VITE_"):
+		d.Hint = "当前版本的前端基于 Vue CLI，不会读取 VITE_ 变量"
+	case kind == DeviationMissing && apiKeys[key]:
+		d.Hint = "缺少该变量时前端无法拼出后端地址"
+		d.API = true
+	case kind == DeviationChanged && apiKeys[key]:
+		d.Hint = "与默认值不同，前端会按该值访问后端"
+		// 生产环境的地址通常需要按部署修改，只有开发环境的改动才可能是访问不到 API 的原因
+		d.API = file == EnvDevelopment
+	}
+	return d
+}
+
+// unquote 去掉值两侧的引号（上游 env 文件中 ENV = 'development' 带引号）
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,62 @@
+package gvarelease
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvDefaults(t *testing.T) {
+	if d := EnvDefaults("v2.7.9"); d[EnvDevelopment]["VITE_FILE_API"] != "/api" {
+		t.Errorf("v2.7.9: %v", d[EnvDevelopment])
+	}
+	if d := EnvDefaults("v2.5.3"); d[EnvDevelopment]["VITE_BASE_API"] != "/api" || d[EnvDevelopment]["VITE_FILE_API"] != "" {
+		t.Errorf("v2.5.3: %v", d[EnvDevelopment])
+	}
+	if d := EnvDefaults("v2.4.6"); d[EnvDevelopment]["VUE_APP_BASE_API"] != "/api" {
+		t.Errorf("v2.4.6: %v", d[EnvDevelopment])
+	}
+	if d := EnvDefaults(""); d[EnvDevelopment]["VITE_EDITOR"] != "code" {
+		t.Errorf("未知版本应使用最新默认值: %v", d[EnvDevelopment])
+	}
+}
+
+func TestDiffEnv(t *testing.T) {
+	web := t.TempDir()
+	// 从旧版本升级后保留的 .env.development：仍使用 VUE_APP_BASE_API，端口已由面板改为 9999
+	os.WriteFile(filepath.Join(web, EnvDevelopment), []byte(`ENV = 'development'
+VITE_CLI_PORT = 8080
+VITE_SERVER_PORT = 9999
+VUE_APP_BASE_API = /api
+VITE_BASE_PATH = http://localhost
+VITE_POSITION = open
+`), 0644)
+
+	got := map[string]EnvDeviation{}
+	for _, d := range DiffEnv(web, "v2.5.2", 9999, 0) {
+		got[d.File+" "+d.Key] = d
+	}
+	check := func(key string, kind DeviationKind, api bool) {
+		t.Helper()
+		d, ok := got[key]
+		if !ok || d.Kind != kind || d.API != api {
+			t.Errorf("%s = %+v, ok=%v", key, d, ok)
+		}
+	}
+	check(".env.development VITE_BASE_API", DeviationMissing, true)
+	check(".env.development VUE_APP_BASE_API", DeviationExtra, true)
+	check(".env.development VITE_BASE_PATH", DeviationChanged, true)
+	check(".env.development VITE_POSITION", DeviationExtra, false)
+	check(".env.production VITE_BASE_API", DeviationMissing, true)
+	if _, ok := got[".env.development VITE_SERVER_PORT"]; ok {
+		t.Error("与面板设置一致的端口不算差异")
+	}
+	if _, ok := got[".env.development ENV"]; ok {
+		t.Error("带引号的值应与默认值一致")
+	}
+
+	list := DiffEnv(web, "v2.5.2", 0, 0)
+	if !list[0].API || list[len(list)-1].API {
+		t.Errorf("影响 API 的差异应排在前面: %+v", list)
+	}
+}
@@ -0,0 +1,172 @@
+// Package gvarelease 关注 gin-vue-admin 上游的新版本：查询发布列表、读取项目当前的 GVA 版本，
+// 并从更新说明中摘出不兼容变更，提醒升级前需要注意的地方
+package gvarelease
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gva-launcher/download"
+	"gva-launcher/updater"
+)
+
+// 上游仓库的发布列表接口和页面
+const (
+	ReleasesURL = "https://api.github.com/repos/flipped-aurora/gin-vue-admin/releases?per_page=20"
+	PageURL     = "https://github.com/flipped-aurora/gin-vue-admin/releases"
+)
+
+// CheckInterval 两次查询之间的最短间隔
+const CheckInterval = 24 * time.Hour
+
+// maxBreaking 每个版本最多摘录的不兼容变更条数
+const maxBreaking = 20
+
+// Release 上游的一个发布版本
+type Release struct {
+	Tag        string    `json:"tag_name"`
+	Name       string    `json:"name"`
+	Notes      string    `json:"body"`
+	URL        string    `json:"html_url"`
+	Published  time.Time `json:"published_at"`
+	Draft      bool      `json:"draft"`
+	Prerelease bool      `json:"prerelease"`
+}
+
+// Fetch 查询上游的正式发布（从新到旧，跳过草稿和预发布）
+func Fetch() ([]Release, error) {
+	return fetch(ReleasesURL)
+}
+
+// fetch 查询发布列表
+func fetch(url string) ([]Release, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "GVAPanel")
+
+	resp, err := download.Client(15 * time.Second).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("查询 GVA 发布失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("查询 GVA 发布失败: HTTP %d", resp.StatusCode)
+	}
+
+	var all []Release
+	if err := json.NewDecoder(resp.Body).Decode(&all); err != nil {
+		return nil, fmt.Errorf("解析 GVA 发布信息失败: %v", err)
+	}
+	var releases []Release
+	for _, rel := range all {
+		if !rel.Draft && !rel.Prerelease && rel.Tag != "" {
+			releases = append(releases, rel)
+		}
+	}
+	return releases, nil
+}
+
+// Newer 比 current 新的版本（保持从新到旧的顺序）；current 为空时只返回最新版本
+func Newer(releases []Release, current string) []Release {
+	if current == "" {
+		if len(releases) > 0 {
+			return releases[:1]
+		}
+		return nil
+	}
+	var newer []Release
+	for _, rel := range releases {
+		if updater.IsNewer(rel.Tag, current) {
+			newer = append(newer, rel)
+		}
+	}
+	return newer
+}
+
+// versionPattern server/global/version.go 中的版本号常量
+var versionPattern = regexp.MustCompile(`Version\s*=\s*"(v?\d+(\.\d+)*[^"]*)"`)
+
+// ProjectVersion 项目当前的 GVA 版本：优先读取 server/global/version.go，
+// 没有时使用 web/package.json 的 version（都读取不到时返回空字符串）
+func ProjectVersion(root string) string {
+	if data, err := os.ReadFile(filepath.Join(root, "server", "global", "version.go")); err == nil {
+		if m := versionPattern.FindStringSubmatch(string(data)); m != nil {
+			return "v" + strings.TrimPrefix(m[1], "v")
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "web", "package.json")); err == nil {
+		var pkg struct {
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Version != "" {
+			return "v" + strings.TrimPrefix(pkg.Version, "v")
+		}
+	}
+	return ""
+}
+
+// breakingWords 标题或条目中表示不兼容变更的关键词（小写）
+var breakingWords = []string{
+	"breaking", "不兼容", "破坏性", "重大变更", "重要变更", "迁移", "migration",
+	"废弃", "deprecated", "移除", "removed", "⚠",
+}
+
+// isBreaking 文本是否提到不兼容变更
+func isBreaking(text string) bool {
+	text = strings.ToLower(text)
+	for _, word := range breakingWords {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// listItem 去掉列表标记后的条目内容（不是列表项时 ok 为 false）
+var listItem = regexp.MustCompile(`^(?:[-*+]|\d+[.)、])\s+(.*)$`)
+
+// Breaking 从更新说明（Markdown）中摘出不兼容变更：
+// 标题提到不兼容 / 迁移等关键词时收录该节下的全部条目，其他条目本身提到关键词时单独收录
+func Breaking(notes string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	add := func(text string) {
+		text = strings.TrimSpace(text)
+		if text != "" && !seen[text] && len(items) < maxBreaking {
+			seen[text] = true
+			items = append(items, text)
+		}
+	}
+
+	inSection := false
+	for _, line := range strings.Split(notes, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			inSection = isBreaking(line)
+		case strings.HasPrefix(line, "**") && strings.HasSuffix(line, "**"):
+			// 用加粗代替标题的写法
+			inSection = isBreaking(line)
+		default:
+			text := line
+			m := listItem.FindStringSubmatch(line)
+			if m != nil {
+				text = m[1]
+			}
+			if (inSection && m != nil) || isBreaking(text) {
+				add(text)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,91 @@
+package gvarelease
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchSkipsPrereleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"tag_name":"v2.8.1-beta","prerelease":true},
+			{"tag_name":"v2.8.0","body":"notes","html_url":"https://github.com/x/releases/tag/v2.8.0","published_at":"2025-03-01T00:00:00Z"},
+			{"tag_name":"v2.7.9","draft":true},
+			{"tag_name":"v2.7.8"}
+		]`)
+	}))
+	defer server.Close()
+
+	releases, err := fetch(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 2 || releases[0].Tag != "v2.8.0" || releases[0].Published.Year() != 2025 || releases[1].Tag != "v2.7.8" {
+		t.Errorf("releases = %+v", releases)
+	}
+}
+
+func TestNewer(t *testing.T) {
+	releases := []Release{{Tag: "v2.8.1"}, {Tag: "v2.8.0"}, {Tag: "v2.7.9"}}
+	if got := Newer(releases, "v2.7.9"); len(got) != 2 || got[0].Tag != "v2.8.1" {
+		t.Errorf("got %+v", got)
+	}
+	if got := Newer(releases, "v2.8.1"); len(got) != 0 {
+		t.Errorf("已是最新版本: %+v", got)
+	}
+	if got := Newer(releases, ""); len(got) != 1 || got[0].Tag != "v2.8.1" {
+		t.Errorf("未知版本时只返回最新版本: %+v", got)
+	}
+}
+
+func TestProjectVersion(t *testing.T) {
+	root := t.TempDir()
+	if got := ProjectVersion(root); got != "" {
+		t.Errorf("空目录: %q", got)
+	}
+
+	os.MkdirAll(filepath.Join(root, "web"), 0755)
+	os.WriteFile(filepath.Join(root, "web", "package.json"), []byte(`{"name":"gin-vue-admin","version":"2.7.9"}`), 0644)
+	if got := ProjectVersion(root); got != "v2.7.9" {
+		t.Errorf("package.json: %q", got)
+	}
+
+	os.MkdirAll(filepath.Join(root, "server", "global"), 0755)
+	os.WriteFile(filepath.Join(root, "server", "global", "version.go"), []byte("package global\n\n// Version 当前版本号\nconst Version = \"v2.8.0\"\n"), 0644)
+	if got := ProjectVersion(root); got != "v2.8.0" {
+		t.Errorf("version.go: %q", got)
+	}
+}
+
+func TestBreaking(t *testing.T) {
+	notes := strings.Join([]string{
+		"## 新功能",
+		"- 新增 MCP 支持",
+		"- 修复菜单显示问题",
+		"## ⚠️ 不兼容变更",
+		"- 数据库表 sys_users 新增字段，请执行迁移",
+		"* 移除 `global.GVA_REDIS` 单例",
+		"说明文字不收录",
+		"**其他**",
+		"1. 配置项 `zap.prefix` 已废弃",
+		"- 优化启动速度",
+	}, "\r\n")
+
+	got := Breaking(notes)
+	want := []string{
+		"数据库表 sys_users 新增字段，请执行迁移",
+		"移除 `global.GVA_REDIS` 单例",
+		"配置项 `zap.prefix` 已废弃",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if got := Breaking("- 修复若干问题\n- 优化性能"); len(got) != 0 {
+		t.Errorf("没有不兼容变更: %v", got)
+	}
+}
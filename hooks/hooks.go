@@ -0,0 +1,201 @@
+// Package hooks 负责事件钩子：把用户配置的脚本绑定到服务启动、崩溃、依赖安装等事件，
+// 事件发生时立即执行（不在任务队列中排队，不受队列暂停影响），在任务中心记录日志
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+)
+
+// Event 事件名
+type Event string
+
+const (
+	BeforeStart  Event = "before-start"  // 启动服务之前（等待执行完成后再启动）
+	AfterStart   Event = "after-start"   // 前后端服务启动之后
+	OnCrash      Event = "on-crash"      // 服务进程意外退出
+	AfterInstall Event = "after-install" // 依赖安装成功之后
+	AfterBuild   Event = "after-build"   // 项目构建成功之后
+
+	DeployUnhealthy Event = "deploy-unhealthy" // 远程部署后健康检查没有通过（用于告警）
+)
+
+// Events 所有支持的事件（界面下拉框按此顺序显示）
+var Events = []Event{BeforeStart, AfterStart, OnCrash, AfterInstall, AfterBuild, DeployUnhealthy}
+
+// Label 事件的中文说明
+func (e Event) Label() string {
+	switch e {
+	case BeforeStart:
+		return "启动前"
+	case AfterStart:
+		return "启动后"
+	case OnCrash:
+		return "服务崩溃"
+	case AfterInstall:
+		return "依赖安装后"
+	case AfterBuild:
+		return "构建后"
+	case DeployUnhealthy:
+		return "部署后不健康"
+	default:
+		return string(e)
+	}
+}
+
+// Vars 传给钩子脚本的上下文（以 GVA_ 前缀的环境变量提供）
+type Vars map[string]string
+
+// Env 转换为环境变量列表（按键名排序，键名自动加 GVA_ 前缀并转大写）
+func (v Vars) Env() []string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, "GVA_"+strings.ToUpper(k)+"="+v[k])
+	}
+	return env
+}
+
+// maxRecent 保留的最近事件条数
+const maxRecent = 50
+
+// timeout 钩子脚本最长执行时间，超时后结束脚本（before-start 钩子卡住时不会一直阻止启动服务）
+var timeout = 2 * time.Minute
+
+// Record 一次事件记录（供状态导出使用）
+type Record struct {
+	Event Event     `json:"event"`
+	Time  time.Time `json:"time"`
+	Vars  Vars      `json:"vars,omitempty"`
+}
+
+// Dispatcher 事件分发器（hooks 为空或 queue 为 nil 时不执行任何脚本，但仍记录事件）
+type Dispatcher struct {
+	queue *jobs.Queue
+	hooks func() []config.Hook
+
+	mu     sync.Mutex
+	recent []Record      // 最近的事件（从旧到新）
+	counts map[Event]int // 各事件累计触发次数
+}
+
+// NewDispatcher 创建分发器（hooks 在每次触发时调用，保证读取到最新配置）
+func NewDispatcher(queue *jobs.Queue, hooks func() []config.Hook) *Dispatcher {
+	return &Dispatcher{queue: queue, hooks: hooks}
+}
+
+// Fire 触发事件，为每个匹配的钩子提交一个任务，返回提交的任务
+func (d *Dispatcher) Fire(event Event, vars Vars) []*jobs.Job {
+	if d == nil {
+		return nil
+	}
+	d.record(event, vars)
+	if d.queue == nil || d.hooks == nil {
+		return nil
+	}
+
+	all := Vars{"event": string(event)}
+	for k, v := range vars {
+		all[k] = v
+	}
+	env := all.Env()
+
+	var submitted []*jobs.Job
+	for _, hook := range d.hooks() {
+		if Event(hook.Event) != event || strings.TrimSpace(hook.Command) == "" {
+			continue
+		}
+		command := hook.Command
+		dir := vars["root"]
+		job := d.queue.Go(fmt.Sprintf("钩子 %s", event), func(ctx context.Context, j *jobs.Job) error {
+			return runScript(ctx, j, dir, command, env)
+		})
+		submitted = append(submitted, job)
+	}
+	return submitted
+}
+
+// record 记录一次事件（超过 maxRecent 条时丢弃最旧的）
+func (d *Dispatcher) record(event Event, vars Vars) {
+	copied := make(Vars, len(vars))
+	for k, v := range vars {
+		copied[k] = v
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.counts == nil {
+		d.counts = make(map[Event]int)
+	}
+	d.counts[event]++
+	d.recent = append(d.recent, Record{Event: event, Time: time.Now(), Vars: copied})
+	if len(d.recent) > maxRecent {
+		d.recent = d.recent[len(d.recent)-maxRecent:]
+	}
+}
+
+// Recent 最近触发的事件（从旧到新，最多 50 条）
+func (d *Dispatcher) Recent() []Record {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Record(nil), d.recent...)
+}
+
+// Counts 各事件自启动以来的触发次数
+func (d *Dispatcher) Counts() map[Event]int {
+	counts := make(map[Event]int)
+	if d == nil {
+		return counts
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for e, n := range d.counts {
+		counts[e] = n
+	}
+	return counts
+}
+
+// FireAndWait 触发事件并等待所有钩子执行完成，返回第一个失败的错误
+func (d *Dispatcher) FireAndWait(event Event, vars Vars) error {
+	var firstErr error
+	for _, job := range d.Fire(event, vars) {
+		if err := job.Wait(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// runScript 通过系统 shell 执行钩子命令（工作目录为 GVA 根目录），输出实时写入任务日志，超过 timeout 时结束脚本
+func runScript(ctx context.Context, j *jobs.Job, dir, command string, env []string) error {
+	j.Logf("$ %s", command)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	name, args := sysutil.ShellCommand(command)
+	err := sysutil.RunOutputEnvContext(ctx, dir, env, j, name, args...)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("钩子脚本超过 %s 没有结束，已停止", timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("钩子脚本执行失败: %v", err)
+	}
+	return nil
+}
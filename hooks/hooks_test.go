@@ -0,0 +1,151 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil/sysutiltest"
+	"gva-launcher/jobs"
+)
+
+// shellCommand 当前平台执行钩子时调用的命令行
+func shellCommand(command string) string {
+	if runtime.GOOS == "windows" {
+		return "cmd /C " + command
+	}
+	return "sh -c " + command
+}
+
+func TestVarsEnv(t *testing.T) {
+	got := Vars{"root": "/gva", "backend_port": "8888"}.Env()
+	want := []string{"GVA_BACKEND_PORT=8888", "GVA_ROOT=/gva"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFireAndWait(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle(shellCommand("echo start"), "started\n", nil)
+	fake.Handle(shellCommand("exit 1"), "", errors.New("exit status 1"))
+
+	list := []config.Hook{
+		{Event: string(BeforeStart), Command: "echo start"},
+		{Event: string(AfterStart), Command: "echo after"},
+		{Event: string(BeforeStart), Command: "exit 1"},
+		{Event: string(BeforeStart), Command: "  "},
+	}
+	d := NewDispatcher(startQueue(t), func() []config.Hook { return list })
+
+	if err := d.FireAndWait(BeforeStart, Vars{"root": "/gva"}); err == nil {
+		t.Error("有钩子失败时应返回错误")
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("应只执行 2 个 before-start 钩子, calls = %v", calls)
+	}
+	if calls[0].Dir != "/gva" {
+		t.Errorf("工作目录 = %q, want /gva", calls[0].Dir)
+	}
+	want := []string{"GVA_EVENT=before-start", "GVA_ROOT=/gva"}
+	if !reflect.DeepEqual(calls[0].Env, want) {
+		t.Errorf("env = %v, want %v", calls[0].Env, want)
+	}
+}
+
+func TestHooksSkipBusyQueue(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle(shellCommand("echo start"), "started\n", nil)
+
+	q := startQueue(t)
+	release := make(chan struct{})
+	defer close(release)
+	q.Submit("安装依赖", func(ctx context.Context, j *jobs.Job) error {
+		<-release
+		return nil
+	})
+	q.Pause()
+
+	list := []config.Hook{{Event: string(BeforeStart), Command: "echo start"}}
+	d := NewDispatcher(q, func() []config.Hook { return list })
+	done := make(chan error, 1)
+	go func() { done <- d.FireAndWait(BeforeStart, Vars{"root": "/gva"}) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("钩子不应等待队列中的任务或队列暂停")
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("需要 sh 和 sleep")
+	}
+	original := timeout
+	timeout = 100 * time.Millisecond
+	t.Cleanup(func() { timeout = original })
+
+	list := []config.Hook{{Event: string(BeforeStart), Command: "exec sleep 5"}}
+	d := NewDispatcher(startQueue(t), func() []config.Hook { return list })
+	start := time.Now()
+	err := d.FireAndWait(BeforeStart, nil)
+	if err == nil || !strings.Contains(err.Error(), "已停止") || time.Since(start) > 3*time.Second {
+		t.Errorf("超时后应停止脚本, err = %v, elapsed = %s", err, time.Since(start))
+	}
+}
+
+func TestNilDispatcher(t *testing.T) {
+	var d *Dispatcher
+	if jobs := d.Fire(OnCrash, nil); jobs != nil {
+		t.Errorf("nil 分发器不应提交任务, got %v", jobs)
+	}
+	if err := d.FireAndWait(BeforeStart, nil); err != nil {
+		t.Errorf("nil 分发器不应返回错误, got %v", err)
+	}
+}
+
+// startQueue 创建任务队列并在后台执行，测试结束时停止
+func startQueue(t *testing.T) *jobs.Queue {
+	q := jobs.NewQueue("")
+	ctx, cancel := context.WithCancel(context.Background())
+	go q.Run(ctx)
+	t.Cleanup(cancel)
+	return q
+}
+
+func TestRecentEvents(t *testing.T) {
+	d := NewDispatcher(nil, nil)
+	for i := 0; i < maxRecent+5; i++ {
+		d.Fire(AfterStart, nil)
+	}
+	d.Fire(OnCrash, Vars{"service": "backend"})
+
+	recent := d.Recent()
+	if len(recent) != maxRecent {
+		t.Fatalf("len(Recent) = %d, want %d", len(recent), maxRecent)
+	}
+	last := recent[len(recent)-1]
+	if last.Event != OnCrash || last.Vars["service"] != "backend" {
+		t.Errorf("最后一条事件 = %+v", last)
+	}
+
+	counts := d.Counts()
+	if counts[AfterStart] != maxRecent+5 || counts[OnCrash] != 1 {
+		t.Errorf("Counts = %v", counts)
+	}
+
+	var nilDispatcher *Dispatcher
+	if nilDispatcher.Recent() != nil || len(nilDispatcher.Counts()) != 0 {
+		t.Error("nil 分发器应返回空记录")
+	}
+}
@@ -0,0 +1,177 @@
+// Package hostsfile 管理系统 hosts 文件中由面板写入的主机名映射（例如 gva.local → 局域网 IP）。
+// 面板写入的每一行都以 "# gvapanel" 结尾，修改和删除时只处理这些行，不影响用户自己的条目
+package hostsfile
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// marker 面板写入的行尾注释
+const marker = "# gvapanel"
+
+// hostnamePattern 合法的主机名（字母、数字、连字符，以点分隔）
+var hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+// Path 系统 hosts 文件路径
+func Path() string {
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+		if root == "" {
+			root = `C:\Windows`
+		}
+		return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+	}
+	return "/etc/hosts"
+}
+
+// ValidHostname 检查主机名是否可以写入 hosts（不能是 IP 或 localhost）
+func ValidHostname(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("主机名不能为空")
+	case len(name) > 253 || !hostnamePattern.MatchString(name):
+		return fmt.Errorf("主机名 %q 不合法，只能包含字母、数字、连字符和点，例如 gva.local", name)
+	case net.ParseIP(name) != nil:
+		return fmt.Errorf("%q 是 IP 地址，请填写主机名", name)
+	case strings.EqualFold(name, "localhost"):
+		return fmt.Errorf("不能修改 localhost 的映射")
+	}
+	return nil
+}
+
+// Lookup 返回 hosts 内容中 name 映射到的 IP（只查找面板写入的行，没有时返回空字符串）
+func Lookup(content, name string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if ip, host, ok := parseMarked(line); ok && strings.EqualFold(host, name) {
+			return ip
+		}
+	}
+	return ""
+}
+
+// Apply 返回把 name 映射到 ip 后的 hosts 内容：替换面板写入的同名行，没有时追加到末尾；
+// ip 为空时只删除映射。保留原有的换行符
+func Apply(content, name, ip string) string {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if _, host, ok := parseMarked(line); ok && strings.EqualFold(host, name) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	result := strings.Join(kept, "")
+
+	if ip == "" {
+		return result
+	}
+	if result != "" && !strings.HasSuffix(result, "\n") {
+		result += newline
+	}
+	return result + ip + "\t" + name + " " + marker + newline
+}
+
+// parseMarked 解析面板写入的一行，返回 IP 和主机名
+func parseMarked(line string) (ip, host string, ok bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasSuffix(line, marker) {
+		return "", "", false
+	}
+	fields := strings.Fields(strings.TrimSuffix(line, marker))
+	if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+		return "", "", false
+	}
+	return fields[0], fields[1], true
+}
+
+// Current 读取系统 hosts 文件中 name 的映射（没有时返回空字符串）
+func Current(name string) string {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		return ""
+	}
+	return Lookup(string(data), name)
+}
+
+// Set 在系统 hosts 文件中把 name 映射到 ip（ip 为空时删除映射）。
+// 当前用户没有写入权限时请求管理员授权（系统会弹出确认窗口）
+func Set(name, ip string) error {
+	if err := ValidHostname(name); err != nil {
+		return apperr.Errorf(apperr.HostsUpdateFailed, "%v", err)
+	}
+	if ip != "" && net.ParseIP(ip) == nil {
+		return apperr.Errorf(apperr.HostsUpdateFailed, "%q 不是有效的 IP 地址", ip)
+	}
+
+	path := Path()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apperr.Errorf(apperr.HostsUpdateFailed, "读取 %s 失败: %v", path, err)
+	}
+	content := Apply(string(data), name, ip)
+	if content == string(data) {
+		return nil
+	}
+
+	err = os.WriteFile(path, []byte(content), 0644)
+	if err == nil {
+		return nil
+	}
+	if !os.IsPermission(err) {
+		return apperr.Errorf(apperr.HostsUpdateFailed, "写入 %s 失败: %v", path, err)
+	}
+	return writeElevated(path, content)
+}
+
+// writeElevated 先写入临时文件，再以管理员身份复制到 hosts
+func writeElevated(path, content string) error {
+	tmp, err := os.CreateTemp("", "gvapanel-hosts-*")
+	if err != nil {
+		return apperr.Errorf(apperr.HostsUpdateFailed, "创建临时文件失败: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return apperr.Errorf(apperr.HostsUpdateFailed, "写入临时文件失败: %v", err)
+	}
+	tmp.Close()
+
+	args := elevatedCopy(runtime.GOOS, tmp.Name(), path)
+	output, err := sysutil.Runner.CombinedOutput("", args[0], args[1:]...)
+	if err != nil {
+		return apperr.Errorf(apperr.HostsUpdateFailed, "以管理员身份写入 %s 失败: %v\n%s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// elevatedCopy 指定系统上以管理员身份把 src 复制到 dst 的命令
+//   - Windows: PowerShell Start-Process -Verb RunAs（UAC 确认）
+//   - macOS:   osascript do shell script ... with administrator privileges
+//   - Linux:   pkexec cp
+func elevatedCopy(goos, src, dst string) []string {
+	switch goos {
+	case "windows":
+		copyCmd := fmt.Sprintf(`/c copy /y "%s" "%s"`, src, dst)
+		return []string{"powershell", "-NoProfile", "-Command",
+			fmt.Sprintf("Start-Process -FilePath cmd -ArgumentList '%s' -Verb RunAs -Wait -WindowStyle Hidden", copyCmd)}
+	case "darwin":
+		script := fmt.Sprintf(`do shell script "cp '%s' '%s'" with administrator privileges`, src, dst)
+		return []string{"osascript", "-e", script}
+	default:
+		return []string{"pkexec", "cp", src, dst}
+	}
+}
@@ -0,0 +1,67 @@
+package hostsfile
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = "127.0.0.1\tlocalhost\r\n::1\tlocalhost\r\n# 192.168.1.5\tgva.local # gvapanel\r\n"
+
+func TestApplyAddsAndReplaces(t *testing.T) {
+	added := Apply(sample, "gva.local", "192.168.1.10")
+	if !strings.HasPrefix(added, sample) || !strings.HasSuffix(added, "192.168.1.10\tgva.local # gvapanel\r\n") {
+		t.Fatalf("added = %q", added)
+	}
+	if ip := Lookup(added, "GVA.local"); ip != "192.168.1.10" {
+		t.Errorf("Lookup = %q", ip)
+	}
+
+	replaced := Apply(added, "gva.local", "10.0.0.2")
+	if strings.Contains(replaced, "192.168.1.10") || strings.Count(replaced, "gva.local # gvapanel") != 2 {
+		t.Errorf("replaced = %q", replaced)
+	}
+	if ip := Lookup(replaced, "gva.local"); ip != "10.0.0.2" {
+		t.Errorf("Lookup = %q", ip)
+	}
+
+	if removed := Apply(replaced, "gva.local", ""); removed != sample {
+		t.Errorf("removed = %q", removed)
+	}
+}
+
+func TestApplyKeepsUserEntries(t *testing.T) {
+	content := "192.168.1.5 gva.local\n10.0.0.1 other.local # gvapanel" // 无结尾换行
+	got := Apply(content, "gva.local", "192.168.1.10")
+	want := "192.168.1.5 gva.local\n10.0.0.1 other.local # gvapanel\n192.168.1.10\tgva.local # gvapanel\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if ip := Lookup(content, "gva.local"); ip != "" {
+		t.Errorf("用户自己的条目不应被识别: %q", ip)
+	}
+}
+
+func TestValidHostname(t *testing.T) {
+	for _, name := range []string{"gva.local", "my-gva", "a1.dev.lan"} {
+		if err := ValidHostname(name); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "localhost", "192.168.1.2", "-gva.local", "gva..local", "gva local", "gva_local"} {
+		if err := ValidHostname(name); err == nil {
+			t.Errorf("%q 应不合法", name)
+		}
+	}
+}
+
+func TestElevatedCopy(t *testing.T) {
+	if got := strings.Join(elevatedCopy("linux", "/tmp/h", "/etc/hosts"), " "); got != "pkexec cp /tmp/h /etc/hosts" {
+		t.Errorf("linux: %s", got)
+	}
+	if got := elevatedCopy("darwin", "/tmp/h", "/etc/hosts"); got[0] != "osascript" || !strings.Contains(got[2], "with administrator privileges") {
+		t.Errorf("darwin: %v", got)
+	}
+	if got := elevatedCopy("windows", `C:\T\h`, `C:\Windows\System32\drivers\etc\hosts`); got[0] != "powershell" || !strings.Contains(got[3], "-Verb RunAs") {
+		t.Errorf("windows: %v", got)
+	}
+}
@@ -0,0 +1,466 @@
+// Package i18ncheck 检查前端的多语言资源（vue-i18n 的 locale 文件）：找出各语言之间缺少的键、空的翻译、
+// 代码中没有用到的键和代码中用到但没有定义的键，避免发布只翻译了一半的菜单和页面
+package i18ncheck
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocaleDirs 查找多语言资源的目录（相对 web 目录，按顺序使用第一个包含语言文件的目录）
+var LocaleDirs = []string{
+	"src/locale",
+	"src/locales",
+	"src/i18n/lang",
+	"src/i18n/locales",
+	"src/i18n",
+	"src/lang",
+}
+
+// localeExts 支持的语言文件扩展名（.js / .ts 只支持 export default 的对象字面量）
+var localeExts = []string{".json", ".yaml", ".yml", ".js", ".ts"}
+
+// sourceExts 查找键使用位置的源码扩展名
+var sourceExts = []string{".vue", ".js", ".ts", ".jsx", ".tsx"}
+
+// langRe 语言文件或目录的名称，例如 en、zh-CN、zh_TW、zh-Hans
+var langRe = regexp.MustCompile(`^[a-z]{2,3}([-_][A-Za-z0-9]{2,4})*$`)
+
+// usageRe 代码中的 t('key')、$t("key")、i18n.global.t(`key`)、tc / te 以及 <i18n-t keypath="key">
+var usageRe = regexp.MustCompile("(?:(?:^|[^\\w$])\\$?t[ce]?\\(\\s*|keypath=)(?:'([^'\\n]+)'|\"([^\"\\n]+)\"|`([^`\\n]+)`)")
+
+// Usage 代码中使用的一个键
+type Usage struct {
+	Key  string
+	File string // 相对 web 目录
+	Line int
+}
+
+// Result 检查结果
+type Result struct {
+	Dir       string              // 多语言资源目录（相对 web 目录），为空表示项目没有多语言资源
+	Languages []string            // 语言（按名称排序）
+	Keys      int                 // 所有语言合计的键数（去除重复）
+	Missing   map[string][]string // 语言 -> 其他语言有、该语言没有的键
+	Empty     map[string][]string // 语言 -> 值为空的键
+	Unused    []string            // 代码中没有用到的键
+	Undefined []Usage             // 代码中用到、没有任何语言定义的键
+	Warnings  []string            // 无法解析的文件等
+}
+
+// Issues 问题总数（缺少、空值和未定义的键，不含未使用的键）
+func (r Result) Issues() int {
+	n := len(r.Undefined)
+	for _, keys := range r.Missing {
+		n += len(keys)
+	}
+	for _, keys := range r.Empty {
+		n += len(keys)
+	}
+	return n
+}
+
+// Check 检查 web 目录中的多语言资源
+func Check(webDir string) (Result, error) {
+	var r Result
+	var locales map[string]map[string]string
+	for _, dir := range LocaleDirs {
+		found, warnings, err := loadLocales(filepath.Join(webDir, filepath.FromSlash(dir)))
+		if err != nil {
+			return r, err
+		}
+		if len(found) > 0 {
+			r.Dir, locales, r.Warnings = dir, found, warnings
+			break
+		}
+	}
+	if r.Dir == "" {
+		return r, nil
+	}
+
+	all := map[string]bool{}
+	for lang, values := range locales {
+		r.Languages = append(r.Languages, lang)
+		for key := range values {
+			all[key] = true
+		}
+	}
+	sort.Strings(r.Languages)
+	r.Keys = len(all)
+
+	r.Missing, r.Empty = map[string][]string{}, map[string][]string{}
+	for lang, values := range locales {
+		for key := range all {
+			value, ok := values[key]
+			switch {
+			case !ok:
+				r.Missing[lang] = append(r.Missing[lang], key)
+			case strings.TrimSpace(value) == "":
+				r.Empty[lang] = append(r.Empty[lang], key)
+			}
+		}
+		sort.Strings(r.Missing[lang])
+		sort.Strings(r.Empty[lang])
+	}
+
+	usages, err := findUsages(webDir, filepath.Join(webDir, filepath.FromSlash(r.Dir)))
+	if err != nil {
+		return r, err
+	}
+	used := map[string]bool{}
+	var prefixes []string
+	for _, u := range usages {
+		if prefix, ok := strings.CutSuffix(u.Key, "*"); ok {
+			prefixes = append(prefixes, prefix)
+			continue
+		}
+		used[u.Key] = true
+		if !all[u.Key] && !isParent(u.Key, all) {
+			r.Undefined = append(r.Undefined, u)
+		}
+	}
+	for key := range all {
+		if !used[key] && !usedByParent(key, used) && !hasAnyPrefix(key, prefixes) {
+			r.Unused = append(r.Unused, key)
+		}
+	}
+	sort.Strings(r.Unused)
+	return r, nil
+}
+
+// isParent key 是否是已定义的键的上级（例如 t('menu') 取整个 menu 对象）
+func isParent(key string, all map[string]bool) bool {
+	for k := range all {
+		if strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// usedByParent key 的某个上级是否被整体使用
+func usedByParent(key string, used map[string]bool) bool {
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		if used[key[:i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyPrefix key 是否匹配动态拼接的键的前缀（例如 t(`menu.${name}`) 使用了 menu. 下的所有键）
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadLocales 读取目录中的语言文件（zh-CN.json）和语言目录（zh-CN/menu.json，键加上文件名前缀 menu.），
+// 返回 语言 -> 扁平化的键值
+func loadLocales(dir string) (map[string]map[string]string, []string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	locales := map[string]map[string]string{}
+	var warnings []string
+	add := func(lang, prefix, path string) {
+		values, err := parseFile(path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", filepath.Base(filepath.Dir(path))+"/"+filepath.Base(path), err))
+			return
+		}
+		if locales[lang] == nil {
+			locales[lang] = map[string]string{}
+		}
+		flatten(prefix, values, locales[lang])
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			if !langRe.MatchString(name) {
+				continue
+			}
+			files, err := os.ReadDir(filepath.Join(dir, name))
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, f := range files {
+				base, ok := localeBase(f)
+				// index.js 通常只是汇总同目录的其他文件
+				if !ok || base == "index" {
+					continue
+				}
+				add(name, base, filepath.Join(dir, name, f.Name()))
+			}
+			continue
+		}
+		if base, ok := localeBase(entry); ok && langRe.MatchString(base) {
+			add(base, "", filepath.Join(dir, name))
+		}
+	}
+	return locales, warnings, nil
+}
+
+// localeBase 语言文件去掉扩展名后的名称，不是支持的语言文件时返回 false
+func localeBase(entry fs.DirEntry) (string, bool) {
+	if entry.IsDir() {
+		return "", false
+	}
+	ext := filepath.Ext(entry.Name())
+	for _, e := range localeExts {
+		if ext == e && !strings.HasSuffix(entry.Name(), ".d.ts") {
+			return strings.TrimSuffix(entry.Name(), ext), true
+		}
+	}
+	return "", false
+}
+
+// parseFile 解析语言文件：JSON 和 YAML 直接解析，JS / TS 把 export default 的对象字面量转换为 YAML 的流式映射后解析
+func parseFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext := filepath.Ext(path); ext == ".js" || ext == ".ts" {
+		if data, err = objectLiteral(string(data)); err != nil {
+			return nil, err
+		}
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("解析失败: %v", err)
+	}
+	return values, nil
+}
+
+// objectLiteral 取出 JS 源码中 export default 后的对象字面量：去掉注释，字符串统一改写为双引号（YAML 可以解析的转义），
+// 不支持模板字符串中的插值、变量引用和函数调用
+func objectLiteral(src string) ([]byte, error) {
+	if i := strings.Index(src, "export default"); i >= 0 {
+		src = src[i:]
+	}
+	start := strings.Index(src, "{")
+	if start < 0 {
+		return nil, fmt.Errorf("没有找到 export default 的对象")
+	}
+
+	var b strings.Builder
+	depth := 0
+	for i := start; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("注释没有结束")
+			}
+			i += end + 3
+		case c == '\'' || c == '"' || c == '`':
+			value, n, err := jsString(src[i:])
+			if err != nil {
+				return nil, err
+			}
+			b.WriteString(strconv.Quote(value))
+			i += n - 1
+		default:
+			b.WriteByte(c)
+			if c == '{' {
+				depth++
+			} else if c == '}' {
+				if depth--; depth == 0 {
+					return []byte(b.String()), nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("对象没有结束")
+}
+
+// jsString 解析 s 开头的 JS 字符串字面量，返回值和字面量的长度
+func jsString(s string) (string, int, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+			case '\n':
+				// 行尾的反斜杠表示续行
+			default:
+				b.WriteByte(s[i])
+			}
+		case quote == '`' && c == '$' && i+1 < len(s) && s[i+1] == '{':
+			return "", 0, fmt.Errorf("不支持模板字符串中的插值")
+		case c == '\n' && quote != '`':
+			return "", 0, fmt.Errorf("字符串没有结束")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("字符串没有结束")
+}
+
+// flatten 把嵌套的对象展开为点分隔的键（数组元素使用下标，例如 tips.0）
+func flatten(prefix string, value any, out map[string]string) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			flatten(join(key), child, out)
+		}
+	case map[any]any:
+		for key, child := range v {
+			flatten(join(fmt.Sprint(key)), child, out)
+		}
+	case []any:
+		for i, child := range v {
+			flatten(join(strconv.Itoa(i)), child, out)
+		}
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// findUsages 在 web/src 的源码中查找使用的键（跳过多语言资源目录）。
+// 动态拼接的键记为前缀加 *，例如 t(`menu.${name}`) 和 t('menu.' + name) 记为 menu.*
+func findUsages(webDir, localeDir string) ([]Usage, error) {
+	var usages []Usage
+	err := filepath.WalkDir(filepath.Join(webDir, "src"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path == localeDir || d.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		supported := false
+		for _, e := range sourceExts {
+			supported = supported || ext == e
+		}
+		// 直接放在 src/i18n 等目录中的语言文件也不算使用位置
+		if !supported || filepath.Dir(path) == localeDir {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(webDir, path)
+		usages = append(usages, scanUsages(filepath.ToSlash(rel), string(data))...)
+		return nil
+	})
+	return usages, err
+}
+
+// scanUsages 查找一个源码文件中使用的键
+func scanUsages(file, src string) []Usage {
+	var usages []Usage
+	for i, line := range strings.Split(src, "\n") {
+		for _, m := range usageRe.FindAllStringSubmatchIndex(line, -1) {
+			var key string
+			template := false
+			switch {
+			case m[2] >= 0:
+				key = line[m[2]:m[3]]
+			case m[4] >= 0:
+				key = line[m[4]:m[5]]
+			default:
+				key, template = line[m[6]:m[7]], true
+			}
+			if template {
+				if j := strings.Index(key, "${"); j >= 0 {
+					key = key[:j] + "*"
+				}
+			} else if strings.HasPrefix(strings.TrimSpace(line[m[1]:]), "+") {
+				key += "*"
+			}
+			if key == "*" || strings.ContainsAny(key, " <>") {
+				continue
+			}
+			usages = append(usages, Usage{Key: key, File: file, Line: i + 1})
+		}
+	}
+	return usages
+}
+
+// Report 文本报告（每类最多列出 limit 个键，limit <= 0 时全部列出）
+func Report(r Result, limit int) string {
+	if r.Dir == "" {
+		return fmt.Sprintf("没有找到多语言资源（查找了 web 下的 %s）\n", strings.Join(LocaleDirs, "、"))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "多语言资源: web/%s\n语言: %s，共 %d 个键\n", r.Dir, strings.Join(r.Languages, "、"), r.Keys)
+	list := func(title string, keys []string) {
+		if len(keys) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s（%d 个）:\n", title, len(keys))
+		for i, key := range keys {
+			if limit > 0 && i == limit {
+				fmt.Fprintf(&b, "  ... 还有 %d 个\n", len(keys)-limit)
+				break
+			}
+			fmt.Fprintf(&b, "  %s\n", key)
+		}
+	}
+	for _, lang := range r.Languages {
+		list(lang+" 缺少的键", r.Missing[lang])
+		list(lang+" 值为空的键", r.Empty[lang])
+	}
+	var undefined []string
+	for _, u := range r.Undefined {
+		undefined = append(undefined, fmt.Sprintf("%s（%s:%d）", u.Key, u.File, u.Line))
+	}
+	list("代码中使用但没有定义的键", undefined)
+	list("代码中没有用到的键（菜单标题等保存在数据库中的键也会列在这里）", r.Unused)
+	for _, w := range r.Warnings {
+		fmt.Fprintf(&b, "\n⚠️ %s\n", w)
+	}
+	if r.Issues() == 0 {
+		b.WriteString("\n✅ 各语言的键一致，没有缺少或空的翻译\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package i18ncheck
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	web := t.TempDir()
+	writeFiles(t, web, map[string]string{
+		"src/locale/zh-CN.json": `{"menu": {"dashboard": "仪表盘", "user": "用户管理", "role": "角色"}, "common": {"ok": "确定", "cancel": "取消"}, "status": {"on": "开", "off": "关"}}`,
+		"src/locale/en.js": `// 英文
+export default {
+  menu: {
+    dashboard: 'Dashboard', // 首页
+    user: 'It\'s users',
+  },
+  common: { ok: "OK", cancel: '' },
+  status: { on: 'On', off: 'Off' },
+  extra: 'http://example.com',
+}
+`,
+		"src/view/dashboard.vue": `<template>
+  <h1>{{ $t('menu.dashboard') }}</h1>
+  <el-button>{{ t("common.ok") }}</el-button>
+  <span>{{ t(` + "`status.${state}`" + `) }}</span>
+  <i18n-t keypath="menu.user" />
+  <p>{{ $t('menu.missing') }}</p>
+</template>`,
+		"src/utils/format.js": `export const label = (name) => i18n.global.t('common.' + name)
+const set = (v) => v`,
+	})
+
+	r, err := Check(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Dir != "src/locale" || !reflect.DeepEqual(r.Languages, []string{"en", "zh-CN"}) || r.Keys != 8 {
+		t.Fatalf("result = %+v", r)
+	}
+	if want := []string{"menu.role"}; !reflect.DeepEqual(r.Missing["en"], want) {
+		t.Errorf("en missing = %v", r.Missing["en"])
+	}
+	if want := []string{"extra"}; !reflect.DeepEqual(r.Missing["zh-CN"], want) {
+		t.Errorf("zh-CN missing = %v", r.Missing["zh-CN"])
+	}
+	if want := []string{"common.cancel"}; !reflect.DeepEqual(r.Empty["en"], want) {
+		t.Errorf("en empty = %v", r.Empty["en"])
+	}
+	// common.* 和 status.* 是动态拼接使用的
+	if want := []string{"extra", "menu.role"}; !reflect.DeepEqual(r.Unused, want) {
+		t.Errorf("unused = %v", r.Unused)
+	}
+	if len(r.Undefined) != 1 || r.Undefined[0] != (Usage{Key: "menu.missing", File: "src/view/dashboard.vue", Line: 6}) {
+		t.Errorf("undefined = %+v", r.Undefined)
+	}
+	if r.Issues() != 4 {
+		t.Errorf("issues = %d", r.Issues())
+	}
+
+	report := Report(r, 0)
+	for _, s := range []string{"web/src/locale", "en 缺少的键（1 个）", "menu.missing（src/view/dashboard.vue:6）"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("报告中缺少 %q:\n%s", s, report)
+		}
+	}
+}
+
+func TestCheckLanguageDirs(t *testing.T) {
+	web := t.TempDir()
+	writeFiles(t, web, map[string]string{
+		"src/i18n/lang/zh-CN/menu.yaml": "dashboard: 仪表盘\nuser: 用户\n",
+		"src/i18n/lang/zh-CN/index.js":  "import menu from './menu.yaml'\nexport default { menu }\n",
+		"src/i18n/lang/en/menu.yaml":    "dashboard: Dashboard\n",
+		"src/i18n/lang/en/broken.js":    "export default { title: `Hi ${name}` }\n",
+		"src/main.js":                   "app.use(i18n)\nconsole.log(t('menu'))\n",
+	})
+
+	r, err := Check(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Dir != "src/i18n/lang" || !reflect.DeepEqual(r.Missing["en"], []string{"menu.user"}) {
+		t.Errorf("result = %+v", r)
+	}
+	// t('menu') 整体使用了 menu 对象
+	if len(r.Unused) != 0 || len(r.Undefined) != 0 {
+		t.Errorf("unused = %v, undefined = %+v", r.Unused, r.Undefined)
+	}
+	if len(r.Warnings) != 1 || !strings.Contains(r.Warnings[0], "en/broken.js") {
+		t.Errorf("warnings = %v", r.Warnings)
+	}
+}
+
+func TestCheckWithoutLocales(t *testing.T) {
+	r, err := Check(t.TempDir())
+	if err != nil || r.Dir != "" {
+		t.Errorf("r = %+v, err = %v", r, err)
+	}
+	if !strings.Contains(Report(r, 0), "没有找到多语言资源") {
+		t.Error(Report(r, 0))
+	}
+}
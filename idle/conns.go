@@ -0,0 +1,86 @@
+package idle
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// Connections 本地端口为 ports 之一、已建立的 TCP 连接数（浏览器打开页面时 Vite 的热更新连接会一直保持）。
+// Linux 读取 /proc/net/tcp，其他系统解析 netstat -an 的输出
+func Connections(ports ...int) (int, error) {
+	wanted := make(map[int]bool)
+	for _, port := range ports {
+		if port > 0 {
+			wanted[port] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return 0, nil
+	}
+
+	if runtime.GOOS == "linux" {
+		// 关闭了 IPv6 时没有 tcp6，读到任一文件即可
+		count, read := 0, false
+		for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+			if data, err := os.ReadFile(path); err == nil {
+				count += parseProcNet(string(data), wanted)
+				read = true
+			}
+		}
+		if read {
+			return count, nil
+		}
+	}
+
+	output, err := sysutil.Runner.Output("", "netstat", "-an")
+	if err != nil {
+		return 0, err
+	}
+	return parseNetstat(string(output), wanted), nil
+}
+
+// tcpEstablished /proc/net/tcp 中 ESTABLISHED 状态的编号
+const tcpEstablished = "01"
+
+// parseProcNet 解析 /proc/net/tcp(6)：local_address 为 十六进制地址:十六进制端口，st 为状态
+func parseProcNet(data string, wanted map[int]bool) int {
+	count := 0
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[3] != tcpEstablished {
+			continue
+		}
+		_, hexPort, ok := strings.Cut(fields[1], ":")
+		if !ok {
+			continue
+		}
+		if port, err := strconv.ParseInt(hexPort, 16, 32); err == nil && wanted[int(port)] {
+			count++
+		}
+	}
+	return count
+}
+
+// parseNetstat 解析 netstat -an：状态列前第二列为本地地址，端口与地址之间 Windows / Linux 用冒号、macOS 用点号，
+// 例如 127.0.0.1:8080、[::1]:8080、127.0.0.1.8080
+func parseNetstat(output string, wanted map[int]bool) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		for i, field := range fields {
+			if field != "ESTABLISHED" || i < 2 {
+				continue
+			}
+			local := fields[i-2]
+			sep := strings.LastIndexAny(local, ":.")
+			if port, err := strconv.Atoi(local[sep+1:]); err == nil && wanted[port] {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,42 @@
+// Package idle 空闲检测：记录最近一次访问的时间（单端口代理转发的请求、服务端口上已建立的连接），
+// 开发服务器长时间没有人访问时由面板自动停止，节省电量和内存
+package idle
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Tracker 最近一次活动的时间（可在多个协程中使用）
+type Tracker struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewTracker 创建记录器，从现在开始计算空闲时间
+func NewTracker() *Tracker {
+	return &Tracker{last: time.Now()}
+}
+
+// Touch 记录一次活动
+func (t *Tracker) Touch() {
+	t.mu.Lock()
+	t.last = time.Now()
+	t.mu.Unlock()
+}
+
+// Idle 距最近一次活动的时间
+func (t *Tracker) Idle() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Since(t.last)
+}
+
+// Middleware 每个请求都记录为一次活动（包在单端口代理外层）
+func Middleware(t *Tracker, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Touch()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,90 @@
+package idle
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareTouches(t *testing.T) {
+	tr := &Tracker{last: time.Now().Add(-time.Hour)}
+	h := Middleware(tr, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if tr.Idle() > time.Minute {
+		t.Errorf("请求后应重新计算空闲时间, idle = %s", tr.Idle())
+	}
+}
+
+func TestParseProcNet(t *testing.T) {
+	data := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 1 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:D1A2 01 00000000:00000000 00:00000000 00000000  1000        0 2 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:D1A2 0100007F:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 3 1 0000000000000000 20 4 30 10 -1
+   3: 0100007F:22B8 0100007F:D1A3 01 00000000:00000000 00:00000000 00000000  1000        0 4 1 0000000000000000 20 4 30 10 -1
+`
+	// 8080（0x1F90）上一个监听、一个已建立的连接；另一条是客户端一侧（远程端口为 8080），不计入
+	if n := parseProcNet(data, map[int]bool{8080: true}); n != 1 {
+		t.Errorf("8080 = %d", n)
+	}
+	if n := parseProcNet(data, map[int]bool{8080: true, 8888: true}); n != 2 {
+		t.Errorf("8080+8888 = %d", n)
+	}
+}
+
+func TestParseNetstat(t *testing.T) {
+	windows := `
+Active Connections
+
+  Proto  Local Address          Foreign Address        State
+  TCP    0.0.0.0:8080           0.0.0.0:0              LISTENING
+  TCP    127.0.0.1:8080         127.0.0.1:53211        ESTABLISHED
+  TCP    127.0.0.1:53211        127.0.0.1:8080         ESTABLISHED
+  TCP    [::1]:8888             [::1]:53300            ESTABLISHED
+  TCP    127.0.0.1:18080        127.0.0.1:53212        ESTABLISHED
+`
+	mac := `Active Internet connections (including servers)
+Proto Recv-Q Send-Q  Local Address          Foreign Address        (state)
+tcp4       0      0  127.0.0.1.8080         127.0.0.1.53211        ESTABLISHED
+tcp4       0      0  127.0.0.1.53211        127.0.0.1.8080         ESTABLISHED
+tcp46      0      0  *.8080                 *.*                    LISTEN
+`
+	wanted := map[int]bool{8080: true, 8888: true}
+	if n := parseNetstat(windows, wanted); n != 2 {
+		t.Errorf("windows = %d", n)
+	}
+	if n := parseNetstat(mac, wanted); n != 1 {
+		t.Errorf("mac = %d", n)
+	}
+}
+
+func TestConnections(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("读取 /proc/net/tcp")
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+	if n, err := Connections(port); err != nil || n != 0 {
+		t.Fatalf("没有连接时 n = %d, err = %v", n, err)
+	}
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	server, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	if n, err := Connections(port); err != nil || n != 1 {
+		t.Errorf("n = %d, err = %v", n, err)
+	}
+}
@@ -0,0 +1,357 @@
+// Package instance 保证同一时间只运行一个面板：第一个实例持有锁文件并监听本机控制端口，
+// 后启动的实例可以通过控制端口让已有窗口获得焦点，或者请求它退出以接管
+package instance
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Info 锁文件内容（记录持有者信息，便于提示）
+type Info struct {
+	PID     int       `json:"pid"`
+	Addr    string    `json:"addr"` // 控制端口地址，例如 127.0.0.1:53124
+	User    string    `json:"user"`
+	Host    string    `json:"host"`
+	Started time.Time `json:"started"`
+	Token   string    `json:"token,omitempty"` // 控制端口的口令（共享目录中的锁不写入，不接受 focus / quit）
+}
+
+// pendingAge 锁文件创建后多久内内容为空或无法解析时视为正在写入（另一个实例刚创建），而不是残留
+const pendingAge = 5 * time.Second
+
+// Lock 当前实例持有的锁
+type Lock struct {
+	path     string
+	token    string
+	listener net.Listener
+
+	// OnFocus 其他实例请求聚焦窗口时调用
+	OnFocus func()
+	// OnQuit 其他实例请求接管（退出当前实例）时调用
+	OnQuit func()
+}
+
+// Existing 已在运行的实例
+type Existing struct {
+	Info
+	path string
+}
+
+// Acquire 尝试获取锁：成功返回 Lock；已有实例在运行时返回 Existing
+// 锁文件存在但持有者已无响应（异常退出）时自动清理后重新获取
+func Acquire(path string) (*Lock, *Existing, error) {
+	return acquire(path, false)
+}
+
+// AcquireShared 获取位于共享目录（例如多个用户、多台主机共用的项目目录）中的锁
+// 其他主机持有的锁无法确认持有者是否存活，一律视为仍被占用，需要对方退出或手动删除锁文件
+func AcquireShared(path string) (*Lock, *Existing, error) {
+	return acquire(path, true)
+}
+
+// acquire 获取锁（shared 为 true 时不清理其他主机的锁）
+func acquire(path string, shared bool) (*Lock, *Existing, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		lock, err := newLock(path, shared)
+		if err == nil {
+			return lock, nil, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, nil, err
+		}
+
+		info, err := readPending(path)
+		if err == nil && (alive(info.Addr) || shared && !info.sameHost()) {
+			return nil, &Existing{Info: info, path: path}, nil
+		}
+		if err != nil && recent(path) {
+			return nil, nil, fmt.Errorf("另一个面板正在创建锁文件，请稍后重试: %s", path)
+		}
+
+		// 持有者已退出，清理残留的锁文件（期间被其他实例换成新的锁时不删除）
+		removeStale(path, info, err)
+	}
+	return nil, nil, fmt.Errorf("无法获取锁文件: %s", path)
+}
+
+// newLock 启动控制端口并创建锁文件（已存在时返回 os.ErrExist）。
+// 非共享的锁写入随机口令，文件只有当前用户可读（Windows 上锁文件位于用户自己的数据目录中）
+func newLock(path string, shared bool) (*Lock, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("启动控制端口失败: %v", err)
+	}
+
+	info := currentInfo(listener.Addr().String())
+	perm := os.FileMode(0600)
+	if shared {
+		perm = 0644
+	} else {
+		info.Token = newToken()
+	}
+	data, _ := json.MarshalIndent(info, "", "  ")
+	if err := create(path, data, perm); err != nil {
+		listener.Close()
+		if errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("创建锁文件失败: %w", err)
+	}
+
+	l := &Lock{path: path, token: info.Token, listener: listener}
+	go l.serve()
+	return l, nil
+}
+
+// create 创建内容完整的锁文件：先写入临时文件再硬链接到 path，其他实例不会读到写了一半的锁文件；
+// 文件系统不支持硬链接时退回 O_EXCL 创建后写入（读到空文件的实例按 pendingAge 视为正在写入）
+func create(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), perm)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Link(tmp.Name(), path)
+	if err == nil || errors.Is(err, os.ErrExist) {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// readPending 读取锁文件；刚创建的锁文件还没有内容时稍等片刻再读
+func readPending(path string) (Info, error) {
+	info, err := readInfo(path)
+	for deadline := time.Now().Add(time.Second); err != nil && recent(path) && time.Now().Before(deadline); {
+		time.Sleep(100 * time.Millisecond)
+		info, err = readInfo(path)
+	}
+	return info, err
+}
+
+// recent 锁文件是否在 pendingAge 之内创建或修改
+func recent(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && time.Since(stat.ModTime()) < pendingAge
+}
+
+// removeStale 删除残留的锁文件：重新读取，内容与判断时相同才删除
+func removeStale(path string, stale Info, staleErr error) {
+	info, err := readInfo(path)
+	if (err == nil) != (staleErr == nil) || err == nil && info != stale {
+		return
+	}
+	os.Remove(path)
+}
+
+// newToken 随机口令
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// currentInfo 当前进程的锁信息
+func currentInfo(addr string) Info {
+	info := Info{PID: os.Getpid(), Addr: addr, Started: time.Now()}
+	if u, err := user.Current(); err == nil {
+		info.User = u.Username
+	}
+	info.Host, _ = os.Hostname()
+	return info
+}
+
+// Owner 持有者描述，例如 "alice@devbox（PID 1234，10-15 09:30 起）"
+func (i Info) Owner() string {
+	return fmt.Sprintf("%s@%s（PID %d，%s 起）", i.User, i.Host, i.PID, i.Started.Format("01-02 15:04"))
+}
+
+// SameUser 是否由当前主机上的当前用户持有（例如同一用户的面板窗口和看守模式）
+func (i Info) SameUser() bool {
+	current := currentInfo("")
+	return i.sameHost() && i.User == current.User
+}
+
+// sameHost 是否由当前主机持有
+func (i Info) sameHost() bool {
+	host, _ := os.Hostname()
+	return i.Host == host
+}
+
+// Release 释放锁（关闭控制端口并删除锁文件）
+func (l *Lock) Release() {
+	l.listener.Close()
+	os.Remove(l.path)
+}
+
+// serve 处理控制端口的命令（每个连接一行「口令 命令」，命令为 ping / focus / quit）
+func (l *Lock) serve() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return
+		}
+		go l.handle(conn)
+	}
+}
+
+// handle 处理单个控制连接
+func (l *Lock) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	// 共享目录中的锁没有公开口令，只响应 ping（其他用户检查持有者是否存活）
+	token, command := "", strings.TrimSpace(line)
+	if fields := strings.Fields(line); len(fields) == 2 {
+		token, command = fields[0], fields[1]
+	}
+	if token != l.token || l.token == "" && command != "ping" {
+		fmt.Fprintln(conn, "denied")
+		return
+	}
+
+	switch command {
+	case "ping":
+		fmt.Fprintln(conn, "pong")
+	case "focus":
+		fmt.Fprintln(conn, "ok")
+		if l.OnFocus != nil {
+			l.OnFocus()
+		}
+	case "quit":
+		fmt.Fprintln(conn, "ok")
+		if l.OnQuit != nil {
+			l.OnQuit()
+		}
+	default:
+		fmt.Fprintln(conn, "unknown")
+	}
+}
+
+// Running 锁文件的持有者是否仍在运行（只检查，不获取锁）
+func Running(path string) bool {
+	info, err := readInfo(path)
+	return err == nil && alive(info.Addr)
+}
+
+// Path 锁文件路径
+func (e *Existing) Path() string {
+	return e.path
+}
+
+// Focus 请求已有实例显示并聚焦窗口
+func (e *Existing) Focus() error {
+	return send(e.Addr, e.Token, "focus")
+}
+
+// TakeOver 请求已有实例退出，并等待它释放锁后获取锁
+func (e *Existing) TakeOver(timeout time.Duration) (*Lock, error) {
+	if err := send(e.Addr, e.Token, "quit"); err != nil {
+		return nil, fmt.Errorf("通知已运行的面板退出失败: %v", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		lock, existing, err := Acquire(e.path)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return lock, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return nil, fmt.Errorf("等待已运行的面板退出超时")
+}
+
+// readInfo 读取锁文件
+func readInfo(path string) (Info, error) {
+	var info Info
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+// alive 控制端口是否有应答（口令不符或旧版本不认识命令时同样说明持有者仍在运行）
+func alive(addr string) bool {
+	_, err := exchange(addr, "ping")
+	return err == nil
+}
+
+// send 带口令发送一行命令并检查应答
+func send(addr, token, command string) error {
+	if token != "" {
+		command = token + " " + command
+	}
+	reply, err := exchange(addr, command)
+	if err != nil {
+		return err
+	}
+	if reply == "denied" {
+		return fmt.Errorf("已运行的面板拒绝了请求（口令不符）")
+	}
+	if reply != "pong" && reply != "ok" {
+		return fmt.Errorf("无效的应答: %s", reply)
+	}
+	return nil
+}
+
+// exchange 发送一行内容并读取一行应答
+func exchange(addr, line string) (string, error) {
+	if addr == "" {
+		return "", fmt.Errorf("控制端口地址为空")
+	}
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(3 * time.Second))
+
+	fmt.Fprintln(conn, line)
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(reply), nil
+}
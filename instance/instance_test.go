@@ -0,0 +1,183 @@
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestAcquireFocusAndTakeOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gva-launcher.lock")
+
+	first, existing, err := Acquire(path)
+	if err != nil || existing != nil {
+		t.Fatalf("第一次获取应成功: existing = %v, err = %v", existing, err)
+	}
+	focused := make(chan struct{}, 1)
+	first.OnFocus = func() { focused <- struct{}{} }
+	first.OnQuit = func() { first.Release() }
+
+	_, existing, err = Acquire(path)
+	if err != nil || existing == nil {
+		t.Fatalf("第二次获取应返回已有实例: err = %v", err)
+	}
+	if existing.PID != os.Getpid() {
+		t.Errorf("PID = %d, want %d", existing.PID, os.Getpid())
+	}
+
+	if err := existing.Focus(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-focused:
+	case <-time.After(2 * time.Second):
+		t.Fatal("已有实例没有收到聚焦请求")
+	}
+
+	second, err := existing.TakeOver(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second.Release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("释放后锁文件应被删除")
+	}
+}
+
+func TestAcquireStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gva-launcher.lock")
+	// 残留的锁文件：控制端口已无人监听
+	os.WriteFile(path, []byte(`{"pid":1,"addr":"127.0.0.1:1"}`), 0644)
+
+	lock, existing, err := Acquire(path)
+	if err != nil || existing != nil {
+		t.Fatalf("残留锁应被清理: existing = %v, err = %v", existing, err)
+	}
+	lock.Release()
+}
+
+func TestAcquirePendingLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gva-launcher.lock")
+	// 另一个实例刚创建、还没写入内容的锁文件不能当作残留删除
+	os.WriteFile(path, nil, 0644)
+	if _, _, err := Acquire(path); err == nil {
+		t.Fatal("刚创建的空锁文件应视为被占用")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal("刚创建的空锁文件不应被删除")
+	}
+
+	old := time.Now().Add(-time.Minute)
+	os.Chtimes(path, old, old)
+	lock, existing, err := Acquire(path)
+	if err != nil || existing != nil {
+		t.Fatalf("很久之前的空锁文件应按残留清理: existing = %v, err = %v", existing, err)
+	}
+	lock.Release()
+}
+
+func TestControlToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gva-launcher.lock")
+	lock, _, err := Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	quit := make(chan struct{}, 1)
+	lock.OnQuit = func() { quit <- struct{}{} }
+
+	info, err := readInfo(path)
+	if err != nil || len(info.Token) != 32 {
+		t.Fatalf("锁文件应包含口令: %+v, err = %v", info, err)
+	}
+	if stat, _ := os.Stat(path); runtime.GOOS != "windows" && stat.Mode().Perm() != 0600 {
+		t.Errorf("锁文件权限 = %v, 应只有当前用户可读", stat.Mode().Perm())
+	}
+	for _, token := range []string{"", "wrong"} {
+		if err := send(info.Addr, token, "quit"); err == nil {
+			t.Errorf("口令 %q 不应被接受", token)
+		}
+	}
+	select {
+	case <-quit:
+		t.Fatal("没有口令的请求不应执行")
+	default:
+	}
+	if !Running(path) {
+		t.Error("口令不符时仍应能判断持有者在运行")
+	}
+
+	// 共享目录中的锁不公开口令，不接受 focus / quit
+	shared := filepath.Join(t.TempDir(), ".gvapanel.lock")
+	sharedLock, _, err := AcquireShared(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sharedLock.Release()
+	_, existing, _ := AcquireShared(shared)
+	if existing == nil || existing.Token != "" || existing.Focus() == nil {
+		t.Errorf("共享锁 existing = %+v", existing)
+	}
+}
+
+func TestRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gva-launcher.lock")
+	if Running(path) {
+		t.Fatal("没有锁文件时不应视为运行中")
+	}
+
+	lock, _, err := Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Running(path) {
+		t.Error("持有锁时应视为运行中")
+	}
+	lock.Release()
+	if Running(path) {
+		t.Error("释放锁后不应视为运行中")
+	}
+}
+
+func TestAcquireSharedKeepsOtherHostLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gvapanel.lock")
+	// 其他主机持有的锁：控制端口无法从本机访问
+	os.WriteFile(path, []byte(`{"pid":42,"addr":"127.0.0.1:1","user":"alice","host":"another-host"}`), 0644)
+
+	_, existing, err := AcquireShared(path)
+	if err != nil || existing == nil {
+		t.Fatalf("其他主机的锁应视为被占用: existing = %v, err = %v", existing, err)
+	}
+	if existing.User != "alice" || existing.SameUser() || existing.Path() != path {
+		t.Errorf("existing = %+v", existing.Info)
+	}
+
+	// 普通锁仍按无响应清理
+	lock, existing, err := Acquire(path)
+	if err != nil || existing != nil {
+		t.Fatalf("Acquire 应清理无响应的锁: existing = %v, err = %v", existing, err)
+	}
+	lock.Release()
+}
+
+func TestAcquireSharedSameUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gvapanel.lock")
+	first, _, err := AcquireShared(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Release()
+
+	_, existing, err := AcquireShared(path)
+	if err != nil || existing == nil {
+		t.Fatalf("第二次获取应返回已有实例: err = %v", err)
+	}
+	if !existing.SameUser() {
+		t.Errorf("同一用户持有的锁应返回 SameUser: %+v", existing.Info)
+	}
+	if existing.Owner() == "" {
+		t.Error("Owner 不应为空")
+	}
+}
@@ -0,0 +1,84 @@
+package sysutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CombinedOutputContext 与 Runner.CombinedOutput 相同，但 ctx 取消时结束进程（Windows 下包括 npm.cmd 启动的 node 等子进程），
+// 返回已有的输出和 ctx.Err()；用于可以暂停、取消的下载任务
+func CombinedOutputContext(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	var output lockedBuffer
+	err := RunOutputContext(ctx, dir, &output, name, args...)
+	return output.Bytes(), err
+}
+
+// RunOutputContext 执行命令，标准输出和标准错误实时写入 output（例如任务日志）；
+// ctx 取消时结束进程并返回 ctx.Err()
+func RunOutputContext(ctx context.Context, dir string, output io.Writer, name string, args ...string) error {
+	return RunOutputEnvContext(ctx, dir, nil, output, name, args...)
+}
+
+// killWait ctx 取消、结束进程后最多再等待多久：进程启动的子进程仍持有输出管道时 Wait 会等到子进程结束
+var killWait = 3 * time.Second
+
+// RunOutputEnvContext 与 RunOutputContext 相同，并在当前环境变量基础上追加 env
+func RunOutputEnvContext(ctx context.Context, dir string, env []string, output io.Writer, name string, args ...string) error {
+	proc, err := Runner.StartOutputEnv(dir, env, output, name, args...)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- proc.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		killTree(proc.OSProcess())
+		select {
+		case <-done:
+		case <-time.After(killWait):
+		}
+		return ctx.Err()
+	}
+}
+
+// killTree 结束进程及其子进程
+func killTree(p *os.Process) {
+	if p == nil {
+		return
+	}
+	if runtime.GOOS == "windows" {
+		if Runner.Run("", "taskkill", "/F", "/T", "/PID", strconv.Itoa(p.Pid)) == nil {
+			return
+		}
+	}
+	p.Kill()
+}
+
+// lockedBuffer 可在进程输出的复制协程和调用方之间共享的缓冲
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write 实现 io.Writer
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Bytes 已写入内容的副本
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
@@ -0,0 +1,46 @@
+package sysutil
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCombinedOutputContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("使用 sh 测试")
+	}
+
+	output, err := CombinedOutputContext(context.Background(), "", "sh", "-c", "echo out; echo err >&2")
+	if err != nil || !strings.Contains(string(output), "out") || !strings.Contains(string(output), "err") {
+		t.Errorf("output = %q, err = %v", output, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	output, err = CombinedOutputContext(ctx, "", "sh", "-c", "echo started; exec sleep 10")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("取消后应返回 ctx 的错误, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("取消后应结束进程")
+	}
+	if !strings.Contains(string(output), "started") {
+		t.Errorf("应返回取消前的输出, got %q", output)
+	}
+
+	// 子进程仍持有输出管道时不一直等待
+	original := killWait
+	killWait = 100 * time.Millisecond
+	t.Cleanup(func() { killWait = original })
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if _, err := CombinedOutputContext(ctx, "", "sh", "-c", "sleep 10; echo done"); !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 5*time.Second {
+		t.Errorf("err = %v, elapsed = %s", err, time.Since(start))
+	}
+}
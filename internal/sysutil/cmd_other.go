@@ -0,0 +1,16 @@
+//go:build !windows
+
+package sysutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// hideWindow 非 Windows 平台没有控制台窗口，无需处理
+func hideWindow(cmd *exec.Cmd) {}
+
+// detach 在新的会话中启动进程，面板退出（或其终端关闭）时不会收到 SIGHUP
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package sysutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachedProcess DETACHED_PROCESS：子进程不继承面板的控制台
+const detachedProcess = 0x00000008
+
+// hideWindow 隐藏子进程的控制台窗口
+func hideWindow(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+}
+
+// detach 在新的进程组中启动进程且不继承控制台，面板退出时不会一起结束
+func detach(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess
+}
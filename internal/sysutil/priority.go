@@ -0,0 +1,53 @@
+package sysutil
+
+import (
+	"os"
+)
+
+// Priority 进程优先级（子进程继承，例如 go run 编译出的程序、npm 启动的 node）
+type Priority string
+
+const (
+	PriorityNormal      Priority = ""             // 正常（不调整）
+	PriorityBelowNormal Priority = "below-normal" // 低于正常：nice 10 / BELOW_NORMAL_PRIORITY_CLASS
+	PriorityLow         Priority = "low"          // 低：nice 19 / IDLE_PRIORITY_CLASS，只在空闲时运行
+)
+
+// Priorities 可选的优先级（下拉框顺序）
+var Priorities = []Priority{PriorityNormal, PriorityBelowNormal, PriorityLow}
+
+// PriorityLabel 优先级的中文名称
+func PriorityLabel(p Priority) string {
+	switch p {
+	case PriorityBelowNormal:
+		return "低于正常"
+	case PriorityLow:
+		return "低"
+	}
+	return "正常"
+}
+
+// SetPriority 调整已启动进程的优先级（正常优先级不做处理）。之后由它创建的子进程继承新的优先级，
+// 已经创建的子进程不受影响，因此应在进程启动后立即调用
+func SetPriority(p *os.Process, priority Priority) error {
+	if p == nil || priority == PriorityNormal {
+		return nil
+	}
+	return setPriority(p.Pid, priority)
+}
+
+// CombinedOutputPriority 与 Runner.CombinedOutputEnv 相同，但进程以 priority 优先级运行
+// （正常优先级时直接调用 CombinedOutputEnv）；调整优先级失败时仍以正常优先级完成命令
+func CombinedOutputPriority(dir string, env []string, priority Priority, name string, args ...string) ([]byte, error) {
+	if priority == PriorityNormal {
+		return Runner.CombinedOutputEnv(dir, env, name, args...)
+	}
+	var output lockedBuffer
+	proc, err := Runner.StartOutputEnv(dir, env, &output, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	SetPriority(proc.OSProcess(), priority)
+	err = proc.Wait()
+	return output.Bytes(), err
+}
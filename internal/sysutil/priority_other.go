@@ -0,0 +1,16 @@
+//go:build !windows
+
+package sysutil
+
+import "syscall"
+
+// niceness 各优先级对应的 nice 值
+var niceness = map[Priority]int{
+	PriorityBelowNormal: 10,
+	PriorityLow:         19,
+}
+
+// setPriority 设置进程的 nice 值
+func setPriority(pid int, priority Priority) error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, pid, niceness[priority])
+}
@@ -0,0 +1,31 @@
+package sysutil
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCombinedOutputPriority(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("使用 sh 测试")
+	}
+
+	// 子进程继承调整后的优先级，用 ps 读取自己的 nice 值
+	output, err := CombinedOutputPriority("", []string{"GVA_TEST=1"}, PriorityBelowNormal, "sh", "-c", "echo $GVA_TEST; sleep 0.2; ps -o ni= -p $$")
+	if err != nil {
+		t.Skipf("ps 不可用: %v %s", err, output)
+	}
+	lines := strings.Fields(string(output))
+	if len(lines) != 2 || lines[0] != "1" || lines[1] != "10" {
+		t.Errorf("output = %q", output)
+	}
+
+	output, err = CombinedOutputPriority("", nil, PriorityNormal, "sh", "-c", "echo ok")
+	if err != nil || strings.TrimSpace(string(output)) != "ok" {
+		t.Errorf("output = %q, err = %v", output, err)
+	}
+	if PriorityLabel(PriorityLow) != "低" || PriorityLabel("unknown") != "正常" {
+		t.Error("PriorityLabel")
+	}
+}
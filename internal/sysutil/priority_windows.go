@@ -0,0 +1,32 @@
+//go:build windows
+
+package sysutil
+
+import "syscall"
+
+const (
+	processSetInformation    = 0x0200 // PROCESS_SET_INFORMATION
+	belowNormalPriorityClass = 0x4000 // BELOW_NORMAL_PRIORITY_CLASS
+	idlePriorityClass        = 0x0040 // IDLE_PRIORITY_CLASS
+)
+
+var procSetPriorityClass = syscall.NewLazyDLL("kernel32.dll").NewProc("SetPriorityClass")
+
+// priorityClasses 各优先级对应的优先级类
+var priorityClasses = map[Priority]uintptr{
+	PriorityBelowNormal: belowNormalPriorityClass,
+	PriorityLow:         idlePriorityClass,
+}
+
+// setPriority 设置进程的优先级类
+func setPriority(pid int, priority Priority) error {
+	h, err := syscall.OpenProcess(processSetInformation, false, uint32(pid))
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(h)
+	if r, _, err := procSetPriorityClass.Call(uintptr(h), priorityClasses[priority]); r == 0 {
+		return err
+	}
+	return nil
+}
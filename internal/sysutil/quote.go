@@ -0,0 +1,13 @@
+package sysutil
+
+import "strings"
+
+// ShellQuote 用单引号包裹 POSIX shell 参数（参数中的 ' 写成 '\''），用于拼接在服务器或本机 sh 中执行的命令
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// XMLEscape 转义 XML 文本中的特殊字符（用于 launchd plist 等配置文件）
+func XMLEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,24 @@
+package sysutil
+
+import "testing"
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"":                  "''",
+		"/opt/gva/log":      "'/opt/gva/log'",
+		"my gva/$HOME":      "'my gva/$HOME'",
+		"/var/log/it's.log": `'/var/log/it'\''s.log'`,
+		"a''b":              `'a'\'''\''b'`,
+	}
+	for in, want := range tests {
+		if got := ShellQuote(in); got != want {
+			t.Errorf("ShellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestXMLEscape(t *testing.T) {
+	if got := XMLEscape("/Users/me/A&B/<gva>"); got != "/Users/me/A&amp;B/&lt;gva&gt;" {
+		t.Errorf("XMLEscape = %s", got)
+	}
+}
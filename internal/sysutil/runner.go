@@ -0,0 +1,154 @@
+package sysutil
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// CommandRunner 外部命令执行器（go/npm/netstat/taskkill 等调用都经由它执行，测试时可替换为假实现）
+type CommandRunner interface {
+	// Run 在 dir 目录中执行命令，只关心是否成功
+	Run(dir string, name string, args ...string) error
+	// Output 在 dir 目录中执行命令并返回标准输出
+	Output(dir string, name string, args ...string) ([]byte, error)
+	// CombinedOutput 在 dir 目录中执行命令并返回标准输出和标准错误
+	CombinedOutput(dir string, name string, args ...string) ([]byte, error)
+	// CombinedOutputEnv 与 CombinedOutput 相同，额外追加环境变量（KEY=VALUE 格式）
+	CombinedOutputEnv(dir string, env []string, name string, args ...string) ([]byte, error)
+	// Start 在 dir 目录中启动长期运行的进程（不等待结束）
+	Start(dir string, name string, args ...string) (Process, error)
+	// StartOutput 与 Start 相同，进程的标准输出和标准错误写入 output
+	StartOutput(dir string, output io.Writer, name string, args ...string) (Process, error)
+	// StartOutputEnv 与 StartOutput 相同，额外追加环境变量（KEY=VALUE 格式）
+	StartOutputEnv(dir string, env []string, output io.Writer, name string, args ...string) (Process, error)
+	// StartDetached 与 Start 相同，但进程脱离面板运行（独立的会话或进程组），标准输出和标准错误追加到 logPath，
+	// 面板退出后进程继续运行
+	StartDetached(dir string, logPath string, name string, args ...string) (Process, error)
+}
+
+// Process 由 CommandRunner.Start 启动的进程
+type Process interface {
+	// OSProcess 返回底层系统进程（假实现可返回 nil）
+	OSProcess() *os.Process
+	// Wait 阻塞到进程结束
+	Wait() error
+}
+
+// Runner 当前使用的命令执行器，默认直接执行系统命令
+var Runner CommandRunner = ExecRunner{}
+
+// ExecRunner 基于 os/exec 的默认实现（Windows 下隐藏控制台窗口）
+type ExecRunner struct{}
+
+// command 创建一个隐藏控制台窗口的命令；项目位于 WSL 中时工具链命令改为通过 wsl.exe 执行
+func (ExecRunner) command(dir string, name string, args ...string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		if wslArgs, ok := wslCommand(dir, name, args); ok {
+			name, args, dir = "wsl.exe", wslArgs, ""
+		}
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	hideWindow(cmd)
+	return cmd
+}
+
+// Run 执行命令，只关心是否成功
+func (r ExecRunner) Run(dir string, name string, args ...string) error {
+	return r.command(dir, name, args...).Run()
+}
+
+// Output 执行命令并返回标准输出
+func (r ExecRunner) Output(dir string, name string, args ...string) ([]byte, error) {
+	return r.command(dir, name, args...).Output()
+}
+
+// CombinedOutput 执行命令并返回标准输出和标准错误
+func (r ExecRunner) CombinedOutput(dir string, name string, args ...string) ([]byte, error) {
+	return r.command(dir, name, args...).CombinedOutput()
+}
+
+// CombinedOutputEnv 在当前环境变量基础上追加 env 后执行命令
+func (r ExecRunner) CombinedOutputEnv(dir string, env []string, name string, args ...string) ([]byte, error) {
+	cmd := r.command(dir, name, args...)
+	if cmd.Args[0] == "wsl.exe" {
+		env = wslEnv(env)
+	}
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.CombinedOutput()
+}
+
+// Start 启动进程（继承当前环境变量）
+func (r ExecRunner) Start(dir string, name string, args ...string) (Process, error) {
+	cmd := r.command(dir, name, args...)
+	cmd.Env = os.Environ()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return execProcess{cmd}, nil
+}
+
+// StartOutput 启动进程，标准输出和标准错误写入 output
+func (r ExecRunner) StartOutput(dir string, output io.Writer, name string, args ...string) (Process, error) {
+	return r.StartOutputEnv(dir, nil, output, name, args...)
+}
+
+// StartOutputEnv 在当前环境变量基础上追加 env 后启动进程，标准输出和标准错误写入 output
+func (r ExecRunner) StartOutputEnv(dir string, env []string, output io.Writer, name string, args ...string) (Process, error) {
+	cmd := r.command(dir, name, args...)
+	if cmd.Args[0] == "wsl.exe" {
+		env = wslEnv(env)
+	}
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return execProcess{cmd}, nil
+}
+
+// StartDetached 启动脱离面板的进程，输出追加到 logPath（面板退出后写入不会因管道关闭而失败）
+func (r ExecRunner) StartDetached(dir string, logPath string, name string, args ...string) (Process, error) {
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	// 子进程持有自己的文件句柄，启动后面板这一侧即可关闭
+	defer logFile.Close()
+
+	cmd := r.command(dir, name, args...)
+	cmd.Env = os.Environ()
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return execProcess{cmd}, nil
+}
+
+// execProcess 包装 *exec.Cmd
+type execProcess struct {
+	cmd *exec.Cmd
+}
+
+// OSProcess 返回底层系统进程
+func (p execProcess) OSProcess() *os.Process {
+	return p.cmd.Process
+}
+
+// Wait 等待进程结束
+func (p execProcess) Wait() error {
+	return p.cmd.Wait()
+}
+
+// ShellCommand 返回通过系统 shell 执行命令行所需的程序和参数（Windows 为 cmd /C，其他为 sh -c）
+func ShellCommand(command string) (string, []string) {
+	if runtime.GOOS == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
@@ -0,0 +1,18 @@
+package sysutil
+
+import "testing"
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KB",
+		5 << 20:         "5.0 MB",
+		3<<30 + 512<<20: "3.5 GB",
+	}
+	for in, want := range tests {
+		if got := FormatSize(in); got != want {
+			t.Errorf("FormatSize(%d) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Package sysutil 提供各个包共用的系统辅助函数（外部命令执行、文件检测）
+package sysutil
+
+import "os"
+
+// FileExists 检查文件是否存在
+func FileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// DirExists 检查目录是否存在
+func DirExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
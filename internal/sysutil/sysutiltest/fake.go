@@ -0,0 +1,181 @@
+// Package sysutiltest 提供测试用的假命令执行器
+package sysutiltest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// Result 一条命令的预设执行结果
+type Result struct {
+	Output string
+	Err    error
+	Exit   error // 进程启动后以错误结束（例如下载中途断网），Err 为 nil 时使用
+}
+
+// Call 一次命令调用记录
+type Call struct {
+	Dir     string
+	Command string   // 命令名和参数以空格连接，例如 "go env GOMODCACHE"
+	Env     []string // CombinedOutputEnv / StartOutputEnv 追加的环境变量
+}
+
+// FakeRunner 按命令行返回预设结果的假执行器，未预设的命令返回错误
+type FakeRunner struct {
+	mu      sync.Mutex
+	results map[string]Result
+	calls   []Call
+}
+
+// New 创建假执行器，并在测试结束前替换 sysutil.Runner
+func New(t *testing.T) *FakeRunner {
+	t.Helper()
+	f := &FakeRunner{results: make(map[string]Result)}
+	original := sysutil.Runner
+	sysutil.Runner = f
+	t.Cleanup(func() { sysutil.Runner = original })
+	return f
+}
+
+// Handle 预设命令的输出和错误
+func (f *FakeRunner) Handle(command string, output string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[command] = Result{Output: output, Err: err}
+}
+
+// HandleExit 预设命令启动成功、输出 output 后以 exit 错误结束（StartOutput 返回的进程 Wait 时返回 exit）
+func (f *FakeRunner) HandleExit(command string, output string, exit error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[command] = Result{Output: output, Exit: exit}
+}
+
+// Calls 返回所有调用记录
+func (f *FakeRunner) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Called 判断某条命令是否被调用过
+func (f *FakeRunner) Called(command string) bool {
+	for _, c := range f.Calls() {
+		if c.Command == command {
+			return true
+		}
+	}
+	return false
+}
+
+// exec 记录调用并查找预设结果
+func (f *FakeRunner) exec(dir string, env []string, name string, args ...string) ([]byte, error) {
+	result, err := f.lookup(dir, env, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err == nil {
+		return []byte(result.Output), result.Exit
+	}
+	return []byte(result.Output), result.Err
+}
+
+// lookup 记录调用并返回预设结果（未预设时返回错误）
+func (f *FakeRunner) lookup(dir string, env []string, name string, args ...string) (Result, error) {
+	command := strings.Join(append([]string{name}, args...), " ")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Dir: dir, Command: command, Env: env})
+	result, ok := f.results[command]
+	if !ok {
+		return Result{}, fmt.Errorf("sysutiltest: 未预设的命令: %s", command)
+	}
+	return result, nil
+}
+
+// Run 实现 sysutil.CommandRunner
+func (f *FakeRunner) Run(dir string, name string, args ...string) error {
+	_, err := f.exec(dir, nil, name, args...)
+	return err
+}
+
+// Output 实现 sysutil.CommandRunner
+func (f *FakeRunner) Output(dir string, name string, args ...string) ([]byte, error) {
+	return f.exec(dir, nil, name, args...)
+}
+
+// CombinedOutput 实现 sysutil.CommandRunner
+func (f *FakeRunner) CombinedOutput(dir string, name string, args ...string) ([]byte, error) {
+	return f.exec(dir, nil, name, args...)
+}
+
+// CombinedOutputEnv 实现 sysutil.CommandRunner（环境变量记录在 Call.Env 中）
+func (f *FakeRunner) CombinedOutputEnv(dir string, env []string, name string, args ...string) ([]byte, error) {
+	return f.exec(dir, env, name, args...)
+}
+
+// Start 实现 sysutil.CommandRunner，预设错误表示启动失败，返回的进程 Wait 时立即结束
+func (f *FakeRunner) Start(dir string, name string, args ...string) (sysutil.Process, error) {
+	result, err := f.lookup(dir, nil, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return fakeProcess{exit: result.Exit}, nil
+}
+
+// StartOutput 实现 sysutil.CommandRunner，预设的输出写入 output，返回的进程 Wait 时立即结束（返回 HandleExit 预设的错误）
+func (f *FakeRunner) StartOutput(dir string, output io.Writer, name string, args ...string) (sysutil.Process, error) {
+	return f.StartOutputEnv(dir, nil, output, name, args...)
+}
+
+// StartOutputEnv 实现 sysutil.CommandRunner（环境变量记录在 Call.Env 中），其余与 StartOutput 相同
+func (f *FakeRunner) StartOutputEnv(dir string, env []string, output io.Writer, name string, args ...string) (sysutil.Process, error) {
+	result, err := f.lookup(dir, env, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	output.Write([]byte(result.Output))
+	return fakeProcess{exit: result.Exit}, nil
+}
+
+// StartDetached 实现 sysutil.CommandRunner，预设的输出追加到 logPath，返回的进程 Wait 时立即结束
+func (f *FakeRunner) StartDetached(dir string, logPath string, name string, args ...string) (sysutil.Process, error) {
+	result, err := f.lookup(dir, nil, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+	logFile.WriteString(result.Output)
+	return fakeProcess{exit: result.Exit}, nil
+}
+
+// fakeProcess 立即结束的假进程
+type fakeProcess struct {
+	exit error
+}
+
+// OSProcess 假进程没有底层系统进程
+func (fakeProcess) OSProcess() *os.Process { return nil }
+
+// Wait 立即返回
+func (p fakeProcess) Wait() error { return p.exit }
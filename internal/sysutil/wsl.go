@@ -0,0 +1,96 @@
+package sysutil
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// WSLDistro 当前项目所在的 WSL 发行版（为空表示项目不在 WSL 中）。
+// 不为空时，不指定目录的工具链命令（例如 go env GOPROXY、npm -v）也在该发行版中执行；
+// 目录位于 \\wsl$ 下的工具链命令总是在对应的发行版中执行
+var WSLDistro string
+
+// wslTools 需要在 WSL 中执行的工具链命令（cmd、taskkill、explorer 等仍在 Windows 中执行）
+var wslTools = []string{"go", "gofmt", "npm", "npx", "node", "pnpm", "yarn"}
+
+// wslPrefixes Windows 访问 WSL 文件系统的路径前缀（\\wsl.localhost 为 Windows 11 的新形式）
+var wslPrefixes = []string{`\\wsl$\`, `\\wsl.localhost\`}
+
+// ParseWSLPath 解析 \\wsl$\<发行版>\... 或 \\wsl.localhost\<发行版>\... 形式的路径（也接受 / 分隔），
+// 返回发行版名称和发行版内的 Linux 路径
+func ParseWSLPath(path string) (distro, linuxPath string, ok bool) {
+	p := strings.ReplaceAll(path, "/", `\`)
+	for _, prefix := range wslPrefixes {
+		if len(p) <= len(prefix) || !strings.EqualFold(p[:len(prefix)], prefix) {
+			continue
+		}
+		rest := p[len(prefix):]
+		distro, linuxPath, _ = strings.Cut(rest, `\`)
+		if distro == "" {
+			return "", "", false
+		}
+		return distro, "/" + strings.ReplaceAll(strings.Trim(linuxPath, `\`), `\`, "/"), true
+	}
+	return "", "", false
+}
+
+// WSLWindowsPath 发行版中的 Linux 绝对路径在 Windows 中的访问路径，例如 /home/dev/go 对应 \\wsl.localhost\Ubuntu\home\dev\go
+func WSLWindowsPath(distro, linuxPath string) string {
+	return `\\wsl.localhost\` + distro + strings.ReplaceAll(linuxPath, "/", `\`)
+}
+
+// wslCommand 工具链命令需要在 WSL 中执行时返回 wsl.exe 的参数：在 dir 对应的 Linux 目录中通过登录 shell 执行
+// （登录 shell 会加载 ~/.profile，nvm、/usr/local/go/bin 等加入 PATH 的工具才能找到）；
+// 参数中同一发行版的 \\wsl$ 路径转换为 Linux 路径
+func wslCommand(dir string, name string, args []string) ([]string, bool) {
+	if !slices.Contains(wslTools, name) {
+		return nil, false
+	}
+	distro, linuxDir, ok := ParseWSLPath(dir)
+	if !ok {
+		if dir != "" || WSLDistro == "" {
+			return nil, false
+		}
+		distro, linuxDir = WSLDistro, "~"
+	}
+
+	wslArgs := []string{"-d", distro, "--cd", linuxDir, "--exec", "bash", "-lc", `exec "$@"`, "bash", name}
+	for _, arg := range args {
+		wslArgs = append(wslArgs, wslArg(distro, arg))
+	}
+	return wslArgs, true
+}
+
+// wslArg 把参数中同一发行版的 \\wsl$ 路径转换为 Linux 路径（包括 -flag=路径 的形式）
+func wslArg(distro, arg string) string {
+	key, value, hasKey := strings.Cut(arg, "=")
+	if !hasKey {
+		value = arg
+	}
+	d, linuxPath, ok := ParseWSLPath(value)
+	if !ok || !strings.EqualFold(d, distro) {
+		return arg
+	}
+	if hasKey {
+		return key + "=" + linuxPath
+	}
+	return linuxPath
+}
+
+// wslEnv 在 WSL 中执行时，面板追加的环境变量需要列入 WSLENV 才会传入发行版
+func wslEnv(env []string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	names := []string{}
+	if existing := os.Getenv("WSLENV"); existing != "" {
+		names = append(names, existing)
+	}
+	for _, kv := range env {
+		if name, _, ok := strings.Cut(kv, "="); ok {
+			names = append(names, name)
+		}
+	}
+	return append(env, "WSLENV="+strings.Join(names, ":"))
+}
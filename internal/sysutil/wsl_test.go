@@ -0,0 +1,80 @@
+package sysutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWSLPath(t *testing.T) {
+	cases := []struct {
+		path, distro, linux string
+		ok                  bool
+	}{
+		{`\\wsl$\Ubuntu\home\dev\gin-vue-admin`, "Ubuntu", "/home/dev/gin-vue-admin", true},
+		{`\\wsl.localhost\Ubuntu-22.04\home\dev\gva\`, "Ubuntu-22.04", "/home/dev/gva", true},
+		{`//WSL$/Debian/srv/gva`, "Debian", "/srv/gva", true},
+		{`\\wsl$\Ubuntu`, "Ubuntu", "/", true},
+		{`\\wsl$\`, "", "", false},
+		{`D:\gin-vue-admin`, "", "", false},
+		{`\\fileserver\share\gva`, "", "", false},
+	}
+	for _, c := range cases {
+		distro, linux, ok := ParseWSLPath(c.path)
+		if distro != c.distro || linux != c.linux || ok != c.ok {
+			t.Errorf("ParseWSLPath(%q) = %q, %q, %v", c.path, distro, linux, ok)
+		}
+	}
+}
+
+func TestWSLWindowsPath(t *testing.T) {
+	got := WSLWindowsPath("Ubuntu", "/home/dev/go/pkg/mod")
+	if got != `\\wsl.localhost\Ubuntu\home\dev\go\pkg\mod` {
+		t.Errorf("WSLWindowsPath = %s", got)
+	}
+	if distro, linux, _ := ParseWSLPath(got); distro != "Ubuntu" || linux != "/home/dev/go/pkg/mod" {
+		t.Errorf("round trip = %s %s", distro, linux)
+	}
+}
+
+func TestWSLCommand(t *testing.T) {
+	args, ok := wslCommand(`\\wsl$\Ubuntu\home\dev\gva\server`, "go", []string{"list", `-modfile=\\wsl$\Ubuntu\home\dev\gva\server\go.mod`, "-m", "all"})
+	want := []string{"-d", "Ubuntu", "--cd", "/home/dev/gva/server", "--exec", "bash", "-lc", `exec "$@"`, "bash",
+		"go", "list", "-modfile=/home/dev/gva/server/go.mod", "-m", "all"}
+	if !ok || !reflect.DeepEqual(args, want) {
+		t.Errorf("wslCommand = %q, %v", args, ok)
+	}
+
+	// 非工具链命令和 Windows 目录不改写
+	if _, ok := wslCommand(`\\wsl$\Ubuntu\home\dev\gva`, "cmd", []string{"/C", "dir"}); ok {
+		t.Error("cmd should run on Windows")
+	}
+	if _, ok := wslCommand(`D:\gva\web`, "npm", []string{"run", "serve"}); ok {
+		t.Error("Windows project should not use WSL")
+	}
+}
+
+func TestWSLCommandDefaultDistro(t *testing.T) {
+	if _, ok := wslCommand("", "npm", []string{"-v"}); ok {
+		t.Error("no default distro: npm -v should run on Windows")
+	}
+
+	WSLDistro = "Ubuntu"
+	defer func() { WSLDistro = "" }()
+	args, ok := wslCommand("", "npm", []string{"-v"})
+	want := []string{"-d", "Ubuntu", "--cd", "~", "--exec", "bash", "-lc", `exec "$@"`, "bash", "npm", "-v"}
+	if !ok || !reflect.DeepEqual(args, want) {
+		t.Errorf("wslCommand = %q, %v", args, ok)
+	}
+}
+
+func TestWSLEnv(t *testing.T) {
+	t.Setenv("WSLENV", "USERPROFILE/p")
+	got := wslEnv([]string{"GOPROXY=https://goproxy.cn", "GOFLAGS=-mod=mod"})
+	want := []string{"GOPROXY=https://goproxy.cn", "GOFLAGS=-mod=mod", "WSLENV=USERPROFILE/p:GOPROXY:GOFLAGS"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wslEnv = %q", got)
+	}
+	if wslEnv(nil) != nil {
+		t.Error("no env: WSLENV not needed")
+	}
+}
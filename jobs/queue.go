@@ -0,0 +1,584 @@
+// Package jobs 是面板内部的后台任务队列：任务按提交顺序逐个执行，输出写入任务日志文件
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gva-launcher/eta"
+	"gva-launcher/events"
+)
+
+// Status 任务状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 排队中
+	StatusRunning   Status = "running"   // 执行中
+	StatusSucceeded Status = "succeeded" // 成功
+	StatusFailed    Status = "failed"    // 失败
+	StatusCanceled  Status = "canceled"  // 已取消
+	StatusPaused    Status = "paused"    // 已暂停（可继续执行）
+)
+
+// Label 状态的中文说明
+func (s Status) Label() string {
+	switch s {
+	case StatusPending:
+		return "排队中"
+	case StatusRunning:
+		return "执行中"
+	case StatusSucceeded:
+		return "成功"
+	case StatusFailed:
+		return "失败"
+	case StatusCanceled:
+		return "已取消"
+	case StatusPaused:
+		return "已暂停"
+	default:
+		return string(s)
+	}
+}
+
+// Finished 任务是否已结束（成功、失败或取消；已暂停的任务未结束）
+func (s Status) Finished() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCanceled
+}
+
+// ErrCanceled 任务被用户取消
+var ErrCanceled = errors.New("任务已取消")
+
+// Func 任务函数（通过 j.Logf/j.Write 输出日志）
+type Func func(ctx context.Context, j *Job) error
+
+// Job 一个后台任务
+type Job struct {
+	ID   int
+	Name string
+
+	mu         sync.Mutex
+	status     Status
+	err        error
+	output     strings.Builder
+	progress   float64 // 0~1，小于 0 表示无法估计进度
+	count      float64 // 已处理的数量（用于计算速度，例如已安装的包）
+	unit       string  // count 的单位，为空表示没有计数
+	createdAt  time.Time
+	startedAt  time.Time
+	finishedAt time.Time
+	done       chan struct{}
+	cancel     context.CancelFunc // 执行中任务的取消函数
+	canceled   bool               // 用户请求了取消
+	pausable   bool               // 可以暂停（SubmitPausable 提交）
+	pausing    bool               // 用户请求了暂停
+	direct     bool               // 不排队、立即执行（Go 提交）
+
+	fn    Func
+	queue *Queue
+}
+
+// Status 当前状态
+func (j *Job) Status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// Err 任务失败时的错误
+func (j *Job) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Output 任务输出
+func (j *Job) Output() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.output.String()
+}
+
+// Times 返回创建、开始和结束时间（未开始/未结束时为零值）
+func (j *Job) Times() (created, started, finished time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.createdAt, j.startedAt, j.finishedAt
+}
+
+// Progress 当前进度（0~1，小于 0 表示无法估计）
+func (j *Job) Progress() float64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.progress
+}
+
+// SetProgress 更新进度（由任务函数调用，超出 0~1 的值会被截断）
+func (j *Job) SetProgress(p float64) {
+	if p > 1 {
+		p = 1
+	}
+	j.mu.Lock()
+	j.progress = p
+	j.mu.Unlock()
+	j.queue.publish(j)
+}
+
+// SetCount 更新已处理的数量（由任务函数调用，界面据此显示速度，例如 12.5 个包/秒）
+func (j *Job) SetCount(count float64, unit string) {
+	j.mu.Lock()
+	j.count, j.unit = count, unit
+	j.mu.Unlock()
+}
+
+// Count 已处理的数量和单位（没有计数时单位为空）
+func (j *Job) Count() (float64, string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.count, j.unit
+}
+
+// Cancel 取消任务：排队中的任务直接标记为已取消，执行中的任务通过 ctx 通知尽快结束
+// 任务已结束时返回 false
+func (j *Job) Cancel() bool {
+	j.mu.Lock()
+	switch j.status {
+	case StatusPending, StatusPaused:
+		j.mu.Unlock()
+		return j.finish(StatusCanceled, ErrCanceled, "已取消\n")
+	case StatusRunning:
+		j.canceled = true
+		cancel := j.cancel
+		j.mu.Unlock()
+		if cancel != nil {
+			cancel()
+		}
+		j.queue.log(j, "请求取消\n")
+		return true
+	default:
+		j.mu.Unlock()
+		return false
+	}
+}
+
+// Pausable 任务是否可以暂停
+func (j *Job) Pausable() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.pausable
+}
+
+// Pause 暂停执行中的任务：通过 ctx 通知任务尽快结束，任务函数因此返回错误时标记为已暂停，
+// Resume 后重新执行任务函数（任务函数需要能利用已完成的部分，例如下载依赖时已下载的缓存）
+// 任务不可暂停或不在执行中时返回 false
+func (j *Job) Pause() bool {
+	j.mu.Lock()
+	if !j.pausable || j.status != StatusRunning || j.canceled {
+		j.mu.Unlock()
+		return false
+	}
+	j.pausing = true
+	cancel := j.cancel
+	j.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	j.queue.log(j, "请求暂停\n")
+	return true
+}
+
+// Resume 继续执行已暂停的任务（重新排队），任务未暂停时返回 false
+func (j *Job) Resume() bool {
+	j.mu.Lock()
+	if j.status != StatusPaused {
+		j.mu.Unlock()
+		return false
+	}
+	j.status = StatusPending
+	j.mu.Unlock()
+
+	j.queue.log(j, "继续执行\n")
+	j.queue.publish(j)
+	j.queue.enqueue(j)
+	return true
+}
+
+// pause 把执行中的任务标记为已暂停
+func (j *Job) pause() {
+	j.mu.Lock()
+	j.status = StatusPaused
+	j.pausing = false
+	j.cancel = nil
+	j.mu.Unlock()
+
+	j.queue.log(j, "已暂停\n")
+	j.queue.publish(j)
+}
+
+// Done 任务结束（成功、失败或取消）时关闭的通道
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait 阻塞到任务结束并返回错误
+func (j *Job) Wait() error {
+	<-j.done
+	return j.Err()
+}
+
+// Write 追加任务输出（实现 io.Writer，同时写入日志文件），并发布 JobOutput 事件
+func (j *Job) Write(p []byte) (int, error) {
+	j.mu.Lock()
+	j.output.Write(p)
+	j.mu.Unlock()
+	j.queue.log(j, string(p))
+	j.queue.publishTopic(events.JobOutput, j)
+	return len(p), nil
+}
+
+// Logf 追加一行任务输出
+func (j *Job) Logf(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+	j.Write([]byte(line))
+}
+
+// start 把排队中的任务标记为执行中并返回任务自己的 ctx（任务已取消时返回 false）
+func (j *Job) start(ctx context.Context) (context.Context, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != StatusPending {
+		return nil, false
+	}
+	jobCtx, cancel := context.WithCancel(ctx)
+	j.status = StatusRunning
+	j.startedAt = time.Now()
+	j.cancel = cancel
+	return jobCtx, true
+}
+
+// finish 结束任务并写入日志（任务已结束时不做修改，返回 false）
+func (j *Job) finish(status Status, err error, logText string) bool {
+	j.mu.Lock()
+	if j.status.Finished() {
+		j.mu.Unlock()
+		return false
+	}
+	j.status = status
+	j.err = err
+	j.finishedAt = time.Now()
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.mu.Unlock()
+
+	j.queue.log(j, logText)
+	j.queue.publish(j)
+	close(j.done)
+	return true
+}
+
+// Queue 任务队列（单个工作协程按顺序执行）
+type Queue struct {
+	logPath string
+
+	// Events 任务状态和进度变化时发布 JobProgress 事件（可为 nil）
+	Events *events.Bus
+
+	// Durations 记录成功任务的耗时，用于估计同名任务的剩余时间（可为 nil）
+	Durations *eta.History
+	// Scope 当前项目的根目录（耗时按项目分别记录，为 nil 时不区分项目）
+	Scope func() string
+
+	mu      sync.Mutex
+	jobs    []*Job
+	nextID  int
+	ctx     context.Context // Run 的 ctx（Go 提交的任务使用）
+	pending []*Job          // 排队中的任务（按提交顺序，不限数量，提交时不会阻塞）
+	wake    chan struct{}   // 有任务加入队列时通知 Run
+	paused  bool
+	resumed chan struct{} // 暂停期间创建，Resume 时关闭
+	logMu   sync.Mutex
+}
+
+// NewQueue 创建任务队列（logDir 为空时不写日志文件），需调用 Run 开始执行任务
+func NewQueue(logDir string) *Queue {
+	q := &Queue{wake: make(chan struct{}, 1)}
+	if logDir != "" {
+		if err := os.MkdirAll(logDir, 0755); err == nil {
+			q.logPath = filepath.Join(logDir, "jobs.log")
+		}
+	}
+	return q
+}
+
+// Typical 同名任务在当前项目中平时的耗时，没有记录时返回 false
+func (q *Queue) Typical(name string) (time.Duration, bool) {
+	return q.Durations.Typical(eta.Key(q.scope(), name))
+}
+
+// scope 当前项目
+func (q *Queue) scope() string {
+	if q.Scope == nil {
+		return ""
+	}
+	return q.Scope()
+}
+
+// LogPath 任务日志文件路径（未启用时为空）
+func (q *Queue) LogPath() string {
+	return q.logPath
+}
+
+// Submit 提交任务，立即返回
+func (q *Queue) Submit(name string, fn Func) *Job {
+	return q.submit(name, fn, false, false)
+}
+
+// SubmitPausable 提交可以暂停的任务（见 Job.Pause），例如网络不稳定时的大量下载
+func (q *Queue) SubmitPausable(name string, fn Func) *Job {
+	return q.submit(name, fn, true, false)
+}
+
+// Go 提交任务并立即在单独的协程中执行：不排队、不受队列暂停影响，仍在任务中心显示并写入日志。
+// 用于不能被前面的长任务（依赖安装、构建）耽误的短任务，例如启动服务前的钩子
+func (q *Queue) Go(name string, fn Func) *Job {
+	return q.submit(name, fn, false, true)
+}
+
+// submit 创建任务并加入队列（direct 为 true 时立即执行）
+func (q *Queue) submit(name string, fn Func, pausable, direct bool) *Job {
+	q.mu.Lock()
+	q.nextID++
+	j := &Job{
+		ID:        q.nextID,
+		Name:      name,
+		status:    StatusPending,
+		progress:  -1,
+		createdAt: time.Now(),
+		done:      make(chan struct{}),
+		pausable:  pausable,
+		direct:    direct,
+		fn:        fn,
+		queue:     q,
+	}
+	q.jobs = append(q.jobs, j)
+	q.mu.Unlock()
+	q.publish(j)
+
+	q.enqueue(j)
+	return j
+}
+
+// enqueue 把任务加入队列（不阻塞），Go 提交的任务在单独的协程中立即执行
+func (q *Queue) enqueue(j *Job) {
+	q.mu.Lock()
+	if !j.direct {
+		q.pending = append(q.pending, j)
+		q.mu.Unlock()
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+		return
+	}
+	ctx := q.ctx
+	q.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go q.run(ctx, j)
+}
+
+// next 取出最早排队的任务（队列为空时返回 nil）
+func (q *Queue) next() *Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		return nil
+	}
+	j := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+	return j
+}
+
+// Jobs 返回所有任务（按提交顺序）
+func (q *Queue) Jobs() []*Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]*Job(nil), q.jobs...)
+}
+
+// Retry 以相同的名称和任务函数重新提交已结束的任务（任务未结束时返回 nil）
+func (q *Queue) Retry(j *Job) *Job {
+	if !j.Status().Finished() {
+		return nil
+	}
+	return q.submit(j.Name, j.fn, j.Pausable(), j.direct)
+}
+
+// Pause 暂停队列：正在执行的任务继续完成，之后的任务等到 Resume 再执行（Go 提交的任务不受影响）
+func (q *Queue) Pause() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.paused {
+		q.paused = true
+		q.resumed = make(chan struct{})
+	}
+}
+
+// Resume 恢复执行暂停的队列
+func (q *Queue) Resume() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.paused {
+		q.paused = false
+		close(q.resumed)
+	}
+}
+
+// Paused 队列是否已暂停
+func (q *Queue) Paused() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.paused
+}
+
+// waitResumed 队列暂停时阻塞到恢复，ctx 取消时返回 false
+func (q *Queue) waitResumed(ctx context.Context) bool {
+	q.mu.Lock()
+	paused, resumed := q.paused, q.resumed
+	q.mu.Unlock()
+	if !paused {
+		return true
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-resumed:
+		return true
+	}
+}
+
+// Run 逐个执行排队中的任务，直到 ctx 取消（调用方在后台协程中运行）
+// ctx 取消后，尚未执行的任务标记为失败，正在执行的任务通过 ctx 得知需要尽快结束
+func (q *Queue) Run(ctx context.Context) {
+	q.mu.Lock()
+	q.ctx = ctx
+	q.mu.Unlock()
+	for {
+		if ctx.Err() != nil {
+			q.drain()
+			return
+		}
+		j := q.next()
+		if j == nil {
+			select {
+			case <-ctx.Done():
+				q.drain()
+				return
+			case <-q.wake:
+			}
+			continue
+		}
+		if !q.waitResumed(ctx) {
+			j.finish(StatusFailed, fmt.Errorf("面板已关闭，任务未执行"), "面板已关闭，任务未执行\n")
+			q.drain()
+			return
+		}
+		q.run(ctx, j)
+	}
+}
+
+// drain 把排队中的任务标记为失败（面板关闭时调用）
+func (q *Queue) drain() {
+	for j := q.next(); j != nil; j = q.next() {
+		j.finish(StatusFailed, fmt.Errorf("面板已关闭，任务未执行"), "面板已关闭，任务未执行\n")
+	}
+}
+
+// run 执行单个任务（捕获 panic，避免拖垮整个队列；排队期间已取消的任务直接跳过）
+func (q *Queue) run(ctx context.Context, j *Job) {
+	jobCtx, ok := j.start(ctx)
+	if !ok {
+		return
+	}
+	q.log(j, "开始执行\n")
+	q.publish(j)
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("任务崩溃: %v", r)
+			}
+		}()
+		err = j.fn(jobCtx, j)
+	}()
+
+	j.mu.Lock()
+	canceled, pausing := j.canceled, j.pausing
+	j.mu.Unlock()
+
+	switch {
+	case canceled:
+		j.finish(StatusCanceled, ErrCanceled, "已取消\n")
+	case pausing && err != nil:
+		j.pause()
+	case err != nil:
+		j.finish(StatusFailed, err, fmt.Sprintf("执行失败: %v\n", err))
+	default:
+		if j.finish(StatusSucceeded, nil, "执行成功\n") {
+			_, started, finished := j.Times()
+			q.Durations.Record(eta.Key(q.scope(), j.Name), finished.Sub(started))
+		}
+	}
+}
+
+// publish 发布任务的当前状态
+func (q *Queue) publish(j *Job) {
+	q.publishTopic(events.JobProgress, j)
+}
+
+// publishTopic 以指定的事件类型发布任务的当前状态
+func (q *Queue) publishTopic(topic events.Topic, j *Job) {
+	if q.Events == nil {
+		return
+	}
+	q.Events.Publish(events.Event{Topic: topic, Job: events.JobState{
+		ID:       j.ID,
+		Name:     j.Name,
+		Status:   string(j.Status()),
+		Progress: j.Progress(),
+	}})
+}
+
+// log 以 "时间 [#ID 名称] 内容" 的格式追加到日志文件
+func (q *Queue) log(j *Job, text string) {
+	if q.logPath == "" {
+		return
+	}
+
+	q.logMu.Lock()
+	defer q.logMu.Unlock()
+
+	file, err := os.OpenFile(q.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	prefix := fmt.Sprintf("%s [#%d %s] ", time.Now().Format("2006-01-02 15:04:05"), j.ID, j.Name)
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		fmt.Fprintln(file, prefix+line)
+	}
+}
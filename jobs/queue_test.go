@@ -0,0 +1,399 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gva-launcher/eta"
+	"gva-launcher/events"
+)
+
+func TestQueueRunsInOrder(t *testing.T) {
+	q := startQueue(t, t.TempDir())
+
+	var order []int
+	var last *Job
+	for i := 1; i <= 3; i++ {
+		n := i
+		last = q.Submit("任务", func(ctx context.Context, j *Job) error {
+			order = append(order, n)
+			j.Logf("第 %d 个", n)
+			return nil
+		})
+	}
+	if err := last.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(order) != 3 || order[0] != 1 || order[2] != 3 {
+		t.Errorf("执行顺序 = %v", order)
+	}
+	if last.Status() != StatusSucceeded || last.Output() != "第 3 个\n" {
+		t.Errorf("status = %s, output = %q", last.Status(), last.Output())
+	}
+
+	data, err := os.ReadFile(q.LogPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "[#3 任务] 第 3 个") {
+		t.Errorf("日志内容不正确:\n%s", data)
+	}
+}
+
+func TestQueueFailureAndPanic(t *testing.T) {
+	q := startQueue(t, "")
+
+	failed := q.Submit("失败", func(ctx context.Context, j *Job) error {
+		return errors.New("boom")
+	})
+	panicked := q.Submit("崩溃", func(ctx context.Context, j *Job) error {
+		panic("oops")
+	})
+	after := q.Submit("之后", func(ctx context.Context, j *Job) error {
+		return nil
+	})
+
+	if err := failed.Wait(); err == nil || failed.Status() != StatusFailed {
+		t.Errorf("失败任务: status = %s, err = %v", failed.Status(), err)
+	}
+	if err := panicked.Wait(); err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Errorf("崩溃任务应返回错误, err = %v", err)
+	}
+	if err := after.Wait(); err != nil {
+		t.Errorf("崩溃后队列应继续执行, err = %v", err)
+	}
+	if len(q.Jobs()) != 3 {
+		t.Errorf("任务数 = %d", len(q.Jobs()))
+	}
+}
+
+// startQueue 创建队列并在后台执行，测试结束时停止
+func startQueue(t *testing.T, logDir string) *Queue {
+	q := NewQueue(logDir)
+	ctx, cancel := context.WithCancel(context.Background())
+	go q.Run(ctx)
+	t.Cleanup(cancel)
+	return q
+}
+
+func TestQueuePublishesEvents(t *testing.T) {
+	q := NewQueue("")
+	q.Events = events.New()
+	var mu sync.Mutex
+	var states []events.JobState
+	q.Events.Subscribe(func(e events.Event) {
+		mu.Lock()
+		states = append(states, e.Job)
+		mu.Unlock()
+	}, events.JobProgress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go q.Run(ctx)
+
+	j := q.Submit("进度", func(ctx context.Context, j *Job) error {
+		j.SetProgress(0.5)
+		return nil
+	})
+	if err := j.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var got []string
+	for _, s := range states {
+		if s.ID != j.ID || s.Name != "进度" {
+			t.Errorf("事件中的任务不正确: %+v", s)
+		}
+		got = append(got, s.Status)
+	}
+	want := []string{"pending", "running", "running", "succeeded"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("状态序列 = %v, want %v", got, want)
+	}
+	if states[2].Progress != 0.5 {
+		t.Errorf("进度 = %v, want 0.5", states[2].Progress)
+	}
+}
+
+func TestQueuePublishesOutput(t *testing.T) {
+	q := NewQueue("")
+	q.Events = events.New()
+	var mu sync.Mutex
+	var ids []int
+	q.Events.Subscribe(func(e events.Event) {
+		mu.Lock()
+		ids = append(ids, e.Job.ID)
+		mu.Unlock()
+	}, events.JobOutput)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go q.Run(ctx)
+
+	j := q.Submit("输出", func(ctx context.Context, j *Job) error {
+		j.Logf("第一行")
+		j.Logf("第二行")
+		return nil
+	})
+	if err := j.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ids) != 2 || ids[0] != j.ID || ids[1] != j.ID {
+		t.Errorf("每次输出应发布一次 JobOutput 事件, got %v", ids)
+	}
+}
+
+func TestQueueDrainOnCancel(t *testing.T) {
+	q := NewQueue("")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	j := q.Submit("未执行", func(ctx context.Context, j *Job) error { return nil })
+	q.Run(ctx)
+	if err := j.Wait(); err == nil || j.Status() != StatusFailed {
+		t.Errorf("关闭后排队中的任务应标记为失败, status = %s", j.Status())
+	}
+}
+
+func TestCancelPendingAndRunning(t *testing.T) {
+	q := startQueue(t, "")
+
+	started := make(chan struct{})
+	running := q.Submit("执行中", func(ctx context.Context, j *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	pending := q.Submit("排队中", func(ctx context.Context, j *Job) error {
+		t.Error("已取消的任务不应执行")
+		return nil
+	})
+
+	<-started
+	if !pending.Cancel() {
+		t.Fatal("排队中的任务应可以取消")
+	}
+	if err := pending.Wait(); !errors.Is(err, ErrCanceled) || pending.Status() != StatusCanceled {
+		t.Errorf("排队中任务: status = %s, err = %v", pending.Status(), err)
+	}
+
+	running.Cancel()
+	if err := running.Wait(); !errors.Is(err, ErrCanceled) || running.Status() != StatusCanceled {
+		t.Errorf("执行中任务: status = %s, err = %v", running.Status(), err)
+	}
+	if running.Cancel() {
+		t.Error("已结束的任务不应再取消")
+	}
+}
+
+func TestRetryAndProgress(t *testing.T) {
+	q := startQueue(t, "")
+
+	attempts := 0
+	first := q.Submit("重试", func(ctx context.Context, j *Job) error {
+		attempts++
+		j.SetProgress(1.5)
+		if attempts == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err := first.Wait(); err == nil {
+		t.Fatal("第一次应失败")
+	}
+	if first.Progress() != 1 {
+		t.Errorf("进度应截断为 1, got %v", first.Progress())
+	}
+
+	second := q.Retry(first)
+	if second == nil || second.Name != "重试" {
+		t.Fatal("Retry 应重新提交同名任务")
+	}
+	if err := second.Wait(); err != nil {
+		t.Errorf("重试应成功, err = %v", err)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	busy := q.Submit("阻塞", func(ctx context.Context, j *Job) error { <-block; return nil })
+	if q.Retry(busy) != nil {
+		t.Error("未结束的任务不应重试")
+	}
+}
+
+func TestSubmitDoesNotBlock(t *testing.T) {
+	q := NewQueue("")
+	submitted := make(chan *Job)
+	go func() {
+		var last *Job
+		for i := 0; i < 1000; i++ {
+			last = q.Submit("任务", func(ctx context.Context, j *Job) error { return nil })
+		}
+		submitted <- last
+	}()
+	var last *Job
+	select {
+	case last = <-submitted:
+	case <-time.After(time.Second):
+		t.Fatal("队列没有执行时提交大量任务也不应阻塞")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	if err := last.Wait(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	q := startQueue(t, "")
+	q.Pause()
+	if !q.Paused() {
+		t.Fatal("Paused 应为 true")
+	}
+
+	j := q.Submit("暂停中", func(ctx context.Context, j *Job) error { return nil })
+	select {
+	case <-j.Done():
+		t.Fatal("暂停时不应执行任务")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	q.Resume()
+	if err := j.Wait(); err != nil || j.Status() != StatusSucceeded {
+		t.Errorf("恢复后应执行任务, status = %s", j.Status())
+	}
+}
+
+func TestGoSkipsQueue(t *testing.T) {
+	q := startQueue(t, "")
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	long := q.Submit("长任务", func(ctx context.Context, j *Job) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	q.Pause()
+
+	j := q.Go("钩子", func(ctx context.Context, j *Job) error { return nil })
+	select {
+	case <-j.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Go 提交的任务不应等待前面的任务和队列暂停")
+	}
+	if j.Status() != StatusSucceeded || long.Status() != StatusRunning {
+		t.Errorf("status = %s, long = %s", j.Status(), long.Status())
+	}
+	if retried := q.Retry(j); retried == nil || retried.Wait() != nil {
+		t.Error("重试时同样立即执行")
+	}
+}
+
+func TestPauseAndResumeJob(t *testing.T) {
+	q := startQueue(t, "")
+
+	plain := q.Submit("普通", func(ctx context.Context, j *Job) error { return nil })
+	plain.Wait()
+	if plain.Pause() {
+		t.Error("普通任务不应可以暂停")
+	}
+
+	started := make(chan struct{}, 2)
+	runs := 0
+	j := q.SubmitPausable("下载", func(ctx context.Context, j *Job) error {
+		runs++
+		if runs > 1 {
+			return nil
+		}
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	if !j.Pause() {
+		t.Fatal("执行中的可暂停任务应可以暂停")
+	}
+	deadline := time.Now().Add(time.Second)
+	for j.Status() != StatusPaused && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if j.Status() != StatusPaused || j.Status().Finished() {
+		t.Fatalf("status = %s, want paused", j.Status())
+	}
+
+	if !j.Resume() {
+		t.Fatal("已暂停的任务应可以继续")
+	}
+	if err := j.Wait(); err != nil || j.Status() != StatusSucceeded || runs != 2 {
+		t.Errorf("继续后应重新执行任务, status = %s, runs = %d, err = %v", j.Status(), runs, err)
+	}
+	if j.Resume() {
+		t.Error("已结束的任务不应继续")
+	}
+	if retry := q.Retry(j); retry == nil || !retry.Pausable() {
+		t.Error("重试的任务应保留可暂停")
+	}
+}
+
+func TestCancelPausedJob(t *testing.T) {
+	q := startQueue(t, "")
+
+	started := make(chan struct{})
+	j := q.SubmitPausable("下载", func(ctx context.Context, j *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	j.Pause()
+	deadline := time.Now().Add(time.Second)
+	for j.Status() != StatusPaused && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !j.Cancel() {
+		t.Fatal("已暂停的任务应可以取消")
+	}
+	if err := j.Wait(); !errors.Is(err, ErrCanceled) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestQueueRecordsDurations(t *testing.T) {
+	q := startQueue(t, "")
+	q.Durations = eta.NewHistory("")
+	q.Scope = func() string { return "/srv/gva" }
+
+	q.Submit("安装依赖", func(ctx context.Context, j *Job) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	q.Submit("安装依赖", func(ctx context.Context, j *Job) error {
+		return errors.New("失败")
+	})
+	// 队列按顺序执行，最后一个任务结束时前面任务的耗时已记录
+	q.Submit("等待", func(ctx context.Context, j *Job) error { return nil }).Wait()
+
+	got, ok := q.Typical("安装依赖")
+	if !ok || got < 20*time.Millisecond {
+		t.Errorf("Typical = %v, %v", got, ok)
+	}
+	q.Scope = func() string { return "/srv/other" }
+	if _, ok := q.Typical("安装依赖"); ok {
+		t.Error("其他项目不应有记录")
+	}
+}
@@ -0,0 +1,484 @@
+// Package kube 根据项目的端口、镜像名称和环境设置生成 Kubernetes 清单（Deployment、Service、Ingress）
+// 或 Helm 的 values.yaml，作为部署到集群的起点。生成的内容按上游 GVA 的 Dockerfile 约定：
+// 后端镜像在 ServerWorkDir 中以 config.docker.yaml 运行，前端镜像中的 nginx 监听 DefaultWebPort
+package kube
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 生成的资源名称
+const (
+	ServerName   = "gva-server"
+	WebName      = "gva-web"
+	ConfigSecret = "gva-server-config" // 保存后端 config.yaml 的 Secret
+)
+
+// ServerWorkDir 上游后端镜像的工作目录（启动命令为 ./server -c config.docker.yaml）
+const ServerWorkDir = "/go/src/github.com/flipped-aurora/gin-vue-admin/server"
+
+// DefaultWebPort 上游前端镜像中 nginx 监听的端口
+const DefaultWebPort = 8080
+
+// EnvVar 后端容器的一个环境变量
+type EnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// Spec 生成清单需要的设置
+type Spec struct {
+	Namespace    string   // 命名空间（为空时不写入，使用 kubectl 的当前命名空间）
+	Host         string   // Ingress 的域名，例如 admin.example.com（为空时不生成 Ingress）
+	TLSSecret    string   // Ingress 使用的证书 Secret（为空时不配置 TLS）
+	ServerImage  string   // 后端镜像，例如 registry.cn-hangzhou.aliyuncs.com/team/gva-server:v1
+	WebImage     string   // 前端镜像
+	ServerPort   int      // 后端容器端口（config.yaml 的 system.addr）
+	WebPort      int      // 前端容器端口（0 表示 DefaultWebPort）
+	Replicas     int      // 副本数（0 表示 1）
+	APIPrefix    string   // 浏览器请求接口的前缀（.env.production 的 VITE_BASE_API，例如 /api；完整地址时不由 Ingress 转发）
+	RouterPrefix string   // 后端的路由前缀（config.yaml 的 system.router-prefix）
+	Env          []EnvVar // 后端容器的环境变量
+}
+
+// dnsLabel Kubernetes 资源名称和命名空间允许的格式（RFC 1123）
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// hostPattern Ingress 域名允许的格式（可以以 *. 开头）
+var hostPattern = regexp.MustCompile(`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// envName 环境变量名称允许的字符
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// Validate 检查设置
+func (s Spec) Validate() error {
+	switch {
+	case s.Namespace != "" && (len(s.Namespace) > 63 || !dnsLabel.MatchString(s.Namespace)):
+		return fmt.Errorf("命名空间只能包含小写字母、数字和 -，且以字母或数字开头和结尾")
+	case s.Host != "" && !hostPattern.MatchString(s.Host):
+		return fmt.Errorf("域名无效: %s（只填写域名，不要带 http:// 和路径）", s.Host)
+	case s.TLSSecret != "" && !dnsLabel.MatchString(s.TLSSecret):
+		return fmt.Errorf("证书 Secret 名称无效: %s", s.TLSSecret)
+	case strings.TrimSpace(s.ServerImage) == "" || strings.TrimSpace(s.WebImage) == "":
+		return fmt.Errorf("请填写前后端的镜像")
+	case s.ServerPort <= 0 || s.ServerPort > 65535:
+		return fmt.Errorf("没有读取到后端端口（config.yaml 的 system.addr）")
+	case s.Replicas < 0:
+		return fmt.Errorf("副本数不能为负数")
+	}
+	for _, e := range s.Env {
+		if !envName.MatchString(e.Name) {
+			return fmt.Errorf("环境变量名称无效: %s", e.Name)
+		}
+	}
+	return nil
+}
+
+// ParseEnv 解析每行一个 KEY=VALUE 的环境变量（忽略空行和 # 开头的注释），按出现顺序返回
+func ParseEnv(text string) ([]EnvVar, error) {
+	var env []EnvVar
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !envName.MatchString(name) {
+			return nil, fmt.Errorf("第 %d 行不是 KEY=VALUE 格式: %s", i+1, line)
+		}
+		env = append(env, EnvVar{Name: name, Value: strings.TrimSpace(value)})
+	}
+	return env, nil
+}
+
+// FormatEnv ParseEnv 的逆操作
+func FormatEnv(env []EnvVar) string {
+	var lines []string
+	for _, e := range env {
+		lines = append(lines, e.Name+"="+e.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// webPort 前端容器端口
+func (s Spec) webPort() int {
+	if s.WebPort > 0 {
+		return s.WebPort
+	}
+	return DefaultWebPort
+}
+
+// replicas 副本数
+func (s Spec) replicas() int {
+	if s.Replicas > 0 {
+		return s.Replicas
+	}
+	return 1
+}
+
+// healthPath 后端健康检查路径（GVA 在路由前缀下注册 /health）
+func (s Spec) healthPath() string {
+	return "/" + strings.Trim(strings.Trim(s.RouterPrefix, "/")+"/health", "/")
+}
+
+// apiRoute Ingress 中转发到后端的路径；接口前缀为完整地址（接口使用单独的域名）或与站点相同时返回空。
+// 接口前缀与后端路由前缀不同时（上游默认 /api 与空），需要 ingress-nginx 的 rewrite-target 去掉前缀，rewrite 返回改写后的目标
+func (s Spec) apiRoute() (path string, rewrite string) {
+	prefix := "/" + strings.Trim(s.APIPrefix, "/")
+	if strings.Contains(s.APIPrefix, "://") || prefix == "/" {
+		return "", ""
+	}
+	if router := "/" + strings.Trim(s.RouterPrefix, "/"); router == prefix {
+		return prefix, ""
+	}
+	rewrite = "/" + strings.Trim(strings.Trim(s.RouterPrefix, "/")+"/$2", "/")
+	return prefix + "(/|$)(.*)", rewrite
+}
+
+// Manifests 生成多文档的 Kubernetes 清单：后端和前端各一个 Deployment 与 Service，填写了域名时再加一个 Ingress。
+// 后端的 config.yaml 从 ConfigSecret 挂载，需要先用开头注释中的 kubectl 命令创建
+func Manifests(s Spec) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteString("# 由 GVAPanel 生成的 Kubernetes 清单，作为部署到集群的起点，请按需修改\n")
+	b.WriteString("# 部署前先用项目的后端配置创建 Secret（数据库地址等需改为集群中可访问的地址）:\n")
+	fmt.Fprintf(&b, "#   kubectl create secret generic %s%s --from-file=config.yaml=server/config.yaml\n", ConfigSecret, namespaceFlag(s.Namespace))
+	fmt.Fprintf(&b, "#   kubectl apply%s -f 本文件\n", namespaceFlag(s.Namespace))
+
+	docs := []any{
+		s.deployment(ServerName, s.ServerImage, s.ServerPort, true),
+		s.service(ServerName, s.ServerPort),
+		s.deployment(WebName, s.WebImage, s.webPort(), false),
+		s.service(WebName, s.webPort()),
+	}
+	if s.Host != "" {
+		for _, ingress := range s.ingresses() {
+			docs = append(docs, ingress)
+		}
+	}
+	for _, doc := range docs {
+		data, err := marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("---\n")
+		b.Write(data)
+	}
+	return b.Bytes(), nil
+}
+
+// namespaceFlag kubectl 的 -n 参数
+func namespaceFlag(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	return " -n " + namespace
+}
+
+// 以下结构只包含生成清单用到的字段，字段顺序即输出顺序
+
+type object struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   metadata `yaml:"metadata"`
+	Spec       any      `yaml:"spec"`
+}
+
+type metadata struct {
+	Name        string            `yaml:"name,omitempty"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type deploymentSpec struct {
+	Replicas int         `yaml:"replicas"`
+	Selector selector    `yaml:"selector"`
+	Template podTemplate `yaml:"template"`
+}
+
+type selector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type podTemplate struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     podSpec  `yaml:"spec"`
+}
+
+type podSpec struct {
+	Containers []containerSpec `yaml:"containers"`
+	Volumes    []volume        `yaml:"volumes,omitempty"`
+}
+
+type containerSpec struct {
+	Name           string        `yaml:"name"`
+	Image          string        `yaml:"image"`
+	Ports          []portSpec    `yaml:"ports"`
+	Env            []EnvVar      `yaml:"env,omitempty"`
+	VolumeMounts   []volumeMount `yaml:"volumeMounts,omitempty"`
+	ReadinessProbe *probe        `yaml:"readinessProbe,omitempty"`
+	LivenessProbe  *probe        `yaml:"livenessProbe,omitempty"`
+}
+
+type portSpec struct {
+	Name          string `yaml:"name"`
+	ContainerPort int    `yaml:"containerPort"`
+}
+
+type volumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	SubPath   string `yaml:"subPath"`
+	ReadOnly  bool   `yaml:"readOnly"`
+}
+
+type volume struct {
+	Name   string       `yaml:"name"`
+	Secret secretVolume `yaml:"secret"`
+}
+
+type secretVolume struct {
+	SecretName string `yaml:"secretName"`
+}
+
+type probe struct {
+	HTTPGet             httpGet `yaml:"httpGet"`
+	InitialDelaySeconds int     `yaml:"initialDelaySeconds"`
+	PeriodSeconds       int     `yaml:"periodSeconds"`
+}
+
+type httpGet struct {
+	Path string `yaml:"path"`
+	Port string `yaml:"port"`
+}
+
+type serviceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []servicePort     `yaml:"ports"`
+}
+
+type servicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort string `yaml:"targetPort"`
+}
+
+type ingressSpec struct {
+	TLS   []ingressTLS  `yaml:"tls,omitempty"`
+	Rules []ingressRule `yaml:"rules"`
+}
+
+type ingressTLS struct {
+	Hosts      []string `yaml:"hosts"`
+	SecretName string   `yaml:"secretName"`
+}
+
+type ingressRule struct {
+	Host string `yaml:"host"`
+	HTTP struct {
+		Paths []ingressPath `yaml:"paths"`
+	} `yaml:"http"`
+}
+
+type ingressPath struct {
+	Path     string         `yaml:"path"`
+	PathType string         `yaml:"pathType"`
+	Backend  ingressBackend `yaml:"backend"`
+}
+
+type ingressBackend struct {
+	Service struct {
+		Name string `yaml:"name"`
+		Port struct {
+			Name string `yaml:"name"`
+		} `yaml:"port"`
+	} `yaml:"service"`
+}
+
+// labels 资源的标签
+func labels(name string) map[string]string {
+	return map[string]string{"app.kubernetes.io/name": name, "app.kubernetes.io/part-of": "gin-vue-admin"}
+}
+
+// deployment 一个 Deployment；后端挂载配置 Secret、设置环境变量和健康检查
+func (s Spec) deployment(name, image string, port int, server bool) object {
+	c := containerSpec{
+		Name:  name,
+		Image: strings.TrimSpace(image),
+		Ports: []portSpec{{Name: "http", ContainerPort: port}},
+	}
+	pod := podSpec{}
+	if server {
+		c.Env = s.Env
+		c.VolumeMounts = []volumeMount{{Name: "config", MountPath: ServerWorkDir + "/config.docker.yaml", SubPath: "config.yaml", ReadOnly: true}}
+		c.ReadinessProbe = &probe{HTTPGet: httpGet{Path: s.healthPath(), Port: "http"}, InitialDelaySeconds: 5, PeriodSeconds: 10}
+		c.LivenessProbe = &probe{HTTPGet: httpGet{Path: s.healthPath(), Port: "http"}, InitialDelaySeconds: 30, PeriodSeconds: 20}
+		pod.Volumes = []volume{{Name: "config", Secret: secretVolume{SecretName: ConfigSecret}}}
+	}
+	pod.Containers = []containerSpec{c}
+	return object{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   metadata{Name: name, Namespace: s.Namespace, Labels: labels(name)},
+		Spec: deploymentSpec{
+			Replicas: s.replicas(),
+			Selector: selector{MatchLabels: map[string]string{"app.kubernetes.io/name": name}},
+			Template: podTemplate{Metadata: metadata{Labels: labels(name)}, Spec: pod},
+		},
+	}
+}
+
+// service 一个 ClusterIP Service
+func (s Spec) service(name string, port int) object {
+	return object{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   metadata{Name: name, Namespace: s.Namespace, Labels: labels(name)},
+		Spec: serviceSpec{
+			Selector: map[string]string{"app.kubernetes.io/name": name},
+			Ports:    []servicePort{{Name: "http", Port: port, TargetPort: "http"}},
+		},
+	}
+}
+
+// ingresses 域名下的页面转发到前端，接口前缀转发到后端。需要改写路径时接口单独放在 gva-api 中，
+// 因为 ingress-nginx 的 rewrite-target 对同一个 Ingress 中的所有路径生效
+func (s Spec) ingresses() []object {
+	backend := func(name string) ingressBackend {
+		var b ingressBackend
+		b.Service.Name = name
+		b.Service.Port.Name = "http"
+		return b
+	}
+	newIngress := func(name string, annotations map[string]string, paths ...ingressPath) object {
+		rule := ingressRule{Host: s.Host}
+		rule.HTTP.Paths = paths
+		spec := ingressSpec{Rules: []ingressRule{rule}}
+		if s.TLSSecret != "" {
+			spec.TLS = []ingressTLS{{Hosts: []string{s.Host}, SecretName: s.TLSSecret}}
+		}
+		meta := metadata{Name: name, Namespace: s.Namespace, Labels: labels(name), Annotations: annotations}
+		return object{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Metadata: meta, Spec: spec}
+	}
+
+	web := ingressPath{Path: "/", PathType: "Prefix", Backend: backend(WebName)}
+	path, rewrite := s.apiRoute()
+	switch {
+	case path == "":
+		return []object{newIngress("gva", nil, web)}
+	case rewrite == "":
+		return []object{newIngress("gva", nil, ingressPath{Path: path, PathType: "Prefix", Backend: backend(ServerName)}, web)}
+	default:
+		api := ingressPath{Path: path, PathType: "ImplementationSpecific", Backend: backend(ServerName)}
+		return []object{newIngress("gva", nil, web), newIngress("gva-api", rewriteAnnotations(rewrite), api)}
+	}
+}
+
+// rewriteAnnotations ingress-nginx 按正则匹配路径并改写的注解
+func rewriteAnnotations(rewrite string) map[string]string {
+	return map[string]string{
+		"nginx.ingress.kubernetes.io/use-regex":      "true",
+		"nginx.ingress.kubernetes.io/rewrite-target": rewrite,
+	}
+}
+
+// marshal 以两个空格缩进输出 YAML（与 kubectl 和 Helm 的习惯一致）
+func marshal(v any) ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// SplitImage 把镜像引用拆成仓库和标签（没有标签时为 latest）；带摘要的引用保留在仓库部分
+func SplitImage(ref string) (repository, tag string) {
+	ref = strings.TrimSpace(ref)
+	slash := strings.LastIndex(ref, "/")
+	if colon := strings.LastIndex(ref, ":"); colon > slash && !strings.Contains(ref, "@") {
+		return ref[:colon], ref[colon+1:]
+	}
+	return ref, "latest"
+}
+
+// HelmValues 生成 Helm 的 values.yaml（常见 chart 的 image / service / ingress 布局，前后端各一节），
+// 用于团队自己维护的 chart；键名与所用 chart 不一致时需要调整
+func HelmValues(s Spec) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	type image struct {
+		Repository string `yaml:"repository"`
+		Tag        string `yaml:"tag"`
+		PullPolicy string `yaml:"pullPolicy"`
+	}
+	type component struct {
+		ReplicaCount int   `yaml:"replicaCount"`
+		Image        image `yaml:"image"`
+		Service      struct {
+			Type string `yaml:"type"`
+			Port int    `yaml:"port"`
+		} `yaml:"service"`
+		Env          []EnvVar `yaml:"env,omitempty"`
+		ConfigSecret string   `yaml:"configSecret,omitempty"`
+		HealthPath   string   `yaml:"healthPath,omitempty"`
+	}
+	newComponent := func(ref string, port int) component {
+		c := component{ReplicaCount: s.replicas()}
+		c.Image.Repository, c.Image.Tag = SplitImage(ref)
+		c.Image.PullPolicy = "IfNotPresent"
+		c.Service.Type = "ClusterIP"
+		c.Service.Port = port
+		return c
+	}
+	server := newComponent(s.ServerImage, s.ServerPort)
+	server.Env = s.Env
+	server.ConfigSecret = ConfigSecret
+	server.HealthPath = s.healthPath()
+	web := newComponent(s.WebImage, s.webPort())
+
+	type ingressValues struct {
+		Enabled     bool              `yaml:"enabled"`
+		Host        string            `yaml:"host,omitempty"`
+		TLSSecret   string            `yaml:"tlsSecret,omitempty"`
+		APIPath     string            `yaml:"apiPath,omitempty"`
+		Annotations map[string]string `yaml:"annotations,omitempty"` // 接口路径的注解
+	}
+	ingress := ingressValues{Enabled: s.Host != "", Host: s.Host, TLSSecret: s.TLSSecret}
+	if s.Host != "" {
+		var rewrite string
+		ingress.APIPath, rewrite = s.apiRoute()
+		if rewrite != "" {
+			ingress.Annotations = rewriteAnnotations(rewrite)
+		}
+	}
+
+	values := struct {
+		Namespace string        `yaml:"namespace,omitempty"`
+		Server    component     `yaml:"server"`
+		Web       component     `yaml:"web"`
+		Ingress   ingressValues `yaml:"ingress"`
+	}{s.Namespace, server, web, ingress}
+
+	data, err := marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	header := "# 由 GVAPanel 生成的 Helm values，按常见 chart 的布局组织（server / web / ingress），请对照所用 chart 调整键名\n"
+	return append([]byte(header), data...), nil
+}
@@ -0,0 +1,180 @@
+package kube
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func testSpec() Spec {
+	return Spec{
+		Namespace:   "gva",
+		Host:        "admin.example.com",
+		TLSSecret:   "admin-tls",
+		ServerImage: "registry.example.com/team/gva-server:v1",
+		WebImage:    "registry.example.com/team/gva-web:v1",
+		ServerPort:  8888,
+		APIPrefix:   "/api",
+		Env:         []EnvVar{{Name: "GIN_MODE", Value: "release"}, {Name: "TZ", Value: "Asia/Shanghai"}},
+	}
+}
+
+// decode 把多文档清单解析为对象列表
+func decode(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var docs []map[string]any
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc map[string]any
+		if err := dec.Decode(&doc); err != nil {
+			break
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func TestManifests(t *testing.T) {
+	data, err := Manifests(testSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := decode(t, data)
+	var kinds []string
+	for _, doc := range docs {
+		kinds = append(kinds, doc["kind"].(string)+"/"+doc["metadata"].(map[string]any)["name"].(string))
+	}
+	want := "Deployment/gva-server Service/gva-server Deployment/gva-web Service/gva-web Ingress/gva Ingress/gva-api"
+	if strings.Join(kinds, " ") != want {
+		t.Fatalf("kinds = %v", kinds)
+	}
+
+	text := string(data)
+	for _, s := range []string{
+		"kubectl create secret generic gva-server-config -n gva --from-file=config.yaml=server/config.yaml",
+		"image: registry.example.com/team/gva-server:v1",
+		"containerPort: 8888",
+		"containerPort: 8080",
+		"mountPath: " + ServerWorkDir + "/config.docker.yaml",
+		"path: /health",
+		"nginx.ingress.kubernetes.io/rewrite-target: /$2",
+		"path: /api(/|$)(.*)",
+		"secretName: admin-tls",
+		"value: Asia/Shanghai",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("清单中缺少 %q:\n%s", s, text)
+		}
+	}
+	// 环境变量只加在后端
+	if strings.Count(text, "GIN_MODE") != 1 {
+		t.Errorf("GIN_MODE 出现 %d 次", strings.Count(text, "GIN_MODE"))
+	}
+}
+
+func TestApiRoute(t *testing.T) {
+	cases := []struct {
+		api, router   string
+		path, rewrite string
+	}{
+		{"/api", "", "/api(/|$)(.*)", "/$2"},
+		{"/api", "/api", "/api", ""},
+		{"/api/", "v1", "/api(/|$)(.*)", "/v1/$2"},
+		{"https://api.example.com", "", "", ""},
+		{"/", "", "", ""},
+	}
+	for _, c := range cases {
+		path, rewrite := Spec{APIPrefix: c.api, RouterPrefix: c.router}.apiRoute()
+		if path != c.path || rewrite != c.rewrite {
+			t.Errorf("%q %q: got %q %q", c.api, c.router, path, rewrite)
+		}
+	}
+	if got := (Spec{RouterPrefix: "/v1/"}).healthPath(); got != "/v1/health" {
+		t.Errorf("healthPath = %q", got)
+	}
+}
+
+func TestManifestsWithoutHost(t *testing.T) {
+	s := testSpec()
+	s.Host, s.Namespace = "", ""
+	data, err := Manifests(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "kind: Ingress") || strings.Contains(string(data), "namespace:") {
+		t.Errorf("没有域名时不生成 Ingress，没有命名空间时不写入:\n%s", data)
+	}
+}
+
+func TestHelmValues(t *testing.T) {
+	data, err := HelmValues(testSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values struct {
+		Server struct {
+			Image   struct{ Repository, Tag string }
+			Service struct{ Port int }
+		}
+		Web struct {
+			Service struct{ Port int }
+		}
+		Ingress struct {
+			Enabled bool
+			Host    string
+			APIPath string `yaml:"apiPath"`
+		}
+	}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		t.Fatal(err)
+	}
+	if values.Server.Image.Repository != "registry.example.com/team/gva-server" || values.Server.Image.Tag != "v1" ||
+		values.Server.Service.Port != 8888 || values.Web.Service.Port != DefaultWebPort {
+		t.Errorf("values = %+v", values)
+	}
+	if !values.Ingress.Enabled || values.Ingress.Host != "admin.example.com" || values.Ingress.APIPath != "/api(/|$)(.*)" {
+		t.Errorf("ingress = %+v", values.Ingress)
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	cases := map[string][2]string{
+		"gva-server":                     {"gva-server", "latest"},
+		"team/gva-server:v1":             {"team/gva-server", "v1"},
+		"localhost:5000/gva-server":      {"localhost:5000/gva-server", "latest"},
+		"localhost:5000/gva-server:v2.1": {"localhost:5000/gva-server", "v2.1"},
+	}
+	for ref, want := range cases {
+		if repo, tag := SplitImage(ref); repo != want[0] || tag != want[1] {
+			t.Errorf("%s: got %s %s", ref, repo, tag)
+		}
+	}
+}
+
+func TestValidateAndParseEnv(t *testing.T) {
+	for _, mutate := range []func(*Spec){
+		func(s *Spec) { s.Namespace = "GVA" },
+		func(s *Spec) { s.Host = "https://admin.example.com" },
+		func(s *Spec) { s.WebImage = "" },
+		func(s *Spec) { s.ServerPort = 0 },
+	} {
+		s := testSpec()
+		mutate(&s)
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v 应报错", s)
+		}
+	}
+
+	env, err := ParseEnv("# 注释\nGIN_MODE=release\n\nDSN = a=b\n")
+	if err != nil || len(env) != 2 || env[1] != (EnvVar{Name: "DSN", Value: "a=b"}) {
+		t.Errorf("env = %+v, err = %v", env, err)
+	}
+	if FormatEnv(env) != "GIN_MODE=release\nDSN=a=b" {
+		t.Errorf("FormatEnv = %q", FormatEnv(env))
+	}
+	if _, err := ParseEnv("NOVALUE"); err == nil {
+		t.Error("缺少 = 时应报错")
+	}
+}
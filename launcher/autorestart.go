@@ -0,0 +1,155 @@
+package launcher
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 意外退出后自动重启的退避策略：第一次等待 restartBaseDelay，之后每次加倍，最长 restartMaxDelay；
+// 连续 restartMaxAttempts 次启动后很快又退出（运行不到 restartStableRun）时放弃，避免配置错误时无限重启
+const (
+	restartBaseDelay   = time.Second
+	restartMaxDelay    = time.Minute
+	restartStableRun   = 2 * time.Minute
+	restartMaxAttempts = 8
+)
+
+// restartState 一个服务的自动重启状态
+type restartState struct {
+	count   int         // 本次手动启动以来自动重启的次数（状态栏显示）
+	attempt int         // 连续快速退出的次数（决定下次的等待时间）
+	started time.Time   // 最近一次启动进程的时间
+	timer   *time.Timer // 等待中的重启
+	gen     int         // 手动启动或停止时加一，已经触发的重启发现不一致时放弃
+}
+
+// restarts 各服务的自动重启状态
+type restarts struct {
+	mu       sync.Mutex
+	services map[string]*restartState
+}
+
+// state 服务的状态（调用方持有锁）
+func (r *restarts) state(service string) *restartState {
+	if r.services == nil {
+		r.services = make(map[string]*restartState)
+	}
+	s, ok := r.services[service]
+	if !ok {
+		s = &restartState{}
+		r.services[service] = s
+	}
+	return s
+}
+
+// restartDelay 第 attempt 次（从 0 开始）自动重启前的等待时间
+func restartDelay(attempt int) time.Duration {
+	delay := restartBaseDelay
+	for i := 0; i < attempt && delay < restartMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, restartMaxDelay)
+}
+
+// next 进程在 now 退出后下次重启前的等待时间；连续快速退出已达 restartMaxAttempts 次时返回 false
+func (s *restartState) next(now time.Time) (time.Duration, bool) {
+	if now.Sub(s.started) >= restartStableRun {
+		s.attempt = 0
+	}
+	if s.attempt >= restartMaxAttempts {
+		return 0, false
+	}
+	delay := restartDelay(s.attempt)
+	s.attempt++
+	return delay, true
+}
+
+// Restarts 服务自动重启的次数（手动启动或停止后清零）
+func (m *ServiceManager) Restarts(service string) int {
+	m.restarts.mu.Lock()
+	defer m.restarts.mu.Unlock()
+	return m.restarts.state(service).count
+}
+
+// markStarted 记录进程启动的时间（用于判断是否稳定运行）
+func (m *ServiceManager) markStarted(service string) {
+	m.restarts.mu.Lock()
+	m.restarts.state(service).started = time.Now()
+	m.restarts.mu.Unlock()
+}
+
+// resetRestarts 手动启动或停止服务时取消等待中的重启，并清零计数
+func (m *ServiceManager) resetRestarts(service string) {
+	m.restarts.mu.Lock()
+	s := m.restarts.state(service)
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	*s = restartState{started: s.started, gen: s.gen + 1}
+	m.restarts.mu.Unlock()
+}
+
+// cancelRestart 直接启动服务（StartBackend / StartFrontend，例如看守模式）时取消等待中的重启，保留计数
+func (m *ServiceManager) cancelRestart(service string) {
+	m.restarts.mu.Lock()
+	s := m.restarts.state(service)
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.gen++
+	m.restarts.mu.Unlock()
+}
+
+// scheduleRestart 服务意外退出后按退避时间安排重启（未开启自动重启时什么也不做）；
+// 上次运行超过 restartStableRun 时从最短的等待时间重新开始
+func (m *ServiceManager) scheduleRestart(service string) {
+	if m.AutoRestart == nil || !m.AutoRestart() {
+		return
+	}
+	output := m.Output(service)
+
+	m.restarts.mu.Lock()
+	s := m.restarts.state(service)
+	delay, ok := s.next(time.Now())
+	if !ok {
+		m.restarts.mu.Unlock()
+		output.Println(fmt.Sprintf("===== 连续 %d 次启动后很快退出，不再自动重启，请查看上面的输出 =====", restartMaxAttempts))
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	gen := s.gen
+	s.timer = time.AfterFunc(delay, func() { m.autoRestart(service, gen) })
+	m.restarts.mu.Unlock()
+
+	output.Println(fmt.Sprintf("===== %d 秒后自动重启%s =====", int(delay.Seconds()), ServiceLabel(service)))
+}
+
+// autoRestart 退避时间到后重启服务：期间被手动启动或停止（gen 已变化，定时器停止前已经触发的也放弃）、
+// 关闭了自动重启或端口已被占用时放弃
+func (m *ServiceManager) autoRestart(service string, gen int) {
+	m.restarts.mu.Lock()
+	s := m.restarts.state(service)
+	if s.gen != gen {
+		m.restarts.mu.Unlock()
+		return
+	}
+	s.timer = nil
+	m.restarts.mu.Unlock()
+
+	if m.stoppingFlag(service).Load() || m.AutoRestart == nil || !m.AutoRestart() {
+		return
+	}
+	if err := m.startService(service); err != nil {
+		m.Output(service).Println(fmt.Sprintf("===== 自动重启失败: %v =====", err))
+		return
+	}
+
+	m.restarts.mu.Lock()
+	m.restarts.state(service).count++
+	m.restarts.mu.Unlock()
+	m.Publish()
+}
@@ -0,0 +1,105 @@
+package launcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestartDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{5, 32 * time.Second},
+		{6, time.Minute},
+		{7, time.Minute},
+		{100, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := restartDelay(tt.attempt); got != tt.want {
+			t.Errorf("restartDelay(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRestartGivesUpAfterMaxAttempts(t *testing.T) {
+	now := time.Now()
+	s := &restartState{started: now}
+	for i := 0; i < restartMaxAttempts; i++ {
+		delay, ok := s.next(now.Add(time.Second))
+		if !ok {
+			t.Fatalf("第 %d 次就放弃了重启", i+1)
+		}
+		if delay != restartDelay(i) {
+			t.Errorf("attempt %d: delay = %s, want %s", i, delay, restartDelay(i))
+		}
+	}
+	if _, ok := s.next(now.Add(time.Second)); ok {
+		t.Errorf("连续 %d 次快速退出后仍继续重启", restartMaxAttempts)
+	}
+}
+
+func TestRestartResetsAfterStableRun(t *testing.T) {
+	now := time.Now()
+	s := &restartState{started: now, attempt: restartMaxAttempts}
+	if _, ok := s.next(now.Add(restartStableRun - time.Second)); ok {
+		t.Fatal("运行不到 restartStableRun 时不应重新计数")
+	}
+	delay, ok := s.next(now.Add(restartStableRun))
+	if !ok || delay != restartBaseDelay {
+		t.Errorf("稳定运行后 next = %s, %v, want %s, true", delay, ok, restartBaseDelay)
+	}
+	if s.attempt != 1 {
+		t.Errorf("attempt = %d, want 1", s.attempt)
+	}
+}
+
+func TestResetRestartsStopsPendingRestart(t *testing.T) {
+	m := newServiceManager(NewProject(t.TempDir()), t.TempDir())
+	m.AutoRestart = func() bool { return true }
+	m.stoppingFlag(ServiceBackend).Store(true)
+	m.scheduleRestart(ServiceBackend)
+
+	m.restarts.mu.Lock()
+	s := m.restarts.state(ServiceBackend)
+	if s.timer == nil || s.attempt != 1 {
+		t.Errorf("scheduleRestart 没有安排重启: attempt = %d", s.attempt)
+	}
+	m.restarts.mu.Unlock()
+
+	m.resetRestarts(ServiceBackend)
+	m.restarts.mu.Lock()
+	s = m.restarts.state(ServiceBackend)
+	if s.timer != nil || s.attempt != 0 || s.count != 0 {
+		t.Errorf("resetRestarts 后状态未清零: %+v", *s)
+	}
+	m.restarts.mu.Unlock()
+}
+
+func TestManualStartDropsFiredRestart(t *testing.T) {
+	m := newServiceManager(NewProject(t.TempDir()), t.TempDir())
+	m.AutoRestart = func() bool { return true }
+	m.scheduleRestart(ServiceBackend)
+
+	m.restarts.mu.Lock()
+	s := m.restarts.state(ServiceBackend)
+	gen := s.gen
+	s.timer.Stop()
+	m.restarts.mu.Unlock()
+
+	// 定时器已经触发、还没有拿到锁时手动启动了服务：触发的重启应放弃
+	m.cancelRestart(ServiceBackend)
+	m.autoRestart(ServiceBackend, gen)
+	if m.Restarts(ServiceBackend) != 0 || m.Backend.IsRunning() {
+		t.Errorf("手动启动后仍执行了自动重启: restarts = %d", m.Restarts(ServiceBackend))
+	}
+	for _, line := range m.Output(ServiceBackend).Tail(10) {
+		if strings.Contains(line, "启动:") {
+			t.Errorf("不应启动服务: %s", line)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package launcher
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// backupFiles 备份的项目配置文件（相对 GVA 根目录）
+var backupFiles = []string{
+	"server/config.yaml",
+	"web/.env",
+	"web/.env.development",
+	"web/.env.production",
+}
+
+// Backup 将项目配置文件打包为 destDir/gva-config-时间.zip，返回压缩包路径
+func (p *Project) Backup(destDir string) (string, error) {
+	if !p.IsSet() {
+		return "", apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", apperr.Errorf(apperr.BackupFailed, "创建备份目录失败: %v", err)
+	}
+
+	zipPath := filepath.Join(destDir, "gva-config-"+time.Now().Format("20060102-150405")+".zip")
+	file, err := os.Create(zipPath)
+	if err != nil {
+		return "", apperr.Errorf(apperr.BackupFailed, "创建备份文件失败: %v", err)
+	}
+
+	zw := zip.NewWriter(file)
+	count := 0
+	for _, rel := range backupFiles {
+		src := filepath.Join(p.Root, filepath.FromSlash(rel))
+		if !sysutil.FileExists(src) {
+			continue
+		}
+		if err := addZipFile(zw, src, rel); err != nil {
+			zw.Close()
+			file.Close()
+			os.Remove(zipPath)
+			return "", apperr.Errorf(apperr.BackupFailed, "备份 %s 失败: %v", rel, err)
+		}
+		count++
+	}
+
+	if err := zw.Close(); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	if count == 0 {
+		os.Remove(zipPath)
+		return "", apperr.Errorf(apperr.BackupFailed, "没有找到需要备份的配置文件")
+	}
+	return zipPath, nil
+}
+
+// addZipFile 把 src 以 name 写入压缩包
+func addZipFile(zw *zip.Writer, src, name string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,259 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/cdnupload"
+	"gva-launcher/config"
+	"gva-launcher/deploy"
+	"gva-launcher/deps"
+	"gva-launcher/distcheck"
+	"gva-launcher/download"
+	"gva-launcher/hooks"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+	"gva-launcher/secretscan"
+)
+
+// BuildManager 负责构建项目（后端 go build，前端 npm run build）
+type BuildManager struct {
+	// Hooks 事件钩子分发器（可为 nil）
+	Hooks *hooks.Dispatcher
+
+	// Priority 构建命令（go build / npm run build）的优先级（为 nil 时正常优先级），见 priority.go
+	Priority func() sysutil.Priority
+
+	// ConfirmSecrets 远程部署、上传静态资源前发现疑似密钥时请用户确认（在任务中调用，阻塞到用户选择），
+	// 返回 true 时继续发布；为 nil 时发现即取消，见 secrets.go
+	ConfirmSecrets func(ctx context.Context, findings []secretscan.Finding) bool
+
+	project *Project
+}
+
+// NewBuildManager 创建构建管理器
+func NewBuildManager(project *Project) *BuildManager {
+	return &BuildManager{project: project}
+}
+
+// BinaryPath 后端编译产物路径（server/gva-server，Windows 下带 .exe）
+func (m *BuildManager) BinaryPath() string {
+	return m.project.BackendBinary()
+}
+
+// DistDir 前端构建产物目录
+func (m *BuildManager) DistDir() string {
+	return filepath.Join(m.project.WebDir(), "dist")
+}
+
+// Build 依次构建后端和前端，命令输出写入 w，成功后触发 after-build 钩子
+func (m *BuildManager) Build(w io.Writer) error {
+	if err := m.BuildBackend(w); err != nil {
+		return err
+	}
+	if err := m.buildFrontend(w, ""); err != nil {
+		return err
+	}
+	m.writeSBOM(context.Background(), w, m.project.ServerDir())
+
+	vars := m.project.HookVars()
+	vars["backend_binary"] = m.BinaryPath()
+	vars["frontend_dist"] = m.DistDir()
+	m.Hooks.Fire(hooks.AfterBuild, vars)
+	return nil
+}
+
+// BuildBackend 只构建后端（go build 到 server/gva-server），命令输出写入 w，不触发钩子
+func (m *BuildManager) BuildBackend(w io.Writer) error {
+	if !m.project.IsValid() {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效")
+	}
+
+	// Go 工作区模式下附加需要的参数
+	flags := deps.GoBuildFlags(m.project.ServerDir())
+	args := append(append([]string{"build"}, flags...), "-o", m.BinaryPath(), ".")
+	fmt.Fprintf(w, "$ go %s\n", strings.Join(append(append([]string{"build"}, flags...), "-o", filepath.Base(m.BinaryPath()), "."), " "))
+	output, err := sysutil.CombinedOutputPriority(m.project.ServerDir(), nil, priorityOf(m.Priority), "go", args...)
+	w.Write(output)
+	if err != nil {
+		return apperr.Errorf(apperr.BuildFailed, "后端构建失败: %v", err)
+	}
+	return nil
+}
+
+// BuildFrontend 只构建前端（生产构建），base 不为空时作为静态资源的访问地址前缀（vite build --base），
+// 成功后触发 after-build 钩子
+func (m *BuildManager) BuildFrontend(w io.Writer, base string) error {
+	if !m.project.IsValid() {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效")
+	}
+	if err := m.buildFrontend(w, base); err != nil {
+		return err
+	}
+
+	vars := m.project.HookVars()
+	vars["frontend_dist"] = m.DistDir()
+	m.Hooks.Fire(hooks.AfterBuild, vars)
+	return nil
+}
+
+// buildFrontend npm run build：构建前检查 .env.production 的接口地址（不能指向本机），
+// 构建后确认 dist 使用了该地址（没有 .env.production 时跳过检查）
+func (m *BuildManager) buildFrontend(w io.Writer, base string) error {
+	envPath := config.EnvProdPath(m.project.Root)
+	checkEnv := sysutil.FileExists(envPath)
+	env := config.ReadProductionEnv(m.project.Root)
+	if checkEnv {
+		if err := env.Validate(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "生产环境接口地址: %s\n", env.APIURL())
+	} else {
+		fmt.Fprintln(w, "未找到 web/.env.production，跳过接口地址检查")
+	}
+
+	args := []string{"run", "build"}
+	if base != "" {
+		args = append(args, "--", "--base="+base)
+	}
+	fmt.Fprintf(w, "$ npm %s\n", strings.Join(args, " "))
+	output, err := sysutil.CombinedOutputPriority(m.project.WebDir(), nil, priorityOf(m.Priority), "npm", args...)
+	w.Write(output)
+	if err != nil {
+		return apperr.Errorf(apperr.BuildFailed, "前端构建失败: %v", err)
+	}
+
+	if checkEnv {
+		result, err := distcheck.Verify(m.DistDir(), env)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "已检查 dist 中的 %d 个文件：使用了接口地址 %s，没有残留本机地址\n", result.Files, env.BaseAPI)
+	}
+	return nil
+}
+
+// BuildAndUpload 生产构建后把静态资源上传到对象存储：凭据取自 config.yaml 中 cdn.Storage 对应的存储配置，
+// 以存储的访问地址加路径前缀作为 --base 构建前端，再上传 dist 中除 index.html 以外的文件
+func (m *BuildManager) BuildAndUpload(ctx context.Context, cdn config.CDN, j *jobs.Job) (cdnupload.Summary, error) {
+	gvaConfig, err := m.project.ReadConfig()
+	if err != nil {
+		return cdnupload.Summary{}, err
+	}
+	target, err := cdnupload.TargetFrom(gvaConfig, cdn.Storage)
+	if err != nil {
+		return cdnupload.Summary{}, err
+	}
+	prefix := cdn.Prefix
+	if prefix == "" {
+		prefix = cdnupload.DefaultPrefix
+	}
+	base := target.PublicBase(prefix)
+	if err := m.BuildFrontend(j, base); err != nil {
+		return cdnupload.Summary{}, err
+	}
+	if err := m.checkSecrets(ctx, j, m.releaseSecrets()); err != nil {
+		return cdnupload.Summary{}, err
+	}
+
+	j.Logf("上传到 %s（%s/%s），访问地址 %s", cdnupload.StorageLabel(target.Storage), target.Bucket, prefix, base)
+	summary, err := cdnupload.Upload(ctx, download.Client(2*time.Minute), target, m.DistDir(), prefix, func(done, total int) {
+		j.SetProgress(float64(done) / float64(total))
+		j.SetCount(float64(done), "个文件")
+	})
+	if err != nil {
+		return summary, err
+	}
+	j.Logf("已上传 %d 个文件，共 %s", summary.Files, sysutil.FormatSize(summary.Bytes))
+	return summary, nil
+}
+
+// BuildAndDeploy 为服务器（Linux，arch 为 GOARCH，为空时为 amd64）交叉编译后端并构建前端，
+// 打包后部署为服务器上的新版本（见 deploy 包），返回新版本的目录名。
+// 填写了健康检查地址时切换后等待服务健康，不健康时按设置自动回滚，并触发 deploy-unhealthy 钩子
+func (m *BuildManager) BuildAndDeploy(ctx context.Context, t deploy.Target, arch string, health deploy.Health, j *jobs.Job) (string, error) {
+	if !m.project.IsValid() {
+		return "", apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效")
+	}
+	if err := t.Validate(); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "%v", err)
+	}
+	if err := health.Validate(); err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "%v", err)
+	}
+	if arch == "" {
+		arch = "amd64"
+	}
+
+	tmp, err := os.MkdirTemp("", "gvapanel-deploy-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	// 交叉编译：关闭 cgo，生成不依赖服务器 glibc 版本的静态可执行文件
+	binary := filepath.Join(tmp, "gva-server")
+	flags := deps.GoBuildFlags(m.project.ServerDir())
+	args := append(append([]string{"build"}, flags...), "-o", binary, ".")
+	env := []string{"GOOS=linux", "GOARCH=" + arch, "CGO_ENABLED=0"}
+	j.Logf("$ %s go %s", strings.Join(env, " "), strings.Join(append(append([]string{"build"}, flags...), "-o", "gva-server", "."), " "))
+	output, err := sysutil.CombinedOutputPriority(m.project.ServerDir(), env, priorityOf(m.Priority), "go", args...)
+	j.Write(output)
+	if err != nil {
+		return "", apperr.Errorf(apperr.BuildFailed, "后端构建失败: %v", err)
+	}
+	if err := m.buildFrontend(j, ""); err != nil {
+		return "", err
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if err := m.checkSecrets(ctx, j, m.releaseSecrets()); err != nil {
+		return "", err
+	}
+
+	// 软件物料清单放在发布包的根目录
+	sbomFiles := m.writeSBOM(ctx, j, tmp)
+
+	archive := filepath.Join(tmp, "release.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		return "", err
+	}
+	err = deploy.Pack(f, binary, m.DistDir(), sbomFiles...)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", apperr.Errorf(apperr.DeployFailed, "打包失败: %v", err)
+	}
+	if info, err := os.Stat(archive); err == nil {
+		j.Logf("已打包 server/gva-server 和 web/dist（%s）", sysutil.FormatSize(info.Size()))
+	}
+
+	id, err := deploy.Upload(ctx, t, archive, j)
+	if err != nil {
+		return "", err
+	}
+	j.Logf("已部署版本 %s", id)
+	if !health.Enabled() {
+		return id, nil
+	}
+
+	rolledBack, err := deploy.Gate(ctx, download.Client(0), t, health, j)
+	if err != nil && ctx.Err() == nil {
+		vars := m.project.HookVars()
+		vars["release"] = id
+		vars["rolled_back_to"] = rolledBack
+		vars["health_url"] = health.URL
+		vars["deploy_error"] = err.Error()
+		m.Hooks.Fire(hooks.DeployUnhealthy, vars)
+	}
+	return id, err
+}
@@ -0,0 +1,52 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+
+	"gva-launcher/codegen"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+)
+
+// CommandDir 项目自定义命令的执行目录（server/ 或 web/）
+func (p *Project) CommandDir(c config.ProjectCommand) string {
+	if c.Dir == config.CommandDirWeb {
+		return p.WebDir()
+	}
+	return p.ServerDir()
+}
+
+// RunProjectCommand 通过系统 shell 执行项目自定义命令，输出实时写入任务日志；任务取消时结束命令
+func RunProjectCommand(ctx context.Context, project *Project, c config.ProjectCommand, j *jobs.Job) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	dir := project.CommandDir(c)
+	if !sysutil.DirExists(dir) {
+		return fmt.Errorf("执行目录不存在: %s", dir)
+	}
+
+	j.Logf("$ %s（目录: %s/）", c.Command, c.Dir)
+	name, args := sysutil.ShellCommand(c.Command)
+	if err := sysutil.RunOutputContext(ctx, dir, j, name, args...); err != nil {
+		return fmt.Errorf("命令「%s」执行失败: %v", c.Name, err)
+	}
+	return nil
+}
+
+// RunGenerators 在 server 目录中依次执行识别到的生成命令，输出实时写入任务日志；某个命令失败时停止，
+// 并提示需要的工具的安装命令（最常见的原因是没有安装 swag / wire）
+func RunGenerators(ctx context.Context, project *Project, gens []codegen.Generator, j *jobs.Job) error {
+	for _, g := range gens {
+		c := config.ProjectCommand{Name: g.Name, Dir: config.CommandDirServer, Command: g.Command}
+		if err := RunProjectCommand(ctx, project, c, j); err != nil {
+			if g.Install != "" && ctx.Err() == nil {
+				j.Logf("如果提示找不到 %s 命令，请先安装: %s", g.Tool, g.Install)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package launcher
+
+import (
+	"context"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/dbsnapshot"
+	"gva-launcher/services"
+)
+
+// DemoReadyTimeout 演示模式重置后等待前端端口就绪的最长时间
+const DemoReadyTimeout = 2 * time.Minute
+
+// DemoSnapshotDir 项目的演示快照目录
+func (p *Project) DemoSnapshotDir() string {
+	return dbsnapshot.Dir(config.DBSnapshotDir(), p.Root)
+}
+
+// TakeDemoSnapshot 把项目当前的数据库保存为演示快照
+func (p *Project) TakeDemoSnapshot() (dbsnapshot.Info, error) {
+	if !p.IsSet() {
+		return dbsnapshot.Info{}, apperr.Errorf(apperr.ProjectNotSet, "GVA根目录未设置")
+	}
+	cfg, err := config.ReadGVAConfig(p.Root)
+	if err != nil {
+		return dbsnapshot.Info{}, err
+	}
+	return dbsnapshot.Take(p.DemoSnapshotDir(), p.Root, cfg)
+}
+
+// ResetDemo 演示模式一键重置：停止服务 → 数据库还原到快照 → 重新启动服务并等待前端端口就绪。
+// 每一步通过 logf 输出进度；还原失败时服务保持停止状态
+func ResetDemo(ctx context.Context, project *Project, serviceManager *ServiceManager, logf func(format string, args ...interface{})) error {
+	if !project.IsValid() {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效: %s", project.Root)
+	}
+	cfg, err := config.ReadGVAConfig(project.Root)
+	if err != nil {
+		return err
+	}
+
+	logf("停止前后端服务")
+	serviceManager.Stop()
+	time.Sleep(serviceManager.timeouts().StopWait())
+
+	logf("还原数据库快照")
+	if err := dbsnapshot.Restore(project.DemoSnapshotDir(), project.Root, cfg); err != nil {
+		return err
+	}
+
+	if err := serviceManager.CheckPorts(); err != nil {
+		return err
+	}
+	logf("启动前后端服务")
+	serviceManager.Start()
+
+	_, frontendPort := project.Ports()
+	logf("等待前端端口 %d 就绪", frontendPort)
+	deadline := time.Now().Add(DemoReadyTimeout)
+	for !services.IsPortInUse(frontendPort) {
+		if time.Now().After(deadline) {
+			return apperr.Errorf(apperr.SvcStartFailed, "前端在 %v 内未就绪（端口 %d）", DemoReadyTimeout, frontendPort)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	serviceManager.Refresh(project.Ports())
+	return nil
+}
@@ -0,0 +1,278 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/hooks"
+	"gva-launcher/trash"
+)
+
+// DependencyStatus 依赖安装状态
+type DependencyStatus struct {
+	Frontend bool // 前端依赖已安装
+	Backend  bool // 后端依赖已安装
+}
+
+// CleanResult 缓存清理结果
+type CleanResult struct {
+	SuccessCount int
+	FailCount    int
+	Errors       []string
+}
+
+// DependencyManager 管理一个项目的前后端依赖
+type DependencyManager struct {
+	// Hooks 事件钩子分发器（可为 nil）
+	Hooks *hooks.Dispatcher
+
+	project *Project
+}
+
+// NewDependencyManager 创建依赖管理器
+func NewDependencyManager(project *Project) *DependencyManager {
+	return &DependencyManager{project: project}
+}
+
+// Check 并发检查前后端依赖
+func (m *DependencyManager) Check() DependencyStatus {
+	var wg sync.WaitGroup
+	var status DependencyStatus
+
+	wg.Add(2)
+
+	// 任务1: 检查前端依赖
+	go func() {
+		defer wg.Done()
+		status.Frontend = deps.FrontendInstalled(m.project.WebDir())
+	}()
+
+	// 任务2: 检查后端依赖
+	go func() {
+		defer wg.Done()
+		status.Backend = deps.BackendInstalled(m.project.ServerDir())
+	}()
+
+	// 等待两个检查都完成
+	wg.Wait()
+	return status
+}
+
+// Install 安装缺失的依赖（npmRegistry/goProxy 为空时使用当前配置的源），完成后校验依赖的完整性（见 Verify）；
+// 网络中断时自动重试，重试说明写入 logf（可为 nil），ctx 取消时结束下载并返回 ctx.Err()
+func (m *DependencyManager) Install(ctx context.Context, npmRegistry, goProxy string, logf func(format string, args ...interface{})) error {
+	// 阶段1: 并发检查前后端依赖状态
+	status := m.Check()
+
+	// 阶段2: 并发安装前后端依赖
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errors []string
+	code := apperr.Unknown // 第一个失败的错误码（前后端都失败时以先完成的为准）
+
+	wg.Add(2)
+
+	// 任务1: 安装前端依赖
+	go func() {
+		defer wg.Done()
+		if !status.Frontend {
+			if err := deps.InstallFrontend(ctx, m.project.WebDir(), npmRegistry, logf); err != nil {
+				mu.Lock()
+				errors = append(errors, "前端: "+err.Error())
+				if code == apperr.Unknown {
+					code = apperr.CodeOf(err)
+				}
+				mu.Unlock()
+			}
+		}
+	}()
+
+	// 任务2: 安装后端依赖
+	go func() {
+		defer wg.Done()
+		if !status.Backend {
+			if err := deps.InstallBackend(ctx, m.project.ServerDir(), goProxy, logf); err != nil {
+				mu.Lock()
+				errors = append(errors, "后端: "+err.Error())
+				if code == apperr.Unknown {
+					code = apperr.CodeOf(err)
+				}
+				mu.Unlock()
+			}
+		}
+	}()
+
+	// 等待安装完成
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if len(errors) > 0 {
+		return apperr.Errorf(code, "安装失败:\n%s", strings.Join(errors, "\n"))
+	}
+	if err := m.Verify(ctx, logf); err != nil {
+		return err
+	}
+
+	m.Hooks.Fire(hooks.AfterInstall, m.project.HookVars())
+	return nil
+}
+
+// Verify 校验已安装依赖的完整性：后端执行 go mod verify，前端检查 node_modules 和 package.json 与 package-lock.json 一致；
+// 校验和不一致（镜像源返回了被篡改的内容或缓存损坏）时返回 DepIntegrityFailed 错误
+func (m *DependencyManager) Verify(ctx context.Context, logf func(format string, args ...interface{})) error {
+	if logf == nil {
+		logf = func(string, ...interface{}) {}
+	}
+
+	var problems []string
+	code := apperr.Unknown
+	logf("$ go mod verify")
+	if err := deps.VerifyBackend(ctx, m.project.ServerDir()); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		problems = append(problems, "后端: "+err.Error())
+		code = apperr.CodeOf(err)
+	}
+	logf("校验 node_modules 与 package-lock.json")
+	if err := deps.VerifyFrontend(m.project.WebDir()); err != nil {
+		problems = append(problems, "前端: "+err.Error())
+		if code == apperr.Unknown {
+			code = apperr.CodeOf(err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return apperr.Errorf(code, "依赖完整性校验未通过:\n%s", strings.Join(problems, "\n"))
+	}
+	logf("依赖完整性校验通过")
+	return nil
+}
+
+// CleanCache 并发清理前端 node_modules 和后端 Go 模块缓存；
+// bin 不为 nil 时移入回收站（可恢复，调用方负责 Commit），为 nil 时直接删除；
+// progress 不为 nil 时在清理每个 Go 模块前调用（done 为正在清理的序号）
+func (m *DependencyManager) CleanCache(bin *trash.Session, progress func(done, total int)) CleanResult {
+	var remove deps.RemoveFunc
+	if bin != nil {
+		remove = bin.Move
+	}
+	var moduleProgress func(current, total int, moduleName string)
+	if progress != nil {
+		moduleProgress = func(current, total int, _ string) { progress(current, total) }
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var result CleanResult
+
+	wg.Add(2)
+
+	// 任务1: 并发清理前端缓存
+	go func() {
+		defer wg.Done()
+		err := deps.CleanFrontendCache(m.project.WebDir(), remove)
+
+		mu.Lock()
+		if err != nil {
+			result.Errors = append(result.Errors, "前端: "+err.Error())
+			result.FailCount++
+		} else {
+			result.SuccessCount++
+		}
+		mu.Unlock()
+	}()
+
+	// 任务2: 并发清理后端缓存
+	go func() {
+		defer wg.Done()
+		successCount, failCount, err := deps.CleanBackendCache(m.project.ServerDir(), remove, moduleProgress)
+
+		mu.Lock()
+		result.SuccessCount += successCount
+		result.FailCount += failCount
+		if err != nil {
+			result.Errors = append(result.Errors, "后端: "+err.Error())
+		}
+		mu.Unlock()
+	}()
+
+	// 等待两个清理任务都完成
+	wg.Wait()
+	return result
+}
+
+// Audit 检查依赖是否完整并执行 npm audit，报告写入 w（依赖缺失或发现高危漏洞时返回错误）
+func (m *DependencyManager) Audit(w io.Writer) error {
+	status := m.Check()
+	fmt.Fprintf(w, "前端依赖已安装: %v\n后端依赖已安装: %v\n", status.Frontend, status.Backend)
+	if !status.Frontend || !status.Backend {
+		return fmt.Errorf("依赖不完整")
+	}
+
+	fmt.Fprintln(w, "$ npm audit --audit-level=high")
+	output, err := deps.AuditFrontend(m.project.WebDir())
+	io.WriteString(w, output)
+	return err
+}
+
+// PruneCache 回收 npm 缓存和 Go 编译缓存（与 CleanCache 不同，不删除 node_modules 和模块缓存）
+func (m *DependencyManager) PruneCache(w io.Writer) error {
+	var errs []string
+
+	fmt.Fprintln(w, "$ npm cache verify")
+	output, err := deps.PruneFrontendCache()
+	io.WriteString(w, output)
+	if err != nil {
+		errs = append(errs, "前端: "+err.Error())
+	}
+
+	fmt.Fprintln(w, "$ go clean -cache")
+	output, err = deps.PruneBackendCache()
+	io.WriteString(w, output)
+	if err != nil {
+		errs = append(errs, "后端: "+err.Error())
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("清理失败:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// Doctor 检查前端依赖的多版本、peer 冲突和 node_modules 损坏
+func (m *DependencyManager) Doctor() (deps.Diagnosis, error) {
+	return deps.Diagnose(m.project.WebDir())
+}
+
+// Fix 执行体检给出的修复操作，命令输出写入 w
+func (m *DependencyManager) Fix(fix deps.Fix, w io.Writer) error {
+	return deps.ApplyFix(m.project.WebDir(), fix, w)
+}
+
+// Workspace 后端模块所在的 Go 工作区（go.work）与本地替换的模块
+func (m *DependencyManager) Workspace() deps.Workspace {
+	return deps.DetectWorkspace(m.project.ServerDir())
+}
+
+// Replaces 后端 go.mod 中的 replace 指令
+func (m *DependencyManager) Replaces() ([]deps.Replace, error) {
+	return deps.ReadReplaces(m.project.ServerDir())
+}
+
+// AddLocalReplace 在后端 go.mod 中把模块替换为本地目录
+func (m *DependencyManager) AddLocalReplace(module, dir string) (deps.Replace, error) {
+	return deps.AddLocalReplace(m.project.ServerDir(), module, dir)
+}
+
+// DropReplace 删除后端 go.mod 中的 replace
+func (m *DependencyManager) DropReplace(r deps.Replace) error {
+	return deps.DropReplace(m.project.ServerDir(), r)
+}
@@ -0,0 +1,169 @@
+package launcher
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/crash"
+	"gva-launcher/outputbuf"
+	"gva-launcher/services"
+)
+
+// 后台运行（脱离面板）的服务：输出写入日志目录下的文件，进程号写入与配置文件同目录的 PID 文件，
+// 面板运行期间跟随日志文件把新输出转到服务输出中；下次启动面板时按 PID 文件重新连接
+const (
+	detachedPollInterval = 500 * time.Millisecond // 读取新输出、检查端口的间隔
+	detachedTailBytes    = 32 * 1024              // 重新连接时先显示的最近输出
+)
+
+// detached 是否以后台运行的方式启动服务
+func (m *ServiceManager) detached() bool {
+	return m.Detached != nil && m.Detached()
+}
+
+// runDetached 以后台运行的方式启动服务并阻塞到进程结束（面板先退出时进程继续运行，PID 文件保留）
+func (m *ServiceManager) runDetached(info *services.ServiceInfo, output *outputbuf.Buffer, service string, dir string, name string, args ...string) error {
+	logPath := config.DetachedLogPath(service)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return err
+	}
+	stop := followLog(logPath, fileSize(logPath), output)
+	defer stop()
+	return services.RunDetached(info, dir, logPath, config.PIDPath(service), name, args...)
+}
+
+// Reattach 重新连接上次退出面板时仍在后台运行的服务（PID 文件存在且端口仍被监听），返回重新连接的服务；
+// 进程已结束时删除遗留的 PID 文件
+func (m *ServiceManager) Reattach() []string {
+	var attached []string
+	for _, service := range []string{ServiceBackend, ServiceFrontend} {
+		pidPath := config.PIDPath(service)
+		pid := services.ReadPIDFile(pidPath)
+		if pid == 0 {
+			continue
+		}
+		port := m.servicePort(service)
+		if port <= 0 || !services.IsPortInUse(port) {
+			os.Remove(pidPath)
+			continue
+		}
+
+		info := m.info(service)
+		info.MarkStarted(port)
+		if proc, err := os.FindProcess(pid); err == nil {
+			info.SetProcess(proc)
+		}
+		m.stoppingFlag(service).Store(false)
+		m.markStarted(service)
+		m.Output(service).Println(fmt.Sprintf("===== %s 已重新连接到后台运行的%s（PID %d） =====", time.Now().Format(time.DateTime), ServiceLabel(service), pid))
+		go m.watchDetached(service, pid, port)
+		attached = append(attached, service)
+	}
+	if len(attached) > 0 {
+		m.Publish()
+	}
+	return attached
+}
+
+// watchDetached 跟随重新连接的服务的输出，直到端口不再被监听或被停止（不是本次面板启动的进程，无法等待其退出）
+func (m *ServiceManager) watchDetached(service string, pid, port int) {
+	defer crash.Recover("后台服务 " + service)
+	logPath := config.DetachedLogPath(service)
+	stop := followLog(logPath, tailOffset(logPath, detachedTailBytes), m.Output(service))
+	for services.IsPortInUse(port) && !m.stoppingFlag(service).Load() {
+		time.Sleep(detachedPollInterval)
+	}
+	stop()
+
+	pidPath := config.PIDPath(service)
+	if services.ReadPIDFile(pidPath) == pid {
+		os.Remove(pidPath)
+	}
+	m.info(service).Reset()
+	m.exited(service, fmt.Errorf("后台进程（PID %d）已不再监听端口 %d", pid, port))
+}
+
+// killDetached 停止服务时一并结束 PID 文件中记录的进程（go run / npm 的父进程，端口可能尚未监听）；
+// 只在该进程正是本服务启动或重新连接的进程时结束，避免误杀复用了进程号的其他进程
+func (m *ServiceManager) killDetached(service string) {
+	pid := services.ReadPIDFile(config.PIDPath(service))
+	if proc := m.info(service).Process(); pid > 0 && proc != nil && proc.Pid == pid {
+		services.KillProcess(pid)
+	}
+}
+
+// followLog 在后台把日志文件从 offset 起新写入的内容转写到 w，返回的 stop 读完剩余内容后停止跟随
+func followLog(path string, offset int64, w io.Writer) (stop func()) {
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(detachedPollInterval)
+		defer ticker.Stop()
+		for {
+			offset = copyFrom(path, offset, w)
+			select {
+			case <-quit:
+				copyFrom(path, offset, w)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		<-done
+	}
+}
+
+// copyFrom 把文件从 offset 起的内容写入 w，返回新的偏移（文件被截断时从头开始）
+func copyFrom(path string, offset int64, w io.Writer) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return offset
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset
+	}
+	n, _ := io.Copy(w, f)
+	return offset + n
+}
+
+// fileSize 文件大小（不存在时为 0）
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// tailOffset 最后 n 字节中第一个完整行的起始偏移（文件不足 n 字节时为 0）
+func tailOffset(path string, n int64) int64 {
+	size := fileSize(path)
+	if size <= n {
+		return 0
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	buf := make([]byte, n)
+	if _, err := f.ReadAt(buf, size-n); err != nil {
+		return size - n
+	}
+	if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+		return size - n + int64(i) + 1
+	}
+	return size - n
+}
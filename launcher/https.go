@@ -0,0 +1,57 @@
+package launcher
+
+import (
+	"net"
+	"os"
+
+	"gva-launcher/config"
+	"gva-launcher/devcert"
+	"gva-launcher/services"
+)
+
+// HTTPSHosts 本地 HTTPS 证书包含的域名和 IP：localhost、回环地址、局域网 IP、本机名以及 extra（空字符串忽略）
+func HTTPSHosts(extra ...string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if ip := services.GetLocalIP(); net.ParseIP(ip) != nil && ip != "127.0.0.1" {
+		hosts = append(hosts, ip)
+	}
+	if name, err := os.Hostname(); err == nil && name != "" && name != "localhost" {
+		hosts = append(hosts, name)
+	}
+	for _, name := range extra {
+		if name != "" {
+			hosts = append(hosts, name)
+		}
+	}
+	return hosts
+}
+
+// HTTPSEnabled 前端开发服务器是否已开启本地 HTTPS
+func (p *Project) HTTPSEnabled() bool {
+	return config.ViteHTTPSEnabled(p.Root)
+}
+
+// EnableHTTPS 为前端开发服务器开启本地 HTTPS：
+// 准备根证书（尚未信任时加入系统信任，可能弹出系统确认），签发 hosts 的证书并写入 vite 配置。
+// 后端仍使用 HTTP，浏览器通过 Vite 的 /api 代理访问后端，页面本身处于安全上下文中
+func (p *Project) EnableHTTPS(certDir string, hosts []string) error {
+	ca, _, err := devcert.LoadOrCreateCA(certDir)
+	if err != nil {
+		return err
+	}
+	if !devcert.Trusted(certDir) {
+		if err := devcert.Install(ca); err != nil {
+			return err
+		}
+	}
+	certPath, keyPath, err := ca.Issue(certDir, hosts)
+	if err != nil {
+		return err
+	}
+	return config.EnableViteHTTPS(p.Root, certPath, keyPath)
+}
+
+// DisableHTTPS 关闭前端开发服务器的本地 HTTPS（证书保留，重新开启时复用根证书）
+func (p *Project) DisableHTTPS() error {
+	return config.DisableViteHTTPS(p.Root)
+}
@@ -0,0 +1,60 @@
+package launcher
+
+import (
+	"context"
+	"time"
+
+	"gva-launcher/events"
+	"gva-launcher/idle"
+)
+
+// 空闲自动停止：服务运行期间定期检查访问情况，前后端端口上有已建立的连接（浏览器页面打开时 Vite 的热更新连接一直保持）
+// 或单端口代理转发过请求都算作活动；连续 IdleStop() 时间没有活动时停止前后端服务并发布 ServicesIdle 事件
+const idleCheckInterval = 30 * time.Second
+
+// idleStop 空闲多长时间后自动停止（0 表示不停止）
+func (m *ServiceManager) idleStop() time.Duration {
+	if m.IdleStop == nil {
+		return 0
+	}
+	return m.IdleStop()
+}
+
+// WatchIdle 持续检查服务是否空闲，直到 ctx 取消；activity 记录单端口代理转发的请求
+func (m *ServiceManager) WatchIdle(ctx context.Context, activity *idle.Tracker) {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		m.checkIdle(activity)
+	}
+}
+
+// checkIdle 检查一次，空闲时间达到设置时停止服务
+func (m *ServiceManager) checkIdle(activity *idle.Tracker) {
+	limit := m.idleStop()
+	if limit <= 0 || !m.IsRunning() {
+		// 未开启或服务没有运行时不计时，之后从启动时重新计算
+		activity.Touch()
+		return
+	}
+	backendPort, frontendPort := m.project.Ports()
+	if n, err := idle.Connections(backendPort, frontendPort); err != nil || n > 0 {
+		// 无法统计连接（例如没有 netstat）时不能判断是否有人在使用，不自动停止
+		activity.Touch()
+		return
+	}
+	if activity.Idle() < limit {
+		return
+	}
+
+	m.Stop()
+	activity.Touch()
+	if m.Events != nil {
+		m.Events.Publish(events.Event{Topic: events.ServicesIdle})
+	}
+}
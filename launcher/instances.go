@@ -0,0 +1,156 @@
+package launcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/instance"
+)
+
+// 多实例：当前项目之外同时管理的其他 GVA 项目（不同根目录、不同端口）。
+// 每个实例有自己的服务管理器、事件总线和项目锁，输出的溢出文件按项目分开保存；
+// 实例的服务随面板运行，不支持退出面板后保持运行和生产模式
+
+// Instance 同时管理的另一个项目
+type Instance struct {
+	Project  *Project
+	Services *ServiceManager
+	lock     *instance.Lock
+}
+
+// Name 实例的显示名称（根目录名）
+func (i *Instance) Name() string {
+	return filepath.Base(i.Project.Root)
+}
+
+// Close 停止实例的服务并释放项目锁
+func (i *Instance) Close() {
+	i.Services.Stop()
+	if i.lock != nil {
+		i.lock.Release()
+		i.lock = nil
+	}
+}
+
+// instanceLogDir 实例输出溢出文件所在目录（按根目录区分，同一项目每次相同）
+func instanceLogDir(root string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(root)))
+	return filepath.Join(config.LogDir(), "instances", hex.EncodeToString(sum[:6]))
+}
+
+// Instances 当前管理的其他实例（按添加顺序）
+type Instances struct {
+	mu   sync.Mutex
+	list []*Instance
+
+	// Setup 创建实例后、加入列表前调用，用于设置等待时间、优先级等与当前项目共用的选项（可为 nil）
+	Setup func(*Instance)
+}
+
+// Add 打开 root 作为新实例：根目录需是有效的 GVA 项目，不能是当前项目或已添加的实例，
+// 端口不能与当前项目或其他实例相同；项目正被其他用户使用时返回 PROJECT_LOCKED 错误
+func (s *Instances) Add(root string, current *Project) (*Instance, error) {
+	root = filepath.Clean(root)
+	project := NewProject(root)
+	if !project.IsValid() {
+		return nil, apperr.Errorf(apperr.ProjectNotSet, "%s 不是有效的 GVA 根目录（需要包含 server 和 web 目录）", root)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current != nil && current.IsSet() && config.SameRoot(current.Root, root) {
+		return nil, fmt.Errorf("%s 是当前项目，不需要添加为实例", root)
+	}
+	taken := map[int]string{}
+	if current != nil && current.IsSet() {
+		for _, port := range portsOf(current) {
+			taken[port] = "当前项目"
+		}
+	}
+	for _, other := range s.list {
+		if config.SameRoot(other.Project.Root, root) {
+			return nil, fmt.Errorf("实例 %s 已添加", root)
+		}
+		for _, port := range portsOf(other.Project) {
+			taken[port] = "实例 " + other.Name()
+		}
+	}
+	for _, port := range portsOf(project) {
+		if owner, ok := taken[port]; ok {
+			return nil, apperr.Errorf(apperr.PortInUse, "%s 的端口 %d 与%s相同，请先修改其中一个项目的端口", root, port, owner)
+		}
+	}
+
+	lock, holder, err := project.Lock()
+	if holder != nil {
+		return nil, project.LockedError(holder)
+	}
+	if err != nil {
+		// 根目录不可写等情况下不阻止使用，只是失去多用户保护（与当前项目相同）
+		lock = nil
+	}
+
+	services := newServiceManager(project, instanceLogDir(root))
+	services.Events = events.New()
+	inst := &Instance{Project: project, Services: services, lock: lock}
+	if s.Setup != nil {
+		s.Setup(inst)
+	}
+	s.list = append(s.list, inst)
+	return inst, nil
+}
+
+// portsOf 项目使用的端口（未读取到的端口不返回）
+func portsOf(p *Project) []int {
+	backendPort, frontendPort := p.Ports()
+	return config.ProjectPorts{BackendPort: backendPort, FrontendPort: frontendPort}.Ports()
+}
+
+// Remove 停止实例的服务、释放项目锁并从列表中移除
+func (s *Instances) Remove(inst *Instance) {
+	s.mu.Lock()
+	s.list = slices.DeleteFunc(s.list, func(i *Instance) bool { return i == inst })
+	s.mu.Unlock()
+	inst.Close()
+}
+
+// List 当前的实例
+func (s *Instances) List() []*Instance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.list)
+}
+
+// Roots 实例的根目录（保存到配置，下次打开面板时恢复）
+func (s *Instances) Roots() []string {
+	var roots []string
+	for _, inst := range s.List() {
+		roots = append(roots, inst.Project.Root)
+	}
+	return roots
+}
+
+// Refresh 按端口占用情况刷新所有实例的运行状态
+func (s *Instances) Refresh() {
+	for _, inst := range s.List() {
+		inst.Services.Refresh(inst.Project.Ports())
+	}
+}
+
+// CloseAll 停止所有实例的服务并释放项目锁（退出面板时调用）
+func (s *Instances) CloseAll() {
+	s.mu.Lock()
+	list := s.list
+	s.list = nil
+	s.mu.Unlock()
+	for _, inst := range list {
+		inst.Close()
+	}
+}
@@ -0,0 +1,87 @@
+package launcher
+
+import (
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestInstancesAdd(t *testing.T) {
+	sysutiltest.New(t) // 关闭实例时按端口结束进程
+	current := newTestProject(t, freePort(t), freePort(t))
+	other := newTestProject(t, freePort(t), freePort(t))
+	var setup []*Instance
+	s := &Instances{Setup: func(inst *Instance) { setup = append(setup, inst) }}
+	defer s.CloseAll()
+
+	if _, err := s.Add(t.TempDir(), current); apperr.CodeOf(err) != apperr.ProjectNotSet {
+		t.Errorf("无效目录: err = %v", err)
+	}
+	if _, err := s.Add(current.Root, current); err == nil {
+		t.Error("当前项目不应能添加为实例")
+	}
+
+	inst, err := s.Add(other.Root, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(setup) != 1 || setup[0] != inst {
+		t.Errorf("Setup 没有在添加时调用: %v", setup)
+	}
+	if inst.lock == nil {
+		t.Error("添加实例时没有锁定项目")
+	}
+	if _, err := s.Add(other.Root, current); err == nil {
+		t.Error("同一项目不应能重复添加")
+	}
+	if roots := s.Roots(); len(roots) != 1 || roots[0] != other.Root {
+		t.Errorf("Roots = %v", roots)
+	}
+}
+
+func TestInstancesAddPortConflict(t *testing.T) {
+	sysutiltest.New(t)
+	backendPort := freePort(t)
+	current := newTestProject(t, backendPort, freePort(t))
+	first := newTestProject(t, freePort(t), freePort(t))
+	s := &Instances{}
+	defer s.CloseAll()
+	if _, err := s.Add(first.Root, current); err != nil {
+		t.Fatal(err)
+	}
+
+	_, firstFrontend := first.Ports()
+	for _, conflict := range []*Project{
+		newTestProject(t, backendPort, freePort(t)),   // 与当前项目相同
+		newTestProject(t, freePort(t), firstFrontend), // 与已添加的实例相同
+	} {
+		if _, err := s.Add(conflict.Root, current); apperr.CodeOf(err) != apperr.PortInUse {
+			t.Errorf("%s: err = %v, want PORT_IN_USE", conflict.Root, err)
+		}
+	}
+	if len(s.List()) != 1 {
+		t.Errorf("端口冲突的项目不应加入列表: %d", len(s.List()))
+	}
+}
+
+func TestInstancesRemoveReleasesLock(t *testing.T) {
+	sysutiltest.New(t)
+	current := newTestProject(t, freePort(t), freePort(t))
+	other := newTestProject(t, freePort(t), freePort(t))
+	s := &Instances{}
+	defer s.CloseAll()
+
+	inst, err := s.Add(other.Root, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Remove(inst)
+	if len(s.List()) != 0 || inst.lock != nil {
+		t.Fatalf("移除后 list = %d, lock = %v", len(s.List()), inst.lock)
+	}
+	// 锁已释放，可以再次添加
+	if _, err := s.Add(other.Root, current); err != nil {
+		t.Errorf("移除后重新添加: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package launcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gva-launcher/crash"
+	"gva-launcher/services"
+)
+
+// Orphan 面板启动时发现的遗留进程：监听着项目端口、看起来是 GVA 开发服务，但不是本次面板启动或重新连接的
+// （通常是上次面板崩溃或被强制结束后留下的 go run / node 进程）
+type Orphan struct {
+	Service string // ServiceBackend 或 ServiceFrontend
+	Port    int
+	Process services.PortProcess
+}
+
+// String 例如 后端端口 8888: main (PID 4321)
+func (o Orphan) String() string {
+	return fmt.Sprintf("%s端口 %d: %s", ServiceLabel(o.Service), o.Port, o.Process)
+}
+
+// FindOrphans 查找监听前后端端口的遗留进程（已重新连接的后台服务除外）。
+// 只认 go run 编译出的 main、编译产物 gva-server 和前端的 node，其他程序占用端口时仍在启动时按端口冲突处理
+func (m *ServiceManager) FindOrphans() []Orphan {
+	var orphans []Orphan
+	for _, service := range []string{ServiceBackend, ServiceFrontend} {
+		port := m.servicePort(service)
+		if m.info(service).Process() != nil || port <= 0 || !services.IsPortInUse(port) {
+			continue
+		}
+		for _, proc := range services.PortProcesses(port) {
+			if m.devProcess(service, proc.Name) {
+				orphans = append(orphans, Orphan{Service: service, Port: port, Process: proc})
+			}
+		}
+	}
+	return orphans
+}
+
+// devProcess 进程名是否是该服务的开发进程（不区分大小写，忽略 .exe）
+func (m *ServiceManager) devProcess(service, name string) bool {
+	name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	if service == ServiceFrontend {
+		return name == "node"
+	}
+	binary := strings.TrimSuffix(strings.ToLower(filepath.Base(m.project.BackendBinary())), ".exe")
+	return name == "main" || name == binary
+}
+
+// Adopt 接管遗留进程：标记服务为运行并记录进程，之后照常显示状态、停止和重启（之前的输出无法取回）
+func (m *ServiceManager) Adopt(o Orphan) {
+	info := m.info(o.Service)
+	info.MarkStarted(o.Port)
+	if proc, err := os.FindProcess(o.Process.PID); err == nil {
+		info.SetProcess(proc)
+	}
+	m.stoppingFlag(o.Service).Store(false)
+	m.markStarted(o.Service)
+	m.markReady(o.Service)
+	m.Output(o.Service).Println(fmt.Sprintf("===== %s 已接管上次遗留的%s进程 %s，之前的输出无法显示 =====",
+		time.Now().Format(time.DateTime), ServiceLabel(o.Service), o.Process))
+	m.Publish()
+	go m.watchAdopted(o)
+}
+
+// watchAdopted 接管的进程不是本次面板启动的，无法等待其退出：端口不再被监听或服务被停止后按进程结束处理
+func (m *ServiceManager) watchAdopted(o Orphan) {
+	defer crash.Recover("接管的进程 " + o.Service)
+	for services.IsPortInUse(o.Port) && !m.stoppingFlag(o.Service).Load() {
+		time.Sleep(detachedPollInterval)
+	}
+	m.info(o.Service).Reset()
+	m.exited(o.Service, fmt.Errorf("接管的进程（PID %d）已不再监听端口 %d", o.Process.PID, o.Port))
+}
+
+// KillOrphans 结束遗留进程（包括子进程）并清理对应服务的状态
+func (m *ServiceManager) KillOrphans(orphans []Orphan) {
+	for _, o := range orphans {
+		services.KillProcess(o.Process.PID)
+		m.info(o.Service).Reset()
+	}
+	m.Publish()
+}
@@ -0,0 +1,109 @@
+package launcher
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/hooks"
+	"gva-launcher/internal/sysutil/sysutiltest"
+	"gva-launcher/services"
+)
+
+func TestDevProcess(t *testing.T) {
+	m := newServiceManager(newTestProject(t, freePort(t), freePort(t)), t.TempDir())
+	tests := []struct {
+		service string
+		name    string
+		want    bool
+	}{
+		{ServiceBackend, "main", true},
+		{ServiceBackend, "MAIN.EXE", true},
+		{ServiceBackend, "gva-server", true},
+		{ServiceBackend, "gva-server.exe", true},
+		{ServiceBackend, "node", false},
+		{ServiceBackend, "nginx", false},
+		{ServiceFrontend, "node", true},
+		{ServiceFrontend, "node.exe", true},
+		{ServiceFrontend, "main", false},
+	}
+	for _, tt := range tests {
+		if got := m.devProcess(tt.service, tt.name); got != tt.want {
+			t.Errorf("devProcess(%s, %s) = %v, want %v", tt.service, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindOrphans(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 通过 netstat 和 tasklist 查询进程")
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	fake := sysutiltest.New(t)
+	fake.Handle(fmt.Sprintf("lsof -i :%d -sTCP:LISTEN -Fpc", port), "p4321\ncmain\np4322\ncnginx\n", nil)
+	m := newServiceManager(newTestProject(t, port, freePort(t)), t.TempDir())
+
+	orphans := m.FindOrphans()
+	if len(orphans) != 1 || orphans[0].Service != ServiceBackend || orphans[0].Process.PID != 4321 {
+		t.Fatalf("orphans = %+v", orphans)
+	}
+
+	// 已重新连接的后台服务不算遗留进程
+	proc, _ := os.FindProcess(4321)
+	m.Backend.SetProcess(proc)
+	if orphans := m.FindOrphans(); len(orphans) != 0 {
+		t.Errorf("重新连接后 orphans = %+v", orphans)
+	}
+}
+
+func TestAdoptAndRelease(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	m := newServiceManager(newTestProject(t, port, freePort(t)), t.TempDir())
+	m.Hooks = hooks.NewDispatcher(nil, nil)
+
+	m.Adopt(Orphan{Service: ServiceBackend, Port: port, Process: services.PortProcess{PID: 4321, Name: "main"}})
+	if lines := m.BackendOutput.Lines(); len(lines) != 1 || !strings.Contains(lines[0], "已接管") {
+		t.Fatalf("接管后 output = %q", lines)
+	}
+
+	// 端口不再被监听后按进程意外结束处理
+	listener.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Hooks.Counts()[hooks.OnCrash] == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if m.Hooks.Counts()[hooks.OnCrash] != 1 {
+		t.Fatal("端口释放后没有按进程结束处理")
+	}
+	if m.Backend.IsRunning() {
+		t.Error("端口释放后仍标记为运行")
+	}
+}
+
+func TestKillOrphans(t *testing.T) {
+	fake := sysutiltest.New(t)
+	m := newServiceManager(newTestProject(t, freePort(t), freePort(t)), t.TempDir())
+	m.Frontend.MarkStarted(5173)
+
+	m.KillOrphans([]Orphan{{Service: ServiceFrontend, Port: 5173, Process: services.PortProcess{PID: 4321, Name: "node"}}})
+	if calls := fake.Calls(); len(calls) != 1 {
+		t.Errorf("calls = %+v", calls)
+	}
+	if m.Frontend.IsRunning() {
+		t.Error("结束遗留进程后仍标记为运行")
+	}
+}
@@ -0,0 +1,60 @@
+package launcher
+
+import (
+	"gva-launcher/config"
+	"gva-launcher/services"
+)
+
+// AllocatePorts 自动分配前后端端口并写入配置（需先停止服务，否则运行中的端口会被视为占用）：
+// 当前端口空闲且不是常用服务端口时保留，否则在 r 范围内按顺序挑选空闲端口（跳过 avoid，例如单端口代理的端口）。
+// 两个端口在一次事务中写入，changed 为 false 时配置未修改
+func (p *Project) AllocatePorts(r config.PortRange, avoid ...int) (backendPort, frontendPort int, changed bool, err error) {
+	oldBackend, oldFrontend := p.Ports()
+	skip := map[int]bool{}
+	for _, port := range avoid {
+		skip[port] = true
+	}
+	usable := func(port int) bool {
+		return port > 0 && !skip[port] && !services.Reserved(port) && !services.IsPortInUse(port)
+	}
+
+	if usable(oldBackend) {
+		backendPort = oldBackend
+		skip[backendPort] = true
+	}
+	if usable(oldFrontend) {
+		frontendPort = oldFrontend
+		skip[frontendPort] = true
+	}
+	if backendPort > 0 && frontendPort > 0 {
+		return backendPort, frontendPort, false, nil
+	}
+
+	need := 0
+	if backendPort == 0 {
+		need++
+	}
+	if frontendPort == 0 {
+		need++
+	}
+	taken := make([]int, 0, len(skip))
+	for port := range skip {
+		taken = append(taken, port)
+	}
+	start, end := r.Bounds()
+	ports, err := services.FindFreePorts(start, end, need, taken...)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if backendPort == 0 {
+		backendPort, ports = ports[0], ports[1:]
+	}
+	if frontendPort == 0 {
+		frontendPort = ports[0]
+	}
+
+	if err := config.WritePorts(p.Root, backendPort, frontendPort); err != nil {
+		return 0, 0, false, err
+	}
+	return backendPort, frontendPort, true, nil
+}
@@ -0,0 +1,193 @@
+package launcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/internal/sysutil"
+)
+
+// errStopWalk 提前结束遍历后端源码
+var errStopWalk = errors.New("stop walking sources")
+
+// backendCommand 启动后端的命令：低资源模式下优先运行预编译的后端（省去 go run 的编译和内存占用），
+// 编译模式下运行缓存目录中的编译产物（需要时先编译，编译失败返回错误），
+// 否则 go run main.go（Go 工作区模式下附加需要的参数）
+func (m *ServiceManager) backendCommand(serverDir string) (name string, args []string, err error) {
+	if m.PreferBinary != nil && m.PreferBinary() {
+		binary, reason := m.prebuiltBackend()
+		if binary != "" {
+			return binary, nil, nil
+		}
+		m.BackendOutput.Println("===== 低资源模式: " + reason + "，改用 go run（可通过定时任务「构建项目」生成预编译的后端） =====")
+	}
+	if m.CompiledRun != nil && m.CompiledRun() {
+		if m.project.WSLDistro() != "" {
+			m.BackendOutput.Println("===== 编译模式: 项目位于 WSL 中，编译产物无法从 Windows 直接运行，改用 go run =====")
+		} else {
+			binary, err := m.compiledBackend(serverDir)
+			return binary, nil, err
+		}
+	}
+	return "go", append(append([]string{"run"}, deps.GoBuildFlags(serverDir)...), "main.go"), nil
+}
+
+// stampSuffix 编译产物旁记录源码指纹的文件后缀
+const stampSuffix = ".stamp"
+
+// compiledBackend 编译模式：后端源码的指纹（见 sourceStamp）与上次编译时相同时直接使用缓存的编译产物，
+// 否则 go build -o 到缓存目录。直接运行编译产物比 go run 启动快，且面板记录的就是后端进程本身
+// （go run 会再启动一个子进程运行编译出的临时文件，结束 go run 不一定能结束后端）
+func (m *ServiceManager) compiledBackend(serverDir string) (string, error) {
+	binary := m.project.CompiledBackend()
+	flags := deps.GoBuildFlags(serverDir)
+	stamp := sourceStamp(serverDir, flags)
+	if saved, err := os.ReadFile(binary + stampSuffix); err == nil && string(saved) == stamp && sysutil.FileExists(binary) {
+		m.BackendOutput.Println("===== 编译模式: 源码没有变化，直接运行上次的编译产物 =====")
+		return binary, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(binary), 0755); err != nil {
+		return "", apperr.Errorf(apperr.BuildFailed, "创建编译缓存目录失败: %v", err)
+	}
+	os.Remove(binary + stampSuffix)
+	args := append(append([]string{"build"}, flags...), "-o", binary, ".")
+	m.BackendOutput.Println(fmt.Sprintf("===== 编译模式: 源码有变化，重新编译 go %s =====", strings.Join(args, " ")))
+	started := time.Now()
+	output, err := sysutil.CombinedOutputPriority(serverDir, nil, priorityOf(m.BuildPriority), "go", args...)
+	m.BackendOutput.Write(output)
+	if err != nil {
+		return "", apperr.Errorf(apperr.BuildFailed, "后端编译失败: %v", err)
+	}
+	m.BackendOutput.Println(fmt.Sprintf("===== 编译完成，用时 %.1f 秒 =====", time.Since(started).Seconds()))
+	// 指纹写入失败只影响下次是否重新编译
+	os.WriteFile(binary+stampSuffix, []byte(stamp), 0644)
+	return binary, nil
+}
+
+// sourceStamp 后端源码的指纹：所有 Go 源码文件（*.go、go.mod、go.sum，包括 go.work 中的其他模块和
+// 替换为本地路径的依赖，见 walkBackendSources）的相对路径、大小和修改时间，以及编译参数的哈希。
+// 文件被修改、新增或删除时指纹都会变化
+func sourceStamp(dir string, flags []string) string {
+	var lines []string
+	walkBackendSources(dir, func(rel string, info fs.FileInfo) bool {
+		lines = append(lines, fmt.Sprintf("%s|%d|%d", filepath.ToSlash(rel), info.Size(), info.ModTime().UnixNano()))
+		return true
+	})
+	sort.Strings(lines)
+	lines = append(lines, "flags|"+strings.Join(flags, " "))
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// prebuiltBackend 可以直接运行的预编译后端：存在且比后端源码（*.go、go.mod、go.sum，包括本地的依赖模块）都新时返回其路径，
+// 否则返回空字符串和原因
+func (m *ServiceManager) prebuiltBackend() (binary string, reason string) {
+	if m.project.WSLDistro() != "" {
+		return "", "项目位于 WSL 中，预编译的后端无法从 Windows 直接运行"
+	}
+	binary = m.project.BackendBinary()
+	info, err := os.Stat(binary)
+	if err != nil {
+		return "", "没有预编译的后端 " + filepath.Base(binary)
+	}
+	if newer := newerSource(m.project.ServerDir(), info.ModTime()); newer != "" {
+		return "", "源码 " + newer + " 在编译之后有修改"
+	}
+	return binary, ""
+}
+
+// newerSource 返回后端源码中第一个修改时间晚于 built 的 Go 源码文件（相对 dir 的路径），没有时返回空字符串
+func newerSource(dir string, built time.Time) string {
+	var newer string
+	walkBackendSources(dir, func(rel string, info fs.FileInfo) bool {
+		if info.ModTime().After(built) {
+			newer = rel
+			return false
+		}
+		return true
+	})
+	return newer
+}
+
+// walkBackendSources 遍历编译后端用到的本地源码：server 目录，生效的 go.work 和 go.work.sum，
+// go.work 中 use 的其他模块，以及 go.work / go.mod 中替换为本地路径的依赖；rel 为相对 dir 的路径
+func walkBackendSources(dir string, fn func(rel string, info fs.FileInfo) bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		walkSources(dir, fn)
+		return
+	}
+	visit := func(path string, info fs.FileInfo) bool {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+		return fn(rel, info)
+	}
+
+	w := deps.DetectWorkspace(dir)
+	if w.Active() {
+		for _, path := range []string{w.GoWork, w.GoWork + ".sum"} {
+			if info, err := os.Stat(path); err == nil && !visit(path, info) {
+				return
+			}
+		}
+	}
+	roots := []string{dir}
+	for _, m := range w.Local {
+		if !slices.Contains(roots, m.Dir) {
+			roots = append(roots, m.Dir)
+		}
+	}
+	for _, root := range roots {
+		stopped := false
+		walkSources(root, func(rel string, info fs.FileInfo) bool {
+			stopped = !visit(filepath.Join(root, rel), info)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// walkSources 遍历 dir 中的 Go 源码文件（*.go、go.mod、go.sum），fn 返回 false 时结束遍历；
+// 跳过隐藏目录和日志、上传文件等目录
+func walkSources(dir string, fn func(rel string, info fs.FileInfo) bool) {
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || name == "log" || name == "uploads" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") && name != "go.mod" && name != "go.sum" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if !fn(rel, info) {
+			return errStopWalk
+		}
+		return nil
+	})
+}
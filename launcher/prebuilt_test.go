@@ -0,0 +1,64 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSource 写入源码文件并把修改时间设为 modified
+func writeSource(t *testing.T, path, content string, modified time.Time) {
+	t.Helper()
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(path, modified, modified)
+}
+
+func TestSourceStampIncludesLocalModules(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := t.TempDir()
+	server := filepath.Join(root, "server")
+	old := time.Now().Add(-time.Hour)
+	writeSource(t, filepath.Join(server, "go.mod"), "module server\n\ngo 1.22\n\nreplace example.com/plugin => ../plugin\n", old)
+	writeSource(t, filepath.Join(server, "main.go"), "package main\n", old)
+	writeSource(t, filepath.Join(server, "log", "ignored.go"), "package log\n", old)
+	writeSource(t, filepath.Join(root, "plugin", "plugin.go"), "package plugin\n", old)
+
+	stamp := sourceStamp(server, nil)
+	if sourceStamp(server, nil) != stamp {
+		t.Fatal("源码没有变化时指纹应相同")
+	}
+	if sourceStamp(server, []string{"-mod=readonly"}) == stamp {
+		t.Error("编译参数变化时指纹应变化")
+	}
+	writeSource(t, filepath.Join(server, "log", "ignored.go"), "package log // changed\n", time.Now())
+	if sourceStamp(server, nil) != stamp {
+		t.Error("日志目录中的文件不影响指纹")
+	}
+
+	// replace 的本地目录中的修改
+	writeSource(t, filepath.Join(root, "plugin", "plugin.go"), "package plugin // changed\n", time.Now())
+	changed := sourceStamp(server, nil)
+	if changed == stamp {
+		t.Error("replace 的本地依赖有修改时指纹应变化")
+	}
+	if got := newerSource(server, old.Add(time.Minute)); got != filepath.Join("..", "plugin", "plugin.go") {
+		t.Errorf("newerSource = %q", got)
+	}
+
+	// go.work 及其中 use 的模块
+	writeSource(t, filepath.Join(root, "go.work"), "go 1.22\n\nuse (\n\t./server\n\t./lib\n)\n", old)
+	writeSource(t, filepath.Join(root, "lib", "go.mod"), "module example.com/lib\n", old)
+	writeSource(t, filepath.Join(root, "lib", "lib.go"), "package lib\n", old)
+	withWork := sourceStamp(server, nil)
+	if withWork == changed {
+		t.Error("新增 go.work 时指纹应变化")
+	}
+	writeSource(t, filepath.Join(root, "lib", "lib.go"), "package lib // changed\n", time.Now())
+	if sourceStamp(server, nil) == withWork {
+		t.Error("go.work 中其他模块有修改时指纹应变化")
+	}
+}
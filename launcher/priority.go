@@ -0,0 +1,13 @@
+package launcher
+
+import (
+	"gva-launcher/internal/sysutil"
+)
+
+// priorityOf 读取优先级设置（为 nil 时正常优先级）
+func priorityOf(setting func() sysutil.Priority) sysutil.Priority {
+	if setting == nil {
+		return sysutil.PriorityNormal
+	}
+	return setting()
+}
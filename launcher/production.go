@@ -0,0 +1,62 @@
+package launcher
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/hooks"
+	"gva-launcher/internal/sysutil"
+)
+
+// StaticDistDir 生产模式下前端构建产物的目录（server/dist）
+func (m *BuildManager) StaticDistDir() string {
+	return config.StaticDistDir(m.project.Root)
+}
+
+// BuildProductionMode 为生产模式准备项目：启用后端 router.go 中上游预留的静态文件路由，
+// 再把前端构建到 server/dist，之后只启动后端即可访问页面。成功后触发 after-build 钩子
+func (m *BuildManager) BuildProductionMode(w io.Writer) error {
+	if !m.project.IsValid() {
+		return apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效")
+	}
+	if err := config.EnableStaticRoutes(m.project.Root); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "已启用 server/initialize/router.go 中的静态文件路由")
+
+	env := m.productionModeEnv()
+	args := []string{"run", "build", "--", "--outDir", filepath.ToSlash(m.StaticDistDir()), "--emptyOutDir"}
+	fmt.Fprintf(w, "$ %s npm %s\n", strings.Join(env, " "), strings.Join(args, " "))
+	output, err := sysutil.CombinedOutputPriority(m.project.WebDir(), env, priorityOf(m.Priority), "npm", args...)
+	w.Write(output)
+	if err != nil {
+		return apperr.Errorf(apperr.BuildFailed, "前端构建失败: %v", err)
+	}
+	if !sysutil.FileExists(filepath.Join(m.StaticDistDir(), "index.html")) {
+		return apperr.Errorf(apperr.BuildFailed, "构建完成但 server/dist 中没有 index.html")
+	}
+
+	vars := m.project.HookVars()
+	vars["frontend_dist"] = m.StaticDistDir()
+	m.Hooks.Fire(hooks.AfterBuild, vars)
+	return nil
+}
+
+// productionModeEnv 生产模式构建前端时覆盖的变量（Vite 中已存在的环境变量优先于 .env 文件，不修改 .env.production）：
+// 页面和接口由同一个后端提供，接口前缀就是后端的路由前缀，文件地址指向本机的后端端口
+func (m *BuildManager) productionModeEnv() []string {
+	backendPort, _ := m.project.Ports()
+	prefix := ""
+	if gvaConfig, err := m.project.ReadConfig(); err == nil {
+		prefix = strings.TrimRight(gvaConfig.System.RouterPrefix, "/")
+	}
+	return []string{
+		"VITE_BASE_API=" + prefix,
+		"VITE_BASE_PATH=http://localhost",
+		fmt.Sprintf("VITE_SERVER_PORT=%d", backendPort),
+	}
+}
@@ -0,0 +1,160 @@
+// Package launcher 是 GVAPanel 的引擎入口，其他工具可以直接嵌入使用：
+//
+//	project := launcher.NewProject("D:/gin-vue-admin")
+//	services := launcher.NewServiceManager(project)
+//	services.Start()
+//
+// Project 描述一个 GVA 项目，ServiceManager 管理前后端进程，
+// DependencyManager 负责依赖检测、安装与缓存清理；两者都可以挂接 hooks.Dispatcher
+// 在启动、崩溃、安装完成等事件发生时执行用户脚本。
+package launcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/hooks"
+	"gva-launcher/instance"
+	"gva-launcher/internal/sysutil"
+)
+
+// Project 一个 GVA 项目（根目录下包含 server/ 和 web/）
+type Project struct {
+	Root string // GVA 根目录
+}
+
+// NewProject 创建项目
+func NewProject(root string) *Project {
+	return &Project{Root: root}
+}
+
+// ServerDir 后端目录
+func (p *Project) ServerDir() string {
+	return filepath.Join(p.Root, "server")
+}
+
+// WebDir 前端目录
+func (p *Project) WebDir() string {
+	return filepath.Join(p.Root, "web")
+}
+
+// BackendBinary 后端编译产物路径（server/gva-server，Windows 下带 .exe）
+func (p *Project) BackendBinary() string {
+	name := "gva-server"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(p.ServerDir(), name)
+}
+
+// CompiledBackend 编译模式下后端编译产物的路径（面板缓存目录下按项目区分，不写入项目目录）
+func (p *Project) CompiledBackend() string {
+	sum := sha256.Sum256([]byte(filepath.Clean(p.Root)))
+	dir := filepath.Base(filepath.Clean(p.Root)) + "-" + hex.EncodeToString(sum[:4])
+	return filepath.Join(config.BinCacheDir(), dir, filepath.Base(p.BackendBinary()))
+}
+
+// LockName 项目锁文件名（位于 GVA 根目录，记录正在管理该项目的面板；获取锁时加入 .git/info/exclude）
+const LockName = ".gvapanel.lock"
+
+// LockPath 项目锁文件路径
+func (p *Project) LockPath() string {
+	return filepath.Join(p.Root, LockName)
+}
+
+// Lock 获取项目锁，避免多个用户同时修改配置或重复启动服务
+// 项目正被其他用户（或其他主机）使用时返回持有者；同一用户的面板窗口和看守模式可以共用项目，
+// 此时 lock 和 holder 都为 nil
+func (p *Project) Lock() (lock *instance.Lock, holder *instance.Existing, err error) {
+	lock, existing, err := instance.AcquireShared(p.LockPath())
+	if existing != nil && !existing.SameUser() {
+		return nil, existing, nil
+	}
+	if lock != nil {
+		config.ExcludeFromGit(p.Root, LockName)
+	}
+	return lock, nil, err
+}
+
+// LockedError 项目被占用时提示持有者的错误
+func (p *Project) LockedError(holder *instance.Existing) error {
+	return apperr.Errorf(apperr.ProjectLocked, "项目 %s 正在被 %s 使用，请等待对方关闭面板后重试\n（确认对方已退出时，可删除锁文件 %s）",
+		p.Root, holder.Owner(), holder.Path())
+}
+
+// IsSet 是否已指定根目录
+func (p *Project) IsSet() bool {
+	return p.Root != ""
+}
+
+// IsValid 是否是有效的 GVA 项目（server/ 和 web/ 都存在）
+func (p *Project) IsValid() bool {
+	return p.IsSet() && sysutil.DirExists(p.ServerDir()) && sysutil.DirExists(p.WebDir())
+}
+
+// WSLDistro 项目所在的 WSL 发行版（根目录为 \\wsl$\<发行版>\... 形式；项目不在 WSL 中时为空）
+func (p *Project) WSLDistro() string {
+	distro, _, _ := sysutil.ParseWSLPath(p.Root)
+	return distro
+}
+
+// UseWSL 项目在 WSL 中时，让不指定目录的工具链命令（go env、npm -v 等）也在该发行版中执行；
+// 项目不在 WSL 中时恢复使用 Windows 的工具链。切换根目录后调用
+func (p *Project) UseWSL() {
+	sysutil.WSLDistro = p.WSLDistro()
+}
+
+// ConfigPath 后端 config.yaml 路径（未设置根目录时为空）
+func (p *Project) ConfigPath() string {
+	return config.GVAConfigPath(p.Root)
+}
+
+// ReadConfig 读取后端 config.yaml
+func (p *Project) ReadConfig() (*config.GVAConfig, error) {
+	return config.ReadGVAConfig(p.Root)
+}
+
+// Ports 读取前后端端口（未设置目录或读取失败时都返回 0）
+func (p *Project) Ports() (backendPort, frontendPort int) {
+	if !p.IsSet() {
+		return 0, 0
+	}
+
+	gvaConfig, err := p.ReadConfig()
+	if err != nil {
+		// 读取失败（选错目录）
+		return 0, 0
+	}
+
+	if gvaConfig.System.Addr > 0 {
+		backendPort = gvaConfig.System.Addr
+	}
+	return backendPort, config.ReadFrontendPort(p.Root)
+}
+
+// SetBackendPort 修改后端端口（写入 config.yaml 并同步前端代理端口）
+func (p *Project) SetBackendPort(port int) error {
+	return config.WriteBackendPort(p.Root, port)
+}
+
+// SetFrontendPort 修改前端端口（写入 .env / .env.development）
+func (p *Project) SetFrontendPort(port int) error {
+	return config.WriteFrontendPort(p.Root, port)
+}
+
+// HookVars 传给事件钩子的项目上下文（GVA_ROOT、GVA_SERVER_DIR、GVA_BACKEND_PORT 等）
+func (p *Project) HookVars() hooks.Vars {
+	backendPort, frontendPort := p.Ports()
+	return hooks.Vars{
+		"root":          p.Root,
+		"server_dir":    p.ServerDir(),
+		"web_dir":       p.WebDir(),
+		"backend_port":  strconv.Itoa(backendPort),
+		"frontend_port": strconv.Itoa(frontendPort),
+	}
+}
@@ -0,0 +1,36 @@
+package launcher
+
+import (
+	"sync"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/proxy"
+)
+
+// proxyTargetsTTL 单端口代理重新读取项目配置的间隔（避免每个请求都读取配置文件）
+const proxyTargetsTTL = time.Second
+
+// ProxyTargets 单端口代理的转发目标：当前的前后端端口、API 前缀和本地 HTTPS 设置（每秒最多读取一次）
+func (p *Project) ProxyTargets() func() proxy.Targets {
+	var mu sync.Mutex
+	var cached proxy.Targets
+	var readAt time.Time
+
+	return func() proxy.Targets {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(readAt) < proxyTargetsTTL {
+			return cached
+		}
+		backendPort, frontendPort := p.Ports()
+		cached = proxy.Targets{
+			FrontendPort:  frontendPort,
+			BackendPort:   backendPort,
+			FrontendHTTPS: p.HTTPSEnabled(),
+			APIPrefix:     config.ReadBaseAPI(p.Root),
+		}
+		readAt = time.Now()
+		return cached
+	}
+}
@@ -0,0 +1,93 @@
+package launcher
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"gva-launcher/apiroutes"
+	"gva-launcher/crash"
+	"gva-launcher/services"
+)
+
+// 服务就绪检测：启动后轮询，后端请求健康检查接口（{router-prefix}/health，旧版本 GVA 没有该接口时返回 404，
+// 收到任何 HTTP 响应都说明路由已注册完成），前端检测端口开始监听；进程提前退出或被停止时不再等待
+const (
+	readyPollInterval = 300 * time.Millisecond
+	readyProbeTimeout = time.Second
+	lateReadyInterval = 2 * time.Second // 就绪等待超时后继续检测的间隔
+)
+
+// backendProbe 后端是否就绪：健康检查接口有 HTTP 响应
+func (m *ServiceManager) backendProbe(port int) func() bool {
+	var prefix string
+	if cfg, err := m.project.ReadConfig(); err == nil {
+		prefix = cfg.System.RouterPrefix
+	}
+	url := apiroutes.BaseURL(port, prefix) + "/health"
+	client := &http.Client{Timeout: readyProbeTimeout}
+	return func() bool {
+		resp, err := client.Get(url)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return true
+	}
+}
+
+// portProbe 端口是否开始监听
+func portProbe(port int) func() bool {
+	return func() bool { return services.IsPortInUse(port) }
+}
+
+// waitReady 等待 probe 通过后标记服务已启动；进程结束（exited 关闭）、服务被停止或超过就绪等待时间时返回 false
+func (m *ServiceManager) waitReady(service string, port int, exited <-chan struct{}, probe func() bool) bool {
+	wait := m.timeouts().StartReady()
+	timeout := time.After(wait)
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+	for !probe() {
+		select {
+		case <-exited:
+			return false
+		case <-timeout:
+			// 进程仍在运行（例如首次编译很慢），在后台继续检测，就绪后再标记为运行
+			m.Output(service).Println(fmt.Sprintf("===== %s 等待%s就绪超时（%s），就绪后自动标记为运行 =====", time.Now().Format(time.DateTime), ServiceLabel(service), wait))
+			go m.waitLateReady(service, port, exited, probe)
+			return false
+		case <-ticker.C:
+		}
+		if m.stoppingFlag(service).Load() {
+			return false
+		}
+	}
+
+	m.markReady(service)
+	m.info(service).MarkStarted(port)
+	m.Publish()
+	return true
+}
+
+// waitLateReady 就绪等待超时后以较低的频率继续检测，直到 probe 通过（标记服务已启动）、进程结束或服务被停止
+func (m *ServiceManager) waitLateReady(service string, port int, exited <-chan struct{}, probe func() bool) {
+	defer crash.Recover("就绪检测 " + service)
+	ticker := time.NewTicker(lateReadyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exited:
+			return
+		case <-ticker.C:
+		}
+		if m.stoppingFlag(service).Load() {
+			return
+		}
+		if probe() {
+			m.markReady(service)
+			m.info(service).MarkStarted(port)
+			m.Publish()
+			return
+		}
+	}
+}
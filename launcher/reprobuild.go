@@ -0,0 +1,19 @@
+package launcher
+
+import (
+	"context"
+	"io"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/reprobuild"
+)
+
+// VerifyReproducible 以固定参数构建两次后端，验证构建是否可复现（见 reprobuild 包），
+// 不修改 server/gva-server，命令输出写入 w
+func (m *BuildManager) VerifyReproducible(ctx context.Context, w io.Writer) (reprobuild.Result, error) {
+	if !m.project.IsValid() {
+		return reprobuild.Result{}, apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效")
+	}
+	return reprobuild.Verify(ctx, m.project.ServerDir(), deps.GoBuildFlags(m.project.ServerDir()), priorityOf(m.Priority), w)
+}
@@ -0,0 +1,41 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/sbom"
+)
+
+// SBOM 生成项目的软件物料清单（见 sbom 包）
+func (m *BuildManager) SBOM(ctx context.Context) (sbom.Document, error) {
+	if !m.project.IsValid() {
+		return sbom.Document{}, apperr.Errorf(apperr.ProjectNotSet, "GVA 根目录无效")
+	}
+	doc, err := sbom.Generate(ctx, m.project.Root, deps.GoBuildFlags(m.project.ServerDir()))
+	doc.ToolVersion = Version
+	return doc, err
+}
+
+// writeSBOM 构建后把物料清单写入 dir，与构建产物放在一起，返回写入的文件；
+// 生成失败不影响构建，只把原因写入 w
+func (m *BuildManager) writeSBOM(ctx context.Context, w io.Writer, dir string) []string {
+	doc, err := m.SBOM(ctx)
+	var paths []string
+	if err == nil {
+		paths, err = doc.WriteFiles(dir)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "⚠️ 生成软件物料清单失败（不影响构建）: %v\n", err)
+		return nil
+	}
+	for _, warning := range doc.Warnings {
+		fmt.Fprintf(w, "⚠️ 软件物料清单不完整: %s\n", warning)
+	}
+	fmt.Fprintf(w, "已生成软件物料清单 %s 和 %s（%d 个组件）\n", filepath.Base(paths[0]), filepath.Base(paths[1]), len(doc.Components))
+	return paths
+}
@@ -0,0 +1,160 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/script"
+	"gva-launcher/services"
+)
+
+// ScriptUsage 脚本控制台中 launcher 函数的用法说明（与 ScriptFuncs 对应）
+var ScriptUsage = []string{
+	"root() — GVA 根目录",
+	"backend_port() / frontend_port() — 前后端端口",
+	"running() — 是否有服务在运行",
+	"start() / stop() — 启动 / 停止前后端服务",
+	"port_open(端口) — 端口是否在监听",
+	"wait_port(端口, timeout=60) — 等待端口开始监听，超时返回 False",
+	"deps_ok() — 前后端依赖是否都已安装",
+	"install() — 安装依赖（使用当前镜像源）",
+	"build() — 构建前后端",
+	"backup() — 备份配置，返回备份文件路径",
+	"demo_snapshot() / demo_reset() — 保存演示数据库快照 / 还原快照并重启服务",
+	"run(\"命令\") — 在根目录执行命令，返回输出",
+	"sleep(秒数) 以及 Starlark 自带的 print、len、range、fail 等",
+}
+
+// ScriptFuncs 脚本控制台可调用的 launcher 函数（构建命令的输出写入 out）
+func ScriptFuncs(project *Project, serviceManager *ServiceManager, depsManager *DependencyManager, buildManager *BuildManager, out io.Writer) script.Funcs {
+	noArgs := func(name string, fn func(ctx context.Context) (starlark.Value, error)) script.Func {
+		return func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(name, args, kwargs); err != nil {
+				return nil, err
+			}
+			return fn(ctx)
+		}
+	}
+	portArg := func(name string, port int) error {
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("%s: 端口无效: %d", name, port)
+		}
+		return nil
+	}
+	logf := func(format string, args ...interface{}) {
+		fmt.Fprintf(out, format+"\n", args...)
+	}
+
+	return script.Funcs{
+		"root": noArgs("root", func(context.Context) (starlark.Value, error) {
+			return starlark.String(project.Root), nil
+		}),
+		"backend_port": noArgs("backend_port", func(context.Context) (starlark.Value, error) {
+			backendPort, _ := project.Ports()
+			return starlark.MakeInt(backendPort), nil
+		}),
+		"frontend_port": noArgs("frontend_port", func(context.Context) (starlark.Value, error) {
+			_, frontendPort := project.Ports()
+			return starlark.MakeInt(frontendPort), nil
+		}),
+		"running": noArgs("running", func(context.Context) (starlark.Value, error) {
+			serviceManager.Refresh(project.Ports())
+			return starlark.Bool(serviceManager.IsRunning()), nil
+		}),
+		"start": noArgs("start", func(context.Context) (starlark.Value, error) {
+			if !project.IsValid() {
+				return nil, fmt.Errorf("start: GVA 根目录无效: %s", project.Root)
+			}
+			if err := serviceManager.CheckPorts(); err != nil {
+				return nil, fmt.Errorf("start: %w", err)
+			}
+			serviceManager.Start()
+			return starlark.None, nil
+		}),
+		"stop": noArgs("stop", func(context.Context) (starlark.Value, error) {
+			serviceManager.Stop()
+			return starlark.None, nil
+		}),
+		"port_open": func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var port int
+			if err := starlark.UnpackArgs("port_open", args, kwargs, "port", &port); err != nil {
+				return nil, err
+			}
+			if err := portArg("port_open", port); err != nil {
+				return nil, err
+			}
+			return starlark.Bool(services.IsPortInUse(port)), nil
+		},
+		"wait_port": func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var port int
+			seconds := 60
+			if err := starlark.UnpackArgs("wait_port", args, kwargs, "port", &port, "timeout?", &seconds); err != nil {
+				return nil, err
+			}
+			if err := portArg("wait_port", port); err != nil {
+				return nil, err
+			}
+			if seconds < 0 {
+				return nil, fmt.Errorf("wait_port: 秒数无效: %d", seconds)
+			}
+			deadline := time.Now().Add(time.Duration(seconds) * time.Second)
+			for {
+				if services.IsPortInUse(port) {
+					return starlark.True, nil
+				}
+				if time.Now().After(deadline) {
+					return starlark.False, nil
+				}
+				select {
+				case <-ctx.Done():
+					return nil, fmt.Errorf("已取消")
+				case <-time.After(500 * time.Millisecond):
+				}
+			}
+		},
+		"deps_ok": noArgs("deps_ok", func(context.Context) (starlark.Value, error) {
+			status := depsManager.Check()
+			return starlark.Bool(status.Frontend && status.Backend), nil
+		}),
+		"install": noArgs("install", func(ctx context.Context) (starlark.Value, error) {
+			return starlark.None, depsManager.Install(ctx, "", "", logf)
+		}),
+		"build": noArgs("build", func(context.Context) (starlark.Value, error) {
+			return starlark.None, buildManager.Build(out)
+		}),
+		"backup": noArgs("backup", func(context.Context) (starlark.Value, error) {
+			path, err := project.Backup(config.BackupDir())
+			return starlark.String(path), err
+		}),
+		"demo_snapshot": noArgs("demo_snapshot", func(context.Context) (starlark.Value, error) {
+			_, err := project.TakeDemoSnapshot()
+			return starlark.None, err
+		}),
+		"demo_reset": noArgs("demo_reset", func(ctx context.Context) (starlark.Value, error) {
+			return starlark.None, ResetDemo(ctx, project, serviceManager, logf)
+		}),
+		"run": func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var command string
+			if err := starlark.UnpackArgs("run", args, kwargs, "command", &command); err != nil {
+				return nil, err
+			}
+			command = strings.TrimSpace(command)
+			if command == "" {
+				return nil, fmt.Errorf("run: 命令为空")
+			}
+			name, shellArgs := sysutil.ShellCommand(command)
+			output, err := sysutil.Runner.CombinedOutputEnv(project.Root, project.HookVars().Env(), name, shellArgs...)
+			if err != nil {
+				return nil, fmt.Errorf("run: %v\n%s", err, strings.TrimSpace(string(output)))
+			}
+			return starlark.String(strings.TrimSpace(string(output))), nil
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/secretscan"
+)
+
+// releaseSecrets 查找会随前端产物发布的疑似密钥：dist 中的文件（按格式识别），
+// 以及 .env.production 中会被打包进前端代码的 VITE_ 变量
+func (m *BuildManager) releaseSecrets() []secretscan.Finding {
+	findings, _ := secretscan.ScanDir(m.DistDir())
+	if data, err := os.ReadFile(config.EnvProdPath(m.project.Root)); err == nil {
+		for _, f := range secretscan.Scan("web/.env.production", data, true) {
+			if strings.HasPrefix(f.Key, "VITE_") {
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// checkSecrets 发布前检查：发现疑似密钥时写入 w 并通过 ConfirmSecrets 请用户确认，
+// 没有确认（或没有设置 ConfirmSecrets）时返回 SecretsFound 错误
+func (m *BuildManager) checkSecrets(ctx context.Context, w io.Writer, findings []secretscan.Finding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "⚠️ 发布内容中有 %d 处疑似密钥:\n%s\n", len(findings), secretscan.Summary(findings, 20))
+	if m.ConfirmSecrets != nil && m.ConfirmSecrets(ctx, findings) {
+		fmt.Fprintln(w, "已确认继续发布")
+		return nil
+	}
+	return apperr.Errorf(apperr.SecretsFound, "发布内容中有 %d 处疑似密钥，已取消", len(findings))
+}
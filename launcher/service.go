@@ -0,0 +1,428 @@
+package launcher
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/crash"
+	"gva-launcher/events"
+	"gva-launcher/hooks"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/logrules"
+	"gva-launcher/outputbuf"
+	"gva-launcher/services"
+)
+
+// ServiceManager 管理一个项目的前后端服务进程
+type ServiceManager struct {
+	Backend  services.ServiceInfo
+	Frontend services.ServiceInfo
+
+	// BackendOutput / FrontendOutput 服务进程的输出（内存中只保留最近的部分，更早的输出在日志目录下的溢出文件中）
+	BackendOutput  *outputbuf.Buffer
+	FrontendOutput *outputbuf.Buffer
+
+	// Hooks 事件钩子分发器（可为 nil）
+	Hooks *hooks.Dispatcher
+
+	// Events 服务运行状态变化时发布 ServiceChanged 事件（可为 nil）
+	Events *events.Bus
+
+	// Timeouts 每次启动时读取最新的等待时间配置（为 nil 时使用默认值）
+	Timeouts func() config.Timeouts
+
+	// PreferBinary 为 true 时（低资源模式）优先运行预编译的后端，见 backendCommand（为 nil 时总是 go run）
+	PreferBinary func() bool
+
+	// CompiledRun 为 true 时后端先 go build 到面板缓存目录再运行，源码没有变化时直接运行上次的编译产物，
+	// 见 compiledBackend（为 nil 时 go run）
+	CompiledRun func() bool
+
+	// AutoRestart 为 true 时服务意外退出后按退避时间自动重启，见 autorestart.go（为 nil 时不重启）
+	AutoRestart func() bool
+
+	// Detached 为 true 时服务脱离面板运行，退出面板后继续运行，见 detached.go（为 nil 时随面板运行）
+	Detached func() bool
+
+	// ProductionMode 为 true 时（生产模式）Start 只启动后端，页面由后端从 server/dist 提供，见 production.go
+	ProductionMode func() bool
+
+	// Priority 服务进程的优先级，BuildPriority 编译模式下编译后端的优先级（为 nil 时正常优先级），见 priority.go
+	Priority      func() sysutil.Priority
+	BuildPriority func() sysutil.Priority
+
+	// IdleStop 连续多长时间没有访问时自动停止服务，见 idlestop.go（为 nil 或 0 时不停止）
+	IdleStop func() time.Duration
+
+	project *Project
+	// backendStopping / frontendStopping 正在主动停止（进程退出不视为崩溃），前后端分别记录，单独停止一个服务不影响另一个
+	backendStopping  atomic.Bool
+	frontendStopping atomic.Bool
+	restarts         restarts
+	attempts         startAttempts
+}
+
+// 服务名称（钩子变量 service 的值）
+const (
+	ServiceBackend  = "backend"
+	ServiceFrontend = "frontend"
+)
+
+// ServiceLabel 服务的中文名称
+func ServiceLabel(service string) string {
+	if service == ServiceBackend {
+		return "后端"
+	}
+	return "前端"
+}
+
+// NewServiceManager 创建服务管理器
+func NewServiceManager(project *Project) *ServiceManager {
+	return newServiceManager(project, config.LogDir())
+}
+
+// newServiceManager 创建服务管理器，输出的溢出文件保存在 logDir 下
+func newServiceManager(project *Project, logDir string) *ServiceManager {
+	return &ServiceManager{
+		BackendOutput:  outputbuf.New(0, 0, filepath.Join(logDir, "backend-output.log")),
+		FrontendOutput: outputbuf.New(0, 0, filepath.Join(logDir, "frontend-output.log")),
+		project:        project,
+	}
+}
+
+// IsRunning 是否有任一服务在运行
+func (m *ServiceManager) IsRunning() bool {
+	return m.Backend.IsRunning() || m.Frontend.IsRunning()
+}
+
+// Start 启动前后端服务：后端就绪后再启动前端，阻塞到前端就绪（生产模式下只启动后端）
+// before-start 钩子执行完成后才启动服务（钩子失败只记录日志，不阻止启动）
+func (m *ServiceManager) Start() {
+	backendPort, frontendPort := m.project.Ports()
+	m.resetRestarts(ServiceBackend)
+	m.resetRestarts(ServiceFrontend)
+
+	m.Hooks.FireAndWait(hooks.BeforeStart, m.project.HookVars())
+
+	ready := m.StartBackend(backendPort)
+	switch {
+	case m.productionMode():
+	case ready:
+		m.StartFrontend(frontendPort)
+	default:
+		m.FrontendOutput.Println(fmt.Sprintf("===== %s 后端没有就绪，未启动前端 =====", time.Now().Format(time.DateTime)))
+	}
+
+	m.Hooks.Fire(hooks.AfterStart, m.project.HookVars())
+}
+
+// PortConflict 启动前发现被占用的端口
+type PortConflict struct {
+	Service string // ServiceBackend 或 ServiceFrontend
+	Port    int
+}
+
+// PortConflicts 启动前检查前后端端口，返回被占用的端口（生产模式下不检查前端）
+func (m *ServiceManager) PortConflicts() []PortConflict {
+	backendPort, frontendPort := m.project.Ports()
+	if m.productionMode() {
+		frontendPort = 0
+	}
+	var conflicts []PortConflict
+	for _, c := range []PortConflict{{ServiceBackend, backendPort}, {ServiceFrontend, frontendPort}} {
+		if c.Port > 0 && services.IsPortInUse(c.Port) {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// CheckPorts 启动前检查前后端端口是否空闲（被占用时返回 PORT_IN_USE 错误）
+func (m *ServiceManager) CheckPorts() error {
+	if conflicts := m.PortConflicts(); len(conflicts) > 0 {
+		return services.CheckPortFree(conflicts[0].Port)
+	}
+	return nil
+}
+
+// productionMode 是否为生产模式
+func (m *ServiceManager) productionMode() bool {
+	return m.ProductionMode != nil && m.ProductionMode()
+}
+
+// timeouts 当前的等待时间配置
+func (m *ServiceManager) timeouts() config.Timeouts {
+	if m.Timeouts == nil {
+		return config.Timeouts{}
+	}
+	return m.Timeouts()
+}
+
+// StartBackend 启动后端服务（go run main.go；编译模式下先编译再运行，低资源模式下优先运行预编译的后端），
+// 阻塞到健康检查接口有响应后标记为运行，返回是否就绪；等待中的自动重启随之取消
+func (m *ServiceManager) StartBackend(port int) bool {
+	m.cancelRestart(ServiceBackend)
+	return m.startBackend(port)
+}
+
+// startBackend StartBackend 的实现（自动重启时也使用，不取消自己所在的重启）
+func (m *ServiceManager) startBackend(port int) bool {
+	m.backendStopping.Store(false)
+	serverDir := m.project.ServerDir()
+	exited := m.start(&m.Backend, m.BackendOutput, ServiceBackend, serverDir, func() (string, []string, error) {
+		return m.backendCommand(serverDir)
+	})
+	return m.waitReady(ServiceBackend, port, exited, m.backendProbe(port))
+}
+
+// StartFrontend 启动前端服务（npm run serve），阻塞到端口开始监听后标记为运行，返回是否就绪；等待中的自动重启随之取消
+func (m *ServiceManager) StartFrontend(port int) bool {
+	m.cancelRestart(ServiceFrontend)
+	return m.startFrontend(port)
+}
+
+// startFrontend StartFrontend 的实现（自动重启时也使用）
+func (m *ServiceManager) startFrontend(port int) bool {
+	m.frontendStopping.Store(false)
+	exited := m.start(&m.Frontend, m.FrontendOutput, ServiceFrontend, m.project.WebDir(), func() (string, []string, error) {
+		return "npm", []string{"run", "serve"}, nil
+	})
+	return m.waitReady(ServiceFrontend, port, exited, portProbe(port))
+}
+
+// frontendEnv 前端进程额外的环境变量：输出不是终端时 Vite 默认不带颜色，强制开启后由输出面板按颜色显示
+var frontendEnv = []string{"FORCE_COLOR=1"}
+
+// serviceEnv 服务进程额外的环境变量（脱离面板运行时不追加）
+func serviceEnv(service string) []string {
+	if service == ServiceFrontend {
+		return frontendEnv
+	}
+	return nil
+}
+
+// start 在后台运行服务进程，返回的通道在 run 结束（进程退出或启动失败）时关闭
+func (m *ServiceManager) start(info *services.ServiceInfo, output *outputbuf.Buffer, service string, dir string, command func() (string, []string, error)) <-chan struct{} {
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		m.run(info, output, service, dir, command)
+	}()
+	return exited
+}
+
+// run 运行服务进程并把输出写入 output，进程不是由 Stop 结束时触发 on-crash 钩子。
+// command 返回要执行的命令（编译模式下先编译后端，编译失败与进程意外退出一样处理）
+func (m *ServiceManager) run(info *services.ServiceInfo, output *outputbuf.Buffer, service string, dir string, command func() (string, []string, error)) {
+	defer crash.Recover("服务进程 " + service)
+	m.markStarted(service)
+	m.beginStart(service)
+
+	// 每次启动和结束写一行分隔，多次运行的输出保存在同一个缓冲中
+	name, args, err := command()
+	if err == nil && m.stoppingFlag(service).Load() {
+		// 编译期间已被停止
+		output.Println(fmt.Sprintf("===== %s 已停止，取消启动 =====", time.Now().Format(time.DateTime)))
+		return
+	}
+	if err == nil {
+		output.Println(fmt.Sprintf("===== %s 启动: %s %s =====", time.Now().Format(time.DateTime), name, strings.Join(args, " ")))
+		info.SetPriority(priorityOf(m.Priority))
+		if m.detached() {
+			err = m.runDetached(info, output, service, dir, name, args...)
+		} else {
+			err = services.RunOutputEnv(info, dir, serviceEnv(service), output, name, args...)
+		}
+	}
+	m.exited(service, err)
+}
+
+// exited 服务进程结束后的处理：写入结束分隔行，不是由 Stop 结束时触发 on-crash 钩子并安排自动重启
+func (m *ServiceManager) exited(service string, err error) {
+	output := m.Output(service)
+	ended := "进程已结束"
+	if err != nil {
+		ended += ": " + err.Error()
+	}
+	output.Println(fmt.Sprintf("===== %s %s =====", time.Now().Format(time.DateTime), ended))
+	exit := events.ExitState{Service: service}
+	if err != nil {
+		exit.Error = err.Error()
+	}
+	// 就绪前退出时先记录启动失败的原因，状态栏随下面的状态事件一起显示
+	m.endStart(service, err, &exit)
+	m.Publish()
+	if m.stoppingFlag(service).Load() {
+		return
+	}
+
+	vars := m.project.HookVars()
+	vars["service"] = service
+	if err != nil {
+		vars["exit_error"] = err.Error()
+	}
+	m.Hooks.Fire(hooks.OnCrash, vars)
+	if m.Events != nil {
+		m.Events.Publish(events.Event{Topic: events.ServiceExited, Exit: exit})
+	}
+	m.scheduleRestart(service)
+}
+
+// diagnoseLines 识别问题时读取的输出行数（最近的部分）
+const diagnoseLines = 500
+
+// Diagnose 用内置规则识别服务最近输出中的常见问题（Redis 连接失败、MySQL 1045、端口被占用等）
+func (m *ServiceManager) Diagnose(service string) []logrules.Issue {
+	return logrules.Classify(service, m.Output(service).Tail(diagnoseLines))
+}
+
+// StopPorts 通过端口杀死进程（比记录的进程更可靠）并清理服务状态
+func (m *ServiceManager) StopPorts(backendPort, frontendPort int) {
+	m.stopPort(ServiceBackend, backendPort)
+	m.stopPort(ServiceFrontend, frontendPort)
+	m.Publish()
+}
+
+// stopPort 结束一个服务端口上的进程并清理该服务的状态
+func (m *ServiceManager) stopPort(service string, port int) {
+	m.stoppingFlag(service).Store(true)
+	m.resetRestarts(service)
+	if port > 0 {
+		services.KillProcessByPort(port)
+	}
+	m.killDetached(service)
+	m.info(service).Reset()
+}
+
+// info 服务的状态
+func (m *ServiceManager) info(service string) *services.ServiceInfo {
+	if service == ServiceBackend {
+		return &m.Backend
+	}
+	return &m.Frontend
+}
+
+// Output 服务进程的输出（界面的日志面板从中读取）
+func (m *ServiceManager) Output(service string) *outputbuf.Buffer {
+	if service == ServiceBackend {
+		return m.BackendOutput
+	}
+	return m.FrontendOutput
+}
+
+// stoppingFlag 服务的主动停止标记
+func (m *ServiceManager) stoppingFlag(service string) *atomic.Bool {
+	if service == ServiceBackend {
+		return &m.backendStopping
+	}
+	return &m.frontendStopping
+}
+
+// servicePort 服务在当前项目中的端口
+func (m *ServiceManager) servicePort(service string) int {
+	backendPort, frontendPort := m.project.Ports()
+	if service == ServiceBackend {
+		return backendPort
+	}
+	return frontendPort
+}
+
+// StartService 单独启动后端或前端（阻塞到服务标记为启动），端口被占用时返回 PORT_IN_USE 错误。
+// 与 Start 一样执行 before-start / after-start 钩子，钩子变量 service 为启动的服务
+func (m *ServiceManager) StartService(service string) error {
+	m.resetRestarts(service)
+	return m.startService(service)
+}
+
+// startService StartService 的实现（自动重启时也使用，不清零重启次数）
+func (m *ServiceManager) startService(service string) error {
+	port := m.servicePort(service)
+	if port > 0 {
+		if err := services.CheckPortFree(port); err != nil {
+			return err
+		}
+	}
+
+	vars := m.project.HookVars()
+	vars["service"] = service
+	m.Hooks.FireAndWait(hooks.BeforeStart, vars)
+	if service == ServiceBackend {
+		m.startBackend(port)
+	} else {
+		m.startFrontend(port)
+	}
+	m.Hooks.Fire(hooks.AfterStart, vars)
+	return nil
+}
+
+// StopService 单独停止后端或前端，另一个服务继续运行
+func (m *ServiceManager) StopService(service string) {
+	m.stopPort(service, m.servicePort(service))
+	m.Publish()
+}
+
+// RestartService 重启后端或前端：停止后等待端口释放（最长为停止等待时间）再启动，
+// 例如修改后端代码后只重启后端，前端的 Vite 开发服务器保持运行
+func (m *ServiceManager) RestartService(service string) error {
+	m.StopService(service)
+	port := m.servicePort(service)
+	deadline := time.Now().Add(m.timeouts().StopWait())
+	for port > 0 && services.IsPortInUse(port) && time.Now().Before(deadline) {
+		time.Sleep(200 * time.Millisecond)
+	}
+	return m.StartService(service)
+}
+
+// Stop 停止当前项目端口上的服务
+func (m *ServiceManager) Stop() {
+	m.StopPorts(m.project.Ports())
+}
+
+// Refresh 根据端口占用情况刷新运行状态（状态有变化时发布事件）
+func (m *ServiceManager) Refresh(backendPort, frontendPort int) {
+	backendRunning := services.IsPortInUse(backendPort)
+	frontendRunning := services.IsPortInUse(frontendPort)
+	backendChanged := m.Backend.SetRunning(backendRunning)
+	frontendChanged := m.Frontend.SetRunning(frontendRunning)
+	// 就绪等待超时后端口才开始监听的服务同样视为已就绪
+	if backendRunning {
+		m.markReady(ServiceBackend)
+	}
+	if frontendRunning {
+		m.markReady(ServiceFrontend)
+	}
+	if backendChanged || frontendChanged {
+		m.Publish()
+	}
+}
+
+// State 当前服务状态快照
+func (m *ServiceManager) State() events.ServiceState {
+	backendPort, frontendPort := m.project.Ports()
+	return events.ServiceState{
+		BackendRunning:   m.Backend.IsRunning(),
+		FrontendRunning:  m.Frontend.IsRunning(),
+		BackendPort:      backendPort,
+		FrontendPort:     frontendPort,
+		BackendRestarts:  m.Restarts(ServiceBackend),
+		FrontendRestarts: m.Restarts(ServiceFrontend),
+		BackendPriority:  string(m.Backend.Priority()),
+		FrontendPriority: string(m.Frontend.Priority()),
+		BackendFailure:   m.StartFailure(ServiceBackend),
+		FrontendFailure:  m.StartFailure(ServiceFrontend),
+		BackendStarted:   m.Backend.StartTime(),
+		FrontendStarted:  m.Frontend.StartTime(),
+	}
+}
+
+// Publish 发布当前服务状态（端口或根目录变化后由调用方通知订阅者刷新）
+func (m *ServiceManager) Publish() {
+	if m.Events == nil {
+		return
+	}
+	m.Events.Publish(events.Event{Topic: events.ServiceChanged, Service: m.State()})
+}
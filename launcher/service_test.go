@@ -0,0 +1,88 @@
+package launcher
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/hooks"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+// newTestProject 创建只包含端口配置的 GVA 项目
+func newTestProject(t *testing.T, backendPort, frontendPort int) *Project {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		filepath.Join(root, "server", "config.yaml"):   fmt.Sprintf("system:\n  addr: %d\n", backendPort),
+		filepath.Join(root, "web", ".env.development"): fmt.Sprintf("VITE_CLI_PORT=%d\n", frontendPort),
+	}
+	for path, content := range files {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewProject(root)
+}
+
+// freePort 取得一个当前空闲的端口
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestStartServicePortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	m := newServiceManager(newTestProject(t, port, freePort(t)), t.TempDir())
+	m.Hooks = hooks.NewDispatcher(nil, nil)
+	if err := m.StartService(ServiceBackend); apperr.CodeOf(err) != apperr.PortInUse {
+		t.Fatalf("err = %v, want PORT_IN_USE", err)
+	}
+	if recent := m.Hooks.Recent(); len(recent) != 0 {
+		t.Errorf("端口被占用时不应触发钩子: %+v", recent)
+	}
+	if m.Backend.IsRunning() {
+		t.Error("端口被占用时不应标记为运行")
+	}
+}
+
+func TestStopServiceKeepsOtherService(t *testing.T) {
+	fake := sysutiltest.New(t)
+	backendPort, frontendPort := freePort(t), freePort(t)
+	m := newServiceManager(newTestProject(t, backendPort, frontendPort), t.TempDir())
+	m.Backend.MarkStarted(backendPort)
+	m.Frontend.MarkStarted(frontendPort)
+
+	m.StopService(ServiceFrontend)
+
+	if m.Frontend.IsRunning() || !m.Backend.IsRunning() {
+		t.Errorf("backend running = %v, frontend running = %v", m.Backend.IsRunning(), m.Frontend.IsRunning())
+	}
+	if !m.frontendStopping.Load() || m.backendStopping.Load() {
+		t.Errorf("backend stopping = %v, frontend stopping = %v", m.backendStopping.Load(), m.frontendStopping.Load())
+	}
+	for _, call := range fake.Calls() {
+		if strings.Contains(call.Command, fmt.Sprintf(":%d", backendPort)) {
+			t.Errorf("停止前端时不应结束后端端口上的进程: %s", call.Command)
+		}
+	}
+	if len(fake.Calls()) == 0 {
+		t.Error("没有结束前端端口上的进程")
+	}
+}
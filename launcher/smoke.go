@@ -0,0 +1,41 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gva-launcher/apiroutes"
+	"gva-launcher/config"
+	"gva-launcher/smoketest"
+)
+
+// SmokeTargets 冒烟测试的地址：后端为 API 根地址（含 config.yaml 的 router-prefix），
+// 前端开启 HTTPS 时使用 https
+func (p *Project) SmokeTargets() smoketest.Targets {
+	backendPort, frontendPort := p.Ports()
+	var prefix string
+	if cfg, err := p.ReadConfig(); err == nil {
+		prefix = cfg.System.RouterPrefix
+	}
+	scheme := "http"
+	if p.HTTPSEnabled() {
+		scheme = "https"
+	}
+	return smoketest.Targets{
+		BackendURL:  apiroutes.BaseURL(backendPort, prefix),
+		FrontendURL: fmt.Sprintf("%s://127.0.0.1:%d", scheme, frontendPort),
+	}
+}
+
+// SmokeTest 执行冒烟测试，每项检查最多等待 Timeouts 中的冒烟测试时长。
+// 生产模式下首页由后端提供，没有 Vite 的热更新 WebSocket
+func (m *ServiceManager) SmokeTest(ctx context.Context, cfg config.SmokeTest) []smoketest.Result {
+	targets := m.project.SmokeTargets()
+	if m.productionMode() {
+		backendPort, _ := m.project.Ports()
+		targets.FrontendURL = fmt.Sprintf("http://127.0.0.1:%d", backendPort)
+		cfg.Skip = append(slices.Clone(cfg.Skip), smoketest.CheckWebSocket)
+	}
+	return smoketest.Run(ctx, smoketest.Checks(targets, cfg), m.timeouts().SmokeTest())
+}
@@ -0,0 +1,119 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"unicode/utf8"
+
+	"gva-launcher/events"
+	"gva-launcher/logrules"
+)
+
+// 启动失败：进程在就绪前退出（go run 编译失败、npm run serve 立即退出等）时，记录退出码和本次启动输出中的报错，
+// 状态栏显示「启动失败」及原因，并随 ServiceExited 事件通知界面
+const (
+	failureLines      = 8   // 说明中最多列出的报错行
+	failureScanLines  = 500 // 查找报错时读取的本次启动输出
+	failureSummaryLen = 80  // 状态栏中原因摘要的最大长度（字符）
+)
+
+// startAttempt 服务最近一次启动的情况
+type startAttempt struct {
+	starting  bool   // 进程已启动，尚未就绪
+	firstLine int    // 启动时输出缓冲已写入的行数，之后的行属于本次启动
+	failure   string // 启动失败的原因摘要（再次启动时清空）
+}
+
+// startAttempts 各服务最近一次启动的情况
+type startAttempts struct {
+	mu       sync.Mutex
+	services map[string]*startAttempt
+}
+
+// state 服务的启动情况（调用方持有锁）
+func (a *startAttempts) state(service string) *startAttempt {
+	if a.services == nil {
+		a.services = make(map[string]*startAttempt)
+	}
+	s, ok := a.services[service]
+	if !ok {
+		s = &startAttempt{}
+		a.services[service] = s
+	}
+	return s
+}
+
+// beginStart 开始一次启动：清除上次的失败原因，记录本次输出的起点
+func (m *ServiceManager) beginStart(service string) {
+	total, _, _ := m.Output(service).Stats()
+	m.attempts.mu.Lock()
+	*m.attempts.state(service) = startAttempt{starting: true, firstLine: total}
+	m.attempts.mu.Unlock()
+}
+
+// markReady 服务已就绪（就绪检测通过，或等待超时后由状态监控发现端口开始监听），之后退出不再视为启动失败
+func (m *ServiceManager) markReady(service string) {
+	m.attempts.mu.Lock()
+	m.attempts.state(service).starting = false
+	m.attempts.mu.Unlock()
+}
+
+// StartFailure 服务上次启动失败的原因摘要（没有失败或已再次启动时为空）
+func (m *ServiceManager) StartFailure(service string) string {
+	m.attempts.mu.Lock()
+	defer m.attempts.mu.Unlock()
+	return m.attempts.state(service).failure
+}
+
+// endStart 进程结束时结束本次启动；就绪前退出且不是被停止时记录启动失败，在 exit 中补充退出码和报错行
+func (m *ServiceManager) endStart(service string, err error, exit *events.ExitState) bool {
+	m.attempts.mu.Lock()
+	s := m.attempts.state(service)
+	starting, firstLine := s.starting, s.firstLine
+	s.starting = false
+	m.attempts.mu.Unlock()
+	if !starting || m.stoppingFlag(service).Load() {
+		return false
+	}
+
+	output := m.Output(service)
+	total, _, _ := output.Stats()
+	// 输出的行数清空后继续递增，启动期间清空过输出时 Tail 只返回清空后的行
+	scan := min(total-firstLine, failureScanLines)
+	exit.Starting = true
+	exit.ExitCode = exitCode(err)
+	exit.Lines = logrules.ErrorLines(output.Tail(scan), failureLines)
+
+	summary := "进程在就绪前退出"
+	if exit.ExitCode >= 0 {
+		summary = fmt.Sprintf("退出码 %d", exit.ExitCode)
+	}
+	if len(exit.Lines) > 0 {
+		summary += ": " + exit.Lines[0]
+	} else if err != nil {
+		summary += ": " + err.Error()
+	}
+	m.attempts.mu.Lock()
+	s.failure = truncate(summary, failureSummaryLen)
+	m.attempts.mu.Unlock()
+	return true
+}
+
+// exitCode 进程的退出码（进程没有启动、编译失败等拿不到退出码时为 -1）
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// truncate 截断过长的文字（按字符）
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
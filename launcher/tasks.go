@@ -0,0 +1,66 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+	"gva-launcher/scheduler"
+)
+
+// TaskActions 定时任务可用的动作：依赖检查、缓存回收、配置备份、项目构建和自定义命令
+func TaskActions(project *Project, depsManager *DependencyManager, buildManager *BuildManager) []scheduler.Action {
+	return []scheduler.Action{
+		{
+			ID:    "dep-audit",
+			Label: "依赖检查",
+			Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+				return depsManager.Audit(j)
+			},
+		},
+		{
+			ID:    "cache-prune",
+			Label: "缓存回收",
+			Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+				return depsManager.PruneCache(j)
+			},
+		},
+		{
+			ID:    "backup",
+			Label: "备份配置",
+			Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+				path, err := project.Backup(config.BackupDir())
+				if err != nil {
+					return err
+				}
+				j.Logf("已备份到 %s", path)
+				return nil
+			},
+		},
+		{
+			ID:    "build",
+			Label: "构建项目",
+			Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+				return buildManager.Build(j)
+			},
+		},
+		{
+			ID:    "command",
+			Label: "自定义命令",
+			Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+				command := strings.TrimSpace(task.Command)
+				if command == "" {
+					return fmt.Errorf("未填写命令")
+				}
+				j.Logf("$ %s", command)
+				name, args := sysutil.ShellCommand(command)
+				output, err := sysutil.Runner.CombinedOutputEnv(project.Root, project.HookVars().Env(), name, args...)
+				j.Write(output)
+				return err
+			},
+		},
+	}
+}
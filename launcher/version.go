@@ -0,0 +1,4 @@
+package launcher
+
+// Version 面板版本号（发布时通过 -ldflags "-X gva-launcher/launcher.Version=v1.2.3" 注入）
+var Version = "v1.0.0"
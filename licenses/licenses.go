@@ -0,0 +1,228 @@
+// Package licenses 第三方依赖的许可证清单：前端读取 web/node_modules 中各包的 package.json（与 license-checker 相同），
+// 后端用 go list 找出实际编译进后端的模块并识别模块目录中的许可证文件（与 go-licenses 相同），
+// 标出著佐权（copyleft）许可证，导出 CSV 供发布基于 GVA 的产品前审查
+package licenses
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 依赖来源
+const (
+	Npm = "npm"
+	Go  = "go"
+)
+
+// Unknown 没有识别出许可证
+const Unknown = "UNKNOWN"
+
+// ReportName 导出的 CSV 在项目根目录中的文件名
+const ReportName = "third-party-licenses.csv"
+
+// Copyleft 著佐权程度
+type Copyleft int
+
+const (
+	Permissive Copyleft = iota // 宽松许可证（MIT、Apache-2.0、BSD 等）
+	Weak                       // 弱著佐权：修改该库本身需要开源（LGPL、MPL、EPL 等）
+	Strong                     // 强著佐权：分发的整个作品需要以相同许可证开源（GPL、AGPL 等）
+)
+
+// Label 著佐权程度的中文说明（宽松许可证为空）
+func (c Copyleft) Label() string {
+	switch c {
+	case Weak:
+		return "弱著佐权"
+	case Strong:
+		return "强著佐权"
+	}
+	return ""
+}
+
+// Dependency 一个依赖的许可证
+type Dependency struct {
+	Ecosystem string // npm / go
+	Name      string
+	Version   string
+	License   string // SPDX 标识或表达式，没有识别出时为 Unknown
+	Copyleft  Copyleft
+	Source    string // 许可证的来源：package.json，或许可证文件的路径
+}
+
+// Report 许可证清单
+type Report struct {
+	Dependencies []Dependency
+	Warnings     []string // 没有扫描的部分（例如前端依赖未安装）
+}
+
+// Flagged 需要关注的依赖：著佐权和没有识别出许可证的依赖
+func (r Report) Flagged() []Dependency {
+	var flagged []Dependency
+	for _, d := range r.Dependencies {
+		if d.Copyleft != Permissive || d.License == Unknown {
+			flagged = append(flagged, d)
+		}
+	}
+	return flagged
+}
+
+// newDependency 按许可证识别著佐权程度
+func newDependency(ecosystem, name, version, license, source string) Dependency {
+	if license == "" {
+		license = Unknown
+	}
+	return Dependency{Ecosystem: ecosystem, Name: name, Version: version, License: license, Copyleft: Classify(license), Source: source}
+}
+
+// sortDependencies 按来源、名称排序
+func sortDependencies(deps []Dependency) {
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Ecosystem != deps[j].Ecosystem {
+			return deps[i].Ecosystem < deps[j].Ecosystem
+		}
+		if deps[i].Name != deps[j].Name {
+			return deps[i].Name < deps[j].Name
+		}
+		return deps[i].Version < deps[j].Version
+	})
+}
+
+// strongPrefixes / weakPrefixes 著佐权许可证的 SPDX 标识前缀（LGPL 需要在 GPL 之前判断）
+var (
+	weakPrefixes   = []string{"LGPL", "MPL", "EPL", "CDDL", "EUPL", "CPL", "OSL", "MS-RL", "CC-BY-SA"}
+	strongPrefixes = []string{"AGPL", "GPL", "SSPL"}
+)
+
+// classifyID 单个许可证标识的著佐权程度；带例外条款（GPL-2.0 WITH Classpath-exception-2.0 等）的 GPL 视为弱著佐权
+func classifyID(id string) Copyleft {
+	id, exception, _ := strings.Cut(strings.ToUpper(strings.TrimSpace(id)), " WITH ")
+	for _, p := range weakPrefixes {
+		if strings.HasPrefix(id, p) {
+			return Weak
+		}
+	}
+	for _, p := range strongPrefixes {
+		if strings.HasPrefix(id, p) {
+			if exception != "" {
+				return Weak
+			}
+			return Strong
+		}
+	}
+	return Permissive
+}
+
+// orPattern / andPattern SPDX 表达式中的 OR、AND（不区分大小写）
+var (
+	orPattern  = regexp.MustCompile(`(?i)\s+OR\s+|/`)
+	andPattern = regexp.MustCompile(`(?i)\s+AND\s+`)
+)
+
+// groupPattern SPDX 表达式中最内层的括号
+var groupPattern = regexp.MustCompile(`\(([^()]*)\)`)
+
+// levelIDs 括号内的部分计算后替换为同等程度的许可证标识
+var levelIDs = [...]string{Permissive: "MIT", Weak: "LGPL", Strong: "GPL"}
+
+// Classify 许可证（SPDX 表达式）的著佐权程度：OR 可以任选其一，取最宽松的；AND 需要同时遵守，取最严格的；
+// 括号内的部分先计算
+func Classify(license string) Copyleft {
+	for groupPattern.MatchString(license) {
+		license = groupPattern.ReplaceAllStringFunc(license, func(group string) string {
+			return " " + levelIDs[classifyFlat(group[1:len(group)-1])] + " "
+		})
+	}
+	return classifyFlat(license)
+}
+
+// classifyFlat 不带括号的表达式的著佐权程度（AND 的优先级高于 OR）
+func classifyFlat(license string) Copyleft {
+	result := Strong
+	for _, alternative := range orPattern.Split(license, -1) {
+		c := Permissive
+		for _, id := range andPattern.Split(alternative, -1) {
+			c = max(c, classifyID(id))
+		}
+		result = min(result, c)
+	}
+	return result
+}
+
+// textRules 按许可证全文识别许可证（依次匹配，先匹配更具体的）
+var textRules = []struct {
+	pattern *regexp.Regexp
+	license string
+}{
+	{regexp.MustCompile(`(?i)GNU AFFERO GENERAL PUBLIC LICENSE`), "AGPL-3.0"},
+	{regexp.MustCompile(`(?is)GNU (LESSER|LIBRARY) GENERAL PUBLIC LICENSE.*Version 3`), "LGPL-3.0"},
+	{regexp.MustCompile(`(?i)GNU (LESSER|LIBRARY) GENERAL PUBLIC LICENSE`), "LGPL-2.1"},
+	{regexp.MustCompile(`(?is)GNU GENERAL PUBLIC LICENSE\s+Version 3`), "GPL-3.0"},
+	{regexp.MustCompile(`(?i)GNU GENERAL PUBLIC LICENSE`), "GPL-2.0"},
+	{regexp.MustCompile(`(?i)Mozilla Public License,? Version 2\.0`), "MPL-2.0"},
+	{regexp.MustCompile(`(?i)Eclipse Public License - v 2\.0`), "EPL-2.0"},
+	{regexp.MustCompile(`(?i)Eclipse Public License`), "EPL-1.0"},
+	{regexp.MustCompile(`(?is)Apache License.*Version 2\.0`), "Apache-2.0"},
+	{regexp.MustCompile(`(?i)Permission is hereby granted, free of charge`), "MIT"},
+	{regexp.MustCompile(`(?i)Permission to use, copy, modify, and/or distribute this software for any`), "ISC"},
+	{regexp.MustCompile(`(?is)Redistribution and use in source and binary forms.*(Neither the name|endorse or promote)`), "BSD-3-Clause"},
+	{regexp.MustCompile(`(?i)Redistribution and use in source and binary forms`), "BSD-2-Clause"},
+	{regexp.MustCompile(`(?i)This is free and unencumbered software released into the public domain`), "Unlicense"},
+}
+
+// Detect 按许可证全文识别 SPDX 标识（没有识别出时为空）
+func Detect(text string) string {
+	for _, rule := range textRules {
+		if rule.pattern.MatchString(text) {
+			return rule.license
+		}
+	}
+	return ""
+}
+
+// licenseFile 目录中的许可证文件（LICENSE、LICENSE.md、LICENCE、COPYING 等，没有时为空）
+func licenseFile(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		name := strings.ToUpper(e.Name())
+		if !e.IsDir() && (strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE") || strings.HasPrefix(name, "COPYING")) {
+			return filepath.Join(dir, e.Name())
+		}
+	}
+	return ""
+}
+
+// detectDir 识别目录中的许可证文件，返回许可证和文件路径
+func detectDir(dir string) (license, source string) {
+	path := licenseFile(dir)
+	if path == "" {
+		return "", ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", path
+	}
+	return Detect(string(data)), path
+}
+
+// WriteCSV 导出 CSV（带 UTF-8 BOM，Excel 可以直接打开中文）
+func WriteCSV(w io.Writer, deps []Dependency) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"ecosystem", "name", "version", "license", "copyleft", "source"})
+	for _, d := range deps {
+		cw.Write([]string{d.Ecosystem, d.Name, d.Version, d.License, d.Copyleft.Label(), d.Source})
+	}
+	cw.Flush()
+	return cw.Error()
+}
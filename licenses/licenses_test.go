@@ -0,0 +1,138 @@
+package licenses
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestClassify(t *testing.T) {
+	cases := map[string]Copyleft{
+		"MIT":                                  Permissive,
+		"Apache-2.0":                           Permissive,
+		"GPL-3.0-only":                         Strong,
+		"AGPL-3.0":                             Strong,
+		"LGPL-2.1-or-later":                    Weak,
+		"MPL-2.0":                              Weak,
+		"(MIT OR GPL-3.0)":                     Permissive,
+		"MIT AND GPL-2.0":                      Strong,
+		"(MIT OR Apache-2.0) AND MPL-2.0":      Weak,
+		"GPL-2.0 WITH Classpath-exception-2.0": Weak,
+		"BSD-3-Clause/GPL-2.0":                 Permissive,
+		Unknown:                                Permissive,
+	}
+	for license, want := range cases {
+		if got := Classify(license); got != want {
+			t.Errorf("Classify(%q) = %d, want %d", license, got, want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{
+		"MIT License\n\nPermission is hereby granted, free of charge, to any person":                                               "MIT",
+		"Apache License\n                           Version 2.0, January 2004":                                                     "Apache-2.0",
+		"Redistribution and use in source and binary forms, with or without\n...\n* Neither the name of Google Inc. nor the names": "BSD-3-Clause",
+		"Redistribution and use in source and binary forms, with or without modification":                                          "BSD-2-Clause",
+		"GNU LESSER GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007":                                                              "LGPL-3.0",
+		"GNU GENERAL PUBLIC LICENSE\n Version 2, June 1991":                                                                        "GPL-2.0",
+		"Mozilla Public License Version 2.0":                                                                                       "MPL-2.0",
+		"All rights reserved.":                                                                                                     "",
+	}
+	for text, want := range cases {
+		if got := Detect(text); got != want {
+			t.Errorf("Detect(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+// writePackage 在 node_modules 中写入一个包
+func writePackage(t *testing.T, dir, packageJSON string, files map[string]string) {
+	t.Helper()
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(packageJSON), 0644)
+	for name, content := range files {
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+}
+
+func TestScanNpm(t *testing.T) {
+	web := t.TempDir()
+	modules := filepath.Join(web, "node_modules")
+	writePackage(t, filepath.Join(modules, "vue"), `{"name":"vue","version":"3.4.0","license":"MIT"}`, nil)
+	writePackage(t, filepath.Join(modules, "@scope", "old"), `{"name":"@scope/old","version":"1.0.0","license":{"type":"BSD-3-Clause"}}`, nil)
+	writePackage(t, filepath.Join(modules, "legacy"), `{"name":"legacy","version":"0.1.0","licenses":[{"type":"MIT"},{"type":"GPL-2.0"}]}`, nil)
+	writePackage(t, filepath.Join(modules, "nolicense"), `{"name":"nolicense","version":"2.0.0"}`,
+		map[string]string{"LICENSE.md": "GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007"})
+	// 嵌套的不同版本单独记录，相同版本只记录一次
+	writePackage(t, filepath.Join(modules, "legacy", "node_modules", "vue"), `{"name":"vue","version":"2.7.0","license":"MIT"}`, nil)
+	writePackage(t, filepath.Join(modules, "nolicense", "node_modules", "vue"), `{"name":"vue","version":"3.4.0","license":"MIT"}`, nil)
+	os.MkdirAll(filepath.Join(modules, ".bin"), 0755)
+
+	deps, err := ScanNpm(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortDependencies(deps)
+	var got []string
+	for _, d := range deps {
+		got = append(got, d.Name+"@"+d.Version+"="+d.License)
+	}
+	want := "@scope/old@1.0.0=BSD-3-Clause legacy@0.1.0=(MIT OR GPL-2.0) nolicense@2.0.0=GPL-3.0 vue@2.7.0=MIT vue@3.4.0=MIT"
+	if strings.Join(got, " ") != want {
+		t.Errorf("deps = %v", got)
+	}
+	for _, d := range deps {
+		if d.Name == "nolicense" && (d.Copyleft != Strong || filepath.Base(d.Source) != "LICENSE.md") {
+			t.Errorf("nolicense = %+v", d)
+		}
+	}
+
+	if _, err := ScanNpm(t.TempDir()); apperr.CodeOf(err) != apperr.LicenseScanFailed {
+		t.Errorf("没有 node_modules 时 err = %v", err)
+	}
+}
+
+func TestScanGo(t *testing.T) {
+	server := t.TempDir()
+	os.WriteFile(filepath.Join(server, "go.mod"), []byte("module server\n"), 0644)
+	modDir := t.TempDir()
+	os.WriteFile(filepath.Join(modDir, "LICENSE"), []byte("Apache License\nVersion 2.0, January 2004"), 0644)
+
+	runner := sysutiltest.New(t)
+	runner.Handle("go list -deps -f "+goListFormat+" ./...",
+		"\n\ngithub.com/gin-gonic/gin\tv1.10.0\t"+modDir+"\ngithub.com/gin-gonic/gin\tv1.10.0\t"+modDir+"\ngithub.com/missing/mod\tv0.1.0\t\n", nil)
+
+	deps, err := ScanGo(context.Background(), server, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 2 || deps[0].License != "Apache-2.0" || deps[1].License != Unknown {
+		t.Errorf("deps = %+v", deps)
+	}
+}
+
+func TestReportAndCSV(t *testing.T) {
+	report := Report{Dependencies: []Dependency{
+		newDependency(Npm, "vue", "3.4.0", "MIT", "package.json"),
+		newDependency(Go, "example.com/gpl", "v1.0.0", "GPL-3.0", "/mod/LICENSE"),
+		newDependency(Go, "example.com/none", "v1.0.0", "", ""),
+	}}
+	if flagged := report.Flagged(); len(flagged) != 2 || flagged[0].Name != "example.com/gpl" {
+		t.Errorf("flagged = %+v", flagged)
+	}
+
+	var out strings.Builder
+	if err := WriteCSV(&out, report.Dependencies); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimPrefix(out.String(), "\ufeff"), "\n")
+	if lines[0] != "ecosystem,name,version,license,copyleft,source" || lines[2] != "go,example.com/gpl,v1.0.0,GPL-3.0,强著佐权,/mod/LICENSE" {
+		t.Errorf("csv = %q", out.String())
+	}
+}
@@ -0,0 +1,155 @@
+package licenses
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// Scan 扫描项目前后端的依赖；一侧无法扫描时记入 Warnings，两侧都无法扫描时返回错误
+func Scan(ctx context.Context, root string, goFlags []string) (Report, error) {
+	var report Report
+	npmDeps, npmErr := ScanNpm(filepath.Join(root, "web"))
+	if npmErr != nil {
+		report.Warnings = append(report.Warnings, "前端: "+npmErr.Error())
+	}
+	goDeps, goErr := ScanGo(ctx, filepath.Join(root, "server"), goFlags)
+	if goErr != nil {
+		report.Warnings = append(report.Warnings, "后端: "+goErr.Error())
+	}
+	if npmErr != nil && goErr != nil {
+		return report, apperr.Errorf(apperr.LicenseScanFailed, "%s", strings.Join(report.Warnings, "\n"))
+	}
+	report.Dependencies = append(npmDeps, goDeps...)
+	sortDependencies(report.Dependencies)
+	return report, nil
+}
+
+// packageJSON package.json 中与许可证有关的字段
+type packageJSON struct {
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	License  json.RawMessage `json:"license"` // "MIT" 或旧写法 {"type": "MIT"}
+	Licenses []struct {
+		Type string `json:"type"`
+	} `json:"licenses"` // 更旧的写法，多个许可证任选其一
+}
+
+// license package.json 声明的许可证（没有声明时为空）
+func (p packageJSON) license() string {
+	var text string
+	if json.Unmarshal(p.License, &text) == nil && text != "" {
+		return text
+	}
+	var typed struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(p.License, &typed) == nil && typed.Type != "" {
+		return typed.Type
+	}
+	var types []string
+	for _, l := range p.Licenses {
+		if l.Type != "" {
+			types = append(types, l.Type)
+		}
+	}
+	if len(types) > 1 {
+		return "(" + strings.Join(types, " OR ") + ")"
+	}
+	return strings.Join(types, "")
+}
+
+// ScanNpm 扫描 webDir/node_modules 中安装的所有包（包括嵌套的 node_modules），同名同版本只记录一次；
+// package.json 没有声明许可证时识别包目录中的许可证文件
+func ScanNpm(webDir string) ([]Dependency, error) {
+	modules := filepath.Join(webDir, "node_modules")
+	if !sysutil.DirExists(modules) {
+		return nil, apperr.Errorf(apperr.LicenseScanFailed, "没有找到 web/node_modules，请先安装前端依赖")
+	}
+	seen := make(map[string]bool)
+	var deps []Dependency
+	var walk func(dir string)
+	walk = func(dir string) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			name := e.Name()
+			switch {
+			case strings.HasPrefix(name, "."):
+				// .bin、.vite、.package-lock.json 等
+			case strings.HasPrefix(name, "@"):
+				walk(filepath.Join(dir, name))
+			case e.IsDir() || e.Type()&os.ModeSymlink != 0:
+				pkgDir := filepath.Join(dir, name)
+				if dep, ok := readPackage(pkgDir); ok && !seen[dep.Name+"@"+dep.Version] {
+					seen[dep.Name+"@"+dep.Version] = true
+					deps = append(deps, dep)
+				}
+				walk(filepath.Join(pkgDir, "node_modules"))
+			}
+		}
+	}
+	walk(modules)
+	return deps, nil
+}
+
+// readPackage 读取包目录中的 package.json
+func readPackage(dir string) (Dependency, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return Dependency{}, false
+	}
+	var pkg packageJSON
+	if json.Unmarshal(data, &pkg) != nil || pkg.Name == "" {
+		return Dependency{}, false
+	}
+	license, source := pkg.license(), "package.json"
+	if license == "" {
+		license, source = detectDir(dir)
+	}
+	return newDependency(Npm, pkg.Name, pkg.Version, license, source), true
+}
+
+// goListFormat 列出后端实际编译的包所属的模块（不包括主模块）：路径、版本、模块目录
+const goListFormat = `{{with .Module}}{{if not .Main}}{{.Path}}{{"\t"}}{{.Version}}{{"\t"}}{{.Dir}}{{end}}{{end}}`
+
+// ScanGo 用 go list -deps 找出后端实际编译进去的模块（而不是 go.mod 中的全部依赖），识别各模块目录中的许可证文件；
+// goFlags 为 Go 工作区模式下附加的参数
+func ScanGo(ctx context.Context, serverDir string, goFlags []string) ([]Dependency, error) {
+	if !sysutil.FileExists(filepath.Join(serverDir, "go.mod")) {
+		return nil, apperr.Errorf(apperr.LicenseScanFailed, "没有找到 server/go.mod")
+	}
+	args := append(append([]string{"list"}, goFlags...), "-deps", "-f", goListFormat, "./...")
+	output, err := sysutil.CombinedOutputContext(ctx, serverDir, "go", args...)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.LicenseScanFailed, "go list 失败（后端依赖可能没有下载）: %v\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	seen := make(map[string]bool)
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		// 没有下载的模块目录为空，保留行尾的制表符
+		fields := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "\t")
+		if len(fields) != 3 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		path, version, dir := fields[0], fields[1], fields[2]
+		license, source := "", ""
+		if dir != "" {
+			license, source = detectDir(dir)
+		}
+		deps = append(deps, newDependency(Go, path, version, license, source))
+	}
+	return deps, nil
+}
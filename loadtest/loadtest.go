@@ -0,0 +1,278 @@
+// Package loadtest 对后端接口的简单压测：以 c 个并发发送 n 个请求（可携带 GVA 的 x-token），
+// 统计延迟分位数和错误率，用于调整 GVA 中间件前后的快速对比
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout 单个请求的默认超时
+const DefaultTimeout = 10 * time.Second
+
+// Options 压测参数
+type Options struct {
+	Method      string        // 请求方法，默认 GET
+	URL         string        // 完整地址，例如 http://127.0.0.1:8888/user/getUserInfo
+	Body        string        // 请求体（JSON），为空时不发送
+	Token       string        // 登录后的 JWT，放在 x-token 请求头中
+	Requests    int           // 请求总数
+	Concurrency int           // 并发数
+	Timeout     time.Duration // 单个请求的超时，为 0 时使用 DefaultTimeout
+}
+
+// Report 压测结果（延迟统计包含失败的请求）
+type Report struct {
+	Method      string
+	URL         string
+	Concurrency int
+	Requests    int         // 实际完成的请求数（中途取消时小于设定值）
+	Errors      int         // 网络错误、HTTP 4xx / 5xx 或 GVA 业务错误码不为 0 的请求数
+	Statuses    map[int]int // HTTP 状态码 -> 次数（网络错误不计入）
+	FirstError  string      // 第一个错误的说明
+	Duration    time.Duration
+	Min         time.Duration
+	Mean        time.Duration
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+// ErrorRate 错误率（0 ~ 1）
+func (r Report) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Requests)
+}
+
+// RPS 每秒完成的请求数
+func (r Report) RPS() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Duration.Seconds()
+}
+
+// Summary 多行的结果摘要
+func (r Report) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s（并发 %d）\n", r.Method, r.URL, r.Concurrency)
+	fmt.Fprintf(&b, "请求 %d 个，用时 %s，%.1f 请求/秒\n", r.Requests, round(r.Duration), r.RPS())
+	fmt.Fprintf(&b, "错误 %d 个（%.1f%%）", r.Errors, r.ErrorRate()*100)
+	if len(r.Statuses) > 0 {
+		codes := make([]int, 0, len(r.Statuses))
+		for code := range r.Statuses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		parts := make([]string, len(codes))
+		for i, code := range codes {
+			parts[i] = fmt.Sprintf("%d×%d", code, r.Statuses[code])
+		}
+		fmt.Fprintf(&b, "，状态码 %s", strings.Join(parts, " "))
+	}
+	fmt.Fprintf(&b, "\n延迟 最小 %s  平均 %s  p50 %s  p90 %s  p99 %s  最大 %s",
+		round(r.Min), round(r.Mean), round(r.P50), round(r.P90), round(r.P99), round(r.Max))
+	if r.FirstError != "" {
+		fmt.Fprintf(&b, "\n首个错误: %s", r.FirstError)
+	}
+	return b.String()
+}
+
+// Compare 两次压测的对比，例如 p50 12ms → 9ms（-25.0%）
+func Compare(before, after Report) string {
+	line := func(name string, a, b time.Duration) string {
+		return fmt.Sprintf("%s %s → %s（%s）", name, round(a), round(b), change(float64(a), float64(b)))
+	}
+	return strings.Join([]string{
+		line("p50", before.P50, after.P50),
+		line("p90", before.P90, after.P90),
+		line("p99", before.P99, after.P99),
+		line("平均", before.Mean, after.Mean),
+		fmt.Sprintf("请求/秒 %.1f → %.1f（%s）", before.RPS(), after.RPS(), change(before.RPS(), after.RPS())),
+		fmt.Sprintf("错误率 %.1f%% → %.1f%%", before.ErrorRate()*100, after.ErrorRate()*100),
+	}, "\n")
+}
+
+// Run 执行压测；progress 不为 nil 时每完成一个请求调用一次（参数为已完成数，可能在多个协程中调用）。
+// ctx 取消时停止发送新请求，返回已完成部分的结果
+func Run(ctx context.Context, opts Options, progress func(done int)) (Report, error) {
+	if opts.Method == "" {
+		opts.Method = http.MethodGet
+	}
+	opts.Method = strings.ToUpper(opts.Method)
+	if !strings.HasPrefix(opts.URL, "http://") && !strings.HasPrefix(opts.URL, "https://") {
+		return Report{}, fmt.Errorf("地址应以 http:// 或 https:// 开头: %s", opts.URL)
+	}
+	if opts.Requests <= 0 || opts.Concurrency <= 0 {
+		return Report{}, fmt.Errorf("请求数和并发数必须大于 0")
+	}
+	if opts.Concurrency > opts.Requests {
+		opts.Concurrency = opts.Requests
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	// 不走代理，每个并发保持一个长连接，避免把建立连接的时间算进延迟
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			MaxIdleConns:        opts.Concurrency,
+			MaxIdleConnsPerHost: opts.Concurrency,
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	type sample struct {
+		sent    bool
+		latency time.Duration
+		status  int
+		err     error
+	}
+	samples := make([]sample, opts.Requests)
+	var next, done atomic.Int64
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				n := int(next.Add(1)) - 1
+				if n >= opts.Requests {
+					return
+				}
+				begin := time.Now()
+				status, err := send(ctx, client, opts)
+				if ctx.Err() != nil {
+					return // 被取消的请求不计入结果
+				}
+				samples[n] = sample{sent: true, latency: time.Since(begin), status: status, err: err}
+				if progress != nil {
+					progress(int(done.Add(1)))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Method:      opts.Method,
+		URL:         opts.URL,
+		Concurrency: opts.Concurrency,
+		Statuses:    map[int]int{},
+		Duration:    time.Since(start),
+	}
+	var latencies []time.Duration
+	var total time.Duration
+	for _, s := range samples {
+		if !s.sent {
+			continue
+		}
+		latencies = append(latencies, s.latency)
+		total += s.latency
+		if s.status > 0 {
+			report.Statuses[s.status]++
+		}
+		if s.err != nil {
+			report.Errors++
+			if report.FirstError == "" {
+				report.FirstError = s.err.Error()
+			}
+		}
+	}
+	report.Requests = len(latencies)
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.Min = latencies[0]
+		report.Max = latencies[len(latencies)-1]
+		report.Mean = total / time.Duration(len(latencies))
+		report.P50 = percentile(latencies, 50)
+		report.P90 = percentile(latencies, 90)
+		report.P99 = percentile(latencies, 99)
+	}
+	return report, nil
+}
+
+// send 发送一个请求并读完响应；返回 HTTP 状态码（网络错误时为 0）和失败原因
+func send(ctx context.Context, client *http.Client, opts Options) (int, error) {
+	var body io.Reader
+	if opts.Body != "" {
+		body = strings.NewReader(opts.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, opts.Method, opts.URL, body)
+	if err != nil {
+		return 0, err
+	}
+	if opts.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if opts.Token != "" {
+		req.Header.Set("x-token", opts.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	// GVA 的接口出错时通常仍返回 HTTP 200，错误放在 JSON 的 code 中
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var result struct {
+			Code *int   `json:"code"`
+			Msg  string `json:"msg"`
+		}
+		if json.Unmarshal(data, &result) == nil && result.Code != nil && *result.Code != 0 {
+			return resp.StatusCode, fmt.Errorf("业务错误码 %d: %s", *result.Code, result.Msg)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// percentile 已排序延迟的第 p 百分位（最近秩法）
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// change 从 a 到 b 的变化百分比
+func change(a, b float64) string {
+	if a == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%+.1f%%", (b-a)/a*100)
+}
+
+// round 按量级取整显示
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
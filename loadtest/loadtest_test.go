@@ -0,0 +1,129 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBackend 模拟 GVA 后端：没有 x-token 时返回 401，每第 10 个请求返回业务错误码
+func newBackend(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if r.Header.Get("x-token") != "jwt" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code":7,"msg":"未登录或非法访问"}`)
+			return
+		}
+		if n%10 == 0 {
+			fmt.Fprint(w, `{"code":7,"msg":"获取失败"}`)
+			return
+		}
+		fmt.Fprint(w, `{"code":0,"data":{},"msg":"成功"}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRun(t *testing.T) {
+	srv, calls := newBackend(t)
+	var progressed atomic.Int32
+	report, err := Run(context.Background(), Options{
+		URL:         srv.URL + "/user/getUserInfo",
+		Token:       "jwt",
+		Requests:    50,
+		Concurrency: 5,
+	}, func(int) { progressed.Add(1) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Requests != 50 || calls.Load() != 50 || progressed.Load() != 50 {
+		t.Fatalf("requests = %d, calls = %d, progress = %d", report.Requests, calls.Load(), progressed.Load())
+	}
+	if report.Errors != 5 || report.ErrorRate() != 0.1 {
+		t.Errorf("errors = %d, rate = %v", report.Errors, report.ErrorRate())
+	}
+	if report.Statuses[http.StatusOK] != 50 || report.Method != http.MethodGet {
+		t.Errorf("report = %+v", report)
+	}
+	if !strings.Contains(report.FirstError, "业务错误码 7") {
+		t.Errorf("FirstError = %q", report.FirstError)
+	}
+	if report.Min > report.P50 || report.P50 > report.P90 || report.P90 > report.P99 || report.P99 > report.Max {
+		t.Errorf("percentiles out of order: %+v", report)
+	}
+	if !strings.Contains(report.Summary(), "错误 5 个（10.0%），状态码 200×50") {
+		t.Errorf("Summary = %s", report.Summary())
+	}
+}
+
+func TestRunWithoutToken(t *testing.T) {
+	srv, _ := newBackend(t)
+	report, err := Run(context.Background(), Options{Method: "post", URL: srv.URL, Body: `{}`, Requests: 4, Concurrency: 8}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Errors != 4 || report.Statuses[http.StatusUnauthorized] != 4 || report.FirstError != "HTTP 401" {
+		t.Errorf("report = %+v", report)
+	}
+	if report.Method != http.MethodPost || report.Concurrency != 4 {
+		t.Errorf("method = %s, concurrency = %d", report.Method, report.Concurrency)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	report, err := Run(ctx, Options{URL: srv.URL, Requests: 1000, Concurrency: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Requests == 0 || report.Requests >= 1000 {
+		t.Errorf("requests = %d", report.Requests)
+	}
+}
+
+func TestRunInvalid(t *testing.T) {
+	if _, err := Run(context.Background(), Options{URL: "127.0.0.1:8888", Requests: 1, Concurrency: 1}, nil); err == nil {
+		t.Error("expected error for URL without scheme")
+	}
+	if _, err := Run(context.Background(), Options{URL: "http://127.0.0.1:8888", Requests: 0, Concurrency: 1}, nil); err == nil {
+		t.Error("expected error for zero requests")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	for p, want := range map[int]time.Duration{50: 50 * time.Millisecond, 90: 90 * time.Millisecond, 99: 99 * time.Millisecond} {
+		if got := percentile(sorted, p); got != want {
+			t.Errorf("p%d = %v, want %v", p, got, want)
+		}
+	}
+	if got := percentile(sorted[:1], 99); got != time.Millisecond {
+		t.Errorf("single sample p99 = %v", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	before := Report{Requests: 100, Duration: time.Second, P50: 20 * time.Millisecond, P90: 40 * time.Millisecond, P99: 80 * time.Millisecond, Mean: 25 * time.Millisecond}
+	after := Report{Requests: 100, Duration: 500 * time.Millisecond, P50: 10 * time.Millisecond, P90: 40 * time.Millisecond, P99: 100 * time.Millisecond, Mean: 15 * time.Millisecond, Errors: 1}
+	got := Compare(before, after)
+	for _, want := range []string{"p50 20ms → 10ms（-50.0%）", "p90 40ms → 40ms（+0.0%）", "p99 80ms → 100ms（+25.0%）", "请求/秒 100.0 → 200.0（+100.0%）", "错误率 0.0% → 1.0%"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Compare missing %q:\n%s", want, got)
+		}
+	}
+}
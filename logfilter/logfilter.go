@@ -0,0 +1,138 @@
+// Package logfilter 服务输出的筛选：按关键字（可选正则表达式）和日志级别过滤行，
+// 级别从 zap、gin、Vite、Go panic 等常见输出格式中识别，没有级别的续行（堆栈等）沿用上一行的级别
+package logfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gva-launcher/ansi"
+)
+
+// Level 日志级别
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+// Levels 可选的最低级别（下拉框顺序），Debug 表示不按级别过滤
+var Levels = []Level{Debug, Info, Warn, Error}
+
+// Label 筛选下拉框中的名称
+func (l Level) Label() string {
+	switch l {
+	case Info:
+		return "信息及以上"
+	case Warn:
+		return "⚠️ 警告及以上"
+	case Error:
+		return "❌ 只看错误"
+	}
+	return "全部级别"
+}
+
+// levelPattern 行中的级别词，取第一次出现的（时间之后的级别字段通常在消息之前）
+var levelPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9_])(panic|dpanic|fatal|error|err|warning|warn|info|debug)(?:[^a-z0-9_]|$)`)
+
+// ginStatusPattern gin 的访问日志：[GIN] 2024/01/02 - 15:04:05 | 500 | ...
+var ginStatusPattern = regexp.MustCompile(`\[GIN\].*?\|\s*(\d{3})\s*\|`)
+
+// goFramePattern Go 堆栈中的函数行，例如 main.main() 或 github.com/x/y.(*T).M(0x1, ...)
+var goFramePattern = regexp.MustCompile(`^[\w./*()-]+\(.*\)$`)
+
+// levelOf 识别一行的级别；没有级别的续行（缩进的行、goroutine 头、Go 堆栈函数行、created by）沿用 prev，其余按信息处理
+func levelOf(line string, prev Level) Level {
+	if m := ginStatusPattern.FindStringSubmatch(line); m != nil {
+		status, _ := strconv.Atoi(m[1])
+		switch {
+		case status >= 500:
+			return Error
+		case status >= 400:
+			return Warn
+		}
+		return Info
+	}
+	if m := levelPattern.FindStringSubmatch(line); m != nil {
+		switch strings.ToLower(m[1]) {
+		case "panic", "dpanic", "fatal", "error", "err":
+			return Error
+		case "warning", "warn":
+			return Warn
+		case "info":
+			return Info
+		}
+		return Debug
+	}
+	trimmed := strings.TrimSpace(line)
+	if trimmed != "" && (line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(trimmed, "goroutine ") ||
+		strings.HasPrefix(trimmed, "created by ") || goFramePattern.MatchString(trimmed)) {
+		return prev
+	}
+	return Info
+}
+
+// Filter 筛选条件
+type Filter struct {
+	Query string // 关键字（不区分大小写）或正则表达式
+	Regex bool   // Query 是正则表达式
+	Level Level  // 只显示该级别及以上的行
+}
+
+// Matcher 编译后的筛选条件
+type Matcher struct {
+	level   Level
+	query   string
+	pattern *regexp.Regexp
+}
+
+// Compile 编译筛选条件，正则表达式无效时返回错误
+func (f Filter) Compile() (*Matcher, error) {
+	m := &Matcher{level: f.Level}
+	query := strings.TrimSpace(f.Query)
+	switch {
+	case query == "":
+	case f.Regex:
+		pattern, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return nil, fmt.Errorf("正则表达式无效: %w", err)
+		}
+		m.pattern = pattern
+	default:
+		m.query = strings.ToLower(query)
+	}
+	return m, nil
+}
+
+// Active 是否有筛选条件（没有时显示全部行）
+func (m *Matcher) Active() bool {
+	return m.level > Debug || m.query != "" || m.pattern != nil
+}
+
+// Match 返回 lines 中符合条件的行的下标（匹配时去掉颜色控制码，级别按行的顺序识别）
+func (m *Matcher) Match(lines []string) []int {
+	var matched []int
+	level := Info
+	for i, line := range lines {
+		line = ansi.Strip(line)
+		if m.level > Debug {
+			level = levelOf(line, level)
+			if level < m.level {
+				continue
+			}
+		}
+		switch {
+		case m.pattern != nil && !m.pattern.MatchString(line):
+			continue
+		case m.query != "" && !strings.Contains(strings.ToLower(line), m.query):
+			continue
+		}
+		matched = append(matched, i)
+	}
+	return matched
+}
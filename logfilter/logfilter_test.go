@@ -0,0 +1,89 @@
+package logfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLevelOf(t *testing.T) {
+	cases := []struct {
+		line string
+		prev Level
+		want Level
+	}{
+		{"[github.com/flipped-aurora/gin-vue-admin/server]2024/01/02 - 15:04:05.000\terror\t/server/initialize/redis.go:20\tredis connect ping failed, err:", Info, Error},
+		{"2024-01-02T15:04:05.000+0800\tinfo\tinitialize/router.go:30\tregister swagger handler", Error, Info},
+		{`{"level":"warn","ts":1704179045,"msg":"slow sql"}`, Info, Warn},
+		{"2024/01/02 15:04:05 info 没有 error", Info, Info},
+		{"[GIN] 2024/01/02 - 15:04:05 | 500 |    1.2ms |  127.0.0.1 | POST     \"/api/user/login\"", Info, Error},
+		{"[GIN] 2024/01/02 - 15:04:05 | 404 |    1.2ms |  127.0.0.1 | GET      \"/api/nope\"", Info, Warn},
+		{"[GIN] 2024/01/02 - 15:04:05 | 200 |    1.2ms |  127.0.0.1 | GET      \"/api/menu/getMenu\"", Error, Info},
+		{"✘ [ERROR] Could not resolve \"vue\"", Info, Error},
+		{"panic: runtime error: invalid memory address", Info, Error},
+		{"goroutine 1 [running]:", Error, Error},
+		{"main.main()", Error, Error},
+		{"github.com/flipped-aurora/gin-vue-admin/server/core.(*Server).Run(0xc000010000)", Error, Error},
+		{"\t/server/main.go:35 +0x1d", Error, Error},
+		{"  VITE v5.4.2  ready in 812 ms", Warn, Warn},
+		{"15:04:05 [vite] hmr update /src/view/layout/index.vue", Error, Info},
+		{"debug: cache miss", Info, Debug},
+		{"", Error, Info},
+	}
+	for _, c := range cases {
+		if got := levelOf(c.line, c.prev); got != c.want {
+			t.Errorf("%q: got %v, want %v", c.line, got, c.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	lines := []string{
+		"===== 2024-01-02 15:04:05 启动: go run main.go =====",
+		"2024/01/02 15:04:05\t\x1b[34minfo\x1b[0m\tserver run success on 8888",
+		"[GIN] 2024/01/02 - 15:04:06 | 200 |    1.2ms |  127.0.0.1 | POST     \"/api/user/login\"",
+		"2024/01/02 15:04:07\t\x1b[33mwarn\x1b[0m\tslow sql /api/user/getUserList",
+		"panic: runtime error: index out of range",
+		"goroutine 42 [running]:",
+		"main.handler()",
+		"\t/server/api/v1/user.go:88 +0x1d",
+		"[GIN] 2024/01/02 - 15:04:08 | 500 |    1.2ms |  127.0.0.1 | GET      \"/api/user/getUserList\"",
+	}
+	match := func(f Filter) []int {
+		t.Helper()
+		m, err := f.Compile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.Match(lines)
+	}
+
+	if got := match(Filter{Level: Error}); !reflect.DeepEqual(got, []int{4, 5, 6, 7, 8}) {
+		t.Errorf("error: %v", got)
+	}
+	if got := match(Filter{Level: Warn}); !reflect.DeepEqual(got, []int{3, 4, 5, 6, 7, 8}) {
+		t.Errorf("warn: %v", got)
+	}
+	if got := match(Filter{Query: "GETUSERLIST"}); !reflect.DeepEqual(got, []int{3, 8}) {
+		t.Errorf("关键字不区分大小写: %v", got)
+	}
+	if got := match(Filter{Query: `\| 5\d\d \|`, Regex: true}); !reflect.DeepEqual(got, []int{8}) {
+		t.Errorf("regex: %v", got)
+	}
+	if got := match(Filter{Query: "/api/user", Level: Warn}); !reflect.DeepEqual(got, []int{3, 8}) {
+		t.Errorf("关键字和级别同时生效: %v", got)
+	}
+	// 颜色控制码不参与匹配
+	if got := match(Filter{Query: "info\tserver"}); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("ansi: %v", got)
+	}
+
+	if _, err := (Filter{Query: "([", Regex: true}).Compile(); err == nil {
+		t.Error("无效的正则表达式应报错")
+	}
+	if m, _ := (Filter{Query: "  "}).Compile(); m.Active() {
+		t.Error("空关键字不算筛选条件")
+	}
+	if m, _ := (Filter{Level: Info}).Compile(); !m.Active() {
+		t.Error("按级别筛选")
+	}
+}
@@ -0,0 +1,160 @@
+// Package logrules 服务输出的问题识别：用规则把常见的 GVA 报错（Redis 连接被拒绝、MySQL 1045、端口被占用、
+// esbuild 平台不匹配等）对应到中文说明和可以一键执行的修复操作
+package logrules
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gva-launcher/ansi"
+)
+
+// FixAction 修复操作
+type FixAction string
+
+const (
+	FixDisableRedis     FixAction = "disable-redis"     // 关闭 config.yaml 的 use-redis 并重启后端
+	FixOpenConfig       FixAction = "open-config"       // 打开 server/config.yaml 修改
+	FixFreePort         FixAction = "free-port"         // 结束占用端口的进程后重新启动服务
+	FixReinstallEsbuild FixAction = "reinstall-esbuild" // 删除 esbuild 后重新安装前端依赖（安装当前系统的平台包）
+)
+
+// Fix 修复操作
+type Fix struct {
+	Action FixAction
+	Title  string
+}
+
+// Rule 一条识别规则
+type Rule struct {
+	ID      string
+	Service string // 只匹配该服务的输出（backend / frontend），为空时匹配所有服务
+	Pattern *regexp.Regexp
+	Title   string
+	// Explain 说明，可以用 $name 引用 Pattern 中的命名分组（例如 $user、$port）
+	Explain string
+	Fix     *Fix
+}
+
+// Rules 内置规则（按顺序匹配，识别结果也按此顺序排列）
+var Rules = []Rule{
+	{
+		ID:      "redis-refused",
+		Service: "backend",
+		Pattern: regexp.MustCompile(`(?i)redis.*(connection refused|actively refused|no connection could be made|i/o timeout)|dial tcp \S+:6379: .*(refused|timeout)`),
+		Title:   "Redis 连接失败",
+		Explain: "config.yaml 开启了 use-redis，但后端无法连接 Redis：Redis 没有启动，或 redis.addr 的地址、端口不正确。" +
+			"请启动 Redis 后在「Redis 配置」中测试连接；暂时不需要 Redis 时可以关闭 use-redis",
+		Fix: &Fix{Action: FixDisableRedis, Title: "关闭 use-redis 并重启后端"},
+	},
+	{
+		ID:      "mysql-access-denied",
+		Service: "backend",
+		Pattern: regexp.MustCompile(`Error 1045.*Access denied for user '(?P<user>[^']*)'`),
+		Title:   "MySQL 用户名或密码错误",
+		Explain: "MySQL 拒绝了用户 $user 的登录（错误 1045）：config.yaml 中 mysql 的 username / password 不正确，" +
+			"或该用户没有从本机连接的权限。修改后重启后端",
+		Fix: &Fix{Action: FixOpenConfig, Title: "打开 config.yaml"},
+	},
+	{
+		ID: "addr-in-use",
+		// Node: listen EADDRINUSE: address already in use :::8080；Go: listen tcp :8888: bind: address already in use
+		// （Windows 为 Only one usage of each socket address）
+		Pattern: regexp.MustCompile(`(?i)EADDRINUSE\S*\s+(?:address already in use\s+)?\S*?:(?P<port>\d+)|listen tcp \S*?:(?P<port>\d+): bind: (?:address already in use|only one usage)`),
+		Title:   "端口被占用",
+		Explain: "端口 $port 已被其他进程占用（可能是上次没有正常退出的服务，或其他程序），服务无法监听。" +
+			"可以结束占用端口的进程，或在「运行状态」中修改端口",
+		Fix: &Fix{Action: FixFreePort, Title: "结束占用端口的进程并重新启动"},
+	},
+	{
+		ID:      "esbuild-platform",
+		Service: "frontend",
+		Pattern: regexp.MustCompile(`(?i)installed esbuild for another platform|@esbuild/(?P<platform>[\w-]+)" package is present but this platform needs`),
+		Title:   "esbuild 平台不匹配",
+		Explain: "node_modules 是在其他系统上安装的（例如在 Windows 和 WSL 之间、或不同电脑之间共用项目目录），" +
+			"esbuild 的平台二进制与当前系统不匹配，Vite 无法启动。需要在当前系统上重新安装 esbuild",
+		Fix: &Fix{Action: FixReinstallEsbuild, Title: "重新安装 esbuild"},
+	},
+}
+
+// Issue 识别出的一个问题
+type Issue struct {
+	RuleID  string
+	Service string // 输出所属的服务
+	Title   string
+	Detail  string // 已填入命名分组的说明
+	Line    string // 匹配的输出行
+	Port    int    // 命名分组 port 的值（没有时为 0）
+	Fix     *Fix
+}
+
+// Classify 用 Rules 识别服务输出中的问题，每条规则最多一个结果（取最后一次出现，即最近一次运行的报错）
+func Classify(service string, lines []string) []Issue {
+	return ClassifyWith(Rules, service, lines)
+}
+
+// ClassifyWith 用指定的规则识别问题
+func ClassifyWith(rules []Rule, service string, lines []string) []Issue {
+	var issues []Issue
+	for _, rule := range rules {
+		if rule.Service != "" && rule.Service != service {
+			continue
+		}
+		for i := len(lines) - 1; i >= 0; i-- {
+			line := strings.TrimSpace(ansi.Strip(lines[i])) // Vite 的输出带颜色
+			if m := rule.Pattern.FindStringSubmatch(line); m != nil {
+				issues = append(issues, newIssue(rule, service, line, m))
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// newIssue 按匹配结果生成问题（同名分组取第一个有值的）
+func newIssue(rule Rule, service, line string, m []string) Issue {
+	groups := make(map[string]string)
+	for i, name := range rule.Pattern.SubexpNames() {
+		if name != "" && m[i] != "" && groups[name] == "" {
+			groups[name] = m[i]
+		}
+	}
+	issue := Issue{
+		RuleID:  rule.ID,
+		Service: service,
+		Title:   rule.Title,
+		Detail:  os.Expand(rule.Explain, func(name string) string { return groups[name] }),
+		Line:    line,
+		Fix:     rule.Fix,
+	}
+	issue.Port, _ = strconv.Atoi(groups["port"])
+	return issue
+}
+
+// errorLinePattern 看起来是报错的输出行：Go 编译错误（文件.go:行:列:）、npm ERR!、panic 和常见的错误关键字
+var errorLinePattern = regexp.MustCompile(`(?i)\berror\b|npm err!|panic|fatal|failed|cannot |undefined|not found|denied|refused|\.go:\d+(:\d+)?: `)
+
+// ErrorLines 输出中最早出现的至多 n 行报错（启动失败时第一条报错通常就是原因），没有识别出报错时返回最后 n 行；
+// 面板自己写入的分隔行（===== 开头）和空行不计入
+func ErrorLines(lines []string, n int) []string {
+	var output, errors []string
+	for _, line := range lines {
+		line = strings.TrimSpace(ansi.Strip(line))
+		if line == "" || strings.HasPrefix(line, "=====") {
+			continue
+		}
+		output = append(output, line)
+		if len(errors) < n && errorLinePattern.MatchString(line) {
+			errors = append(errors, line)
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	if len(output) > n {
+		output = output[len(output)-n:]
+	}
+	return output
+}
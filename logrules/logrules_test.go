@@ -0,0 +1,74 @@
+package logrules
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		service string
+		line    string
+		rule    string
+		port    int
+		detail  string
+	}{
+		{"backend", `2026/10/15 12:00:00 redis connect ping failed, err: dial tcp 127.0.0.1:6379: connect: connection refused`, "redis-refused", 0, ""},
+		{"backend", `[error] failed to initialize database, got error Error 1045 (28000): Access denied for user 'root'@'localhost' (using password: YES)`, "mysql-access-denied", 0,
+			"MySQL 拒绝了用户 root 的登录（错误 1045）：config.yaml 中 mysql 的 username / password 不正确，或该用户没有从本机连接的权限。修改后重启后端"},
+		{"frontend", "\x1b[31mError: listen EADDRINUSE: address already in use :::8080\x1b[39m", "addr-in-use", 8080, ""},
+		{"backend", `listen tcp :8888: bind: Only one usage of each socket address (protocol/network address/port) is normally permitted.`, "addr-in-use", 8888, ""},
+		{"frontend", `Error: You installed esbuild for another platform than the one you're currently using.`, "esbuild-platform", 0, ""},
+	}
+	for _, c := range cases {
+		issues := Classify(c.service, []string{"starting...", c.line, "exit status 1"})
+		if len(issues) != 1 || issues[0].RuleID != c.rule || issues[0].Port != c.port || issues[0].Fix == nil {
+			t.Errorf("Classify(%q) = %+v, want %s", c.line, issues, c.rule)
+			continue
+		}
+		if c.detail != "" && issues[0].Detail != c.detail {
+			t.Errorf("Detail = %q", issues[0].Detail)
+		}
+	}
+}
+
+func TestClassifyServiceAndLatest(t *testing.T) {
+	lines := []string{
+		"listen tcp :8001: bind: address already in use",
+		"listen tcp :8888: bind: address already in use",
+		"dial tcp 127.0.0.1:6379: connect: connection refused",
+	}
+	// 前端的输出不匹配只针对后端的规则
+	if issues := Classify("frontend", lines); len(issues) != 1 || issues[0].RuleID != "addr-in-use" {
+		t.Fatalf("frontend issues = %+v", issues)
+	}
+	issues := Classify("backend", lines)
+	if len(issues) != 2 || issues[0].RuleID != "redis-refused" || issues[1].Port != 8888 {
+		t.Errorf("backend issues = %+v", issues)
+	}
+	if got := Classify("backend", []string{"[GIN-debug] Listening and serving HTTP on :8888"}); len(got) != 0 {
+		t.Errorf("正常输出不应识别出问题: %+v", got)
+	}
+}
+
+func TestErrorLines(t *testing.T) {
+	compile := []string{
+		"===== 2026-10-15 12:00:00 启动: go run main.go =====",
+		"go: downloading github.com/gin-gonic/gin v1.10.0",
+		"# server/api/v1/system",
+		"api/v1/system/sys_user.go:12:2: undefined: userService",
+		"api/v1/system/sys_user.go:30:9: cannot use x (variable of type int) as string value",
+		"",
+		"===== 2026-10-15 12:00:05 进程已结束: exit status 1 =====",
+	}
+	got := ErrorLines(compile, 1)
+	if len(got) != 1 || got[0] != "api/v1/system/sys_user.go:12:2: undefined: userService" {
+		t.Errorf("ErrorLines = %q", got)
+	}
+	if got := ErrorLines(compile, 5); len(got) != 2 {
+		t.Errorf("最多返回识别出的报错行, got %q", got)
+	}
+
+	// 没有报错时返回最后几行
+	plain := []string{"> vite", "", "\x1b[32mVITE\x1b[39m ready", "===== 进程已结束 ====="}
+	if got := ErrorLines(plain, 1); len(got) != 1 || got[0] != "VITE ready" {
+		t.Errorf("ErrorLines = %q", got)
+	}
+}
@@ -0,0 +1,170 @@
+// Package mailer 通过 SMTP 发送纯文本邮件（依赖周报等通知）
+package mailer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+// DefaultPort 未填写端口时使用的提交端口（STARTTLS）
+const DefaultPort = 587
+
+// timeout 连接和整个发送过程的超时
+const timeout = 30 * time.Second
+
+// Server 发信服务器和收件人
+type Server struct {
+	Host     string
+	Port     int // 0 表示 587；465 使用 SSL 直连，其他端口在服务器支持时使用 STARTTLS
+	Username string
+	Password string   // 密码或授权码
+	From     string   // 发件人（为空时使用 Username）
+	To       []string // 收件人
+}
+
+// sender 实际使用的发件人
+func (s Server) sender() string {
+	if s.From != "" {
+		return s.From
+	}
+	return s.Username
+}
+
+// addr 服务器地址（host:port）
+func (s Server) addr() string {
+	port := s.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	return net.JoinHostPort(s.Host, strconv.Itoa(port))
+}
+
+// Validate 检查发信设置是否完整
+func (s Server) Validate() error {
+	switch {
+	case strings.TrimSpace(s.Host) == "":
+		return apperr.Errorf(apperr.MailSendFailed, "未填写 SMTP 服务器")
+	case s.Port < 0 || s.Port > 65535:
+		return apperr.Errorf(apperr.MailSendFailed, "SMTP 端口无效: %d", s.Port)
+	case len(s.To) == 0:
+		return apperr.Errorf(apperr.MailSendFailed, "未填写收件人")
+	}
+	if _, err := mail.ParseAddress(s.sender()); err != nil {
+		return apperr.Errorf(apperr.MailSendFailed, "发件人地址无效: %s", s.sender())
+	}
+	for _, to := range s.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return apperr.Errorf(apperr.MailSendFailed, "收件人地址无效: %s", to)
+		}
+	}
+	return nil
+}
+
+// ParseList 解析以逗号、分号或空白分隔的地址列表
+func ParseList(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == '，' || r == '；' || r == ' ' || r == '\n' || r == '\t'
+	})
+}
+
+// Send 发送一封纯文本邮件
+func Send(s Server, subject, body string) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if err := send(s, message(s.sender(), s.To, subject, body, time.Now())); err != nil {
+		return apperr.Errorf(apperr.MailSendFailed, "发送邮件失败: %v", err)
+	}
+	return nil
+}
+
+// send 连接服务器并投递
+func send(s Server, msg []byte) error {
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: timeout}
+	if s.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.addr(), &tls.Config{ServerName: s.Host})
+	} else {
+		conn, err = dialer.Dial("tcp", s.addr())
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok && s.Port != 465 {
+		if err := c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return err
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("登录失败（请检查用户名和授权码）: %v", err)
+		}
+	}
+	if err := c.Mail(addressOnly(s.sender())); err != nil {
+		return err
+	}
+	for _, to := range s.To {
+		if err := c.Rcpt(addressOnly(to)); err != nil {
+			return fmt.Errorf("收件人 %s 被拒绝: %v", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// addressOnly 去掉显示名，只保留邮箱地址（用于 MAIL FROM / RCPT TO）
+func addressOnly(addr string) string {
+	if a, err := mail.ParseAddress(addr); err == nil {
+		return a.Address
+	}
+	return addr
+}
+
+// message 生成邮件内容：主题按 RFC 2047 编码，正文为 UTF-8 并以 base64 传输
+func message(from string, to []string, subject, body string, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
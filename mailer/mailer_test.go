@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+func TestMessage(t *testing.T) {
+	body := strings.Repeat("依赖周报\n", 20)
+	data := message("面板 <panel@example.com>", []string{"a@example.com", "b@example.com"}, "GVA 依赖周报", body, time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC))
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != "GVA 依赖周报" {
+		t.Errorf("Subject = %q", msg.Header.Get("Subject"))
+	}
+	if msg.Header.Get("To") != "a@example.com, b@example.com" {
+		t.Errorf("To = %q", msg.Header.Get("To"))
+	}
+	var encoded strings.Builder
+	for _, line := range strings.Split(string(data[strings.Index(string(data), "\r\n\r\n")+4:]), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("base64 行超过 76 个字符: %d", len(line))
+		}
+		encoded.WriteString(line)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.String())
+	if err != nil || string(decoded) != strings.ReplaceAll(body, "\n", "\r\n") {
+		t.Errorf("正文解码失败: %v %q", err, decoded)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Server{Host: "smtp.example.com", Username: "me@example.com", To: []string{"you@example.com"}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if valid.addr() != "smtp.example.com:587" {
+		t.Errorf("addr = %s", valid.addr())
+	}
+	for _, s := range []Server{
+		{Username: "me@example.com", To: []string{"you@example.com"}},
+		{Host: "smtp.example.com", Username: "me@example.com"},
+		{Host: "smtp.example.com", Username: "me", To: []string{"you@example.com"}},
+		{Host: "smtp.example.com", From: "me@example.com", To: []string{"not an address"}},
+	} {
+		if err := s.Validate(); apperr.CodeOf(err) != apperr.MailSendFailed {
+			t.Errorf("%+v: err = %v", s, err)
+		}
+	}
+}
+
+func TestParseList(t *testing.T) {
+	got := ParseList("a@example.com, b@example.com；c@example.com\n")
+	if strings.Join(got, "|") != "a@example.com|b@example.com|c@example.com" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,238 @@
+// Package mdns 在局域网中通过 mDNS（Bonjour）广播面板的前端地址：
+// 响应 <名称>.local 的地址查询，并以 DNS-SD 的 _http._tcp 服务发布，
+// 手机、平板等设备无需输入 IP 即可访问或在服务浏览器中发现
+package mdns
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultName 默认广播的主机名（gva-panel.local）
+const DefaultName = "gva-panel"
+
+// 记录的 TTL（秒）：主机和服务记录按 RFC 6762 的建议值
+const (
+	hostTTL    = 120
+	serviceTTL = 4500
+)
+
+// serviceType 发布的 DNS-SD 服务类型
+const serviceType = "_http._tcp.local."
+
+// servicesQuery DNS-SD 服务枚举查询
+const servicesQuery = "_services._dns-sd._udp.local."
+
+// cacheFlush 唯一记录的缓存刷新标志（class 的最高位）
+const cacheFlush = 1 << 15
+
+// mdnsAddr mDNS 组播地址
+var mdnsAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// namePattern 合法的主机名标签
+var namePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidName 检查要广播的主机名（不含 .local）
+func ValidName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("名称 %q 不合法，只能包含小写字母、数字和连字符，例如 %s", name, DefaultName)
+	}
+	return nil
+}
+
+// Service 广播的服务
+type Service struct {
+	Name     string          // 主机名（不含 .local），例如 gva-panel
+	Instance string          // 服务实例名（在服务浏览器中显示），例如 GVA Panel
+	Port     func() int      // 前端端口（每次应答时读取，端口变化后无需重启）
+	Addrs    func() []net.IP // 本机局域网地址
+	Path     string          // TXT 记录中的访问路径（DNS-SD 的 path）
+}
+
+// host 完整的主机名，例如 gva-panel.local.
+func (s Service) host() string {
+	return s.Name + ".local."
+}
+
+// instance 完整的服务实例名，例如 GVA Panel._http._tcp.local.
+func (s Service) instance() string {
+	return s.Instance + "." + serviceType
+}
+
+// Responder 运行中的 mDNS 应答器
+type Responder struct {
+	svc  Service
+	conn *net.UDPConn
+	wg   sync.WaitGroup
+}
+
+// Start 加入 mDNS 组播组开始应答，并立即广播一次服务记录
+func Start(svc Service) (*Responder, error) {
+	if err := ValidName(svc.Name); err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsAddr)
+	if err != nil {
+		return nil, fmt.Errorf("加入 mDNS 组播组失败: %v", err)
+	}
+	r := &Responder{svc: svc, conn: conn}
+	r.announce(false)
+
+	r.wg.Add(1)
+	go r.serve()
+	return r, nil
+}
+
+// Close 广播记录失效（TTL 为 0）并停止应答
+func (r *Responder) Close() error {
+	r.announce(true)
+	err := r.conn.Close()
+	r.wg.Wait()
+	return err
+}
+
+// serve 读取查询并应答
+func (r *Responder) serve() {
+	defer r.wg.Done()
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		if err := query.Unpack(buf[:n]); err != nil || query.Header.Response {
+			continue
+		}
+		resp := r.svc.answer(query, src.Port != mdnsAddr.Port)
+		if resp == nil {
+			continue
+		}
+		packed, err := resp.Pack()
+		if err != nil {
+			continue
+		}
+		// 非 5353 端口发来的是传统单播查询（例如 nslookup），直接回复；其余回复到组播组
+		dst := mdnsAddr
+		if src.Port != mdnsAddr.Port {
+			dst = src
+		}
+		r.conn.WriteToUDP(packed, dst)
+	}
+}
+
+// announce 主动广播全部记录（goodbye 为 true 时 TTL 为 0，通知其他设备删除缓存）
+func (r *Responder) announce(goodbye bool) {
+	resp := &dnsmessage.Message{Header: dnsmessage.Header{Response: true, Authoritative: true}}
+	resp.Answers = append(r.svc.serviceRecords(), r.svc.addressRecords(dnsmessage.TypeALL)...)
+	if goodbye {
+		for i := range resp.Answers {
+			resp.Answers[i].Header.TTL = 0
+		}
+	}
+	if packed, err := resp.Pack(); err == nil {
+		r.conn.WriteToUDP(packed, mdnsAddr)
+	}
+}
+
+// answer 生成查询的应答（没有需要应答的问题时返回 nil）；
+// legacy 为 true 时按传统 DNS 应答（带上查询 ID 和问题，不设置缓存刷新标志）
+func (s Service) answer(query dnsmessage.Message, legacy bool) *dnsmessage.Message {
+	resp := &dnsmessage.Message{Header: dnsmessage.Header{Response: true, Authoritative: true}}
+	if legacy {
+		resp.Header.ID = query.Header.ID
+	}
+
+	for _, q := range query.Questions {
+		name := strings.ToLower(q.Name.String())
+		answered := true
+		switch {
+		case name == s.host():
+			records := s.addressRecords(q.Type)
+			if len(records) == 0 {
+				answered = false
+			}
+			resp.Answers = append(resp.Answers, records...)
+		case name == serviceType && (q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL):
+			records := s.serviceRecords()
+			resp.Answers = append(resp.Answers, records[0])
+			resp.Additionals = append(resp.Additionals, records[1:]...)
+			resp.Additionals = append(resp.Additionals, s.addressRecords(dnsmessage.TypeALL)...)
+		case name == servicesQuery && (q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL):
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(servicesQuery), Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET, TTL: serviceTTL},
+				Body:   &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(serviceType)},
+			})
+		case name == strings.ToLower(s.instance()) && (q.Type == dnsmessage.TypeSRV || q.Type == dnsmessage.TypeTXT || q.Type == dnsmessage.TypeALL):
+			records := s.serviceRecords()
+			resp.Answers = append(resp.Answers, records[1:]...)
+			resp.Additionals = append(resp.Additionals, s.addressRecords(dnsmessage.TypeALL)...)
+		default:
+			answered = false
+		}
+		if answered && legacy {
+			q.Class &^= cacheFlush // 去掉 QU 标志
+			resp.Questions = append(resp.Questions, q)
+		}
+	}
+	if len(resp.Answers) == 0 {
+		return nil
+	}
+	if legacy {
+		for _, records := range [][]dnsmessage.Resource{resp.Answers, resp.Additionals} {
+			for i := range records {
+				records[i].Header.Class &^= cacheFlush
+				records[i].Header.TTL = min(records[i].Header.TTL, 10)
+			}
+		}
+	}
+	return resp
+}
+
+// addressRecords 主机名的 A / AAAA 记录（qtype 为 TypeALL 时两种都返回）
+func (s Service) addressRecords(qtype dnsmessage.Type) []dnsmessage.Resource {
+	name := dnsmessage.MustNewName(s.host())
+	var records []dnsmessage.Resource
+	for _, ip := range s.Addrs() {
+		if ip4 := ip.To4(); ip4 != nil && (qtype == dnsmessage.TypeA || qtype == dnsmessage.TypeALL) {
+			records = append(records, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET | cacheFlush, TTL: hostTTL},
+				Body:   &dnsmessage.AResource{A: [4]byte(ip4)},
+			})
+		} else if ip4 == nil && len(ip) == net.IPv6len && (qtype == dnsmessage.TypeAAAA || qtype == dnsmessage.TypeALL) {
+			records = append(records, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeAAAA, Class: dnsmessage.ClassINET | cacheFlush, TTL: hostTTL},
+				Body:   &dnsmessage.AAAAResource{AAAA: [16]byte(ip)},
+			})
+		}
+	}
+	return records
+}
+
+// serviceRecords DNS-SD 的 PTR、SRV、TXT 记录（顺序固定）
+func (s Service) serviceRecords() []dnsmessage.Resource {
+	instance := dnsmessage.MustNewName(s.instance())
+	path := s.Path
+	if path == "" {
+		path = "/"
+	}
+	return []dnsmessage.Resource{
+		{
+			Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(serviceType), Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET, TTL: serviceTTL},
+			Body:   &dnsmessage.PTRResource{PTR: instance},
+		},
+		{
+			Header: dnsmessage.ResourceHeader{Name: instance, Type: dnsmessage.TypeSRV, Class: dnsmessage.ClassINET | cacheFlush, TTL: hostTTL},
+			Body:   &dnsmessage.SRVResource{Port: uint16(s.Port()), Target: dnsmessage.MustNewName(s.host())},
+		},
+		{
+			Header: dnsmessage.ResourceHeader{Name: instance, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET | cacheFlush, TTL: serviceTTL},
+			Body:   &dnsmessage.TXTResource{TXT: []string{"path=" + path}},
+		},
+	}
+}
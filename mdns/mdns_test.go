@@ -0,0 +1,113 @@
+package mdns
+
+import (
+	"net"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var testService = Service{
+	Name:     "gva-panel",
+	Instance: "GVA Panel",
+	Port:     func() int { return 8080 },
+	Addrs:    func() []net.IP { return []net.IP{net.ParseIP("192.168.1.10"), net.ParseIP("fd00::10")} },
+}
+
+// query 构造一个查询
+func query(name string, qtype dnsmessage.Type) dnsmessage.Message {
+	return dnsmessage.Message{
+		Header: dnsmessage.Header{ID: 42},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(name),
+			Type:  qtype,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+}
+
+func TestAnswerAddress(t *testing.T) {
+	resp := testService.answer(query("GVA-Panel.local.", dnsmessage.TypeA), false)
+	if resp == nil || len(resp.Answers) != 1 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	a := resp.Answers[0]
+	if body := a.Body.(*dnsmessage.AResource); net.IP(body.A[:]).String() != "192.168.1.10" || a.Header.Class != dnsmessage.ClassINET|cacheFlush {
+		t.Errorf("answer = %+v", a)
+	}
+	if resp.Header.ID != 0 || len(resp.Questions) != 0 {
+		t.Errorf("组播应答不应带 ID 和问题: %+v", resp.Header)
+	}
+
+	resp = testService.answer(query("gva-panel.local.", dnsmessage.TypeAAAA), false)
+	if resp == nil || len(resp.Answers) != 1 || resp.Answers[0].Header.Type != dnsmessage.TypeAAAA {
+		t.Errorf("AAAA resp = %+v", resp)
+	}
+
+	if resp := testService.answer(query("other.local.", dnsmessage.TypeA), false); resp != nil {
+		t.Errorf("其他主机名不应应答: %+v", resp)
+	}
+}
+
+func TestAnswerLegacyUnicast(t *testing.T) {
+	resp := testService.answer(query("gva-panel.local.", dnsmessage.TypeA), true)
+	if resp == nil || resp.Header.ID != 42 || len(resp.Questions) != 1 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if h := resp.Answers[0].Header; h.Class != dnsmessage.ClassINET || h.TTL > 10 {
+		t.Errorf("传统单播应答不应设置缓存刷新标志: %+v", h)
+	}
+	if _, err := resp.Pack(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAnswerService(t *testing.T) {
+	resp := testService.answer(query("_http._tcp.local.", dnsmessage.TypePTR), false)
+	if resp == nil || len(resp.Answers) != 1 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if ptr := resp.Answers[0].Body.(*dnsmessage.PTRResource); ptr.PTR.String() != "GVA Panel._http._tcp.local." {
+		t.Errorf("PTR = %s", ptr.PTR)
+	}
+
+	var srv *dnsmessage.SRVResource
+	for _, r := range resp.Additionals {
+		if body, ok := r.Body.(*dnsmessage.SRVResource); ok {
+			srv = body
+		}
+	}
+	if srv == nil || srv.Port != 8080 || srv.Target.String() != "gva-panel.local." {
+		t.Errorf("SRV = %+v", srv)
+	}
+	// SRV、TXT 和两条地址记录
+	if len(resp.Additionals) != 4 {
+		t.Errorf("additionals = %d", len(resp.Additionals))
+	}
+
+	packed, err := resp.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed dnsmessage.Message
+	if err := parsed.Unpack(packed); err != nil || len(parsed.Additionals) != 4 {
+		t.Errorf("parsed = %+v, err = %v", parsed, err)
+	}
+
+	if resp := testService.answer(query("_services._dns-sd._udp.local.", dnsmessage.TypePTR), false); resp == nil || len(resp.Answers) != 1 {
+		t.Errorf("服务枚举 resp = %+v", resp)
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"gva-panel", "gva1", "a"} {
+		if err := ValidName(name); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "GVA", "gva.panel", "-gva", "gva_panel"} {
+		if err := ValidName(name); err == nil {
+			t.Errorf("%q 应不合法", name)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package menus 读取 GVA 数据库中的菜单（sys_base_menus）及其角色分配（sys_authority_menus），
+// 按 GVA 的方式组织成菜单树，并标出导致“菜单不显示”的常见原因：隐藏、没有角色、父菜单缺失或未授权、组件文件不存在
+package menus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/dbclient"
+)
+
+// 查询语句（只取未删除的记录）
+const (
+	menusQuery       = "SELECT id, parent_id, path, name, title, component, hidden, sort FROM sys_base_menus WHERE deleted_at IS NULL"
+	rolesQuery       = "SELECT authority_id, authority_name FROM sys_authorities WHERE deleted_at IS NULL"
+	assignmentsQuery = "SELECT sys_base_menu_id, sys_authority_authority_id FROM sys_authority_menus"
+)
+
+// Menu 一个菜单
+type Menu struct {
+	ID        int
+	ParentID  int
+	Path      string
+	Name      string
+	Title     string
+	Component string
+	Hidden    bool
+	Sort      int
+	Roles     []string // 分配了该菜单的角色（角色名，按角色 ID 排序）
+	Issues    []string // 可能导致菜单不显示的原因
+	Children  []*Menu
+}
+
+// Load 从项目 root 的数据库读取菜单树（根菜单和各级子菜单按 sort 排序），并检查常见问题
+func Load(root string, cfg *config.GVAConfig) ([]*Menu, error) {
+	menuRows, err := dbclient.Query(root, cfg, menusQuery)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.DBQueryFailed, "读取 sys_base_menus 失败: %v", err)
+	}
+	roleRows, err := dbclient.Query(root, cfg, rolesQuery)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.DBQueryFailed, "读取 sys_authorities 失败: %v", err)
+	}
+	assignRows, err := dbclient.Query(root, cfg, assignmentsQuery)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.DBQueryFailed, "读取 sys_authority_menus 失败: %v", err)
+	}
+	return Build(menuRows, roleRows, assignRows, filepath.Join(root, "web", "src")), nil
+}
+
+// Build 由查询结果组织菜单树（父菜单不存在的菜单放在根一级的最后）；srcDir 为前端 src 目录，用于检查组件文件是否存在（为空时不检查）
+func Build(menuRows, roleRows, assignRows [][]string, srcDir string) []*Menu {
+	roleNames := map[string]string{}
+	for _, row := range roleRows {
+		if len(row) >= 2 {
+			roleNames[row[0]] = row[1]
+		}
+	}
+	// 菜单 ID -> 角色 ID（按数值排序）
+	assigned := map[int][]string{}
+	for _, row := range assignRows {
+		if len(row) >= 2 {
+			id, _ := strconv.Atoi(row[0])
+			assigned[id] = append(assigned[id], row[1])
+		}
+	}
+
+	byID := map[int]*Menu{}
+	var all []*Menu
+	for _, row := range menuRows {
+		if len(row) < 8 {
+			continue
+		}
+		m := &Menu{Path: row[2], Name: row[3], Title: row[4], Component: row[5], Hidden: parseBool(row[6])}
+		m.ID, _ = strconv.Atoi(row[0])
+		m.ParentID, _ = strconv.Atoi(row[1])
+		m.Sort, _ = strconv.Atoi(row[7])
+		roleIDs := assigned[m.ID]
+		sort.Slice(roleIDs, func(i, j int) bool { return numLess(roleIDs[i], roleIDs[j]) })
+		for _, id := range roleIDs {
+			name := roleNames[id]
+			if name == "" {
+				name = id
+			}
+			m.Roles = append(m.Roles, name)
+		}
+		byID[m.ID] = m
+		all = append(all, m)
+	}
+
+	var roots, orphans []*Menu
+	for _, m := range all {
+		if m.Hidden {
+			m.Issues = append(m.Issues, "hidden 为 true，不会出现在侧边栏（仍可通过路由访问）")
+		}
+		if len(m.Roles) == 0 {
+			m.Issues = append(m.Issues, "没有分配给任何角色，所有用户都看不到")
+		}
+		if srcDir != "" && m.Component != "" && !componentExists(srcDir, m.Component) {
+			m.Issues = append(m.Issues, fmt.Sprintf("组件文件 src/%s 不存在，打开时会报错", strings.TrimPrefix(m.Component, "/")))
+		}
+		if m.ParentID == 0 {
+			roots = append(roots, m)
+			continue
+		}
+		parent, ok := byID[m.ParentID]
+		if !ok {
+			m.Issues = append(m.Issues, fmt.Sprintf("父菜单 %d 不存在，GVA 不会显示该菜单", m.ParentID))
+			orphans = append(orphans, m)
+			continue
+		}
+		if missing := without(m.Roles, parent.Roles); len(missing) > 0 {
+			m.Issues = append(m.Issues, fmt.Sprintf("角色 %s 没有父菜单「%s」的权限，看不到该菜单", strings.Join(missing, "、"), parent.Title))
+		}
+		parent.Children = append(parent.Children, m)
+	}
+
+	// 父菜单不存在的菜单排在最后
+	sortMenus(roots)
+	sortMenus(orphans)
+	return append(roots, orphans...)
+}
+
+// VisibleTo 角色 role 能否在侧边栏看到该菜单（不考虑父菜单）
+func (m *Menu) VisibleTo(role string) bool {
+	return !m.Hidden && slices.Contains(m.Roles, role)
+}
+
+// Walk 按树的顺序遍历菜单，depth 从 0 开始
+func Walk(menus []*Menu, fn func(m *Menu, depth int)) {
+	var walk func(list []*Menu, depth int)
+	walk = func(list []*Menu, depth int) {
+		for _, m := range list {
+			fn(m, depth)
+			walk(m.Children, depth+1)
+		}
+	}
+	walk(menus, 0)
+}
+
+// Roles 菜单树中出现过的全部角色名（排序后）
+func Roles(menus []*Menu) []string {
+	seen := map[string]bool{}
+	var roles []string
+	Walk(menus, func(m *Menu, _ int) {
+		for _, r := range m.Roles {
+			if !seen[r] {
+				seen[r] = true
+				roles = append(roles, r)
+			}
+		}
+	})
+	sort.Strings(roles)
+	return roles
+}
+
+// sortMenus 按 sort、ID 递归排序
+func sortMenus(list []*Menu) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Sort != list[j].Sort {
+			return list[i].Sort < list[j].Sort
+		}
+		return list[i].ID < list[j].ID
+	})
+	for _, m := range list {
+		sortMenus(m.Children)
+	}
+}
+
+// componentExists 组件文件是否存在（component 相对前端 src 目录，例如 view/dashboard/index.vue）
+func componentExists(srcDir, component string) bool {
+	_, err := os.Stat(filepath.Join(srcDir, filepath.FromSlash(strings.TrimPrefix(component, "/"))))
+	return err == nil
+}
+
+// parseBool 数据库返回的布尔值（mysql / sqlite 为 1 / 0，PostgreSQL 为 t / f）
+func parseBool(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true":
+		return true
+	}
+	return false
+}
+
+// numLess 按数值比较角色 ID（无法解析时按字符串比较）
+func numLess(a, b string) bool {
+	x, errX := strconv.Atoi(a)
+	y, errY := strconv.Atoi(b)
+	if errX != nil || errY != nil {
+		return a < b
+	}
+	return x < y
+}
+
+// without 在 list 中但不在 other 中的元素
+func without(list, other []string) []string {
+	var result []string
+	for _, s := range list {
+		if !slices.Contains(other, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
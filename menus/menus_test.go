@@ -0,0 +1,57 @@
+package menus
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	src := t.TempDir()
+	os.MkdirAll(filepath.Join(src, "view", "dashboard"), 0755)
+	os.WriteFile(filepath.Join(src, "view", "dashboard", "index.vue"), nil, 0644)
+	os.WriteFile(filepath.Join(src, "view", "routerHolder.vue"), nil, 0644)
+
+	menuRows := [][]string{
+		{"3", "2", "user", "user", "用户管理", "view/superAdmin/user/user.vue", "0", "2"},
+		{"1", "0", "dashboard", "dashboard", "仪表盘", "view/dashboard/index.vue", "0", "1"},
+		{"2", "0", "admin", "superAdmin", "超级管理员", "view/routerHolder.vue", "f", "3"},
+		{"4", "2", "about", "about", "关于", "view/routerHolder.vue", "t", "1"},
+		{"5", "9", "lost", "lost", "孤儿", "view/routerHolder.vue", "0", "1"},
+	}
+	roleRows := [][]string{{"888", "普通用户"}, {"9528", "测试角色"}}
+	assignRows := [][]string{{"1", "9528"}, {"1", "888"}, {"2", "888"}, {"3", "888"}, {"3", "9528"}, {"5", "888"}}
+
+	roots := Build(menuRows, roleRows, assignRows, src)
+	var order []string
+	Walk(roots, func(m *Menu, depth int) {
+		order = append(order, strings.Repeat("-", depth)+m.Title)
+	})
+	if want := []string{"仪表盘", "超级管理员", "-关于", "-用户管理", "孤儿"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v", order)
+	}
+
+	dashboard, admin := roots[0], roots[1]
+	about, user := admin.Children[0], admin.Children[1]
+	if !reflect.DeepEqual(dashboard.Roles, []string{"普通用户", "测试角色"}) || len(dashboard.Issues) != 0 {
+		t.Errorf("dashboard = %+v", dashboard)
+	}
+	if !about.Hidden || len(about.Issues) != 2 {
+		t.Errorf("about 应有隐藏和没有角色两个问题: %v", about.Issues)
+	}
+	issues := strings.Join(user.Issues, "\n")
+	if !strings.Contains(issues, "src/view/superAdmin/user/user.vue") || !strings.Contains(issues, "测试角色 没有父菜单「超级管理员」") {
+		t.Errorf("user issues = %v", user.Issues)
+	}
+	if lost := roots[2]; len(lost.Issues) != 1 || !strings.Contains(lost.Issues[0], "父菜单 9 不存在") {
+		t.Errorf("lost issues = %v", lost.Issues)
+	}
+	if !dashboard.VisibleTo("测试角色") || about.VisibleTo("普通用户") {
+		t.Error("VisibleTo 结果不正确")
+	}
+	if got := Roles(roots); !reflect.DeepEqual(got, []string{"普通用户", "测试角色"}) {
+		t.Errorf("Roles = %v", got)
+	}
+}
@@ -0,0 +1,181 @@
+// Package metrics 把面板管理的服务状态、端口和最近事件通过本地 HTTP 接口导出：
+// /metrics 为 Prometheus 文本格式，/status 为 JSON，便于监控系统抓取由面板管理的开发/测试机器。
+// 接口只读，不提供任何控制操作
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"gva-launcher/hooks"
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+)
+
+// DefaultAddr 界面中建议的监听地址（只监听本机）
+const DefaultAddr = "127.0.0.1:9531"
+
+// 运行模式
+const (
+	ModePanel    = "panel"    // 面板窗口
+	ModeWatchdog = "watchdog" // 看守模式
+)
+
+// Service 单个服务的状态
+type Service struct {
+	Name      string     `json:"name"` // backend / frontend
+	Port      int        `json:"port"`
+	Running   bool       `json:"running"`              // 端口是否在监听
+	StartedAt *time.Time `json:"started_at,omitempty"` // 由当前面板进程启动的时间
+}
+
+// Snapshot 某一时刻的状态
+type Snapshot struct {
+	Version  string         `json:"version"`
+	Mode     string         `json:"mode"`
+	Root     string         `json:"root"`
+	Time     time.Time      `json:"time"`
+	Services []Service      `json:"services"`
+	Counts   map[string]int `json:"event_counts"` // 自面板启动以来各事件的触发次数
+	Events   []hooks.Record `json:"events"`       // 最近的事件（从旧到新）
+}
+
+// portInUse 检测端口是否被监听（测试中可替换）
+var portInUse = services.IsPortInUse
+
+// Collect 采集当前状态（端口以实际监听情况为准，服务不是由本进程启动时也能正确显示）
+func Collect(mode string, project *launcher.Project, manager *launcher.ServiceManager) Snapshot {
+	backendPort, frontendPort := project.Ports()
+	snap := Snapshot{
+		Version: launcher.Version,
+		Mode:    mode,
+		Root:    project.Root,
+		Time:    time.Now(),
+		Services: []Service{
+			service("backend", backendPort, &manager.Backend),
+			service("frontend", frontendPort, &manager.Frontend),
+		},
+		Counts: make(map[string]int),
+		Events: manager.Hooks.Recent(),
+	}
+	for event, n := range manager.Hooks.Counts() {
+		snap.Counts[string(event)] = n
+	}
+	if snap.Events == nil {
+		snap.Events = []hooks.Record{}
+	}
+	return snap
+}
+
+// service 单个服务的状态
+func service(name string, port int, info *services.ServiceInfo) Service {
+	s := Service{Name: name, Port: port, Running: port > 0 && portInUse(port)}
+	if started := info.StartTime(); s.Running && !started.IsZero() {
+		s.StartedAt = &started
+	}
+	return s
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出状态
+func WritePrometheus(w io.Writer, snap Snapshot) {
+	fmt.Fprintln(w, "# HELP gvapanel_info 面板信息（值恒为 1）")
+	fmt.Fprintln(w, "# TYPE gvapanel_info gauge")
+	fmt.Fprintf(w, "gvapanel_info{version=\"%s\",mode=\"%s\",root=\"%s\"} 1\n",
+		escapeLabel(snap.Version), escapeLabel(snap.Mode), escapeLabel(snap.Root))
+
+	fmt.Fprintln(w, "# HELP gvapanel_service_up 服务端口是否在监听（1 运行中，0 未运行）")
+	fmt.Fprintln(w, "# TYPE gvapanel_service_up gauge")
+	for _, s := range snap.Services {
+		fmt.Fprintf(w, "gvapanel_service_up{service=\"%s\",port=\"%d\"} %d\n", s.Name, s.Port, boolValue(s.Running))
+	}
+
+	fmt.Fprintln(w, "# HELP gvapanel_service_start_time_seconds 服务由面板启动的时间（Unix 秒）")
+	fmt.Fprintln(w, "# TYPE gvapanel_service_start_time_seconds gauge")
+	for _, s := range snap.Services {
+		if s.StartedAt != nil {
+			fmt.Fprintf(w, "gvapanel_service_start_time_seconds{service=\"%s\"} %d\n", s.Name, s.StartedAt.Unix())
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP gvapanel_events_total 自面板启动以来各事件的触发次数")
+	fmt.Fprintln(w, "# TYPE gvapanel_events_total counter")
+	for _, event := range eventNames(snap.Counts) {
+		fmt.Fprintf(w, "gvapanel_events_total{event=\"%s\"} %d\n", escapeLabel(event), snap.Counts[event])
+	}
+
+	fmt.Fprintln(w, "# HELP gvapanel_last_event_timestamp_seconds 各事件最近一次触发的时间（Unix 秒）")
+	fmt.Fprintln(w, "# TYPE gvapanel_last_event_timestamp_seconds gauge")
+	last := make(map[string]time.Time)
+	for _, r := range snap.Events {
+		last[string(r.Event)] = r.Time
+	}
+	for _, event := range eventNames(snap.Counts) {
+		if t, ok := last[event]; ok {
+			fmt.Fprintf(w, "gvapanel_last_event_timestamp_seconds{event=\"%s\"} %d\n", escapeLabel(event), t.Unix())
+		}
+	}
+}
+
+// eventNames 所有支持的事件，加上计数中出现的其他事件（按名称排序）
+func eventNames(counts map[string]int) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, e := range hooks.Events {
+		seen[string(e)] = true
+		names = append(names, string(e))
+	}
+	for e := range counts {
+		if !seen[e] {
+			names = append(names, e)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// escapeLabel 转义 Prometheus 标签值中的反斜杠、双引号和换行
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// boolValue 布尔值转为 1/0
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Handler 状态接口（snapshot 在每次请求时调用）
+func Handler(snapshot func() Snapshot) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WritePrometheus(w, snapshot())
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(snapshot())
+	})
+	return mux
+}
+
+// Serve 在 addr 上监听并在后台提供状态接口（监听失败时立即返回错误）
+// 返回的 Server.Addr 为实际监听地址（addr 端口为 0 时由系统分配）
+func Serve(addr string, handler http.Handler) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("状态接口监听 %s 失败: %v", addr, err)
+	}
+	server := &http.Server{Addr: listener.Addr().String(), Handler: handler, ReadHeaderTimeout: 5 * time.Second}
+	go server.Serve(listener)
+	return server, nil
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/hooks"
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+)
+
+// newTestProject 后端端口 8888、前端默认 8080 的项目
+func newTestProject(t *testing.T) *launcher.Project {
+	t.Helper()
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "web"), 0755)
+	os.MkdirAll(filepath.Join(root, "server"), 0755)
+	if err := os.WriteFile(filepath.Join(root, "server", "config.yaml"), []byte("system:\n  addr: 8888\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return launcher.NewProject(root)
+}
+
+func TestCollect(t *testing.T) {
+	portInUse = func(port int) bool { return port == 8888 }
+	defer func() { portInUse = services.IsPortInUse }()
+
+	project := newTestProject(t)
+	manager := launcher.NewServiceManager(project)
+	manager.Hooks = hooks.NewDispatcher(nil, nil)
+	manager.Backend.MarkStarted(8888)
+	manager.Hooks.Fire(hooks.OnCrash, hooks.Vars{"service": "frontend"})
+
+	snap := Collect(ModeWatchdog, project, manager)
+	if len(snap.Services) != 2 {
+		t.Fatalf("Services = %+v", snap.Services)
+	}
+	backend, frontend := snap.Services[0], snap.Services[1]
+	if !backend.Running || backend.Port != 8888 || backend.StartedAt == nil {
+		t.Errorf("backend = %+v", backend)
+	}
+	if frontend.Running || frontend.Port != 8080 || frontend.StartedAt != nil {
+		t.Errorf("frontend = %+v", frontend)
+	}
+	if snap.Counts["on-crash"] != 1 || len(snap.Events) != 1 {
+		t.Errorf("Counts = %v, Events = %v", snap.Counts, snap.Events)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	started := time.Unix(1700000000, 0)
+	snap := Snapshot{
+		Version: "v1.0.0",
+		Mode:    ModePanel,
+		Root:    `C:\gva "demo"`,
+		Services: []Service{
+			{Name: "backend", Port: 8888, Running: true, StartedAt: &started},
+			{Name: "frontend", Port: 8080},
+		},
+		Counts: map[string]int{"on-crash": 2},
+		Events: []hooks.Record{{Event: hooks.OnCrash, Time: time.Unix(1700000100, 0)}},
+	}
+
+	var b strings.Builder
+	WritePrometheus(&b, snap)
+	out := b.String()
+	for _, want := range []string{
+		`gvapanel_info{version="v1.0.0",mode="panel",root="C:\\gva \"demo\""} 1`,
+		`gvapanel_service_up{service="backend",port="8888"} 1`,
+		`gvapanel_service_up{service="frontend",port="8080"} 0`,
+		`gvapanel_service_start_time_seconds{service="backend"} 1700000000`,
+		`gvapanel_events_total{event="on-crash"} 2`,
+		`gvapanel_events_total{event="before-start"} 0`,
+		`gvapanel_last_event_timestamp_seconds{event="on-crash"} 1700000100`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("输出缺少 %s\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `gvapanel_service_start_time_seconds{service="frontend"}`) {
+		t.Error("未由面板启动的服务不应输出启动时间")
+	}
+}
+
+func TestServe(t *testing.T) {
+	snap := Snapshot{Version: "v1.0.0", Mode: ModePanel, Services: []Service{{Name: "backend", Port: 8888, Running: true}}}
+	server, err := Serve("127.0.0.1:0", Handler(func() Snapshot { return snap }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	base := "http://" + server.Addr
+
+	resp, err := http.Get(base + "/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var got Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != "v1.0.0" || len(got.Services) != 1 || !got.Services[0].Running {
+		t.Errorf("/status = %+v", got)
+	}
+
+	resp, err = http.Get(base + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `gvapanel_service_up{service="backend",port="8888"} 1`) {
+		t.Errorf("/metrics = %s", body)
+	}
+}
@@ -0,0 +1,205 @@
+// Package npmscript web/package.json 中的 npm 脚本：按文件中的顺序列出全部脚本（build、lint、preview、test:unit 等），
+// 在后台用 npm run 运行并把输出写入每个脚本各自的缓冲，多个脚本可以同时运行，长期运行的脚本（preview）可随时结束
+package npmscript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/outputbuf"
+)
+
+// ServeScript 面板作为前端服务启动的脚本（在「启动」中运行，不在脚本列表中重复运行）
+const ServeScript = "serve"
+
+// colorEnv 输出不是终端时 Vite、ESLint 等默认不带颜色，运行脚本时强制开启（由面板按颜色显示）
+const colorEnv = "FORCE_COLOR=1"
+
+// maxOutput 每个脚本在内存中保留的输出
+const maxOutput = 1 << 20
+
+// ErrRunning 脚本正在运行
+var ErrRunning = errors.New("脚本正在运行，可先结束它")
+
+// Script package.json 中的一个脚本
+type Script struct {
+	Name    string
+	Command string
+}
+
+// List 读取 webDir/package.json 中的脚本（保持文件中的顺序）
+func List(webDir string) ([]Script, error) {
+	data, err := os.ReadFile(filepath.Join(webDir, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+	return parseScripts(data)
+}
+
+// parseScripts 按出现顺序解析 package.json 的 scripts（encoding/json 解析到 map 时会丢失顺序，这里逐个读取键值）
+func parseScripts(data []byte) ([]Script, error) {
+	var pkg struct {
+		Scripts json.RawMessage `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("package.json 格式错误: %w", err)
+	}
+	if len(pkg.Scripts) == 0 || string(pkg.Scripts) == "null" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(pkg.Scripts))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("package.json 的 scripts 不是对象")
+	}
+	var scripts []Script
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("package.json 格式错误: %w", err)
+		}
+		var command any
+		if err := dec.Decode(&command); err != nil {
+			return nil, fmt.Errorf("package.json 格式错误: %w", err)
+		}
+		if text, ok := command.(string); ok {
+			scripts = append(scripts, Script{Name: tok.(string), Command: text})
+		}
+	}
+	return scripts, nil
+}
+
+// State 脚本最近一次运行的状态
+type State int
+
+const (
+	Idle      State = iota // 还没有运行过
+	Running                // 正在运行
+	Succeeded              // 上次运行成功
+	Failed                 // 上次运行失败
+	Stopped                // 上次运行被结束
+)
+
+// Icon 状态图标（列表中显示）
+func (s State) Icon() string {
+	switch s {
+	case Running:
+		return "⏳"
+	case Succeeded:
+		return "✅"
+	case Failed:
+		return "❌"
+	case Stopped:
+		return "⏹"
+	}
+	return "▫️"
+}
+
+// run 一个脚本的输出和运行状态
+type run struct {
+	output *outputbuf.Buffer
+	state  State
+	cancel context.CancelFunc
+}
+
+// Runner 在 Dir（web 目录）中运行脚本
+type Runner struct {
+	Dir string
+	Env []string // 在面板的环境变量基础上追加的变量（另外总是追加 FORCE_COLOR=1）
+
+	mu   sync.Mutex
+	runs map[string]*run
+}
+
+// NewRunner 创建在 webDir 中运行脚本的 Runner
+func NewRunner(webDir string, env []string) *Runner {
+	return &Runner{Dir: webDir, Env: env, runs: make(map[string]*run)}
+}
+
+// get 返回脚本的运行记录（没有时创建），调用方持有锁
+func (r *Runner) get(name string) *run {
+	rn, ok := r.runs[name]
+	if !ok {
+		rn = &run{output: outputbuf.New(maxOutput, 0, "")}
+		r.runs[name] = rn
+	}
+	return rn
+}
+
+// Output 脚本的输出（多次运行的输出保存在同一个缓冲中，每次运行前后写一行分隔）
+func (r *Runner) Output(name string) *outputbuf.Buffer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.get(name).output
+}
+
+// State 脚本最近一次运行的状态
+func (r *Runner) State(name string) State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rn, ok := r.runs[name]; ok {
+		return rn.state
+	}
+	return Idle
+}
+
+// Stop 结束正在运行的脚本（包括 npm 启动的子进程）
+func (r *Runner) Stop(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rn, ok := r.runs[name]; ok && rn.cancel != nil {
+		rn.cancel()
+	}
+}
+
+// StopAll 结束所有正在运行的脚本
+func (r *Runner) StopAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rn := range r.runs {
+		if rn.cancel != nil {
+			rn.cancel()
+		}
+	}
+}
+
+// Run 运行 npm run name 并等待结束（在后台协程中调用），ctx 取消或 Stop 时结束进程；
+// 脚本已在运行时返回 ErrRunning
+func (r *Runner) Run(ctx context.Context, name string) error {
+	r.mu.Lock()
+	rn := r.get(name)
+	if rn.cancel != nil {
+		r.mu.Unlock()
+		return ErrRunning
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	rn.cancel, rn.state = cancel, Running
+	r.mu.Unlock()
+	defer cancel()
+
+	start := time.Now()
+	rn.output.Println(fmt.Sprintf("===== %s 运行: npm run %s =====", start.Format(time.DateTime), name))
+	err := sysutil.RunOutputEnvContext(ctx, r.Dir, append(slices.Clip(r.Env), colorEnv), rn.output, "npm", "run", name)
+
+	state, ended := Succeeded, "已完成"
+	switch {
+	case ctx.Err() != nil:
+		state, ended, err = Stopped, "已结束", ctx.Err()
+	case err != nil:
+		state, ended = Failed, "失败: "+err.Error()
+	}
+	// 先更新状态再写分隔行，跟踪输出的界面刷新时读到的是结束后的状态
+	r.mu.Lock()
+	rn.cancel, rn.state = nil, state
+	r.mu.Unlock()
+	rn.output.Println(fmt.Sprintf("===== %s %s（耗时 %s）=====", time.Now().Format(time.DateTime), ended, time.Since(start).Round(time.Second)))
+	return err
+}
@@ -0,0 +1,107 @@
+package npmscript
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestList(t *testing.T) {
+	web := t.TempDir()
+	pkg := `{
+  "name": "gin-vue-admin",
+  "scripts": {
+    "serve": "node openDocument.js && vite --host --mode development",
+    "build": "vite build --mode production",
+    "preview": "vite preview",
+    "test:unit": "vitest",
+    "lint": "eslint --fix src",
+    "weird": {"nested": true}
+  },
+  "dependencies": {"vue": "^3.4.0"}
+}`
+	os.WriteFile(filepath.Join(web, "package.json"), []byte(pkg), 0644)
+	scripts, err := List(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range scripts {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, " ") != "serve build preview test:unit lint" {
+		t.Errorf("应按文件中的顺序列出字符串脚本: %v", names)
+	}
+	if scripts[1].Command != "vite build --mode production" {
+		t.Errorf("build = %q", scripts[1].Command)
+	}
+
+	for _, data := range []string{`{"name":"x"}`, `{"scripts":null}`, `{"scripts":{}}`} {
+		if scripts, err := parseScripts([]byte(data)); err != nil || len(scripts) != 0 {
+			t.Errorf("%s: scripts = %v, err = %v", data, scripts, err)
+		}
+	}
+	for _, data := range []string{`{"scripts":`, `{"scripts":["build"]}`} {
+		if _, err := parseScripts([]byte(data)); err == nil {
+			t.Errorf("%s 应报错", data)
+		}
+	}
+	if _, err := List(filepath.Join(web, "missing")); err == nil {
+		t.Error("没有 package.json 时应报错")
+	}
+}
+
+func TestRun(t *testing.T) {
+	web := t.TempDir()
+	fake := sysutiltest.New(t)
+	fake.Handle("npm run lint", "\x1b[32m✔\x1b[39m no problems\n", nil)
+	fake.Handle("npm run test:unit", "1 failed\n", errors.New("exit status 1"))
+
+	r := NewRunner(web, []string{"npm_config_registry=https://registry.npmmirror.com"})
+	if r.State("lint") != Idle {
+		t.Errorf("state = %v", r.State("lint"))
+	}
+	if err := r.Run(context.Background(), "lint"); err != nil {
+		t.Fatal(err)
+	}
+	if r.State("lint") != Succeeded {
+		t.Errorf("state = %v", r.State("lint"))
+	}
+	lines := r.Output("lint").Lines()
+	if len(lines) != 3 || !strings.Contains(lines[0], "运行: npm run lint") || !strings.Contains(lines[1], "no problems") || !strings.Contains(lines[2], "已完成") {
+		t.Errorf("lines = %q", lines)
+	}
+
+	if err := r.Run(context.Background(), "test:unit"); err == nil {
+		t.Error("脚本失败时应返回错误")
+	}
+	if r.State("test:unit") != Failed || !strings.Contains(strings.Join(r.Output("test:unit").Lines(), "\n"), "失败: exit status 1") {
+		t.Errorf("state = %v, lines = %q", r.State("test:unit"), r.Output("test:unit").Lines())
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 || calls[0].Dir != web || strings.Join(calls[0].Env, " ") != "npm_config_registry=https://registry.npmmirror.com FORCE_COLOR=1" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	sysutiltest.New(t).Handle("npm run preview", "", nil)
+	r := NewRunner(t.TempDir(), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := r.Run(ctx, "preview"); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v", err)
+	}
+	if r.State("preview") != Stopped {
+		t.Errorf("state = %v", r.State("preview"))
+	}
+	// 没有在运行的脚本时结束不会出错
+	r.Stop("preview")
+	r.StopAll()
+}
@@ -0,0 +1,313 @@
+// Package outputbuf 服务进程输出的有界缓冲：内存中按行保留最近的输出（总大小有上限），
+// 超出上限被挤出的旧行追加写入磁盘文件（文件同样有大小上限，超出后轮换为 .1），
+// 长时间运行、输出频繁的 Vite 开发服务器也不会让面板的内存无限增长
+package outputbuf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 默认上限
+const (
+	DefaultMemoryBytes = 2 << 20  // 内存中保留的输出
+	DefaultSpillBytes  = 20 << 20 // 单个溢出文件的大小（轮换后磁盘上最多保留两个）
+	maxLineBytes       = 64 << 10 // 单行的最大长度（超出的部分截断，没有换行的输出到达该长度时按一行处理）
+)
+
+// Buffer 有界输出缓冲（实现 io.Writer，可在多个协程中使用）
+type Buffer struct {
+	maxMemory int
+	maxSpill  int64
+	spillPath string
+
+	mu        sync.Mutex
+	lines     []string // 内存中的行，lines[head:] 有效
+	head      int
+	size      int    // 内存中各行的总字节数
+	partial   string // 尚未收到换行的内容
+	total     int    // 写入过的总行数（清空后继续递增，作为 Since 的序号）
+	spilled   int    // 写入磁盘的行数
+	spill     *os.File
+	spillSize int64
+	spillErr  error // 第一次写溢出文件失败的原因（之后不再尝试，被挤出的行直接丢弃）
+	watchers  map[chan struct{}]struct{}
+}
+
+// New 创建缓冲；maxMemory、maxSpill 不大于 0 时使用默认值，spillPath 为空时被挤出的行直接丢弃
+func New(maxMemory int, maxSpill int64, spillPath string) *Buffer {
+	if maxMemory <= 0 {
+		maxMemory = DefaultMemoryBytes
+	}
+	if maxSpill <= 0 {
+		maxSpill = DefaultSpillBytes
+	}
+	return &Buffer{maxMemory: maxMemory, maxSpill: maxSpill, spillPath: spillPath}
+}
+
+// Write 接收进程输出，按行保存（\r\n 和 \n 都视为换行）
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	text := b.partial + string(p)
+	parts := strings.Split(text, "\n")
+	b.partial = parts[len(parts)-1]
+	for _, line := range parts[:len(parts)-1] {
+		b.add(strings.TrimRight(line, "\r"))
+	}
+	if len(b.partial) >= maxLineBytes {
+		// 按一行处理时同样截断在完整的字符处；被截开的字符留到下一次写入补全
+		cut := runeCut(b.partial, maxLineBytes)
+		rest := b.partial[cut:]
+		b.add(b.partial[:cut])
+		b.partial = ""
+		if len(rest) < utf8.UTFMax && !utf8.FullRuneInString(rest) {
+			b.partial = rest
+		}
+	}
+	b.notify()
+	return len(p), nil
+}
+
+// Println 追加一行面板自己的说明（例如服务启动的分隔行）
+func (b *Buffer) Println(line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.partial != "" {
+		b.add(b.partial)
+		b.partial = ""
+	}
+	b.add(line)
+	b.notify()
+}
+
+// Watch 订阅新输出：每次写入或清空后向返回的通道发送通知（通道有 1 个缓冲，来不及处理的通知合并为一次），
+// 调用返回的函数取消订阅
+func (b *Buffer) Watch() (<-chan struct{}, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	if b.watchers == nil {
+		b.watchers = make(map[chan struct{}]struct{})
+	}
+	b.watchers[ch] = struct{}{}
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.watchers, ch)
+	}
+}
+
+// notify 通知订阅者（不阻塞，调用方持有锁）
+func (b *Buffer) notify() {
+	for ch := range b.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// add 追加一行，超出内存上限时把最旧的行移到溢出文件（调用方持有锁）
+func (b *Buffer) add(line string) {
+	if len(line) > maxLineBytes {
+		line = line[:runeCut(line, maxLineBytes)]
+	}
+	b.lines = append(b.lines, line)
+	b.size += len(line) + 1
+	b.total++
+
+	var evicted []string
+	for b.size > b.maxMemory && b.head < len(b.lines)-1 {
+		old := b.lines[b.head]
+		b.lines[b.head] = ""
+		b.head++
+		b.size -= len(old) + 1
+		evicted = append(evicted, old)
+	}
+	// 有效部分不到一半时整理切片，释放前面已挤出的行
+	if b.head > len(b.lines)/2 {
+		b.lines = append([]string(nil), b.lines[b.head:]...)
+		b.head = 0
+	}
+	if len(evicted) > 0 {
+		b.writeSpill(evicted)
+	}
+}
+
+// runeCut 把 s 截断到不超过 n 字节时的截断位置，不留下半个 UTF-8 字符（包括 s 本身以不完整的字符结尾时）
+func runeCut(s string, n int) int {
+	if len(s) > n {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return n
+	}
+	n = len(s)
+	start := n - 1
+	for start > 0 && n-start < utf8.UTFMax && !utf8.RuneStart(s[start]) {
+		start--
+	}
+	if start >= 0 && !utf8.FullRuneInString(s[start:]) {
+		return start
+	}
+	return n
+}
+
+// writeSpill 把被挤出的行追加到溢出文件，文件超出上限时轮换（调用方持有锁）
+func (b *Buffer) writeSpill(lines []string) {
+	if b.spillPath == "" || b.spillErr != nil {
+		return
+	}
+	if b.spill == nil {
+		if b.spillErr = os.MkdirAll(filepath.Dir(b.spillPath), 0755); b.spillErr != nil {
+			return
+		}
+		f, err := os.OpenFile(b.spillPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			b.spillErr = err
+			return
+		}
+		info, _ := f.Stat()
+		b.spill = f
+		if info != nil {
+			b.spillSize = info.Size()
+		}
+	}
+
+	data := strings.Join(lines, "\n") + "\n"
+	if b.spillSize > 0 && b.spillSize+int64(len(data)) > b.maxSpill {
+		b.spill.Close()
+		b.spill = nil
+		os.Rename(b.spillPath, b.spillPath+".1")
+		f, err := os.OpenFile(b.spillPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			b.spillErr = err
+			return
+		}
+		b.spill = f
+		b.spillSize = 0
+	}
+	n, err := b.spill.WriteString(data)
+	b.spillSize += int64(n)
+	if err != nil {
+		b.spillErr = err
+		return
+	}
+	b.spilled += len(lines)
+}
+
+// Lines 内存中保留的行（包括尚未换行的内容），从旧到新
+func (b *Buffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := append([]string(nil), b.lines[b.head:]...)
+	if b.partial != "" {
+		lines = append(lines, b.partial)
+	}
+	return lines
+}
+
+// Tail 最近的 n 行
+func (b *Buffer) Tail(n int) []string {
+	lines := b.Lines()
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// Since 序号 seq 之后新增的完整行（行的序号从 1 开始，seq 为 0 表示内存中的所有行）以及最后一行的序号，
+// 调用方保存返回的序号用于下一次读取；中间的行已被挤出内存时从内存中最早的行开始。
+// 清空缓冲不重置序号，清空前读取过的调用方照常读到清空后新增的所有行
+func (b *Buffer) Since(seq int) ([]string, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if seq > b.total {
+		seq = 0
+	}
+	first := b.total - (len(b.lines) - b.head) // 内存中第一行之前的序号
+	skip := max(seq-first, 0)
+	return append([]string(nil), b.lines[b.head+skip:]...), b.total
+}
+
+// Stats 写入过的总行数（包括清空前的）、移到磁盘的行数和内存中占用的字节数
+func (b *Buffer) Stats() (total, spilled, memoryBytes int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total, b.spilled, b.size + len(b.partial)
+}
+
+// SpillPath 溢出文件的路径（轮换后的旧文件为 SpillPath() + ".1"）
+func (b *Buffer) SpillPath() string {
+	return b.spillPath
+}
+
+// SpillErr 写溢出文件失败的原因（没有失败时为 nil）
+func (b *Buffer) SpillErr() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spillErr
+}
+
+// ReadAll 完整的输出：磁盘上的溢出文件（包括轮换的旧文件）加上内存中的行
+func (b *Buffer) ReadAll() (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var sb strings.Builder
+	if b.spillPath != "" {
+		for _, path := range []string{b.spillPath + ".1", b.spillPath} {
+			data, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return "", err
+			}
+			sb.Write(data)
+		}
+	}
+	for _, line := range b.lines[b.head:] {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(b.partial)
+	return sb.String(), nil
+}
+
+// Clear 清空内存中的输出并删除溢出文件
+func (b *Buffer) Clear() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lines, b.head, b.size, b.partial = nil, 0, 0, ""
+	b.spilled, b.spillSize, b.spillErr = 0, 0, nil
+	b.notify()
+	if b.spill != nil {
+		b.spill.Close()
+		b.spill = nil
+	}
+	if b.spillPath == "" {
+		return nil
+	}
+	for _, path := range []string{b.spillPath, b.spillPath + ".1"} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 关闭溢出文件（之后再写入时重新打开）
+func (b *Buffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spill == nil {
+		return nil
+	}
+	err := b.spill.Close()
+	b.spill = nil
+	return err
+}
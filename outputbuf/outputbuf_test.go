@@ -0,0 +1,179 @@
+package outputbuf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWriteSplitsLines(t *testing.T) {
+	b := New(0, 0, "")
+	fmt.Fprint(b, "VITE v5.0.0  ready\r\n  ➜  Local:   ")
+	fmt.Fprint(b, "http://localhost:8080/\n  ➜  Network")
+	want := []string{"VITE v5.0.0  ready", "  ➜  Local:   http://localhost:8080/", "  ➜  Network"}
+	if got := b.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines = %q", got)
+	}
+	if got := b.Tail(1); !reflect.DeepEqual(got, want[2:]) {
+		t.Errorf("Tail = %q", got)
+	}
+	b.Println("=== 停止 ===")
+	if total, _, _ := b.Stats(); total != 4 {
+		t.Errorf("total = %d", total)
+	}
+}
+
+func TestMemoryCapSpillsToDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "frontend-output.log")
+	b := New(100, 0, path)
+	defer b.Close()
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(b, "line %02d\n", i) // 每行 8 字节 + 换行
+	}
+
+	total, spilled, memory := b.Stats()
+	if total != 50 || memory > 100 || spilled != 50-len(b.Lines()) {
+		t.Errorf("total = %d, spilled = %d, memory = %d, lines = %d", total, spilled, memory, len(b.Lines()))
+	}
+	if last := b.Tail(1); last[0] != "line 49" {
+		t.Errorf("last line = %q", last)
+	}
+
+	all, err := b.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(all, "\n"), "\n")
+	if len(lines) != 50 || lines[0] != "line 00" || lines[49] != "line 49" {
+		t.Errorf("ReadAll returned %d lines: %q ... %q", len(lines), lines[0], lines[len(lines)-1])
+	}
+}
+
+func TestSpillRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backend-output.log")
+	b := New(10, 100, path)
+	defer b.Close()
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(b, "line %02d\n", i)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > 100 {
+		t.Fatalf("spill file: %v, %v", info, err)
+	}
+	old, err := os.Stat(path + ".1")
+	if err != nil || old.Size() > 100 {
+		t.Fatalf("rotated file: %v, %v", old, err)
+	}
+
+	// 轮换后最早的输出被丢弃，但保留的内容仍然连续
+	all, _ := b.ReadAll()
+	if !strings.HasSuffix(all, "line 98\nline 99\n") || strings.Contains(all, "line 00\n") {
+		t.Errorf("ReadAll = %q", all)
+	}
+
+	if err := b.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Error("Clear should remove rotated spill file")
+	}
+	if len(b.Lines()) != 0 {
+		t.Error("Clear should drop lines in memory")
+	}
+}
+
+func TestLongLineWithoutNewline(t *testing.T) {
+	b := New(1<<20, 0, "")
+	b.Write([]byte(strings.Repeat("x", maxLineBytes+10)))
+	lines := b.Lines()
+	if len(lines) != 1 || len(lines[0]) != maxLineBytes {
+		t.Fatalf("lines = %d, first = %d bytes", len(lines), len(lines[0]))
+	}
+}
+
+func TestLongLineKeepsWholeRunes(t *testing.T) {
+	b := New(1<<20, 0, "")
+	// 「中」占 3 个字节，maxLineBytes 处正好在字符中间
+	b.Println("xx" + strings.Repeat("中", maxLineBytes/3+1))
+	line := b.Lines()[0]
+	if !utf8.ValidString(line) || len(line) != maxLineBytes-2 {
+		t.Errorf("截断后 %d 字节, valid = %v", len(line), utf8.ValidString(line))
+	}
+}
+
+func TestPartialLineKeepsWholeRunes(t *testing.T) {
+	b := New(1<<20, 0, "")
+	// 没有换行的输出正好到达 maxLineBytes 时，最后的「中」只写入了前 2 个字节
+	b.Write([]byte(strings.Repeat("x", maxLineBytes-2) + "中"[:2]))
+	b.Write([]byte("中"[2:] + "文\n"))
+	lines := b.Lines()
+	if len(lines) != 2 || lines[0] != strings.Repeat("x", maxLineBytes-2) || lines[1] != "中文" {
+		t.Fatalf("lines = %d, last = %q", len(lines), lines[len(lines)-1])
+	}
+}
+
+func TestWithoutSpillPath(t *testing.T) {
+	b := New(20, 0, "")
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(b, "line %d\n", i)
+	}
+	if _, spilled, memory := b.Stats(); spilled != 0 || memory > 20 {
+		t.Errorf("spilled = %d, memory = %d", spilled, memory)
+	}
+	if b.SpillErr() != nil {
+		t.Error(b.SpillErr())
+	}
+}
+
+func TestSinceAndWatch(t *testing.T) {
+	b := New(20, 0, "")
+	ch, cancel := b.Watch()
+	fmt.Fprint(b, "a\nb\npartial")
+	select {
+	case <-ch:
+	default:
+		t.Fatal("写入后应收到通知")
+	}
+
+	lines, seq := b.Since(0)
+	if !reflect.DeepEqual(lines, []string{"a", "b"}) || seq != 2 {
+		t.Errorf("Since(0) = %q, %d", lines, seq)
+	}
+	fmt.Fprint(b, "\nc\n")
+	if lines, seq = b.Since(seq); !reflect.DeepEqual(lines, []string{"partial", "c"}) || seq != 4 {
+		t.Errorf("Since(2) = %q, %d", lines, seq)
+	}
+
+	// 读取不及时、中间的行已被挤出内存时从内存中最早的行开始
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(b, "line %d\n", i)
+	}
+	if lines, seq = b.Since(seq); len(lines) == 0 || lines[len(lines)-1] != "line 9" || seq != 14 || len(lines) >= 10 {
+		t.Errorf("Since(4) = %q, %d", lines, seq)
+	}
+
+	cancel()
+	<-ch
+	b.Clear()
+	select {
+	case <-ch:
+		t.Error("取消订阅后不应再收到通知")
+	default:
+	}
+	// 清空后序号继续递增，清空后新增的行不会因为序号不大于上次读取的位置而被跳过
+	b.Println("restart")
+	if lines, seq = b.Since(seq); !reflect.DeepEqual(lines, []string{"restart"}) || seq != 15 {
+		t.Errorf("清空后 Since = %q, %d", lines, seq)
+	}
+	b.Clear()
+	for i := 0; i < 2; i++ {
+		b.Println(fmt.Sprintf("after %d", i))
+	}
+	if lines, seq = b.Since(seq); !reflect.DeepEqual(lines, []string{"after 0", "after 1"}) || seq != 17 {
+		t.Errorf("再次清空后 Since = %q, %d", lines, seq)
+	}
+}
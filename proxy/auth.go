@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+)
+
+// 访问保护方式
+const (
+	AuthNone  = ""      // 不保护
+	AuthBasic = "basic" // 用户名密码（HTTP Basic Auth）
+	AuthToken = "token" // 访问令牌（分享链接中带上令牌，首次访问后保存到 Cookie）
+)
+
+// TokenParam 分享链接中携带访问令牌的参数名（同时作为 Cookie 名）
+const TokenParam = "gvapanel_token"
+
+// Auth 单端口代理的访问保护
+type Auth struct {
+	Mode     string // AuthNone、AuthBasic 或 AuthToken
+	Username string // AuthBasic 的用户名
+	Password string // AuthBasic 的密码
+	Token    string // AuthToken 的访问令牌
+}
+
+// NewToken 生成随机访问令牌
+func NewToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Protect 为 next 加上访问保护（AuthNone 时原样返回）
+func Protect(next http.Handler, auth Auth) http.Handler {
+	switch auth.Mode {
+	case AuthBasic:
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			if !ok || !equal(user, auth.Username) || !equal(pass, auth.Password) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="GVAPanel", charset="UTF-8"`)
+				deny(w, http.StatusUnauthorized, "请输入面板设置的用户名和密码")
+				return
+			}
+			// GVA 接口使用 x-token 鉴权，去掉 Basic 凭据，避免转发给前后端
+			r.Header.Del("Authorization")
+			next.ServeHTTP(w, r)
+		})
+	case AuthToken:
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := r.URL.Query().Get(TokenParam); token != "" && equal(token, auth.Token) {
+				// 分享链接首次访问：令牌保存到 Cookie，再跳转到不带令牌的地址，避免令牌留在地址栏和历史记录中
+				http.SetCookie(w, &http.Cookie{Name: TokenParam, Value: token, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+				query := r.URL.Query()
+				query.Del(TokenParam)
+				target := *r.URL
+				target.RawQuery = query.Encode()
+				http.Redirect(w, r, target.RequestURI(), http.StatusFound)
+				return
+			}
+			if cookie, err := r.Cookie(TokenParam); err == nil && equal(cookie.Value, auth.Token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			deny(w, http.StatusForbidden, "请使用面板分享的完整链接（包含访问令牌）访问")
+		})
+	default:
+		return next
+	}
+}
+
+// equal 以固定时间比较字符串，避免通过响应时间猜测密码
+func equal(a, b string) bool {
+	return b != "" && subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// deny 返回拒绝访问的说明
+func deny(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write([]byte("GVAPanel 单端口代理: " + message + "\n"))
+}
@@ -0,0 +1,104 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// echoHandler 返回请求路径和是否带有 Authorization
+func echoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.URL.RequestURI()+" auth="+r.Header.Get("Authorization"))
+	})
+}
+
+func TestProtectNone(t *testing.T) {
+	w := httptest.NewRecorder()
+	Protect(echoHandler(), Auth{}).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("不保护时应直接转发, code = %d", w.Code)
+	}
+}
+
+func TestProtectBasic(t *testing.T) {
+	h := Protect(echoHandler(), Auth{Mode: AuthBasic, Username: "demo", Password: "s3cret"})
+
+	cases := []struct {
+		user, pass string
+		set        bool
+		code       int
+	}{
+		{set: false, code: http.StatusUnauthorized},
+		{user: "demo", pass: "wrong", set: true, code: http.StatusUnauthorized},
+		{user: "demo", pass: "s3cret", set: true, code: http.StatusOK},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/api/base/login", nil)
+		if c.set {
+			r.SetBasicAuth(c.user, c.pass)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != c.code {
+			t.Errorf("%s/%s: code = %d, want %d", c.user, c.pass, w.Code, c.code)
+		}
+		if c.code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Error("401 应带 WWW-Authenticate 头")
+		}
+		if c.code == http.StatusOK && w.Body.String() != "/api/base/login auth=" {
+			t.Errorf("Basic 凭据不应转发: %q", w.Body.String())
+		}
+	}
+}
+
+func TestProtectToken(t *testing.T) {
+	h := Protect(echoHandler(), Auth{Mode: AuthToken, Token: "abc123"})
+
+	// 没有令牌或令牌错误时拒绝
+	for _, target := range []string{"/", "/?gvapanel_token=wrong"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: code = %d, want 403", target, w.Code)
+		}
+	}
+
+	// 分享链接：保存 Cookie 并跳转到不带令牌的地址
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/dashboard?x=1&gvapanel_token=abc123", nil))
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/dashboard?x=1" {
+		t.Fatalf("code = %d, location = %q", w.Code, w.Header().Get("Location"))
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != TokenParam || !cookies[0].HttpOnly {
+		t.Fatalf("cookies = %v", cookies)
+	}
+
+	// 之后的请求凭 Cookie 访问
+	r := httptest.NewRequest("GET", "/src/main.js", nil)
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "/src/main.js") {
+		t.Errorf("code = %d, body = %q", w.Code, w.Body.String())
+	}
+}
+
+func TestProtectEmptyCredentials(t *testing.T) {
+	// 未设置令牌时任何请求都不能通过
+	h := Protect(echoHandler(), Auth{Mode: AuthToken})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: TokenParam, Value: ""})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("code = %d, want 403", w.Code)
+	}
+
+	if a, b := NewToken(), NewToken(); len(a) != 32 || a == b {
+		t.Errorf("NewToken = %q, %q", a, b)
+	}
+}
@@ -0,0 +1,78 @@
+// Package proxy 是单端口访问使用的反向代理：同一个端口上把 API 前缀（默认 /api）转发到后端，
+// 其余请求（页面、静态资源、Vite 热更新 WebSocket）转发到前端开发服务器。
+// 局域网用户只需要访问一个端口，浏览器看到的前后端同源，不再有跨域问题
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAddr 默认监听地址（所有网卡，局域网可访问）
+const DefaultAddr = "0.0.0.0:8800"
+
+// Targets 转发目标（每个请求都会重新读取，修改端口后无需重启代理）
+type Targets struct {
+	FrontendPort  int
+	BackendPort   int
+	FrontendHTTPS bool   // 前端开发服务器已开启本地 HTTPS
+	APIPrefix     string // 转发到后端的路径前缀（转发时去掉），例如 /api
+}
+
+// Handler 创建反向代理
+func Handler(targets func() Targets) http.Handler {
+	// 前端使用本地 HTTPS 时跳过证书校验（只连接本机回环地址）
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	rp := &httputil.ReverseProxy{
+		Transport: transport,
+		Rewrite: func(r *httputil.ProxyRequest) {
+			t := targets()
+			// 使用 localhost 而不是 127.0.0.1：Node 17+ 下 Vite 可能只监听 [::1]，拨号时依次尝试 IPv4 和 IPv6 回环地址
+			target := &url.URL{Scheme: "http", Host: fmt.Sprintf("localhost:%d", t.FrontendPort)}
+
+			prefix := strings.TrimRight(t.APIPrefix, "/")
+			path := r.In.URL.Path
+			if prefix != "" && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+				// 与 GVA 前端的 Vite 代理相同：去掉 API 前缀后转发到后端
+				target.Host = fmt.Sprintf("localhost:%d", t.BackendPort)
+				r.Out.URL.Path = strings.TrimPrefix(path, prefix)
+				r.Out.URL.RawPath = ""
+				if r.Out.URL.Path == "" {
+					r.Out.URL.Path = "/"
+				}
+			} else if t.FrontendHTTPS {
+				target.Scheme = "https"
+			}
+
+			r.SetURL(target)
+			r.SetXForwarded()
+			// 保留浏览器访问的 Host，前端生成的链接和热更新地址仍指向代理端口
+			r.Out.Host = r.In.Host
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprintf(w, "GVAPanel 单端口代理: 无法连接服务（%v）\n请确认前后端服务已在面板中启动。\n", err)
+		},
+	}
+	return rp
+}
+
+// Serve 在 addr 上启动代理（后台运行），返回的 Server.Addr 为实际监听地址，可调用 Close 关闭
+func Serve(addr string, handler http.Handler) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("单端口代理监听 %s 失败: %v", addr, err)
+	}
+	server := &http.Server{Addr: listener.Addr().String(), Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	return server, nil
+}
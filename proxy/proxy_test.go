@@ -0,0 +1,86 @@
+package proxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// echoServer 返回 "名称 路径" 的测试服务，以及它的端口
+func echoServer(t *testing.T, name string) int {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, name+" "+r.URL.RequestURI())
+	}))
+	t.Cleanup(server.Close)
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	n, _ := strconv.Atoi(port)
+	return n
+}
+
+// get 请求代理并返回状态码和内容
+func get(t *testing.T, base, path string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(base + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestProxyRoutesAPIToBackend(t *testing.T) {
+	targets := Targets{
+		FrontendPort: echoServer(t, "frontend"),
+		BackendPort:  echoServer(t, "backend"),
+		APIPrefix:    "/api",
+	}
+	server, err := Serve("127.0.0.1:0", Handler(func() Targets { return targets }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	base := "http://" + server.Addr
+
+	cases := map[string]string{
+		"/":                     "frontend /",
+		"/src/main.js":          "frontend /src/main.js",
+		"/api/base/login?x=1":   "backend /base/login?x=1",
+		"/api":                  "backend /",
+		"/apidoc":               "frontend /apidoc",
+		"/api/user/getUserInfo": "backend /user/getUserInfo",
+	}
+	for path, want := range cases {
+		if code, body := get(t, base, path); code != http.StatusOK || body != want {
+			t.Errorf("%s: %d %q, want %q", path, code, body, want)
+		}
+	}
+}
+
+func TestProxyUpstreamDown(t *testing.T) {
+	// 先占用再释放一个端口，保证没有服务监听
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	server, err := Serve("127.0.0.1:0", Handler(func() Targets {
+		return Targets{FrontendPort: port, BackendPort: port, APIPrefix: "/api"}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	code, body := get(t, "http://"+server.Addr, "/")
+	if code != http.StatusBadGateway || !strings.Contains(body, "请确认前后端服务已在面板中启动") {
+		t.Errorf("%d %q", code, body)
+	}
+}
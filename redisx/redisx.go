@@ -0,0 +1,138 @@
+// Package redisx 提供不依赖第三方客户端的 Redis 连接测试（直接使用 RESP 文本命令）
+package redisx
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+// 测试时写入的临时键
+const testKey = "gva_launcher_test"
+
+// TestConnection 测试 Redis 连接（包含完整的认证和功能测试）
+// dialTimeout 为 TCP 连接超时；返回每个步骤的结果描述，任一步骤失败时返回带排查建议的错误
+func TestConnection(addr, password string, db int, dialTimeout time.Duration) ([]string, error) {
+	var testResults []string
+
+	// 1. TCP连接测试
+	testResults = append(testResults, "🔍 步骤1: TCP连接测试")
+
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return testResults, apperr.Errorf(apperr.RedisConnect, "❌ TCP连接失败: %v\n\n请检查:\n1. Redis 地址是否正确 (%s)\n2. Redis 服务是否启动\n3. 防火墙设置\n4. 网络连接", err, addr)
+	}
+	defer conn.Close()
+	testResults = append(testResults, "✅ TCP连接成功")
+
+	// 2. Redis协议握手测试
+	testResults = append(testResults, "\n🔍 步骤2: Redis协议测试")
+
+	// 设置读写超时
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// 3. 统一密码认证测试（始终发送AUTH命令）
+	testResults = append(testResults, "\n🔍 步骤3: Redis认证测试")
+
+	// 发送 AUTH 命令（使用用户输入的密码，可能为空）
+	var authCmd string
+	if password == "" {
+		authCmd = "AUTH \"\"\r\n" // 空密码用双引号包围
+	} else {
+		authCmd = fmt.Sprintf("AUTH %s\r\n", password)
+	}
+	response, err := roundTrip(conn, authCmd)
+	if err != nil {
+		return testResults, fmt.Errorf("❌ 认证响应超时: %v\n\n可能原因:\n1. Redis服务器无响应\n2. 网络连接问题", err)
+	}
+
+	if strings.HasPrefix(response, "+OK") {
+		if password == "" {
+			testResults = append(testResults, "✅ 认证成功（无密码模式）")
+		} else {
+			testResults = append(testResults, "✅ 密码认证成功")
+		}
+	} else if strings.Contains(response, "no password is set") {
+		// Redis服务器没有设置密码，这是正常情况
+		if password != "" {
+			// 用户输入了密码，但Redis没有设置密码
+			return testResults, apperr.Errorf(apperr.RedisAuthFailed, "❌ Redis认证失败\n\nRedis服务器未设置密码，但您输入了密码\n\n请清空密码字段或在Redis服务器设置密码")
+		}
+		testResults = append(testResults, "✅ 认证成功（Redis无密码配置）")
+	} else {
+		// 其他认证错误（密码错误等）
+		return testResults, apperr.Errorf(apperr.RedisAuthFailed, "❌ Redis认证失败\n\n服务器响应: %s\n\n请检查密码是否与Redis服务器配置一致", response)
+	}
+
+	// 4. 数据库选择测试
+	testResults = append(testResults, "\n🔍 步骤4: 数据库选择测试")
+	if db != 0 {
+		response, err := roundTrip(conn, fmt.Sprintf("SELECT %d\r\n", db))
+		if err != nil {
+			return testResults, fmt.Errorf("❌ 读取数据库选择响应失败: %v", err)
+		}
+		if !strings.HasPrefix(response, "+OK") {
+			return testResults, fmt.Errorf("❌ 数据库选择失败\n\n服务器响应: %s\n\n请检查数据库编号 %d 是否有效", response, db)
+		}
+		testResults = append(testResults, fmt.Sprintf("✅ 成功选择数据库 %d", db))
+	} else {
+		testResults = append(testResults, "✅ 使用默认数据库 0")
+	}
+
+	// 5. PING命令测试
+	testResults = append(testResults, "\n🔍 步骤5: PING命令测试")
+	response, err = roundTrip(conn, "PING\r\n")
+	if err != nil {
+		return testResults, fmt.Errorf("❌ 读取PING响应失败: %v", err)
+	}
+	if !strings.HasPrefix(response, "+PONG") {
+		return testResults, fmt.Errorf("❌ PING测试失败\n\n期望响应: +PONG\n实际响应: %s", response)
+	}
+	testResults = append(testResults, "✅ PING测试成功，Redis响应正常")
+
+	// 6. 基本读写测试
+	testResults = append(testResults, "\n🔍 步骤6: 基本读写功能测试")
+
+	// 设置一个测试键值
+	testValue := fmt.Sprintf("test_%d", time.Now().Unix())
+	response, err = roundTrip(conn, fmt.Sprintf("SET %s %s\r\n", testKey, testValue))
+	if err != nil {
+		return testResults, fmt.Errorf("❌ 读取SET响应失败: %v", err)
+	}
+	if !strings.HasPrefix(response, "+OK") {
+		return testResults, fmt.Errorf("❌ SET命令失败\n\n响应: %s", response)
+	}
+
+	// 读取测试键值
+	response, err = roundTrip(conn, fmt.Sprintf("GET %s\r\n", testKey))
+	if err != nil {
+		return testResults, fmt.Errorf("❌ 读取GET响应失败: %v", err)
+	}
+	if !strings.Contains(response, testValue) {
+		return testResults, fmt.Errorf("❌ 读写功能测试失败\n\n期望值: %s\n实际响应: %s", testValue, response)
+	}
+	testResults = append(testResults, "✅ 读写功能测试成功")
+
+	// 清理测试数据
+	conn.Write([]byte(fmt.Sprintf("DEL %s\r\n", testKey)))
+
+	testResults = append(testResults, "\n🎉 所有测试通过！Redis配置完全正确。")
+	return testResults, nil
+}
+
+// roundTrip 发送一条命令并读取一次响应
+func roundTrip(conn net.Conn, command string) (string, error) {
+	if _, err := conn.Write([]byte(command)); err != nil {
+		return "", err
+	}
+
+	buffer := make([]byte, 1024)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(buffer[:n])), nil
+}
@@ -0,0 +1,296 @@
+// Package registry 用系统的 docker 命令构建项目镜像（server/Dockerfile、web/Dockerfile），
+// 打上标签后推送到镜像仓库（Docker Hub、阿里云 ACR、Harbor 等）。
+// 登录凭据写入临时的 docker 配置目录（docker --config），推送结束后删除，不修改用户的 ~/.docker/config.json
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// 仓库类型
+const (
+	DockerHub = "dockerhub"
+	AliyunACR = "aliyun-acr"
+	Harbor    = "harbor"
+)
+
+// Kinds 支持的仓库类型（界面下拉框按此顺序显示）
+var Kinds = []string{DockerHub, AliyunACR, Harbor}
+
+// dockerHubAuthKey Docker Hub 在 docker 配置文件中的键
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// KindLabel 仓库类型的中文名称
+func KindLabel(kind string) string {
+	switch kind {
+	case DockerHub:
+		return "Docker Hub"
+	case AliyunACR:
+		return "阿里云 ACR"
+	case Harbor:
+		return "Harbor"
+	default:
+		return kind
+	}
+}
+
+// DefaultServer 仓库类型的默认地址（Harbor 没有默认地址）
+func DefaultServer(kind string) string {
+	switch kind {
+	case DockerHub:
+		return "docker.io"
+	case AliyunACR:
+		return "registry.cn-hangzhou.aliyuncs.com"
+	default:
+		return ""
+	}
+}
+
+// Registry 镜像仓库
+type Registry struct {
+	Kind      string // DockerHub、AliyunACR 或 Harbor
+	Server    string // 仓库地址，例如 registry.cn-shanghai.aliyuncs.com、harbor.example.com（Docker Hub 为空或 docker.io）
+	Namespace string // 命名空间（Docker Hub 的用户名或组织、ACR 的命名空间、Harbor 的项目）
+	Username  string
+	Password  string
+}
+
+// server 实际使用的仓库地址
+func (r Registry) server() string {
+	server := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(r.Server), "https://"), "http://"), "/")
+	if server == "" {
+		return DefaultServer(r.Kind)
+	}
+	return server
+}
+
+// Validate 检查必填项
+func (r Registry) Validate() error {
+	switch {
+	case r.server() == "":
+		return fmt.Errorf("请填写仓库地址")
+	case strings.TrimSpace(r.Namespace) == "":
+		return fmt.Errorf("请填写命名空间")
+	case !namePattern.MatchString(strings.TrimSpace(r.Namespace)):
+		return fmt.Errorf("命名空间只能包含小写字母、数字和 . _ -")
+	case strings.TrimSpace(r.Username) == "" || r.Password == "":
+		return fmt.Errorf("请填写仓库的用户名和密码")
+	}
+	return nil
+}
+
+// namePattern 镜像名称和命名空间允许的字符（docker 要求小写）
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+
+// tagPattern 标签允许的字符
+var tagPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+// ValidTag 标签是否有效
+func ValidTag(tag string) bool {
+	return tagPattern.MatchString(tag)
+}
+
+// Reference 镜像的完整名称，例如 registry.cn-hangzhou.aliyuncs.com/team/gva-server:v1.2.0
+// （Docker Hub 省略仓库地址）
+func (r Registry) Reference(name, tag string) string {
+	repo := strings.TrimSpace(r.Namespace) + "/" + name + ":" + tag
+	if server := r.server(); server != "docker.io" && server != "index.docker.io" {
+		return server + "/" + repo
+	}
+	return repo
+}
+
+// authKey 仓库在 docker 配置文件 auths 中的键
+func (r Registry) authKey() string {
+	if server := r.server(); server != "docker.io" && server != "index.docker.io" {
+		return server
+	}
+	return dockerHubAuthKey
+}
+
+// DockerConfig docker 配置文件（config.json）的内容，只包含该仓库的登录凭据
+func (r Registry) DockerConfig() ([]byte, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(strings.TrimSpace(r.Username) + ":" + r.Password))
+	return json.MarshalIndent(map[string]any{
+		"auths": map[string]any{r.authKey(): map[string]string{"auth": auth}},
+	}, "", "  ")
+}
+
+// Image 要构建的一个镜像
+type Image struct {
+	Name       string // 镜像名称，例如 gva-server
+	Context    string // 构建目录（server/ 或 web/）
+	Dockerfile string // Dockerfile 路径
+}
+
+// ProjectImages GVA 项目中有 Dockerfile 的镜像（上游在 server/ 和 web/ 下各有一个）
+func ProjectImages(root string) []Image {
+	var images []Image
+	for _, item := range []struct{ dir, name string }{{"server", "gva-server"}, {"web", "gva-web"}} {
+		dir := filepath.Join(root, item.dir)
+		dockerfile := filepath.Join(dir, "Dockerfile")
+		if sysutil.FileExists(dockerfile) {
+			images = append(images, Image{Name: item.name, Context: dir, Dockerfile: dockerfile})
+		}
+	}
+	return images
+}
+
+// Progress 推送进度：已完成的层数和目前发现的层数
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// pushLine docker push 输出中描述某一层状态的行，例如「5f70bf18a086: Pushed」
+var pushLine = regexp.MustCompile(`^([0-9a-f]{12}): (Preparing|Waiting|Pushing|Pushed|Layer already exists|Mounted from .+|Retrying .+)`)
+
+// ProgressWriter 解析 docker push 的输出并在层状态变化时回调进度，输出原样写入 w
+type ProgressWriter struct {
+	w        io.Writer
+	onChange func(Progress)
+
+	mu      sync.Mutex
+	partial string
+	layers  map[string]bool // 层 ID -> 是否已完成
+}
+
+// NewProgressWriter 创建进度解析器
+func NewProgressWriter(w io.Writer, onChange func(Progress)) *ProgressWriter {
+	return &ProgressWriter{w: w, onChange: onChange, layers: make(map[string]bool)}
+}
+
+// Write 写入输出（可以在任意位置断开）
+func (p *ProgressWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	text := p.partial + strings.ReplaceAll(string(b), "\r", "\n")
+	lines := strings.Split(text, "\n")
+	p.partial = lines[len(lines)-1]
+	changed := false
+	for _, line := range lines[:len(lines)-1] {
+		m := pushLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		done := m[2] == "Pushed" || m[2] == "Layer already exists" || strings.HasPrefix(m[2], "Mounted from")
+		if old, ok := p.layers[m[1]]; !ok || old != done {
+			p.layers[m[1]] = done
+			changed = true
+		}
+	}
+	progress := p.progress()
+	p.mu.Unlock()
+
+	if changed && p.onChange != nil {
+		p.onChange(progress)
+	}
+	return p.w.Write(b)
+}
+
+// progress 当前进度（调用方持有锁）
+func (p *ProgressWriter) progress() Progress {
+	progress := Progress{Total: len(p.layers)}
+	for _, done := range p.layers {
+		if done {
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+// Result 一个镜像的推送结果
+type Result struct {
+	Reference string
+	Digest    string // 仓库返回的摘要（sha256:...），没有识别到时为空
+}
+
+// digestLine docker push 最后一行，例如「v1: digest: sha256:ab... size: 1570」
+var digestLine = regexp.MustCompile(`digest: (sha256:[0-9a-f]{64})`)
+
+// Push 依次构建镜像并推送：docker build -t 引用（tag 与 latest），再用临时配置目录中的凭据 docker push。
+// onProgress 在推送的层状态变化时回调（image 为正在推送的镜像序号，从 0 开始）
+func Push(ctx context.Context, r Registry, images []Image, tag string, w io.Writer, onProgress func(image int, p Progress)) ([]Result, error) {
+	if err := r.Validate(); err != nil {
+		return nil, apperr.Errorf(apperr.ImagePushFailed, "%v", err)
+	}
+	if !ValidTag(tag) {
+		return nil, apperr.Errorf(apperr.ImagePushFailed, "标签无效: %s（只能包含字母、数字和 _ . -）", tag)
+	}
+	if len(images) == 0 {
+		return nil, apperr.Errorf(apperr.ImagePushFailed, "项目中没有找到 server/Dockerfile 或 web/Dockerfile")
+	}
+
+	configDir, err := os.MkdirTemp("", "gvapanel-docker-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(configDir)
+	data, err := r.DockerConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), data, 0600); err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for i, image := range images {
+		ref := r.Reference(image.Name, tag)
+		latest := r.Reference(image.Name, "latest")
+		fmt.Fprintf(w, "$ docker build -t %s -t %s -f %s .\n", ref, latest, image.Dockerfile)
+		if err := sysutil.RunOutputContext(ctx, image.Context, w, "docker", "build", "-t", ref, "-t", latest, "-f", image.Dockerfile, "."); err != nil {
+			if ctx.Err() != nil {
+				return results, ctx.Err()
+			}
+			return results, apperr.Errorf(apperr.ImagePushFailed, "构建镜像 %s 失败: %v", image.Name, err)
+		}
+
+		result := Result{Reference: ref}
+		for _, target := range []string{ref, latest} {
+			fmt.Fprintf(w, "$ docker push %s\n", target)
+			var output strings.Builder
+			pw := NewProgressWriter(io.MultiWriter(w, &output), func(p Progress) {
+				if onProgress != nil {
+					onProgress(i, p)
+				}
+			})
+			if err := sysutil.RunOutputContext(ctx, "", pw, "docker", "--config", configDir, "push", target); err != nil {
+				if ctx.Err() != nil {
+					return results, ctx.Err()
+				}
+				return results, apperr.Errorf(apperr.ImagePushFailed, "推送 %s 失败: %v%s", target, err, pushHint(output.String()))
+			}
+			if m := digestLine.FindStringSubmatch(output.String()); m != nil && target == ref {
+				result.Digest = m[1]
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// pushHint 根据 docker push 的输出给出常见原因
+func pushHint(output string) string {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "unauthorized") || strings.Contains(lower, "authentication required"):
+		return "（用户名或密码错误，或没有该命名空间的推送权限）"
+	case strings.Contains(lower, "denied"):
+		return "（仓库拒绝推送：命名空间不存在或没有推送权限）"
+	case strings.Contains(lower, "x509") || strings.Contains(lower, "server gave http response to https client"):
+		return "（仓库使用自签名证书或 HTTP，需要在 docker 的 insecure-registries 中添加该地址）"
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestReference(t *testing.T) {
+	cases := []struct {
+		r    Registry
+		want string
+	}{
+		{Registry{Kind: DockerHub, Namespace: "team"}, "team/gva-server:v1"},
+		{Registry{Kind: AliyunACR, Namespace: "team"}, "registry.cn-hangzhou.aliyuncs.com/team/gva-server:v1"},
+		{Registry{Kind: AliyunACR, Server: "registry.cn-shanghai.aliyuncs.com", Namespace: "team"}, "registry.cn-shanghai.aliyuncs.com/team/gva-server:v1"},
+		{Registry{Kind: Harbor, Server: "https://harbor.example.com/", Namespace: "gva"}, "harbor.example.com/gva/gva-server:v1"},
+	}
+	for _, c := range cases {
+		if got := c.r.Reference("gva-server", "v1"); got != c.want {
+			t.Errorf("%+v: got %q, want %q", c.r, got, c.want)
+		}
+	}
+}
+
+func TestDockerConfig(t *testing.T) {
+	for _, c := range []struct {
+		r   Registry
+		key string
+	}{
+		{Registry{Kind: DockerHub, Username: "me", Password: "p:w"}, "https://index.docker.io/v1/"},
+		{Registry{Kind: Harbor, Server: "harbor.example.com", Username: "robot$gva+ci", Password: "secret"}, "harbor.example.com"},
+	} {
+		data, err := c.r.DockerConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cfg struct {
+			Auths map[string]struct{ Auth string } `json:"auths"`
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			t.Fatal(err)
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(cfg.Auths[c.key].Auth)
+		if string(decoded) != c.r.Username+":"+c.r.Password {
+			t.Errorf("%s: auth = %q (config = %s)", c.key, decoded, data)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Registry{Kind: AliyunACR, Namespace: "team", Username: "u", Password: "p"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	for _, r := range []Registry{
+		{Kind: Harbor, Namespace: "gva", Username: "u", Password: "p"},
+		{Kind: DockerHub, Namespace: "Team", Username: "u", Password: "p"},
+		{Kind: DockerHub, Namespace: "team", Username: "u"},
+	} {
+		if err := r.Validate(); err == nil {
+			t.Errorf("%+v 应报错", r)
+		}
+	}
+	if ValidTag("v1.2.0") != true || ValidTag("-bad") || ValidTag("a b") {
+		t.Error("ValidTag")
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	var got []Progress
+	var out strings.Builder
+	pw := NewProgressWriter(&out, func(p Progress) { got = append(got, p) })
+	// 输出可能在任意位置断开
+	pw.Write([]byte("The push refers to repository [docker.io/team/gva-server]\n5f70bf18a086: Prep"))
+	pw.Write([]byte("aring\n0a9a5dfd008f: Preparing\n5f70bf18a086: Layer already exists\n"))
+	pw.Write([]byte("0a9a5dfd008f: Pushed\nv1: digest: sha256:" + strings.Repeat("a", 64) + " size: 1570\n"))
+
+	last := got[len(got)-1]
+	if last.Done != 2 || last.Total != 2 {
+		t.Errorf("progress = %+v", got)
+	}
+	if len(got) != 2 || got[0] != (Progress{Done: 1, Total: 2}) {
+		t.Errorf("每次写入只在状态变化时回调一次, got %+v", got)
+	}
+	if !strings.HasSuffix(out.String(), "size: 1570\n") {
+		t.Errorf("输出应原样写入: %q", out.String())
+	}
+}
+
+func TestPushBuildsAndPushes(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "server"), 0755)
+	os.WriteFile(filepath.Join(root, "server", "Dockerfile"), []byte("FROM golang"), 0644)
+	images := ProjectImages(root)
+	if len(images) != 1 || images[0].Name != "gva-server" {
+		t.Fatalf("images = %+v", images)
+	}
+
+	r := Registry{Kind: DockerHub, Namespace: "team", Username: "u", Password: "p"}
+	runner := sysutiltest.New(t)
+	build := "docker build -t team/gva-server:v1 -t team/gva-server:latest -f " + images[0].Dockerfile + " ."
+	runner.Handle(build, "ok\n", nil)
+
+	// 推送命令带有临时生成的配置目录，假执行器中未预设，推送失败
+	var log strings.Builder
+	if _, err := Push(context.Background(), r, images, "v1", &log, nil); apperr.CodeOf(err) != apperr.ImagePushFailed {
+		t.Errorf("err = %v", err)
+	}
+	calls := runner.Calls()
+	if len(calls) != 2 || calls[0].Command != build || calls[0].Dir != images[0].Context {
+		t.Fatalf("calls = %+v", calls)
+	}
+	push := strings.Fields(calls[1].Command)
+	if len(push) != 5 || push[1] != "--config" || push[3] != "push" || push[4] != "team/gva-server:v1" {
+		t.Errorf("push = %q", calls[1].Command)
+	}
+	if _, err := os.Stat(push[2]); !os.IsNotExist(err) {
+		t.Errorf("推送结束后应删除临时配置目录: %v", err)
+	}
+
+	if _, err := Push(context.Background(), r, images, "bad tag", &log, nil); apperr.CodeOf(err) != apperr.ImagePushFailed {
+		t.Errorf("标签无效时应报错, err = %v", err)
+	}
+	if _, err := Push(context.Background(), r, nil, "v1", &log, nil); apperr.CodeOf(err) != apperr.ImagePushFailed {
+		t.Errorf("没有镜像时应报错, err = %v", err)
+	}
+}
+
+func TestPushHint(t *testing.T) {
+	if !strings.Contains(pushHint("unauthorized: authentication required"), "用户名或密码") {
+		t.Error("unauthorized")
+	}
+	if pushHint("ok") != "" {
+		t.Error("没有识别到原因时应为空")
+	}
+}
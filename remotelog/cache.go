@@ -0,0 +1,109 @@
+package remotelog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache 本地日志缓存（gzip）。每次查看追加一个 gzip 成员，读取时按顺序解压全部成员
+type Cache struct {
+	Path string
+}
+
+// NewCache 目标在 dir 中的缓存
+func NewCache(dir string, t Target) *Cache {
+	return &Cache{Path: filepath.Join(dir, t.Key())}
+}
+
+// Updated 缓存最后写入的时间（没有缓存时为零值），下次查看时只需传输这之后的日志
+func (c *Cache) Updated() time.Time {
+	info, err := os.Stat(c.Path)
+	if err != nil || info.Size() == 0 {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// Tail 读取缓存中最后 n 行
+func (c *Cache) Tail(n int) ([]string, error) {
+	f, err := os.Open(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > 2*n {
+			lines = append(lines[:0], lines[len(lines)-n:]...)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	// 最后一个成员可能因面板异常退出而不完整，已读出的行仍然返回
+	if err := scanner.Err(); err != nil && err != io.ErrUnexpectedEOF {
+		return lines, err
+	}
+	return lines, nil
+}
+
+// Clear 删除缓存
+func (c *Cache) Clear() error {
+	if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// cacheWriter 追加写入缓存的一个 gzip 成员
+type cacheWriter struct {
+	file *os.File
+	zw   *gzip.Writer
+}
+
+// openWriter 打开缓存准备追加
+func (c *Cache) openWriter() (*cacheWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(c.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheWriter{file: f, zw: gzip.NewWriter(f)}, nil
+}
+
+// writeLines 写入若干行并刷新，面板异常退出时已收到的日志也能保留
+func (w *cacheWriter) writeLines(lines []string) error {
+	if _, err := io.WriteString(w.zw, strings.Join(lines, "\n")+"\n"); err != nil {
+		return err
+	}
+	return w.zw.Flush()
+}
+
+// close 结束 gzip 成员并关闭文件
+func (w *cacheWriter) close() error {
+	err := w.zw.Close()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
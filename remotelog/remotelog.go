@@ -0,0 +1,102 @@
+// Package remotelog 通过系统的 ssh 客户端查看远程服务器上的 GVA 日志。
+// 为了在较慢的 VPN 链路上也能使用：关键字和起始时间在服务器端过滤后再传输（ssh -C 压缩），
+// 收到的日志以 gzip 缓存在本地，再次查看时只传输上次缓存之后的新日志
+package remotelog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// DefaultLines 默认先显示的最近行数
+const DefaultLines = 200
+
+// Target 远程日志文件（需要已配置 SSH 密钥登录，面板不会输入密码）
+type Target struct {
+	Host string // 服务器地址
+	Port int    // SSH 端口（0 表示 22）
+	User string // 用户名（为空时使用 ssh 配置中的默认用户）
+	Path string // 日志文件路径，例如 /opt/gva/server/log/server.log
+
+	Identity string // 私钥文件（为空时使用 ssh 的默认密钥和 ssh-agent）
+}
+
+// Validate 检查必填项
+func (t Target) Validate() error {
+	switch {
+	case strings.TrimSpace(t.Host) == "":
+		return fmt.Errorf("请填写服务器地址")
+	case strings.TrimSpace(t.Path) == "":
+		return fmt.Errorf("请填写日志文件路径")
+	case t.Port < 0 || t.Port > 65535:
+		return fmt.Errorf("SSH 端口无效")
+	}
+	return nil
+}
+
+// Filter 服务器端过滤条件
+type Filter struct {
+	Pattern string    // 只传输匹配的行（grep -E 扩展正则，为空时不过滤）
+	Since   time.Time // 只传输该时间之后的日志（零值表示不限）
+	Lines   int       // 开始跟踪前先显示的最近行数（0 表示 DefaultLines）
+	Resume  bool      // 接着本地缓存：只传输缓存之后的日志，并先显示缓存中的内容
+}
+
+// sinceLayout 与服务器端比较时使用的时间格式（字符串比较即可判断先后）
+const sinceLayout = "2006-01-02 15:04:05"
+
+// sinceFilter 服务器端按时间过滤的 awk 脚本：识别行内第一个时间戳（2006-01-02 15:04:05、
+// 2006/01/02 - 15:04:05 等 GVA / zap 常见格式），早于起始时间的行丢弃；
+// 没有时间戳的行（例如堆栈）跟随上一行的判断
+const sinceFilter = `{ if (match($0, /[0-9][0-9][0-9][0-9][-\/][0-9][0-9][-\/][0-9][0-9]( - |[ T])[0-9][0-9]:[0-9][0-9]:[0-9][0-9]/)) { t = substr($0, RSTART, RLENGTH); gsub(/\//, "-", t); sub(/ - |T/, " ", t); p = (t >= s) } if (p) { print; fflush() } }`
+
+// RemoteCommand 在服务器上执行的命令：tail 跟踪文件，按需经过时间和关键字过滤
+func RemoteCommand(path string, f Filter) string {
+	lines := f.Lines
+	if lines <= 0 {
+		lines = DefaultLines
+	}
+	cmd := fmt.Sprintf("tail -n %d -F %s", lines, sysutil.ShellQuote(path))
+	if !f.Since.IsZero() {
+		cmd += " | awk -v s=" + sysutil.ShellQuote(f.Since.Format(sinceLayout)) + " " + sysutil.ShellQuote(sinceFilter)
+	}
+	if f.Pattern != "" {
+		cmd += " | grep --line-buffered -E -- " + sysutil.ShellQuote(f.Pattern)
+	}
+	return cmd
+}
+
+// Command 本地执行的 ssh 命令（-C 压缩传输；BatchMode 避免等待密码输入；
+// 服务器地址前加 --，以 - 开头的地址不会被当作 ssh 选项）
+func Command(t Target, f Filter) (name string, args []string) {
+	args = []string{"-C", "-o", "BatchMode=yes", "-o", "ServerAliveInterval=30"}
+	if t.Port > 0 && t.Port != 22 {
+		args = append(args, "-p", strconv.Itoa(t.Port))
+	}
+	if t.Identity != "" {
+		args = append(args, "-i", t.Identity, "-o", "IdentitiesOnly=yes")
+	}
+	host := strings.TrimSpace(t.Host)
+	if user := strings.TrimSpace(t.User); user != "" {
+		host = user + "@" + host
+	}
+	return "ssh", append(args, "--", host, RemoteCommand(strings.TrimSpace(t.Path), f))
+}
+
+// unsafeName 缓存文件名中需要替换的字符
+var unsafeName = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// Key 目标对应的缓存文件名（同一服务器的同一文件共用缓存）
+func (t Target) Key() string {
+	port := t.Port
+	if port == 0 {
+		port = 22
+	}
+	key := fmt.Sprintf("%s@%s_%d_%s", t.User, t.Host, port, strings.TrimLeft(t.Path, `/\`))
+	return strings.Trim(unsafeName.ReplaceAllString(key, "_"), "_") + ".log.gz"
+}
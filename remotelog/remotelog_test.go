@@ -0,0 +1,199 @@
+package remotelog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestCommand(t *testing.T) {
+	name, args := Command(Target{Host: "10.0.0.5", Port: 2222, User: "deploy", Path: "/opt/gva/log/server.log"}, Filter{})
+	got := strings.Join(append([]string{name}, args...), " ")
+	want := "ssh -C -o BatchMode=yes -o ServerAliveInterval=30 -p 2222 -- deploy@10.0.0.5 tail -n 200 -F '/opt/gva/log/server.log'"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	_, args = Command(Target{Host: "gva.example.com", Port: 22, Path: "/var/log/gva.log"}, Filter{Lines: 50})
+	if got := strings.Join(args, " "); strings.Contains(got, "-p ") || !strings.Contains(got, " -- gva.example.com tail -n 50 ") {
+		t.Errorf("args = %q", got)
+	}
+
+	_, args = Command(Target{Host: "h", Path: "/a.log", Identity: "/tmp/keys/prod"}, Filter{})
+	if got := strings.Join(args, " "); !strings.Contains(got, " -i /tmp/keys/prod -o IdentitiesOnly=yes -- h ") {
+		t.Errorf("args = %q", got)
+	}
+
+	// 以 - 开头的地址在 -- 之后，不会被解析为 ssh 选项
+	_, args = Command(Target{Host: "-oProxyCommand=touch /tmp/x", Path: "/a.log"}, Filter{})
+	for i, arg := range args {
+		if arg == "-oProxyCommand=touch /tmp/x" && (i == 0 || args[i-1] != "--") {
+			t.Errorf("args = %q", args)
+		}
+	}
+}
+
+func TestRemoteCommandFilters(t *testing.T) {
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	cmd := RemoteCommand("/var/log/it's.log", Filter{Pattern: "ERROR|panic", Since: since})
+
+	if !strings.HasPrefix(cmd, `tail -n 200 -F '/var/log/it'\''s.log' | awk -v s='2024-05-01 10:00:00' `) {
+		t.Errorf("cmd = %q", cmd)
+	}
+	// 关键字过滤放在最后，时间过滤仍能看到没有匹配关键字的时间戳行
+	if !strings.HasSuffix(cmd, ` | grep --line-buffered -E -- 'ERROR|panic'`) {
+		t.Errorf("cmd = %q", cmd)
+	}
+	if strings.Contains(RemoteCommand("/a.log", Filter{}), "|") {
+		t.Error("没有过滤条件时不应使用管道")
+	}
+}
+
+func TestTargetValidateAndKey(t *testing.T) {
+	for _, target := range []Target{{Path: "/a.log"}, {Host: "h"}, {Host: "h", Path: "/a.log", Port: 70000}} {
+		if err := target.Validate(); err == nil {
+			t.Errorf("%+v 应报错", target)
+		}
+	}
+	key := Target{Host: "10.0.0.5", User: "root", Path: "/opt/gva/log/server.log"}.Key()
+	if key != "root_10.0.0.5_22_opt_gva_log_server.log.log.gz" {
+		t.Errorf("key = %q", key)
+	}
+}
+
+func TestCacheAppendsMembers(t *testing.T) {
+	cache := NewCache(t.TempDir(), Target{Host: "h", Path: "/a.log"})
+	if lines, err := cache.Tail(10); err != nil || lines != nil || !cache.Updated().IsZero() {
+		t.Fatalf("空缓存: lines = %v, err = %v", lines, err)
+	}
+
+	for _, batch := range [][]string{{"a", "b"}, {"c"}} {
+		w, err := cache.openWriter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.writeLines(batch); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines, err := cache.Tail(2)
+	if err != nil || strings.Join(lines, ",") != "b,c" {
+		t.Errorf("lines = %v, err = %v", lines, err)
+	}
+	if cache.Updated().IsZero() {
+		t.Error("Updated 不应为零值")
+	}
+	if err := cache.Clear(); err != nil || !cache.Updated().IsZero() {
+		t.Errorf("Clear: %v", err)
+	}
+}
+
+func TestStreamCachesLines(t *testing.T) {
+	target := Target{Host: "h", Path: "/a.log"}
+	filter := Filter{Pattern: "ERROR", Lines: 10}
+	name, args := Command(target, filter)
+	runner := sysutiltest.New(t)
+	runner.Handle(strings.Join(append([]string{name}, args...), " "), "ERROR one\r\nERROR two\n", nil)
+
+	cache := NewCache(t.TempDir(), target)
+	received := make(chan []string, 2)
+	exited := make(chan error, 1)
+	stream := &Stream{
+		OnLines: func(lines []string) { received <- lines },
+		OnExit:  func(err error) { exited <- err },
+	}
+	released := make(chan struct{}, 2)
+	if err := stream.Start(target, filter, cache, func() { released <- struct{}{} }); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case lines := <-received:
+		if strings.Join(lines, ",") != "ERROR one,ERROR two" {
+			t.Errorf("lines = %v", lines)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("未收到日志")
+	}
+	select {
+	case err := <-exited:
+		if err == nil {
+			t.Error("连接断开时应报告错误")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("未收到退出通知")
+	}
+
+	lines, err := cache.Tail(10)
+	if err != nil || strings.Join(lines, ",") != "ERROR one,ERROR two" {
+		t.Errorf("缓存: lines = %v, err = %v", lines, err)
+	}
+	// 收到输出后删除私钥，退出时不再重复调用
+	if len(released) != 1 {
+		t.Errorf("release 调用了 %d 次", len(released))
+	}
+}
+
+func TestStreamReleasesIdentityOnExit(t *testing.T) {
+	target := Target{Host: "h", Path: "/a.log", Identity: "/tmp/keys/prod"}
+	name, args := Command(target, Filter{})
+	runner := sysutiltest.New(t)
+	runner.HandleExit(strings.Join(append([]string{name}, args...), " "), "", fmt.Errorf("exit status 255"))
+
+	// 认证失败没有输出时，ssh 退出后删除私钥
+	released := make(chan struct{}, 2)
+	exited := make(chan error, 1)
+	stream := &Stream{OnExit: func(err error) { exited <- err }}
+	if err := stream.Start(target, Filter{}, nil, func() { released <- struct{}{} }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("未收到退出通知")
+	}
+	if len(released) != 1 {
+		t.Errorf("release 调用了 %d 次", len(released))
+	}
+}
+
+func TestStreamResumesFromCache(t *testing.T) {
+	target := Target{Host: "h", Path: "/a.log"}
+	cache := NewCache(t.TempDir(), target)
+	w, err := cache.openWriter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.writeLines([]string{"cached"})
+	w.close()
+
+	// 接着缓存时只请求缓存之后的日志
+	name, args := Command(target, Filter{Since: cache.Updated()})
+	runner := sysutiltest.New(t)
+	runner.Handle(strings.Join(append([]string{name}, args...), " "), "fresh\n", nil)
+
+	exited := make(chan error, 1)
+	stream := &Stream{OnExit: func(err error) { exited <- err }}
+	if err := stream.Start(target, Filter{Resume: true}, cache, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("未收到退出通知")
+	}
+
+	if got := stream.Output(); got != "cached\nfresh" {
+		t.Errorf("output = %q", got)
+	}
+	if lines, _ := cache.Tail(10); strings.Join(lines, ",") != "cached,fresh" {
+		t.Errorf("缓存: %v", lines)
+	}
+}
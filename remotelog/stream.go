@@ -0,0 +1,196 @@
+package remotelog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// maxLines 保留在内存中用于显示的最近行数
+const maxLines = 2000
+
+// Stream 一个运行中的远程日志跟踪
+type Stream struct {
+	// OnLines 收到新日志时调用（在读取输出的协程中）
+	OnLines func(lines []string)
+	// OnExit ssh 退出时调用（Stop 主动停止时 err 为 nil）
+	OnExit func(err error)
+
+	mu       sync.Mutex
+	proc     sysutil.Process
+	cache    *cacheWriter
+	lines    []string
+	partial  string
+	stopping bool
+	release  func()
+}
+
+// Start 开始跟踪（已在运行时返回错误）。cache 不为空时收到的日志同时写入缓存；
+// f.Since 为零值时只传输缓存之后的新日志，先显示缓存中已有的内容。
+// release 不为空时在 ssh 不再需要 t.Identity 时调用一次：收到第一段输出（已完成认证）、ssh 退出或启动失败时，
+// 用于尽早删除临时私钥
+func (s *Stream) Start(t Target, f Filter, cache *Cache, release func()) error {
+	if release == nil {
+		release = func() {}
+	}
+	if err := t.Validate(); err != nil {
+		release()
+		return err
+	}
+
+	var cached []string
+	if cache != nil && f.Resume {
+		f.Since = cache.Updated()
+		lines := f.Lines
+		if lines <= 0 {
+			lines = DefaultLines
+		}
+		// 缓存损坏时不影响查看，只是没有历史内容
+		cached, _ = cache.Tail(lines)
+	}
+
+	s.mu.Lock()
+	if s.proc != nil {
+		s.mu.Unlock()
+		release()
+		return fmt.Errorf("远程日志已在跟踪")
+	}
+	s.mu.Unlock()
+
+	var writer *cacheWriter
+	if cache != nil {
+		w, err := cache.openWriter()
+		if err != nil {
+			release()
+			return fmt.Errorf("打开日志缓存失败: %v", err)
+		}
+		writer = w
+	}
+
+	s.mu.Lock()
+	s.lines = cached
+	s.partial = ""
+	s.stopping = false
+	s.cache = writer
+	s.release = release
+	s.mu.Unlock()
+
+	if len(cached) > 0 && s.OnLines != nil {
+		s.OnLines(cached)
+	}
+
+	name, args := Command(t, f)
+	proc, err := sysutil.Runner.StartOutput("", s, name, args...)
+	if err != nil {
+		s.mu.Lock()
+		s.cache = nil
+		s.mu.Unlock()
+		if writer != nil {
+			writer.close()
+		}
+		s.releaseIdentity()
+		return fmt.Errorf("启动 ssh 失败: %v（请确认已安装 OpenSSH 客户端）", err)
+	}
+
+	s.mu.Lock()
+	s.proc = proc
+	s.mu.Unlock()
+
+	go s.wait(proc)
+	return nil
+}
+
+// wait 等待 ssh 退出并通知
+func (s *Stream) wait(proc sysutil.Process) {
+	err := proc.Wait()
+
+	s.mu.Lock()
+	stopping := s.stopping
+	writer := s.cache
+	s.proc = nil
+	s.cache = nil
+	s.mu.Unlock()
+
+	if writer != nil {
+		writer.close()
+	}
+	s.releaseIdentity()
+	if stopping {
+		err = nil
+	} else if err != nil {
+		err = fmt.Errorf("ssh 已退出: %v（请确认已配置 SSH 密钥登录，且日志文件存在）", err)
+	} else {
+		err = fmt.Errorf("ssh 连接已断开")
+	}
+	if s.OnExit != nil {
+		s.OnExit(err)
+	}
+}
+
+// releaseIdentity 调用 Start 传入的 release（只调用一次）
+func (s *Stream) releaseIdentity() {
+	s.mu.Lock()
+	release := s.release
+	s.release = nil
+	s.mu.Unlock()
+	if release != nil {
+		release()
+	}
+}
+
+// Stop 停止跟踪（未运行时不做任何事）
+func (s *Stream) Stop() {
+	s.mu.Lock()
+	proc := s.proc
+	s.stopping = true
+	s.mu.Unlock()
+
+	if proc != nil && proc.OSProcess() != nil {
+		proc.OSProcess().Kill()
+	}
+}
+
+// Running 是否在跟踪
+func (s *Stream) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.proc != nil
+}
+
+// Output 最近的日志
+func (s *Stream) Output() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.lines, "\n")
+}
+
+// Write 接收 ssh 输出（实现 io.Writer），按行写入缓存并通知；收到输出说明 ssh 已完成认证，不再需要私钥文件
+func (s *Stream) Write(p []byte) (int, error) {
+	s.releaseIdentity()
+	s.mu.Lock()
+	text := s.partial + string(p)
+	lines := strings.Split(text, "\n")
+	s.partial = lines[len(lines)-1]
+	lines = lines[:len(lines)-1]
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+	}
+
+	if len(lines) > 0 {
+		s.lines = append(s.lines, lines...)
+		if len(s.lines) > maxLines {
+			s.lines = s.lines[len(s.lines)-maxLines:]
+		}
+		if s.cache != nil {
+			s.cache.writeLines(lines)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(lines) > 0 && s.OnLines != nil {
+		s.OnLines(lines)
+	}
+	return len(p), nil
+}
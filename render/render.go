@@ -0,0 +1,171 @@
+// Package render 处理面板窗口的 OpenGL 渲染初始化失败：部分虚拟机和远程桌面会话没有可用的 OpenGL 驱动，
+// 窗口创建时 Fyne 只输出 GL 错误后退出或崩溃。启动窗口前写入状态文件，窗口开始绘制后更新；
+// 上次启动没有开始绘制（状态文件停在 pending）时改用 Mesa 的软件渲染（llvmpipe），软件渲染也失败时说明原因，
+// 由入口退回命令行（看守）模式
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"gva-launcher/internal/sysutil"
+)
+
+// 渲染方式（--render 参数）
+const (
+	Auto     = "auto"     // 默认使用显卡驱动，上次初始化失败后自动改用软件渲染
+	Software = "software" // 强制软件渲染
+	Hardware = "hardware" // 强制使用显卡驱动（清除自动切换的记录）
+)
+
+// EnvSoftware 面板以软件渲染重新启动时设置的环境变量
+const EnvSoftware = "GVAPANEL_SOFTWARE_RENDER"
+
+// 状态文件的内容
+const (
+	statePending         = "pending"          // 正在初始化（进程在此之后退出说明初始化失败）
+	statePendingSoftware = "pending-software" // 以软件渲染初始化中
+	stateSoftware        = "software"         // 软件渲染可用，之后的启动继续使用
+)
+
+// softwareEnv Mesa 软件渲染的环境变量（Linux / BSD 上的 OpenGL 由 Mesa 提供时生效）
+var softwareEnv = [][2]string{
+	{"LIBGL_ALWAYS_SOFTWARE", "1"},
+	{"GALLIUM_DRIVER", "llvmpipe"},
+	{EnvSoftware, "1"},
+}
+
+// Supported 当前系统能否通过环境变量切换到软件渲染（Windows 需要把 Mesa 的 opengl32.dll 放到程序目录，macOS 总有可用的 OpenGL）
+func Supported() bool {
+	return supported(runtime.GOOS)
+}
+
+// supported Supported 的实现（系统由参数提供，便于测试）
+func supported(goos string) bool {
+	return goos != "windows" && goos != "darwin"
+}
+
+// Decision 本次启动使用的渲染方式
+type Decision struct {
+	Software bool   // 使用软件渲染
+	Reason   string // 改用软件渲染的原因（为空时不需要提示）
+	GiveUp   bool   // 软件渲染上次也没有成功，或当前系统无法切换，应退回命令行模式
+}
+
+// Decide 根据 --render 参数、环境变量和上次启动留下的状态决定渲染方式
+func Decide(path, mode string) Decision {
+	return decide(readState(path), mode, os.Getenv(EnvSoftware) == "1", Supported())
+}
+
+// decide Decide 的实现（状态、环境变量和系统由参数提供，便于测试）
+func decide(state, mode string, relaunched, supported bool) Decision {
+	switch mode {
+	case Software:
+		return Decision{Software: true}
+	case Hardware:
+		return Decision{}
+	}
+	switch {
+	case relaunched:
+		return Decision{Software: true, Reason: "窗口渲染初始化失败，已改用软件渲染重新启动"}
+	case state == stateSoftware:
+		return Decision{Software: true}
+	case state == statePendingSoftware:
+		return Decision{Software: true, GiveUp: true}
+	case state == statePending && !supported:
+		return Decision{GiveUp: true}
+	case state == statePending:
+		return Decision{Software: true, Reason: "上次启动时窗口渲染初始化失败，本次改用软件渲染"}
+	}
+	return Decision{}
+}
+
+// UseSoftware 在当前进程中设置软件渲染的环境变量（需在创建窗口之前调用，重新启动的子进程同样继承）
+func UseSoftware() {
+	for _, kv := range softwareEnv {
+		os.Setenv(kv[0], kv[1])
+	}
+}
+
+// Guard 记录本次启动的窗口是否开始绘制
+type Guard struct {
+	path     string
+	software bool
+	started  atomic.Bool
+}
+
+// Begin 写入「正在初始化」状态，返回的 Guard 在窗口开始绘制后调用 Started
+func Begin(path string, software bool) *Guard {
+	state := statePending
+	if software {
+		state = statePendingSoftware
+	}
+	writeState(path, state)
+	return &Guard{path: path, software: software}
+}
+
+// Started 窗口已开始绘制：软件渲染时记住以后继续使用，否则删除状态文件
+func (g *Guard) Started() {
+	if !g.started.CompareAndSwap(false, true) {
+		return
+	}
+	if g.software {
+		writeState(g.path, stateSoftware)
+	} else {
+		os.Remove(g.path)
+	}
+}
+
+// Failed 界面主循环结束时窗口是否从未开始绘制（窗口创建失败时 Fyne 结束主循环）
+func (g *Guard) Failed() bool {
+	return !g.started.Load()
+}
+
+// Reset 删除状态文件（--render hardware 或退回命令行模式后，下次启动重新尝试显卡驱动）
+func Reset(path string) {
+	os.Remove(path)
+}
+
+// Explain 无法显示窗口时的说明
+func Explain() string {
+	return explain(runtime.GOOS)
+}
+
+// explain Explain 的实现（系统由参数提供，便于测试）
+func explain(goos string) string {
+	if goos == "windows" {
+		return "面板窗口的 OpenGL 渲染初始化失败（常见于没有显卡驱动的虚拟机和远程桌面会话）。" +
+			"可以把 Mesa3D for Windows 的 opengl32.dll 放到面板程序所在目录后重新打开，或使用命令行（看守）模式"
+	}
+	return "面板窗口的 OpenGL 渲染初始化失败，改用软件渲染（Mesa llvmpipe）后仍然失败。" +
+		"请安装 Mesa 的 OpenGL 驱动（例如 libgl1-mesa-dri），或使用命令行（看守）模式；" +
+		"驱动修复后可用 --render hardware 重新尝试显卡渲染"
+}
+
+// readState 读取状态文件（不存在时为空）
+func readState(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writeState 写入状态文件（失败时忽略，只是失去自动切换）
+func writeState(path, state string) {
+	os.WriteFile(path, []byte(state+"\n"), 0644)
+}
+
+// Relaunch 以软件渲染和相同的参数重新启动面板（调用方随后应退出当前进程）
+func Relaunch() error {
+	UseSoftware()
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	_, err = sysutil.Runner.Start(filepath.Dir(exe), exe, os.Args[1:]...)
+	return err
+}
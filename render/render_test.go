@@ -0,0 +1,67 @@
+package render
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDecide(t *testing.T) {
+	cases := []struct {
+		name       string
+		state      string
+		mode       string
+		relaunched bool
+		supported  bool
+		want       Decision
+	}{
+		{"首次启动", "", Auto, false, true, Decision{}},
+		{"上次初始化失败", statePending, Auto, false, true, Decision{Software: true, Reason: "上次启动时窗口渲染初始化失败，本次改用软件渲染"}},
+		{"Windows 上无法切换", statePending, Auto, false, false, Decision{GiveUp: true}},
+		{"软件渲染也失败", statePendingSoftware, Auto, false, true, Decision{Software: true, GiveUp: true}},
+		{"记住软件渲染", stateSoftware, Auto, false, true, Decision{Software: true}},
+		{"重新启动的子进程", "", Auto, true, true, Decision{Software: true, Reason: "窗口渲染初始化失败，已改用软件渲染重新启动"}},
+		{"强制软件渲染", "", Software, false, true, Decision{Software: true}},
+		{"强制显卡渲染", stateSoftware, Hardware, false, true, Decision{}},
+	}
+	for _, c := range cases {
+		if got := decide(c.state, c.mode, c.relaunched, c.supported); got != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestGuard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "render")
+
+	g := Begin(path, false)
+	if readState(path) != statePending || !g.Failed() {
+		t.Fatalf("state = %q", readState(path))
+	}
+	g.Started()
+	if readState(path) != "" || g.Failed() {
+		t.Errorf("显卡渲染成功后应删除状态文件, state = %q", readState(path))
+	}
+
+	g = Begin(path, true)
+	if readState(path) != statePendingSoftware {
+		t.Fatalf("state = %q", readState(path))
+	}
+	g.Started()
+	if readState(path) != stateSoftware {
+		t.Errorf("软件渲染成功后应记住, state = %q", readState(path))
+	}
+
+	Reset(path)
+	if readState(path) != "" {
+		t.Error("Reset 应删除状态文件")
+	}
+}
+
+func TestSupported(t *testing.T) {
+	if !supported("linux") || supported("windows") || supported("darwin") {
+		t.Error("只有 Linux / BSD 可以通过环境变量切换到软件渲染")
+	}
+	if explain("windows") == explain("linux") {
+		t.Error("Windows 的说明应提示 opengl32.dll")
+	}
+}
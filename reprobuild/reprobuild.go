@@ -0,0 +1,221 @@
+// Package reprobuild 验证后端构建是否可复现：以固定的参数（-trimpath、清空 build ID、关闭 cgo）构建两次，
+// 第二次使用全新的构建缓存，比较两个可执行文件的 SHA-256，并从嵌入的构建信息（debug/buildinfo）中
+// 找出两次构建不同的输入（Go 版本、依赖版本、构建参数、版本控制信息），用于构建来源的证明
+package reprobuild
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// Flags 可复现构建的固定参数：去掉可执行文件中的本机路径，清空随构建环境变化的 build ID
+var Flags = []string{"-trimpath", "-ldflags=-buildid="}
+
+// Env 可复现构建固定的环境变量：关闭 cgo，避免引入本机 C 工具链和头文件路径
+var Env = []string{"CGO_ENABLED=0"}
+
+// Build 一次构建的结果
+type Build struct {
+	SHA256 string
+	Size   int64
+	Inputs map[string]string // 构建信息中的输入，见 Inputs
+}
+
+// Difference 两次构建不同的一项输入（某次构建中没有该项时对应的值为空）
+type Difference struct {
+	Input         string
+	First, Second string
+}
+
+// Result 验证结果
+type Result struct {
+	Builds       [2]Build
+	Reproducible bool // 两次构建的 SHA-256 相同
+	Differences  []Difference
+	Warnings     []string // 结果相同但不足以证明来源的情况，例如工作区有未提交的修改
+}
+
+// Verify 在 serverDir 中以固定参数构建两次后端并比较结果，goFlags 为额外的 go build 参数（例如工作区模式的参数），
+// 命令输出写入 w；构建失败时返回 BuildFailed 错误
+func Verify(ctx context.Context, serverDir string, goFlags []string, priority sysutil.Priority, w io.Writer) (Result, error) {
+	tmp, err := os.MkdirTemp("", "gvapanel-repro-")
+	if err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(tmp)
+
+	var result Result
+	for i := range result.Builds {
+		if ctx.Err() != nil {
+			return Result{}, ctx.Err()
+		}
+		env := Env
+		if i == 1 {
+			// 第二次构建不复用第一次的编译结果，所有包（包括标准库）都重新编译
+			fmt.Fprintln(w, "使用全新的构建缓存再次构建（需要重新编译所有依赖，耗时较长）")
+			env = append(append([]string{}, Env...), "GOCACHE="+filepath.Join(tmp, "gocache"))
+		}
+		binary := filepath.Join(tmp, fmt.Sprintf("build%d", i+1), "gva-server")
+		args := append(append(append([]string{"build"}, goFlags...), Flags...), "-o", binary, ".")
+		fmt.Fprintf(w, "$ %s go %s\n", strings.Join(env, " "), strings.Join(append(append(append([]string{"build"}, goFlags...), Flags...), "-o", "gva-server", "."), " "))
+		output, err := sysutil.CombinedOutputPriority(serverDir, env, priority, "go", args...)
+		w.Write(output)
+		if err != nil {
+			return Result{}, apperr.Errorf(apperr.BuildFailed, "第 %d 次构建失败: %v", i+1, err)
+		}
+		if result.Builds[i], err = inspect(binary); err != nil {
+			return Result{}, apperr.Errorf(apperr.BuildFailed, "读取第 %d 次构建的结果失败: %v", i+1, err)
+		}
+		fmt.Fprintf(w, "第 %d 次构建: sha256:%s\n", i+1, result.Builds[i].SHA256)
+
+		// 可执行文件中残留本机路径时结果依赖于项目所在的位置
+		if i == 0 && localPath(binary, serverDir) {
+			result.Warnings = append(result.Warnings, "可执行文件中包含项目的本机路径，换一个目录构建时结果会不同（可能是依赖通过 cgo 或代码生成记录了绝对路径）")
+		}
+	}
+
+	result.Reproducible = result.Builds[0].SHA256 == result.Builds[1].SHA256
+	result.Differences = Compare(result.Builds[0].Inputs, result.Builds[1].Inputs)
+	result.Warnings = append(result.Warnings, warnings(result.Builds[0].Inputs)...)
+	return result, nil
+}
+
+// inspect 计算可执行文件的 SHA-256 并读取其中的构建信息
+func inspect(path string) (Build, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Build{}, err
+	}
+	sum := sha256.Sum256(data)
+	b := Build{SHA256: hex.EncodeToString(sum[:]), Size: int64(len(data))}
+	info, err := buildinfo.Read(bytes.NewReader(data))
+	if err != nil {
+		return Build{}, err
+	}
+	b.Inputs = Inputs(info)
+	return b, nil
+}
+
+// localPath 判断可执行文件中是否包含 dir 的绝对路径
+func localPath(binary, dir string) bool {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(binary)
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(data, []byte(abs)) || bytes.Contains(data, []byte(filepath.ToSlash(abs)))
+}
+
+// Inputs 把构建信息展开为「输入 → 值」：go（工具链版本）、main（主模块）、
+// dep <模块路径>（版本和校验和，被替换时附带替换目标）、build <设置>（构建参数、环境和版本控制信息）
+func Inputs(info *debug.BuildInfo) map[string]string {
+	inputs := map[string]string{
+		"go":   info.GoVersion,
+		"main": strings.TrimSpace(info.Main.Path + " " + info.Main.Version + " " + info.Main.Sum),
+	}
+	for _, dep := range info.Deps {
+		value := strings.TrimSpace(dep.Version + " " + dep.Sum)
+		if r := dep.Replace; r != nil {
+			value += strings.TrimRight(" => "+r.Path+" "+r.Version+" "+r.Sum, " ")
+		}
+		inputs["dep "+dep.Path] = value
+	}
+	for _, s := range info.Settings {
+		inputs["build "+s.Key] = s.Value
+	}
+	return inputs
+}
+
+// Compare 返回两组输入中值不同的项（按输入名排序）
+func Compare(first, second map[string]string) []Difference {
+	var diffs []Difference
+	for key, value := range first {
+		if other, ok := second[key]; !ok || other != value {
+			diffs = append(diffs, Difference{Input: key, First: value, Second: other})
+		}
+	}
+	for key, value := range second {
+		if _, ok := first[key]; !ok {
+			diffs = append(diffs, Difference{Input: key, Second: value})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Input < diffs[j].Input })
+	return diffs
+}
+
+// warnings 检查构建信息中影响来源证明的设置
+func warnings(inputs map[string]string) []string {
+	var list []string
+	switch {
+	case inputs["build vcs.revision"] == "":
+		list = append(list, "构建信息中没有版本控制信息（项目不在 Git 仓库中或使用了 -buildvcs=false），无法把结果对应到某个提交")
+	case inputs["build vcs.modified"] == "true":
+		list = append(list, "工作区有未提交的修改，构建结果无法对应到提交 "+inputs["build vcs.revision"])
+	}
+	if inputs["build -trimpath"] != "true" {
+		list = append(list, "构建参数中没有 -trimpath（可能被 GOFLAGS 覆盖），可执行文件会记录本机路径")
+	}
+	return list
+}
+
+// Report 生成可复制的验证报告：结论、构建参数、来源信息、两次构建的摘要以及不同的输入
+func Report(r Result) string {
+	var b strings.Builder
+	if r.Reproducible {
+		b.WriteString("✅ 构建可复现：两次构建的结果完全相同\n")
+	} else {
+		b.WriteString("❌ 构建不可复现：两次构建的结果不同\n")
+	}
+	inputs := r.Builds[0].Inputs
+	fmt.Fprintf(&b, "\n构建参数: %s go build %s\n", strings.Join(Env, " "), strings.Join(Flags, " "))
+	fmt.Fprintf(&b, "Go 版本: %s\n", inputs["go"])
+	fmt.Fprintf(&b, "主模块: %s\n", inputs["main"])
+	fmt.Fprintf(&b, "目标平台: %s/%s\n", inputs["build GOOS"], inputs["build GOARCH"])
+	if revision := inputs["build vcs.revision"]; revision != "" {
+		fmt.Fprintf(&b, "提交: %s（%s）\n", revision, inputs["build vcs.time"])
+	}
+	for i, build := range r.Builds {
+		fmt.Fprintf(&b, "第 %d 次构建: sha256:%s（%d 字节）\n", i+1, build.SHA256, build.Size)
+	}
+
+	if len(r.Differences) > 0 {
+		b.WriteString("\n不同的构建输入:\n")
+		for _, d := range r.Differences {
+			fmt.Fprintf(&b, "  %s\n    第 1 次: %s\n    第 2 次: %s\n", d.Input, orNone(d.First), orNone(d.Second))
+		}
+	} else if !r.Reproducible {
+		b.WriteString("\n构建信息中记录的输入完全相同，差异来自构建信息之外：例如 go:embed 或代码生成的文件在两次构建之间发生了变化，" +
+			"或依赖在编译时读取了时间、随机数等环境信息\n")
+	}
+	if len(r.Warnings) > 0 {
+		b.WriteString("\n注意:\n")
+		for _, w := range r.Warnings {
+			fmt.Fprintf(&b, "  ⚠️ %s\n", w)
+		}
+	}
+	return b.String()
+}
+
+// orNone 空值显示为（无）
+func orNone(s string) string {
+	if s == "" {
+		return "（无）"
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+package reprobuild
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestInputsAndCompare(t *testing.T) {
+	info := &debug.BuildInfo{
+		GoVersion: "go1.22.0",
+		Main:      debug.Module{Path: "github.com/flipped-aurora/gin-vue-admin/server", Version: "(devel)"},
+		Deps: []*debug.Module{
+			{Path: "github.com/gin-gonic/gin", Version: "v1.9.1", Sum: "h1:abc"},
+			{Path: "example.com/local", Version: "v0.0.0", Replace: &debug.Module{Path: "../local"}},
+		},
+		Settings: []debug.BuildSetting{{Key: "-trimpath", Value: "true"}, {Key: "vcs.revision", Value: "abc123"}},
+	}
+	first := Inputs(info)
+	want := map[string]string{
+		"go":                           "go1.22.0",
+		"main":                         "github.com/flipped-aurora/gin-vue-admin/server (devel)",
+		"dep github.com/gin-gonic/gin": "v1.9.1 h1:abc",
+		"dep example.com/local":        "v0.0.0 => ../local",
+		"build -trimpath":              "true",
+		"build vcs.revision":           "abc123",
+	}
+	for key, value := range want {
+		if first[key] != value {
+			t.Errorf("%s = %q, want %q", key, first[key], value)
+		}
+	}
+
+	second := Inputs(info)
+	if diffs := Compare(first, second); len(diffs) != 0 {
+		t.Errorf("相同的输入不应有差异: %+v", diffs)
+	}
+	second["go"] = "go1.22.1"
+	delete(second, "dep example.com/local")
+	second["build vcs.modified"] = "true"
+	diffs := Compare(first, second)
+	if len(diffs) != 3 ||
+		diffs[0] != (Difference{Input: "build vcs.modified", Second: "true"}) ||
+		diffs[1] != (Difference{Input: "dep example.com/local", First: "v0.0.0 => ../local"}) ||
+		diffs[2] != (Difference{Input: "go", First: "go1.22.0", Second: "go1.22.1"}) {
+		t.Errorf("diffs = %+v", diffs)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	if got := warnings(map[string]string{"build -trimpath": "true", "build vcs.revision": "abc"}); len(got) != 0 {
+		t.Errorf("warnings = %q", got)
+	}
+	got := warnings(map[string]string{"build vcs.revision": "abc", "build vcs.modified": "true"})
+	if len(got) != 2 || !strings.Contains(got[0], "未提交的修改") || !strings.Contains(got[1], "-trimpath") {
+		t.Errorf("warnings = %q", got)
+	}
+	if got := warnings(map[string]string{"build -trimpath": "true"}); len(got) != 1 || !strings.Contains(got[0], "版本控制信息") {
+		t.Errorf("warnings = %q", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	inputs := map[string]string{"go": "go1.22.0", "build GOOS": "linux", "build GOARCH": "amd64", "build vcs.revision": "abc123"}
+	r := Result{Builds: [2]Build{{SHA256: "aa", Inputs: inputs}, {SHA256: "bb", Inputs: inputs}}}
+	text := Report(r)
+	for _, s := range []string{"❌ 构建不可复现", "CGO_ENABLED=0 go build -trimpath -ldflags=-buildid=", "目标平台: linux/amd64", "提交: abc123", "第 2 次构建: sha256:bb", "构建信息之外"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("报告中缺少 %q:\n%s", s, text)
+		}
+	}
+
+	r.Reproducible = true
+	r.Differences = []Difference{{Input: "go", First: "go1.22.0"}}
+	text = Report(r)
+	if !strings.Contains(text, "✅ 构建可复现") || !strings.Contains(text, "第 2 次: （无）") || strings.Contains(text, "构建信息之外") {
+		t.Errorf("report:\n%s", text)
+	}
+}
+
+func TestVerifyBuildFailed(t *testing.T) {
+	runner := sysutiltest.New(t)
+	_, err := Verify(context.Background(), t.TempDir(), nil, sysutil.PriorityNormal, &strings.Builder{})
+	if apperr.CodeOf(err) != apperr.BuildFailed {
+		t.Errorf("err = %v", err)
+	}
+	calls := runner.Calls()
+	if len(calls) != 1 || !strings.HasPrefix(calls[0].Command, "go build -trimpath -ldflags=-buildid= -o ") || strings.Join(calls[0].Env, " ") != "CGO_ENABLED=0" {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Verify(ctx, t.TempDir(), nil, sysutil.PriorityNormal, &strings.Builder{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("取消后 err = %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("需要用全新的构建缓存编译标准库")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("没有 go 命令")
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/repro\n\ngo 1.21\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() { println(\"gva\") }\n"), 0644)
+
+	var log strings.Builder
+	r, err := Verify(context.Background(), dir, nil, sysutil.PriorityNormal, &log)
+	if err != nil {
+		t.Fatalf("err = %v\n%s", err, log.String())
+	}
+	if !r.Reproducible || len(r.Differences) != 0 {
+		t.Errorf("result = %+v", r)
+	}
+	if r.Builds[0].Inputs["build -trimpath"] != "true" || r.Builds[0].Inputs["build CGO_ENABLED"] != "0" {
+		t.Errorf("inputs = %v", r.Builds[0].Inputs)
+	}
+}
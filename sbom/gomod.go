@@ -0,0 +1,81 @@
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/licenses"
+)
+
+// readGo 后端实际编译进去的模块（与许可证清单相同，见 licenses.ScanGo），依赖关系来自 go mod graph；
+// 依赖图读取失败时仍然返回模块列表，并记入 Warnings
+func readGo(ctx context.Context, serverDir string, goFlags []string, doc *Document) ([]Component, error) {
+	deps, err := licenses.ScanGo(ctx, serverDir, goFlags)
+	if err != nil {
+		return nil, err
+	}
+	var components []Component
+	for _, d := range deps {
+		license := d.License
+		if license == licenses.Unknown {
+			license = ""
+		}
+		components = append(components, Component{Ecosystem: licenses.Go, Name: d.Name, Version: d.Version, License: license})
+	}
+
+	output, err := sysutil.CombinedOutputContext(ctx, serverDir, "go", "mod", "graph")
+	if err != nil {
+		doc.Warnings = append(doc.Warnings, "后端: go mod graph 失败，清单中没有模块之间的依赖关系: "+strings.TrimSpace(string(output)))
+		return components, nil
+	}
+	addGoGraph(doc, mainModule(serverDir), components, output)
+	return components, nil
+}
+
+// addGoGraph 把 go mod graph 的输出（每行“模块@版本 依赖@版本”，主模块没有版本）加入依赖关系。
+// 图中是 go.mod 要求的版本，构建时使用的是 MVS 选出的版本：只保留两端都编译进后端的模块，
+// 依赖方需是选中的版本，被依赖方按路径对应到选中的版本
+func addGoGraph(doc *Document, main string, components []Component, graph []byte) {
+	selected := make(map[string]Component)
+	for _, c := range components {
+		selected[c.Name] = c
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(graph))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		fromPath, fromVersion, _ := strings.Cut(fields[0], "@")
+		toPath, _, _ := strings.Cut(fields[1], "@")
+		to, ok := selected[toPath]
+		if !ok {
+			continue
+		}
+		switch from, ok := selected[fromPath]; {
+		case fromPath == main && fromVersion == "":
+			doc.addDependency(RootRef, to.PURL())
+		case ok && from.Version == fromVersion:
+			doc.addDependency(from.PURL(), to.PURL())
+		}
+	}
+}
+
+// mainModule server/go.mod 中的模块路径
+func mainModule(serverDir string) string {
+	data, err := os.ReadFile(filepath.Join(serverDir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package sbom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/licenses"
+)
+
+// lockFile package-lock.json（lockfileVersion 2 / 3 的 packages 字段，键为安装路径，例如 node_modules/a/node_modules/b）
+type lockFile struct {
+	LockfileVersion int                    `json:"lockfileVersion"`
+	Packages        map[string]lockPackage `json:"packages"`
+}
+
+// lockPackage 锁文件中的一个包
+type lockPackage struct {
+	Version              string            `json:"version"`
+	Integrity            string            `json:"integrity"`
+	License              json.RawMessage   `json:"license"`
+	Dev                  bool              `json:"dev"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+}
+
+// license 包声明的许可证（只识别字符串写法）
+func (p lockPackage) license() string {
+	var text string
+	json.Unmarshal(p.License, &text)
+	return text
+}
+
+// requires 包的依赖名称（开发依赖只对项目本身有意义，已安装的包不会安装自己的开发依赖）
+func (p lockPackage) requires(root bool) []string {
+	var names []string
+	for _, deps := range []map[string]string{p.Dependencies, p.OptionalDependencies} {
+		for name := range deps {
+			names = append(names, name)
+		}
+	}
+	if root {
+		for name := range p.DevDependencies {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// lockPaths 依次查找的锁文件：项目提交的 package-lock.json，以及 npm install 在 node_modules 中生成的隐藏锁文件
+var lockPaths = []string{"package-lock.json", filepath.Join("node_modules", ".package-lock.json")}
+
+// readNpm 读取 webDir 中的 npm 锁文件，依赖关系按 Node.js 的查找规则（从所在目录向上查找 node_modules）解析
+func readNpm(webDir string, doc *Document) ([]Component, error) {
+	var data []byte
+	var err error
+	for _, name := range lockPaths {
+		if data, err = os.ReadFile(filepath.Join(webDir, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, apperr.Errorf(apperr.SBOMFailed, "没有找到 web/package-lock.json，请先安装前端依赖（暂不支持 pnpm / yarn 的锁文件）")
+	}
+	var lock lockFile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, apperr.Errorf(apperr.SBOMFailed, "package-lock.json 格式错误: %v", err)
+	}
+	if lock.Packages == nil {
+		return nil, apperr.Errorf(apperr.SBOMFailed, "package-lock.json 的 lockfileVersion 为 %d，请用 npm 7 及以上版本重新安装依赖", lock.LockfileVersion)
+	}
+
+	// 同名同版本的包可能安装在多个位置，只记录一次；任一位置不是开发依赖时就不是开发依赖
+	refs := make(map[string]string)
+	index := make(map[string]int)
+	var components []Component
+	for path, pkg := range lock.Packages {
+		if path == "" || pkg.Link || pkg.Version == "" {
+			continue
+		}
+		c := Component{
+			Ecosystem: licenses.Npm,
+			Name:      packageName(path),
+			Version:   pkg.Version,
+			License:   pkg.license(),
+			Dev:       pkg.Dev,
+			Integrity: pkg.Integrity,
+		}
+		ref := c.PURL()
+		refs[path] = ref
+		if i, ok := index[ref]; ok {
+			components[i].Dev = components[i].Dev && c.Dev
+			continue
+		}
+		index[ref] = len(components)
+		components = append(components, c)
+	}
+
+	for path, pkg := range lock.Packages {
+		from := refs[path]
+		if path == "" {
+			from = RootRef
+		} else if from == "" {
+			continue
+		}
+		for _, name := range pkg.requires(path == "") {
+			if to := refs[resolve(lock.Packages, path, name)]; to != "" {
+				doc.addDependency(from, to)
+			}
+		}
+	}
+	return components, nil
+}
+
+// packageName 安装路径中的包名（最后一个 node_modules/ 之后的部分，包括作用域）
+func packageName(path string) string {
+	if i := strings.LastIndex(path, "node_modules/"); i >= 0 {
+		return path[i+len("node_modules/"):]
+	}
+	return path
+}
+
+// resolve 从安装路径 from 查找依赖 name 实际使用的安装路径：先找 from/node_modules/name，再逐级向上，找不到时为空
+func resolve(packages map[string]lockPackage, from, name string) string {
+	dir := from
+	for {
+		candidate := "node_modules/" + name
+		if dir != "" {
+			candidate = dir + "/" + candidate
+		}
+		if _, ok := packages[candidate]; ok {
+			return candidate
+		}
+		if dir == "" {
+			return ""
+		}
+		// 去掉最后一级 node_modules/<包名>（作用域包名包含一个 /）
+		i := strings.LastIndex(dir, "node_modules/")
+		if i < 0 {
+			dir = ""
+			continue
+		}
+		dir = strings.TrimSuffix(dir[:i], "/")
+	}
+}
@@ -0,0 +1,157 @@
+// Package sbom 生成项目的软件物料清单（SBOM）：后端为实际编译进后端的 Go 模块（go list -deps）及 go mod graph 中的依赖关系，
+// 前端为 package-lock.json 中的包（开发依赖标为不随产物发布），输出 CycloneDX 1.5 和 SPDX 2.3 两种 JSON 格式，
+// 与构建产物放在一起（构建后端时写在 server/ 中，部署时打包进发布包的根目录）
+package sbom
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/licenses"
+)
+
+// 输出的文件名
+const (
+	CycloneDXName = "sbom.cdx.json"
+	SPDXName      = "sbom.spdx.json"
+)
+
+// Component 清单中的一个依赖
+type Component struct {
+	Ecosystem string // licenses.Npm / licenses.Go
+	Name      string
+	Version   string
+	License   string // SPDX 标识或表达式（没有识别出时为空）
+	Dev       bool   // 开发依赖：只用于构建，不随产物发布
+	Integrity string // npm 锁文件中的完整性校验值（sha512-<base64>），没有时为空
+}
+
+// PURL 组件的 Package URL（同时作为清单中引用组件的标识）
+func (c Component) PURL() string {
+	purl := "pkg:golang/" + c.Name
+	if c.Ecosystem != licenses.Go {
+		// 作用域包 @scope/name 中的 @ 需要编码
+		purl = "pkg:npm/" + strings.Replace(c.Name, "@", "%40", 1)
+	}
+	if c.Version == "" {
+		// 本地 replace 的模块没有版本
+		return purl
+	}
+	return purl + "@" + url.PathEscape(c.Version)
+}
+
+// Document 项目的物料清单
+type Document struct {
+	Name        string    // 项目名（根目录名）
+	Version     string    // 项目版本（web/package.json 的 version，没有时为空）
+	Created     time.Time // 生成时间
+	ToolVersion string    // 生成清单的面板版本
+	Serial      string    // 清单的唯一标识（UUID）
+	Components  []Component
+	// Dependencies 依赖关系：组件的 PURL → 直接依赖的 PURL；键为 RootRef 时是项目的直接依赖
+	Dependencies map[string][]string
+	Warnings     []string // 没有包含的部分（例如没有找到 package-lock.json）
+}
+
+// RootRef 依赖关系中表示项目本身的标识
+const RootRef = "gva-project"
+
+// Generate 生成项目 root 的物料清单；一侧无法读取时记入 Warnings，两侧都无法读取时返回错误。
+// goFlags 为 Go 工作区模式下附加的参数
+func Generate(ctx context.Context, root string, goFlags []string) (Document, error) {
+	doc := Document{
+		Name:         filepath.Base(root),
+		Version:      projectVersion(filepath.Join(root, "web")),
+		Created:      time.Now().UTC(),
+		Serial:       newUUID(),
+		Dependencies: make(map[string][]string),
+	}
+
+	npmComponents, npmErr := readNpm(filepath.Join(root, "web"), &doc)
+	if npmErr != nil {
+		doc.Warnings = append(doc.Warnings, "前端: "+npmErr.Error())
+	}
+	goComponents, goErr := readGo(ctx, filepath.Join(root, "server"), goFlags, &doc)
+	if goErr != nil {
+		doc.Warnings = append(doc.Warnings, "后端: "+goErr.Error())
+	}
+	if npmErr != nil && goErr != nil {
+		return doc, apperr.Errorf(apperr.SBOMFailed, "%s", strings.Join(doc.Warnings, "\n"))
+	}
+	doc.Components = append(npmComponents, goComponents...)
+	sort.Slice(doc.Components, func(i, j int) bool {
+		a, b := doc.Components[i], doc.Components[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return doc, nil
+}
+
+// addDependency 记录 from 直接依赖 to（重复的关系只记录一次）
+func (d *Document) addDependency(from, to string) {
+	for _, existing := range d.Dependencies[from] {
+		if existing == to {
+			return
+		}
+	}
+	d.Dependencies[from] = append(d.Dependencies[from], to)
+}
+
+// WriteFiles 把 CycloneDX 和 SPDX 两种格式写入 dir，返回写入的文件路径
+func (d Document) WriteFiles(dir string) ([]string, error) {
+	var paths []string
+	for _, f := range []struct {
+		name  string
+		write func(Document) ([]byte, error)
+	}{
+		{CycloneDXName, CycloneDX},
+		{SPDXName, SPDX},
+	} {
+		data, err := f.write(d)
+		if err != nil {
+			return paths, apperr.Errorf(apperr.SBOMFailed, "生成 %s 失败: %v", f.name, err)
+		}
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return paths, apperr.Errorf(apperr.SBOMFailed, "写入 %s 失败: %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// projectVersion web/package.json 中的版本号（GVA 的版本，读取失败时为空）
+func projectVersion(webDir string) string {
+	data, err := os.ReadFile(filepath.Join(webDir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Version string `json:"version"`
+	}
+	json.Unmarshal(data, &pkg)
+	return pkg.Version
+}
+
+// newUUID 随机生成的 UUID（版本 4）
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,242 @@
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+	"gva-launcher/licenses"
+)
+
+// testLock lockfileVersion 3：vue 依赖两个版本的 @vue/shared（嵌套安装的版本优先），vite 是开发依赖
+const testLock = `{
+  "name": "gin-vue-admin",
+  "version": "2.7.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "gin-vue-admin", "version": "2.7.0", "dependencies": {"vue": "^3.4.0"}, "devDependencies": {"vite": "^5.0.0"}},
+    "node_modules/vue": {"version": "3.4.0", "license": "MIT", "integrity": "sha512-AAECAw==", "dependencies": {"@vue/shared": "3.4.0"}},
+    "node_modules/vue/node_modules/@vue/shared": {"version": "3.4.0", "license": "MIT"},
+    "node_modules/@vue/shared": {"version": "3.3.0", "license": "MIT", "dev": true},
+    "node_modules/vite": {"version": "5.0.0", "license": "MIT", "dev": true, "dependencies": {"@vue/shared": "*"}},
+    "node_modules/local": {"resolved": "../local", "link": true}
+  }
+}`
+
+func writeProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	web := filepath.Join(root, "web")
+	os.MkdirAll(web, 0755)
+	os.WriteFile(filepath.Join(web, "package.json"), []byte(`{"name": "gin-vue-admin", "version": "2.7.0"}`), 0644)
+	os.WriteFile(filepath.Join(web, "package-lock.json"), []byte(testLock), 0644)
+	server := filepath.Join(root, "server")
+	os.MkdirAll(server, 0755)
+	os.WriteFile(filepath.Join(server, "go.mod"), []byte("module github.com/flipped-aurora/gin-vue-admin/server\n"), 0644)
+	return root
+}
+
+func find(components []Component, name, version string) (Component, bool) {
+	for _, c := range components {
+		if c.Name == name && c.Version == version {
+			return c, true
+		}
+	}
+	return Component{}, false
+}
+
+func TestReadNpm(t *testing.T) {
+	root := writeProject(t)
+	doc := Document{Dependencies: map[string][]string{}}
+	components, err := readNpm(filepath.Join(root, "web"), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 4 {
+		t.Fatalf("components = %+v", components)
+	}
+	vue, _ := find(components, "vue", "3.4.0")
+	nested, _ := find(components, "@vue/shared", "3.4.0")
+	hoisted, _ := find(components, "@vue/shared", "3.3.0")
+	vite, _ := find(components, "vite", "5.0.0")
+	if vue.Dev || !vite.Dev || !hoisted.Dev || nested.Dev {
+		t.Errorf("dev 标记不正确: %+v", components)
+	}
+	if nested.PURL() != "pkg:npm/%40vue/shared@3.4.0" {
+		t.Errorf("purl = %s", nested.PURL())
+	}
+	want := map[string][]string{
+		RootRef:     {vite.PURL(), vue.PURL()},
+		vue.PURL():  {nested.PURL()},
+		vite.PURL(): {hoisted.PURL()},
+	}
+	for from, to := range want {
+		got := doc.Dependencies[from]
+		if !slices.Equal(slices.Sorted(slices.Values(got)), slices.Sorted(slices.Values(to))) {
+			t.Errorf("%s 依赖 %v, want %v", from, got, to)
+		}
+	}
+
+	if _, err := readNpm(t.TempDir(), &doc); apperr.CodeOf(err) != apperr.SBOMFailed {
+		t.Errorf("没有锁文件时 err = %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	root := writeProject(t)
+	runner := sysutiltest.New(t)
+	runner.Handle(`go list -deps -f {{with .Module}}{{if not .Main}}{{.Path}}{{"\t"}}{{.Version}}{{"\t"}}{{.Dir}}{{end}}{{end}} ./...`,
+		"github.com/gin-gonic/gin\tv1.10.0\t\ngithub.com/gin-contrib/sse\tv0.1.0\t\n", nil)
+	runner.Handle("go mod graph",
+		"github.com/flipped-aurora/gin-vue-admin/server github.com/gin-gonic/gin@v1.10.0\n"+
+			"github.com/gin-gonic/gin@v1.10.0 github.com/gin-contrib/sse@v0.0.9\n"+
+			"github.com/gin-gonic/gin@v1.9.0 github.com/gin-contrib/sse@v0.0.1\n"+
+			"github.com/gin-gonic/gin@v1.10.0 golang.org/x/tools@v0.1.0\n", nil)
+
+	doc, err := Generate(context.Background(), root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Name != filepath.Base(root) || doc.Version != "2.7.0" || len(doc.Components) != 6 || len(doc.Warnings) != 0 {
+		t.Fatalf("doc = %+v", doc)
+	}
+	gin := "pkg:golang/github.com/gin-gonic/gin@v1.10.0"
+	if got := doc.Dependencies[gin]; len(got) != 1 || got[0] != "pkg:golang/github.com/gin-contrib/sse@v0.1.0" {
+		t.Errorf("gin 依赖 %v（应对应到选中的版本，忽略没有编译进后端的模块）", got)
+	}
+	if !strings.Contains(strings.Join(doc.Dependencies[RootRef], " "), gin) {
+		t.Errorf("项目应直接依赖 gin: %v", doc.Dependencies[RootRef])
+	}
+	if c, _ := find(doc.Components, "github.com/gin-gonic/gin", "v1.10.0"); c.Ecosystem != licenses.Go || c.License != "" {
+		t.Errorf("gin = %+v", c)
+	}
+
+	// 两侧都无法读取时返回错误
+	if _, err := Generate(context.Background(), t.TempDir(), nil); apperr.CodeOf(err) != apperr.SBOMFailed {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func testDocument() Document {
+	doc := Document{
+		Name:        "gva",
+		Version:     "2.7.0",
+		Created:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		ToolVersion: "v1.2.0",
+		Serial:      "00000000-0000-4000-8000-000000000000",
+		Components: []Component{
+			{Ecosystem: licenses.Npm, Name: "vue", Version: "3.4.0", License: "MIT", Integrity: "sha512-AAECAw=="},
+			{Ecosystem: licenses.Npm, Name: "vite", Version: "5.0.0", License: "SEE LICENSE IN LICENSE.md", Dev: true},
+			{Ecosystem: licenses.Go, Name: "github.com/gin-gonic/gin", Version: "v1.10.0", License: "(MIT OR Apache-2.0)"},
+		},
+		Dependencies: map[string][]string{},
+	}
+	doc.addDependency(RootRef, doc.Components[0].PURL())
+	doc.addDependency(RootRef, doc.Components[1].PURL())
+	doc.addDependency(RootRef, doc.Components[0].PURL())
+	return doc
+}
+
+func TestCycloneDX(t *testing.T) {
+	data, err := CycloneDX(testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bom struct {
+		BOMFormat    string `json:"bomFormat"`
+		SpecVersion  string `json:"specVersion"`
+		SerialNumber string `json:"serialNumber"`
+		Metadata     struct {
+			Component struct{ Name, Version string }
+		}
+		Components []struct {
+			Name     string
+			Scope    string
+			PURL     string
+			Licenses []struct{ Expression string }
+			Hashes   []struct{ Alg, Content string }
+		}
+		Dependencies []struct {
+			Ref       string
+			DependsOn []string
+		}
+	}
+	if err := json.Unmarshal(data, &bom); err != nil {
+		t.Fatal(err)
+	}
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != "1.5" || bom.SerialNumber != "urn:uuid:00000000-0000-4000-8000-000000000000" ||
+		bom.Metadata.Component.Name != "gva" || len(bom.Components) != 3 {
+		t.Fatalf("bom = %s", data)
+	}
+	vue, vite, gin := bom.Components[0], bom.Components[1], bom.Components[2]
+	if vue.Scope != "required" || vue.Hashes[0].Alg != "SHA-512" || vue.Hashes[0].Content != "00010203" {
+		t.Errorf("vue = %+v", vue)
+	}
+	if vite.Scope != "excluded" || len(vite.Licenses) != 0 {
+		t.Errorf("vite = %+v（开发依赖的 scope 为 excluded，无法识别的许可证写法不写入）", vite)
+	}
+	if gin.PURL != "pkg:golang/github.com/gin-gonic/gin@v1.10.0" || gin.Licenses[0].Expression != "(MIT OR Apache-2.0)" {
+		t.Errorf("gin = %+v", gin)
+	}
+	if len(bom.Dependencies) != 1 || bom.Dependencies[0].Ref != RootRef || len(bom.Dependencies[0].DependsOn) != 2 {
+		t.Errorf("dependencies = %+v", bom.Dependencies)
+	}
+}
+
+func TestSPDX(t *testing.T) {
+	data, err := SPDX(testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		SPDXVersion  string `json:"spdxVersion"`
+		CreationInfo struct{ Creators []string }
+		Packages     []struct {
+			SPDXID          string
+			LicenseDeclared string
+			Checksums       []struct{ Algorithm, ChecksumValue string }
+		}
+		Relationships []struct {
+			SPDXElementID      string
+			RelationshipType   string
+			RelatedSPDXElement string
+		}
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.SPDXVersion != "SPDX-2.3" || doc.CreationInfo.Creators[0] != "Tool: GVAPanel-v1.2.0" || len(doc.Packages) != 4 {
+		t.Fatalf("doc = %s", data)
+	}
+	if doc.Packages[1].LicenseDeclared != "MIT" || doc.Packages[1].Checksums[0].Algorithm != "SHA512" || doc.Packages[2].LicenseDeclared != "NOASSERTION" {
+		t.Errorf("packages = %+v", doc.Packages)
+	}
+	var types []string
+	for _, r := range doc.Relationships {
+		types = append(types, r.SPDXElementID+" "+r.RelationshipType+" "+r.RelatedSPDXElement)
+	}
+	want := "SPDXRef-DOCUMENT DESCRIBES SPDXRef-Project|SPDXRef-Package-2 DEV_DEPENDENCY_OF SPDXRef-Project|SPDXRef-Project DEPENDS_ON SPDXRef-Package-1"
+	if strings.Join(types, "|") != want {
+		t.Errorf("relationships = %v", types)
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := testDocument().WriteFiles(dir)
+	if err != nil || len(paths) != 2 || filepath.Base(paths[0]) != CycloneDXName || filepath.Base(paths[1]) != SPDXName {
+		t.Fatalf("paths = %v, err = %v", paths, err)
+	}
+	for _, path := range paths {
+		if data, err := os.ReadFile(path); err != nil || !json.Valid(data) {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package sbom
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// toolName 清单中记录的生成工具
+const toolName = "GVAPanel"
+
+// CycloneDX 生成 CycloneDX 1.5 JSON：开发依赖的 scope 为 excluded（不随产物发布）
+func CycloneDX(d Document) ([]byte, error) {
+	type license struct {
+		Expression string `json:"expression"`
+	}
+	type hash struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	}
+	type component struct {
+		Type     string    `json:"type"`
+		BOMRef   string    `json:"bom-ref"`
+		Name     string    `json:"name"`
+		Version  string    `json:"version,omitempty"`
+		Scope    string    `json:"scope,omitempty"`
+		PURL     string    `json:"purl,omitempty"`
+		Licenses []license `json:"licenses,omitempty"`
+		Hashes   []hash    `json:"hashes,omitempty"`
+	}
+	type dependency struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	}
+	type tool struct {
+		Type    string `json:"type"`
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+
+	var components []component
+	for _, c := range d.Components {
+		cc := component{Type: "library", BOMRef: c.PURL(), Name: c.Name, Version: c.Version, Scope: "required", PURL: c.PURL()}
+		if c.Dev {
+			cc.Scope = "excluded"
+		}
+		if validExpression(c.License) {
+			cc.Licenses = []license{{Expression: c.License}}
+		}
+		if alg, sum := integrityHash(c.Integrity); sum != "" {
+			cc.Hashes = []hash{{Alg: "SHA-" + strings.TrimPrefix(alg, "sha"), Content: sum}}
+		}
+		components = append(components, cc)
+	}
+	var deps []dependency
+	for _, ref := range dependencyRefs(d) {
+		deps = append(deps, dependency{Ref: ref, DependsOn: d.Dependencies[ref]})
+	}
+
+	bom := map[string]any{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": "urn:uuid:" + d.Serial,
+		"version":      1,
+		"metadata": map[string]any{
+			"timestamp": d.Created.Format(time.RFC3339),
+			"tools":     map[string]any{"components": []tool{{Type: "application", Name: toolName, Version: d.ToolVersion}}},
+			"component": component{Type: "application", BOMRef: RootRef, Name: d.Name, Version: d.Version},
+		},
+		"components":   components,
+		"dependencies": deps,
+	}
+	return json.MarshalIndent(bom, "", "  ")
+}
+
+// SPDX 生成 SPDX 2.3 JSON：项目为文档描述的包，依赖关系为 DEPENDS_ON，项目的直接开发依赖为 DEV_DEPENDENCY_OF
+func SPDX(d Document) ([]byte, error) {
+	type checksum struct {
+		Algorithm string `json:"algorithm"`
+		Value     string `json:"checksumValue"`
+	}
+	type externalRef struct {
+		Category string `json:"referenceCategory"`
+		Type     string `json:"referenceType"`
+		Locator  string `json:"referenceLocator"`
+	}
+	type pkg struct {
+		ID               string        `json:"SPDXID"`
+		Name             string        `json:"name"`
+		Version          string        `json:"versionInfo,omitempty"`
+		Download         string        `json:"downloadLocation"`
+		FilesAnalyzed    bool          `json:"filesAnalyzed"`
+		LicenseConcluded string        `json:"licenseConcluded"`
+		LicenseDeclared  string        `json:"licenseDeclared"`
+		Copyright        string        `json:"copyrightText"`
+		Checksums        []checksum    `json:"checksums,omitempty"`
+		ExternalRefs     []externalRef `json:"externalRefs,omitempty"`
+	}
+	type relationship struct {
+		Element string `json:"spdxElementId"`
+		Type    string `json:"relationshipType"`
+		Related string `json:"relatedSpdxElement"`
+	}
+
+	const noAssertion = "NOASSERTION"
+	rootID := "SPDXRef-Project"
+	ids := map[string]string{RootRef: rootID}
+	dev := make(map[string]bool)
+	packages := []pkg{{ID: rootID, Name: d.Name, Version: d.Version, Download: noAssertion,
+		LicenseConcluded: noAssertion, LicenseDeclared: noAssertion, Copyright: noAssertion}}
+	for i, c := range d.Components {
+		id := "SPDXRef-Package-" + strconv.Itoa(i+1)
+		ids[c.PURL()] = id
+		dev[c.PURL()] = c.Dev
+		p := pkg{ID: id, Name: c.Name, Version: c.Version, Download: noAssertion,
+			LicenseConcluded: noAssertion, LicenseDeclared: noAssertion, Copyright: noAssertion,
+			ExternalRefs: []externalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: c.PURL()}}}
+		if validExpression(c.License) {
+			p.LicenseDeclared = c.License
+		}
+		if alg, sum := integrityHash(c.Integrity); sum != "" {
+			p.Checksums = []checksum{{Algorithm: strings.ToUpper(alg), Value: sum}}
+		}
+		packages = append(packages, p)
+	}
+
+	relationships := []relationship{{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", Related: rootID}}
+	for _, ref := range dependencyRefs(d) {
+		for _, to := range d.Dependencies[ref] {
+			if ids[ref] == "" || ids[to] == "" {
+				continue
+			}
+			if ref == RootRef && dev[to] {
+				relationships = append(relationships, relationship{Element: ids[to], Type: "DEV_DEPENDENCY_OF", Related: rootID})
+				continue
+			}
+			relationships = append(relationships, relationship{Element: ids[ref], Type: "DEPENDS_ON", Related: ids[to]})
+		}
+	}
+
+	doc := map[string]any{
+		"spdxVersion":       "SPDX-2.3",
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"name":              d.Name,
+		"documentNamespace": "https://spdx.org/spdxdocs/" + d.Name + "-" + d.Serial,
+		"creationInfo": map[string]any{
+			"created":  d.Created.Format(time.RFC3339),
+			"creators": []string{"Tool: " + strings.TrimSuffix(toolName+"-"+d.ToolVersion, "-")},
+		},
+		"documentDescribes": []string{rootID},
+		"packages":          packages,
+		"relationships":     relationships,
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// dependencyRefs 依赖关系中的组件（项目在前，其余按标识排序，保证输出稳定）
+func dependencyRefs(d Document) []string {
+	var refs []string
+	for ref := range d.Dependencies {
+		if ref != RootRef {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	for _, to := range d.Dependencies {
+		sort.Strings(to)
+	}
+	if _, ok := d.Dependencies[RootRef]; ok {
+		refs = append([]string{RootRef}, refs...)
+	}
+	return refs
+}
+
+// expressionPattern 可以写入清单的许可证表达式（SPDX 标识、AND / OR / WITH 和括号）；
+// 其他写法（例如 SEE LICENSE IN LICENSE.md）写为未声明
+var expressionPattern = regexp.MustCompile(`^[A-Za-z0-9.+\-]+(\s+(AND|OR|WITH)\s+\(*[A-Za-z0-9.+\-]+\)*)*$`)
+
+// validExpression 许可证是否可以作为 SPDX 表达式写入清单
+func validExpression(license string) bool {
+	license = strings.Trim(license, "()")
+	return license != "" && expressionPattern.MatchString(license)
+}
+
+// integrityHash 把 npm 的完整性校验值（sha512-<base64>，可能有多个）转换为算法和十六进制摘要；
+// 只接受 sha256 / sha384 / sha512，无法识别时摘要为空
+func integrityHash(integrity string) (alg, sum string) {
+	for _, part := range strings.Fields(integrity) {
+		alg, encoded, ok := strings.Cut(part, "-")
+		if !ok || (alg != "sha256" && alg != "sha384" && alg != "sha512") {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		return alg, hex.EncodeToString(data)
+	}
+	return "", ""
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+// at 构造本地时间
+func at(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) 应返回错误", spec)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		spec, from, want string
+	}{
+		{"0 2 * * *", "2025-03-10 01:59", "2025-03-10 02:00"},
+		{"0 2 * * *", "2025-03-10 02:00", "2025-03-11 02:00"},
+		{"@nightly", "2025-03-10 10:30", "2025-03-11 02:00"},
+		{"*/15 * * * *", "2025-03-10 10:01", "2025-03-10 10:15"},
+		{"5/20 * * * *", "2025-03-10 10:26", "2025-03-10 10:45"},
+		{"0 9-17 * * 1-5", "2025-03-08 12:00", "2025-03-10 09:00"}, // 周六 -> 周一
+		{"@weekly", "2025-03-10 00:00", "2025-03-16 03:00"},        // 下一个周日
+		{"0 0 * * 7", "2025-03-10 00:00", "2025-03-16 00:00"},      // 7 也表示周日
+		{"0 0 1 * *", "2025-03-10 00:00", "2025-04-01 00:00"},
+		{"0 0 13 * 5", "2025-03-10 00:00", "2025-03-13 00:00"}, // 日和周满足其一
+		{"30 8 1,15 * *", "2025-03-02 00:00", "2025-03-15 08:30"},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.spec, err)
+		}
+		if got := c.Next(at(tt.from)); !got.Equal(at(tt.want)) {
+			t.Errorf("%q 从 %s 起的下次执行 = %s, want %s", tt.spec, tt.from, got.Format("2006-01-02 15:04"), tt.want)
+		}
+	}
+}
+
+func TestCronNeverMatches(t *testing.T) {
+	c, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Next(at("2025-01-01 00:00")); !got.IsZero() {
+		t.Errorf("2 月 31 日不存在，应返回零值, got %s", got)
+	}
+}
@@ -0,0 +1,186 @@
+// Package scheduler 是面板的定时任务子系统：按 cron 表达式周期性地把任务提交到任务队列，
+// 并记录每个任务的上次执行结果
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/jobs"
+)
+
+// Action 定时任务可执行的动作
+type Action struct {
+	ID    string // 保存在配置中的动作名，例如 dep-audit
+	Label string // 界面显示的名称
+	Run   func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error
+}
+
+// State 任务的执行情况
+type State struct {
+	LastRun    time.Time
+	LastStatus jobs.Status // 未执行过时为空
+	LastError  string
+	Running    bool
+}
+
+// Scheduler 定时任务调度器
+type Scheduler struct {
+	queue   *jobs.Queue
+	actions []Action
+	tasks   func() []config.ScheduledTask
+
+	mu     sync.Mutex
+	states map[string]*State
+
+	// OnChange 任务状态变化时调用（在调度协程中执行，可为 nil）
+	OnChange func()
+}
+
+// New 创建调度器（tasks 在每次检查时调用，保证读取到最新配置）
+func New(queue *jobs.Queue, actions []Action, tasks func() []config.ScheduledTask) *Scheduler {
+	return &Scheduler{
+		queue:   queue,
+		actions: actions,
+		tasks:   tasks,
+		states:  make(map[string]*State),
+	}
+}
+
+// Actions 所有可用动作（按注册顺序）
+func (s *Scheduler) Actions() []Action {
+	return s.actions
+}
+
+// Action 按 ID 查找动作
+func (s *Scheduler) Action(id string) (Action, bool) {
+	for _, a := range s.actions {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Action{}, false
+}
+
+// Run 每分钟整点检查一次并提交命中的任务，直到 ctx 取消（调用方在后台协程中运行）
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		wait := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case t := <-timer.C:
+			s.tick(t)
+		}
+	}
+}
+
+// tick 提交当前分钟命中的所有已启用任务
+func (s *Scheduler) tick(t time.Time) {
+	for _, task := range s.tasks() {
+		if !task.Enabled {
+			continue
+		}
+		cron, err := ParseCron(task.Cron)
+		if err != nil || !cron.Matches(t) {
+			continue
+		}
+		s.RunNow(task)
+	}
+}
+
+// RunNow 立即提交任务（上一次还在执行时跳过，返回 nil）
+func (s *Scheduler) RunNow(task config.ScheduledTask) *jobs.Job {
+	action, ok := s.Action(task.Action)
+	if !ok {
+		s.finish(task.Name, time.Now(), jobs.StatusFailed, fmt.Errorf("未知的任务动作: %s", task.Action))
+		return nil
+	}
+
+	s.mu.Lock()
+	state := s.state(task.Name)
+	if state.Running {
+		s.mu.Unlock()
+		return nil
+	}
+	state.Running = true
+	s.mu.Unlock()
+	s.changed()
+
+	job := s.queue.Submit("定时任务 "+task.Name, func(ctx context.Context, j *jobs.Job) error {
+		start := time.Now()
+		err := action.Run(ctx, j, task)
+		status := jobs.StatusSucceeded
+		if err != nil {
+			status = jobs.StatusFailed
+		}
+		s.finish(task.Name, start, status, err)
+		return err
+	})
+
+	// 在任务中心取消的任务（排队中取消时任务函数不会执行）同样要结束运行状态
+	go func() {
+		<-job.Done()
+		if job.Status() == jobs.StatusCanceled {
+			s.finish(task.Name, time.Now(), jobs.StatusCanceled, job.Err())
+		}
+	}()
+	return job
+}
+
+// finish 记录执行结果
+func (s *Scheduler) finish(name string, start time.Time, status jobs.Status, err error) {
+	s.mu.Lock()
+	state := s.state(name)
+	state.Running = false
+	state.LastRun = start
+	state.LastStatus = status
+	state.LastError = ""
+	if err != nil {
+		state.LastError = err.Error()
+	}
+	s.mu.Unlock()
+	s.changed()
+}
+
+// State 任务的执行情况（按任务名）
+func (s *Scheduler) State(name string) State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.state(name)
+}
+
+// state 获取或创建任务状态（调用方持有锁）
+func (s *Scheduler) state(name string) *State {
+	st, ok := s.states[name]
+	if !ok {
+		st = &State{}
+		s.states[name] = st
+	}
+	return st
+}
+
+// changed 通知状态变化
+func (s *Scheduler) changed() {
+	if s.OnChange != nil {
+		s.OnChange()
+	}
+}
+
+// NextRun 计算任务的下次执行时间（未启用或表达式错误时返回零值和原因）
+func NextRun(task config.ScheduledTask, after time.Time) (time.Time, error) {
+	if !task.Enabled {
+		return time.Time{}, fmt.Errorf("未启用")
+	}
+	cron, err := ParseCron(task.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cron.Next(after), nil
+}
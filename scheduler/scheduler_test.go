@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gva-launcher/config"
+	"gva-launcher/jobs"
+)
+
+func TestRunNowRecordsState(t *testing.T) {
+	actions := []Action{
+		{ID: "ok", Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error { return nil }},
+		{ID: "fail", Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error { return errors.New("boom") }},
+	}
+	s := New(startQueue(t), actions, func() []config.ScheduledTask { return nil })
+
+	if err := s.RunNow(config.ScheduledTask{Name: "a", Action: "ok"}).Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if st := s.State("a"); st.LastStatus != jobs.StatusSucceeded || st.Running || st.LastRun.IsZero() {
+		t.Errorf("state(a) = %+v", st)
+	}
+
+	s.RunNow(config.ScheduledTask{Name: "b", Action: "fail"}).Wait()
+	if st := s.State("b"); st.LastStatus != jobs.StatusFailed || st.LastError != "boom" {
+		t.Errorf("state(b) = %+v", st)
+	}
+
+	if job := s.RunNow(config.ScheduledTask{Name: "c", Action: "missing"}); job != nil {
+		t.Error("未知动作不应提交任务")
+	}
+	if st := s.State("c"); st.LastStatus != jobs.StatusFailed {
+		t.Errorf("state(c) = %+v", st)
+	}
+}
+
+func TestTickSkipsDisabled(t *testing.T) {
+	ran := make(chan string, 2)
+	actions := []Action{{ID: "x", Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+		ran <- task.Name
+		return nil
+	}}}
+	tasks := []config.ScheduledTask{
+		{Name: "off", Cron: "* * * * *", Action: "x", Enabled: false},
+		{Name: "bad", Cron: "nope", Action: "x", Enabled: true},
+		{Name: "on", Cron: "* * * * *", Action: "x", Enabled: true},
+	}
+	s := New(startQueue(t), actions, func() []config.ScheduledTask { return tasks })
+
+	s.tick(at("2025-03-10 10:00"))
+	if name := <-ran; name != "on" {
+		t.Errorf("执行了 %s, want on", name)
+	}
+	if len(ran) != 0 {
+		t.Errorf("只应执行一个任务")
+	}
+}
+
+// startQueue 创建任务队列并在后台执行，测试结束时停止
+func startQueue(t *testing.T) *jobs.Queue {
+	q := jobs.NewQueue("")
+	ctx, cancel := context.WithCancel(context.Background())
+	go q.Run(ctx)
+	t.Cleanup(cancel)
+	return q
+}
@@ -0,0 +1,144 @@
+// Package script 是脚本控制台使用的脚本运行时：嵌入 Starlark（Python 语法的子集）解释器，
+// 通过注册的函数调用 launcher 接口，适合编写一次性的自动化片段。
+//
+//	# 依赖缺失时先安装，再启动服务并等待后端端口
+//	if not deps_ok():
+//	    install()
+//	start()
+//	port = backend_port()
+//	print("后端已启动:", wait_port(port, 60))
+//
+// 顶层允许 if / for / while 语句，变量可以重新赋值；语法错误时不执行任何语句
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"go.starlark.net/resolve"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// fileName 错误信息中脚本的文件名
+const fileName = "console.star"
+
+// fileOptions 控制台脚本的语言选项：允许顶层的控制语句和 while，便于像 shell 脚本一样逐行书写
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+// Func 脚本可调用的函数（参数按 Starlark 的调用约定传入，可用 starlark.UnpackArgs 解析；ctx 在脚本取消时取消）
+type Func func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)
+
+// Funcs 函数表（函数名 → 实现）
+type Funcs map[string]Func
+
+// Interpreter 脚本解释器
+type Interpreter struct {
+	funcs   Funcs
+	globals starlark.StringDict
+	out     io.Writer
+}
+
+// New 创建解释器（out 接收 print 的输出；funcs 可覆盖内置函数）
+func New(out io.Writer, funcs Funcs) *Interpreter {
+	in := &Interpreter{funcs: Funcs{"sleep": sleep}, out: out}
+	for name, fn := range funcs {
+		in.funcs[name] = fn
+	}
+	return in
+}
+
+// Names 注册的函数名（按字母排序，不含 Starlark 自带的 print、len 等）
+func (in *Interpreter) Names() []string {
+	names := make([]string, 0, len(in.funcs))
+	for name := range in.funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Var 读取顶层变量（脚本执行结束后查看结果用；字符串不带引号，未定义时为 nil）
+func (in *Interpreter) Var(name string) starlark.Value {
+	return in.globals[name]
+}
+
+// Run 解析并执行脚本；语法错误时不执行任何语句，ctx 取消时尽快停止
+func (in *Interpreter) Run(ctx context.Context, src string) error {
+	thread := &starlark.Thread{
+		Name:  "console",
+		Print: func(_ *starlark.Thread, msg string) { fmt.Fprintln(in.out, msg) },
+	}
+	stop := context.AfterFunc(ctx, func() { thread.Cancel("脚本已取消") })
+	defer stop()
+
+	predeclared := make(starlark.StringDict, len(in.funcs))
+	for name, fn := range in.funcs {
+		predeclared[name] = starlark.NewBuiltin(name, func(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			v, err := fn(ctx, args, kwargs)
+			if v == nil && err == nil {
+				v = starlark.None
+			}
+			return v, err
+		})
+	}
+
+	globals, err := starlark.ExecFileOptions(fileOptions, thread, fileName, src, predeclared)
+	in.globals = globals
+	if ctx.Err() != nil {
+		return fmt.Errorf("脚本已取消")
+	}
+	return describe(err)
+}
+
+// describe 把解释器的错误转成带行号的说明，例如 第 3 行: undefined: foo
+func describe(err error) error {
+	var syntaxErr syntax.Error
+	var resolveErrs resolve.ErrorList
+	var evalErr *starlark.EvalError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("第 %d 行: %s", syntaxErr.Pos.Line, syntaxErr.Msg)
+	case errors.As(err, &resolveErrs) && len(resolveErrs) > 0:
+		first := resolveErrs[0]
+		return fmt.Errorf("第 %d 行: %s", first.Pos.Line, first.Msg)
+	case errors.As(err, &evalErr):
+		// 取最内层位于脚本中的调用位置（内置函数出错时为调用它的那一行）
+		for i := range evalErr.CallStack {
+			if pos := evalErr.CallStack.At(i).Pos; pos.Filename() == fileName {
+				return fmt.Errorf("第 %d 行: %w", pos.Line, err)
+			}
+		}
+	}
+	return err
+}
+
+// sleep 内置函数 sleep(秒数)，可被取消
+func sleep(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seconds starlark.Value
+	if err := starlark.UnpackPositionalArgs("sleep", args, kwargs, 1, &seconds); err != nil {
+		return nil, err
+	}
+	f, ok := starlark.AsFloat(seconds)
+	if !ok || f < 0 {
+		return nil, fmt.Errorf("sleep: 秒数无效: %s", seconds)
+	}
+	select {
+	case <-time.After(time.Duration(f * float64(time.Second))):
+		return starlark.None, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("已取消")
+	}
+}
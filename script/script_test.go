@@ -0,0 +1,125 @@
+package script
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.starlark.net/starlark"
+)
+
+// run 执行脚本，返回 print 输出
+func run(t *testing.T, src string, funcs Funcs) (string, *Interpreter, error) {
+	t.Helper()
+	var out strings.Builder
+	in := New(&out, funcs)
+	err := in.Run(context.Background(), src)
+	return out.String(), in, err
+}
+
+func TestControlFlow(t *testing.T) {
+	calls := 0
+	funcs := Funcs{
+		"deps_ok": func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			return starlark.False, nil
+		},
+		"install": func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			calls++
+			return nil, nil
+		},
+	}
+	src := `
+# 依赖缺失时安装
+if not deps_ok():
+    install()
+else:
+    print("不应执行")
+
+n = 0
+for i in range(3):
+    print("第", i + 1, "次")
+    n += 1
+name = "GVA \"Panel\""
+print(name, n)
+done = n == 3
+`
+	out, in, err := run(t, src, funcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("install 调用 %d 次, want 1", calls)
+	}
+	want := "第 1 次\n第 2 次\n第 3 次\nGVA \"Panel\" 3\n"
+	if out != want {
+		t.Errorf("输出 = %q, want %q", out, want)
+	}
+	if in.Var("done") != starlark.True {
+		t.Errorf("done = %v", in.Var("done"))
+	}
+}
+
+func TestWhile(t *testing.T) {
+	remaining := 3
+	funcs := Funcs{
+		"pending": func(ctx context.Context, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			remaining--
+			return starlark.Bool(remaining >= 0), nil
+		},
+	}
+	out, _, err := run(t, "while pending():\n  print(\"tick\")\n", funcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "tick\ntick\ntick\n" {
+		t.Errorf("输出 = %q", out)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cases := map[string]string{
+		"if True:\nprint(1)\n":                           "第 2 行: ",
+		"print(\"abc\n":                                  "第 1 行: ",
+		"print(1)\nprint(missing)\n":                     "第 2 行: undefined: missing",
+		"unknown_func()\n":                               "第 1 行: undefined: unknown_func",
+		"fail(\"停止执行\")\nprint(\"no\")\n":                "第 1 行: fail: 停止执行",
+		"sleep(-1)\n":                                    "第 1 行: sleep: 秒数无效: -1",
+		"def f():\n  return 1 / 0\nf()\nprint(\"no\")\n": "第 2 行: floating-point division by zero",
+	}
+	for src, want := range cases {
+		out, _, err := run(t, src, nil)
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("%q: err = %v, want %s", src, err, want)
+		}
+		if strings.Contains(out, "no") {
+			t.Errorf("%q: 出错后不应继续执行", src)
+		}
+	}
+}
+
+func TestSyntaxErrorRunsNothing(t *testing.T) {
+	out, _, err := run(t, "print(\"first\")\nif\n", nil)
+	if err == nil || out != "" {
+		t.Errorf("语法错误时不应执行任何语句: out = %q, err = %v", out, err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	for name, src := range map[string]string{
+		"sleep": "while True:\n  sleep(10)\n",
+		"busy":  "n = 0\nwhile True:\n  n += 1\n",
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		var out strings.Builder
+		start := time.Now()
+		err := New(&out, nil).Run(ctx, src)
+		cancel()
+		if err == nil || err.Error() != "脚本已取消" {
+			t.Errorf("%s: err = %v, want 脚本已取消", name, err)
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Errorf("%s: 取消后应立即停止", name)
+		}
+	}
+}
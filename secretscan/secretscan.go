@@ -0,0 +1,248 @@
+// Package secretscan 在打包发布产物或导出诊断信息之前查找明显的密钥：配置文件中密码、AK/SK、JWT 签名密钥等
+// 敏感配置项的值，以及任何文本中符合云厂商 AccessKey、私钥、JWT 令牌、地址中密码等格式的内容。
+// 发布前发现时需要用户确认才继续，导出诊断信息时直接打码
+package secretscan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gva-launcher/audit"
+)
+
+// maxFileSize 扫描的单个文件大小上限，更大的文件（通常是图片、字体之类）跳过
+const maxFileSize = 5 << 20
+
+// Finding 一处疑似密钥
+type Finding struct {
+	File  string // 文件（相对路径，扫描文本时为调用方给出的名称）
+	Line  int
+	Rule  string // 命中的规则，例如「阿里云 AccessKey ID」「敏感配置项」
+	Key   string // 敏感配置项的键名（格式规则命中时为空）
+	Value string // 打码后的值
+
+	start, end int // 值在原文中的位置（用于 Redact）
+}
+
+// String 一行的说明，例如 server/config.yaml:12 敏感配置项 password: se****et
+func (f Finding) String() string {
+	rule := f.Rule
+	if f.Key != "" {
+		rule += " " + f.Key
+	}
+	return fmt.Sprintf("%s:%d %s: %s", f.File, f.Line, rule, f.Value)
+}
+
+// pattern 按格式识别的密钥；表达式有分组时只有第一个分组是密钥（例如地址中的密码）
+type pattern struct {
+	rule string
+	re   *regexp.Regexp
+}
+
+var patterns = []pattern{
+	{"AWS AccessKey ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"阿里云 AccessKey ID", regexp.MustCompile(`\bLTAI[0-9A-Za-z]{12,24}\b`)},
+	{"腾讯云 SecretId", regexp.MustCompile(`\bAKID[0-9A-Za-z]{32}\b`)},
+	{"GitHub 令牌", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`)},
+	{"JWT 令牌", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"私钥", regexp.MustCompile(`(?s)-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----.*?-----END (?:[A-Z]+ )?PRIVATE KEY-----`)},
+	{"地址中的密码", regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s"'` + "`" + `]+:([^@\s"'` + "`" + `]+)@`)},
+	{"连接串中的密码", regexp.MustCompile(`[A-Za-z0-9_.-]+:([^@\s/"']+)@tcp\(`)},
+}
+
+// keyValueRe 配置文件中的一行键值：YAML 的 key: value、.env 的 KEY=value、JSON 的 "key": "value",
+var keyValueRe = regexp.MustCompile(`^\s*(?:export\s+)?["']?([A-Za-z0-9_.\-]+)["']?\s*[:=][ \t]*(.*)$`)
+
+// SensitiveKey 键名是否为敏感配置项：与审计日志相同的敏感词（password、token、secret、signing-key 等），
+// 以及单独作为一段的 ak / sk（例如 OSS_AK、oss.sk）。下划线按短横线处理
+func SensitiveKey(key string) bool {
+	normalized := strings.ReplaceAll(strings.ToLower(key), "_", "-")
+	if audit.Sensitive(normalized) {
+		return true
+	}
+	for _, part := range strings.FieldsFunc(normalized, func(r rune) bool { return r == '-' || r == '.' }) {
+		if part == "ak" || part == "sk" {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan 扫描一段文本，name 为结果中的文件名；keys 为 true 时按配置文件检查敏感配置项的值，
+// 为 false 时只按格式识别（例如压缩后的前端脚本，其中的 password 多是表单字段名）
+func Scan(name string, data []byte, keys bool) []Finding {
+	text := string(data)
+	var findings []Finding
+	add := func(rule, key string, start, end int) {
+		value := text[start:end]
+		if placeholder(value) {
+			return
+		}
+		findings = append(findings, Finding{
+			File: name, Line: strings.Count(text[:start], "\n") + 1,
+			Rule: rule, Key: key, Value: mask(value),
+			start: start, end: end,
+		})
+	}
+
+	for _, p := range patterns {
+		for _, m := range p.re.FindAllStringSubmatchIndex(text, -1) {
+			if len(m) >= 4 && m[2] >= 0 {
+				add(p.rule, "", m[2], m[3])
+			} else {
+				add(p.rule, "", m[0], m[1])
+			}
+		}
+	}
+
+	if keys {
+		offset := 0
+		for _, line := range strings.SplitAfter(text, "\n") {
+			if m := keyValueRe.FindStringSubmatchIndex(strings.TrimRight(line, "\r\n")); m != nil && SensitiveKey(line[m[2]:m[3]]) {
+				if start, end := valueSpan(line, m[4], m[5]); start < end {
+					add("敏感配置项", line[m[2]:m[3]], offset+start, offset+end)
+				}
+			}
+			offset += len(line)
+		}
+	}
+
+	// 同一处同时命中多条规则时只保留最先出现的一条
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].start < findings[j].start })
+	var unique []Finding
+	for _, f := range findings {
+		if n := len(unique); n > 0 && f.start < unique[n-1].end {
+			continue
+		}
+		unique = append(unique, f)
+	}
+	return unique
+}
+
+// valueSpan 去掉值两边的引号、末尾的逗号和行尾注释，返回值在行中的位置
+func valueSpan(line string, start, end int) (int, int) {
+	value := line[start:end]
+	trimmed := strings.TrimSpace(value)
+	start += strings.Index(value, trimmed)
+	end = start + len(trimmed)
+	value = strings.TrimSuffix(trimmed, ",")
+	end -= len(trimmed) - len(value)
+
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if i := strings.IndexByte(value[1:], value[0]); i >= 0 {
+			return start + 1, start + 1 + i
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return start, start + len(value)
+}
+
+// placeholder 判断值是否为空或示例值（your-secret-key、${PASSWORD}、<password>、******、xxx 等）
+func placeholder(value string) bool {
+	v := strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case v == "", v == "null", v == "~", v == "true", v == "false", v == "{}", v == "[]":
+		return true
+	case strings.HasPrefix(v, "${"), strings.HasPrefix(v, "<"), strings.HasPrefix(v, "{{"), strings.HasPrefix(v, "process.env"):
+		return true
+	case strings.Contains(v, "your"), strings.Contains(v, "example"), strings.Contains(v, "changeme"), strings.Contains(v, "xxx"):
+		return true
+	}
+	return strings.Trim(v, v[:1]) == "" // 同一个字符重复，例如 ******
+}
+
+// mask 打码：只保留首尾各两个字符（较短的值全部隐去）
+func mask(value string) string {
+	r := []rune(value)
+	if len(r) <= 8 {
+		return audit.Redacted
+	}
+	return string(r[:2]) + "****" + string(r[len(r)-2:])
+}
+
+// Redact 把 Scan 在 data 中找到的密钥替换为 audit.Redacted
+func Redact(data []byte, findings []Finding) []byte {
+	out := append([]byte{}, data...)
+	for i := len(findings) - 1; i >= 0; i-- {
+		f := findings[i]
+		if f.end > len(out) || f.start > f.end {
+			continue
+		}
+		out = append(out[:f.start], append([]byte(audit.Redacted), out[f.end:]...)...)
+	}
+	return out
+}
+
+// RedactText 扫描并打码一段文本（按配置文件检查），用于导出诊断信息
+func RedactText(text string) string {
+	return string(Redact([]byte(text), Scan("", []byte(text), true)))
+}
+
+// textExts 按文本扫描的文件扩展名
+var textExts = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".map": true, ".html": true, ".htm": true, ".css": true, ".json": true,
+	".txt": true, ".xml": true, ".svg": true, ".yaml": true, ".yml": true, ".toml": true, ".ini": true, ".conf": true,
+}
+
+// ScanDir 扫描目录中的文本文件（只按格式识别，见 Scan），结果中的文件名以目录名开头，例如 dist/assets/index.js
+func ScanDir(dir string) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !textExts[strings.ToLower(filepath.Ext(path))] {
+			return err
+		}
+		if info, err := d.Info(); err != nil || info.Size() > maxFileSize {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(filepath.Dir(dir), path)
+		findings = append(findings, Scan(filepath.ToSlash(rel), data, false)...)
+		return nil
+	})
+	return findings, err
+}
+
+// ScanConfigs 扫描目录第一层的配置文件（config.yaml、config.docker.yaml、.env.production 等，按配置文件检查），
+// 结果中的文件名以目录名开头，例如 server/config.yaml
+func ScanConfigs(dir string) ([]Finding, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, e := range entries {
+		name := e.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if e.IsDir() || !(strings.HasPrefix(name, ".env") || ext == ".yaml" || ext == ".yml" || ext == ".toml" || ext == ".ini" || ext == ".conf") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, Scan(filepath.Base(dir)+"/"+name, data, true)...)
+	}
+	return findings, nil
+}
+
+// Summary 把结果格式化为多行文字（最多 limit 条，其余只给出数量）
+func Summary(findings []Finding, limit int) string {
+	var lines []string
+	for i, f := range findings {
+		if i == limit {
+			lines = append(lines, fmt.Sprintf("……另外 %d 处", len(findings)-limit))
+			break
+		}
+		lines = append(lines, f.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"gva-launcher/apperr"
+)
+
+// ReservedPorts 自动分配端口时避开的常用服务端口（即使当前空闲，以后也可能被这些服务占用）
+var ReservedPorts = []int{
+	1433,  // SQL Server
+	3000,  // 常见的 Node 开发服务器
+	3306,  // MySQL
+	5173,  // Vite 默认端口
+	5432,  // PostgreSQL
+	6379,  // Redis
+	8848,  // Nacos
+	9000,  // MinIO
+	9200,  // Elasticsearch
+	27017, // MongoDB
+}
+
+// portInUse 检查端口是否被占用（测试中可替换）
+var portInUse = IsPortInUse
+
+// FindFreePorts 在 [start, end] 中按顺序找出 n 个空闲端口，跳过 ReservedPorts 和 avoid 中的端口
+func FindFreePorts(start, end, n int, avoid ...int) ([]int, error) {
+	skip := make(map[int]bool, len(ReservedPorts)+len(avoid))
+	for _, port := range ReservedPorts {
+		skip[port] = true
+	}
+	for _, port := range avoid {
+		skip[port] = true
+	}
+
+	var ports []int
+	for port := start; port <= end && len(ports) < n; port++ {
+		if skip[port] || portInUse(port) {
+			continue
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) < n {
+		return nil, apperr.Errorf(apperr.PortAllocFailed, "端口范围 %d-%d 中没有足够的空闲端口（需要 %d 个）", start, end, n)
+	}
+	return ports, nil
+}
+
+// NextFreePort 从 port 的下一个端口开始向后（最多 100 个）找一个空闲端口，用于配置的端口被占用时换端口
+func NextFreePort(port int, avoid ...int) (int, error) {
+	ports, err := FindFreePorts(port+1, min(port+100, 65535), 1, avoid...)
+	if err != nil {
+		return 0, err
+	}
+	return ports[0], nil
+}
+
+// Reserved 端口是否是自动分配时避开的常用服务端口
+func Reserved(port int) bool {
+	for _, p := range ReservedPorts {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
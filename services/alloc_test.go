@@ -0,0 +1,61 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"gva-launcher/apperr"
+)
+
+// stubPortInUse 把 busy 中的端口视为已占用
+func stubPortInUse(t *testing.T, busy ...int) {
+	t.Helper()
+	old := portInUse
+	portInUse = func(port int) bool {
+		for _, p := range busy {
+			if p == port {
+				return true
+			}
+		}
+		return false
+	}
+	t.Cleanup(func() { portInUse = old })
+}
+
+func TestFindFreePorts(t *testing.T) {
+	stubPortInUse(t, 3305, 3308)
+
+	// 跳过已占用、常用服务端口（3306）和 avoid 中的端口
+	got, err := FindFreePorts(3305, 3320, 3, 3307)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{3309, 3310, 3311}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindFreePortsExhausted(t *testing.T) {
+	stubPortInUse(t, 8000, 8001)
+
+	_, err := FindFreePorts(8000, 8002, 2)
+	if apperr.CodeOf(err) != apperr.PortAllocFailed {
+		t.Errorf("范围内空闲端口不足时应返回 PORT_ALLOC_FAILED, got %v", err)
+	}
+}
+
+func TestReserved(t *testing.T) {
+	if !Reserved(6379) || !Reserved(5173) || Reserved(8888) {
+		t.Error("Reserved 结果不正确")
+	}
+}
+
+func TestNextFreePort(t *testing.T) {
+	stubPortInUse(t, 8888, 8889)
+
+	// 跳过已占用和 avoid 中的端口
+	got, err := NextFreePort(8888, 8890)
+	if err != nil || got != 8891 {
+		t.Errorf("got %d, %v; want 8891", got, err)
+	}
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// WritePIDFile 把进程号写入 PID 文件（后台运行的服务由下次启动的面板按它重新连接）
+func WritePIDFile(path string, pid int) error {
+	return os.WriteFile(path, []byte(strconv.Itoa(pid)+"\n"), 0644)
+}
+
+// ReadPIDFile 读取 PID 文件中的进程号（文件不存在或内容无效时返回 0）
+func ReadPIDFile(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// IsPortInUse 检查端口是否被占用：先尝试监听所有网卡（IPv4/IPv6 双栈），
+// 再连接两个回环地址，覆盖只监听 127.0.0.1 或 [::1] 的服务（例如 Node 17+ 下 Vite 默认监听 ::1）
+func IsPortInUse(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return true
+	}
+	listener.Close()
+
+	for _, host := range []string{"127.0.0.1", "::1"} {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPortFree 端口已被占用时返回 PORT_IN_USE 错误
+func CheckPortFree(port int) error {
+	if IsPortInUse(port) {
+		return apperr.Errorf(apperr.PortInUse, "端口 %d 已被占用，请先关闭占用该端口的程序或换一个端口", port)
+	}
+	return nil
+}
+
+// KillProcess 结束进程（包括子进程）
+func KillProcess(pid int) {
+	if runtime.GOOS == "windows" {
+		// /T 参数会杀死整个进程树（包括子进程）
+		sysutil.Runner.Run("", "taskkill", "/F", "/T", "/PID", fmt.Sprintf("%d", pid))
+	} else {
+		sysutil.Runner.Run("", "kill", "-9", fmt.Sprintf("%d", pid))
+	}
+}
+
+// KillProcessByPort 通过端口号杀死占用该端口的进程，返回终止的进程数
+func KillProcessByPort(port int) int {
+	if runtime.GOOS == "windows" {
+		// 项目在 WSL 中时，Windows 侧监听端口的只是 WSL 的端口转发进程，需要在发行版中结束真正的服务进程
+		if sysutil.WSLDistro != "" {
+			if killed := killProcessByPortWSL(sysutil.WSLDistro, port); killed > 0 {
+				return killed
+			}
+		}
+		return killProcessByPortWindows(port)
+	}
+	return killProcessByPortUnix(port)
+}
+
+// killProcessByPortWindows 使用 netstat 查找占用端口的进程 PID，再用 taskkill 结束
+func killProcessByPortWindows(port int) int {
+	output, err := sysutil.Runner.Output("", "cmd", "/C", fmt.Sprintf("netstat -ano | findstr :%d", port))
+	if err != nil {
+		// netstat命令执行失败
+		return 0
+	}
+
+	killedCount := 0
+	for _, pid := range parseNetstatPIDs(string(output), port) {
+		// 找到PID，执行taskkill
+		if sysutil.Runner.Run("", "taskkill", "/F", "/T", "/PID", fmt.Sprintf("%d", pid)) == nil {
+			killedCount++
+		}
+	}
+	return killedCount
+}
+
+// killProcessByPortUnix Linux/Mac: 使用 lsof 查找监听端口的进程（IPv4 和 IPv6），再用 kill 结束
+func killProcessByPortUnix(port int) int {
+	output, err := sysutil.Runner.Output("", "lsof", "-ti", fmt.Sprintf(":%d", port), "-sTCP:LISTEN")
+	if err != nil {
+		// lsof命令执行失败（没有进程监听该端口时也返回非 0）
+		return 0
+	}
+
+	killedCount := 0
+	for _, pid := range parseLsofPIDs(string(output)) {
+		// 找到PID，执行kill
+		if sysutil.Runner.Run("", "kill", "-9", strconv.Itoa(pid)) == nil {
+			killedCount++
+		}
+	}
+	return killedCount
+}
+
+// killProcessByPortWSL 在 WSL 发行版中使用 lsof 查找监听端口的进程，再用 kill 结束
+func killProcessByPortWSL(distro string, port int) int {
+	output, err := sysutil.Runner.Output("", "wsl.exe", "-d", distro, "--exec", "lsof", "-ti", fmt.Sprintf(":%d", port), "-sTCP:LISTEN")
+	if err != nil {
+		return 0
+	}
+
+	killedCount := 0
+	for _, pid := range parseLsofPIDs(string(output)) {
+		if sysutil.Runner.Run("", "wsl.exe", "-d", distro, "--exec", "kill", "-9", strconv.Itoa(pid)) == nil {
+			killedCount++
+		}
+	}
+	return killedCount
+}
+
+// parseLsofPIDs 解析 lsof -t 输出（每行一个 PID），去除重复
+func parseLsofPIDs(output string) []int {
+	var pids []int
+	seen := map[int]bool{}
+	for _, line := range strings.Fields(output) {
+		pid, err := strconv.Atoi(line)
+		if err != nil || seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// parseNetstatPIDs 解析 netstat -ano 输出，返回本地地址为 port 且处于 LISTENING 状态的 PID（去除重复）。
+// 本地地址可能是 0.0.0.0:8080、127.0.0.1:8080 或 IPv6 的 [::]:8080、[::1]:8080
+func parseNetstatPIDs(output string, port int) []int {
+	var pids []int
+	seen := map[int]bool{}
+	suffix := fmt.Sprintf(":%d", port)
+	for _, line := range strings.Split(output, "\n") {
+		// 跳过空行
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// 查找 LISTENING 状态的行
+		if !strings.Contains(line, "LISTENING") {
+			continue
+		}
+
+		// 提取 PID（最后一列）
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+
+		// findstr 也会匹配到远程地址或更长的端口号，只保留本地地址的端口完全相同的行
+		if !strings.HasSuffix(fields[1], suffix) {
+			continue
+		}
+
+		pid, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil || seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// PortProcess 监听端口的进程
+type PortProcess struct {
+	PID  int
+	Name string
+}
+
+// String 例如 node (PID 4321)，没有取到进程名时只显示 PID
+func (p PortProcess) String() string {
+	if p.Name == "" {
+		return fmt.Sprintf("PID %d", p.PID)
+	}
+	return fmt.Sprintf("%s (PID %d)", p.Name, p.PID)
+}
+
+// PortProcesses 查找监听端口的进程（PID 和进程名），用于在结束进程前告诉用户是谁占用了端口
+func PortProcesses(port int) []PortProcess {
+	if runtime.GOOS == "windows" {
+		// 与 KillProcessByPort 相同，项目在 WSL 中时优先查找发行版中的服务进程
+		if sysutil.WSLDistro != "" {
+			if procs := portProcessesWSL(sysutil.WSLDistro, port); len(procs) > 0 {
+				return procs
+			}
+		}
+		return portProcessesWindows(port)
+	}
+	return portProcessesUnix(port)
+}
+
+// portProcessesWindows 使用 netstat 查找 PID，再用 tasklist 查询进程名
+func portProcessesWindows(port int) []PortProcess {
+	output, err := sysutil.Runner.Output("", "cmd", "/C", fmt.Sprintf("netstat -ano | findstr :%d", port))
+	if err != nil {
+		return nil
+	}
+
+	var procs []PortProcess
+	for _, pid := range parseNetstatPIDs(string(output), port) {
+		proc := PortProcess{PID: pid}
+		if out, err := sysutil.Runner.Output("", "tasklist", "/FI", fmt.Sprintf("PID eq %d", pid), "/FO", "CSV", "/NH"); err == nil {
+			proc.Name = parseTasklistName(string(out))
+		}
+		procs = append(procs, proc)
+	}
+	return procs
+}
+
+// portProcessesUnix Linux/Mac: 使用 lsof 的字段输出同时取得 PID 和进程名
+func portProcessesUnix(port int) []PortProcess {
+	output, err := sysutil.Runner.Output("", "lsof", "-i", fmt.Sprintf(":%d", port), "-sTCP:LISTEN", "-Fpc")
+	if err != nil {
+		return nil
+	}
+	return parseLsofProcesses(string(output))
+}
+
+// portProcessesWSL 在 WSL 发行版中使用 lsof 查找监听端口的进程
+func portProcessesWSL(distro string, port int) []PortProcess {
+	output, err := sysutil.Runner.Output("", "wsl.exe", "-d", distro, "--exec", "lsof", "-i", fmt.Sprintf(":%d", port), "-sTCP:LISTEN", "-Fpc")
+	if err != nil {
+		return nil
+	}
+	return parseLsofProcesses(string(output))
+}
+
+// parseLsofProcesses 解析 lsof -Fpc 输出：p 开头的行是 PID，随后 c 开头的行是进程名，其他字段忽略（去除重复）
+func parseLsofProcesses(output string) []PortProcess {
+	var procs []PortProcess
+	seen := map[int]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, err := strconv.Atoi(line[1:])
+			if err != nil || seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			procs = append(procs, PortProcess{PID: pid})
+		case 'c':
+			if n := len(procs); n > 0 && procs[n-1].Name == "" {
+				procs[n-1].Name = line[1:]
+			}
+		}
+	}
+	return procs
+}
+
+// parseTasklistName 解析 tasklist /FO CSV /NH 输出的第一列（映像名称），没有匹配的进程时返回空
+func parseTasklistName(output string) string {
+	line := strings.TrimSpace(output)
+	if !strings.HasPrefix(line, "\"") {
+		// 没有匹配时输出「信息: 没有运行的任务匹配指定标准。」
+		return ""
+	}
+	name, _, _ := strings.Cut(line[1:], "\"")
+	return name
+}
+
+// LocalIPs 获取本机所有局域网地址：先 IPv4（按网卡顺序），再 IPv6 全局地址。
+// 跳过回环、未启用的网卡、APIPA（169.254.x.x）和 IPv6 链路本地地址（fe80::，需要带网卡名才能访问）
+func LocalIPs() (ipv4, ipv6 []string) {
+	// 获取所有网络接口
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, nil
+	}
+
+	// 遍历所有网络接口
+	for _, iface := range interfaces {
+		// 跳过未启用或回环接口
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		// 获取接口的地址
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			if ipv4Addr := ipNet.IP.To4(); ipv4Addr != nil {
+				ipv4 = append(ipv4, ipv4Addr.String())
+			} else if ipNet.IP.IsGlobalUnicast() {
+				ipv6 = append(ipv6, ipNet.IP.String())
+			}
+		}
+	}
+	return ipv4, ipv6
+}
+
+// GetLocalIP 获取本机局域网IP地址（返回最后一个有效 IPv4，避开VPN；没有 IPv4 时返回第一个 IPv6）
+func GetLocalIP() string {
+	ipv4, ipv6 := LocalIPs()
+
+	// 返回最后一个有效IP（通常VPN和虚拟适配器在前面）
+	if len(ipv4) > 0 {
+		return ipv4[len(ipv4)-1]
+	}
+	if len(ipv6) > 0 {
+		return ipv6[0]
+	}
+
+	return "localhost"
+}
+
+// HostURL 拼接访问地址，IPv6 地址加上方括号，例如 http://[fd00::2]:8080
+func HostURL(scheme, host string, port int) string {
+	return scheme + "://" + net.JoinHostPort(strings.Trim(host, "[]"), strconv.Itoa(port))
+}
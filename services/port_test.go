@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+const sampleNetstat = `
+  TCP    0.0.0.0:8888           0.0.0.0:0              LISTENING       1234
+  TCP    [::]:8888              [::]:0                 LISTENING       1234
+  TCP    127.0.0.1:8888         127.0.0.1:50000        ESTABLISHED     5678
+  TCP    0.0.0.0:18888          0.0.0.0:0              LISTENING       abc
+  TCP    0.0.0.0:8888           LISTENING
+  TCP    [::1]:18888            [::]:0                 LISTENING       4321
+  TCP    127.0.0.1:50001        127.0.0.1:8888         ESTABLISHED     9999
+  TCP    [::1]:8888             [::]:0                 LISTENING       2468
+`
+
+func TestParseNetstatPIDs(t *testing.T) {
+	// IPv4 和 IPv6 行的同一 PID 只返回一次，端口号只匹配本地地址且完全相同
+	want := []int{1234, 2468}
+	if got := parseNetstatPIDs(sampleNetstat, 8888); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := parseNetstatPIDs(sampleNetstat, 18888); !reflect.DeepEqual(got, []int{4321}) {
+		t.Errorf("got %v, want [4321]", got)
+	}
+	if got := parseNetstatPIDs("", 8888); len(got) != 0 {
+		t.Errorf("空输出应返回空列表, got %v", got)
+	}
+}
+
+func TestKillProcessByPortWindows(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("cmd /C netstat -ano | findstr :8888", sampleNetstat, nil)
+	fake.Handle("taskkill /F /T /PID 1234", "", nil)
+	fake.Handle("taskkill /F /T /PID 2468", "", nil)
+
+	if got := killProcessByPortWindows(8888); got != 2 {
+		t.Errorf("killed = %d, want 2", got)
+	}
+
+	// netstat 没有匹配时 findstr 返回非 0
+	if got := killProcessByPortWindows(9999); got != 0 {
+		t.Errorf("killed = %d, want 0", got)
+	}
+}
+
+func TestKillProcessByPortUnix(t *testing.T) {
+	fake := sysutiltest.New(t)
+	// 同时监听 IPv4 和 IPv6 的两个进程
+	fake.Handle("lsof -ti :8888 -sTCP:LISTEN", "4321\n8765\n4321\n", nil)
+	fake.Handle("kill -9 4321", "", nil)
+	fake.Handle("kill -9 8765", "", nil)
+
+	if got := killProcessByPortUnix(8888); got != 2 {
+		t.Errorf("killed = %d, want 2", got)
+	}
+	if !fake.Called("kill -9 4321") || !fake.Called("kill -9 8765") {
+		t.Error("应分别结束 4321 和 8765")
+	}
+
+	fake.Handle("lsof -ti :8080 -sTCP:LISTEN", "", errors.New("exit status 1"))
+	if got := killProcessByPortUnix(8080); got != 0 {
+		t.Errorf("killed = %d, want 0", got)
+	}
+}
+
+func TestKillProcessByPortWSL(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("wsl.exe -d Ubuntu --exec lsof -ti :8080 -sTCP:LISTEN", "2345\n", nil)
+	fake.Handle("wsl.exe -d Ubuntu --exec kill -9 2345", "", nil)
+
+	if got := killProcessByPortWSL("Ubuntu", 8080); got != 1 {
+		t.Errorf("killed = %d, want 1", got)
+	}
+	if !fake.Called("wsl.exe -d Ubuntu --exec kill -9 2345") {
+		t.Error("应在发行版中结束 2345")
+	}
+}
+
+func TestIsPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Skip("无法监听端口:", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	if !IsPortInUse(port) {
+		t.Errorf("端口 %d 正在监听，应判定为占用", port)
+	}
+
+	listener.Close()
+	if IsPortInUse(port) {
+		t.Errorf("端口 %d 已释放，应判定为空闲", port)
+	}
+}
+
+func TestIsPortInUseLoopbackOnly(t *testing.T) {
+	// 只监听回环地址的服务（例如 Vite 只监听 [::1]）也应判定为占用
+	for _, addr := range []string{"127.0.0.1:0", "[::1]:0"} {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			continue // 系统未启用 IPv6
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		if !IsPortInUse(port) {
+			t.Errorf("%s 正在监听，应判定为占用", listener.Addr())
+		}
+		listener.Close()
+	}
+}
+
+func TestHostURL(t *testing.T) {
+	cases := map[string]string{
+		"192.168.1.5": "http://192.168.1.5:8080",
+		"gva.local":   "http://gva.local:8080",
+		"fd00::2":     "http://[fd00::2]:8080",
+		"[fd00::2]":   "http://[fd00::2]:8080",
+	}
+	for host, want := range cases {
+		if got := HostURL("http", host, 8080); got != want {
+			t.Errorf("%s: got %s, want %s", host, got, want)
+		}
+	}
+}
+
+func TestCheckPortFree(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Skip("无法监听本地端口")
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	if err := CheckPortFree(port); apperr.CodeOf(err) != apperr.PortInUse {
+		t.Errorf("被占用的端口应返回 PORT_IN_USE, got %v", err)
+	}
+}
+
+func TestPortProcessesUnix(t *testing.T) {
+	fake := sysutiltest.New(t)
+	// IPv4 和 IPv6 各一条记录，同一进程只保留一次
+	fake.Handle("lsof -i :8080 -sTCP:LISTEN -Fpc", "p4321\ncnode\nf23\np4321\ncnode\nf24\np8765\ncvite\nf12\n", nil)
+
+	want := []PortProcess{{PID: 4321, Name: "node"}, {PID: 8765, Name: "vite"}}
+	if got := portProcessesUnix(8080); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got := want[0].String(); got != "node (PID 4321)" {
+		t.Errorf("String = %q", got)
+	}
+	if got := (PortProcess{PID: 7}).String(); got != "PID 7" {
+		t.Errorf("String = %q", got)
+	}
+
+	fake.Handle("lsof -i :9999 -sTCP:LISTEN -Fpc", "", errors.New("exit status 1"))
+	if got := portProcessesUnix(9999); got != nil {
+		t.Errorf("got %+v, want nil", got)
+	}
+}
+
+func TestPortProcessesWindows(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("cmd /C netstat -ano | findstr :8888", sampleNetstat, nil)
+	fake.Handle("tasklist /FI PID eq 1234 /FO CSV /NH", "\"server.exe\",\"1234\",\"Console\",\"1\",\"45,000 K\"\r\n", nil)
+	fake.Handle("tasklist /FI PID eq 2468 /FO CSV /NH", "信息: 没有运行的任务匹配指定标准。\r\n", nil)
+
+	want := []PortProcess{{PID: 1234, Name: "server.exe"}, {PID: 2468}}
+	if got := portProcessesWindows(8888); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+// Package services 负责 GVA 前后端进程的启动、停止以及端口相关的系统操作
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// ServiceInfo 服务信息。服务进程的协程、端口刷新、自动重启和界面会同时读写，字段由 mu 保护，只通过方法访问
+type ServiceInfo struct {
+	mu        sync.Mutex
+	running   bool
+	port      int
+	startTime time.Time
+	process   *os.Process
+	priority  sysutil.Priority // 进程启动后设置的优先级（正常时为空）
+}
+
+// IsRunning 服务是否在运行
+func (s *ServiceInfo) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Port 最近一次标记启动时的端口
+func (s *ServiceInfo) Port() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.port
+}
+
+// StartTime 运行中服务的启动时间（未运行或不知道启动时间时为零值）
+func (s *ServiceInfo) StartTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return time.Time{}
+	}
+	return s.startTime
+}
+
+// Process 面板启动、重新连接或接管的进程（没有时为 nil）
+func (s *ServiceInfo) Process() *os.Process {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.process
+}
+
+// SetProcess 记录服务的进程
+func (s *ServiceInfo) SetProcess(p *os.Process) {
+	s.mu.Lock()
+	s.process = p
+	s.mu.Unlock()
+}
+
+// Priority 运行中服务进程的优先级（正常或未运行时为空）
+func (s *ServiceInfo) Priority() sysutil.Priority {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return ""
+	}
+	return s.priority
+}
+
+// SetPriority 设置下次启动的进程使用的优先级
+func (s *ServiceInfo) SetPriority(p sysutil.Priority) {
+	s.mu.Lock()
+	s.priority = p
+	s.mu.Unlock()
+}
+
+// MarkStarted 标记服务已启动
+func (s *ServiceInfo) MarkStarted(port int) {
+	s.mu.Lock()
+	s.running = true
+	s.port = port
+	s.startTime = time.Now()
+	s.mu.Unlock()
+}
+
+// SetRunning 按端口检测的结果更新运行状态，返回是否有变化。
+// 不是由面板启动的服务从发现端口监听时开始计时，停止后清空，下次启动重新计时
+func (s *ServiceInfo) SetRunning(running bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed := s.running != running
+	s.running = running
+	if !running {
+		s.startTime = time.Time{}
+	} else if s.startTime.IsZero() {
+		s.startTime = time.Now()
+	}
+	return changed
+}
+
+// Reset 清理服务状态
+func (s *ServiceInfo) Reset() {
+	s.mu.Lock()
+	s.running = false
+	s.process = nil
+	s.startTime = time.Time{}
+	s.mu.Unlock()
+}
+
+// stopped 进程已结束或启动失败（保留进程记录，由停止时的 Reset 清理）
+func (s *ServiceInfo) stopped() {
+	s.mu.Lock()
+	s.running = false
+	s.startTime = time.Time{}
+	s.mu.Unlock()
+}
+
+// Uptime 服务已运行的时长（未运行或不知道启动时间时为 0）
+func (s *ServiceInfo) Uptime(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running || s.startTime.IsZero() || now.Before(s.startTime) {
+		return 0
+	}
+	return now.Sub(s.startTime)
+}
+
+// FormatUptime 运行时长的简短写法，例如 45s、23m、1h 23m、2d 3h
+func FormatUptime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
+// Run 在 dir 目录中运行命令并阻塞到进程结束（代码式启动），返回启动失败或进程退出的错误
+func Run(info *ServiceInfo, dir string, name string, args ...string) error {
+	return RunOutput(info, dir, nil, name, args...)
+}
+
+// RunOutput 与 Run 相同，output 不为 nil 时进程的标准输出和标准错误写入 output
+func RunOutput(info *ServiceInfo, dir string, output io.Writer, name string, args ...string) error {
+	return RunOutputEnv(info, dir, nil, output, name, args...)
+}
+
+// RunOutputEnv 与 RunOutput 相同，output 不为 nil 时额外追加环境变量 env（KEY=VALUE 格式）
+func RunOutputEnv(info *ServiceInfo, dir string, env []string, output io.Writer, name string, args ...string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// 服务崩溃
+			info.stopped()
+			err = fmt.Errorf("服务崩溃: %v", r)
+		}
+	}()
+
+	// 工作目录只作用于本次启动的进程，不修改面板自身的当前目录（前后端并发启动时互不影响）
+	if !sysutil.DirExists(dir) {
+		info.stopped()
+		return apperr.Errorf(apperr.SvcDirNotFound, "服务目录不存在: %s", dir)
+	}
+
+	// 启动服务
+	var proc sysutil.Process
+	if output != nil {
+		proc, err = sysutil.Runner.StartOutputEnv(dir, env, output, name, args...)
+	} else {
+		proc, err = sysutil.Runner.Start(dir, name, args...)
+	}
+	return wait(info, proc, err, "")
+}
+
+// RunDetached 与 Run 相同，但进程脱离面板运行：输出追加到 logPath，进程号写入 pidPath，
+// 面板退出后服务继续运行；进程结束时删除 PID 文件
+func RunDetached(info *ServiceInfo, dir string, logPath string, pidPath string, name string, args ...string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			info.stopped()
+			err = fmt.Errorf("服务崩溃: %v", r)
+		}
+	}()
+
+	if !sysutil.DirExists(dir) {
+		info.stopped()
+		return apperr.Errorf(apperr.SvcDirNotFound, "服务目录不存在: %s", dir)
+	}
+	proc, err := sysutil.Runner.StartDetached(dir, logPath, name, args...)
+	return wait(info, proc, err, pidPath)
+}
+
+// wait 记录启动的进程并阻塞到进程结束；pidPath 不为空时在进程运行期间保留 PID 文件
+func wait(info *ServiceInfo, proc sysutil.Process, err error, pidPath string) error {
+	if err != nil {
+		// 启动失败
+		info.stopped()
+		return apperr.Errorf(apperr.SvcStartFailed, "启动失败: %v", err)
+	}
+
+	// 启动成功
+	p := proc.OSProcess()
+	info.mu.Lock()
+	info.process = p
+	priority := info.priority
+	info.mu.Unlock()
+	// 尽早调整优先级，之后创建的子进程（go run 编译出的程序、npm 启动的 node）一起继承
+	sysutil.SetPriority(p, priority)
+	if pidPath != "" && p != nil {
+		WritePIDFile(pidPath, p.Pid)
+		defer os.Remove(pidPath)
+	}
+
+	// 等待进程结束
+	err = proc.Wait()
+	// 服务已停止
+	info.stopped()
+	return err
+}
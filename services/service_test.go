@@ -0,0 +1,134 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestRunUsesCommandDir(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go run main.go", "", nil)
+
+	wd, _ := os.Getwd()
+	dir := t.TempDir()
+	var info ServiceInfo
+	if err := Run(&info, dir, "go", "run", "main.go"); err != nil {
+		t.Fatalf("Run 失败: %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 1 || calls[0].Dir != dir {
+		t.Errorf("命令应在服务目录中执行, calls = %+v", calls)
+	}
+	if now, _ := os.Getwd(); now != wd {
+		t.Errorf("不应修改当前目录: %s -> %s", wd, now)
+	}
+}
+
+func TestRunOutputCapturesOutput(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("npm run serve", "VITE ready\n", nil)
+
+	var info ServiceInfo
+	var out strings.Builder
+	if err := RunOutput(&info, t.TempDir(), &out, "npm", "run", "serve"); err != nil {
+		t.Fatalf("RunOutput 失败: %v", err)
+	}
+	if out.String() != "VITE ready\n" {
+		t.Errorf("输出应写入 output, got %q", out.String())
+	}
+}
+
+func TestRunMissingDir(t *testing.T) {
+	fake := sysutiltest.New(t)
+
+	var info ServiceInfo
+	if err := Run(&info, "/path/does/not/exist", "go", "run", "main.go"); err == nil {
+		t.Error("目录不存在时应返回错误")
+	}
+	if len(fake.Calls()) != 0 {
+		t.Error("目录不存在时不应启动进程")
+	}
+}
+
+func TestRunDetachedWritesLog(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle("go run main.go", "[GIN-debug] Listening and serving HTTP on :8888\n", nil)
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "backend-detached.log")
+	pidPath := filepath.Join(dir, "backend.pid")
+	os.WriteFile(logPath, []byte("上次的输出\n"), 0644)
+
+	var info ServiceInfo
+	if err := RunDetached(&info, dir, logPath, pidPath, "go", "run", "main.go"); err != nil {
+		t.Fatalf("RunDetached 失败: %v", err)
+	}
+	data, _ := os.ReadFile(logPath)
+	if string(data) != "上次的输出\n[GIN-debug] Listening and serving HTTP on :8888\n" {
+		t.Errorf("输出应追加到日志文件, got %q", data)
+	}
+	if _, err := os.Stat(pidPath); !os.IsNotExist(err) {
+		t.Error("进程结束后不应保留 PID 文件")
+	}
+}
+
+func TestPIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backend.pid")
+	if ReadPIDFile(path) != 0 {
+		t.Error("文件不存在时应返回 0")
+	}
+	if err := WritePIDFile(path, 4321); err != nil {
+		t.Fatal(err)
+	}
+	if got := ReadPIDFile(path); got != 4321 {
+		t.Errorf("pid = %d", got)
+	}
+	os.WriteFile(path, []byte("abc"), 0644)
+	if ReadPIDFile(path) != 0 {
+		t.Error("内容无效时应返回 0")
+	}
+}
+
+func TestUptime(t *testing.T) {
+	var info ServiceInfo
+	now := time.Now()
+	if info.Uptime(now) != 0 {
+		t.Error("未运行时为 0")
+	}
+	info.MarkStarted(8888)
+	if got := info.Uptime(info.StartTime().Add(83 * time.Minute)); got != 83*time.Minute {
+		t.Errorf("uptime = %v", got)
+	}
+	info.Reset()
+	if !info.StartTime().IsZero() || info.Uptime(now) != 0 {
+		t.Error("停止后应清空启动时间")
+	}
+
+	// 进程退出后同样清空，下次启动重新计时
+	sysutiltest.New(t).Handle("go run main.go", "", nil)
+	info.MarkStarted(8888)
+	Run(&info, t.TempDir(), "go", "run", "main.go")
+	if info.IsRunning() || !info.StartTime().IsZero() {
+		t.Errorf("进程结束后 running = %v, start = %v", info.IsRunning(), info.StartTime())
+	}
+}
+
+func TestFormatUptime(t *testing.T) {
+	cases := map[time.Duration]string{
+		45 * time.Second:                "45s",
+		23*time.Minute + 10*time.Second: "23m",
+		83 * time.Minute:                "1h 23m",
+		51 * time.Hour:                  "2d 3h",
+	}
+	for d, want := range cases {
+		if got := FormatUptime(d); got != want {
+			t.Errorf("%v: got %q, want %q", d, got, want)
+		}
+	}
+}
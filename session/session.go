@@ -0,0 +1,152 @@
+// Package session 检测面板所在的图形会话：Windows / macOS 桌面、Linux 的 X11、Wayland（通过 XWayland 显示）
+// 和 SSH 转发的 X11；没有可用的图形会话时说明原因，入口据此退回命令行（看守）模式
+package session
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Kind 图形会话类型
+type Kind string
+
+const (
+	Desktop Kind = "desktop" // Windows / macOS 本机桌面
+	X11     Kind = "x11"
+	Wayland Kind = "wayland" // Wayland 会话，面板窗口通过 XWayland 显示
+	SSHX11  Kind = "ssh-x11" // SSH 转发的 X11（ssh -X / -Y）
+	None    Kind = "none"    // 没有可用的图形会话
+)
+
+// Info 图形会话信息
+type Info struct {
+	Kind           Kind
+	OS             string
+	Display        string // DISPLAY
+	WaylandDisplay string // WAYLAND_DISPLAY
+	SSH            bool   // 是否通过 SSH 登录
+}
+
+// Detect 检测当前进程的图形会话
+func Detect() Info {
+	return detect(runtime.GOOS, os.Getenv)
+}
+
+// detect Detect 的实现（系统和环境变量由参数提供，便于测试）
+func detect(goos string, getenv func(string) string) Info {
+	info := Info{
+		OS:             goos,
+		Display:        getenv("DISPLAY"),
+		WaylandDisplay: getenv("WAYLAND_DISPLAY"),
+		SSH:            getenv("SSH_CONNECTION") != "" || getenv("SSH_CLIENT") != "" || getenv("SSH_TTY") != "",
+	}
+	wayland := info.WaylandDisplay != "" || getenv("XDG_SESSION_TYPE") == "wayland"
+
+	switch goos {
+	case "windows", "darwin":
+		// 通过 SSH 登录的 Windows / macOS 会话无法访问桌面，窗口不会显示
+		if info.SSH {
+			info.Kind = None
+		} else {
+			info.Kind = Desktop
+		}
+	default:
+		switch {
+		case info.Display == "":
+			info.Kind = None
+		case info.SSH && remoteDisplay(info.Display):
+			info.Kind = SSHX11
+		case wayland:
+			info.Kind = Wayland
+		default:
+			info.Kind = X11
+		}
+	}
+	return info
+}
+
+// remoteDisplay DISPLAY 是否指向网络上的 X 服务器（例如 ssh -X 设置的 localhost:10.0），本机的形式为 :0
+func remoteDisplay(display string) bool {
+	host, _, ok := strings.Cut(display, ":")
+	return ok && host != "" && !strings.HasPrefix(host, "/")
+}
+
+// Available 是否可以显示面板窗口
+func (i Info) Available() bool {
+	return i.Kind != None
+}
+
+// Explain 会话的说明：不可用时说明原因和解决办法，SSH 转发和 Wayland 下说明需要注意的地方
+func (i Info) Explain() string {
+	switch i.Kind {
+	case Desktop:
+		return "图形会话: 本机桌面"
+	case X11:
+		return "图形会话: X11（DISPLAY=" + i.Display + "）"
+	case Wayland:
+		return "图形会话: Wayland（WAYLAND_DISPLAY=" + i.WaylandDisplay + "），面板窗口通过 XWayland 显示，" +
+			"窗口尺寸按缩放后的逻辑分辨率计算；界面模糊时可在系统设置中关闭 XWayland 应用的缩放"
+	case SSHX11:
+		return "图形会话: SSH 转发的 X11（DISPLAY=" + i.Display + "），窗口绘制和文件对话框会比较慢，" +
+			"窗口尺寸按本地显示器计算；服务的访问地址是远程主机的地址"
+	}
+
+	switch {
+	case i.OS == "windows" || i.OS == "darwin":
+		return "当前是通过 SSH 登录的会话，无法访问桌面显示面板窗口。请在本机桌面上打开面板，或使用命令行（看守）模式"
+	case i.WaylandDisplay != "":
+		return "检测到 Wayland 会话（WAYLAND_DISPLAY=" + i.WaylandDisplay + "），但 DISPLAY 未设置：面板窗口需要通过 XWayland 显示，" +
+			"请确认桌面环境启用了 XWayland（GNOME / KDE 默认启用，sway 需要 xwayland enable）"
+	case i.SSH:
+		return "当前是没有转发 X11 的 SSH 会话（DISPLAY 未设置）。需要窗口时请使用 ssh -X 或 ssh -Y 登录（服务器需开启 X11Forwarding），" +
+			"否则请使用命令行（看守）模式"
+	default:
+		return "没有检测到图形会话（DISPLAY 和 WAYLAND_DISPLAY 都未设置），例如在纯终端、容器或 systemd 服务中运行。" +
+			"请在桌面环境中打开面板，或使用命令行（看守）模式"
+	}
+}
+
+// ParseWlrRandr 解析 wlr-randr 的输出（sway、Hyprland 等 wlroots 合成器），
+// 返回第一个启用的输出按缩放换算后的逻辑分辨率
+func ParseWlrRandr(output string) (width, height float32, ok bool) {
+	var px [2]int
+	scale := 1.0
+	enabled, found := true, false
+	flush := func() bool {
+		if found && enabled && scale > 0 {
+			width, height, ok = float32(float64(px[0])/scale), float32(float64(px[1])/scale), true
+			return true
+		}
+		return false
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		// 没有缩进的行是新的输出（显示器）
+		if line != "" && line[0] != ' ' && line[0] != '\t' {
+			if flush() {
+				return
+			}
+			px, scale, enabled, found = [2]int{}, 1.0, true, false
+			continue
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Enabled:"):
+			enabled = strings.TrimSpace(strings.TrimPrefix(line, "Enabled:")) == "yes"
+		case strings.HasPrefix(line, "Scale:"):
+			if s, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(line, "Scale:")), 64); err == nil {
+				scale = s
+			}
+		case strings.Contains(line, " px") && strings.Contains(line, "current"):
+			// 格式示例：1920x1080 px, 60.000000 Hz (preferred, current)
+			if n, _ := fmt.Sscanf(line, "%dx%d px", &px[0], &px[1]); n == 2 {
+				found = true
+			}
+		}
+	}
+	flush()
+	return
+}
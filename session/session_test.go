@@ -0,0 +1,73 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name string
+		goos string
+		vars map[string]string
+		want Kind
+	}{
+		{"windows desktop", "windows", nil, Desktop},
+		{"windows over ssh", "windows", map[string]string{"SSH_CONNECTION": "10.0.0.2 50000 10.0.0.1 22"}, None},
+		{"macOS desktop", "darwin", map[string]string{"TERM_PROGRAM": "Apple_Terminal"}, Desktop},
+		{"x11", "linux", map[string]string{"DISPLAY": ":0", "XDG_SESSION_TYPE": "x11"}, X11},
+		{"wayland with xwayland", "linux", map[string]string{"DISPLAY": ":0", "WAYLAND_DISPLAY": "wayland-0"}, Wayland},
+		{"wayland without xwayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-1"}, None},
+		{"ssh -X", "linux", map[string]string{"DISPLAY": "localhost:10.0", "SSH_CLIENT": "10.0.0.2 50000 22"}, SSHX11},
+		{"ssh using local display", "linux", map[string]string{"DISPLAY": ":0", "SSH_TTY": "/dev/pts/1"}, X11},
+		{"ssh without forwarding", "linux", map[string]string{"SSH_TTY": "/dev/pts/1"}, None},
+		{"container", "linux", nil, None},
+	}
+	for _, c := range cases {
+		info := detect(c.goos, env(c.vars))
+		if info.Kind != c.want {
+			t.Errorf("%s: Kind = %s, want %s", c.name, info.Kind, c.want)
+		}
+		if info.Available() != (c.want != None) || info.Explain() == "" {
+			t.Errorf("%s: Available = %v, Explain = %q", c.name, info.Available(), info.Explain())
+		}
+	}
+}
+
+func TestExplainUnavailable(t *testing.T) {
+	info := detect("linux", env(map[string]string{"SSH_CONNECTION": "x"}))
+	if !strings.Contains(info.Explain(), "ssh -X") {
+		t.Errorf("Explain = %s", info.Explain())
+	}
+	info = detect("linux", env(map[string]string{"WAYLAND_DISPLAY": "wayland-0"}))
+	if !strings.Contains(info.Explain(), "XWayland") {
+		t.Errorf("Explain = %s", info.Explain())
+	}
+}
+
+func TestParseWlrRandr(t *testing.T) {
+	output := `HDMI-A-1 "Dell Inc. DELL U2720Q (HDMI-A-1)"
+  Enabled: no
+  Modes:
+    3840x2160 px, 60.000000 Hz (preferred)
+eDP-1 "Sharp Corporation 0x14D0 (eDP-1)"
+  Enabled: yes
+  Modes:
+    2560x1600 px, 60.000000 Hz (preferred, current)
+    1920x1200 px, 59.950001 Hz
+  Position: 0,0
+  Transform: normal
+  Scale: 1.600000
+`
+	w, h, ok := ParseWlrRandr(output)
+	if !ok || w != 1600 || h != 1000 {
+		t.Errorf("ParseWlrRandr = %v x %v, %v", w, h, ok)
+	}
+	if _, _, ok := ParseWlrRandr("wlr-randr: compositor doesn't support wlr-output-management"); ok {
+		t.Error("expected failure for unsupported compositor")
+	}
+}
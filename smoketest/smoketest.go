@@ -0,0 +1,270 @@
+// Package smoketest 服务启动后的冒烟测试：登录接口、验证码接口、前端首页和前端 WebSocket（Vite 热更新），
+// 以及用户配置的地址。每项检查在等待时长内反复尝试直到通过，比只检测端口是否打开更能说明服务真正可用
+package smoketest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gva-launcher/config"
+)
+
+// 内置检查
+const (
+	CheckLogin     = "login"
+	CheckCaptcha   = "captcha"
+	CheckIndex     = "index"
+	CheckWebSocket = "websocket"
+)
+
+// Builtin 内置检查及其显示名称（按执行结果的显示顺序）
+var Builtin = []struct {
+	ID   string
+	Name string
+}{
+	{CheckLogin, "登录接口"},
+	{CheckCaptcha, "验证码接口"},
+	{CheckIndex, "前端首页"},
+	{CheckWebSocket, "前端 WebSocket"},
+}
+
+// 每次尝试的超时和两次尝试之间的间隔
+const (
+	attemptTimeout = 5 * time.Second
+	retryInterval  = time.Second
+)
+
+// Targets 被测试的地址
+type Targets struct {
+	BackendURL  string // 后端 API 根地址（含 router-prefix），例如 http://127.0.0.1:8888
+	FrontendURL string // 前端地址，例如 http://127.0.0.1:8080
+}
+
+// Check 一项检查
+type Check struct {
+	ID   string
+	Name string
+	run  func(ctx context.Context, client *http.Client) error
+}
+
+// Result 一项检查的结果
+type Result struct {
+	ID      string
+	Name    string
+	OK      bool
+	Detail  string        // 失败原因（最后一次尝试的错误）或通过时的说明
+	Elapsed time.Duration // 从开始到通过（或放弃）的时间
+}
+
+// Checks 按配置生成检查列表：未跳过的内置检查加上自定义检查
+func Checks(t Targets, cfg config.SmokeTest) []Check {
+	backend := strings.TrimRight(t.BackendURL, "/")
+	frontend := strings.TrimRight(t.FrontendURL, "/")
+
+	var checks []Check
+	for _, b := range Builtin {
+		if slices.Contains(cfg.Skip, b.ID) {
+			continue
+		}
+		c := Check{ID: b.ID, Name: b.Name}
+		switch b.ID {
+		case CheckLogin:
+			// 空的用户名密码：GVA 返回 HTTP 200 和业务错误码，说明路由、数据库和中间件都已就绪
+			c.run = func(ctx context.Context, client *http.Client) error {
+				_, err := postJSON(ctx, client, backend+"/base/login", `{"username":"","password":"","captcha":"","captchaId":""}`)
+				return err
+			}
+		case CheckCaptcha:
+			c.run = func(ctx context.Context, client *http.Client) error {
+				body, err := postJSON(ctx, client, backend+"/base/captcha", `{}`)
+				if err != nil {
+					return err
+				}
+				var resp struct {
+					Code int    `json:"code"`
+					Msg  string `json:"msg"`
+				}
+				if err := json.Unmarshal(body, &resp); err != nil {
+					return fmt.Errorf("返回的不是 JSON: %v", err)
+				}
+				if resp.Code != 0 {
+					return fmt.Errorf("业务错误码 %d: %s", resp.Code, resp.Msg)
+				}
+				return nil
+			}
+		case CheckIndex:
+			c.run = func(ctx context.Context, client *http.Client) error {
+				body, err := get(ctx, client, frontend+"/", http.StatusOK)
+				if err != nil {
+					return err
+				}
+				if !bytes.Contains(bytes.ToLower(body), []byte("<html")) {
+					return fmt.Errorf("返回的内容不是 HTML 页面")
+				}
+				return nil
+			}
+		case CheckWebSocket:
+			c.run = func(ctx context.Context, client *http.Client) error {
+				return websocketHandshake(ctx, client, frontend+"/")
+			}
+		}
+		checks = append(checks, c)
+	}
+
+	for _, custom := range cfg.Custom {
+		url := strings.NewReplacer("{backend}", backend, "{frontend}", frontend).Replace(custom.URL)
+		status := custom.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		name := custom.Name
+		if name == "" {
+			name = custom.URL
+		}
+		checks = append(checks, Check{ID: "custom", Name: name, run: func(ctx context.Context, client *http.Client) error {
+			_, err := get(ctx, client, url, status)
+			return err
+		}})
+	}
+	return checks
+}
+
+// Run 并行执行检查，每项在 wait 时长内反复尝试直到通过；结果顺序与 checks 一致
+func Run(ctx context.Context, checks []Check, wait time.Duration) []Result {
+	client := newClient()
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, client, c)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runCheck 反复尝试一项检查直到通过或 ctx 结束
+func runCheck(ctx context.Context, client *http.Client, c Check) Result {
+	start := time.Now()
+	for {
+		attempt, cancel := context.WithTimeout(ctx, attemptTimeout)
+		err := c.run(attempt, client)
+		cancel()
+		if err == nil {
+			return Result{ID: c.ID, Name: c.Name, OK: true, Elapsed: time.Since(start)}
+		}
+		select {
+		case <-ctx.Done():
+			return Result{ID: c.ID, Name: c.Name, Detail: err.Error(), Elapsed: time.Since(start)}
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// Passed 是否全部通过
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if !r.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary 一行结果摘要，例如 ✅ 登录接口 ✅ 验证码接口 ❌ 前端首页
+func Summary(results []Result) string {
+	parts := make([]string, len(results))
+	for i, r := range results {
+		mark := "❌"
+		if r.OK {
+			mark = "✅"
+		}
+		parts[i] = mark + " " + r.Name
+	}
+	return strings.Join(parts, "  ")
+}
+
+// newClient 测试本机服务使用的 HTTP 客户端：不走代理；前端开启 HTTPS 时使用的是本地自签证书，不校验证书
+func newClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		Proxy:           nil,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+}
+
+// postJSON 发送 JSON 请求，要求返回 200，返回响应内容
+func postJSON(ctx context.Context, client *http.Client, url, body string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(client, req, http.StatusOK)
+}
+
+// get 发送 GET 请求，要求返回 status，返回响应内容
+func get(ctx context.Context, client *http.Client, url string, status int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return do(client, req, status)
+}
+
+// do 执行请求并检查状态码
+func do(client *http.Client, req *http.Request, status int) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != status {
+		return nil, fmt.Errorf("%s %s 返回 HTTP %d（期望 %d）", req.Method, req.URL.Path, resp.StatusCode, status)
+	}
+	return body, nil
+}
+
+// websocketHandshake 以 Vite 热更新的子协议发起 WebSocket 握手，要求返回 101
+func websocketHandshake(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	key := make([]byte, 16)
+	rand.Read(key)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", base64.StdEncoding.EncodeToString(key))
+	req.Header.Set("Sec-WebSocket-Protocol", "vite-hmr")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("WebSocket 握手返回 HTTP %d（期望 101）", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package smoketest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gva-launcher/config"
+)
+
+// newServers 模拟 GVA 后端和前端开发服务器；后端前两次请求返回 502（模拟仍在启动）
+func newServers(t *testing.T) Targets {
+	var calls atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/base/login":
+			fmt.Fprint(w, `{"code":7,"msg":"用户名不能为空"}`)
+		case "/api/base/captcha":
+			fmt.Fprint(w, `{"code":0,"data":{"captchaId":"x"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	frontend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") == "websocket" {
+			conn, buf, _ := w.(http.Hijacker).Hijack()
+			buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+			buf.Flush()
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `<!DOCTYPE html><html><body><div id="app"></div></body></html>`)
+	}))
+	t.Cleanup(backend.Close)
+	t.Cleanup(frontend.Close)
+	return Targets{BackendURL: backend.URL + "/api", FrontendURL: frontend.URL}
+}
+
+func TestRunAllPass(t *testing.T) {
+	targets := newServers(t)
+	results := Run(context.Background(), Checks(targets, config.SmokeTest{}), 10*time.Second)
+	if len(results) != 4 || !Passed(results) {
+		t.Fatalf("results = %+v", results)
+	}
+	if s := Summary(results); s != "✅ 登录接口  ✅ 验证码接口  ✅ 前端首页  ✅ 前端 WebSocket" {
+		t.Errorf("Summary = %s", s)
+	}
+}
+
+func TestRunSkipAndCustom(t *testing.T) {
+	targets := newServers(t)
+	cfg := config.SmokeTest{
+		Skip:   []string{CheckLogin, CheckCaptcha, CheckWebSocket},
+		Custom: []config.SmokeCheck{{Name: "健康检查", URL: "{backend}/health"}, {URL: "{frontend}/", Status: 200}},
+	}
+	checks := Checks(targets, cfg)
+	if len(checks) != 3 || checks[1].Name != "健康检查" || checks[2].Name != "{frontend}/" {
+		t.Fatalf("checks = %+v", checks)
+	}
+	results := Run(context.Background(), checks, 4*time.Second)
+	if !results[0].OK || results[1].OK || !results[2].OK {
+		t.Fatalf("results = %+v", results)
+	}
+	if !strings.Contains(results[1].Detail, "404") || Passed(results) {
+		t.Errorf("失败原因应包含状态码: %+v", results[1])
+	}
+}
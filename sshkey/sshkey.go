@@ -0,0 +1,328 @@
+// Package sshkey 管理面板使用的 SSH 密钥（ed25519，每个密钥一个子目录）：
+// 生成密钥对、导出 authorized_keys 格式的公钥；私钥用口令加密保存（PBKDF2-SHA256 + AES-256-GCM），
+// 使用时解密为临时文件交给系统的 ssh 命令，ssh 用完后立即删除
+package sshkey
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// 密钥子目录中的文件名
+const (
+	PublicFile  = "id_ed25519.pub"
+	PrivateFile = "id_ed25519.enc"
+)
+
+// TempPattern 临时私钥目录的名称（os.MkdirTemp 的 pattern，见 TempIdentity）
+const TempPattern = "gvapanel-sshkey-*"
+
+// MinPassphrase 口令的最短长度
+const MinPassphrase = 8
+
+// iterations PBKDF2 迭代次数（测试中可调小）
+var iterations = 600000
+
+// namePattern 密钥名称（作为子目录名）
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Key 密钥信息（不含私钥）
+type Key struct {
+	Name        string    // 名称（子目录名）
+	PublicKey   string    // authorized_keys 格式的公钥（ssh-ed25519 AAAA... 注释）
+	Fingerprint string    // SHA256 指纹（与 ssh-keygen -l 的输出一致）
+	Created     time.Time // 生成时间
+}
+
+// encrypted 加密后的私钥文件内容
+type encrypted struct {
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"` // 加密的 OpenSSH 格式私钥
+}
+
+// Generate 生成 ed25519 密钥对并保存到 dir/name，私钥用 passphrase 加密
+func Generate(dir, name, comment, passphrase string) (*Key, error) {
+	if !namePattern.MatchString(name) {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "密钥名称 %q 不合法，只能包含字母、数字、点、下划线和连字符", name)
+	}
+	if len(passphrase) < MinPassphrase {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "口令至少需要 %d 个字符", MinPassphrase)
+	}
+	keyDir := filepath.Join(dir, name)
+	if _, err := os.Stat(keyDir); err == nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "密钥 %s 已存在", name)
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "生成密钥失败: %v", err)
+	}
+	sealed, err := seal(marshalPrivate(pub, priv, comment), passphrase)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "加密私钥失败: %v", err)
+	}
+
+	authorized := AuthorizedKey(pub, comment)
+	if err := os.MkdirAll(keyDir, 0700); err != nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "创建密钥目录失败: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(keyDir, PrivateFile), sealed, 0600); err != nil {
+		os.RemoveAll(keyDir)
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "保存私钥失败: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(keyDir, PublicFile), []byte(authorized+"\n"), 0644); err != nil {
+		os.RemoveAll(keyDir)
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "保存公钥失败: %v", err)
+	}
+	return &Key{Name: name, PublicKey: authorized, Fingerprint: Fingerprint(pub), Created: time.Now()}, nil
+}
+
+// List 列出 dir 中的密钥（按名称排序，读取失败的子目录跳过）
+func List(dir string) []Key {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var keys []Key
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name(), PublicFile)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		authorized := strings.TrimSpace(string(data))
+		fields := strings.Fields(authorized)
+		if len(fields) < 2 {
+			continue
+		}
+		wire, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			continue
+		}
+		key := Key{Name: entry.Name(), PublicKey: authorized, Fingerprint: fingerprintOf(wire)}
+		if info, err := os.Stat(path); err == nil {
+			key.Created = info.ModTime()
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys
+}
+
+// Remove 删除密钥
+func Remove(dir, name string) error {
+	if !namePattern.MatchString(name) {
+		return apperr.Errorf(apperr.SSHKeyFailed, "密钥名称 %q 不合法", name)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+		return apperr.Errorf(apperr.SSHKeyFailed, "删除密钥失败: %v", err)
+	}
+	return nil
+}
+
+// Unlock 用口令解密私钥，返回 OpenSSH 格式（PEM）的私钥
+func Unlock(dir, name, passphrase string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name, PrivateFile))
+	if err != nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "读取私钥失败: %v", err)
+	}
+	var e encrypted
+	if err := json.Unmarshal(data, &e); err != nil || e.KDF != "pbkdf2-sha256" {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "私钥文件格式错误")
+	}
+	gcm, err := newGCM(passphrase, e.Salt, e.Iterations)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "解密私钥失败: %v", err)
+	}
+	plain, err := gcm.Open(nil, e.Nonce, e.Data, nil)
+	if err != nil {
+		return nil, apperr.Errorf(apperr.SSHKeyFailed, "口令错误，无法解密私钥 %s", name)
+	}
+	return plain, nil
+}
+
+// WriteIdentity 把解密后的私钥写入 dir 中的临时文件（仅当前用户可读），供 ssh -i 使用。
+// Windows 上权限位不起作用：先创建空文件，用 icacls 限制为只有当前用户可以访问后再写入私钥
+func WriteIdentity(dir, name string, private []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", apperr.Errorf(apperr.SSHKeyFailed, "创建临时目录失败: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if runtime.GOOS == "windows" {
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			return "", apperr.Errorf(apperr.SSHKeyFailed, "创建临时私钥失败: %v", err)
+		}
+		current, err := user.Current()
+		if err == nil {
+			err = restrictToOwner(path, current.Username)
+		}
+		if err != nil {
+			os.Remove(path)
+			return "", apperr.Errorf(apperr.SSHKeyFailed, "限制临时私钥的访问权限失败: %v", err)
+		}
+	}
+	if err := os.WriteFile(path, private, 0600); err != nil {
+		return "", apperr.Errorf(apperr.SSHKeyFailed, "写入临时私钥失败: %v", err)
+	}
+	return path, nil
+}
+
+// TempIdentity 为一次 ssh 操作把私钥写入 base 中新建的临时目录（base 为空时使用系统临时目录），
+// 返回私钥文件路径和删除该目录的函数（可重复调用）。调用方在 ssh 完成认证或退出后立即调用 remove，
+// 解密后的私钥只在需要时留在磁盘上
+func TempIdentity(base, name string, private []byte) (string, func(), error) {
+	dir, err := os.MkdirTemp(base, TempPattern)
+	if err != nil {
+		return "", nil, apperr.Errorf(apperr.SSHKeyFailed, "创建临时目录失败: %v", err)
+	}
+	var once sync.Once
+	remove := func() { once.Do(func() { os.RemoveAll(dir) }) }
+	path, err := WriteIdentity(dir, name, private)
+	if err != nil {
+		remove()
+		return "", nil, err
+	}
+	return path, remove, nil
+}
+
+// RemoveTemp 删除 base 中所有的临时私钥目录（面板启动时清理异常退出遗留的，退出时清理仍未删除的），返回删除的数量
+func RemoveTemp(base string) int {
+	if base == "" {
+		base = os.TempDir()
+	}
+	dirs, _ := filepath.Glob(filepath.Join(base, TempPattern))
+	removed := 0
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err == nil {
+			removed++
+		}
+	}
+	return removed
+}
+
+// restrictToOwner 用 icacls 去掉文件继承的访问权限（Users、Everyone 等），只授予 username 完全控制
+// （Windows 的 OpenSSH 同样拒绝其他用户可以读取的私钥）
+func restrictToOwner(path, username string) error {
+	output, err := sysutil.Runner.CombinedOutput("", "icacls", path, "/inheritance:r", "/grant:r", username+":F")
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// AuthorizedKey authorized_keys 格式的公钥
+func AuthorizedKey(pub ed25519.PublicKey, comment string) string {
+	line := "ssh-ed25519 " + base64.StdEncoding.EncodeToString(wirePublic(pub))
+	if comment = strings.TrimSpace(comment); comment != "" {
+		line += " " + comment
+	}
+	return line
+}
+
+// Fingerprint 公钥的 SHA256 指纹
+func Fingerprint(pub ed25519.PublicKey) string {
+	return fingerprintOf(wirePublic(pub))
+}
+
+// fingerprintOf 按 SSH 公钥编码计算指纹
+func fingerprintOf(wire []byte) string {
+	sum := sha256.Sum256(wire)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// seal 用口令加密
+func seal(plain []byte, passphrase string) ([]byte, error) {
+	e := encrypted{KDF: "pbkdf2-sha256", Iterations: iterations, Salt: make([]byte, 16)}
+	if _, err := rand.Read(e.Salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, e.Salt, e.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	e.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+	e.Data = gcm.Seal(nil, e.Nonce, plain, nil)
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// newGCM 由口令派生 AES-256-GCM
+func newGCM(passphrase string, salt []byte, iter int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iter, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// wirePublic SSH 协议中的公钥编码
+func wirePublic(pub ed25519.PublicKey) []byte {
+	var b []byte
+	b = appendString(b, []byte("ssh-ed25519"))
+	return appendString(b, pub)
+}
+
+// marshalPrivate 编码为 OpenSSH 格式（openssh-key-v1，不加密）的私钥
+func marshalPrivate(pub ed25519.PublicKey, priv ed25519.PrivateKey, comment string) []byte {
+	var check [4]byte
+	rand.Read(check[:])
+
+	var section []byte
+	section = append(section, check[:]...)
+	section = append(section, check[:]...)
+	section = appendString(section, []byte("ssh-ed25519"))
+	section = appendString(section, pub)
+	section = appendString(section, priv)
+	section = appendString(section, []byte(comment))
+	for i := byte(1); len(section)%8 != 0; i++ {
+		section = append(section, i)
+	}
+
+	b := []byte("openssh-key-v1\x00")
+	b = appendString(b, []byte("none"))
+	b = appendString(b, []byte("none"))
+	b = appendString(b, nil)
+	b = binary.BigEndian.AppendUint32(b, 1)
+	b = appendString(b, wirePublic(pub))
+	b = appendString(b, section)
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: b})
+}
+
+// appendString 追加 SSH 协议的 string（4 字节长度 + 内容）
+func appendString(b, s []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
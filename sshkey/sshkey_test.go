@@ -0,0 +1,159 @@
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"encoding/pem"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func init() {
+	// 测试中不需要抵抗暴力破解
+	iterations = 1000
+}
+
+func TestGenerateAndUnlock(t *testing.T) {
+	dir := t.TempDir()
+	key, err := Generate(dir, "prod", "gvapanel@test", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key.PublicKey, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5") || !strings.HasSuffix(key.PublicKey, " gvapanel@test") {
+		t.Errorf("public key = %q", key.PublicKey)
+	}
+
+	keys := List(dir)
+	if len(keys) != 1 || keys[0].Name != "prod" || keys[0].Fingerprint != key.Fingerprint {
+		t.Fatalf("List = %+v", keys)
+	}
+
+	// 私钥不能以明文保存
+	sealed, err := os.ReadFile(filepath.Join(dir, "prod", PrivateFile))
+	if err != nil || strings.Contains(string(sealed), "OPENSSH PRIVATE KEY") {
+		t.Fatalf("sealed = %s, err = %v", sealed, err)
+	}
+
+	if _, err := Unlock(dir, "prod", "wrong passphrase"); apperr.CodeOf(err) != apperr.SSHKeyFailed {
+		t.Errorf("错误口令应报错, err = %v", err)
+	}
+	private, err := Unlock(dir, "prod", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(private)
+	if block == nil || block.Type != "OPENSSH PRIVATE KEY" || !strings.HasPrefix(string(block.Bytes), "openssh-key-v1\x00") {
+		t.Fatalf("private = %s", private)
+	}
+
+	if _, err := Generate(dir, "prod", "", "correct horse"); err == nil {
+		t.Error("同名密钥应报错")
+	}
+	if err := Remove(dir, "prod"); err != nil || len(List(dir)) != 0 {
+		t.Errorf("Remove: %v", err)
+	}
+}
+
+func TestGenerateRejectsInvalidInput(t *testing.T) {
+	for _, c := range []struct{ name, passphrase string }{
+		{"../evil", "correct horse"},
+		{"", "correct horse"},
+		{"prod", "short"},
+	} {
+		if _, err := Generate(t.TempDir(), c.name, "", c.passphrase); apperr.CodeOf(err) != apperr.SSHKeyFailed {
+			t.Errorf("%+v: err = %v", c, err)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	// RFC 8032 测试向量 1 的公钥
+	pub := ed25519.PublicKey{
+		0xd7, 0x5a, 0x98, 0x01, 0x82, 0xb1, 0x0a, 0xb7, 0xd5, 0x4b, 0xfe, 0xd3, 0xc9, 0x64, 0x07, 0x3a,
+		0x0e, 0xe1, 0x72, 0xf3, 0xda, 0xa6, 0x23, 0x25, 0xaf, 0x02, 0x1a, 0x68, 0xf7, 0x07, 0x51, 0x1a,
+	}
+	if got := AuthorizedKey(pub, ""); got != "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINdamAGCsQq31Uv+08lkBzoO4XLz2qYjJa8CGmj3B1Ea" {
+		t.Errorf("AuthorizedKey = %q", got)
+	}
+	if !strings.HasPrefix(Fingerprint(pub), "SHA256:") || len(Fingerprint(pub)) != 50 {
+		t.Errorf("Fingerprint = %q", Fingerprint(pub))
+	}
+}
+
+func TestPrivateKeyAcceptedBySSHKeygen(t *testing.T) {
+	keygen, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Skip("未安装 ssh-keygen")
+	}
+	dir := t.TempDir()
+	key, err := Generate(dir, "prod", "gvapanel@test", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := Unlock(dir, "prod", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := WriteIdentity(filepath.Join(dir, "tmp"), "prod", private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command(keygen, "-y", "-f", path).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(string(out)); len(got) < 2 || got[1] != strings.Fields(key.PublicKey)[1] {
+		t.Errorf("ssh-keygen -y = %q, want %q", out, key.PublicKey)
+	}
+}
+
+func TestTempIdentity(t *testing.T) {
+	base := t.TempDir()
+	stale := filepath.Join(base, "gvapanel-sshkey-123")
+	os.MkdirAll(stale, 0700)
+	os.WriteFile(filepath.Join(stale, "prod"), []byte("old"), 0600)
+	other := filepath.Join(base, "keep")
+	os.MkdirAll(other, 0700)
+
+	path, remove, err := TempIdentity(base, "prod", []byte("private"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "private" || filepath.Base(path) != "prod" {
+		t.Fatalf("identity = %q, err = %v", data, err)
+	}
+	remove()
+	remove()
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Error("remove 应删除临时目录")
+	}
+
+	if n := RemoveTemp(base); n != 1 {
+		t.Errorf("RemoveTemp = %d", n)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("应删除遗留的临时私钥目录")
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Error("不应删除其他目录")
+	}
+}
+
+func TestRestrictToOwner(t *testing.T) {
+	fake := sysutiltest.New(t)
+	fake.Handle(`icacls C:\tmp\prod /inheritance:r /grant:r DEV\alice:F`, "processed file: C:\\tmp\\prod", nil)
+	if err := restrictToOwner(`C:\tmp\prod`, `DEV\alice`); err != nil {
+		t.Fatal(err)
+	}
+	// 执行失败时带上 icacls 的输出
+	fake.Handle(`icacls C:\tmp\other /inheritance:r /grant:r DEV\alice:F`, "Access is denied.", errors.New("exit status 5"))
+	if err := restrictToOwner(`C:\tmp\other`, `DEV\alice`); err == nil || !strings.Contains(err.Error(), "Access is denied.") {
+		t.Errorf("err = %v", err)
+	}
+}
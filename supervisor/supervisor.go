@@ -0,0 +1,119 @@
+// Package supervisor 统一管理面板的后台协程：所有工作协程共享一个随应用关闭而取消的 context，
+// 关闭时等待它们退出，避免协程泄漏或在窗口关闭后继续操作界面
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Supervisor 后台协程管理器
+type Supervisor struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	nextID  int
+	running map[int]string // 正在运行的协程 ID -> 名称
+
+	// OnPanic 工作协程 panic 时调用（可为 nil，panic 被吞掉后协程正常结束）
+	OnPanic func(name string, recovered interface{})
+}
+
+// New 创建管理器
+func New() *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Supervisor{
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[int]string),
+	}
+}
+
+// Context 所有工作协程共享的 context（Cancel 后关闭）
+func (s *Supervisor) Context() context.Context {
+	return s.ctx
+}
+
+// Go 启动一个受管理的工作协程（fn 应在 ctx 取消后尽快返回）
+// 已经开始关闭时不再启动，返回 false
+func (s *Supervisor) Go(name string, fn func(ctx context.Context)) bool {
+	s.mu.Lock()
+	if s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return false
+	}
+	s.nextID++
+	id := s.nextID
+	s.running[id] = name
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil && s.OnPanic != nil {
+				s.OnPanic(name, r)
+			}
+			s.mu.Lock()
+			delete(s.running, id)
+			s.mu.Unlock()
+			s.wg.Done()
+		}()
+		fn(s.ctx)
+	}()
+	return true
+}
+
+// Running 正在运行的协程名称（排序后返回）
+func (s *Supervisor) Running() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.running))
+	for _, name := range s.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Cancel 通知所有工作协程退出（不等待）
+func (s *Supervisor) Cancel() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancel()
+}
+
+// Shutdown 取消所有工作协程并等待退出，超时返回仍未退出的协程
+func (s *Supervisor) Shutdown(timeout time.Duration) error {
+	s.Cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("以下后台任务未能及时退出: %v", s.Running())
+	}
+}
+
+// Sleep 等待 d 或 ctx 取消，ctx 取消时返回 false
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
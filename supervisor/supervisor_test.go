@@ -0,0 +1,75 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShutdownWaitsForWorkers(t *testing.T) {
+	s := New()
+	stopped := make(chan string, 2)
+
+	for _, name := range []string{"a", "b"} {
+		name := name
+		s.Go(name, func(ctx context.Context) {
+			<-ctx.Done()
+			stopped <- name
+		})
+	}
+	if got := s.Running(); len(got) != 2 {
+		t.Fatalf("Running = %v", got)
+	}
+
+	if err := s.Shutdown(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if len(stopped) != 2 || len(s.Running()) != 0 {
+		t.Errorf("所有协程都应退出, running = %v", s.Running())
+	}
+
+	if s.Go("late", func(ctx context.Context) {}) {
+		t.Error("关闭后不应再启动协程")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	s := New()
+	release := make(chan struct{})
+	defer close(release)
+	s.Go("stuck", func(ctx context.Context) { <-release })
+
+	if err := s.Shutdown(50 * time.Millisecond); err == nil {
+		t.Error("协程未退出时应返回错误")
+	}
+}
+
+func TestPanicIsRecovered(t *testing.T) {
+	s := New()
+	panicked := make(chan string, 1)
+	s.OnPanic = func(name string, r interface{}) { panicked <- name }
+
+	s.Go("boom", func(ctx context.Context) { panic("x") })
+	select {
+	case name := <-panicked:
+		if name != "boom" {
+			t.Errorf("name = %q", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("未调用 OnPanic")
+	}
+	if err := s.Shutdown(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if !Sleep(ctx, time.Millisecond) {
+		t.Error("未取消时应返回 true")
+	}
+	cancel()
+	if Sleep(ctx, time.Hour) {
+		t.Error("取消后应立即返回 false")
+	}
+}
@@ -0,0 +1,197 @@
+package sysservice
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// lookPath 查找命令（测试中可替换）
+var lookPath = exec.LookPath
+
+// NSSMPath Windows 上 NSSM 的路径（不是 Windows 或没有安装时为空）
+func NSSMPath() string {
+	if runtime.GOOS != "windows" {
+		return ""
+	}
+	path, err := lookPath("nssm")
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// Install 注册服务并立即启动（已存在同名服务时覆盖），系统会弹出管理员授权窗口
+func Install(s Spec) error {
+	if !Supported(runtime.GOOS) {
+		return apperr.Errorf(apperr.SysServiceFailed, "当前系统（%s）不支持注册系统服务", runtime.GOOS)
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if !sysutil.FileExists(s.Binary) {
+		return apperr.Errorf(apperr.SysServiceFailed, "后端可执行文件 %s 不存在，请先构建后端", s.Binary)
+	}
+	return runScript(runtime.GOOS, "注册", func(dir string) (string, error) {
+		unitFile := filepath.Join(dir, "unit")
+		if unit := Unit(runtime.GOOS, s); unit != "" {
+			if err := os.WriteFile(unitFile, []byte(unit), 0644); err != nil {
+				return "", err
+			}
+		}
+		return installScript(runtime.GOOS, s, unitFile, NSSMPath()), nil
+	})
+}
+
+// Uninstall 停止并删除服务（服务不存在时视为成功），系统会弹出管理员授权窗口
+func Uninstall(name string) error {
+	if !Supported(runtime.GOOS) {
+		return apperr.Errorf(apperr.SysServiceFailed, "当前系统（%s）不支持注册系统服务", runtime.GOOS)
+	}
+	if !namePattern.MatchString(name) {
+		return apperr.Errorf(apperr.SysServiceFailed, "服务名 %q 无效", name)
+	}
+	return runScript(runtime.GOOS, "卸载", func(string) (string, error) {
+		return uninstallScript(runtime.GOOS, name), nil
+	})
+}
+
+// Installed 是否已注册同名服务（Windows 上服务和计划任务都算）
+func Installed(name string) bool {
+	if runtime.GOOS != "windows" {
+		return sysutil.FileExists(UnitPath(runtime.GOOS, name))
+	}
+	if _, err := sysutil.Runner.CombinedOutput("", "sc.exe", "query", name); err == nil {
+		return true
+	}
+	_, err := sysutil.Runner.CombinedOutput("", "schtasks", "/Query", "/TN", TaskPath+name)
+	return err == nil
+}
+
+// runScript 在临时目录中生成脚本（write 返回脚本内容，可在目录中写入其他文件），再以管理员身份执行
+func runScript(goos, action string, write func(dir string) (string, error)) error {
+	dir, err := os.MkdirTemp("", "gvapanel-service-*")
+	if err != nil {
+		return apperr.Errorf(apperr.SysServiceFailed, "创建临时目录失败: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	script, err := write(dir)
+	if err != nil {
+		return apperr.Errorf(apperr.SysServiceFailed, "写入临时文件失败: %v", err)
+	}
+	path := filepath.Join(dir, "service.sh")
+	if goos == "windows" {
+		// Windows PowerShell 5 只有带 BOM 时才按 UTF-8 读取脚本（服务说明中有中文）
+		path = filepath.Join(dir, "service.ps1")
+		script = "\ufeff" + strings.ReplaceAll(script, "\n", "\r\n")
+	}
+	if err := os.WriteFile(path, []byte(script), 0644); err != nil {
+		return apperr.Errorf(apperr.SysServiceFailed, "写入临时文件失败: %v", err)
+	}
+
+	args := elevatedCommand(goos, path)
+	output, err := sysutil.Runner.CombinedOutput("", args[0], args[1:]...)
+	if err != nil {
+		return apperr.Errorf(apperr.SysServiceFailed, "以管理员身份%s服务失败: %v\n%s", action, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// elevatedCommand 指定系统上以管理员身份执行脚本的命令
+//   - Windows: PowerShell Start-Process -Verb RunAs（UAC 确认），返回脚本的退出码
+//   - macOS:   osascript do shell script ... with administrator privileges
+//   - Linux:   pkexec sh
+func elevatedCommand(goos, script string) []string {
+	switch goos {
+	case "windows":
+		return []string{"powershell", "-NoProfile", "-Command",
+			fmt.Sprintf(`$p = Start-Process -FilePath powershell -ArgumentList '-NoProfile -ExecutionPolicy Bypass -File "%s"' -Verb RunAs -Wait -PassThru -WindowStyle Hidden; exit $p.ExitCode`, script)}
+	case "darwin":
+		return []string{"osascript", "-e", fmt.Sprintf(`do shell script "sh '%s'" with administrator privileges`, script)}
+	default:
+		return []string{"pkexec", "sh", script}
+	}
+}
+
+// installScript 注册并启动服务的脚本（unitFile 为已写好的服务配置文件，nssm 为 NSSM 的路径）
+func installScript(goos string, s Spec, unitFile, nssm string) string {
+	switch goos {
+	case "linux":
+		return lines(
+			"set -e",
+			"install -m 644 "+sysutil.ShellQuote(unitFile)+" "+sysutil.ShellQuote(UnitPath(goos, s.Name)),
+			"systemctl daemon-reload",
+			"systemctl enable "+s.Name+".service",
+			"systemctl restart "+s.Name+".service",
+		)
+	case "darwin":
+		return lines(
+			"set -e",
+			"launchctl bootout system/"+Label(s.Name)+" 2>/dev/null || true",
+			"install -m 644 -o root -g wheel "+sysutil.ShellQuote(unitFile)+" "+sysutil.ShellQuote(UnitPath(goos, s.Name)),
+			"launchctl bootstrap system "+sysutil.ShellQuote(UnitPath(goos, s.Name)),
+		)
+	}
+
+	if nssm != "" {
+		// 原生命令失败不会触发 $ErrorActionPreference，每条命令后检查退出码
+		n := "& " + psQuote(nssm) + " "
+		check := "; if ($LASTEXITCODE -ne 0) { exit $LASTEXITCODE }"
+		return lines(
+			"$ErrorActionPreference = 'Stop'",
+			n+"stop "+s.Name+" 2>$null | Out-Null",
+			n+"remove "+s.Name+" confirm 2>$null | Out-Null",
+			n+"install "+s.Name+" "+psQuote(s.Binary)+check,
+			n+"set "+s.Name+" AppDirectory "+psQuote(s.WorkDir)+check,
+			n+"set "+s.Name+" Description "+psQuote(s.describe())+check,
+			n+"set "+s.Name+" Start SERVICE_AUTO_START"+check,
+			n+"start "+s.Name+check,
+		)
+	}
+	// 没有 NSSM 时注册为开机以 SYSTEM 身份运行的计划任务，不限制运行时间，异常退出后重试
+	return lines(
+		"$ErrorActionPreference = 'Stop'",
+		"$action = New-ScheduledTaskAction -Execute "+psQuote(s.Binary)+" -WorkingDirectory "+psQuote(s.WorkDir),
+		"$trigger = New-ScheduledTaskTrigger -AtStartup",
+		"$principal = New-ScheduledTaskPrincipal -UserId 'SYSTEM' -LogonType ServiceAccount -RunLevel Highest",
+		"$settings = New-ScheduledTaskSettingsSet -ExecutionTimeLimit ([TimeSpan]::Zero) -RestartCount 3 -RestartInterval (New-TimeSpan -Minutes 1) -AllowStartIfOnBatteries -DontStopIfGoingOnBatteries",
+		"Register-ScheduledTask -TaskName "+psQuote(s.Name)+" -TaskPath "+psQuote(TaskPath)+" -Action $action -Trigger $trigger -Principal $principal -Settings $settings -Description "+psQuote(s.describe())+" -Force | Out-Null",
+		"Start-ScheduledTask -TaskName "+psQuote(s.Name)+" -TaskPath "+psQuote(TaskPath),
+	)
+}
+
+// uninstallScript 停止并删除服务的脚本（Windows 上服务和计划任务都删除）
+func uninstallScript(goos, name string) string {
+	switch goos {
+	case "linux":
+		return lines(
+			"systemctl disable --now "+name+".service 2>/dev/null || true",
+			"rm -f "+sysutil.ShellQuote(UnitPath(goos, name)),
+			"systemctl daemon-reload",
+		)
+	case "darwin":
+		return lines(
+			"launchctl bootout system/"+Label(name)+" 2>/dev/null || true",
+			"rm -f "+sysutil.ShellQuote(UnitPath(goos, name)),
+		)
+	}
+	return lines(
+		"sc.exe stop "+name+" 2>$null | Out-Null",
+		"sc.exe delete "+name+" 2>$null | Out-Null",
+		"Stop-ScheduledTask -TaskName "+psQuote(name)+" -TaskPath "+psQuote(TaskPath)+" -ErrorAction SilentlyContinue",
+		"Unregister-ScheduledTask -TaskName "+psQuote(name)+" -TaskPath "+psQuote(TaskPath)+" -Confirm:$false -ErrorAction SilentlyContinue",
+		"exit 0",
+	)
+}
+
+// lines 按行拼接脚本
+func lines(l ...string) string {
+	return strings.Join(l, "\n") + "\n"
+}
@@ -0,0 +1,177 @@
+// Package sysservice 把构建好的后端注册为系统服务，开机运行，不依赖面板窗口：
+//   - Linux:   /etc/systemd/system/<名称>.service（systemd 单元）
+//   - macOS:   /Library/LaunchDaemons/com.xiaoafengclub.gvapanel.<名称>.plist（launchd 守护进程）
+//   - Windows: 已安装 NSSM 时注册为 Windows 服务；否则注册为以 SYSTEM 身份开机运行的计划任务
+//     （gva-server 不是服务程序，直接用 sc.exe 注册会因不响应服务控制而启动失败）
+//
+// 注册和卸载需要管理员权限，通过 pkexec / osascript / UAC 请求授权
+package sysservice
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/internal/sysutil"
+)
+
+// LabelPrefix launchd 守护进程名的前缀
+const LabelPrefix = "com.xiaoafengclub.gvapanel."
+
+// TaskPath Windows 计划任务所在的文件夹
+const TaskPath = `\GVAPanel\`
+
+// Spec 要注册的服务
+type Spec struct {
+	Name        string // 服务名（systemd 单元名、Windows 服务名），例如 gva-server
+	Description string // 服务说明
+	Binary      string // 后端可执行文件（server/gva-server）
+	WorkDir     string // 工作目录（server/，后端从这里读取 config.yaml）
+	User        string // Linux / macOS 上运行服务的用户（为空时为 root）
+}
+
+// namePattern 服务名只允许字母、数字和 . _ -（同时用于文件名和命令参数）
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// DefaultName 项目默认的服务名：gva-<根目录名>（根目录名中的其他字符替换为 -）
+func DefaultName(root string) string {
+	base := strings.Trim(regexp.MustCompile(`[^A-Za-z0-9_.-]+`).ReplaceAllString(filepath.Base(root), "-"), "-.")
+	if base == "" {
+		return "gva-server"
+	}
+	return "gva-" + strings.ToLower(base)
+}
+
+// Validate 检查服务名和路径
+func (s Spec) Validate() error {
+	if !namePattern.MatchString(s.Name) {
+		return apperr.Errorf(apperr.SysServiceFailed, "服务名 %q 无效（只能包含字母、数字和 . _ -）", s.Name)
+	}
+	if s.Binary == "" || !filepath.IsAbs(s.Binary) {
+		return apperr.Errorf(apperr.SysServiceFailed, "后端可执行文件需要是绝对路径")
+	}
+	if s.WorkDir == "" || !filepath.IsAbs(s.WorkDir) {
+		return apperr.Errorf(apperr.SysServiceFailed, "工作目录需要是绝对路径")
+	}
+	return nil
+}
+
+// Label launchd 守护进程名
+func Label(name string) string {
+	return LabelPrefix + name
+}
+
+// Kind 指定系统上注册的服务类型（界面显示用），nssm 为是否找到了 NSSM
+func Kind(goos string, nssm bool) string {
+	switch goos {
+	case "linux":
+		return "systemd 单元"
+	case "darwin":
+		return "launchd 守护进程"
+	case "windows":
+		if nssm {
+			return "Windows 服务（NSSM）"
+		}
+		return "开机计划任务（SYSTEM）"
+	default:
+		return ""
+	}
+}
+
+// Supported 指定系统是否支持注册系统服务
+func Supported(goos string) bool {
+	return Kind(goos, false) != ""
+}
+
+// UnitPath 服务配置文件的路径（Windows 没有配置文件，返回空字符串）
+func UnitPath(goos, name string) string {
+	switch goos {
+	case "linux":
+		return "/etc/systemd/system/" + name + ".service"
+	case "darwin":
+		return "/Library/LaunchDaemons/" + Label(name) + ".plist"
+	default:
+		return ""
+	}
+}
+
+// LogPath macOS 上服务输出的日志文件（systemd 写入 journal，用 journalctl -u 查看）
+func (s Spec) LogPath() string {
+	return filepath.Join(s.WorkDir, s.Name+".log")
+}
+
+// Unit 指定系统的服务配置文件内容（Windows 没有配置文件，返回空字符串）
+func Unit(goos string, s Spec) string {
+	switch goos {
+	case "linux":
+		return systemdUnit(s)
+	case "darwin":
+		return launchDaemon(s)
+	default:
+		return ""
+	}
+}
+
+// systemdUnit systemd 单元：网络就绪后启动，异常退出后 5 秒重启
+func systemdUnit(s Spec) string {
+	var b strings.Builder
+	b.WriteString("# 由 GVAPanel 生成，可在面板「安装为系统服务」中卸载\n")
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=" + s.describe() + "\n")
+	b.WriteString("After=network-online.target\nWants=network-online.target\n\n")
+	b.WriteString("[Service]\nType=simple\n")
+	if s.User != "" {
+		b.WriteString("User=" + s.User + "\n")
+	}
+	// WorkingDirectory= 不去掉引号，整行就是路径，只需转义说明符 %
+	b.WriteString("WorkingDirectory=" + strings.ReplaceAll(s.WorkDir, "%", "%%") + "\n")
+	// ExecStart 中的 $ 会被展开为环境变量，需要写成 $$
+	b.WriteString("ExecStart=" + strings.ReplaceAll(systemdQuote(s.Binary), "$", "$$") + "\n")
+	b.WriteString("Restart=on-failure\nRestartSec=5\n\n")
+	b.WriteString("[Install]\nWantedBy=multi-user.target\n")
+	return b.String()
+}
+
+// systemdQuote 按 systemd 的规则转义路径：% 写成 %%（说明符），含空格等字符时加双引号（引号内的 " 和 \ 需要转义）
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\;") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// launchDaemon launchd 守护进程配置：开机运行，异常退出后重启，输出写入工作目录下的日志文件
+func launchDaemon(s Spec) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	b.WriteString("\t<key>Label</key>\n\t<string>" + Label(s.Name) + "</string>\n")
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n\t\t<string>" + sysutil.XMLEscape(s.Binary) + "</string>\n\t</array>\n")
+	b.WriteString("\t<key>WorkingDirectory</key>\n\t<string>" + sysutil.XMLEscape(s.WorkDir) + "</string>\n")
+	if s.User != "" {
+		b.WriteString("\t<key>UserName</key>\n\t<string>" + sysutil.XMLEscape(s.User) + "</string>\n")
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	b.WriteString("\t<key>StandardOutPath</key>\n\t<string>" + sysutil.XMLEscape(s.LogPath()) + "</string>\n")
+	b.WriteString("\t<key>StandardErrorPath</key>\n\t<string>" + sysutil.XMLEscape(s.LogPath()) + "</string>\n")
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+// psQuote 用单引号包裹 PowerShell 字符串
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// describe 服务说明（未填写时使用默认值）
+func (s Spec) describe() string {
+	if s.Description != "" {
+		return s.Description
+	}
+	return fmt.Sprintf("GVA 后端 %s（由 GVAPanel 注册）", s.Name)
+}
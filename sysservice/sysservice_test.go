@@ -0,0 +1,133 @@
+package sysservice
+
+import (
+	"strings"
+	"testing"
+)
+
+func testSpec() Spec {
+	return Spec{
+		Name:    "gva-demo",
+		Binary:  "/home/me/my gva/server/gva-server",
+		WorkDir: "/home/me/my gva/server",
+		User:    "me",
+	}
+}
+
+func TestDefaultNameAndValidate(t *testing.T) {
+	cases := map[string]string{
+		"/home/me/gin-vue-admin": "gva-gin-vue-admin",
+		"/home/me/My Project":    "gva-my-project",
+		"/home/me/项目":            "gva-server",
+	}
+	for root, want := range cases {
+		if got := DefaultName(root); got != want {
+			t.Errorf("%s: got %q, want %q", root, got, want)
+		}
+	}
+
+	if err := testSpec().Validate(); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	for _, mutate := range []func(*Spec){
+		func(s *Spec) { s.Name = "gva server" },
+		func(s *Spec) { s.Name = "-gva" },
+		func(s *Spec) { s.Binary = "server/gva-server" },
+		func(s *Spec) { s.WorkDir = "" },
+	} {
+		s := testSpec()
+		mutate(&s)
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v 应报错", s)
+		}
+	}
+}
+
+func TestSystemdUnit(t *testing.T) {
+	s := testSpec()
+	s.Binary = "/opt/100%/$HOME/gva-server"
+	unit := Unit("linux", s)
+	for _, want := range []string{
+		"Description=GVA 后端 gva-demo（由 GVAPanel 注册）\n",
+		"User=me\n",
+		"WorkingDirectory=/home/me/my gva/server\n",
+		"ExecStart=/opt/100%%/$$HOME/gva-server\n",
+		"Restart=on-failure\n",
+		"WantedBy=multi-user.target\n",
+	} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("单元缺少 %q:\n%s", want, unit)
+		}
+	}
+	if UnitPath("linux", "gva-demo") != "/etc/systemd/system/gva-demo.service" {
+		t.Errorf("UnitPath = %s", UnitPath("linux", "gva-demo"))
+	}
+}
+
+func TestLaunchDaemon(t *testing.T) {
+	s := testSpec()
+	s.Binary = "/Users/me/A&B/server/gva-server"
+	plist := Unit("darwin", s)
+	for _, want := range []string{
+		"<string>" + LabelPrefix + "gva-demo</string>",
+		"<string>/Users/me/A&amp;B/server/gva-server</string>",
+		"<key>UserName</key>\n\t<string>me</string>",
+		"<key>SuccessfulExit</key>\n\t\t<false/>",
+		"<string>/home/me/my gva/server/gva-demo.log</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("plist 缺少 %q:\n%s", want, plist)
+		}
+	}
+	if Unit("windows", s) != "" || UnitPath("windows", "gva-demo") != "" {
+		t.Error("Windows 没有配置文件")
+	}
+}
+
+func TestInstallScripts(t *testing.T) {
+	s := testSpec()
+	linux := installScript("linux", s, "/tmp/x/unit", "")
+	if !strings.Contains(linux, "install -m 644 '/tmp/x/unit' '/etc/systemd/system/gva-demo.service'\n") ||
+		!strings.Contains(linux, "systemctl enable gva-demo.service\n") {
+		t.Errorf("linux:\n%s", linux)
+	}
+	if !strings.Contains(installScript("darwin", s, "/tmp/x/unit", ""), "launchctl bootstrap system '/Library/LaunchDaemons/"+Label("gva-demo")+".plist'") {
+		t.Error("darwin 应用 launchctl bootstrap 加载")
+	}
+
+	s.Binary, s.WorkDir = `C:\Users\O'Neil\gva\server\gva-server.exe`, `C:\Users\O'Neil\gva\server`
+	nssm := installScript("windows", s, "", `C:\tools\nssm.exe`)
+	for _, want := range []string{
+		`& 'C:\tools\nssm.exe' install gva-demo 'C:\Users\O''Neil\gva\server\gva-server.exe'; if ($LASTEXITCODE -ne 0)`,
+		`set gva-demo AppDirectory 'C:\Users\O''Neil\gva\server'`,
+		"set gva-demo Start SERVICE_AUTO_START",
+	} {
+		if !strings.Contains(nssm, want) {
+			t.Errorf("nssm 脚本缺少 %q:\n%s", want, nssm)
+		}
+	}
+	task := installScript("windows", s, "", "")
+	if !strings.Contains(task, "New-ScheduledTaskTrigger -AtStartup") || !strings.Contains(task, "-WorkingDirectory 'C:\\Users\\O''Neil\\gva\\server'") {
+		t.Errorf("计划任务脚本:\n%s", task)
+	}
+
+	if !strings.Contains(uninstallScript("linux", "gva-demo"), "systemctl disable --now gva-demo.service") ||
+		!strings.Contains(uninstallScript("windows", "gva-demo"), "sc.exe delete gva-demo") {
+		t.Error("卸载脚本")
+	}
+}
+
+func TestElevatedCommand(t *testing.T) {
+	if got := strings.Join(elevatedCommand("linux", "/tmp/x/service.sh"), " "); got != "pkexec sh /tmp/x/service.sh" {
+		t.Errorf("linux = %s", got)
+	}
+	if got := elevatedCommand("darwin", "/tmp/x/service.sh"); got[0] != "osascript" || !strings.Contains(got[2], "with administrator privileges") {
+		t.Errorf("darwin = %q", got)
+	}
+	if got := elevatedCommand("windows", `C:\T\service.ps1`); got[0] != "powershell" || !strings.Contains(got[3], "-Verb RunAs") || !strings.Contains(got[3], "exit $p.ExitCode") {
+		t.Errorf("windows = %q", got)
+	}
+	if Kind("windows", true) != "Windows 服务（NSSM）" || Supported("plan9") {
+		t.Error("Kind / Supported")
+	}
+}
@@ -0,0 +1,340 @@
+// Package trash 面板管理的回收站：清理缓存时把 node_modules 和 Go 模块缓存目录移入回收站而不是直接删除，
+// 误清理后可以恢复。移入的内容保存在原位置所在磁盘上的 .gvapanel-trash 目录中（移动只是重命名，不复制数据），
+// 每次清理为一个批次，批次列表保存在面板数据目录下的 index.json 中，超过保留期的批次自动彻底删除
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gva-launcher/apperr"
+)
+
+// DirName 原位置所在磁盘上保存回收站内容的目录名（目录中的 .gitignore 忽略所有内容，不会出现在项目的 git 状态中）
+const DirName = ".gvapanel-trash"
+
+// Retention 批次的保留期，超过后清理缓存时自动彻底删除
+const Retention = 7 * 24 * time.Hour
+
+// Item 移入回收站的一个目录
+type Item struct {
+	Original string      `json:"original"`       // 原位置
+	Stored   string      `json:"stored"`         // 回收站中的位置
+	Mode     fs.FileMode `json:"mode,omitempty"` // 移动前为只读目录时的原权限（恢复后还原）
+}
+
+// Batch 一次清理移入回收站的内容
+type Batch struct {
+	ID      string    `json:"id"`
+	Label   string    `json:"label"` // 例如「清理缓存」
+	Created time.Time `json:"created"`
+	Items   []Item    `json:"items"`
+}
+
+// Trash 回收站（索引保存在 dir/index.json）
+type Trash struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// New 创建回收站
+func New(dir string) *Trash {
+	return &Trash{dir: dir}
+}
+
+// Session 正在进行的一次清理，可在多个协程中同时移入
+type Session struct {
+	trash *Trash
+	mu    sync.Mutex
+	batch Batch
+}
+
+// Begin 开始一次清理
+func (t *Trash) Begin(label string) *Session {
+	now := time.Now()
+	return &Session{trash: t, batch: Batch{ID: now.Format("20060102-150405.000"), Label: label, Created: now}}
+}
+
+// Move 把 path 移入 volumeDir 下的回收站目录（volumeDir 需与 path 位于同一磁盘，例如项目根目录、模块缓存根目录）；
+// path 不存在时什么也不做
+func (s *Session) Move(path, volumeDir string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	n := len(s.batch.Items)
+	// 先占位，并发移入时序号不重复
+	s.batch.Items = append(s.batch.Items, Item{})
+	s.mu.Unlock()
+
+	item := Item{Original: path, Stored: filepath.Join(volumeDir, DirName, s.batch.ID, strconv.Itoa(n))}
+	if err := move(path, item.Stored, info, &item); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.batch.Items[n] = item
+	s.mu.Unlock()
+	return nil
+}
+
+// move 重命名到回收站；目录为只读时（Go 模块缓存）先加上写权限，移动目录需要更新其中的 ..
+func move(path, stored string, info fs.FileInfo, item *Item) error {
+	if err := prepareVolume(filepath.Dir(filepath.Dir(stored))); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(stored), 0755); err != nil {
+		return err
+	}
+	if mode := info.Mode().Perm(); info.IsDir() && mode&0200 == 0 {
+		if err := os.Chmod(path, mode|0200); err != nil {
+			return err
+		}
+		item.Mode = mode
+	}
+	if err := os.Rename(path, stored); err != nil {
+		if item.Mode != 0 {
+			os.Chmod(path, item.Mode)
+		}
+		return err
+	}
+	return nil
+}
+
+// prepareVolume 创建磁盘上的回收站目录，并写入忽略所有内容的 .gitignore
+func prepareVolume(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		return os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+	return nil
+}
+
+// Commit 把本次清理记录到回收站索引（没有移入任何内容时忽略）
+func (s *Session) Commit() error {
+	s.mu.Lock()
+	batch := s.batch
+	var items []Item
+	for _, item := range batch.Items {
+		if item.Stored != "" {
+			items = append(items, item)
+		}
+	}
+	batch.Items = items
+	s.mu.Unlock()
+
+	if len(batch.Items) == 0 {
+		return nil
+	}
+	return s.trash.update(func(batches []Batch) []Batch {
+		return append(batches, batch)
+	})
+}
+
+// List 回收站中的批次（从新到旧）
+func (t *Trash) List() ([]Batch, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	batches, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(batches, func(i, j int) bool { return batches[i].Created.After(batches[j].Created) })
+	return batches, nil
+}
+
+// Restore 把批次中的内容移回原位置。原位置已存在的（例如已重新安装依赖）不覆盖，保留在回收站中；
+// 全部恢复后从索引中移除批次
+func (t *Trash) Restore(id string) (restored, skipped int, err error) {
+	updateErr := t.update(func(batches []Batch) []Batch {
+		i := find(batches, id)
+		if i < 0 {
+			return batches
+		}
+		var remaining []Item
+		for _, item := range batches[i].Items {
+			if _, statErr := os.Lstat(item.Original); statErr == nil {
+				skipped++
+				remaining = append(remaining, item)
+				continue
+			}
+			if moveErr := restoreItem(item); moveErr != nil {
+				remaining = append(remaining, item)
+				if err == nil {
+					err = apperr.Errorf(apperr.TrashRestoreFailed, "恢复 %s 失败: %v", item.Original, moveErr)
+				}
+				continue
+			}
+			restored++
+		}
+		if len(remaining) == 0 {
+			cleanupVolumes(batches[i])
+			return append(batches[:i], batches[i+1:]...)
+		}
+		batches[i].Items = remaining
+		return batches
+	})
+	if updateErr != nil {
+		return restored, skipped, updateErr
+	}
+	if err == nil && skipped > 0 {
+		err = apperr.Errorf(apperr.TrashRestoreFailed, "%d 项的原位置已存在，未覆盖，仍保留在回收站中", skipped)
+	}
+	return restored, skipped, err
+}
+
+// restoreItem 把一项移回原位置并还原只读权限
+func restoreItem(item Item) error {
+	if err := os.MkdirAll(filepath.Dir(item.Original), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(item.Stored, item.Original); err != nil {
+		return err
+	}
+	if item.Mode != 0 {
+		os.Chmod(item.Original, item.Mode)
+	}
+	return nil
+}
+
+// Purge 彻底删除一个批次
+func (t *Trash) Purge(id string) error {
+	var err error
+	updateErr := t.update(func(batches []Batch) []Batch {
+		i := find(batches, id)
+		if i < 0 {
+			return batches
+		}
+		if err = purge(batches[i]); err != nil {
+			return batches
+		}
+		return append(batches[:i], batches[i+1:]...)
+	})
+	if updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// PurgeExpired 彻底删除超过保留期的批次，返回删除的批次数
+func (t *Trash) PurgeExpired(now time.Time) (int, error) {
+	purged := 0
+	var err error
+	updateErr := t.update(func(batches []Batch) []Batch {
+		var kept []Batch
+		for _, b := range batches {
+			if now.Sub(b.Created) < Retention {
+				kept = append(kept, b)
+				continue
+			}
+			if purgeErr := purge(b); purgeErr != nil {
+				kept = append(kept, b)
+				if err == nil {
+					err = purgeErr
+				}
+				continue
+			}
+			purged++
+		}
+		return kept
+	})
+	if updateErr != nil {
+		return purged, updateErr
+	}
+	return purged, err
+}
+
+// purge 删除批次中的所有内容（Go 模块缓存中的目录是只读的，需要先加上写权限才能删除其中的文件）
+func purge(b Batch) error {
+	for _, item := range b.Items {
+		filepath.WalkDir(item.Stored, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && d.IsDir() {
+				if info, infoErr := d.Info(); infoErr == nil && info.Mode().Perm()&0200 == 0 {
+					os.Chmod(path, info.Mode().Perm()|0200)
+				}
+			}
+			return nil
+		})
+		if err := os.RemoveAll(item.Stored); err != nil {
+			return fmt.Errorf("删除 %s 失败: %w", item.Stored, err)
+		}
+	}
+	cleanupVolumes(b)
+	return nil
+}
+
+// cleanupVolumes 删除批次在各磁盘上留下的空目录（回收站目录中只剩 .gitignore 时一并删除）
+func cleanupVolumes(b Batch) {
+	for _, item := range b.Items {
+		batchDir := filepath.Dir(item.Stored)
+		os.Remove(batchDir)
+		volume := filepath.Dir(batchDir)
+		if entries, err := os.ReadDir(volume); err == nil && len(entries) == 1 && entries[0].Name() == ".gitignore" {
+			os.RemoveAll(volume)
+		}
+	}
+}
+
+// find 批次在列表中的位置（不存在时为 -1）
+func find(batches []Batch, id string) int {
+	for i, b := range batches {
+		if b.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexPath 索引文件路径
+func (t *Trash) indexPath() string {
+	return filepath.Join(t.dir, "index.json")
+}
+
+// load 读取索引（调用方持有锁）
+func (t *Trash) load() ([]Batch, error) {
+	data, err := os.ReadFile(t.indexPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var batches []Batch
+	if err := json.Unmarshal(data, &batches); err != nil {
+		return nil, fmt.Errorf("回收站索引格式错误: %w", err)
+	}
+	return batches, nil
+}
+
+// update 读取索引、修改后写回
+func (t *Trash) update(fn func([]Batch) []Batch) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	batches, err := t.load()
+	if err != nil {
+		return err
+	}
+	batches = fn(batches)
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(batches, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.indexPath(), data, 0644)
+}
@@ -0,0 +1,138 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeFile 创建文件（包括上级目录）
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoveAndRestore(t *testing.T) {
+	root := t.TempDir()
+	nodeModules := filepath.Join(root, "web", "node_modules")
+	writeFile(t, filepath.Join(nodeModules, "vue", "package.json"), "{}")
+
+	tr := New(filepath.Join(t.TempDir(), "trash"))
+	s := tr.Begin("清理缓存")
+	if err := s.Move(nodeModules, root); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Move(filepath.Join(root, "missing"), root); err != nil {
+		t.Fatalf("不存在的路径应忽略: %v", err)
+	}
+	if err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(nodeModules); !os.IsNotExist(err) {
+		t.Fatal("移入回收站后原位置不应存在")
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, DirName, ".gitignore")); string(data) != "*\n" {
+		t.Errorf(".gitignore = %q", data)
+	}
+
+	batches, err := tr.List()
+	if err != nil || len(batches) != 1 || len(batches[0].Items) != 1 {
+		t.Fatalf("List = %+v, %v", batches, err)
+	}
+
+	restored, skipped, err := tr.Restore(batches[0].ID)
+	if err != nil || restored != 1 || skipped != 0 {
+		t.Fatalf("Restore = %d, %d, %v", restored, skipped, err)
+	}
+	if _, err := os.Stat(filepath.Join(nodeModules, "vue", "package.json")); err != nil {
+		t.Errorf("恢复后文件应存在: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, DirName)); !os.IsNotExist(err) {
+		t.Error("全部恢复后应删除空的回收站目录")
+	}
+	if batches, _ := tr.List(); len(batches) != 0 {
+		t.Errorf("全部恢复后应从索引中移除, got %+v", batches)
+	}
+}
+
+func TestRestoreSkipsExisting(t *testing.T) {
+	root := t.TempDir()
+	nodeModules := filepath.Join(root, "node_modules")
+	writeFile(t, filepath.Join(nodeModules, "a.js"), "old")
+
+	tr := New(t.TempDir())
+	s := tr.Begin("清理缓存")
+	if err := s.Move(nodeModules, root); err != nil {
+		t.Fatal(err)
+	}
+	s.Commit()
+
+	// 清理后已重新安装依赖
+	writeFile(t, filepath.Join(nodeModules, "a.js"), "new")
+	batches, _ := tr.List()
+	restored, skipped, err := tr.Restore(batches[0].ID)
+	if err == nil || restored != 0 || skipped != 1 {
+		t.Fatalf("Restore = %d, %d, %v", restored, skipped, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(nodeModules, "a.js")); string(data) != "new" {
+		t.Error("不应覆盖已存在的内容")
+	}
+	if batches, _ := tr.List(); len(batches) != 1 {
+		t.Error("未恢复的内容应保留在回收站中")
+	}
+}
+
+func TestReadOnlyModuleDir(t *testing.T) {
+	modCache := t.TempDir()
+	module := filepath.Join(modCache, "github.com", "gin-gonic", "gin@v1.9.1")
+	writeFile(t, filepath.Join(module, "gin.go"), "package gin")
+	os.Chmod(filepath.Join(module, "gin.go"), 0444)
+	os.Chmod(module, 0555)
+
+	tr := New(t.TempDir())
+	s := tr.Begin("清理缓存")
+	if err := s.Move(module, modCache); err != nil {
+		t.Fatal(err)
+	}
+	s.Commit()
+	batches, _ := tr.List()
+	if batches[0].Items[0].Mode != 0555 {
+		t.Errorf("Mode = %v, want 0555", batches[0].Items[0].Mode)
+	}
+
+	if err := tr.Purge(batches[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(batches[0].Items[0].Stored); !os.IsNotExist(err) {
+		t.Error("彻底删除后内容不应存在")
+	}
+	if batches, _ := tr.List(); len(batches) != 0 {
+		t.Errorf("彻底删除后应从索引中移除, got %+v", batches)
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "node_modules", "a.js"), "")
+
+	tr := New(t.TempDir())
+	s := tr.Begin("清理缓存")
+	s.Move(filepath.Join(root, "node_modules"), root)
+	s.Commit()
+
+	if n, err := tr.PurgeExpired(time.Now()); err != nil || n != 0 {
+		t.Fatalf("未过期的批次不应删除: %d, %v", n, err)
+	}
+	if n, err := tr.PurgeExpired(time.Now().Add(Retention + time.Hour)); err != nil || n != 1 {
+		t.Fatalf("PurgeExpired = %d, %v", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, DirName)); !os.IsNotExist(err) {
+		t.Error("删除后应清理空的回收站目录")
+	}
+}
@@ -0,0 +1,366 @@
+// Package troubleshoot 引导式排查：针对「前端打不开」「登录报 token 过期」「验证码不显示」等常见问题，
+// 按可能性依次执行相关检查（服务端口、前端代理配置、Redis、JWT 配置、系统时钟等），
+// 第一个发现的问题作为最可能的原因，并给出可以一键执行的修复操作
+package troubleshoot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/smoketest"
+)
+
+// Symptom 用户遇到的问题
+type Symptom string
+
+const (
+	FrontendDown   Symptom = "frontend-down"   // 前端打不开
+	TokenExpired   Symptom = "token-expired"   // 登录报 token 过期
+	CaptchaMissing Symptom = "captcha-missing" // 验证码不显示
+)
+
+// Symptoms 所有支持的问题（界面按此顺序显示）
+var Symptoms = []Symptom{FrontendDown, TokenExpired, CaptchaMissing}
+
+// Label 问题的中文说明
+func (s Symptom) Label() string {
+	switch s {
+	case FrontendDown:
+		return "前端打不开"
+	case TokenExpired:
+		return "登录报 token 过期"
+	case CaptchaMissing:
+		return "验证码不显示"
+	default:
+		return string(s)
+	}
+}
+
+// Status 检查结果
+type Status string
+
+const (
+	StatusOK      Status = "ok"      // 正常
+	StatusWarning Status = "warning" // 可能有影响，但不是问题的原因
+	StatusProblem Status = "problem" // 发现问题
+)
+
+// FixAction 修复操作
+type FixAction string
+
+const (
+	FixStartServices FixAction = "start-services"  // 启动前后端服务
+	FixSyncProxyPort FixAction = "sync-proxy-port" // 把前端代理的后端端口改为 config.yaml 中的端口
+	FixDisableRedis  FixAction = "disable-redis"   // 关闭 config.yaml 的 use-redis
+	FixFreePort      FixAction = "free-port"       // 结束占用端口的进程
+)
+
+// Fix 建议的修复操作
+type Fix struct {
+	Action FixAction
+	Title  string
+	Port   int // FixFreePort 时要释放的端口，FixSyncProxyPort 时为后端端口
+}
+
+// Finding 一项检查的结论
+type Finding struct {
+	Check  string // 检查名称，例如「前端代理配置」
+	Status Status
+	Detail string
+	Fix    *Fix // 可以一键修复时不为 nil
+}
+
+// Report 一次排查的结果
+type Report struct {
+	Symptom  Symptom
+	Findings []Finding
+}
+
+// Culprit 最可能的原因：检查按可能性排列，第一个发现的问题；没有问题时返回 nil
+func (r Report) Culprit() *Finding {
+	for i := range r.Findings {
+		if r.Findings[i].Status == StatusProblem {
+			return &r.Findings[i]
+		}
+	}
+	return nil
+}
+
+// Env 排查所需的项目信息和检查手段（由调用方提供，便于测试）
+type Env struct {
+	Root         string
+	BackendPort  int
+	FrontendPort int
+	BackendURL   string // 后端 API 根地址（含 router-prefix）
+	FrontendURL  string // 前端地址
+
+	PortInUse   func(port int) bool
+	Redis       func(addr, password string, db int) error    // 连接并认证 Redis
+	NetworkTime func(ctx context.Context) (time.Time, error) // 网络上的标准时间（检查本机时钟偏差）
+	Client      *http.Client                                 // 请求前端页面使用（为 nil 时使用默认客户端）
+	Now         func() time.Time                             // 为 nil 时使用 time.Now
+}
+
+// check 一项检查
+type check func(ctx context.Context, env Env) Finding
+
+// flows 每个问题依次执行的检查（越靠前越可能是原因，前面的检查是后面的前提）
+var flows = map[Symptom][]check{
+	FrontendDown:   {checkFrontendRunning, checkFrontendPage, checkBackendRunning, checkProxyPort},
+	TokenExpired:   {checkBackendRunning, checkClock, checkJWTConfig, checkMultipoint, checkRedis, checkProxyPort},
+	CaptchaMissing: {checkBackendRunning, checkCaptchaAPI, checkRedis, checkProxyPort, checkCaptchaViaProxy},
+}
+
+// Run 执行问题对应的所有检查
+func Run(ctx context.Context, symptom Symptom, env Env) Report {
+	report := Report{Symptom: symptom}
+	for _, c := range flows[symptom] {
+		if ctx.Err() != nil {
+			break
+		}
+		report.Findings = append(report.Findings, c(ctx, env))
+	}
+	return report
+}
+
+// ok / warn / problem 构造检查结论
+func ok(name, detail string) Finding { return Finding{Check: name, Status: StatusOK, Detail: detail} }
+func warn(name, detail string) Finding {
+	return Finding{Check: name, Status: StatusWarning, Detail: detail}
+}
+func problem(name, detail string, fix *Fix) Finding {
+	return Finding{Check: name, Status: StatusProblem, Detail: detail, Fix: fix}
+}
+
+// startFix 启动服务的修复操作
+var startFix = &Fix{Action: FixStartServices, Title: "启动服务"}
+
+func checkBackendRunning(ctx context.Context, env Env) Finding {
+	const name = "后端服务"
+	if env.BackendPort <= 0 {
+		return problem(name, "未读取到后端端口，请检查 server/config.yaml 的 system.addr", nil)
+	}
+	if !env.PortInUse(env.BackendPort) {
+		return problem(name, fmt.Sprintf("后端端口 %d 没有在监听，后端没有运行或启动失败（可在服务输出中查看原因）", env.BackendPort), startFix)
+	}
+	return ok(name, fmt.Sprintf("端口 %d 正在监听", env.BackendPort))
+}
+
+func checkFrontendRunning(ctx context.Context, env Env) Finding {
+	const name = "前端服务"
+	if env.FrontendPort <= 0 {
+		return problem(name, "未读取到前端端口，请检查 web/.env.development 的 VITE_CLI_PORT", nil)
+	}
+	if !env.PortInUse(env.FrontendPort) {
+		return problem(name, fmt.Sprintf("前端端口 %d 没有在监听，前端没有运行或启动失败（可在服务输出中查看原因）", env.FrontendPort), startFix)
+	}
+	return ok(name, fmt.Sprintf("端口 %d 正在监听", env.FrontendPort))
+}
+
+func checkFrontendPage(ctx context.Context, env Env) Finding {
+	const name = "前端页面"
+	if env.FrontendPort <= 0 || !env.PortInUse(env.FrontendPort) {
+		return warn(name, "前端没有运行，跳过")
+	}
+	client := env.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(env.FrontendURL, "/")+"/", nil)
+	if err != nil {
+		return warn(name, err.Error())
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return problem(name, fmt.Sprintf("端口 %d 在监听，但请求首页失败: %v（可能被其他程序占用，或前端仍在编译）", env.FrontendPort, err),
+			&Fix{Action: FixFreePort, Title: fmt.Sprintf("结束占用端口 %d 的进程", env.FrontendPort), Port: env.FrontendPort})
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusOK || !bytes.Contains(bytes.ToLower(body), []byte("<html")) {
+		return problem(name, fmt.Sprintf("端口 %d 返回的不是前端页面（HTTP %d），端口可能被其他程序占用", env.FrontendPort, resp.StatusCode),
+			&Fix{Action: FixFreePort, Title: fmt.Sprintf("结束占用端口 %d 的进程", env.FrontendPort), Port: env.FrontendPort})
+	}
+	return ok(name, "首页可以正常打开；浏览器中仍打不开时请确认访问的地址和端口，局域网访问时检查防火墙")
+}
+
+func checkProxyPort(ctx context.Context, env Env) Finding {
+	const name = "前端代理配置"
+	proxyPort := config.ReadFrontendBackendPort(env.Root)
+	fix := &Fix{Action: FixSyncProxyPort, Title: fmt.Sprintf("把前端代理端口改为 %d", env.BackendPort), Port: env.BackendPort}
+	switch {
+	case env.BackendPort <= 0:
+		return warn(name, "未读取到后端端口，跳过")
+	case proxyPort == 0:
+		return problem(name, "web/.env.development 中没有 VITE_SERVER_PORT，前端请求不会转发到后端", fix)
+	case proxyPort != env.BackendPort:
+		return problem(name, fmt.Sprintf("前端把接口请求转发到端口 %d，但后端监听的是 %d（可能连到了旧的后端或其他项目）", proxyPort, env.BackendPort), fix)
+	}
+	return ok(name, fmt.Sprintf("前端把 %s 转发到后端端口 %d", config.ReadBaseAPI(env.Root), proxyPort))
+}
+
+func checkRedis(ctx context.Context, env Env) Finding {
+	const name = "Redis"
+	cfg, err := config.ReadGVAConfig(env.Root)
+	if err != nil {
+		return warn(name, "读取 config.yaml 失败: "+err.Error())
+	}
+	if !cfg.System.UseRedis {
+		return ok(name, "未开启 use-redis，令牌和验证码保存在后端内存中")
+	}
+	if err := env.Redis(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB); err != nil {
+		return problem(name, fmt.Sprintf("开启了 use-redis，但连接 %s 失败: %v（令牌校验、验证码都依赖 Redis）", cfg.Redis.Addr, err),
+			&Fix{Action: FixDisableRedis, Title: "关闭 use-redis（重启后端后生效）"})
+	}
+	return ok(name, "已连接 "+cfg.Redis.Addr)
+}
+
+func checkMultipoint(ctx context.Context, env Env) Finding {
+	const name = "多点登录拦截"
+	cfg, err := config.ReadGVAConfig(env.Root)
+	if err != nil {
+		return warn(name, "读取 config.yaml 失败: "+err.Error())
+	}
+	switch {
+	case cfg.System.UseMultipoint && !cfg.System.UseRedis:
+		return problem(name, "开启了 use-multipoint 但没有开启 use-redis：多点登录拦截需要 Redis 保存登录状态。请开启 Redis 或关闭 use-multipoint", nil)
+	case cfg.System.UseMultipoint:
+		return warn(name, "开启了 use-multipoint：同一账号在其他地方登录后，这里的令牌会失效并提示重新登录")
+	}
+	return ok(name, "未开启 use-multipoint")
+}
+
+func checkJWTConfig(ctx context.Context, env Env) Finding {
+	const name = "JWT 配置"
+	cfg, err := config.ReadGVAConfig(env.Root)
+	if err != nil {
+		return warn(name, "读取 config.yaml 失败: "+err.Error())
+	}
+	expires, err := ParseDuration(cfg.JWT.ExpiresTime)
+	if err != nil {
+		return problem(name, fmt.Sprintf("jwt.expires-time 无法解析（%q），请使用 7d、12h 这样的格式", cfg.JWT.ExpiresTime), nil)
+	}
+	if expires < 10*time.Minute {
+		return problem(name, fmt.Sprintf("jwt.expires-time 为 %s，令牌很快就会过期，开发时建议设置为 7d", cfg.JWT.ExpiresTime), nil)
+	}
+	if buffer, err := ParseDuration(cfg.JWT.BufferTime); err == nil && buffer >= expires {
+		return warn(name, fmt.Sprintf("jwt.buffer-time（%s）不小于 expires-time（%s），每次请求都会换发新令牌", cfg.JWT.BufferTime, cfg.JWT.ExpiresTime))
+	}
+	return ok(name, "令牌有效期 "+cfg.JWT.ExpiresTime)
+}
+
+// maxClockSkew 允许的本机时钟偏差
+const maxClockSkew = 2 * time.Minute
+
+func checkClock(ctx context.Context, env Env) Finding {
+	const name = "系统时钟"
+	now := time.Now
+	if env.Now != nil {
+		now = env.Now
+	}
+	standard, err := env.NetworkTime(ctx)
+	if err != nil {
+		return warn(name, "无法获取网络时间，跳过: "+err.Error())
+	}
+	skew := now().Sub(standard)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxClockSkew {
+		return problem(name, fmt.Sprintf("本机时间与网络时间相差 %s，签发的令牌会被判定为已过期或尚未生效。请开启系统的自动同步时间"+
+			"（Windows: 设置 → 时间和语言 → 立即同步；Linux: timedatectl set-ntp true；macOS: 系统设置 → 通用 → 日期与时间）",
+			skew.Round(time.Second)), nil)
+	}
+	return ok(name, fmt.Sprintf("与网络时间相差 %s", skew.Round(time.Second)))
+}
+
+// captchaOnly 只执行验证码接口的冒烟测试配置
+var captchaOnly = config.SmokeTest{Skip: []string{smoketest.CheckLogin, smoketest.CheckIndex, smoketest.CheckWebSocket}}
+
+// captchaWait 请求验证码接口的等待时长（失败时在此时长内重试）
+var captchaWait = 5 * time.Second
+
+func checkCaptchaAPI(ctx context.Context, env Env) Finding {
+	const name = "验证码接口"
+	if env.BackendPort <= 0 || !env.PortInUse(env.BackendPort) {
+		return warn(name, "后端没有运行，跳过")
+	}
+	results := smoketest.Run(ctx, smoketest.Checks(smoketest.Targets{BackendURL: env.BackendURL}, captchaOnly), captchaWait)
+	if len(results) == 1 && !results[0].OK {
+		return problem(name, "后端 /base/captcha 请求失败: "+results[0].Detail+"（开启 Redis 时请确认 Redis 可用，其余原因请查看后端输出）", nil)
+	}
+	return ok(name, "后端可以生成验证码")
+}
+
+func checkCaptchaViaProxy(ctx context.Context, env Env) Finding {
+	const name = "通过前端请求验证码"
+	if env.FrontendPort <= 0 || !env.PortInUse(env.FrontendPort) {
+		return problem(name, "前端没有运行", startFix)
+	}
+	base := strings.TrimRight(env.FrontendURL, "/") + config.ReadBaseAPI(env.Root)
+	results := smoketest.Run(ctx, smoketest.Checks(smoketest.Targets{BackendURL: base}, captchaOnly), captchaWait)
+	if len(results) == 1 && !results[0].OK {
+		return problem(name, "经前端代理请求 "+base+"/base/captcha 失败: "+results[0].Detail, nil)
+	}
+	return ok(name, "浏览器经前端代理可以获取验证码；页面上仍不显示时请在浏览器开发者工具中查看 captcha 请求")
+}
+
+// ParseDuration 解析 GVA config.yaml 中的时长（在 time.ParseDuration 的基础上支持天，例如 7d、1d12h）
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var days time.Duration
+	if i := strings.Index(s, "d"); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("无效的时长: %s", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		s = s[i+1:]
+		if s == "" {
+			return days, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return days + d, nil
+}
+
+// HTTPDate 通过 HTTP 响应的 Date 头获取网络时间（依次尝试 urls，返回第一个成功的结果）
+func HTTPDate(ctx context.Context, client *http.Client, urls ...string) (time.Time, error) {
+	var lastErr error = fmt.Errorf("没有可用的地址")
+	for _, url := range urls {
+		reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodHead, url, nil)
+		if err != nil {
+			cancel()
+			lastErr = err
+			continue
+		}
+		resp, err := client.Do(req)
+		cancel()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			return date, nil
+		}
+		lastErr = fmt.Errorf("%s 没有返回 Date 头", url)
+	}
+	return time.Time{}, lastErr
+}
+
+// TimeSources 获取网络时间使用的地址（国内外各一个）
+var TimeSources = []string{"https://www.baidu.com", "https://www.cloudflare.com"}
@@ -0,0 +1,200 @@
+package troubleshoot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeProject 创建只包含 config.yaml 和 .env.development 的项目
+func writeProject(t *testing.T, configYAML string, proxyPort int) string {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "server"), 0755)
+	os.MkdirAll(filepath.Join(root, "web"), 0755)
+	os.WriteFile(filepath.Join(root, "server", "config.yaml"), []byte(configYAML), 0644)
+	env := fmt.Sprintf("VITE_CLI_PORT=8080\nVITE_SERVER_PORT=%d\nVITE_BASE_API=/api\n", proxyPort)
+	os.WriteFile(filepath.Join(root, "web", ".env.development"), []byte(env), 0644)
+	return root
+}
+
+const baseConfig = `system:
+  addr: 8888
+  use-redis: false
+  use-multipoint: false
+jwt:
+  expires-time: 7d
+  buffer-time: 1d
+redis:
+  addr: 127.0.0.1:6379
+`
+
+// testEnv 所有端口都在监听、Redis 和时钟都正常的环境
+func testEnv(root string) Env {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	return Env{
+		Root:         root,
+		BackendPort:  8888,
+		FrontendPort: 8080,
+		PortInUse:    func(int) bool { return true },
+		Redis:        func(string, string, int) error { return nil },
+		NetworkTime:  func(context.Context) (time.Time, error) { return now, nil },
+		Now:          func() time.Time { return now },
+	}
+}
+
+func TestFrontendDownNotRunning(t *testing.T) {
+	env := testEnv(writeProject(t, baseConfig, 8888))
+	env.PortInUse = func(port int) bool { return port != 8080 }
+
+	report := Run(context.Background(), FrontendDown, env)
+	culprit := report.Culprit()
+	if culprit == nil || culprit.Check != "前端服务" || culprit.Fix == nil || culprit.Fix.Action != FixStartServices {
+		t.Fatalf("culprit = %+v", culprit)
+	}
+}
+
+func TestFrontendDownPortTaken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"msg":"another app"}`)
+	}))
+	defer srv.Close()
+
+	env := testEnv(writeProject(t, baseConfig, 8888))
+	env.FrontendURL = srv.URL
+	report := Run(context.Background(), FrontendDown, env)
+	culprit := report.Culprit()
+	if culprit == nil || culprit.Check != "前端页面" || culprit.Fix == nil || culprit.Fix.Action != FixFreePort || culprit.Fix.Port != 8080 {
+		t.Fatalf("culprit = %+v", culprit)
+	}
+}
+
+func TestFrontendDownProxyMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!DOCTYPE html><html><body></body></html>`)
+	}))
+	defer srv.Close()
+
+	env := testEnv(writeProject(t, baseConfig, 8001))
+	env.FrontendURL = srv.URL
+	report := Run(context.Background(), FrontendDown, env)
+	culprit := report.Culprit()
+	if culprit == nil || culprit.Check != "前端代理配置" || culprit.Fix.Action != FixSyncProxyPort || culprit.Fix.Port != 8888 {
+		t.Fatalf("culprit = %+v", culprit)
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		skew   time.Duration
+		check  string
+	}{
+		{"时钟偏差", baseConfig, 10 * time.Minute, "系统时钟"},
+		{"有效期过短", baseConfig[:len(baseConfig)-len("  expires-time: 7d\n  buffer-time: 1d\nredis:\n  addr: 127.0.0.1:6379\n")] +
+			"  expires-time: 30s\nredis:\n  addr: 127.0.0.1:6379\n", 0, "JWT 配置"},
+		{"多点登录没有 Redis", "system:\n  addr: 8888\n  use-multipoint: true\njwt:\n  expires-time: 7d\n", 0, "多点登录拦截"},
+		{"一切正常", baseConfig, 0, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			env := testEnv(writeProject(t, c.config, 8888))
+			now := env.Now()
+			env.Now = func() time.Time { return now.Add(c.skew) }
+			culprit := Run(context.Background(), TokenExpired, env).Culprit()
+			switch {
+			case c.check == "" && culprit != nil:
+				t.Errorf("不应发现问题: %+v", culprit)
+			case c.check != "" && (culprit == nil || culprit.Check != c.check):
+				t.Errorf("culprit = %+v, want %s", culprit, c.check)
+			}
+		})
+	}
+}
+
+func TestCaptchaMissingRedisDown(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":7,"msg":"验证码获取失败"}`)
+	}))
+	defer backend.Close()
+
+	root := writeProject(t, "system:\n  addr: 8888\n  use-redis: true\nredis:\n  addr: 127.0.0.1:6379\n", 8888)
+	env := testEnv(root)
+	env.BackendURL = backend.URL
+	env.Redis = func(string, string, int) error { return errors.New("connection refused") }
+	captchaWait = 500 * time.Millisecond
+	defer func() { captchaWait = 5 * time.Second }()
+
+	report := Run(context.Background(), CaptchaMissing, env)
+	if report.Culprit() == nil || report.Culprit().Check != "验证码接口" {
+		t.Fatalf("culprit = %+v", report.Culprit())
+	}
+	var redis *Finding
+	for i := range report.Findings {
+		if report.Findings[i].Check == "Redis" {
+			redis = &report.Findings[i]
+		}
+	}
+	if redis == nil || redis.Status != StatusProblem || redis.Fix == nil || redis.Fix.Action != FixDisableRedis {
+		t.Errorf("Redis = %+v", redis)
+	}
+}
+
+func TestCaptchaViaProxy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/base/captcha" || r.URL.Path == "/base/captcha" {
+			fmt.Fprint(w, `{"code":0,"data":{"captchaId":"x"}}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	env := testEnv(writeProject(t, baseConfig, 8888))
+	env.BackendURL = srv.URL
+	env.FrontendURL = srv.URL
+	report := Run(context.Background(), CaptchaMissing, env)
+	if culprit := report.Culprit(); culprit != nil {
+		t.Fatalf("不应发现问题: %+v", culprit)
+	}
+	if len(report.Findings) != 5 {
+		t.Errorf("findings = %d, want 5", len(report.Findings))
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"7d":    7 * 24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"12h":   12 * time.Hour,
+		"30s":   30 * time.Second,
+	}
+	for s, want := range cases {
+		if got, err := ParseDuration(s); err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "xd", "7 days"} {
+		if _, err := ParseDuration(s); err == nil {
+			t.Errorf("ParseDuration(%q) 应返回错误", s)
+		}
+	}
+}
+
+func TestHTTPDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "Thu, 15 Oct 2026 12:00:00 GMT")
+	}))
+	defer srv.Close()
+
+	got, err := HTTPDate(context.Background(), srv.Client(), "http://127.0.0.1:1", srv.URL)
+	if err != nil || !got.Equal(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("HTTPDate = %v, %v", got, err)
+	}
+}
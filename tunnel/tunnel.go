@@ -0,0 +1,246 @@
+// Package tunnel 启动外网穿透客户端（cloudflared / ngrok / frpc / 自定义命令），
+// 从客户端输出中识别公网地址，便于向远程的同事或客户演示本地运行的 GVA
+package tunnel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+)
+
+// 支持的穿透客户端
+const (
+	Cloudflared = "cloudflared" // Cloudflare 快速隧道（无需账号，地址每次不同）
+	Ngrok       = "ngrok"       // ngrok（需要先执行 ngrok config add-authtoken）
+	Frp         = "frp"         // frpc + 自己的 frps 服务器（公网地址手动填写）
+	Custom      = "custom"      // 自定义命令
+)
+
+// Providers 界面下拉框中的客户端（按此顺序显示）
+var Providers = []string{Cloudflared, Ngrok, Frp, Custom}
+
+// Label 客户端的显示名称
+func Label(provider string) string {
+	switch provider {
+	case Cloudflared:
+		return "Cloudflare Tunnel (cloudflared)"
+	case Ngrok:
+		return "ngrok"
+	case Frp:
+		return "frp (frpc)"
+	case Custom:
+		return "自定义命令"
+	default:
+		return provider
+	}
+}
+
+// maxOutputLines 保留的客户端输出行数（启动失败时显示）
+const maxOutputLines = 30
+
+// urlPatterns 各客户端输出中的公网地址
+var urlPatterns = map[string]*regexp.Regexp{
+	Cloudflared: regexp.MustCompile(`https://[a-z0-9-]+\.trycloudflare\.com`),
+	Ngrok:       regexp.MustCompile(`url=(https://[^\s"]+)`),
+	Custom:      regexp.MustCompile(`https://[^\s"'|<>]+`),
+}
+
+// Command 启动客户端的命令（target 为本地前端地址，例如 http://localhost:8080）
+func Command(cfg config.Tunnel, target string) (name string, args []string, err error) {
+	switch cfg.Provider {
+	case Cloudflared:
+		// 改写 Host 头，避免 Vite 拒绝陌生域名（server.allowedHosts）
+		args = []string{"tunnel", "--no-autoupdate", "--url", target, "--http-host-header", "localhost"}
+		if strings.HasPrefix(target, "https://") {
+			// 本地 HTTPS 证书只在本机受信任
+			args = append(args, "--no-tls-verify")
+		}
+		return "cloudflared", args, nil
+	case Ngrok:
+		return "ngrok", []string{"http", target, "--host-header=rewrite", "--log", "stdout", "--log-format", "logfmt"}, nil
+	case Frp:
+		if strings.TrimSpace(cfg.FrpConfig) == "" {
+			return "", nil, fmt.Errorf("请先选择 frpc 配置文件")
+		}
+		return "frpc", []string{"-c", cfg.FrpConfig}, nil
+	case Custom:
+		command := strings.TrimSpace(cfg.Command)
+		if command == "" {
+			return "", nil, fmt.Errorf("请先填写自定义命令")
+		}
+		port := target[strings.LastIndex(target, ":")+1:]
+		command = strings.NewReplacer("{url}", target, "{port}", port).Replace(command)
+		name, args = sysutil.ShellCommand(command)
+		return name, args, nil
+	default:
+		return "", nil, fmt.Errorf("未选择穿透客户端")
+	}
+}
+
+// FindURL 从客户端输出的一行中识别公网地址（没有时返回空字符串）
+func FindURL(provider, line string) string {
+	pattern, ok := urlPatterns[provider]
+	if !ok {
+		return ""
+	}
+	m := pattern.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	url := m[len(m)-1]
+	if strings.Contains(url, "localhost") || strings.Contains(url, "127.0.0.1") {
+		return ""
+	}
+	return url
+}
+
+// Tunnel 一个运行中的穿透客户端
+type Tunnel struct {
+	// OnURL 识别到公网地址时调用（在读取输出的协程中）
+	OnURL func(url string)
+	// OnExit 客户端退出时调用（Stop 主动停止时 err 为 nil）
+	OnExit func(err error)
+
+	mu       sync.Mutex
+	proc     sysutil.Process
+	provider string
+	url      string
+	lines    []string
+	partial  string
+	stopping bool
+}
+
+// Start 启动客户端（已在运行时返回错误）；cfg.PublicURL 不为空时直接作为公网地址
+func (t *Tunnel) Start(cfg config.Tunnel, target string) error {
+	name, args, err := Command(cfg, target)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	if t.proc != nil {
+		t.mu.Unlock()
+		return fmt.Errorf("穿透客户端已在运行")
+	}
+	t.provider = cfg.Provider
+	t.url = ""
+	t.lines = nil
+	t.partial = ""
+	t.stopping = false
+	t.mu.Unlock()
+
+	proc, err := sysutil.Runner.StartOutput("", t, name, args...)
+	if err != nil {
+		return fmt.Errorf("启动 %s 失败: %v（请确认已安装并加入 PATH）", name, err)
+	}
+
+	t.mu.Lock()
+	t.proc = proc
+	t.mu.Unlock()
+
+	if url := strings.TrimSpace(cfg.PublicURL); url != "" {
+		t.setURL(url)
+	}
+
+	go t.wait(proc)
+	return nil
+}
+
+// wait 等待客户端退出并通知
+func (t *Tunnel) wait(proc sysutil.Process) {
+	err := proc.Wait()
+
+	t.mu.Lock()
+	stopping := t.stopping
+	t.proc = nil
+	t.url = ""
+	t.mu.Unlock()
+
+	if stopping {
+		err = nil
+	} else if err == nil {
+		err = fmt.Errorf("穿透客户端已退出")
+	}
+	if t.OnExit != nil {
+		t.OnExit(err)
+	}
+}
+
+// Stop 停止客户端（未运行时不做任何事）
+func (t *Tunnel) Stop() {
+	t.mu.Lock()
+	proc := t.proc
+	t.stopping = true
+	t.mu.Unlock()
+
+	if proc != nil && proc.OSProcess() != nil {
+		proc.OSProcess().Kill()
+	}
+}
+
+// Running 客户端是否在运行
+func (t *Tunnel) Running() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.proc != nil
+}
+
+// URL 已识别的公网地址（尚未识别或未运行时为空）
+func (t *Tunnel) URL() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.url
+}
+
+// Output 最近的客户端输出
+func (t *Tunnel) Output() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return strings.Join(t.lines, "\n")
+}
+
+// Write 接收客户端输出（实现 io.Writer），按行识别公网地址
+func (t *Tunnel) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	text := t.partial + string(p)
+	lines := strings.Split(text, "\n")
+	t.partial = lines[len(lines)-1]
+	lines = lines[:len(lines)-1]
+
+	found := ""
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		t.lines = append(t.lines, line)
+		if t.url == "" && found == "" {
+			found = FindURL(t.provider, line)
+		}
+	}
+	if len(t.lines) > maxOutputLines {
+		t.lines = t.lines[len(t.lines)-maxOutputLines:]
+	}
+	t.mu.Unlock()
+
+	if found != "" {
+		t.setURL(found)
+	}
+	return len(p), nil
+}
+
+// setURL 记录公网地址并通知
+func (t *Tunnel) setURL(url string) {
+	t.mu.Lock()
+	if t.url != "" {
+		t.mu.Unlock()
+		return
+	}
+	t.url = url
+	t.mu.Unlock()
+
+	if t.OnURL != nil {
+		t.OnURL(url)
+	}
+}
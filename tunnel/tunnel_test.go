@@ -0,0 +1,125 @@
+package tunnel
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil/sysutiltest"
+)
+
+func TestCommand(t *testing.T) {
+	cases := []struct {
+		cfg    config.Tunnel
+		target string
+		want   string
+	}{
+		{config.Tunnel{Provider: Cloudflared}, "http://localhost:8080", "cloudflared tunnel --no-autoupdate --url http://localhost:8080 --http-host-header localhost"},
+		{config.Tunnel{Provider: Cloudflared}, "https://localhost:8080", "cloudflared tunnel --no-autoupdate --url https://localhost:8080 --http-host-header localhost --no-tls-verify"},
+		{config.Tunnel{Provider: Ngrok}, "http://localhost:8080", "ngrok http http://localhost:8080 --host-header=rewrite --log stdout --log-format logfmt"},
+		{config.Tunnel{Provider: Frp, FrpConfig: "/etc/frpc.toml"}, "http://localhost:8080", "frpc -c /etc/frpc.toml"},
+	}
+	for _, c := range cases {
+		name, args, err := Command(c.cfg, c.target)
+		if err != nil {
+			t.Errorf("%s: %v", c.cfg.Provider, err)
+			continue
+		}
+		if got := strings.Join(append([]string{name}, args...), " "); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.cfg.Provider, got, c.want)
+		}
+	}
+
+	_, args, err := Command(config.Tunnel{Provider: Custom, Command: "bore local {port} --to bore.pub"}, "http://localhost:8080")
+	if err != nil || !strings.Contains(strings.Join(args, " "), "bore local 8080 --to bore.pub") {
+		t.Errorf("custom: args = %v, err = %v", args, err)
+	}
+
+	for _, cfg := range []config.Tunnel{{Provider: Frp}, {Provider: Custom}, {}} {
+		if _, _, err := Command(cfg, "http://localhost:8080"); err == nil {
+			t.Errorf("%+v 应报错", cfg)
+		}
+	}
+}
+
+func TestFindURL(t *testing.T) {
+	cases := []struct {
+		provider, line, want string
+	}{
+		{Cloudflared, "2024-05-01T10:00:00Z INF |  https://quiet-lake-1234.trycloudflare.com                                 |", "https://quiet-lake-1234.trycloudflare.com"},
+		{Cloudflared, "INF Thank you for trying Cloudflare Tunnel. https://www.cloudflare.com/website-terms/", ""},
+		{Ngrok, `t=2024-05-01T10:00:00+0800 lvl=info msg="started tunnel" obj=tunnels name=command_line addr=http://localhost:8080 url=https://ab12-34.ngrok-free.app`, "https://ab12-34.ngrok-free.app"},
+		{Custom, "your url is: https://demo.example.com", "https://demo.example.com"},
+		{Custom, "listening on https://127.0.0.1:4040", ""},
+		{Frp, "start proxy success", ""},
+	}
+	for _, c := range cases {
+		if got := FindURL(c.provider, c.line); got != c.want {
+			t.Errorf("%s %q: got %q, want %q", c.provider, c.line, got, c.want)
+		}
+	}
+}
+
+func TestStartDetectsURL(t *testing.T) {
+	runner := sysutiltest.New(t)
+	runner.Handle("cloudflared tunnel --no-autoupdate --url http://localhost:8080 --http-host-header localhost",
+		"INF Requesting new quick Tunnel...\nINF |  https://quiet-lake-1234.trycloudflare.com  |\nINF Registered tunnel connection\n", nil)
+
+	urls := make(chan string, 1)
+	exited := make(chan error, 1)
+	tun := &Tunnel{
+		OnURL:  func(url string) { urls <- url },
+		OnExit: func(err error) { exited <- err },
+	}
+	if err := tun.Start(config.Tunnel{Provider: Cloudflared}, "http://localhost:8080"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case url := <-urls:
+		if url != "https://quiet-lake-1234.trycloudflare.com" {
+			t.Errorf("url = %q", url)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("没有识别到公网地址")
+	}
+
+	// 假进程立即结束，视为意外退出
+	select {
+	case err := <-exited:
+		if err == nil {
+			t.Error("非主动停止的退出应返回错误")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("没有收到退出通知")
+	}
+	if tun.Running() || !strings.Contains(tun.Output(), "Registered tunnel connection") {
+		t.Errorf("running = %v, output = %q", tun.Running(), tun.Output())
+	}
+}
+
+func TestStartUsesPublicURL(t *testing.T) {
+	runner := sysutiltest.New(t)
+	runner.Handle("frpc -c frpc.toml", "start proxy success\n", nil)
+
+	urls := make(chan string, 1)
+	tun := &Tunnel{OnURL: func(url string) { urls <- url }}
+	if err := tun.Start(config.Tunnel{Provider: Frp, FrpConfig: "frpc.toml", PublicURL: "https://gva.example.com"}, "http://localhost:8080"); err != nil {
+		t.Fatal(err)
+	}
+	if url := <-urls; url != "https://gva.example.com" {
+		t.Errorf("url = %q", url)
+	}
+}
+
+func TestStartFailure(t *testing.T) {
+	sysutiltest.New(t) // 未预设的命令返回错误
+	tun := &Tunnel{}
+	if err := tun.Start(config.Tunnel{Provider: Ngrok}, "http://localhost:8080"); err == nil || !strings.Contains(err.Error(), "PATH") {
+		t.Errorf("err = %v", err)
+	}
+	if tun.Running() {
+		t.Error("启动失败时不应标记为运行中")
+	}
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/supervisor"
+)
+
+// showAllocPortsDialog 显示自动分配端口对话框：扫描范围内的空闲端口，一次写入前后端配置
+func (l *GVALauncher) showAllocPortsDialog() {
+	if !l.project.IsSet() {
+		dialog.ShowInformation("提示", "请先指定 GVA 根目录", l.window)
+		return
+	}
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	start, end := l.config.PortRange.Bounds()
+	startEntry := widget.NewEntry()
+	startEntry.SetText(strconv.Itoa(start))
+	endEntry := widget.NewEntry()
+	endEntry.SetText(strconv.Itoa(end))
+
+	help := widget.NewLabel(fmt.Sprintf("当前端口: 后端 %d，前端 %d\n\n", l.backendPort, l.frontendPort) +
+		"当前端口空闲时保留，被占用、与其他项目冲突或与 MySQL（3306）、Redis（6379）、Vite（5173）等常用服务冲突时，" +
+		"在下面的范围内挑选空闲端口，并同时写入后端 config.yaml 和前端环境配置（任一步失败时全部还原）。" +
+		"服务正在运行时会先停止。")
+	help.Wrapping = fyne.TextWrapWord
+
+	rangeBox := container.NewGridWithColumns(3, startEntry, widget.NewLabelWithStyle("至", fyne.TextAlignCenter, fyne.TextStyle{}), endEntry)
+	content := container.NewVBox(help, widget.NewForm(widget.NewFormItem("扫描范围", rangeBox)))
+
+	d := dialog.NewCustomConfirm("🎲 自动分配端口", "🎲 分配", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		r := config.PortRange{}
+		r.Start, _ = strconv.Atoi(strings.TrimSpace(startEntry.Text))
+		r.End, _ = strconv.Atoi(strings.TrimSpace(endEntry.Text))
+		if s, e := r.Bounds(); s != r.Start || e != r.End {
+			dialog.ShowError(fmt.Errorf("端口范围无效 (1-65535，起始端口不能大于结束端口)"), l.window)
+			return
+		}
+		l.config.PortRange = r
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		wasRunning := l.services.IsRunning()
+		if wasRunning {
+			l.services.StopPorts(l.backendPort, l.frontendPort)
+			l.enableStartButton()
+			l.stopButton.Disable()
+		}
+
+		// 避开面板自身服务和其他项目登记的端口
+		avoid := append(l.panelPorts(), config.OtherProjectPorts(l.config.Projects, l.config.GVARootPath)...)
+		l.background("自动分配端口", func(ctx context.Context) func() {
+			// 等待刚停止的服务释放端口
+			if wasRunning && !supervisor.Sleep(ctx, l.config.EffectiveTimeouts().StopWait()) {
+				return nil
+			}
+			backendPort, frontendPort, changed, err := l.project.AllocatePorts(r, avoid...)
+
+			return func() {
+				if err != nil {
+					l.showError(err, nil)
+					return
+				}
+				l.backendPort = backendPort
+				l.frontendPort = frontendPort
+				l.updateServiceStatus()
+				l.registerProjectPorts()
+
+				message := fmt.Sprintf("当前端口均可用，无需修改\n\n后端: %d\n前端: %d", backendPort, frontendPort)
+				if changed {
+					message = fmt.Sprintf("端口已分配\n\n后端: %d\n前端: %d", backendPort, frontendPort)
+				}
+				if wasRunning {
+					message += "\n\n服务已自动关闭，请重新启动"
+				}
+				dialog.ShowInformation("成功", message, l.window)
+			}
+		})
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(50), 0))
+	d.Show()
+}
+
+// panelPorts 面板自身服务（单端口代理、状态导出接口）监听的端口，分配端口时需要避开
+func (l *GVALauncher) panelPorts() []int {
+	var ports []int
+	for _, addr := range []string{l.config.Proxy.Addr, l.config.Metrics.Addr} {
+		if _, portStr, err := net.SplitHostPort(addr); err == nil {
+			if port, err := strconv.Atoi(portStr); err == nil {
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apiroutes"
+	"gva-launcher/apperr"
+)
+
+// apiColumns API 表格的列标题
+var apiColumns = []string{"方法", "路径", "分组", "说明"}
+
+// apiCell 一个 API 在表格第 col 列显示的文字
+func apiCell(r apiroutes.Route, col int) string {
+	switch col {
+	case 0:
+		return r.Method
+	case 1:
+		return r.Path
+	case 2:
+		return r.Group
+	default:
+		return r.Description
+	}
+}
+
+// showAPIDialog 在后台读取项目数据库中的 API 列表，完成后显示浏览窗口
+func (l *GVALauncher) showAPIDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg, err := l.project.ReadConfig()
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+	root := l.project.Root
+	backendPort, _ := l.project.Ports()
+	baseURL := apiroutes.BaseURL(backendPort, cfg.System.RouterPrefix)
+
+	progress := dialog.NewCustomWithoutButtons("🧭 API 浏览", widget.NewLabel("正在读取 sys_apis ..."), l.window)
+	progress.Show()
+	l.background("读取 API 列表", func(context.Context) func() {
+		routes, err := apiroutes.Load(root, cfg)
+		return func() {
+			progress.Hide()
+			if err != nil {
+				l.showError(err, nil)
+				return
+			}
+			l.showAPITable(routes, baseURL)
+		}
+	})
+}
+
+// showAPITable 以表格显示 API，可按关键字筛选，选中后复制路径或 curl 命令
+func (l *GVALauncher) showAPITable(all []apiroutes.Route, baseURL string) {
+	shown := all
+
+	detail := widget.NewLabel("选择一行查看 curl 命令")
+	detail.Wrapping = fyne.TextWrapWord
+	var selected *apiroutes.Route
+	curlBtn := widget.NewButton("📋 复制为 curl", func() {
+		if selected != nil {
+			l.copyToClipboard(selected.Curl(baseURL), "curl 命令")
+		}
+	})
+	pathBtn := widget.NewButton("📋 复制路径", func() {
+		if selected != nil {
+			l.copyToClipboard(selected.Path, "API 路径")
+		}
+	})
+	loadTestBtn := widget.NewButton("⏱️ 压测", func() {
+		if selected != nil {
+			l.showLoadTestDialog(selected.Method, selected.Path)
+		}
+	})
+	curlBtn.Disable()
+	pathBtn.Disable()
+	loadTestBtn.Disable()
+
+	table := widget.NewTableWithHeaders(
+		func() (int, int) { return len(shown), len(apiColumns) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(apiCell(shown[id.Row], id.Col))
+		},
+	)
+	table.ShowHeaderColumn = false
+	table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		o.(*widget.Label).SetText(apiColumns[id.Col])
+	}
+	for col, width := range []float32{8, 34, 14, 30} {
+		table.SetColumnWidth(col, l.calcVW(width))
+	}
+	table.OnSelected = func(id widget.TableCellID) {
+		r := shown[id.Row]
+		selected = &r
+		detail.SetText(fmt.Sprintf("%s %s（%s）\n%s", r.Method, r.Path, r.Description, r.Curl(baseURL)))
+		curlBtn.Enable()
+		pathBtn.Enable()
+		loadTestBtn.Enable()
+	}
+
+	count := widget.NewLabel("")
+	filter := widget.NewEntry()
+	filter.SetPlaceHolder("按方法、路径、分组或说明筛选，多个关键字用空格分隔")
+	filter.OnChanged = func(keyword string) {
+		shown = apiroutes.Filter(all, keyword)
+		count.SetText(fmt.Sprintf("共 %d 个", len(shown)))
+		table.UnselectAll()
+		table.Refresh()
+	}
+	count.SetText(fmt.Sprintf("共 %d 个", len(shown)))
+
+	status := "后端未运行，curl 命令需要启动后端后才能调用"
+	if l.services.IsRunning() {
+		status = "后端地址: " + baseURL
+	}
+	help := widget.NewLabel("API 列表来自数据库的 sys_apis 表（后端初始化数据库时写入，在「超级管理员 → API 管理」中维护）。" +
+		"需要登录的接口请把 curl 命令中的 <token> 换成登录后浏览器 localStorage 中的 token。\n" + status)
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(help, container.NewBorder(nil, nil, nil, count, filter))
+	bottom := container.NewVBox(widget.NewSeparator(), detail, container.NewHBox(curlBtn, pathBtn, loadTestBtn))
+	content := container.NewBorder(top, bottom, nil, nil, table)
+
+	d := dialog.NewCustom("🧭 API 浏览", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(90), l.calcVH(75)))
+	d.Show()
+}
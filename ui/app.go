@@ -0,0 +1,595 @@
+// Package ui 是 GVAPanel 的 Fyne 图形界面，业务逻辑全部委托给 launcher 包
+package ui
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/cleanup"
+	"gva-launcher/config"
+	"gva-launcher/crash"
+	"gva-launcher/depreport"
+	"gva-launcher/deps"
+	"gva-launcher/download"
+	"gva-launcher/envcache"
+	"gva-launcher/eta"
+	"gva-launcher/events"
+	"gva-launcher/gvarelease"
+	"gva-launcher/hooks"
+	"gva-launcher/idle"
+	"gva-launcher/instance"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+	"gva-launcher/loadtest"
+	"gva-launcher/mdns"
+	"gva-launcher/remotelog"
+	"gva-launcher/render"
+	"gva-launcher/scheduler"
+	"gva-launcher/smoketest"
+	"gva-launcher/sshkey"
+	"gva-launcher/supervisor"
+	"gva-launcher/trash"
+	"gva-launcher/tunnel"
+	"gva-launcher/updater"
+)
+
+// GVALauncher 启动器主结构
+type GVALauncher struct {
+	config        config.Config
+	project       *launcher.Project
+	services      *launcher.ServiceManager
+	instances     *launcher.Instances // 同时管理的其他项目（多实例）
+	deps          *launcher.DependencyManager
+	builds        *launcher.BuildManager
+	jobs          *jobs.Queue            // 后台任务队列（钩子脚本、定时任务等）
+	scheduler     *scheduler.Scheduler   // 定时任务调度器
+	lock          *instance.Lock         // 单实例锁（获取失败时为 nil）
+	projectLock   *instance.Lock         // 项目锁（多用户保护，获取失败时为 nil）
+	projectHolder *instance.Existing     // 占用当前项目的其他用户（未被占用时为 nil）
+	configWatch   context.CancelFunc     // 停止监听当前项目的配置文件（未监听时为 nil）
+	supervisor    *supervisor.Supervisor // 后台协程管理（窗口关闭时统一取消）
+	facts         *envcache.Cache        // 环境信息缓存（镜像源、模块缓存目录、屏幕分辨率）
+	trash         *trash.Trash           // 清理缓存的回收站
+	metricsServer *http.Server           // 状态导出接口（未开启时为 nil）
+	proxyServer   *http.Server           // 单端口访问代理（未开启时为 nil）
+	activity      *idle.Tracker          // 单端口代理转发请求的活动记录（空闲自动停止）
+	bus           *events.Bus            // 消息总线（引擎发布服务、任务、配置事件，界面订阅后刷新）
+	tasks         pendingTasks           // 后台操作完成后要在主线程中执行的界面更新（见 background）
+	backendPort   int                    // 从 GVA config.yaml 读取的后端端口
+	frontendPort  int                    // 前端端口（默认 8080）
+	httpsEnabled  bool                   // 前端开发服务器是否已开启本地 HTTPS（访问地址使用 https）
+	tunnel        *tunnel.Tunnel         // 外网穿透客户端
+	remoteLog     *remotelog.Stream      // 远程日志跟踪（未打开过时为 nil）
+	sshKeys       map[string][]byte      // 本次运行中已解锁的 SSH 私钥（名称 → 私钥，只保存在内存中）
+	mdns          *mdns.Responder        // mDNS 广播（未开启时为 nil）
+
+	// 应用图标
+	iconData []byte
+
+	// 屏幕信息
+	screenWidth  float32
+	screenHeight float32
+
+	// 窗口尺寸（基于屏幕分辨率计算）
+	windowWidth  float32
+	windowHeight float32
+
+	// UI 组件
+	window              fyne.Window
+	gvaPathEntry        *widget.Entry
+	depStatusLabel      *widget.Label
+	frontendDepLabel    *widget.Label // 前端依赖状态
+	backendDepLabel     *widget.Label // 后端依赖状态
+	backendStatusLabel  *widget.Label
+	frontendStatusLabel *widget.Label
+	urlLabel            *widget.Label
+	backendURLLabel     *widget.Label
+	startButton         *widget.Button
+	stopButton          *widget.Button
+	backendControls     serviceControls // 单独启动/停止/重启后端
+	frontendControls    serviceControls // 单独启动/停止/重启前端
+	checkDepsButton     *widget.Button
+	installDepsButton   *widget.Button
+	frontendMirrorEntry *widget.Entry
+	backendMirrorEntry  *widget.Entry
+	frontendMirrorBtn   *widget.Button
+	backendMirrorBtn    *widget.Button
+	toolchainLabel      *widget.Label // 缺少 go / npm 时的说明
+	tunnelStatusLabel   *widget.Label
+	tunnelURLLabel      *widget.Label
+	tunnelStartBtn      *widget.Button
+	tunnelStopBtn       *widget.Button
+	commandsBox         *fyne.Container // 项目自定义命令的按钮
+	commandsHint        *widget.Label
+	generatorsBox       *fyne.Container // 识别到的后端生成命令的按钮
+	tunnelFrontendUp    bool            // 上次事件中前端是否在运行（用于判断启停变化）
+	uptimeRendered      time.Time       // 上次按时刷新运行时长的时间
+	warnedConflicts     string          // 已提示过的端口冲突（同样的冲突只提示一次）
+	gvaReleaseBtn       *widget.Button
+	gvaReleases         []gvarelease.Release // 上游 GVA 的发布列表（用于新版本提醒）
+	depReportBtn        *widget.Button
+	depReport           *depreport.Report // 最近一次生成的依赖周报
+	depReportUnread     bool              // 最近的周报还没有查看
+	cleanupBtn          *widget.Button
+	cleanupReport       *cleanup.Report // 最近一次空间清理扫描的报告
+	cleanupUnread       bool            // 最近的清理报告还没有查看
+	bottom              *bottomPanel    // 下方面板（服务输出和终端）
+	logs                *logPanel       // 后端输出标签页
+	webLogs             *logPanel       // 前端输出标签页
+	terminal            *terminalPanel  // 终端标签页
+	scripts             *scriptsPanel   // npm 脚本标签页
+	mainBox             *fyne.Container // 窗口内容（下方面板显示时换成上下分栏）
+	mainContent         *fyne.Container // 各功能区域
+	smokeLabel          *widget.Label
+	instancesBox        *fyne.Container    // 其他实例的状态行
+	smokeResults        []smoketest.Result // 最近一次冒烟测试的结果
+	loadTestOptions     loadtest.Options   // 上一次压测的参数（token 只保存在内存中）
+	loadTestReport      *loadtest.Report   // 上一次压测的结果（用于前后对比）
+
+	// Redis 配置组件
+	redisSwitch    *widget.Check
+	redisAddrEntry *widget.Entry
+	redisPassEntry *widget.Entry
+	redisDBEntry   *widget.Entry
+	redisTestBtn   *widget.Button
+	redisSaveBtn   *widget.Button
+	redisCancelBtn *widget.Button
+
+	// Redis 配置缓存（用于取消操作）
+	cachedRedisConfig struct {
+		UseRedis bool
+		Addr     string
+		Password string
+		DB       int
+	}
+
+	// 本机 go / npm 检测结果（toolchainKnown 为 false 表示尚未检测完成）
+	toolchain      deps.Toolchain
+	toolchainKnown bool
+	toolchainNotes []string // Apple Silicon 上改用原生工具链的说明和 Rosetta 转译提示
+
+	// 脚本控制台中编辑的脚本（关闭对话框后保留）
+	scriptSource string
+
+	// 窗口渲染初始化的记录（开始绘制后更新）和改用软件渲染的说明，见 SetRender
+	renderGuard  *render.Guard
+	renderNotice string
+
+	// 响应式按钮列表（用于窗口大小改变时刷新）
+	responsiveButtons []*ResponsiveButton
+}
+
+// New 创建启动器（iconData 为应用图标 PNG，可为空）
+func New(iconData []byte) *GVALauncher {
+	l := &GVALauncher{iconData: iconData, supervisor: supervisor.New()}
+
+	// 上次获取的环境信息，启动时无需等待 npm/go/屏幕检测命令
+	l.facts = envcache.New(config.CachePath())
+	deps.Facts = l.facts
+	l.trash = trash.New(config.TrashDir())
+
+	l.loadConfig() // 加载配置（如果不存在会自动检测屏幕尺寸并创建）
+
+	// 后台协程 panic 时写入崩溃报告（协程结束，面板继续运行）
+	crash.Setup(config.CrashDir(), launcher.Version)
+	l.supervisor.OnPanic = func(name string, recovered interface{}) {
+		crash.Report(name, recovered, debug.Stack())
+	}
+	return l
+}
+
+// SetRender 设置渲染初始化的记录：窗口显示后调用 guard.Started；notice 不为空时在主窗口中提示改用了软件渲染
+func (l *GVALauncher) SetRender(guard *render.Guard, notice string) {
+	l.renderGuard, l.renderNotice = guard, notice
+}
+
+// rendered 窗口已正常显示（已创建原生窗口，或用户已在窗口中操作、关闭了窗口）
+func (l *GVALauncher) rendered() {
+	if l.renderGuard != nil {
+		l.renderGuard.Started()
+	}
+}
+
+// showWindow 显示窗口，窗口创建成功时记为渲染成功。
+// OpenGL 初始化失败时 Fyne 不会创建原生窗口，主循环结束后 guard.Failed 为 true，以软件渲染重新启动
+func (l *GVALauncher) showWindow(w fyne.Window) {
+	w.Show()
+	if windowShown(w) {
+		l.rendered()
+	}
+}
+
+// windowShown 窗口是否已创建原生窗口（无法获取原生窗口的平台视为已创建）
+func windowShown(w fyne.Window) bool {
+	native, ok := w.(driver.NativeWindow)
+	if !ok {
+		return true
+	}
+	shown := true
+	native.RunNative(func(context any) {
+		switch c := context.(type) {
+		case driver.X11WindowContext:
+			shown = c.WindowHandle != 0
+		case driver.WaylandWindowContext:
+			shown = c.WaylandSurface != 0
+		case driver.WindowsWindowContext:
+			shown = c.HWND != 0
+		case driver.MacWindowContext:
+			shown = c.NSWindow != 0
+		}
+	})
+	return shown
+}
+
+// loadConfig 加载配置
+func (l *GVALauncher) loadConfig() {
+	// 每次启动都检测屏幕分辨率，并计算窗口尺寸
+	l.detectScreenSize()
+	l.windowWidth = l.screenWidth * 0.42   // 窗口宽度 = 屏幕宽度的 42%
+	l.windowHeight = l.screenHeight * 0.89 // 窗口高度 = 屏幕高度的 89%
+
+	l.config = config.Load()
+	if l.project == nil {
+		l.project = launcher.NewProject(l.config.GVARootPath)
+		l.services = launcher.NewServiceManager(l.project)
+		l.deps = launcher.NewDependencyManager(l.project)
+		l.builds = launcher.NewBuildManager(l.project)
+
+		// 事件钩子通过任务队列执行，每次触发时读取最新的钩子配置
+		l.jobs = jobs.NewQueue(config.LogDir())
+		// 成功任务的耗时按项目记录，用于估计下次执行的剩余时间
+		l.jobs.Durations = eta.NewHistory(config.DurationsPath())
+		l.jobs.Scope = func() string { return l.project.Root }
+
+		// 服务状态和任务进度通过消息总线通知界面，引擎不直接操作控件
+		l.bus = events.New()
+		l.services.Events = l.bus
+		l.jobs.Events = l.bus
+
+		dispatcher := hooks.NewDispatcher(l.jobs, func() []config.Hook { return l.config.Hooks })
+		l.services.Hooks = dispatcher
+		l.deps.Hooks = dispatcher
+		l.builds.Hooks = dispatcher
+		l.services.Timeouts = func() config.Timeouts { return l.config.EffectiveTimeouts() }
+		l.services.PreferBinary = func() bool { return l.config.LowResource }
+		l.services.AutoRestart = func() bool { return l.config.AutoRestart }
+		l.services.CompiledRun = func() bool { return l.config.CompiledRun }
+		l.services.ProductionMode = func() bool { return l.config.ProductionMode }
+		l.services.Detached = func() bool { return l.config.Detached }
+		l.services.Priority = func() sysutil.Priority { return sysutil.Priority(l.config.Priority.Services) }
+		l.services.BuildPriority = func() sysutil.Priority { return sysutil.Priority(l.config.Priority.Builds) }
+		l.services.IdleStop = func() time.Duration { return time.Duration(l.config.IdleMinutes) * time.Minute }
+		l.activity = idle.NewTracker()
+		l.builds.Priority = l.services.BuildPriority
+		l.builds.ConfirmSecrets = l.confirmSecrets
+		l.setupInstances()
+
+		// 定时任务同样提交到任务队列执行
+		l.scheduler = scheduler.New(l.jobs, append(launcher.TaskActions(l.project, l.deps, l.builds), l.depReportAction(), l.cleanupAction()),
+			func() []config.ScheduledTask { return l.config.Schedules })
+		// 定时任务状态变化时发布事件，定时任务对话框打开期间订阅后刷新
+		l.scheduler.OnChange = func() { l.bus.Publish(events.Event{Topic: events.ScheduleChanged}) }
+	} else {
+		l.project.Root = l.config.GVARootPath
+	}
+	l.project.UseWSL()
+}
+
+// saveConfig 保存配置
+func (l *GVALauncher) saveConfig() error {
+	if err := config.Save(l.config); err != nil {
+		return err
+	}
+	l.bus.Publish(events.Event{Topic: events.ConfigChanged})
+	return nil
+}
+
+// setRootPath 切换 GVA 根目录（只更新内存中的配置，保存由调用方决定）
+func (l *GVALauncher) setRootPath(root string) {
+	wasWSL := l.project.WSLDistro()
+	l.config.GVARootPath = root
+	l.project.Root = root
+	l.project.UseWSL()
+	l.refreshProjectCommands()
+	l.watchProjectConfig()
+	// 已打开过的终端切换到新项目的同一目录
+	if l.terminal != nil && l.terminal.dirSelect.Selected != "" {
+		l.openTerminal()
+	}
+	if l.scripts != nil && l.scripts.runner != nil {
+		l.loadScripts()
+	}
+
+	// 在 Windows 项目和 WSL 项目之间切换时，使用的 go / npm 不同，需要重新检测
+	if l.project.WSLDistro() != wasWSL {
+		l.detectToolchain(nil)
+	}
+}
+
+// Run 创建用户界面并进入主循环
+func (l *GVALauncher) Run() {
+	// 界面回调在主线程执行，其中的 panic 会结束面板，退出前先写入崩溃报告
+	defer crash.Recover("界面主循环")
+
+	myApp := app.New()
+	l.applyContrast(myApp)
+
+	// 设置应用图标（全局）
+	if len(l.iconData) > 0 {
+		myApp.SetIcon(fyne.NewStaticResource("icon.png", l.iconData))
+	}
+
+	// 单实例检测：已有面板在运行时询问切换还是接管
+	// 获取锁失败（例如目录不可写）时不阻止使用，只是失去单实例保护
+	lock, existing, _ := instance.Acquire(config.LockPath())
+	if existing != nil {
+		l.showInstancePrompt(myApp, existing)
+	} else {
+		l.showMainWindow(myApp, lock)
+	}
+
+	// 任务队列在主窗口和实例提示之前启动，退出时随 supervisor 一起结束
+	l.supervisor.Go("任务队列", l.jobs.Run)
+
+	myApp.Run()
+
+	// 主循环结束后取消并等待所有后台协程退出，避免其在窗口销毁后继续访问界面
+	l.supervisor.Cancel()
+	l.supervisor.Shutdown(3 * time.Second)
+
+	if l.metricsServer != nil {
+		l.metricsServer.Close()
+	}
+	if l.proxyServer != nil {
+		l.proxyServer.Close()
+	}
+	if l.mdns != nil {
+		l.mdns.Close()
+	}
+	if l.tunnel != nil {
+		l.tunnel.Stop()
+	}
+	if l.remoteLog != nil {
+		l.remoteLog.Stop()
+	}
+	if l.lock != nil {
+		sshkey.RemoveTemp("")
+	}
+	l.instances.CloseAll()
+	l.releaseProject()
+	if l.lock != nil {
+		l.lock.Release()
+	}
+}
+
+// showMainWindow 创建并显示主窗口（lock 为当前实例持有的单实例锁，可为 nil）
+func (l *GVALauncher) showMainWindow(myApp fyne.App, lock *instance.Lock) {
+	l.lock = lock
+	if lock != nil {
+		// 其他实例请求聚焦时显示窗口，请求接管时退出（在订阅中处理）
+		lock.OnFocus = func() {
+			l.bus.Publish(events.Event{Topic: events.ShowRequested})
+		}
+		lock.OnQuit = func() {
+			l.bus.Publish(events.Event{Topic: events.QuitRequested})
+		}
+	}
+
+	l.window = myApp.NewWindow("GVAPanel")
+	l.window.SetMaster()
+
+	// 清理上次自更新留下的旧版本
+	updater.CleanupOld()
+	// 清理上次异常退出遗留的临时私钥（持有单实例锁时才清理，不会删除其他面板正在使用的）
+	if lock != nil {
+		sshkey.RemoveTemp("")
+	}
+
+	// 下载使用的代理和镜像（配置被手动改坏时使用系统环境变量）
+	download.Configure(l.config.Network)
+
+	// 依赖管理区域
+	depArea := l.createDependencyArea()
+
+	// 服务控制区域
+	serviceArea := l.createServiceArea()
+
+	// GVA 根目录配置区域
+	pathArea := l.createPathArea()
+
+	// 镜像源配置区域
+	mirrorArea := l.createMirrorArea()
+
+	// Redis 对接区域
+	redisArea := l.createRedisArea()
+
+	// 快捷复制区域
+	copyArea := l.createCopyArea()
+
+	// 外网穿透区域
+	tunnelArea := l.createTunnelArea()
+
+	// 项目命令区域
+	commandsArea := l.createCommandsArea()
+
+	// 面板工具区域
+	toolsArea := l.createToolsArea()
+
+	// 下方的服务输出和终端面板（默认隐藏）
+	l.createBottomPanel()
+
+	// 主布局（各区域已自带边界线，无需额外 Separator）
+	l.mainContent = container.NewVBox(
+		depArea,
+		serviceArea,
+		pathArea,
+		mirrorArea,
+		redisArea,
+		copyArea,
+		tunnelArea,
+		commandsArea,
+		toolsArea,
+	)
+
+	l.mainBox = container.NewStack(l.mainContent)
+
+	// 窗口大小改变时刷新所有响应式按钮（布局过程中不直接修改控件，通过消息总线放到下一次界面回调中执行）
+	l.window.SetContent(container.New(&resizeLayout{onResize: func() { l.post("刷新响应式按钮", l.refreshResponsiveButtons) }}, l.mainBox))
+
+	// 订阅引擎事件，统一切换到主线程后刷新界面
+	l.subscribeEvents()
+	l.window.Resize(fyne.NewSize(l.windowWidth, l.windowHeight))
+	l.window.CenterOnScreen() // ⭐ 窗口居中显示
+
+	// 启动时立即更新端口和地址显示
+	l.updatePortsFromGVAConfig()
+
+	// 启动时加载镜像源配置（先显示缓存，后台刷新）
+	l.loadMirrorConfig()
+
+	// 启动时立即加载 Redis 配置
+	l.loadRedisConfig()
+
+	// 启动定时任务调度
+	l.supervisor.Go("定时任务", l.scheduler.Run)
+
+	// 定时发布 Tick 事件，状态栏中的运行时长、任务的已用时间据此刷新
+	l.supervisor.Go("时钟", func(ctx context.Context) { l.bus.Ticks(ctx, time.Second) })
+
+	// 长时间没有访问时自动停止服务
+	l.supervisor.Go("空闲自动停止", func(ctx context.Context) { l.services.WatchIdle(ctx, l.activity) })
+
+	// 状态导出接口（地址被看守模式占用时由看守模式继续导出，这里不提示）
+	l.startMetrics()
+
+	// 启动时自动检测（如果已设置 GVA 根目录）
+	// 依赖检测需要执行 npm ls 和 go env，放到后台进行，窗口先显示"检测中"；
+	// 检测完成时下面的项目锁已经取得，依赖齐全时开机自动启动服务
+	if l.project.IsSet() {
+		l.depStatusLabel.SetText("⏳ 检测中...")
+		l.checkDependencies(l.autoStartServices)
+		// 上次退出面板时仍在后台运行的服务重新连接，继续显示输出和状态
+		l.services.Reattach()
+		l.checkServiceStatus()
+		l.watchProjectConfig()
+	} else {
+		// 未设置根目录时只检测 go / npm，缺失时提前提示
+		l.detectToolchain(nil)
+	}
+
+	// 窗口关闭时取消所有后台协程（状态监控、安装、调度等），不再更新界面
+	l.window.SetOnClosed(func() {
+		l.rendered()
+		l.supervisor.Cancel()
+	})
+
+	l.showWindow(l.window)
+
+	// 其他用户正在使用同一项目时提示
+	l.lockProject()
+
+	// 上次崩溃后遗留的 go run / node 进程仍占着端口时询问接管还是结束
+	l.checkOrphans()
+
+	// 重新打开上次同时管理的其他项目
+	l.restoreInstances()
+
+	// 单端口访问代理（端口被占用时提示，可在设置中更换）
+	if err := l.startProxy(); err != nil {
+		l.showError(err, nil)
+	}
+
+	// mDNS 广播
+	if err := l.startMDNS(); err != nil {
+		l.showError(err, nil)
+	}
+
+	// 上次运行崩溃时提示查看或提交报告
+	l.checkCrashReports()
+
+	// 证书已过期或即将到期时提醒
+	l.checkCertExpiry()
+
+	// 项目提供了配置模板但本地还没有 config.yaml 时提示填写
+	l.checkConfigTemplate()
+
+	// 本次改用了软件渲染时说明原因
+	if l.renderNotice != "" {
+		dialog.ShowInformation("软件渲染", l.renderNotice+"。界面绘制会比较慢，显卡驱动修复后可用 --render hardware 启动恢复显卡渲染", l.window)
+	}
+
+	// 上游 GVA 有新版本时在根目录区域提醒
+	l.checkGVARelease()
+}
+
+// refreshResponsiveButtons 按当前窗口大小刷新所有响应式按钮
+// 注意：这里不应该修改 screenWidth/screenHeight，它们应该始终保持为实际屏幕分辨率，用于计算比例
+func (l *GVALauncher) refreshResponsiveButtons() {
+	for _, rb := range l.responsiveButtons {
+		rb.Refresh()
+	}
+}
+
+// subscribeEvents 订阅消息总线：服务状态变化时刷新状态显示，配置保存后重新显示访问地址（根目录可能已变化），
+// 项目配置文件被修改后重新读取端口，后台操作完成后执行登记的界面更新。
+// 任务进度、服务输出等由对应的对话框和面板在显示期间单独订阅
+func (l *GVALauncher) subscribeEvents() {
+	l.subscribe(func(e events.Event) {
+		switch e.Topic {
+		case events.ServiceChanged:
+			l.renderServiceStatus(e.Service)
+			l.followFrontend(e.Service)
+		case events.ServiceExited:
+			l.onServiceExited(e.Exit)
+		case events.ServicesIdle:
+			l.onServicesIdle()
+		case events.ConfigChanged:
+			l.renderServiceStatus(l.services.State())
+			l.renderTunnelStatus()
+			l.renderGVARelease()
+		case events.ProjectChanged:
+			l.projectConfigChanged()
+		case events.TunnelChanged:
+			l.onTunnelChanged(e.Tunnel)
+		case events.ShowRequested:
+			l.window.Show()
+			l.window.RequestFocus()
+		case events.QuitRequested:
+			fyne.CurrentApp().Quit()
+		case events.Tick:
+			l.renderUptime(e.Time)
+		case events.TaskDone:
+			l.finishTask(e.Task.ID)
+		}
+	}, events.ServiceChanged, events.ServiceExited, events.ServicesIdle, events.ConfigChanged, events.ProjectChanged,
+		events.TunnelChanged, events.ShowRequested, events.QuitRequested, events.Tick, events.TaskDone)
+}
+
+// subscribe 订阅消息总线上的事件，handler 在主线程中调用，返回取消订阅的函数。
+// 后台协程只发布事件，界面控件只在这里的订阅（以及用户操作的回调）中更新
+func (l *GVALauncher) subscribe(handler events.Handler, topics ...events.Topic) (unsubscribe func()) {
+	return l.subscribeOn(l.bus, handler, topics...)
+}
+
+// subscribeOn 与 subscribe 相同，订阅指定的总线（多实例的服务管理器各自有总线）
+func (l *GVALauncher) subscribeOn(bus *events.Bus, handler events.Handler, topics ...events.Topic) (unsubscribe func()) {
+	return bus.SubscribeOn(l.runOnUI, handler, topics...)
+}
+
+// runOnUI 在主线程中执行界面更新（只在 subscribeOn 中使用）；面板关闭后直接丢弃，避免访问已销毁的窗口
+func (l *GVALauncher) runOnUI(fn func()) {
+	if l.supervisor.Context().Err() != nil {
+		return
+	}
+	fyne.Do(fn)
+}
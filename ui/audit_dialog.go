@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/audit"
+	"gva-launcher/config"
+)
+
+// auditColumns 审计日志表格的列标题
+var auditColumns = []string{"时间", "用户", "文件", "键", "修改前", "修改后"}
+
+// auditCell 一条记录在表格第 col 列显示的文字
+func auditCell(e audit.Entry, col int) string {
+	switch col {
+	case 0:
+		return e.Time.Format("2006-01-02 15:04:05")
+	case 1:
+		return e.User
+	case 2:
+		return e.File
+	case 3:
+		return e.Key
+	case 4:
+		return e.Old
+	default:
+		return e.New
+	}
+}
+
+// loadAuditEntries 读取面板配置和当前项目配置的审计日志（从新到旧）
+func (l *GVALauncher) loadAuditEntries() ([]audit.Entry, error) {
+	panel, err := audit.Read(config.AuditLogPath())
+	if err != nil {
+		return nil, err
+	}
+	var project []audit.Entry
+	if l.project.IsSet() {
+		if project, err = audit.Read(config.ProjectAuditPath(l.project.Root)); err != nil {
+			return nil, err
+		}
+	}
+	return audit.Merge(panel, project), nil
+}
+
+// showAuditDialog 以表格显示配置修改记录，可按关键字筛选
+func (l *GVALauncher) showAuditDialog() {
+	all, err := l.loadAuditEntries()
+	if err != nil {
+		l.showError(fmt.Errorf("读取审计日志失败: %w", err), nil)
+		return
+	}
+	shown := all
+
+	detail := widget.NewLabel("选择一行查看完整的修改内容")
+	detail.Wrapping = fyne.TextWrapWord
+	var selected *audit.Entry
+	copyBtn := widget.NewButton("📋 复制修改前的值", func() {
+		if selected != nil {
+			l.copyToClipboard(selected.Old, "修改前的值")
+		}
+	})
+	copyBtn.Disable()
+
+	table := widget.NewTableWithHeaders(
+		func() (int, int) { return len(shown), len(auditColumns) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(auditCell(shown[id.Row], id.Col))
+		},
+	)
+	table.ShowHeaderColumn = false
+	table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		o.(*widget.Label).SetText(auditColumns[id.Col])
+	}
+	for col, width := range []float32{16, 12, 14, 16, 14, 14} {
+		table.SetColumnWidth(col, l.calcVW(width))
+	}
+	table.OnSelected = func(id widget.TableCellID) {
+		e := shown[id.Row]
+		selected = &e
+		detail.SetText(fmt.Sprintf("%s  %s 修改了 %s 中的 %s\n修改前: %s\n修改后: %s",
+			auditCell(e, 0), e.User, e.File, e.Key, e.Old, e.New))
+		copyBtn.Enable()
+	}
+
+	count := widget.NewLabel("")
+	filter := widget.NewEntry()
+	filter.SetPlaceHolder("按用户、文件、键或值筛选")
+	filter.OnChanged = func(keyword string) {
+		shown = nil
+		for _, e := range all {
+			if e.Match(keyword) {
+				shown = append(shown, e)
+			}
+		}
+		count.SetText(fmt.Sprintf("共 %d 条", len(shown)))
+		table.UnselectAll()
+		table.Refresh()
+	}
+	count.SetText(fmt.Sprintf("共 %d 条", len(shown)))
+
+	help := widget.NewLabel("面板对配置文件的每次修改都追加记录在审计日志中（与配置备份分开保存，只追加不修改）：" +
+		"面板配置记录在面板数据目录，项目配置（config.yaml、.env、vite 配置）记录在 GVA 根目录的 " + config.ProjectAuditFile + "（不提交到仓库），" +
+		"共用同一项目的其他用户的修改也会显示在这里。密码、令牌等敏感值只记录是否修改。")
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(help, container.NewBorder(nil, nil, nil, count, filter))
+	bottom := container.NewVBox(widget.NewSeparator(), detail, container.NewHBox(copyBtn))
+	content := container.NewBorder(top, bottom, nil, nil, table)
+
+	d := dialog.NewCustom("🕰️ 配置审计", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(90), l.calcVH(75)))
+	d.Show()
+}
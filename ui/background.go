@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	"gva-launcher/events"
+)
+
+// pendingTasks 后台操作完成后要在主线程中执行的界面更新，按 TaskDone 事件中的 ID 取回
+type pendingTasks struct {
+	mu     sync.Mutex
+	nextID int
+	apply  map[int]func()
+}
+
+// add 登记界面更新，返回事件中使用的 ID
+func (p *pendingTasks) add(apply func()) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.apply == nil {
+		p.apply = make(map[int]func())
+	}
+	p.nextID++
+	p.apply[p.nextID] = apply
+	return p.nextID
+}
+
+// take 取出并删除登记的界面更新（没有时返回 nil）
+func (p *pendingTasks) take(id int) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	apply := p.apply[id]
+	delete(p.apply, id)
+	return apply
+}
+
+// background 在后台协程中执行 work，结束后通过 post 把 work 返回的界面更新交给主线程（返回 nil 时不更新界面）。
+// 界面发起的一次性操作（读取列表、测试连接等）都通过这里执行，后台协程中不直接修改控件
+func (l *GVALauncher) background(name string, work func(ctx context.Context) func()) {
+	l.supervisor.Go(name, func(ctx context.Context) {
+		if apply := work(ctx); apply != nil {
+			l.post(name, apply)
+		}
+	})
+}
+
+// post 登记界面更新并发布 TaskDone 事件，由 subscribeEvents 的订阅在主线程中执行。
+// 用于后台任务中途需要界面配合的情况，例如请用户确认、显示定时任务生成的报告
+func (l *GVALauncher) post(name string, apply func()) {
+	id := l.tasks.add(apply)
+	l.bus.Publish(events.Event{Topic: events.TaskDone, Task: events.TaskState{ID: id, Name: name}})
+}
+
+// finishTask 执行 post 登记的界面更新（在主线程中调用）
+func (l *GVALauncher) finishTask(id int) {
+	if apply := l.tasks.take(id); apply != nil {
+		apply()
+	}
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// bottomPanel 窗口下方的面板（后端输出、前端输出、npm 脚本、终端四个标签页），显示时与上方各区域上下分栏
+type bottomPanel struct {
+	box    *fyne.Container
+	tabs   *container.AppTabs
+	offset float64 // 分栏位置（隐藏后再显示时保持）
+}
+
+// createBottomPanel 创建下方面板（默认隐藏）
+func (l *GVALauncher) createBottomPanel() *fyne.Container {
+	b := &bottomPanel{offset: 0.6}
+	l.bottom = b
+	b.tabs = container.NewAppTabs(append(l.createLogArea(), l.createScriptsArea(), l.createTerminalArea())...)
+	b.tabs.OnSelected = func(tab *container.TabItem) {
+		if tab == l.terminal.tab {
+			l.focusTerminal()
+		}
+	}
+
+	// 收起按钮叠放在标签栏右侧
+	hideBtn := widget.NewButton("✖ 收起", l.hideBottomPanel)
+	b.box = container.NewBorder(widget.NewSeparator(), nil, nil, nil,
+		container.NewStack(b.tabs, container.NewVBox(container.NewHBox(layout.NewSpacer(), hideBtn))))
+	b.box.Hide()
+	return b.box
+}
+
+// toggleBottomPanel 显示下方面板并切换到 tab；已经显示着该标签页时收起
+func (l *GVALauncher) toggleBottomPanel(tab *container.TabItem) {
+	b := l.bottom
+	if b.box.Visible() {
+		if b.tabs.Selected() == tab {
+			l.hideBottomPanel()
+			return
+		}
+		b.tabs.Select(tab)
+		return
+	}
+
+	split := container.NewVSplit(container.NewVScroll(l.mainContent), b.box)
+	split.Offset = b.offset
+	l.mainBox.Objects = []fyne.CanvasObject{split}
+	b.box.Show()
+	l.mainBox.Refresh()
+	b.tabs.Select(tab)
+	l.followLogs()
+	l.followScripts()
+	l.followTerminal()
+	if tab == l.terminal.tab {
+		l.focusTerminal()
+	}
+}
+
+// hideBottomPanel 收起下方面板，恢复原来的布局（终端中正在执行的命令和正在运行的 npm 脚本不受影响）
+func (l *GVALauncher) hideBottomPanel() {
+	b := l.bottom
+	if !b.box.Visible() {
+		return
+	}
+	l.stopFollowingLogs()
+	l.stopFollowingScripts()
+	l.stopFollowingTerminal()
+	if split, ok := l.mainBox.Objects[0].(*container.Split); ok {
+		b.offset = split.Offset
+	}
+	b.box.Hide()
+	l.mainBox.Objects = []fyne.CanvasObject{l.mainContent}
+	l.mainBox.Refresh()
+}
@@ -0,0 +1,557 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/supervisor"
+)
+
+// createPathArea 创建路径配置区域
+func (l *GVALauncher) createPathArea() *fyne.Container {
+	// 9. 标题装箱 + 上下边界线
+	// 上游有新版本时显示（见 renderGVARelease）
+	l.gvaReleaseBtn = widget.NewButton("", func() {
+		l.showGVAReleaseDialog()
+	})
+	l.gvaReleaseBtn.Importance = widget.HighImportance
+	l.gvaReleaseBtn.Hide()
+	// 定时任务生成依赖周报后显示（见 renderDepReport）
+	l.depReportBtn = widget.NewButton("", func() {
+		l.showDepReportDialog()
+	})
+	l.depReportBtn.Hide()
+	// 定时任务扫描到可以清理的内容后显示（见 renderCleanupReport）
+	l.cleanupBtn = widget.NewButton("", func() {
+		l.showCleanupDialog()
+	})
+	l.cleanupBtn.Hide()
+
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("📁 GVA 根目录配置", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			l.gvaReleaseBtn,
+			l.depReportBtn,
+			l.cleanupBtn,
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	// 10. 浏览行装箱
+	l.gvaPathEntry = widget.NewEntry()
+	l.gvaPathEntry.SetPlaceHolder("请选择 GVA 根目录...")
+	l.gvaPathEntry.SetText(l.config.GVARootPath)
+
+	browseBtn := widget.NewButton("　📂 浏览...　", func() {
+		l.showCustomFolderDialog()
+	})
+
+	// 用 Border 布局：右边固定按钮，中间自动填充输入框
+	pathBox := container.NewBorder(
+		nil, nil, // 上下不限制
+		nil,            // 左边不限制
+		browseBtn,      // 右边：按钮
+		l.gvaPathEntry, // 中间：输入框（自动填充）
+	)
+
+	return container.NewVBox(
+		titleBox,
+		pathBox,
+	)
+}
+
+// ========================================
+// 跨平台文件浏览辅助函数
+// ========================================
+
+// getInitialBrowsePath 获取浏览器的初始路径（跨平台）
+func getInitialBrowsePath(configPath string) string {
+	// 如果有配置路径且存在，使用配置路径
+	if configPath != "" {
+		if _, err := os.Stat(configPath); err == nil {
+			return configPath
+		}
+	}
+
+	// 根据操作系统返回默认路径
+	switch runtime.GOOS {
+	case "windows":
+		return "" // 空字符串代表显示驱动器列表
+	default:
+		// Unix-like: 返回根目录
+		return "/"
+	}
+}
+
+// DirItem 目录项结构（用于文件浏览）
+type DirItem struct {
+	Path     string
+	Name     string
+	IsParent bool
+}
+
+// listDrives 列出所有可用驱动器（仅 Windows）
+func listDrives() []DirItem {
+	var drives []DirItem
+
+	// 只在 Windows 上执行
+	if runtime.GOOS != "windows" {
+		return drives
+	}
+
+	// 检测 A-Z 所有可能的驱动器
+	for _, drive := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		drivePath := string(drive) + ":\\"
+		if _, err := os.Stat(drivePath); err == nil {
+			drives = append(drives, DirItem{
+				Path:     drivePath,
+				Name:     string(drive) + ":",
+				IsParent: false,
+			})
+		}
+	}
+
+	return drives
+}
+
+// isRootPath 判断是否是根路径（跨平台）
+func isRootPath(path string) bool {
+	switch runtime.GOOS {
+	case "windows":
+		// Windows: 判断是否是盘符根（C:\, D:\ 等）
+		if path == "" {
+			return true // 空字符串代表驱动器列表层
+		}
+		return filepath.VolumeName(path)+"\\" == path
+	default:
+		// Unix-like: 判断是否是 /
+		return path == "/" || path == ""
+	}
+}
+
+// getParentPath 获取父路径（跨平台）
+func getParentPath(path string) string {
+	if runtime.GOOS == "windows" {
+		// Windows: 如果是盘符根，返回驱动器列表
+		if filepath.VolumeName(path)+"\\" == path {
+			return "" // 空字符串 = 显示驱动器列表
+		}
+	}
+
+	// 其他情况返回父目录
+	return filepath.Dir(path)
+}
+
+// showCustomFolderDialog 显示类似 Windows 资源管理器风格的目录浏览窗口（独立窗口）
+func (l *GVALauncher) showCustomFolderDialog() {
+	// 获取初始路径（跨平台）
+	selectedPath := getInitialBrowsePath(l.config.GVARootPath)
+
+	// 创建独立窗口
+	browseWindow := fyne.CurrentApp().NewWindow("📂 浏览文件夹")
+
+	// 创建路径输入框（显示当前路径 + 可手动输入）
+	pathInput := widget.NewEntry()
+	pathInput.SetPlaceHolder("输入或粘贴路径，按回车或点击跳转")
+	pathInput.SetText(selectedPath) // 初始显示当前路径
+
+	// 状态标签
+	statusLabel := widget.NewLabel("")
+
+	// 存储所有目录项
+	var currentDirs []DirItem
+
+	// 勾选的目录（用于确认时提交）
+	var checkedPath string
+	var checkedID widget.ListItemID = -1
+
+	// 目录列表
+	var dirList *widget.List
+
+	// 更新目录列表
+	updateDirList := func(path string) {
+		currentDirs = []DirItem{}
+		// 清除勾选状态（切换目录时）
+		checkedPath = ""
+		checkedID = -1
+
+		// Windows 特殊处理：空路径 = 显示驱动器列表
+		if runtime.GOOS == "windows" && path == "" {
+			drives := listDrives()
+			for _, drive := range drives {
+				currentDirs = append(currentDirs, DirItem{
+					Path:     drive.Path,
+					Name:     "💿 " + drive.Name,
+					IsParent: false,
+				})
+			}
+			selectedPath = ""
+			pathInput.SetText("💿 选择驱动器")
+			statusLabel.SetText("") // 删除数量显示
+			if dirList != nil {
+				dirList.Refresh()
+			}
+			return
+		}
+
+		selectedPath = path
+		pathInput.SetText(path) // 更新输入框显示当前路径
+
+		// 添加"返回上级"或"返回驱动器列表"选项
+		if runtime.GOOS == "windows" {
+			// Windows: 如果是磁盘根目录（C:\, D:\ 等），显示"返回驱动器列表"
+			if isRootPath(path) {
+				currentDirs = append(currentDirs, DirItem{
+					Path:     "", // 空字符串代表驱动器列表
+					Name:     "⬆️ 返回驱动器列表",
+					IsParent: true,
+				})
+			} else {
+				// 非根目录，显示"返回上级"
+				parentPath := getParentPath(path)
+				currentDirs = append(currentDirs, DirItem{
+					Path:     parentPath,
+					Name:     "⬆️ 返回上级",
+					IsParent: true,
+				})
+			}
+		} else {
+			// Unix-like: 如果不是根目录 /，添加"返回上级"
+			if !isRootPath(path) {
+				parentPath := getParentPath(path)
+				currentDirs = append(currentDirs, DirItem{
+					Path:     parentPath,
+					Name:     "⬆️ 返回上级",
+					IsParent: true,
+				})
+			}
+		}
+
+		// 读取目录
+		files, err := os.ReadDir(path)
+		if err != nil {
+			statusLabel.SetText("❌ 无法读取目录")
+			if dirList != nil {
+				dirList.Refresh()
+			}
+			return
+		}
+
+		// 只显示文件夹
+		count := 0
+		for _, f := range files {
+			if f.IsDir() {
+				currentDirs = append(currentDirs, DirItem{
+					Path:     filepath.Join(path, f.Name()),
+					Name:     "📁 " + f.Name(),
+					IsParent: false,
+				})
+				count++
+			}
+		}
+
+		// 检查是否是GVA目录
+		serverPath := filepath.Join(path, "server")
+		webPath := filepath.Join(path, "web")
+
+		if sysutil.DirExists(serverPath) && sysutil.DirExists(webPath) {
+			statusLabel.SetText("✅ 有效的 GVA 项目")
+		} else {
+			statusLabel.SetText("") // 删除数量显示
+		}
+
+		if dirList != nil {
+			dirList.Refresh()
+		}
+	}
+
+	// 创建目录列表
+	dirList = widget.NewList(
+		func() int {
+			return len(currentDirs)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id >= len(currentDirs) {
+				return
+			}
+
+			label := obj.(*widget.Label)
+			item := currentDirs[id]
+
+			// 显示名称，如果是勾选的项则在后面添加绿色勾
+			if id == checkedID {
+				label.SetText(item.Name + " ✅")
+			} else {
+				label.SetText(item.Name)
+			}
+		},
+	)
+
+	// 双击进入目录
+	var lastClickTime time.Time
+	var lastClickID widget.ListItemID = -1
+
+	dirList.OnSelected = func(id widget.ListItemID) {
+		if id >= len(currentDirs) {
+			return
+		}
+
+		now := time.Now()
+		item := currentDirs[id]
+
+		// 如果是"返回上级"，单击即可进入
+		if item.IsParent {
+			// 单击返回上级
+			updateDirList(item.Path)
+			selectedPath = item.Path
+			// 清除勾选状态
+			checkedPath = ""
+			checkedID = -1
+			dirList.UnselectAll()
+			return
+		}
+
+		// 点击检测
+
+		// 检测双击（500ms内点击同一项）
+		if id == lastClickID && now.Sub(lastClickTime) < 500*time.Millisecond && lastClickTime.UnixNano() > 0 {
+			// 双击：进入目录
+			// 双击进入目录
+			updateDirList(item.Path)
+			selectedPath = item.Path
+			// 清除勾选状态
+			checkedPath = ""
+			checkedID = -1
+			lastClickID = -1
+			// 立即取消选中，使下次点击能触发 OnSelected
+			dirList.UnselectAll()
+		} else {
+			// 单击：切换勾选状态
+			// 单击文件
+
+			if checkedID == id {
+				// 再次单击同一项：取消勾选
+				// 取消勾选
+				checkedPath = ""
+				checkedID = -1
+			} else {
+				// 单击其他项：勾选新项
+				// 勾选文件
+				checkedPath = item.Path
+				checkedID = id
+			}
+
+			selectedPath = item.Path
+			lastClickID = id
+			lastClickTime = now
+
+			// 刷新列表显示勾选状态
+			dirList.Refresh()
+
+			// 关键修复：立即取消选中，让下次点击能触发 OnSelected
+			// 延迟执行，避免影响当前选中效果
+			l.background("取消选中", func(ctx context.Context) func() {
+				if !supervisor.Sleep(ctx, 50*time.Millisecond) {
+					return nil
+				}
+				return dirList.UnselectAll
+			})
+		}
+	}
+
+	// 跳转到输入的路径
+	jumpToPath := func() {
+		inputPath := strings.TrimSpace(pathInput.Text)
+		if inputPath == "" {
+			return
+		}
+
+		// 检查路径是否存在
+		if _, err := os.Stat(inputPath); err != nil {
+			statusLabel.SetText("❌ 路径不存在或无法访问")
+			return
+		}
+
+		// 展开该目录（updateDirList 会自动更新 pathInput）
+		updateDirList(inputPath)
+		selectedPath = inputPath
+		// 不清空输入框，让 updateDirList 更新显示
+	}
+
+	// 跳转按钮
+	jumpBtn := widget.NewButton("　🔍 跳转　", func() {
+		jumpToPath()
+	})
+
+	// 回车键跳转
+	pathInput.OnSubmitted = func(text string) {
+		jumpToPath()
+	}
+
+	// 确认按钮
+	confirmBtn := widget.NewButton("✅ 确认", func() {
+		// 优先使用勾选的路径，如果没有勾选则使用输入框路径
+		var finalPath string
+		if checkedPath != "" {
+			// 有勾选的目录，使用勾选的
+			finalPath = checkedPath
+			// 提交勾选的路径
+		} else {
+			// 没有勾选，使用输入框路径
+			finalPath = strings.TrimSpace(pathInput.Text)
+			// 提交输入框路径
+		}
+
+		if finalPath == "" {
+			dialog.ShowError(fmt.Errorf("请选择一个文件夹"), browseWindow)
+			return
+		}
+
+		if !sysutil.DirExists(finalPath) {
+			dialog.ShowError(fmt.Errorf("所选文件夹不存在"), browseWindow)
+			return
+		}
+
+		// ============ 优先级1：检查是否是同一个路径 ============
+		if finalPath == l.config.GVARootPath {
+			// 路径没有变化，直接关闭窗口，不做任何操作
+			browseWindow.Close()
+			return
+		}
+
+		// ============ 路径发生了变化，需要处理 ============
+
+		// 优先级2：记录旧状态（在修改路径之前）
+		oldBackendPort := l.backendPort
+		oldFrontendPort := l.frontendPort
+		wasRunning := l.services.IsRunning()
+
+		// 优先级3：立即更新路径
+		l.gvaPathEntry.SetText(finalPath)
+		l.setRootPath(finalPath)
+		l.lockProject()
+		l.checkConfigTemplate()
+
+		// 优先级4：立即读取新路径的端口配置（同步执行）
+		l.updatePortsFromGVAConfig()
+		// 注意：如果新路径是错误路径，updatePortsFromGVAConfig会将端口设为0
+
+		// 优先级5：停止旧端口的服务（无论新路径是否正确）
+		if wasRunning {
+			// 使用旧端口号停止服务并清理服务状态
+			l.services.StopPorts(oldBackendPort, oldFrontendPort)
+
+			// 更新UI显示
+			l.enableStartButton()
+			l.stopButton.Disable()
+			l.updateServiceStatus()
+
+			// 等待服务停止
+			time.Sleep(l.config.EffectiveTimeouts().StopWait())
+		}
+
+		// 优先级6：加载其他配置（镜像源先显示缓存，后台刷新），后台检查依赖
+		l.loadMirrorConfig()
+		l.loadRedisConfig()
+		l.checkDependencies(nil)
+
+		// 保存配置
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), browseWindow)
+			return
+		}
+
+		// 关闭浏览窗口并显示提示
+		if wasRunning {
+			// 根据新路径是否有效显示不同提示
+			var message string
+			if l.backendPort > 0 && l.frontendPort > 0 {
+				// 新路径有效
+				message = fmt.Sprintf("GVA目录已更新\n\n旧端口服务已自动关闭:\n• 后端: %d\n• 前端: %d\n\n新端口:\n• 后端: %d\n• 前端: %d",
+					oldBackendPort, oldFrontendPort, l.backendPort, l.frontendPort)
+			} else {
+				// 新路径无效
+				message = fmt.Sprintf("GVA目录已更新\n\n旧端口服务已自动关闭:\n• 后端: %d\n• 前端: %d\n\n⚠️ 新路径配置读取失败，请检查目录是否正确",
+					oldBackendPort, oldFrontendPort)
+			}
+			dialog.ShowInformation("提示", message, browseWindow)
+		}
+		browseWindow.Close()
+	})
+
+	// 取消按钮
+	cancelBtn := widget.NewButton("❌ 取消", func() {
+		browseWindow.Close()
+	})
+
+	// 按钮容器
+	buttons := container.NewGridWithColumns(2, confirmBtn, cancelBtn)
+
+	// 路径输入行：标签 + 输入框 + 按钮
+	pathRow := container.NewBorder(
+		nil, nil,
+		widget.NewLabel("当前路径:"),
+		jumpBtn,
+		pathInput,
+	)
+
+	// 窗口内容
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle("📂 选择 GVA 根目录", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			widget.NewSeparator(),
+			pathRow,
+			statusLabel,
+			widget.NewSeparator(),
+		),
+		buttons,
+		nil,
+		nil,
+		dirList,
+	)
+
+	// 初始化目录列表
+	updateDirList(selectedPath)
+
+	browseWindow.SetContent(content)
+
+	// 设置窗口大小（屏幕分辨率的一半）
+	// 保护逻辑：确保屏幕尺寸有效
+	if l.screenWidth <= 0 || l.screenHeight <= 0 {
+		// 1. 尝试从配置文件重新加载
+		l.loadConfig()
+
+		// 2. 如果还是无效，重新检测屏幕
+		if l.screenWidth <= 0 || l.screenHeight <= 0 {
+			l.detectScreenSize()
+			l.windowWidth = l.screenWidth * 0.42
+			l.windowHeight = l.screenHeight * 0.89
+		}
+	}
+
+	windowWidth := l.screenWidth / 2   // 屏幕宽度的一半
+	windowHeight := l.screenHeight / 2 // 屏幕高度的一半
+
+	// 浏览窗口尺寸已计算
+
+	// 设置固定大小（防止内容自动扩展窗口）
+	browseWindow.SetFixedSize(true)
+	browseWindow.Resize(fyne.NewSize(windowWidth, windowHeight))
+	browseWindow.CenterOnScreen()
+	browseWindow.Show()
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/cdnupload"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+)
+
+// showProductionBuildDialog 生产构建：填写部署后的站点地址和接口前缀，写入 .env.production 后构建前端，
+// 并确认构建出的 dist 确实使用了该地址（避免用 localhost 接口构建后部署）
+func (l *GVALauncher) showProductionBuildDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+
+	env := config.ReadProductionEnv(l.project.Root)
+	basePathEntry := widget.NewEntry()
+	basePathEntry.SetPlaceHolder("https://admin.example.com")
+	basePathEntry.SetText(env.BasePath)
+	baseAPIEntry := widget.NewEntry()
+	baseAPIEntry.SetPlaceHolder(config.DefaultBaseAPI)
+	baseAPIEntry.SetText(env.BaseAPI)
+	fileAPIEntry := widget.NewEntry()
+	fileAPIEntry.SetPlaceHolder("与接口前缀相同")
+	if env.FileAPI != env.BaseAPI {
+		fileAPIEntry.SetText(env.FileAPI)
+	}
+
+	current := func() config.ProductionEnv {
+		return config.ProductionEnv{
+			BasePath: strings.TrimSpace(basePathEntry.Text),
+			BaseAPI:  strings.TrimSpace(baseAPIEntry.Text),
+			FileAPI:  strings.TrimSpace(fileAPIEntry.Text),
+		}
+	}
+	preview := widget.NewLabel("")
+	preview.Wrapping = fyne.TextWrapWord
+	updatePreview := func(string) {
+		e := current()
+		if err := e.Validate(); err != nil {
+			preview.SetText("⚠️ " + err.Error())
+			return
+		}
+		preview.SetText("✅ 部署后浏览器请求的接口地址: " + e.APIURL())
+	}
+	basePathEntry.OnChanged = updatePreview
+	baseAPIEntry.OnChanged = updatePreview
+	fileAPIEntry.OnChanged = updatePreview
+	updatePreview("")
+
+	// 上传到对象存储（凭据使用 config.yaml 中后端的存储配置）
+	storageLabels := make([]string, len(cdnupload.Storages))
+	for i, storage := range cdnupload.Storages {
+		storageLabels[i] = cdnupload.StorageLabel(storage)
+	}
+	storageSelect := widget.NewSelect(storageLabels, nil)
+	storageSelect.SetSelectedIndex(0)
+	for i, storage := range cdnupload.Storages {
+		if storage == l.config.CDN.Storage {
+			storageSelect.SetSelectedIndex(i)
+		}
+	}
+	prefixEntry := widget.NewEntry()
+	prefixEntry.SetPlaceHolder(cdnupload.DefaultPrefix)
+	prefixEntry.SetText(l.config.CDN.Prefix)
+	cdnBox := container.NewVBox(widget.NewForm(
+		widget.NewFormItem("存储", storageSelect),
+		widget.NewFormItem("路径前缀", prefixEntry),
+	))
+	cdnCheck := widget.NewCheck("构建后把静态资源上传到对象存储（使用 config.yaml 中后端的存储凭据，按存储的访问地址构建）", func(on bool) {
+		if on {
+			cdnBox.Show()
+		} else {
+			cdnBox.Hide()
+		}
+	})
+	cdnCheck.SetChecked(l.config.CDN.Enabled)
+	if !l.config.CDN.Enabled {
+		cdnBox.Hide()
+	}
+
+	help := widget.NewLabel("填写部署后的访问地址，保存到 web/.env.production 后执行 npm run build。" +
+		"接口前缀通常保持 /api，由 nginx 把 /api 转发到后端；后端使用单独的域名时填写完整地址。" +
+		"构建完成后会检查 dist 中确实使用了该接口地址，且没有残留 localhost、127.0.0.1 等本机地址。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("站点地址", basePathEntry),
+		widget.NewFormItem("接口前缀", baseAPIEntry),
+		widget.NewFormItem("文件前缀", fileAPIEntry),
+	)
+	content := container.NewVBox(help, form, preview, widget.NewSeparator(), cdnCheck, cdnBox)
+
+	d := dialog.NewCustomConfirm("🏗️ 生产构建", "🏗️ 保存并构建", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := config.WriteProductionEnv(l.project.Root, current()); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.config.CDN = config.CDN{
+			Enabled: cdnCheck.Checked,
+			Storage: cdnupload.Storages[storageSelect.SelectedIndex()],
+			Prefix:  strings.Trim(strings.TrimSpace(prefixEntry.Text), "/"),
+		}
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		l.buildFrontend()
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
+
+// buildFrontend 通过任务队列构建前端并检查 dist 中的接口地址，开启上传时构建后上传静态资源
+func (l *GVALauncher) buildFrontend() {
+	if !l.ensureProjectOwner() || !l.requireServiceTool(launcher.ServiceFrontend) {
+		return
+	}
+	cdn := l.config.CDN
+	var summary cdnupload.Summary
+	job := l.jobs.Submit("生产构建", func(ctx context.Context, j *jobs.Job) error {
+		if !cdn.Enabled {
+			return l.builds.BuildFrontend(j, "")
+		}
+		var err error
+		summary, err = l.builds.BuildAndUpload(ctx, cdn, j)
+		return err
+	})
+	l.waitJob(job, "🏗️ 生产构建", "正在构建前端...", func(err error) {
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			message := "前端已构建到 " + l.builds.DistDir() + "\n已确认 dist 使用的接口地址: " + config.ReadProductionEnv(l.project.Root).APIURL()
+			if cdn.Enabled {
+				message += fmt.Sprintf("\n已上传 %d 个静态资源文件到%s，共 %s；部署时只需把 index.html 放到站点上",
+					summary.Files, cdnupload.StorageLabel(cdn.Storage), sysutil.FormatSize(summary.Bytes))
+			}
+			dialog.ShowInformation("构建完成", message, l.window)
+		}
+	})
+}
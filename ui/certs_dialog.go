@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/certstore"
+	"gva-launcher/config"
+	"gva-launcher/devcert"
+)
+
+// managedCerts 面板管理的全部证书：导入的部署证书，以及本地 HTTPS 签发的开发证书（存在时）
+func managedCerts() []certstore.Info {
+	infos := certstore.List(config.DeployCertDir())
+	if info, err := certstore.Inspect(filepath.Join(config.CertDir(), devcert.CertFile)); err == nil {
+		info.Name = "本地 HTTPS"
+		info.KeyPath = filepath.Join(config.CertDir(), devcert.KeyFile)
+		infos = append(infos, *info)
+	}
+	return infos
+}
+
+// certExpiryText 证书到期时间的说明
+func certExpiryText(info certstore.Info, now time.Time) string {
+	date := info.NotAfter.Local().Format("2006-01-02")
+	switch days := info.DaysLeft(now); {
+	case days < 0:
+		return fmt.Sprintf("❌ 已于 %s 过期", date)
+	case info.Expiring(now):
+		return fmt.Sprintf("⚠️ %s 到期（剩 %d 天）", date, days)
+	default:
+		return fmt.Sprintf("✅ %s 到期（剩 %d 天）", date, days)
+	}
+}
+
+// checkCertExpiry 启动时检查证书有效期，已过期或即将到期时提醒
+func (l *GVALauncher) checkCertExpiry() {
+	now := time.Now()
+	var lines []string
+	for _, info := range managedCerts() {
+		if info.Expiring(now) {
+			lines = append(lines, fmt.Sprintf("• %s（%s）: %s", info.Name, strings.Join(info.DNSNames, ", "), certExpiryText(info, now)))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	message := "以下证书已过期或将在 30 天内到期，请及时续期后重新导入：\n\n" + strings.Join(lines, "\n")
+	dialog.ShowConfirm("📜 证书即将到期", message+"\n\n是否打开证书管理？", func(ok bool) {
+		if ok {
+			l.showCertsDialog()
+		}
+	}, l.window)
+}
+
+// showCertsDialog 显示证书管理：导入、查看有效期、删除部署证书
+func (l *GVALauncher) showCertsDialog() {
+	dir := config.DeployCertDir()
+	list := container.NewVBox()
+
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		now := time.Now()
+		infos := managedCerts()
+		if len(infos) == 0 {
+			list.Add(widget.NewLabel("还没有导入证书"))
+		}
+		for _, info := range infos {
+			title := widget.NewLabelWithStyle(info.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			detail := widget.NewLabel(fmt.Sprintf("域名: %s\n签发者: %s\n%s",
+				strings.Join(info.DNSNames, ", "), info.Issuer, certExpiryText(info, now)))
+			detail.Wrapping = fyne.TextWrapWord
+
+			copyBtn := widget.NewButton("📋 复制路径", func() {
+				l.copyPathToClipboard(filepath.Dir(info.CertPath), "证书目录")
+			})
+			buttons := container.NewHBox(layout.NewSpacer(), copyBtn)
+			if filepath.Dir(filepath.Dir(info.CertPath)) == filepath.Clean(dir) {
+				buttons.Add(widget.NewButton("🗑️ 删除", func() {
+					dialog.ShowConfirm("删除证书", fmt.Sprintf("确定删除证书 %s？", info.Name), func(ok bool) {
+						if !ok {
+							return
+						}
+						if err := certstore.Remove(dir, info.Name); err != nil {
+							l.showError(err, nil)
+							return
+						}
+						refresh()
+					}, l.window)
+				}))
+			}
+			list.Add(container.NewBorder(nil, nil, title, buttons))
+			list.Add(detail)
+			list.Add(widget.NewSeparator())
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	// 导入
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("例如: gva.example.com")
+	certEntry := widget.NewEntry()
+	certEntry.SetPlaceHolder("证书链（PEM，例如 fullchain.pem）")
+	keyEntry := widget.NewEntry()
+	keyEntry.SetPlaceHolder("私钥（PEM，例如 privkey.pem）")
+	browse := func(entry *widget.Entry) fyne.CanvasObject {
+		btn := widget.NewButton("📁", func() {
+			dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+				if err != nil || reader == nil {
+					return
+				}
+				defer reader.Close()
+				entry.SetText(reader.URI().Path())
+			}, l.window)
+		})
+		return container.NewBorder(nil, nil, nil, btn, entry)
+	}
+
+	importBtn := widget.NewButton("📥 导入", func() {
+		name := strings.TrimSpace(nameEntry.Text)
+		info, err := certstore.Import(dir, name, strings.TrimSpace(certEntry.Text), strings.TrimSpace(keyEntry.Text))
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		nameEntry.SetText("")
+		certEntry.SetText("")
+		keyEntry.SetText("")
+		refresh()
+		dialog.ShowInformation("成功", fmt.Sprintf("证书 %s 已导入\n\n%s", name, certExpiryText(*info, time.Now())), l.window)
+	})
+
+	importForm := widget.NewForm(
+		widget.NewFormItem("名称", nameEntry),
+		widget.NewFormItem("证书", browse(certEntry)),
+		widget.NewFormItem("私钥", browse(keyEntry)),
+	)
+
+	help := widget.NewLabel("导入部署使用的 TLS 证书和私钥，导入时会校验私钥与证书是否匹配、证书是否在有效期内。" +
+		"证书保存在面板数据目录中（fullchain.pem / privkey.pem），面板启动时检查有效期，到期前 30 天开始提醒。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(30)))
+
+	content := container.NewVBox(
+		help,
+		scroll,
+		widget.NewLabelWithStyle("导入证书", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		importForm,
+		importBtn,
+	)
+	dialog.ShowCustom("📜 证书管理", "关闭", content, l.window)
+}
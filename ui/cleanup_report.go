@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/cleanup"
+	"gva-launcher/config"
+	"gva-launcher/deps"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+	"gva-launcher/scheduler"
+	"gva-launcher/trash"
+)
+
+// cleanupTaskName 每周扫描可回收空间的定时任务名
+const cleanupTaskName = "空间清理扫描"
+
+// cleanupAction 定时任务动作：扫描可回收的空间，发现可以清理的内容时在面板中提醒
+func (l *GVALauncher) cleanupAction() scheduler.Action {
+	return scheduler.Action{
+		ID:    cleanup.ActionID,
+		Label: "空间清理扫描",
+		Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+			j.Logf("正在扫描旧的构建产物、已移除的项目的依赖和已删除的项目的编译缓存...")
+			r := cleanup.Scan(ctx, l.cleanupOptions())
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			j.Write([]byte(r.Text()))
+			l.post("空间清理报告", func() { l.onCleanupReport(r) })
+			return nil
+		},
+	}
+}
+
+// cleanupOptions 扫描范围：当前项目和多实例为正在管理的项目，从多实例中移除过的项目为已移除的项目；
+// 端口登记表中只是切换过根目录的项目仍在使用，不检查；没有 go 命令时不检查工具链
+func (l *GVALauncher) cleanupOptions() cleanup.Options {
+	active := append([]string{l.project.Root}, l.config.Instances...)
+	var known []string
+	for _, p := range l.config.Projects {
+		known = append(known, p.Root)
+	}
+	modCache, _ := deps.GoModCache()
+	return cleanup.Options{Active: active, Removed: l.config.Removed, Known: known, BinCacheDir: config.BinCacheDir(), ModCache: modCache}
+}
+
+// onCleanupReport 扫描完成：有可以清理的内容时显示提醒并发送系统通知
+func (l *GVALauncher) onCleanupReport(r cleanup.Report) {
+	l.cleanupReport = &r
+	l.cleanupUnread = len(r.Items) > 0
+	l.renderCleanupReport()
+	if l.cleanupUnread {
+		fyne.CurrentApp().SendNotification(fyne.NewNotification("GVA 空间清理", r.Summary()))
+	}
+}
+
+// renderCleanupReport 有未查看的清理报告时在根目录区域显示提醒按钮
+func (l *GVALauncher) renderCleanupReport() {
+	if l.cleanupBtn == nil {
+		return
+	}
+	if l.cleanupReport == nil || !l.cleanupUnread {
+		l.cleanupBtn.Hide()
+		return
+	}
+	l.cleanupBtn.SetText("🧹 " + l.cleanupReport.Summary())
+	l.cleanupBtn.Show()
+}
+
+// cleanupTask 扫描可回收空间的定时任务（没有时返回 -1）
+func (l *GVALauncher) cleanupTask() int {
+	for i, task := range l.config.Schedules {
+		if task.Action == cleanup.ActionID {
+			return i
+		}
+	}
+	return -1
+}
+
+// showCleanupDialog 空间清理：查看最近一次扫描的报告，勾选后一键清理，设置每周扫描
+func (l *GVALauncher) showCleanupDialog() {
+	l.cleanupUnread = false
+	l.renderCleanupReport()
+
+	var items []cleanup.Item
+	if l.cleanupReport != nil {
+		items = l.cleanupReport.Items
+	}
+	summary := widget.NewLabel("本次运行面板后还没有扫描过，点击「立即扫描」或等待定时任务执行")
+	if l.cleanupReport != nil {
+		summary.SetText(l.cleanupReport.Summary() + "（扫描于 " + l.cleanupReport.Generated.Format(time.DateTime) + "）")
+	}
+
+	// 每项一个勾选框，只默认勾选已删除的项目的编译缓存，项目目录中的内容由用户确认后勾选
+	checks := make([]*widget.Check, len(items))
+	list := container.NewVBox()
+	for i, item := range items {
+		checks[i] = widget.NewCheck(fmt.Sprintf("[%s] %s  %s", item.Kind.Label(), sysutil.FormatSize(item.Size), item.Paths[0]), nil)
+		checks[i].SetChecked(item.Suggested)
+		reason := widget.NewLabel("　　" + item.Reason)
+		reason.Wrapping = fyne.TextWrapWord
+		list.Add(container.NewVBox(checks[i], reason))
+	}
+	scroll := container.NewVScroll(list)
+
+	weeklyCheck := widget.NewCheck("每周一 10:00 自动扫描（可在「⏰ 定时任务」中修改时间）", nil)
+	if i := l.cleanupTask(); i >= 0 {
+		weeklyCheck.SetChecked(l.config.Schedules[i].Enabled)
+	}
+	trashCheck := widget.NewCheck(fmt.Sprintf("移入回收站（%d 天内可在「♻️ 回收站」中恢复，之后才释放空间）", int(trash.Retention.Hours()/24)), nil)
+	trashCheck.SetChecked(true)
+
+	// applySchedule 按勾选添加或停用每周的定时任务（保留用户修改过的执行时间）
+	applySchedule := func() error {
+		if i := l.cleanupTask(); i >= 0 {
+			l.config.Schedules[i].Enabled = weeklyCheck.Checked
+		} else if weeklyCheck.Checked {
+			l.config.Schedules = append(l.config.Schedules, config.ScheduledTask{
+				Name:    cleanupTaskName,
+				Cron:    cleanup.WeeklyCron,
+				Action:  cleanup.ActionID,
+				Enabled: true,
+			})
+		}
+		if err := l.saveConfig(); err != nil {
+			return fmt.Errorf("保存配置失败: %w", err)
+		}
+		return nil
+	}
+
+	var d dialog.Dialog
+	scanBtn := widget.NewButton("🔍 立即扫描", func() {
+		if err := applySchedule(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		task := config.ScheduledTask{Name: cleanupTaskName, Action: cleanup.ActionID}
+		if i := l.cleanupTask(); i >= 0 {
+			task = l.config.Schedules[i]
+		}
+		if l.scheduler.RunNow(task) == nil {
+			dialog.ShowInformation("提示", "正在扫描中", l.window)
+			return
+		}
+		d.Hide()
+		dialog.ShowInformation("已开始", "已提交到任务中心，扫描完成后在面板顶部提醒", l.window)
+	})
+	cleanBtn := widget.NewButton("🧹 清理勾选的内容", func() {
+		var selected []cleanup.Item
+		for i, item := range items {
+			if checks[i].Checked {
+				selected = append(selected, item)
+			}
+		}
+		if len(selected) == 0 {
+			l.showError(fmt.Errorf("请至少勾选一项"), nil)
+			return
+		}
+		d.Hide()
+		l.performCleanup(selected, trashCheck.Checked)
+	})
+	if len(items) == 0 {
+		cleanBtn.Disable()
+	}
+
+	help := widget.NewLabel("扫描当前项目和多实例中超过 30 天没有更新的 web/dist，从多实例中移除的项目遗留的 node_modules、server/dist 和 server/gva-server，" +
+		"面板编译缓存中项目目录已被删除的编译产物，以及 Go 模块缓存中面板里没有任何项目的 go.mod / go.work 引用的工具链（golang.org/toolchain）。" +
+		"正在管理的项目的依赖、server/dist 和 server/gva-server 不会列出；项目目录中的内容和工具链不会默认勾选，请先查看列表，确认不再需要后勾选。")
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(help, summary)
+	bottom := container.NewVBox(weeklyCheck, trashCheck, container.NewGridWithColumns(2, scanBtn, cleanBtn))
+	content := container.NewBorder(top, bottom, nil, nil, scroll)
+	d = dialog.NewCustomConfirm("🧹 空间清理", "💾 保存", "关闭", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := applySchedule(); err != nil {
+			l.showError(err, nil)
+		}
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(65), l.calcVH(80)))
+	d.Show()
+}
+
+// performCleanup 在任务中清理选中的内容（useTrash 为 true 时移入回收站，同时彻底删除过期的回收站内容），完成后显示结果
+func (l *GVALauncher) performCleanup(items []cleanup.Item, useTrash bool) {
+	var freed int64
+	var errs []string
+	job := l.jobs.Submit("空间清理", func(ctx context.Context, j *jobs.Job) error {
+		if !useTrash {
+			freed, errs = cleanup.Clean(items, nil)
+		} else {
+			if n, err := l.trash.PurgeExpired(time.Now()); err != nil {
+				j.Logf("删除过期的回收站内容失败: %v", err)
+			} else if n > 0 {
+				j.Logf("已彻底删除 %d 次过期的清理", n)
+			}
+			bin := l.trash.Begin("空间清理")
+			freed, errs = cleanup.Clean(items, bin.Move)
+			if err := bin.Commit(); err != nil {
+				errs = append(errs, "记录回收站失败: "+err.Error())
+			}
+		}
+		j.Logf("已清理 %s", sysutil.FormatSize(freed))
+		if len(errs) > 0 {
+			return fmt.Errorf("部分内容清理失败:\n%s", strings.Join(errs, "\n"))
+		}
+		return nil
+	})
+	l.waitJob(job, "🧹 空间清理", "正在清理...", func(err error) {
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			msg := fmt.Sprintf("✅ 已清理 %s", sysutil.FormatSize(freed))
+			if useTrash {
+				msg += "\n\n清理的内容已移入回收站，误清理时可在「♻️ 回收站」中恢复"
+			}
+			dialog.ShowInformation("清理完成", msg, l.window)
+		}
+		// 已清理的内容从报告中去掉
+		if l.cleanupReport != nil {
+			var rest []cleanup.Item
+			for _, item := range l.cleanupReport.Items {
+				if !containsItem(items, item) {
+					rest = append(rest, item)
+				}
+			}
+			l.cleanupReport.Items = rest
+		}
+	})
+}
+
+// containsItem items 中是否有与 item 主要路径相同的一项
+func containsItem(items []cleanup.Item, item cleanup.Item) bool {
+	for _, other := range items {
+		if other.Paths[0] == item.Paths[0] {
+			return true
+		}
+	}
+	return false
+}
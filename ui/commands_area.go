@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/codegen"
+	"gva-launcher/config"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+)
+
+// createCommandsArea 创建项目命令区域：项目自定义命令（.gvapanel-commands.json）各显示为一个按钮
+func (l *GVALauncher) createCommandsArea() *fyne.Container {
+	editBtn := widget.NewButton("⚙️ 编辑", l.showCommandsDialog)
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("🧩 项目命令", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			layout.NewSpacer(),
+			editBtn,
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	l.commandsHint = widget.NewLabel("")
+	l.commandsHint.Wrapping = fyne.TextWrapWord
+	l.commandsBox = container.NewGridWithColumns(3)
+	l.generatorsBox = container.NewHBox()
+	l.refreshProjectCommands()
+
+	return container.NewVBox(titleBox, l.commandsHint, l.commandsBox, l.generatorsBox)
+}
+
+// refreshProjectCommands 读取当前项目的自定义命令并重建按钮，同时重新识别后端的生成命令（切换根目录、保存命令后调用）
+func (l *GVALauncher) refreshProjectCommands() {
+	if l.commandsBox == nil {
+		return
+	}
+	l.commandsBox.Objects = nil
+
+	commands, err := config.LoadProjectCommands(l.project.Root)
+	switch {
+	case !l.project.IsSet():
+		l.commandsHint.SetText("请先指定 GVA 根目录")
+	case err != nil:
+		l.commandsHint.SetText("❌ " + err.Error())
+	case len(commands) == 0:
+		l.commandsHint.SetText("点击「⚙️ 编辑」把团队常用的命令（例如生成代码、同步字典）添加为按钮，" +
+			"保存在根目录的 " + config.ProjectCommandsFile + " 中，可提交到仓库与团队共享")
+	default:
+		l.commandsHint.SetText("")
+	}
+	if l.commandsHint.Text == "" {
+		l.commandsHint.Hide()
+	} else {
+		l.commandsHint.Show()
+	}
+
+	for _, c := range commands {
+		c := c
+		l.commandsBox.Add(widget.NewButton("▶ "+c.Name, func() {
+			l.confirmProjectCommand(c)
+		}))
+	}
+	l.commandsBox.Refresh()
+	l.detectGenerators()
+}
+
+// detectGenerators 在后台识别后端用到的生成命令（go generate、wire、swag init），识别后显示在自定义命令下方
+func (l *GVALauncher) detectGenerators() {
+	if !l.project.IsSet() {
+		l.renderGenerators(nil)
+		return
+	}
+	serverDir := l.project.ServerDir()
+	l.background("识别生成命令", func(context.Context) func() {
+		// server 目录不存在等情况不显示
+		gens, _ := codegen.Detect(serverDir)
+		return func() {
+			if l.project.ServerDir() == serverDir {
+				l.renderGenerators(gens)
+			}
+		}
+	})
+}
+
+// renderGenerators 把识别到的生成命令显示为按钮，多个时另有按建议顺序全部执行的按钮
+func (l *GVALauncher) renderGenerators(gens []codegen.Generator) {
+	box := l.generatorsBox
+	box.Objects = nil
+	if len(gens) == 0 {
+		box.Hide()
+		return
+	}
+	box.Add(widget.NewLabel("🔧 代码生成:"))
+	for _, g := range gens {
+		box.Add(widget.NewButton("▶ "+g.Name, func() {
+			l.runGenerators("🔧 "+g.Name, []codegen.Generator{g})
+		}))
+	}
+	if len(gens) > 1 {
+		box.Add(widget.NewButton("⏩ 全部执行", func() {
+			l.runGenerators("🔧 全部生成", gens)
+		}))
+	}
+	box.Add(widget.NewButton("ℹ️", func() {
+		l.showGeneratorsInfo(gens)
+	}))
+	box.Show()
+	box.Refresh()
+}
+
+// runGenerators 通过任务队列在 server/ 中依次执行生成命令，结束后显示输出
+func (l *GVALauncher) runGenerators(title string, gens []codegen.Generator) {
+	var commands []string
+	for _, g := range gens {
+		commands = append(commands, g.Command)
+	}
+	job := l.jobs.Submit("代码生成", func(ctx context.Context, j *jobs.Job) error {
+		return launcher.RunGenerators(ctx, l.project, gens, j)
+	})
+	l.waitJob(job, title, "正在执行: "+strings.Join(commands, " && "), func(err error) {
+		if errors.Is(err, jobs.ErrCanceled) {
+			return
+		}
+		l.showJobLog(job)
+	})
+}
+
+// showGeneratorsInfo 显示每个生成命令的命令行、识别依据和工具的安装命令
+func (l *GVALauncher) showGeneratorsInfo(gens []codegen.Generator) {
+	var lines []string
+	for i, g := range gens {
+		line := fmt.Sprintf("%d. %s\n   识别依据: %s", i+1, g.Command, g.Reason)
+		if g.Install != "" {
+			line += "\n   安装 " + g.Tool + ": " + g.Install
+		}
+		lines = append(lines, line)
+	}
+	text := widget.NewLabel(strings.Join(lines, "\n\n"))
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	help := widget.NewLabel("根据 server/ 中的源码自动识别：//go:generate 指令、wire 的注入器（wireinject 构建标签）和 swag 的接口文档注释（@title）。" +
+		"拉取代码后点击「⏩ 全部执行」按列出的顺序重新生成，输出记录在任务中心。")
+	help.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("🔧 代码生成", "关闭", container.NewVBox(help, text), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
+
+// confirmProjectCommand 显示要执行的命令，确认后执行（命令文件可能来自仓库，执行前让用户看到完整命令行）
+func (l *GVALauncher) confirmProjectCommand(c config.ProjectCommand) {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	message := fmt.Sprintf("在 %s/ 中执行：\n\n%s", c.Dir, c.Command)
+	dialog.ShowConfirm("▶ "+c.Name, message, func(ok bool) {
+		if ok {
+			l.runProjectCommand(c)
+		}
+	}, l.window)
+}
+
+// runProjectCommand 通过任务队列执行自定义命令，结束后显示命令的输出
+func (l *GVALauncher) runProjectCommand(c config.ProjectCommand) {
+	job := l.jobs.Submit("命令 "+c.Name, func(ctx context.Context, j *jobs.Job) error {
+		return launcher.RunProjectCommand(ctx, l.project, c, j)
+	})
+	l.waitJob(job, "▶ "+c.Name, "正在执行: "+c.Command, func(err error) {
+		if errors.Is(err, jobs.ErrCanceled) {
+			return
+		}
+		l.showJobLog(job)
+	})
+}
+
+// commandRow 命令编辑对话框中的一行
+type commandRow struct {
+	nameEntry    *widget.Entry
+	dirSelect    *widget.Select
+	commandEntry *widget.Entry
+}
+
+// showCommandsDialog 编辑当前项目的自定义命令
+func (l *GVALauncher) showCommandsDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	commands, err := config.LoadProjectCommands(l.project.Root)
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+
+	var rows []*commandRow
+	rowsBox := container.NewVBox()
+
+	addRow := func(c config.ProjectCommand) {
+		row := &commandRow{
+			nameEntry:    widget.NewEntry(),
+			dirSelect:    widget.NewSelect([]string{config.CommandDirServer, config.CommandDirWeb}, nil),
+			commandEntry: widget.NewEntry(),
+		}
+		row.nameEntry.SetPlaceHolder("按钮名称，例如: 生成代码")
+		row.nameEntry.SetText(c.Name)
+		row.dirSelect.SetSelected(c.Dir)
+		row.commandEntry.SetPlaceHolder("例如: go generate ./... 或 npm run lint")
+		row.commandEntry.SetText(c.Command)
+		rows = append(rows, row)
+
+		var rowBox *fyne.Container
+		deleteBtn := widget.NewButton("🗑️", func() {
+			for i, r := range rows {
+				if r == row {
+					rows = append(rows[:i], rows[i+1:]...)
+					break
+				}
+			}
+			rowsBox.Remove(rowBox)
+		})
+		rowBox = container.NewVBox(
+			container.NewBorder(nil, nil, nil, deleteBtn, container.NewGridWithColumns(2, row.nameEntry, row.dirSelect)),
+			row.commandEntry,
+			widget.NewSeparator(),
+		)
+		rowsBox.Add(rowBox)
+	}
+
+	for _, c := range commands {
+		addRow(c)
+	}
+
+	addBtn := widget.NewButton("➕ 添加命令", func() {
+		addRow(config.ProjectCommand{Dir: config.CommandDirServer})
+	})
+
+	help := widget.NewLabel("每个命令在「🧩 项目命令」中显示为一个按钮，点击后在 server/ 或 web/ 下通过系统 shell 执行，" +
+		"输出记录在任务中心，执行结束后显示。命令保存在 " + config.ProjectCommandsPath(l.project.Root) + "，可提交到仓库与团队共享。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(rowsBox)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(80), l.calcVH(25)))
+
+	content := container.NewBorder(help, addBtn, nil, nil, scroll)
+
+	dialog.ShowCustomConfirm("🧩 项目命令", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		var list []config.ProjectCommand
+		for _, row := range rows {
+			c := config.ProjectCommand{
+				Name:    strings.TrimSpace(row.nameEntry.Text),
+				Dir:     row.dirSelect.Selected,
+				Command: strings.TrimSpace(row.commandEntry.Text),
+			}
+			if c.Name == "" && c.Command == "" {
+				continue
+			}
+			list = append(list, c)
+		}
+
+		if err := config.SaveProjectCommands(l.project.Root, list); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.refreshProjectCommands()
+		dialog.ShowInformation("成功", fmt.Sprintf("已保存 %d 个项目命令", len(list)), l.window)
+	}, l.window)
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/compose"
+	"gva-launcher/config"
+	"gva-launcher/jobs"
+)
+
+// showComposeDialog 本地依赖：用 docker compose 只运行 MySQL 和 Redis，后端和前端照常由面板启动，
+// 容器启动后把端口写入 config.yaml
+func (l *GVALauncher) showComposeDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg := l.config.Compose
+
+	mysqlPortEntry := widget.NewEntry()
+	mysqlPortEntry.SetText(strconv.Itoa(compose.DefaultMySQLPort))
+	if cfg.MySQLPort > 0 {
+		mysqlPortEntry.SetText(strconv.Itoa(cfg.MySQLPort))
+	}
+	redisPortEntry := widget.NewEntry()
+	redisPortEntry.SetText(strconv.Itoa(compose.DefaultRedisPort))
+	if cfg.RedisPort > 0 {
+		redisPortEntry.SetText(strconv.Itoa(cfg.RedisPort))
+	}
+	passEntry := widget.NewPasswordEntry()
+	passEntry.SetText(compose.DefaultMySQLPassword)
+	if cfg.MySQLPassword != "" {
+		passEntry.SetText(cfg.MySQLPassword)
+	}
+	databaseEntry := widget.NewEntry()
+	databaseEntry.SetText(compose.DefaultDatabase)
+	if cfg.Database != "" {
+		databaseEntry.SetText(cfg.Database)
+	}
+	writeMySQLCheck := widget.NewCheck("启动后把 MySQL 连接写入 config.yaml", nil)
+	writeMySQLCheck.SetChecked(!cfg.SkipMySQL)
+
+	// readSpec 读取表单并保存设置
+	readSpec := func() (compose.Spec, error) {
+		mysqlPort, err1 := strconv.Atoi(strings.TrimSpace(mysqlPortEntry.Text))
+		redisPort, err2 := strconv.Atoi(strings.TrimSpace(redisPortEntry.Text))
+		if err1 != nil || err2 != nil {
+			return compose.Spec{}, fmt.Errorf("端口需要是数字")
+		}
+		spec := compose.Spec{
+			MySQLPort:     mysqlPort,
+			RedisPort:     redisPort,
+			MySQLPassword: passEntry.Text,
+			Database:      strings.TrimSpace(databaseEntry.Text),
+		}
+		if err := spec.Validate(); err != nil {
+			return spec, err
+		}
+		l.config.Compose = config.Compose{
+			MySQLPort:     spec.MySQLPort,
+			RedisPort:     spec.RedisPort,
+			MySQLPassword: spec.MySQLPassword,
+			Database:      spec.Database,
+			SkipMySQL:     !writeMySQLCheck.Checked,
+		}
+		if err := l.saveConfig(); err != nil {
+			return spec, fmt.Errorf("保存配置失败: %w", err)
+		}
+		return spec, nil
+	}
+
+	upBtn := widget.NewButton("▶️ 启动依赖", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		spec, err := readSpec()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		root, writeMySQL := l.project.Root, writeMySQLCheck.Checked
+		job := l.jobs.Submit("启动本地依赖", func(ctx context.Context, j *jobs.Job) error {
+			if err := compose.Up(ctx, root, spec, j); err != nil {
+				return err
+			}
+			return compose.Apply(root, spec, writeMySQL)
+		})
+		l.waitJob(job, "🐬 本地依赖", "正在启动 MySQL 和 Redis 容器（首次启动需要拉取镜像）...", func(err error) {
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+			case err != nil:
+				l.showError(err, nil)
+			default:
+				l.loadRedisConfig()
+				db := spec.MySQL()
+				msg := fmt.Sprintf("MySQL: %s:%s\nRedis: %s\n\n", db.Path, db.Port, spec.RedisAddr())
+				if writeMySQL {
+					msg += "已写入 config.yaml，重启后端后生效"
+				} else {
+					msg += "已写入 Redis 配置。新建的空数据库请在前端的初始化页面填写上面的 MySQL 地址完成初始化"
+				}
+				dialog.ShowInformation("本地依赖已启动", msg, l.window)
+			}
+		})
+	})
+	downBtn := widget.NewButton("⏹️ 停止依赖", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		root := l.project.Root
+		job := l.jobs.Submit("停止本地依赖", func(ctx context.Context, j *jobs.Job) error {
+			return compose.Down(ctx, root, j)
+		})
+		l.waitJob(job, "🐬 本地依赖", "正在停止 MySQL 和 Redis 容器...", func(err error) {
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+			case err != nil:
+				l.showError(err, nil)
+			default:
+				dialog.ShowInformation("本地依赖已停止", "容器已删除，数据保存在数据卷中，下次启动时仍在", l.window)
+			}
+		})
+	})
+
+	help := widget.NewLabel("使用系统的 docker compose（需已安装并启动 Docker）只运行 MySQL 和 Redis，后端和前端仍在本机运行。" +
+		"compose 文件生成在 deploy/docker-compose/docker-compose.deps.yaml，端口只映射到 127.0.0.1。" +
+		"GVA 检测到已配置数据库时不会显示初始化页面，新建的空数据库请取消勾选写入 MySQL 连接，启动后在前端初始化。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("MySQL 端口", mysqlPortEntry),
+		widget.NewFormItem("Redis 端口", redisPortEntry),
+		widget.NewFormItem("root 密码", passEntry),
+		widget.NewFormItem("数据库名", databaseEntry),
+		widget.NewFormItem("", writeMySQLCheck),
+	)
+	d := dialog.NewCustom("🐬 本地依赖", "关闭", container.NewVBox(help, form, container.NewGridWithColumns(2, upBtn, downBtn)), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(50), 0))
+	d.Show()
+}
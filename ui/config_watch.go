@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/fswatch"
+)
+
+// watchProjectConfig 监听当前项目的 config.yaml 和前端 env 文件，文件变化时发布 ProjectChanged 事件，
+// 在面板外修改端口后立即更新显示，不再定时读取；切换项目时停止旧的监听
+func (l *GVALauncher) watchProjectConfig() {
+	if l.configWatch != nil {
+		l.configWatch()
+		l.configWatch = nil
+	}
+	if !l.project.IsSet() {
+		return
+	}
+	root := l.project.Root
+	files := []string{config.GVAConfigPath(root), config.EnvDevPath(root), filepath.Join(root, "web", ".env")}
+	ctx, cancel := context.WithCancel(l.supervisor.Context())
+	l.configWatch = cancel
+	l.supervisor.Go("监听项目配置", func(context.Context) {
+		fswatch.Watch(ctx, files, func() { l.bus.Publish(events.Event{Topic: events.ProjectChanged}) })
+	})
+}
+
+// projectConfigChanged 项目配置文件变化后重新读取端口，端口有变化时更新显示和项目端口登记。
+// 面板自己写入配置（修改端口、自动分配等）时端口已经更新，不重复处理；不改变启动、停止按钮的状态
+func (l *GVALauncher) projectConfigChanged() {
+	backendPort, frontendPort := l.project.Ports()
+	if backendPort == l.backendPort && frontendPort == l.frontendPort {
+		return
+	}
+	l.updatePortsFromGVAConfig()
+	l.services.Publish()
+}
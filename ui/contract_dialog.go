@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apicontract"
+	"gva-launcher/apperr"
+)
+
+// showContractDialog 接口契约：对比后端 swagger 文档与前端 web/src/api 中的调用，列出前端调用了但后端没有的接口和前端没有调用的接口
+func (l *GVALauncher) showContractDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	r, err := apicontract.Check(l.project.Root)
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+
+	summary := widget.NewLabel("")
+	summary.Wrapping = fyne.TextWrapWord
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapOff
+
+	show := func(r apicontract.Result) {
+		text := fmt.Sprintf("✅ 后端 %d 个接口，前端 %d 处调用，前端调用的接口后端都有提供", len(r.Backend), len(r.Calls))
+		if len(r.Unmatched) > 0 {
+			text = fmt.Sprintf("⚠️ 后端 %d 个接口，前端 %d 处调用，其中 %d 个接口后端没有提供", len(r.Backend), len(r.Calls), len(r.Unmatched))
+		}
+		summary.SetText(fmt.Sprintf("%s（%d 个后端接口前端没有调用）", text, len(r.Unused)))
+		output.SetText(apicontract.Report(r, 0))
+	}
+	show(r)
+
+	recheckBtn := widget.NewButton("🔄 重新对比", func() {
+		r, err := apicontract.Check(l.project.Root)
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		show(r)
+	})
+	copyBtn := widget.NewButton("📋 复制报告", func() {
+		l.copyToClipboard(output.Text, "接口契约报告")
+	})
+
+	help := widget.NewLabel("后端接口来自 swag init 生成的 server/docs/swagger.json（@Router 注释），前端调用来自 web/src/api 和插件 api 目录中 service({ url, method }) 的写法，" +
+		"没有写 method 时按 GET。路径参数统一比较，查询参数忽略。代码生成或修改接口后请先重新执行 swag init。")
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(summary, widget.NewSeparator())
+	bottom := container.NewVBox(help, container.NewHBox(recheckBtn, copyBtn))
+	d := dialog.NewCustom("🤝 接口契约", "关闭", container.NewBorder(top, bottom, nil, nil, output), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(65), l.calcVH(75)))
+	d.Show()
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// statusKind 状态标记的种类
+type statusKind int
+
+const (
+	statusRunning statusKind = iota // 运行中
+	statusStopped                   // 已停止
+	statusOK                        // 正常
+	statusFailed                    // 失败 / 缺失
+	statusWarning                   // 部分异常
+	statusIdle                      // 未运行 / 未检测
+)
+
+// statusMarks 每种状态的标记：彩色 emoji 与形状不同的单色符号（高对比度模式下使用，不依赖颜色区分）
+var statusMarks = map[statusKind][2]string{
+	statusRunning: {"✅", "▶"},
+	statusStopped: {"🔴", "■"},
+	statusOK:      {"✅", "✔"},
+	statusFailed:  {"❌", "✘"},
+	statusWarning: {"⚠️", "▲"},
+	statusIdle:    {"⚪", "○"},
+}
+
+// statusBadge 状态标记加文字，例如「✅ 运行中」；高对比度模式下为方括号徽标「[▶ 运行中]」
+func (l *GVALauncher) statusBadge(kind statusKind, text string) string {
+	marks := statusMarks[kind]
+	if l.config.HighContrast {
+		return "[" + marks[1] + " " + text + "]"
+	}
+	return marks[0] + " " + text
+}
+
+// applyContrast 按配置切换高对比度主题
+func (l *GVALauncher) applyContrast(app fyne.App) {
+	if l.config.HighContrast {
+		app.Settings().SetTheme(highContrastTheme{})
+	} else {
+		app.Settings().SetTheme(theme.DefaultTheme())
+	}
+}
+
+// setHighContrast 开关高对比度模式：保存配置，切换主题并刷新各区域的状态文字
+func (l *GVALauncher) setHighContrast(on bool) error {
+	l.config.HighContrast = on
+	if err := l.saveConfig(); err != nil {
+		return err
+	}
+	l.applyContrast(fyne.CurrentApp())
+	l.updateServiceStatus()
+	l.renderTunnelStatus()
+	if l.project.IsSet() {
+		l.checkDependencies(nil)
+	}
+	return nil
+}
+
+// highContrastTheme 高对比度主题：黑底白字，强调色为黄色，边框和分隔线为白色（字体、图标和尺寸沿用默认主题）
+type highContrastTheme struct{}
+
+var (
+	hcBlack  = color.NRGBA{A: 0xff}
+	hcWhite  = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	hcYellow = color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff}
+	hcCyan   = color.NRGBA{R: 0x00, G: 0xe5, B: 0xff, A: 0xff}
+	hcGray   = color.NRGBA{R: 0xb0, G: 0xb0, B: 0xb0, A: 0xff}
+	hcDark   = color.NRGBA{R: 0x1a, G: 0x1a, B: 0x1a, A: 0xff}
+)
+
+// Color 高对比度配色（未列出的颜色使用默认主题的深色配色）
+func (highContrastTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	switch name {
+	case theme.ColorNameBackground, theme.ColorNameInputBackground, theme.ColorNameMenuBackground,
+		theme.ColorNameOverlayBackground, theme.ColorNameHeaderBackground:
+		return hcBlack
+	case theme.ColorNameForeground, theme.ColorNameInputBorder, theme.ColorNameSeparator,
+		theme.ColorNameForegroundOnError, theme.ColorNameForegroundOnSuccess:
+		return hcWhite
+	case theme.ColorNameButton, theme.ColorNameDisabledButton:
+		return hcDark
+	case theme.ColorNamePrimary, theme.ColorNameFocus, theme.ColorNameWarning:
+		return hcYellow
+	case theme.ColorNameForegroundOnPrimary, theme.ColorNameForegroundOnWarning:
+		return hcBlack
+	case theme.ColorNameHyperlink:
+		return hcCyan
+	case theme.ColorNameDisabled, theme.ColorNamePlaceHolder:
+		return hcGray
+	case theme.ColorNameSelection, theme.ColorNameHover, theme.ColorNamePressed:
+		return color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0x66}
+	}
+	return theme.DefaultTheme().Color(name, theme.VariantDark)
+}
+
+// Font 沿用默认主题
+func (highContrastTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+// Icon 沿用默认主题
+func (highContrastTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+// Size 沿用默认主题
+func (highContrastTheme) Size(name fyne.ThemeSizeName) float32 {
+	return theme.DefaultTheme().Size(name)
+}
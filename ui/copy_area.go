@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"net"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/services"
+)
+
+// createCopyArea 创建快捷复制区域（数据库 DSN、配置文件路径、日志目录等）
+func (l *GVALauncher) createCopyArea() *fyne.Container {
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("📋 快捷复制", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	// 每个按钮点击时才读取最新值，切换目录后无需刷新
+	dsnBtn := widget.NewButton("🗄️ 数据库 DSN", func() {
+		gvaConfig, err := l.project.ReadConfig()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		dsn, err := gvaConfig.DatabaseDSN()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.copyToClipboard(dsn, "数据库 DSN")
+	})
+	serverConfigBtn := widget.NewButton("📄 后端配置文件", func() {
+		l.copyPathToClipboard(l.project.ConfigPath(), "后端配置文件路径")
+	})
+	envBtn := widget.NewButton("📄 前端环境配置", func() {
+		l.copyPathToClipboard(config.EnvDevPath(l.project.Root), "前端环境配置路径")
+	})
+	panelConfigBtn := widget.NewButton("⚙️ 面板配置文件", func() {
+		l.copyToClipboard(config.Path(), "面板配置文件路径")
+	})
+	logDirBtn := widget.NewButton("📜 后端日志目录", func() {
+		l.copyPathToClipboard(config.BackendLogDir(l.project.Root), "后端日志目录")
+	})
+
+	// 使用 GridWithColumns 让按钮平均分配宽度
+	buttonBox := container.NewGridWithColumns(3,
+		dsnBtn,
+		serverConfigBtn,
+		envBtn,
+		panelConfigBtn,
+		logDirBtn,
+	)
+
+	return container.NewVBox(
+		titleBox,
+		buttonBox,
+	)
+}
+
+// ========================================
+// 剪贴板辅助函数
+// ========================================
+
+// copyToClipboard 复制内容到剪贴板并提示（what 为提示中显示的内容名称）
+func (l *GVALauncher) copyToClipboard(content string, what string) {
+	l.window.Clipboard().SetContent(content)
+	dialog.ShowInformation("成功", what+"已复制到剪贴板", l.window)
+}
+
+// copyPathToClipboard 复制文件/目录路径到剪贴板（路径为空时提示先指定目录）
+func (l *GVALauncher) copyPathToClipboard(path string, what string) {
+	if path == "" {
+		dialog.ShowInformation("提示", "请先指定 GVA 根目录", l.window)
+		return
+	}
+	l.copyToClipboard(path, what)
+}
+
+// getFrontendURL 获取前端访问地址（使用局域网主机名或IP，开启本地 HTTPS 时为 https；生产模式下页面由后端提供）
+func (l *GVALauncher) getFrontendURL() string {
+	if l.config.ProductionMode {
+		return l.getBackendURL()
+	}
+	scheme := "http"
+	if l.httpsEnabled {
+		scheme = "https"
+	}
+	return services.HostURL(scheme, l.lanHost(), l.frontendPort)
+}
+
+// getBackendURL 获取后端访问地址（使用局域网主机名或IP）
+func (l *GVALauncher) getBackendURL() string {
+	return services.HostURL("http", l.lanHost(), l.backendPort)
+}
+
+// lanHost 访问地址中使用的主机：设置了主机名时使用主机名，否则使用本机局域网IP
+func (l *GVALauncher) lanHost() string {
+	if l.config.Hostname != "" {
+		return l.config.Hostname
+	}
+	return services.GetLocalIP()
+}
+
+// serverURL 面板内置服务（代理、状态导出）的访问地址；监听 0.0.0.0 或 [::] 时使用 lanHost
+func (l *GVALauncher) serverURL(addr string) string {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return ""
+	}
+	port, _ := strconv.Atoi(portStr)
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = l.lanHost()
+	}
+	return services.HostURL("http", host, port)
+}
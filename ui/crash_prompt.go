@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/crash"
+)
+
+// checkCrashReports 上次运行留下崩溃报告时，询问用户查看报告或提交 Issue
+// 报告不会自动上传，提交时只是在浏览器中打开预填了报告内容的 Issue 页面
+func (l *GVALauncher) checkCrashReports() {
+	pending := crash.Pending()
+	if len(pending) == 0 {
+		return
+	}
+	latest := pending[len(pending)-1]
+
+	info := widget.NewLabel(fmt.Sprintf(
+		"面板上次运行时发生了崩溃（共 %d 份报告），报告已保存在本地:\n\n%s\n\n"+
+			"报告只包含面板版本、系统信息和调用栈，不会自动上传。提交 Issue 时会在浏览器中打开预填的页面（疑似密码、密钥会替换为 ******），可在提交前修改内容。",
+		len(pending), latest))
+	info.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	dismissAll := func() {
+		for _, p := range pending {
+			crash.Dismiss(p)
+		}
+		d.Hide()
+	}
+
+	openBtn := widget.NewButton("📄 打开报告", func() {
+		fyne.CurrentApp().OpenURL(fileURL(latest))
+	})
+	folderBtn := widget.NewButton("📂 打开目录", func() {
+		fyne.CurrentApp().OpenURL(fileURL(filepath.Dir(latest)))
+	})
+	submitBtn := widget.NewButton("🐞 提交 Issue", func() {
+		link, err := crash.IssueLink(latest)
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if u, err := url.Parse(link); err == nil {
+			fyne.CurrentApp().OpenURL(u)
+		}
+		dismissAll()
+	})
+	ignoreBtn := widget.NewButton("忽略", dismissAll)
+
+	content := container.NewVBox(
+		info,
+		container.NewGridWithColumns(4, openBtn, folderBtn, submitBtn, ignoreBtn),
+	)
+	d = dialog.NewCustomWithoutButtons("⚠️ 崩溃报告", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(85), 0))
+	d.Show()
+}
+
+// fileURL 把本地路径转换为 file:// 地址（Windows 盘符路径前补 /）
+func fileURL(path string) *url.URL {
+	p := filepath.ToSlash(path)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return &url.URL{Scheme: "file", Path: p}
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/dbsnapshot"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+)
+
+// demoLoginPath GVA 前端登录页路由
+const demoLoginPath = "/#/login"
+
+// demoSnapshotStatus 当前项目演示快照的说明文字
+func (l *GVALauncher) demoSnapshotStatus() string {
+	info, err := dbsnapshot.Load(l.project.DemoSnapshotDir())
+	switch {
+	case os.IsNotExist(err):
+		return "还没有保存快照：先把数据库准备成上课需要的状态，再点击「保存快照」"
+	case err != nil:
+		return "⚠️ " + err.Error()
+	}
+	return fmt.Sprintf("快照: %s 数据库 %s，保存于 %s（%.1f MB）",
+		info.DbType, info.Dbname, info.Created.Format("2006-01-02 15:04"), float64(info.Size)/(1<<20))
+}
+
+// showDemoDialog 显示演示模式：保存数据库快照，一键还原数据库、重启服务并打开登录页
+func (l *GVALauncher) showDemoDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	help := widget.NewLabel("适合每节课重复同样演示环境的场景：先保存一次数据库快照，之后每次上课前点击「一键重置」，" +
+		"面板会停止服务、把数据库还原到快照、重新启动前后端，并在浏览器中打开登录页。")
+	help.Wrapping = fyne.TextWrapWord
+
+	status := widget.NewLabel(l.demoSnapshotStatus())
+	status.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+
+	snapshotBtn := widget.NewButton("📸 保存快照", func() {
+		progress := dialog.NewProgressInfinite("演示模式", "正在导出数据库...", l.window)
+		progress.Show()
+		l.background("保存演示快照", func(context.Context) func() {
+			_, err := l.project.TakeDemoSnapshot()
+			return func() {
+				progress.Hide()
+				if err != nil {
+					l.showError(err, nil)
+					return
+				}
+				status.SetText(l.demoSnapshotStatus())
+			}
+		})
+	})
+
+	resetBtn := widget.NewButton("🎓 一键重置", func() {
+		d.Hide()
+		l.resetDemo()
+	})
+	resetBtn.Importance = widget.HighImportance
+
+	content := container.NewVBox(
+		help,
+		widget.NewSeparator(),
+		status,
+		container.NewGridWithColumns(2, snapshotBtn, resetBtn),
+	)
+
+	d = dialog.NewCustom("🎓 演示模式", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(40), l.calcVH(30)))
+	d.Show()
+}
+
+// resetDemo 通过任务队列执行演示重置，完成后在浏览器中打开前端登录页
+func (l *GVALauncher) resetDemo() {
+	if !l.requireToolchain() {
+		return
+	}
+
+	job := l.jobs.Submit("演示重置", func(ctx context.Context, j *jobs.Job) error {
+		return launcher.ResetDemo(ctx, l.project, l.services, j.Logf)
+	})
+
+	l.waitJob(job, "🎓 演示模式", "正在还原数据库并重启服务...", func(err error) {
+		l.checkServiceStatus()
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			if u, err := url.Parse(l.getFrontendURL() + demoLoginPath); err == nil {
+				fyne.CurrentApp().OpenURL(u)
+			}
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/depreport"
+	"gva-launcher/jobs"
+	"gva-launcher/mailer"
+	"gva-launcher/scheduler"
+)
+
+// depReportTaskName 每周生成周报的定时任务名
+const depReportTaskName = "依赖周报"
+
+// depReportAction 定时任务动作：生成依赖周报，在面板中提醒，开启邮件时发送到收件人
+func (l *GVALauncher) depReportAction() scheduler.Action {
+	return scheduler.Action{
+		ID:    depreport.ActionID,
+		Label: "依赖周报",
+		Run: func(ctx context.Context, j *jobs.Job, task config.ScheduledTask) error {
+			if !l.project.IsSet() {
+				return apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录")
+			}
+			j.Logf("正在检查过期依赖、前端漏洞和 GVA 新版本...")
+			r := depreport.Generate(ctx, l.project.Root, l.config.DepReport.KnownVulns)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			j.Write([]byte(r.Text()))
+			l.post("依赖健康报告", func() { l.onDepReport(r) })
+
+			if !l.config.DepReport.Email {
+				return nil
+			}
+			j.Logf("发送邮件到 %s", strings.Join(l.config.SMTP.To, ", "))
+			return mailer.Send(smtpServer(l.config.SMTP), "GVA 依赖周报: "+r.Summary(), r.Text())
+		},
+	}
+}
+
+// smtpServer 面板配置中的发信服务器
+func smtpServer(s config.SMTP) mailer.Server {
+	return mailer.Server{Host: s.Host, Port: s.Port, Username: s.Username, Password: s.Password, From: s.From, To: s.To}
+}
+
+// onDepReport 周报生成完成：记住本次的漏洞（下次只列出新漏洞），显示提醒并发送系统通知
+func (l *GVALauncher) onDepReport(r depreport.Report) {
+	l.depReport = &r
+	l.depReportUnread = true
+	l.config.DepReport.KnownVulns = r.VulnKeys()
+	if err := l.saveConfig(); err != nil {
+		l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+	}
+	l.renderDepReport()
+	fyne.CurrentApp().SendNotification(fyne.NewNotification("GVA 依赖周报", r.Summary()))
+}
+
+// renderDepReport 有未查看的周报时在根目录区域显示提醒按钮
+func (l *GVALauncher) renderDepReport() {
+	if l.depReportBtn == nil {
+		return
+	}
+	if l.depReport == nil || !l.depReportUnread {
+		l.depReportBtn.Hide()
+		return
+	}
+	l.depReportBtn.SetText("📰 依赖周报: " + l.depReport.Summary())
+	l.depReportBtn.Show()
+}
+
+// depReportTask 生成周报的定时任务（没有时返回 -1）
+func (l *GVALauncher) depReportTask() int {
+	for i, task := range l.config.Schedules {
+		if task.Action == depreport.ActionID {
+			return i
+		}
+	}
+	return -1
+}
+
+// showDepReportDialog 依赖周报：查看最近一次的周报，设置每周生成和邮件发送
+func (l *GVALauncher) showDepReportDialog() {
+	l.depReportUnread = false
+	l.renderDepReport()
+
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapOff
+	output.SetMinRowsVisible(12)
+	if l.depReport != nil {
+		output.SetText(l.depReport.Text())
+	} else {
+		output.SetPlaceHolder("本次运行面板后还没有生成过周报，点击「立即生成」或等待定时任务执行")
+	}
+
+	weeklyCheck := widget.NewCheck("每周一 9:00 自动生成（可在「⏰ 定时任务」中修改时间）", nil)
+	if i := l.depReportTask(); i >= 0 {
+		weeklyCheck.SetChecked(l.config.Schedules[i].Enabled)
+	}
+	emailCheck := widget.NewCheck("生成后发送邮件", nil)
+	emailCheck.SetChecked(l.config.DepReport.Email)
+
+	cfg := l.config.SMTP
+	hostEntry := widget.NewEntry()
+	hostEntry.SetPlaceHolder("例如: smtp.qq.com")
+	hostEntry.SetText(cfg.Host)
+	portEntry := widget.NewEntry()
+	portEntry.SetPlaceHolder(fmt.Sprintf("%d（465 使用 SSL）", mailer.DefaultPort))
+	if cfg.Port > 0 {
+		portEntry.SetText(strconv.Itoa(cfg.Port))
+	}
+	userEntry := widget.NewEntry()
+	userEntry.SetText(cfg.Username)
+	passEntry := widget.NewPasswordEntry()
+	passEntry.SetPlaceHolder("密码或授权码")
+	passEntry.SetText(cfg.Password)
+	fromEntry := widget.NewEntry()
+	fromEntry.SetPlaceHolder("留空使用用户名")
+	fromEntry.SetText(cfg.From)
+	toEntry := widget.NewEntry()
+	toEntry.SetPlaceHolder("多个收件人用逗号分隔")
+	toEntry.SetText(strings.Join(cfg.To, ", "))
+
+	// readSMTP 读取表单中的发信设置
+	readSMTP := func() (config.SMTP, error) {
+		s := config.SMTP{
+			Host:     strings.TrimSpace(hostEntry.Text),
+			Username: strings.TrimSpace(userEntry.Text),
+			Password: passEntry.Text,
+			From:     strings.TrimSpace(fromEntry.Text),
+			To:       mailer.ParseList(toEntry.Text),
+		}
+		if text := strings.TrimSpace(portEntry.Text); text != "" {
+			port, err := strconv.Atoi(text)
+			if err != nil || port <= 0 || port > 65535 {
+				return s, fmt.Errorf("SMTP 端口需要是 1-65535 之间的整数")
+			}
+			s.Port = port
+		}
+		return s, nil
+	}
+
+	// apply 保存表单，并按勾选添加或停用每周的定时任务（保留用户修改过的执行时间）
+	apply := func() error {
+		s, err := readSMTP()
+		if err != nil {
+			return err
+		}
+		if emailCheck.Checked {
+			if err := smtpServer(s).Validate(); err != nil {
+				return err
+			}
+		}
+		l.config.SMTP = s
+		l.config.DepReport.Email = emailCheck.Checked
+		if i := l.depReportTask(); i >= 0 {
+			l.config.Schedules[i].Enabled = weeklyCheck.Checked
+		} else if weeklyCheck.Checked {
+			l.config.Schedules = append(l.config.Schedules, config.ScheduledTask{
+				Name:    depReportTaskName,
+				Cron:    depreport.WeeklyCron,
+				Action:  depreport.ActionID,
+				Enabled: true,
+			})
+		}
+		if err := l.saveConfig(); err != nil {
+			return fmt.Errorf("保存配置失败: %w", err)
+		}
+		return nil
+	}
+
+	generateBtn := widget.NewButton("▶️ 立即生成", func() {
+		if err := apply(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		task := config.ScheduledTask{Name: depReportTaskName, Action: depreport.ActionID}
+		if i := l.depReportTask(); i >= 0 {
+			task = l.config.Schedules[i]
+		}
+		if l.scheduler.RunNow(task) == nil {
+			dialog.ShowInformation("提示", "周报正在生成中", l.window)
+			return
+		}
+		dialog.ShowInformation("已开始", "已提交到任务中心，生成完成后在面板顶部提醒", l.window)
+	})
+	testBtn := widget.NewButton("✉️ 发送测试邮件", func() {
+		s, err := readSMTP()
+		if err == nil {
+			err = smtpServer(s).Validate()
+		}
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		progress := dialog.NewCustomWithoutButtons("✉️ 发送测试邮件", widget.NewLabel("正在发送..."), l.window)
+		progress.Show()
+		l.background("发送测试邮件", func(context.Context) func() {
+			err := mailer.Send(smtpServer(s), "GVA 面板测试邮件", "这是一封测试邮件，收到说明依赖周报可以通过邮件发送。")
+			return func() {
+				progress.Hide()
+				if err != nil {
+					l.showError(err, nil)
+					return
+				}
+				dialog.ShowInformation("已发送", "测试邮件已发送到:\n"+strings.Join(s.To, "\n"), l.window)
+			}
+		})
+	})
+
+	help := widget.NewLabel("周报包括前后端有新版本的直接依赖（go list -m -u、npm outdated）、上次周报之后新出现的前端漏洞（npm audit）和上游 GVA 新版本，" +
+		"需要访问模块代理、npm 镜像源和 GitHub。生成后在面板顶部提醒，开启邮件时同时发送到收件人。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("SMTP 服务器", hostEntry),
+		widget.NewFormItem("端口", portEntry),
+		widget.NewFormItem("用户名", userEntry),
+		widget.NewFormItem("密码", passEntry),
+		widget.NewFormItem("发件人", fromEntry),
+		widget.NewFormItem("收件人", toEntry),
+	)
+	bottom := container.NewVBox(weeklyCheck, emailCheck, form, container.NewGridWithColumns(2, generateBtn, testBtn))
+	content := container.NewBorder(help, bottom, nil, nil, output)
+	d := dialog.NewCustomConfirm("📰 依赖周报", "💾 保存", "关闭", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := apply(); err != nil {
+			l.showError(err, nil)
+		}
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(65), l.calcVH(85)))
+	d.Show()
+}
@@ -0,0 +1,298 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/deploy"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+)
+
+// deployArchs 可选的服务器 CPU 架构（GOARCH）
+var deployArchs = []string{"amd64", "arm64"}
+
+// showDeployDialog 远程部署：交叉编译后端、构建前端后上传到登记的服务器上的新版本目录，
+// 再切换 current 链接；列出服务器上的版本，可一键回滚到上一个版本或切换到任意保留的版本
+func (l *GVALauncher) showDeployDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg := l.config.Deploy
+
+	var serverNames []string
+	for _, s := range l.config.Servers {
+		serverNames = append(serverNames, s.Name)
+	}
+	serverSelect := widget.NewSelect(serverNames, nil)
+	serverSelect.PlaceHolder = "在「🖥️ 远程服务器」中登记后选择"
+	if _, ok := config.FindServer(l.config.Servers, cfg.Server); ok {
+		serverSelect.SetSelected(cfg.Server)
+	}
+	dirEntry := widget.NewEntry()
+	dirEntry.SetPlaceHolder("例如: /opt/gva")
+	dirEntry.SetText(cfg.Dir)
+	archSelect := widget.NewSelect(deployArchs, nil)
+	archSelect.SetSelected(deployArchs[0])
+	for _, arch := range deployArchs {
+		if arch == cfg.Arch {
+			archSelect.SetSelected(arch)
+		}
+	}
+	restartEntry := widget.NewEntry()
+	restartEntry.SetPlaceHolder("例如: sudo systemctl restart gva（留空则不执行）")
+	restartEntry.SetText(cfg.Restart)
+	keepEntry := widget.NewEntry()
+	keepEntry.SetPlaceHolder(strconv.Itoa(deploy.DefaultKeep))
+	if cfg.Keep > 0 {
+		keepEntry.SetText(strconv.Itoa(cfg.Keep))
+	}
+
+	// 部署后的健康检查
+	healthEntry := widget.NewEntry()
+	healthEntry.SetPlaceHolder("例如: https://admin.example.com/api/health（留空则不检查）")
+	healthEntry.SetText(cfg.HealthURL)
+	healthRemoteCheck := widget.NewCheck("在服务器上检查（通过 ssh 执行 curl，适合 http://127.0.0.1:8888/health 这类地址）", nil)
+	healthRemoteCheck.SetChecked(cfg.HealthRemote)
+	healthTimeoutEntry := widget.NewEntry()
+	healthTimeoutEntry.SetPlaceHolder(strconv.Itoa(int(deploy.DefaultHealthTimeout.Seconds())))
+	if cfg.HealthTimeout > 0 {
+		healthTimeoutEntry.SetText(strconv.Itoa(cfg.HealthTimeout))
+	}
+	autoRollbackCheck := widget.NewCheck("健康检查没有通过时自动回滚到上一个版本", nil)
+	autoRollbackCheck.SetChecked(cfg.AutoRollback)
+
+	// settings 读取表单并保存到面板配置
+	settings := func() (config.Deploy, config.Server, error) {
+		d := config.Deploy{
+			Server:       serverSelect.Selected,
+			Dir:          strings.TrimSpace(dirEntry.Text),
+			Arch:         archSelect.Selected,
+			Restart:      strings.TrimSpace(restartEntry.Text),
+			HealthURL:    strings.TrimSpace(healthEntry.Text),
+			HealthRemote: healthRemoteCheck.Checked,
+			AutoRollback: autoRollbackCheck.Checked,
+		}
+		if text := strings.TrimSpace(keepEntry.Text); text != "" {
+			keep, err := strconv.Atoi(text)
+			if err != nil || keep < 2 {
+				return d, config.Server{}, fmt.Errorf("保留的版本数需要是不小于 2 的整数（至少保留当前版本和上一个版本）")
+			}
+			d.Keep = keep
+		}
+		if text := strings.TrimSpace(healthTimeoutEntry.Text); text != "" {
+			seconds, err := strconv.Atoi(text)
+			if err != nil || seconds <= 0 {
+				return d, config.Server{}, fmt.Errorf("健康检查等待时间需要是正整数（秒）")
+			}
+			d.HealthTimeout = seconds
+		}
+		if err := deployHealth(d).Validate(); err != nil {
+			return d, config.Server{}, err
+		}
+		server, ok := config.FindServer(l.config.Servers, d.Server)
+		if !ok {
+			return d, server, fmt.Errorf("请选择部署到的服务器")
+		}
+		l.config.Deploy = d
+		if err := l.saveConfig(); err != nil {
+			return d, server, fmt.Errorf("保存配置失败: %w", err)
+		}
+		return d, server, nil
+	}
+
+	// withTarget 读取表单，解锁服务器使用的 SSH 密钥后回调部署目标；操作结束后调用 release 删除临时私钥
+	withTarget := func(done func(t deploy.Target, release func())) {
+		d, server, err := settings()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		t := deploy.Target{Host: server.Host, Port: server.Port, User: server.User, Dir: d.Dir, Restart: d.Restart, Keep: d.Keep}
+		if err := t.Validate(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if server.Key == "" {
+			done(t, func() {})
+			return
+		}
+		l.unlockSSHKey(server.Key, func(identity string, release func()) {
+			t.Identity = identity
+			done(t, release)
+		})
+	}
+
+	list := container.NewVBox(widget.NewLabel("点击「🔄 刷新」读取服务器上的版本"))
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	var refresh func()
+	var switchTo func(id string)
+
+	// render 显示服务器上的版本，current 指向的版本加上标记
+	render := func(r deploy.Releases) {
+		list.Objects = nil
+		if len(r.IDs) == 0 {
+			list.Add(widget.NewLabel("服务器上还没有部署过的版本"))
+		}
+		for _, id := range r.IDs {
+			text := id
+			if at, ok := deploy.ReleaseTime(id); ok {
+				text = at.Format(time.DateTime) + "  (" + deploy.ReleasesDir + "/" + id + ")"
+			}
+			if id == r.Current {
+				list.Add(container.NewHBox(widget.NewLabelWithStyle(l.statusBadge(statusRunning, text+"  当前版本"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})))
+				continue
+			}
+			btn := widget.NewButton("切换到此版本", func() { switchTo(id) })
+			list.Add(container.NewBorder(nil, nil, widget.NewLabel(l.statusBadge(statusIdle, text)), container.NewHBox(layout.NewSpacer(), btn)))
+		}
+		if r.Current == "" && len(r.IDs) > 0 {
+			status.SetText("⚠️ current 链接不存在或没有指向版本目录")
+		}
+		list.Refresh()
+	}
+
+	refresh = func() {
+		withTarget(func(t deploy.Target, release func()) {
+			status.SetText("正在读取服务器上的版本...")
+			l.background("读取部署版本", func(ctx context.Context) func() {
+				releases, err := deploy.List(ctx, t)
+				release()
+				return func() {
+					if err != nil {
+						status.SetText("❌ " + err.Error())
+						return
+					}
+					status.SetText(fmt.Sprintf("%s:%s 上有 %d 个版本", t.Host, t.Dir, len(releases.IDs)))
+					render(releases)
+				}
+			})
+		})
+	}
+
+	// runRemote 通过任务队列执行回滚或切换，完成后刷新版本列表
+	runRemote := func(title string, fn func(ctx context.Context, t deploy.Target, j *jobs.Job) (string, error)) {
+		withTarget(func(t deploy.Target, release func()) {
+			var id string
+			job := l.jobs.Submit(title, func(ctx context.Context, j *jobs.Job) error {
+				defer release()
+				var err error
+				id, err = fn(ctx, t, j)
+				return err
+			})
+			l.waitJob(job, title, "正在切换服务器上的版本...", func(err error) {
+				switch {
+				case errors.Is(err, jobs.ErrCanceled):
+				case err != nil:
+					l.showError(err, nil)
+				default:
+					dialog.ShowInformation(title, "current 已指向 "+deploy.ReleasesDir+"/"+id, l.window)
+				}
+				refresh()
+			})
+		})
+	}
+
+	switchTo = func(id string) {
+		dialog.ShowConfirm("切换版本", "把服务器上的 current 切换到 "+id+" 并执行重启命令？", func(ok bool) {
+			if !ok {
+				return
+			}
+			runRemote("切换版本", func(ctx context.Context, t deploy.Target, j *jobs.Job) (string, error) {
+				return id, deploy.Switch(ctx, t, id, j)
+			})
+		}, l.window)
+	}
+
+	deployBtn := widget.NewButton("🚢 部署新版本", func() {
+		if !l.ensureProjectOwner() || !l.requireServiceTool(launcher.ServiceBackend) || !l.requireServiceTool(launcher.ServiceFrontend) {
+			return
+		}
+		withTarget(func(t deploy.Target, release func()) {
+			arch := archSelect.Selected
+			health := deployHealth(l.config.Deploy)
+			var id string
+			job := l.jobs.Submit("远程部署", func(ctx context.Context, j *jobs.Job) error {
+				defer release()
+				var err error
+				id, err = l.builds.BuildAndDeploy(ctx, t, arch, health, j)
+				return err
+			})
+			l.waitJob(job, "🚢 远程部署", "正在构建并上传到 "+t.Host+"...", func(err error) {
+				switch {
+				case errors.Is(err, jobs.ErrCanceled):
+				case err != nil:
+					l.showError(err, nil)
+				default:
+					message := fmt.Sprintf("已部署版本 %s，%s/%s 已指向新版本；有问题时可一键回滚到上一个版本", id, t.Dir, deploy.CurrentLink)
+					if health.Enabled() {
+						message += "\n健康检查已通过: " + health.URL
+					}
+					dialog.ShowInformation("部署完成", message, l.window)
+				}
+				refresh()
+			})
+		})
+	})
+	rollbackBtn := widget.NewButton("↩️ 回滚到上一个版本", func() {
+		dialog.ShowConfirm("回滚", "把服务器上的 current 切回上一个版本并执行重启命令？", func(ok bool) {
+			if !ok {
+				return
+			}
+			runRemote("回滚部署", func(ctx context.Context, t deploy.Target, j *jobs.Job) (string, error) {
+				return deploy.Rollback(ctx, t, j)
+			})
+		}, l.window)
+	})
+	refreshBtn := widget.NewButton("🔄 刷新", refresh)
+
+	help := widget.NewLabel("为服务器交叉编译后端（Linux，关闭 cgo）并构建前端，通过系统的 ssh / scp 上传到部署目录下新的版本目录 " +
+		"releases/<时间>，解压完成后再原子地切换 current 链接，不会覆盖正在运行的文件。shared/ 中的文件（例如生产环境的 config.yaml）" +
+		"会链接到每个版本的 server/ 下；nginx 指向 current/web/dist，后端服务在 current/server 中运行。需已配置 SSH 密钥登录。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("服务器", serverSelect),
+		widget.NewFormItem("部署目录", dirEntry),
+		widget.NewFormItem("CPU 架构", archSelect),
+		widget.NewFormItem("重启命令", restartEntry),
+		widget.NewFormItem("保留版本数", keepEntry),
+		widget.NewFormItem("健康检查地址", healthEntry),
+		widget.NewFormItem("", healthRemoteCheck),
+		widget.NewFormItem("等待时间（秒）", healthTimeoutEntry),
+		widget.NewFormItem("", autoRollbackCheck),
+	)
+	buttons := container.NewHBox(deployBtn, rollbackBtn, layout.NewSpacer(), refreshBtn)
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(0, l.calcVH(25)))
+	top := container.NewVBox(help, form, buttons, status, widget.NewSeparator())
+	d := dialog.NewCustom("🚢 远程部署", "关闭", container.NewBorder(top, nil, nil, nil, scroll), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
+
+// deployHealth 部署设置中的健康检查
+func deployHealth(d config.Deploy) deploy.Health {
+	return deploy.Health{
+		URL:      d.HealthURL,
+		Remote:   d.HealthRemote,
+		Timeout:  time.Duration(d.HealthTimeout) * time.Second,
+		Rollback: d.AutoRollback,
+	}
+}
@@ -0,0 +1,340 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+	"gva-launcher/trash"
+)
+
+// createDependencyArea 创建依赖管理区域
+func (l *GVALauncher) createDependencyArea() *fyne.Container {
+	// 1. 标题装箱 + 底部边界线
+	titleBox := container.NewVBox(
+		container.NewHBox(
+			widget.NewLabelWithStyle("🔧 依赖管理", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		),
+		widget.NewSeparator(), // 底部边界线
+	)
+
+	// 2. 状态信息（直接使用Label）
+	// 缺少 go / npm 时的说明（检测到后才显示）
+	l.toolchainLabel = widget.NewLabel("")
+	l.toolchainLabel.Wrapping = fyne.TextWrapWord
+	l.toolchainLabel.Hide()
+	l.depStatusLabel = widget.NewLabel(l.statusBadge(statusIdle, "未检测"))
+	l.frontendDepLabel = widget.NewLabel("　　• 请先指定 GVA 根目录")
+	l.backendDepLabel = widget.NewLabel("")
+
+	// 4. 按钮行装箱（30vw + 4个Spacer）
+	l.checkDepsButton = widget.NewButton("🔍 检查依赖状态", func() {
+		l.checkDependencies(nil)
+	})
+	cleanCacheButton := widget.NewButton("🗑️ 清理缓存", func() {
+		l.cleanAllCache()
+	})
+	l.installDepsButton = widget.NewButton("📦 安装依赖", func() {
+		l.installDependencies()
+	})
+	doctorButton := widget.NewButton("🩺 前端体检", func() {
+		l.runFrontendDoctor()
+	})
+
+	// 使用 GridWithColumns 让按钮平均分配宽度
+	buttonBox := container.NewGridWithColumns(4,
+		l.checkDepsButton,
+		doctorButton,
+		cleanCacheButton,
+		l.installDepsButton,
+	)
+
+	// 3. 三行状态文字用GridWithRows均匀分配
+	statusGrid := container.NewGridWithRows(3,
+		l.depStatusLabel,
+		l.frontendDepLabel,
+		l.backendDepLabel,
+	)
+
+	// 自定义小间距（2px）
+	spacer1 := canvas.NewRectangle(color.Transparent)
+	spacer1.SetMinSize(fyne.NewSize(1, 2)) // 标题和状态之间2px
+
+	spacer2 := canvas.NewRectangle(color.Transparent)
+	spacer2.SetMinSize(fyne.NewSize(1, 2)) // 状态和按钮之间2px
+
+	// 使用 VBox 组合所有元素
+	return container.NewVBox(
+		titleBox,
+		spacer1,
+		l.toolchainLabel,
+		statusGrid, // 三行状态文字（均匀分配）
+		spacer2,
+		buttonBox,
+	)
+}
+
+// depBadge 一端依赖的安装状态，例如「✅ 前端依赖已安装」
+func (l *GVALauncher) depBadge(installed bool, side string) string {
+	if installed {
+		return l.statusBadge(statusOK, side+"依赖已安装")
+	}
+	return l.statusBadge(statusFailed, side+"依赖未安装")
+}
+
+// checkDependencies 在后台检查依赖状态并刷新显示，完成后调用 done（可为 nil），参数为工具链和前后端依赖是否齐全
+func (l *GVALauncher) checkDependencies(done func(ok bool)) {
+	if done == nil {
+		done = func(bool) {}
+	}
+	// 先检测 go / npm，缺失时禁用相关按钮并显示说明
+	l.detectToolchain(func(tc deps.Toolchain) {
+		if !l.project.IsSet() {
+			l.depStatusLabel.SetText(l.statusBadge(statusIdle, "未检测"))
+			l.frontendDepLabel.SetText("　　• 请先指定 GVA 根目录")
+			l.backendDepLabel.SetText("")
+			l.checkDepsButton.Disable()
+			l.installDepsButton.Disable()
+			done(false)
+			return
+		}
+
+		l.checkDepsButton.Enable()
+		if tc.Complete() {
+			l.installDepsButton.Enable()
+		}
+		l.background("检查依赖", func(context.Context) func() {
+			// 并发检查前后端依赖
+			status := l.deps.Check()
+			replaces, _ := l.deps.Replaces()
+			workspace := workspaceSummary(l.deps.Workspace(), replaces)
+			return func() {
+				l.renderDependencies(tc, status, workspace)
+				done(tc.Complete() && status.Frontend && status.Backend)
+			}
+		})
+	})
+}
+
+// renderDependencies 显示依赖检查的结果（workspace 为 go.work 和 replace 的说明，没有时为空）
+func (l *GVALauncher) renderDependencies(tc deps.Toolchain, status launcher.DependencyStatus, workspace string) {
+	switch {
+	case status.Frontend && status.Backend:
+		l.depStatusLabel.SetText(l.statusBadge(statusOK, "配置正常"))
+	case !status.Frontend && !status.Backend:
+		l.depStatusLabel.SetText(l.statusBadge(statusFailed, "依赖缺失"))
+	default:
+		l.depStatusLabel.SetText(l.statusBadge(statusWarning, "依赖部分缺失"))
+	}
+	l.frontendDepLabel.SetText("　　• " + l.depBadge(status.Frontend, "前端"))
+	l.backendDepLabel.SetText("　　• " + l.depBadge(status.Backend, "后端"))
+
+	if workspace != "" {
+		l.backendDepLabel.SetText(l.backendDepLabel.Text + workspace)
+	}
+
+	// 没有对应工具时检测结果不可信，改为说明原因
+	if !tc.HasNpm() {
+		l.frontendDepLabel.SetText("　　• ⚠️ 未检测到 npm，无法检测前端依赖")
+	}
+	if !tc.HasGo() {
+		l.backendDepLabel.SetText("　　• ⚠️ 未检测到 go，无法检测后端依赖")
+	}
+	if !tc.Complete() {
+		l.depStatusLabel.SetText("⚠️ 缺少 " + strings.Join(tc.Missing(), "、"))
+	}
+}
+
+// installDependencies 安装依赖
+func (l *GVALauncher) installDependencies() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.ensureProjectOwner() || !l.requireToolchain() {
+		return
+	}
+
+	// 内存不足时 npm install 容易被系统终止（树莓派等单板机），确认后再安装
+	if warning := deps.ReadMemory().NpmInstallWarning(); warning != "" && !l.deps.Check().Frontend {
+		dialog.ShowConfirm("⚠️ 内存不足", warning+"\n\n仍然安装依赖？", func(ok bool) {
+			if ok {
+				l.submitInstall()
+			}
+		}, l.window)
+		return
+	}
+	l.submitInstall()
+}
+
+// submitInstall 提交安装依赖任务并等待完成
+func (l *GVALauncher) submitInstall() {
+	// 从界面输入框读取镜像源地址
+	mirrorURL := strings.TrimSpace(l.frontendMirrorEntry.Text)
+	proxyURL := strings.TrimSpace(l.backendMirrorEntry.Text)
+
+	// 通过任务队列执行，可在任务中心查看进度、暂停、取消或重试
+	job := l.jobs.SubmitPausable("安装依赖", func(ctx context.Context, j *jobs.Job) error {
+		j.Logf("$ npm install（镜像: %s）", mirrorURL)
+		j.Logf("$ go mod download（GOPROXY: %s）", proxyURL)
+		if !l.deps.Check().Frontend {
+			defer watchInstallProgress(ctx, j, l.project.WebDir())()
+		}
+		return l.deps.Install(ctx, mirrorURL, proxyURL, j.Logf)
+	})
+
+	l.waitJob(job, "安装依赖", "正在安装依赖，请稍候...", func(err error) {
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			dialog.ShowInformation("成功", "依赖安装完成，完整性校验通过", l.window)
+		}
+
+		l.checkDependencies(nil)
+	})
+}
+
+// watchInstallProgress 每秒统计 node_modules 中已安装的包，作为安装任务的计数（显示速度）和进度
+// （按 package-lock.json 中的包数量估计，没有 lock 文件时只显示速度）；返回停止统计的函数
+func watchInstallProgress(ctx context.Context, j *jobs.Job, webDir string) (stop func()) {
+	expected := deps.ExpectedPackages(webDir)
+	ctx, stop = context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			installed := deps.InstalledPackages(webDir)
+			j.SetCount(float64(installed), "个包")
+			if expected > 0 {
+				// 后端依赖可能仍在下载，进度不显示为完成
+				j.SetProgress(min(float64(installed)/float64(expected), 0.99))
+			}
+		}
+	}()
+	return stop
+}
+
+// ========================================
+// 缓存清理功能
+// ========================================
+
+// cleanAllCache 清理所有缓存（主函数）
+func (l *GVALauncher) cleanAllCache() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	// 默认移入回收站，误确认时可以恢复
+	trashCheck := widget.NewCheck(fmt.Sprintf("移入回收站（%d 天内可在「♻️ 回收站」中恢复）", int(trash.Retention.Hours()/24)), nil)
+	trashCheck.SetChecked(true)
+	message := widget.NewLabel("此操作将清理 GVA 前后端所有缓存文件:\n\n" +
+		"• 前端: web/node_modules/\n" +
+		"• 后端: Go 模块缓存 (保留 go.sum)\n\n" +
+		"清理后需要重新安装依赖才能运行。不移入回收站时直接删除，无法恢复。\n\n" +
+		"是否继续？")
+
+	// 显示确认对话框
+	dialog.ShowCustomConfirm("⚠️ 清理缓存确认", "确定", "取消", container.NewVBox(message, trashCheck),
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			// 用户确认，开始清理
+			l.performCacheClean(trashCheck.Checked)
+		},
+		l.window,
+	)
+}
+
+// performCacheClean 执行缓存清理（useTrash 为 true 时移入回收站，同时彻底删除过期的回收站内容）
+func (l *GVALauncher) performCacheClean(useTrash bool) {
+	// 检查服务是否在运行，如果在运行则先停止
+	wasRunning := l.services.IsRunning()
+
+	// 如果服务正在运行，先停止所有服务
+	if wasRunning {
+		l.stopGVA()
+	}
+
+	// 通过任务队列执行，可在任务中心查看进度
+	var result launcher.CleanResult
+	job := l.jobs.Submit("清理缓存", func(ctx context.Context, j *jobs.Job) error {
+		// 按已清理的 Go 模块显示进度和速度
+		progress := func(done, total int) {
+			j.SetCount(float64(done), "个模块")
+			j.SetProgress(float64(done) / float64(total))
+		}
+		if !useTrash {
+			result = l.deps.CleanCache(nil, progress)
+			j.Logf("成功 %d 项，失败 %d 项", result.SuccessCount, result.FailCount)
+		} else {
+			if n, err := l.trash.PurgeExpired(time.Now()); err != nil {
+				j.Logf("删除过期的回收站内容失败: %v", err)
+			} else if n > 0 {
+				j.Logf("已彻底删除 %d 次过期的清理", n)
+			}
+			bin := l.trash.Begin("清理缓存")
+			result = l.deps.CleanCache(bin, progress)
+			j.Logf("成功 %d 项，失败 %d 项（已移入回收站）", result.SuccessCount, result.FailCount)
+			if err := bin.Commit(); err != nil {
+				result.Errors = append(result.Errors, "记录回收站失败: "+err.Error())
+			}
+		}
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("清理失败:\n%s", strings.Join(result.Errors, "\n"))
+		}
+		return nil
+	})
+
+	l.waitJob(job, "清理缓存", "正在清理缓存...", func(err error) {
+		if errors.Is(err, jobs.ErrCanceled) {
+			return
+		}
+
+		// 显示结果
+		if len(result.Errors) > 0 {
+			msg := fmt.Sprintf("清理完成（部分失败）\n\n✅ 成功: %d\n❌ 失败: %d\n\n错误:\n%s",
+				result.SuccessCount, result.FailCount, strings.Join(result.Errors, "\n"))
+			dialog.ShowInformation("清理结果", msg, l.window)
+		} else {
+			var msg string
+			if wasRunning {
+				msg = fmt.Sprintf("✅ 清理成功！\n\n已清理 %d 项缓存\n\n服务已自动关闭，请重新安装依赖后启动", result.SuccessCount)
+			} else {
+				msg = fmt.Sprintf("✅ 清理成功！\n\n已清理 %d 项缓存\n\n提示: 请运行「安装依赖」重新安装", result.SuccessCount)
+			}
+			if useTrash {
+				msg += "\n\n清理的内容已移入回收站，误清理时可在「♻️ 回收站」中恢复"
+			}
+			dialog.ShowInformation("清理成功", msg, l.window)
+		}
+
+		// 更新依赖状态
+		l.checkDependencies(nil)
+	})
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/jobs"
+)
+
+// issueLabels 前端依赖问题类型的显示名称
+var issueLabels = map[deps.IssueKind]string{
+	deps.IssueDuplicate:  "🔁 多个版本",
+	deps.IssuePeer:       "🔗 peer 冲突",
+	deps.IssueInvalid:    "⚠️ 版本不符",
+	deps.IssueMissing:    "❌ 未安装",
+	deps.IssueExtraneous: "🧹 未声明",
+	deps.IssueCorrupted:  "💥 已损坏",
+}
+
+// runFrontendDoctor 在后台体检前端依赖，完成后显示结果
+func (l *GVALauncher) runFrontendDoctor() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.requireToolchain() {
+		return
+	}
+
+	progress := dialog.NewProgressInfinite("🩺 前端体检", "正在检查前端依赖...", l.window)
+	progress.Show()
+	l.background("前端体检", func(context.Context) func() {
+		diagnosis, err := l.deps.Doctor()
+		return func() {
+			progress.Hide()
+			if err != nil {
+				l.showError(err, nil)
+				return
+			}
+			l.showDoctorDialog(diagnosis)
+		}
+	})
+}
+
+// showDoctorDialog 显示前端依赖体检结果和修复建议
+func (l *GVALauncher) showDoctorDialog(diagnosis deps.Diagnosis) {
+	list := container.NewVBox()
+	for _, issue := range diagnosis.Issues {
+		detail := widget.NewLabel(issue.Detail)
+		detail.Wrapping = fyne.TextWrapWord
+		list.Add(container.NewBorder(nil, nil, widget.NewLabelWithStyle(issueLabels[issue.Kind], fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), nil, detail))
+	}
+	if len(diagnosis.Issues) == 0 {
+		list.Add(widget.NewLabel("✅ 未发现问题：没有重复的 Vue / Element Plus 版本，peer 依赖均已满足，node_modules 完整"))
+	}
+
+	var d dialog.Dialog
+	fixes := container.NewVBox()
+	for _, fix := range diagnosis.Fixes() {
+		fix := fix
+		fixes.Add(widget.NewButton("🛠️ "+fix.Title, func() {
+			d.Hide()
+			l.applyDoctorFix(fix)
+		}))
+	}
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(35)))
+
+	help := widget.NewLabel("修复按建议的顺序逐项执行，每项完成后重新体检。重新安装会先删除 node_modules 中对应的包，再按 package-lock.json 安装。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewBorder(nil, container.NewVBox(widget.NewSeparator(), help, fixes), nil, nil, scroll)
+	d = dialog.NewCustom(fmt.Sprintf("🩺 前端体检（%d 个问题）", len(diagnosis.Issues)), "关闭", content, l.window)
+	d.Show()
+}
+
+// applyDoctorFix 通过任务队列执行修复，完成后重新体检
+func (l *GVALauncher) applyDoctorFix(fix deps.Fix) {
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	job := l.jobs.Submit(fix.Title, func(ctx context.Context, j *jobs.Job) error {
+		return l.deps.Fix(fix, j)
+	})
+
+	l.waitJob(job, "🩺 前端体检", fix.Title+"...", func(err error) {
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			l.runFrontendDoctor()
+		}
+		l.checkDependencies(nil)
+	})
+}
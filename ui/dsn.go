@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"slices"
+	"strconv"
+
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/dsn"
+)
+
+// acceptDSN 在地址输入框中粘贴连接串时，解析后交给 fill 填入表单的各个字段（只接受 schemes 中的协议）。
+// 解析失败时保持原样不提示，用户可能还在输入；保存时的校验会指出错误
+func acceptDSN(entry *widget.Entry, fill func(dsn.Info), schemes ...string) {
+	prev := entry.OnChanged
+	entry.OnChanged = func(text string) {
+		if dsn.Looks(text) {
+			if info, err := dsn.Parse(text); err == nil && slices.Contains(schemes, info.Scheme) {
+				fill(info)
+				return
+			}
+		}
+		if prev != nil {
+			prev(text)
+		}
+	}
+}
+
+// sshDSN 由 SSH 表单生成连接串（端口无效或为空时省略）
+func sshDSN(host, port, user string) string {
+	info := dsn.Info{Scheme: dsn.SSH, Host: host, User: user}
+	info.Port, _ = strconv.Atoi(port)
+	return info.String()
+}
+
+// fillSSHForm 把解析出的 SSH 连接串填入地址、端口和用户名输入框
+func fillSSHForm(info dsn.Info, hostEntry, portEntry, userEntry *widget.Entry) {
+	hostEntry.SetText(info.Host)
+	portEntry.SetText("")
+	if info.Port > 0 {
+		portEntry.SetText(strconv.Itoa(info.Port))
+	}
+	if info.User != "" {
+		userEntry.SetText(info.User)
+	}
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/gvarelease"
+)
+
+// envDeviationText 一项差异的显示文字
+func envDeviationText(d gvarelease.EnvDeviation) string {
+	mark := "ℹ️"
+	if d.API {
+		mark = "⚠️"
+	}
+	var text string
+	switch d.Kind {
+	case gvarelease.DeviationMissing:
+		text = fmt.Sprintf("%s %s 缺少 %s（默认 %s）", mark, d.File, d.Key, d.Default)
+	case gvarelease.DeviationExtra:
+		text = fmt.Sprintf("%s %s 多出 %s = %s", mark, d.File, d.Key, d.Value)
+	default:
+		text = fmt.Sprintf("%s %s %s = %s（默认 %s）", mark, d.File, d.Key, d.Value, d.Default)
+	}
+	if d.Hint != "" {
+		text += "\n" + d.Hint
+	}
+	return text
+}
+
+// envDefaultsText 上游默认的 env 文件内容（用于复制后对照修改）
+func envDefaultsText(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s = %s\n", key, values[key])
+	}
+	return b.String()
+}
+
+// showEnvDiffDialog 对比前端 env 文件与当前 GVA 版本的上游默认值，影响后端 API 访问的差异排在最前
+func (l *GVALauncher) showEnvDiffDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	version := gvarelease.ProjectVersion(l.project.Root)
+	backendPort, frontendPort := l.project.Ports()
+	deviations := gvarelease.DiffEnv(l.project.WebDir(), version, backendPort, frontendPort)
+
+	shownVersion := version
+	if shownVersion == "" {
+		shownVersion = "未知（按最新版本对比）"
+	}
+	api := 0
+	for _, d := range deviations {
+		if d.API {
+			api++
+		}
+	}
+	summary := fmt.Sprintf("GVA 版本: %s，共 %d 处差异", shownVersion, len(deviations))
+	if api > 0 {
+		summary += fmt.Sprintf("，其中 %d 处可能导致前端访问不到后端 API", api)
+	}
+	summaryLabel := widget.NewLabel(summary)
+	summaryLabel.Wrapping = fyne.TextWrapWord
+
+	list := container.NewVBox()
+	for _, d := range deviations {
+		label := widget.NewLabel(envDeviationText(d))
+		label.Wrapping = fyne.TextWrapWord
+		if d.API {
+			label.TextStyle = fyne.TextStyle{Bold: true}
+		}
+		list.Add(label)
+	}
+	if len(deviations) == 0 {
+		list.Add(widget.NewLabel("✅ env 文件与上游默认值一致"))
+	}
+
+	defaults := gvarelease.EnvDefaults(version)
+	copyBtn := widget.NewButton("📋 复制默认 "+gvarelease.EnvDevelopment, func() {
+		l.copyToClipboard(envDefaultsText(defaults[gvarelease.EnvDevelopment]), "默认 "+gvarelease.EnvDevelopment)
+	})
+
+	help := widget.NewLabel("默认值为面板内置的上游各版本 web 目录下 env 文件内容；前后端端口按面板当前设置对比。" +
+		"升级 GVA 后沿用旧的 env 文件是前端请求不到后端的常见原因。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(40)))
+
+	content := container.NewBorder(container.NewVBox(summaryLabel, widget.NewSeparator()),
+		container.NewVBox(help, container.NewHBox(copyBtn)), nil, nil, scroll)
+	dialog.NewCustom("🧾 前端 env 对比", "关闭", content, l.window).Show()
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+)
+
+// showError 显示错误对话框（parent 为 nil 时使用主窗口）
+// 带错误码的错误显示本地化标题和错误码，并提供排查说明链接；其他错误使用 Fyne 默认的错误对话框
+func (l *GVALauncher) showError(err error, parent fyne.Window) {
+	if parent == nil {
+		parent = l.window
+	}
+
+	d := apperr.Describe(err, apperr.DefaultLang())
+	if d.Code == apperr.Unknown {
+		dialog.ShowError(err, parent)
+		return
+	}
+
+	detail := widget.NewLabel(d.Detail)
+	detail.Wrapping = fyne.TextWrapWord
+	codeLabel := widget.NewLabelWithStyle("错误码: "+string(d.Code), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+
+	scroll := container.NewVScroll(detail)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(12)))
+	content := container.NewBorder(nil, codeLabel, nil, nil, scroll)
+
+	dialog.ShowCustomConfirm("❌ "+d.Message, "📖 排查说明", "关闭", content, func(open bool) {
+		if !open {
+			return
+		}
+		if u, err := url.Parse(d.HelpURL); err == nil {
+			fyne.CurrentApp().OpenURL(u)
+		}
+	}, parent)
+}
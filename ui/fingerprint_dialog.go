@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/fingerprint"
+	"gva-launcher/launcher"
+)
+
+// fingerprintDiffText 两份指纹差异的显示文字
+func fingerprintDiffText(local, other fingerprint.Fingerprint) string {
+	diffs := fingerprint.Diff(local, other)
+	if len(diffs) == 0 {
+		return "✅ 两台机器的环境指纹完全相同"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "共 %d 项不同（本机 %s ↔ %s，导出于 %s）\n", len(diffs), local.Host, other.Host, other.Created.Format("2006-01-02 15:04"))
+	for _, d := range diffs {
+		fmt.Fprintf(&b, "\n• %s\n　　本机: %s\n　　%s: %s", fingerprint.Label(d.Key), d.Local, other.Host, d.Other)
+	}
+	return b.String()
+}
+
+// showFingerprintDialog 环境指纹：导出本机的工具版本、镜像源、环境变量和配置文件哈希，与另一台机器导出的指纹对比
+func (l *GVALauncher) showFingerprintDialog() {
+	var local fingerprint.Fingerprint
+	status := widget.NewLabel("⏳ 正在收集本机环境...")
+	otherEntry := widget.NewMultiLineEntry()
+	otherEntry.SetPlaceHolder("粘贴另一台机器导出的指纹（JSON），或点击「📁 打开文件」")
+	otherEntry.SetMinRowsVisible(6)
+	result := widget.NewLabel("")
+	result.Wrapping = fyne.TextWrapWord
+
+	var exportBtn, copyBtn, compareBtn *widget.Button
+	exportBtn = widget.NewButton("💾 导出到文件", func() {
+		data, err := local.Marshal()
+		if err == nil {
+			err = os.MkdirAll(config.FingerprintDir(), 0755)
+		}
+		path := filepath.Join(config.FingerprintDir(), fmt.Sprintf("%s-%s.json", local.Host, local.Created.Format("20060102-150405")))
+		if err == nil {
+			err = os.WriteFile(path, data, 0644)
+		}
+		if err != nil {
+			l.showError(fmt.Errorf("导出指纹失败: %w", err), nil)
+			return
+		}
+		l.window.Clipboard().SetContent(path)
+		dialog.ShowInformation("已导出", "指纹已保存到:\n"+path+"\n\n路径已复制到剪贴板", l.window)
+	})
+	copyBtn = widget.NewButton("📋 复制指纹", func() {
+		data, _ := local.Marshal()
+		l.copyToClipboard(string(data), "本机环境指纹")
+	})
+	openBtn := widget.NewButton("📁 打开文件", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			if data, err := io.ReadAll(reader); err == nil {
+				otherEntry.SetText(string(data))
+			}
+		}, l.window)
+	})
+	compareBtn = widget.NewButton("🔍 对比", func() {
+		other, err := fingerprint.Parse([]byte(otherEntry.Text))
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		result.SetText(fingerprintDiffText(local, other))
+	})
+	for _, btn := range []*widget.Button{exportBtn, copyBtn, compareBtn} {
+		btn.Disable()
+	}
+
+	// 收集需要执行 go / npm / node 命令，放到后台
+	root := ""
+	if l.project.IsSet() {
+		root = l.project.Root
+	}
+	l.background("收集环境指纹", func(context.Context) func() {
+		f := fingerprint.Collect(root, launcher.Version)
+		return func() {
+			local = f
+			status.SetText(fmt.Sprintf("✅ 本机（%s）环境指纹已收集，共 %d 项，收集于 %s", f.Host, len(f.Items), f.Created.Format(time.TimeOnly)))
+			for _, btn := range []*widget.Button{exportBtn, copyBtn, compareBtn} {
+				btn.Enable()
+			}
+		}
+	})
+
+	help := widget.NewLabel("指纹包括系统、go / node / npm 版本、镜像源、go env 设置、代理等相关环境变量，以及项目配置文件（config.yaml、go.sum、package-lock.json 等）的哈希。" +
+		"配置文件只记录哈希，代理地址中的密码会被隐去，可以直接发给同事。请同事导出指纹后粘贴到下方对比，找出两台机器的差异。")
+	help.Wrapping = fyne.TextWrapWord
+
+	resultScroll := container.NewVScroll(result)
+	resultScroll.SetMinSize(fyne.NewSize(0, l.calcVH(30)))
+
+	content := container.NewVBox(
+		help,
+		status,
+		container.NewGridWithColumns(2, exportBtn, copyBtn),
+		widget.NewSeparator(),
+		otherEntry,
+		container.NewGridWithColumns(2, openBtn, compareBtn),
+		resultScroll,
+	)
+	d := dialog.NewCustom("🧬 环境指纹", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(70), 0))
+	d.Show()
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/gvarelease"
+)
+
+// factGVAReleases 上游发布列表的缓存键
+const factGVAReleases = "gva_releases"
+
+// checkGVARelease 在后台查询上游新版本（结果按 gvarelease.CheckInterval 缓存），完成后刷新提醒
+func (l *GVALauncher) checkGVARelease() {
+	if l.config.GVARelease.Disabled {
+		return
+	}
+	l.background("检查 GVA 新版本", func(context.Context) func() {
+		data, err := l.facts.Get(factGVAReleases, gvarelease.CheckInterval, func() (string, error) {
+			releases, err := gvarelease.Fetch()
+			if err != nil {
+				return "", err
+			}
+			data, err := json.Marshal(releases)
+			return string(data), err
+		})
+		if err != nil {
+			// 网络不可用时不打扰用户，下次启动再查询
+			return nil
+		}
+		var releases []gvarelease.Release
+		if json.Unmarshal([]byte(data), &releases) != nil {
+			return nil
+		}
+		return func() {
+			l.gvaReleases = releases
+			l.renderGVARelease()
+		}
+	})
+}
+
+// pendingGVAReleases 比当前项目新的上游版本
+func (l *GVALauncher) pendingGVAReleases() (current string, newer []gvarelease.Release) {
+	if l.project.IsSet() {
+		current = gvarelease.ProjectVersion(l.project.Root)
+	}
+	return current, gvarelease.Newer(l.gvaReleases, current)
+}
+
+// renderGVARelease 有未忽略的新版本时在根目录区域显示提醒按钮
+func (l *GVALauncher) renderGVARelease() {
+	if l.gvaReleaseBtn == nil {
+		return
+	}
+	_, newer := l.pendingGVAReleases()
+	if l.config.GVARelease.Disabled || len(newer) == 0 || newer[0].Tag == l.config.GVARelease.Dismissed {
+		l.gvaReleaseBtn.Hide()
+		return
+	}
+	l.gvaReleaseBtn.SetText(fmt.Sprintf("🆕 GVA %s 已发布", newer[0].Tag))
+	l.gvaReleaseBtn.Show()
+}
+
+// showGVAReleaseDialog 显示上游新版本摘要：各版本的发布时间和不兼容变更
+func (l *GVALauncher) showGVAReleaseDialog() {
+	current, newer := l.pendingGVAReleases()
+	if current == "" {
+		current = "未知"
+	}
+
+	list := container.NewVBox()
+	for _, rel := range newer {
+		title := rel.Tag
+		if rel.Name != "" && rel.Name != rel.Tag {
+			title += "　" + rel.Name
+		}
+		if !rel.Published.IsZero() {
+			title += "（" + rel.Published.Local().Format("2006-01-02") + "）"
+		}
+
+		text := "更新说明中没有标注不兼容变更"
+		if items := gvarelease.Breaking(rel.Notes); len(items) > 0 {
+			text = "⚠️ 不兼容变更:\n• " + strings.Join(items, "\n• ")
+		}
+		detail := widget.NewLabel(text)
+		detail.Wrapping = fyne.TextWrapWord
+
+		link := rel.URL
+		if link == "" {
+			link = gvarelease.PageURL
+		}
+		openBtn := widget.NewButton("🌐 发布说明", func() {
+			if u, err := url.Parse(link); err == nil {
+				fyne.CurrentApp().OpenURL(u)
+			}
+		})
+		list.Add(container.NewBorder(nil, nil, widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), openBtn))
+		list.Add(detail)
+		list.Add(widget.NewSeparator())
+	}
+	if len(newer) == 0 {
+		list.Add(widget.NewLabel("当前项目已是最新版本"))
+	}
+
+	watchCheck := widget.NewCheck("每天检查 GVA 新版本", nil)
+	watchCheck.SetChecked(!l.config.GVARelease.Disabled)
+	dismissCheck := widget.NewCheck("不再提醒此版本", nil)
+	if len(newer) == 0 {
+		dismissCheck.Disable()
+	} else {
+		dismissCheck.SetChecked(newer[0].Tag == l.config.GVARelease.Dismissed)
+	}
+
+	header := widget.NewLabel(fmt.Sprintf("项目当前版本: %s。升级前请阅读下列版本的不兼容变更，并先备份数据库和配置。", current))
+	header.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(40)))
+
+	content := container.NewBorder(header, container.NewVBox(watchCheck, dismissCheck), nil, nil, scroll)
+	dialog.ShowCustomConfirm("🆕 GVA 新版本", "💾 保存", "关闭", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		l.config.GVARelease.Disabled = !watchCheck.Checked
+		l.config.GVARelease.Dismissed = ""
+		if dismissCheck.Checked && len(newer) > 0 {
+			l.config.GVARelease.Dismissed = newer[0].Tag
+		}
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		if watchCheck.Checked && len(l.gvaReleases) == 0 {
+			l.checkGVARelease()
+		}
+	}, l.window)
+}
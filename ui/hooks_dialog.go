@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/hooks"
+)
+
+// hookRow 钩子编辑对话框中的一行
+type hookRow struct {
+	eventSelect  *widget.Select
+	commandEntry *widget.Entry
+}
+
+// showHooksDialog 显示事件钩子编辑对话框
+func (l *GVALauncher) showHooksDialog() {
+	// 下拉框显示 "before-start（启动前）"，保存时还原为事件名
+	var options []string
+	eventByOption := make(map[string]hooks.Event)
+	for _, event := range hooks.Events {
+		option := fmt.Sprintf("%s（%s）", event, event.Label())
+		options = append(options, option)
+		eventByOption[option] = event
+	}
+	optionOf := func(event string) string {
+		for option, e := range eventByOption {
+			if string(e) == event {
+				return option
+			}
+		}
+		return options[0]
+	}
+
+	var rows []*hookRow
+	rowsBox := container.NewVBox()
+
+	addRow := func(hook config.Hook) {
+		row := &hookRow{
+			eventSelect:  widget.NewSelect(options, nil),
+			commandEntry: widget.NewEntry(),
+		}
+		row.eventSelect.SetSelected(optionOf(hook.Event))
+		row.commandEntry.SetPlaceHolder("例如: scripts/notify.sh 或 echo %GVA_EVENT%")
+		row.commandEntry.SetText(hook.Command)
+		rows = append(rows, row)
+
+		var rowBox *fyne.Container
+		deleteBtn := widget.NewButton("🗑️", func() {
+			for i, r := range rows {
+				if r == row {
+					rows = append(rows[:i], rows[i+1:]...)
+					break
+				}
+			}
+			rowsBox.Remove(rowBox)
+		})
+		rowBox = container.NewBorder(nil, nil, row.eventSelect, deleteBtn, row.commandEntry)
+		rowsBox.Add(rowBox)
+	}
+
+	for _, hook := range l.config.Hooks {
+		addRow(hook)
+	}
+
+	addBtn := widget.NewButton("➕ 添加钩子", func() {
+		addRow(config.Hook{Event: string(hooks.BeforeStart)})
+	})
+
+	help := widget.NewLabel("命令在 GVA 根目录下通过系统 shell 执行，可使用环境变量：\n" +
+		"GVA_EVENT、GVA_ROOT、GVA_SERVER_DIR、GVA_WEB_DIR、GVA_BACKEND_PORT、GVA_FRONTEND_PORT\n" +
+		"服务崩溃时另有 GVA_SERVICE（backend/frontend）和 GVA_EXIT_ERROR")
+	help.Wrapping = fyne.TextWrapWord
+
+	logPath := l.jobs.LogPath()
+	logBox := container.NewBorder(nil, nil, nil,
+		widget.NewButton("　📋 复制　", func() {
+			l.copyPathToClipboard(logPath, "任务日志路径")
+		}),
+		widget.NewLabel("执行日志: "+logPath),
+	)
+
+	scroll := container.NewVScroll(rowsBox)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(80), l.calcVH(25)))
+
+	content := container.NewBorder(
+		help,
+		container.NewVBox(addBtn, widget.NewSeparator(), logBox),
+		nil, nil,
+		scroll,
+	)
+
+	dialog.ShowCustomConfirm("🪝 事件钩子", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		var list []config.Hook
+		for _, row := range rows {
+			command := strings.TrimSpace(row.commandEntry.Text)
+			if command == "" {
+				continue
+			}
+			list = append(list, config.Hook{
+				Event:   string(eventByOption[row.eventSelect.Selected]),
+				Command: command,
+			})
+		}
+
+		l.config.Hooks = list
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("成功", fmt.Sprintf("已保存 %d 个事件钩子", len(list)), l.window)
+	}, l.window)
+}
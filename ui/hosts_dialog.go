@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/hostsfile"
+	"gva-launcher/services"
+)
+
+// showHostsDialog 显示局域网主机名设置（写入系统 hosts 文件）
+func (l *GVALauncher) showHostsDialog() {
+	old := l.config.Hostname
+	mapped := ""
+	if old != "" {
+		mapped = hostsfile.Current(old)
+	}
+
+	// 可选的局域网地址：IPv4 在前，IPv6 全局地址在后；默认选中已映射的地址
+	ipv4, ipv6 := services.LocalIPs()
+	ipSelect := widget.NewSelect(append(ipv4, ipv6...), nil)
+	ip := services.GetLocalIP()
+	for _, option := range ipSelect.Options {
+		if option == mapped {
+			ip = mapped
+		}
+	}
+	ipSelect.SetSelected(ip)
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("例如: gva.local（留空则使用 IP）")
+	nameEntry.SetText(old)
+
+	status := "当前未设置主机名，访问地址使用局域网 IP"
+	if old != "" {
+		switch mapped {
+		case ip:
+			status = fmt.Sprintf("hosts 中已映射: %s → %s", old, ip)
+		case "":
+			status = fmt.Sprintf("⚠️ hosts 中没有 %s 的映射，保存后重新写入", old)
+		default:
+			status = fmt.Sprintf("⚠️ hosts 中 %s 仍指向 %s，该地址已不在本机网卡上，保存后更新", old, mapped)
+		}
+	}
+
+	help := widget.NewLabel("设置后面板在系统 hosts 文件中把主机名映射到本机局域网 IP，" +
+		"界面显示和复制的前后端地址、单端口访问地址都改用主机名，本地 HTTPS 证书也会包含该主机名（需重新启用 HTTPS）。" +
+		"修改 hosts 需要管理员权限，系统会弹出授权窗口。局域网内的其他机器需要在各自的 hosts 中加入同样的映射。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		widget.NewForm(
+			widget.NewFormItem("主机名", nameEntry),
+			widget.NewFormItem("局域网 IP", ipSelect),
+		),
+		widget.NewSeparator(),
+		widget.NewLabel(status),
+	)
+
+	dialog.ShowCustomConfirm("🏷️ 局域网主机名", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		name := strings.ToLower(strings.TrimSpace(nameEntry.Text))
+		ip := ipSelect.Selected
+		if name != "" {
+			if err := hostsfile.ValidHostname(name); err != nil {
+				l.showError(err, nil)
+				return
+			}
+			if ip == "" || ip == "localhost" {
+				l.showError(fmt.Errorf("未检测到局域网 IP，无法映射主机名"), nil)
+				return
+			}
+		}
+
+		progress := dialog.NewCustomWithoutButtons("🏷️ 局域网主机名", widget.NewLabel("正在修改 hosts，请在系统弹出的授权窗口中允许..."), l.window)
+		progress.Show()
+
+		// 请求管理员授权时会等待用户确认，放到后台执行
+		l.background("修改 hosts", func(context.Context) func() {
+			var err error
+			if old != "" && old != name {
+				err = hostsfile.Set(old, "")
+			}
+			if err == nil && name != "" {
+				err = hostsfile.Set(name, ip)
+			}
+
+			return func() {
+				progress.Hide()
+				if err != nil {
+					l.showError(err, nil)
+					return
+				}
+				l.config.Hostname = name
+				if err := l.saveConfig(); err != nil {
+					l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+					return
+				}
+				l.updateServiceStatus()
+
+				msg := "已删除主机名映射，访问地址改用局域网 IP"
+				if name != "" {
+					msg = fmt.Sprintf("已映射 %s → %s\n\n前端地址: %s", name, ip, l.getFrontendURL())
+				}
+				dialog.ShowInformation("成功", msg, l.window)
+			}
+		})
+	}, l.window)
+}
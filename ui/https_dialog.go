@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/devcert"
+	"gva-launcher/launcher"
+)
+
+// showHTTPSDialog 显示本地 HTTPS 设置：为前端开发服务器签发受信任的证书，
+// 便于测试剪贴板、定位、PWA 等只在安全上下文中可用的功能
+func (l *GVALauncher) showHTTPSDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+
+	certDir := config.CertDir()
+	hosts := launcher.HTTPSHosts(l.config.Hostname)
+
+	enableCheck := widget.NewCheck("启用本地 HTTPS（前端开发服务器）", nil)
+	enableCheck.SetChecked(l.project.HTTPSEnabled())
+
+	trustText := "根证书: 尚未加入系统信任（启用时会请求加入，系统可能弹出确认窗口）"
+	if devcert.Trusted(certDir) {
+		trustText = "根证书: 已加入系统信任"
+	}
+
+	help := widget.NewLabel("启用后面板会生成本机专用的根证书并加入系统信任，再为下列地址签发证书，" +
+		"写入 web/vite 配置的 server.https。后端仍使用 HTTP，浏览器通过前端的 /api 代理访问后端，页面处于安全上下文中。" +
+		"关闭时只删除面板写入的配置行。修改后需要重新启动前端服务。")
+	help.Wrapping = fyne.TextWrapWord
+
+	certBox := container.NewBorder(nil, nil, nil,
+		widget.NewButton("　📋 复制　", func() {
+			l.copyPathToClipboard(certDir, "证书目录")
+		}),
+		widget.NewLabel("证书目录: "+certDir),
+	)
+
+	content := container.NewVBox(
+		help,
+		enableCheck,
+		widget.NewSeparator(),
+		widget.NewLabel("证书地址: "+strings.Join(hosts, ", ")),
+		widget.NewLabel(trustText),
+		certBox,
+	)
+
+	dialog.ShowCustomConfirm("🔒 本地 HTTPS", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if !l.ensureProjectOwner() {
+			return
+		}
+
+		enable := enableCheck.Checked
+		progress := dialog.NewCustomWithoutButtons("🔒 本地 HTTPS", widget.NewLabel("正在配置，请在系统弹出的确认窗口中允许..."), l.window)
+		progress.Show()
+
+		// 加入系统信任时会等待用户确认，放到后台执行
+		l.background("本地 HTTPS", func(context.Context) func() {
+			var err error
+			if enable {
+				err = l.project.EnableHTTPS(certDir, hosts)
+			} else {
+				err = l.project.DisableHTTPS()
+			}
+
+			return func() {
+				progress.Hide()
+				l.httpsEnabled = l.project.HTTPSEnabled()
+				l.updateServiceStatus()
+				if err != nil {
+					l.showError(err, nil)
+					return
+				}
+
+				msg := "本地 HTTPS 已关闭"
+				if enable {
+					msg = "本地 HTTPS 已启用\n\n前端地址: " + l.getFrontendURL()
+				}
+				if l.services.Frontend.IsRunning() {
+					msg += "\n\n请重新启动前端服务使设置生效"
+				}
+				dialog.ShowInformation("成功", msg, l.window)
+			}
+		})
+	}, l.window)
+}
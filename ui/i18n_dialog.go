@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/i18ncheck"
+)
+
+// showI18nDialog 多语言检查：对比前端各语言的 locale 文件，列出缺少、为空、未使用和未定义的键
+func (l *GVALauncher) showI18nDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+
+	summary := widget.NewLabel("")
+	summary.Wrapping = fyne.TextWrapWord
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapOff
+
+	check := func() {
+		r, err := i18ncheck.Check(l.project.WebDir())
+		if err != nil {
+			l.showError(fmt.Errorf("读取多语言资源失败: %w", err), nil)
+			return
+		}
+		switch {
+		case r.Dir == "":
+			summary.SetText("项目没有使用多语言资源（vue-i18n 的 locale 文件）")
+		case r.Issues() == 0:
+			summary.SetText(fmt.Sprintf("✅ %d 种语言、%d 个键，没有缺少或空的翻译（%d 个键在代码中没有用到）", len(r.Languages), r.Keys, len(r.Unused)))
+		default:
+			summary.SetText(fmt.Sprintf("⚠️ %d 种语言、%d 个键，发现 %d 处缺少、为空或未定义的翻译（%d 个键在代码中没有用到）",
+				len(r.Languages), r.Keys, r.Issues(), len(r.Unused)))
+		}
+		output.SetText(i18ncheck.Report(r, 0))
+	}
+	check()
+
+	recheckBtn := widget.NewButton("🔄 重新检查", check)
+	copyBtn := widget.NewButton("📋 复制报告", func() {
+		l.copyToClipboard(output.Text, "多语言检查报告")
+	})
+
+	help := widget.NewLabel("读取 web/src 下 locale、locales、i18n、lang 等目录中的语言文件（JSON、YAML 或 export default 对象的 JS / TS），" +
+		"按键对比各语言，并在前端源码中查找 t('键')、$t('键') 的使用位置。动态拼接的键（例如 t(`menu.${name}`)）按前缀计为已使用；" +
+		"菜单标题等保存在数据库中的键也会显示为未使用，请确认后再删除。")
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(summary, widget.NewSeparator())
+	bottom := container.NewVBox(help, container.NewHBox(recheckBtn, copyBtn))
+	d := dialog.NewCustom("🌐 多语言检查", "关闭", container.NewBorder(top, bottom, nil, nil, output), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), l.calcVH(75)))
+	d.Show()
+}
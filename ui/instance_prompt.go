@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/instance"
+)
+
+// showInstancePrompt 面板已在运行时显示的提示窗口：切换到已有窗口、接管或退出
+func (l *GVALauncher) showInstancePrompt(myApp fyne.App, existing *instance.Existing) {
+	prompt := myApp.NewWindow("GVAPanel")
+
+	info := widget.NewLabel(fmt.Sprintf(
+		"GVAPanel 已在运行，同时运行两个面板会争用端口和配置文件。\n\n"+
+			"　• 进程 PID: %d\n　• 用户: %s@%s\n　• 启动时间: %s",
+		existing.PID, existing.User, existing.Host, existing.Started.Format("2006-01-02 15:04:05")))
+
+	var focusBtn, takeOverBtn, quitBtn *widget.Button
+
+	focusBtn = widget.NewButton("🔍 切换到已运行的面板", func() {
+		if err := existing.Focus(); err != nil {
+			l.showError(fmt.Errorf("切换失败: %w", err), prompt)
+			return
+		}
+		l.rendered()
+		myApp.Quit()
+	})
+
+	takeOverBtn = widget.NewButton("⚡ 接管（关闭已运行的面板）", func() {
+		focusBtn.Disable()
+		takeOverBtn.Disable()
+		quitBtn.Disable()
+		info.SetText("正在等待已运行的面板退出...")
+
+		l.background("接管面板实例", func(context.Context) func() {
+			lock, err := existing.TakeOver(10 * time.Second)
+			return func() {
+				if err != nil {
+					info.SetText("接管失败")
+					quitBtn.Enable()
+					l.showError(err, prompt)
+					return
+				}
+				// 先显示主窗口再关闭提示窗口，避免应用因没有窗口而退出
+				l.showMainWindow(myApp, lock)
+				prompt.Close()
+			}
+		})
+	})
+
+	quitBtn = widget.NewButton("❌ 退出", func() {
+		l.rendered()
+		myApp.Quit()
+	})
+
+	prompt.SetContent(container.NewVBox(
+		info,
+		widget.NewSeparator(),
+		focusBtn,
+		takeOverBtn,
+		quitBtn,
+	))
+	// 用窗口的关闭按钮关闭提示时窗口已正常显示过
+	prompt.SetOnClosed(l.rendered)
+	prompt.CenterOnScreen()
+	l.showWindow(prompt)
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+	"gva-launcher/supervisor"
+)
+
+// instanceRefreshInterval 其他实例按端口刷新运行状态的间隔
+const instanceRefreshInterval = 5 * time.Second
+
+// createInstancesArea 创建其他实例区域：当前项目之外同时运行的 GVA 项目，每个实例一行
+func (l *GVALauncher) createInstancesArea() *fyne.Container {
+	addBtn := widget.NewButton("　➕ 添加实例　", func() {
+		l.addInstance()
+	})
+	l.instancesBox = container.NewVBox()
+	l.renderInstances()
+	return container.NewVBox(
+		container.NewHBox(widget.NewLabel("其他实例:"), layout.NewSpacer(), addBtn),
+		l.instancesBox,
+	)
+}
+
+// setupInstances 初始化实例列表：与当前项目共用等待时间、优先级、自动重启等设置，
+// 但钩子、生产模式和退出面板后保持运行只作用于当前项目
+func (l *GVALauncher) setupInstances() {
+	l.instances = &launcher.Instances{Setup: func(inst *launcher.Instance) {
+		m := inst.Services
+		m.Timeouts = l.services.Timeouts
+		m.PreferBinary = l.services.PreferBinary
+		m.AutoRestart = l.services.AutoRestart
+		m.CompiledRun = l.services.CompiledRun
+		m.Priority = l.services.Priority
+		m.BuildPriority = l.services.BuildPriority
+		l.subscribeOn(m.Events, func(events.Event) { l.renderInstances() }, events.ServiceChanged)
+	}}
+}
+
+// restoreInstances 重新打开上次添加的实例（无法打开的实例提示原因后从列表中移除）
+func (l *GVALauncher) restoreInstances() {
+	var failed []string
+	for _, root := range l.config.Instances {
+		if _, err := l.instances.Add(root, l.project); err != nil {
+			failed = append(failed, "• "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		l.saveInstances()
+		dialog.ShowInformation("⚠️ 部分实例没有打开", strings.Join(failed, "\n"), l.window)
+	}
+	l.supervisor.Go("实例状态监控", func(ctx context.Context) {
+		for supervisor.Sleep(ctx, instanceRefreshInterval) {
+			l.instances.Refresh()
+		}
+	})
+}
+
+// saveInstances 保存实例列表，并把实例的端口登记到项目端口表（自动分配端口时避开）
+func (l *GVALauncher) saveInstances() {
+	l.config.Instances = l.instances.Roots()
+	// 重新添加的实例不再算作已移除
+	l.config.Removed = slices.DeleteFunc(l.config.Removed, func(root string) bool {
+		return slices.ContainsFunc(l.config.Instances, func(inst string) bool { return config.SameRoot(root, inst) })
+	})
+	for _, inst := range l.instances.List() {
+		backendPort, frontendPort := inst.Project.Ports()
+		l.config.Projects, _ = config.RegisterProject(l.config.Projects,
+			config.ProjectPorts{Root: inst.Project.Root, BackendPort: backendPort, FrontendPort: frontendPort})
+	}
+	if err := l.saveConfig(); err != nil {
+		l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+	}
+}
+
+// addInstance 选择另一个 GVA 根目录添加为实例
+func (l *GVALauncher) addInstance() {
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil || uri == nil {
+			return
+		}
+		if _, err := l.instances.Add(uri.Path(), l.project); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.saveInstances()
+		l.renderInstances()
+	}, l.window)
+}
+
+// renderInstances 按实例列表和运行状态重建实例行（在主线程中调用）
+func (l *GVALauncher) renderInstances() {
+	if l.instancesBox == nil {
+		return
+	}
+	l.instancesBox.RemoveAll()
+	list := l.instances.List()
+	if len(list) == 0 {
+		l.instancesBox.Add(widget.NewLabel("　• 没有其他实例（可同时运行端口不同的多个 GVA 项目）"))
+	}
+	for _, inst := range list {
+		l.instancesBox.Add(l.instanceRow(inst))
+	}
+	l.instancesBox.Refresh()
+}
+
+// instanceRow 一个实例的状态行：名称、前后端状态和端口，以及启动 / 停止 / 移除按钮
+func (l *GVALauncher) instanceRow(inst *launcher.Instance) fyne.CanvasObject {
+	state := inst.Services.State()
+	label := widget.NewLabel(fmt.Sprintf("　• %s　后端: %s %s　前端: %s %s", inst.Name(),
+		l.instanceStatus(state.BackendRunning, state.BackendFailure), portText(state.BackendPort),
+		l.instanceStatus(state.FrontendRunning, state.FrontendFailure), portText(state.FrontendPort)))
+
+	var actionBtn *widget.Button
+	if inst.Services.IsRunning() {
+		actionBtn = widget.NewButton("　⏹ 停止　", func() {
+			actionBtn.Disable()
+			l.supervisor.Go("停止实例 "+inst.Name(), func(context.Context) { inst.Services.Stop() })
+		})
+	} else {
+		actionBtn = widget.NewButton("　▶️ 启动　", func() {
+			l.startInstance(inst, actionBtn)
+		})
+	}
+	copyBtn := widget.NewButton("　📋 复制链接　", func() {
+		_, frontendPort := inst.Project.Ports()
+		if frontendPort <= 0 {
+			dialog.ShowInformation("提示", "端口未配置，无法复制链接", l.window)
+			return
+		}
+		scheme := "http"
+		if inst.Project.HTTPSEnabled() {
+			scheme = "https"
+		}
+		l.copyToClipboard(services.HostURL(scheme, l.lanHost(), frontendPort), "链接")
+	})
+	removeBtn := widget.NewButton("　✖ 移除　", func() {
+		l.removeInstance(inst)
+	})
+	return container.NewHBox(label, layout.NewSpacer(), actionBtn, copyBtn, removeBtn)
+}
+
+// instanceStatus 实例中一个服务的状态徽标
+func (l *GVALauncher) instanceStatus(running bool, failure string) string {
+	switch {
+	case running:
+		return l.statusBadge(statusRunning, "运行中")
+	case failure != "":
+		return l.statusBadge(statusFailed, "启动失败")
+	default:
+		return l.statusBadge(statusStopped, "已停止")
+	}
+}
+
+// portText 状态行中的端口（未读取到时显示未配置）
+func portText(port int) string {
+	if port <= 0 {
+		return "端口: 未配置"
+	}
+	return fmt.Sprintf("端口: %d", port)
+}
+
+// startInstance 在后台启动实例的前后端服务（端口被占用时提示，不启动）
+func (l *GVALauncher) startInstance(inst *launcher.Instance, btn *widget.Button) {
+	if err := inst.Services.CheckPorts(); err != nil {
+		l.showError(err, nil)
+		return
+	}
+	btn.Disable()
+	btn.SetText("　⏳ 启动中...　")
+	l.background("启动实例 "+inst.Name(), func(context.Context) func() {
+		inst.Services.Start()
+		return l.renderInstances
+	})
+}
+
+// removeInstance 停止实例的服务并从列表中移除（服务运行中时先确认）
+func (l *GVALauncher) removeInstance(inst *launcher.Instance) {
+	remove := func() {
+		l.background("移除实例 "+inst.Name(), func(context.Context) func() {
+			l.instances.Remove(inst)
+			return func() {
+				l.config.Removed = append(l.config.Removed, inst.Project.Root)
+				l.saveInstances()
+				l.renderInstances()
+			}
+		})
+	}
+	if !inst.Services.IsRunning() {
+		remove()
+		return
+	}
+	dialog.ShowConfirm("移除实例", "实例 "+inst.Name()+" 的服务正在运行，移除时会停止服务，确定移除吗？", func(ok bool) {
+		if ok {
+			remove()
+		}
+	}, l.window)
+}
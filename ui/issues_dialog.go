@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/deps"
+	"gva-launcher/events"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+	"gva-launcher/logrules"
+	"gva-launcher/services"
+)
+
+// diagnoseServiceLogs 识别前后端最近输出中的常见问题（「🧠 日志诊断」）
+func (l *GVALauncher) diagnoseServiceLogs() {
+	issues := append(l.services.Diagnose(launcher.ServiceBackend), l.services.Diagnose(launcher.ServiceFrontend)...)
+	if len(issues) == 0 {
+		dialog.ShowInformation("🧠 日志诊断", "最近的服务输出中没有识别出已知问题", l.window)
+		return
+	}
+	l.showIssuesDialog(issues)
+}
+
+// onServiceExited 服务意外退出时识别该服务的输出，识别出已知问题时显示说明和修复操作；
+// 没有识别出已知问题但服务在就绪前退出（启动失败）时显示退出码和输出中的报错
+func (l *GVALauncher) onServiceExited(exit events.ExitState) {
+	if issues := l.services.Diagnose(exit.Service); len(issues) > 0 {
+		l.showIssuesDialog(issues)
+		return
+	}
+	if exit.Starting {
+		l.showStartFailure(exit)
+	}
+}
+
+// showStartFailure 显示服务启动失败的原因
+func (l *GVALauncher) showStartFailure(exit events.ExitState) {
+	reason := "进程在就绪前退出"
+	if exit.ExitCode >= 0 {
+		reason += fmt.Sprintf("（退出码 %d）", exit.ExitCode)
+	} else if exit.Error != "" {
+		reason += ": " + exit.Error
+	}
+	message := widget.NewLabel(reason + "，输出中的报错:")
+	message.Wrapping = fyne.TextWrapWord
+
+	lines := strings.Join(exit.Lines, "\n")
+	if lines == "" {
+		lines = "（没有输出）"
+	}
+	output := widget.NewLabelWithStyle(lines, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	output.Wrapping = fyne.TextWrapBreak
+	copyBtn := widget.NewButton("📋 复制", func() {
+		l.copyToClipboard(reason+"\n"+lines, "报错")
+	})
+
+	content := container.NewVBox(message, output, copyBtn)
+	d := dialog.NewCustom("❌ "+launcher.ServiceLabel(exit.Service)+"启动失败", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
+
+// showIssuesDialog 显示识别出的问题：说明、匹配的输出行和一键修复
+func (l *GVALauncher) showIssuesDialog(issues []logrules.Issue) {
+	list := container.NewVBox()
+	var d dialog.Dialog
+	for _, issue := range issues {
+		title := widget.NewLabelWithStyle(launcher.ServiceLabel(issue.Service)+": "+issue.Title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		detail := widget.NewLabel(issue.Detail)
+		detail.Wrapping = fyne.TextWrapWord
+		line := widget.NewLabelWithStyle(issue.Line, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+		line.Wrapping = fyne.TextWrapBreak
+		list.Add(title)
+		list.Add(detail)
+		list.Add(line)
+		if issue.Fix != nil {
+			list.Add(widget.NewButton("🛠️ "+issue.Fix.Title, func() {
+				d.Hide()
+				l.applyIssueFix(issue)
+			}))
+		}
+		list.Add(widget.NewSeparator())
+	}
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(0, l.calcVH(35)))
+	d = dialog.NewCustom(fmt.Sprintf("🧠 日志诊断（%d 个问题）", len(issues)), "关闭", scroll, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
+
+// serviceControlsOf 服务对应的单独控制按钮
+func (l *GVALauncher) serviceControlsOf(service string) *serviceControls {
+	if service == launcher.ServiceBackend {
+		return &l.backendControls
+	}
+	return &l.frontendControls
+}
+
+// applyIssueFix 执行识别结果给出的修复操作
+func (l *GVALauncher) applyIssueFix(issue logrules.Issue) {
+	controls := l.serviceControlsOf(issue.Service)
+	switch issue.Fix.Action {
+	case logrules.FixDisableRedis:
+		if err := config.WriteUseRedis(l.project.Root, false); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.loadRedisConfig()
+		l.restartService(launcher.ServiceBackend, &l.backendControls)
+
+	case logrules.FixOpenConfig:
+		fyne.CurrentApp().OpenURL(fileURL(config.GVAConfigPath(l.project.Root)))
+
+	case logrules.FixFreePort:
+		if issue.Port <= 0 {
+			return
+		}
+		dialog.ShowConfirm("结束进程", fmt.Sprintf("确定结束占用端口 %d 的进程并重新启动%s？", issue.Port, launcher.ServiceLabel(issue.Service)), func(ok bool) {
+			if !ok {
+				return
+			}
+			l.background("释放端口", func(context.Context) func() {
+				services.KillProcessByPort(issue.Port)
+				return func() { l.startService(issue.Service, controls) }
+			})
+		}, l.window)
+
+	case logrules.FixReinstallEsbuild:
+		if !l.ensureProjectOwner() {
+			return
+		}
+		fix := deps.Fix{Action: deps.FixReinstall, Packages: []string{"esbuild", "@esbuild"}, Title: issue.Fix.Title}
+		job := l.jobs.Submit(fix.Title, func(ctx context.Context, j *jobs.Job) error {
+			return l.deps.Fix(fix, j)
+		})
+		l.waitJob(job, "🧠 日志诊断", fix.Title+"...", func(err error) {
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+			case err != nil:
+				l.showError(err, nil)
+			case !l.services.Frontend.IsRunning():
+				l.startService(launcher.ServiceFrontend, controls)
+			}
+		})
+	}
+}
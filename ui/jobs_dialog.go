@@ -0,0 +1,319 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/eta"
+	"gva-launcher/events"
+	"gva-launcher/jobs"
+)
+
+// jobRow 任务中心中的一个任务
+type jobRow struct {
+	job       *jobs.Job
+	title     *widget.Label
+	bar       *widget.ProgressBar
+	barBusy   *widget.ProgressBarInfinite
+	cancelBtn *widget.Button
+	retryBtn  *widget.Button
+	pauseBtn  *widget.Button // 可暂停的任务（下载依赖）暂停 / 继续
+	typical   string         // 同名任务平时的耗时（没有记录时为空）
+}
+
+// refresh 根据任务当前状态刷新显示
+func (r *jobRow) refresh() {
+	status := r.job.Status()
+	r.title.SetText(jobTitle(r.job, r.typical))
+
+	progress := r.job.Progress()
+	switch {
+	case status == jobs.StatusRunning && progress < 0:
+		r.bar.Hide()
+		r.barBusy.Show()
+		r.barBusy.Start()
+	case status == jobs.StatusRunning:
+		r.barBusy.Stop()
+		r.barBusy.Hide()
+		r.bar.Show()
+		r.bar.SetValue(progress)
+	case status == jobs.StatusSucceeded:
+		r.barBusy.Stop()
+		r.barBusy.Hide()
+		r.bar.Show()
+		r.bar.SetValue(1)
+	case status == jobs.StatusPaused:
+		// 暂停时保留已完成的进度
+		r.barBusy.Stop()
+		r.barBusy.Hide()
+		r.bar.Show()
+		r.bar.SetValue(max(progress, 0))
+	default:
+		r.barBusy.Stop()
+		r.barBusy.Hide()
+		r.bar.Show()
+		r.bar.SetValue(0)
+	}
+
+	if status.Finished() {
+		r.cancelBtn.Disable()
+	} else {
+		r.cancelBtn.Enable()
+	}
+	if status == jobs.StatusFailed || status == jobs.StatusCanceled {
+		r.retryBtn.Enable()
+	} else {
+		r.retryBtn.Disable()
+	}
+
+	if status == jobs.StatusPaused {
+		r.pauseBtn.SetText("▶ 继续")
+	} else {
+		r.pauseBtn.SetText("⏸ 暂停")
+	}
+	if status == jobs.StatusRunning || status == jobs.StatusPaused {
+		r.pauseBtn.Enable()
+	} else {
+		r.pauseBtn.Disable()
+	}
+}
+
+// jobTitle 任务的标题行：编号、名称、状态和耗时（执行中为开始时间和平时的耗时）
+func jobTitle(j *jobs.Job, typical string) string {
+	status := j.Status()
+	icon := map[jobs.Status]string{
+		jobs.StatusPending:   "🕒",
+		jobs.StatusRunning:   "⏳",
+		jobs.StatusSucceeded: "✅",
+		jobs.StatusFailed:    "❌",
+		jobs.StatusCanceled:  "⛔",
+		jobs.StatusPaused:    "⏸️",
+	}[status]
+
+	created, started, finished := j.Times()
+	text := fmt.Sprintf("%s #%d %s　%s　%s", icon, j.ID, j.Name, status.Label(), created.Format("15:04:05"))
+	// 界面只在任务事件发生时刷新，执行中的任务显示开始时间而不是不断变化的耗时
+	switch {
+	case !finished.IsZero() && !started.IsZero():
+		text += "　耗时 " + finished.Sub(started).Round(time.Second).String()
+	case !started.IsZero():
+		text += "　开始于 " + started.Format("15:04:05")
+		if typical != "" {
+			text += "，平时约 " + typical
+		}
+	}
+	if err := j.Err(); err != nil && status == jobs.StatusFailed {
+		text += "\n　　" + firstLine(err.Error())
+	}
+	return text
+}
+
+// showJobsDialog 显示任务中心：所有后台任务（安装、清理、构建、钩子、定时任务）的进度，
+// 支持取消、重试、查看日志以及暂停整个队列
+func (l *GVALauncher) showJobsDialog() {
+	rowsBox := container.NewVBox()
+	rows := make(map[int]*jobRow)
+
+	addRow := func(j *jobs.Job) {
+		row := &jobRow{
+			job:     j,
+			title:   widget.NewLabel(""),
+			bar:     widget.NewProgressBar(),
+			barBusy: widget.NewProgressBarInfinite(),
+		}
+		if d, ok := l.jobs.Typical(j.Name); ok {
+			row.typical = eta.FormatDuration(d)
+		}
+		row.cancelBtn = widget.NewButton("⛔ 取消", func() {
+			j.Cancel()
+			row.refresh()
+		})
+		row.retryBtn = widget.NewButton("🔁 重试", func() {
+			l.jobs.Retry(j)
+		})
+		row.pauseBtn = widget.NewButton("⏸ 暂停", func() {
+			if j.Status() == jobs.StatusPaused {
+				j.Resume()
+			} else {
+				j.Pause()
+			}
+			row.refresh()
+		})
+		if !j.Pausable() {
+			row.pauseBtn.Hide()
+		}
+		logBtn := widget.NewButton("📄 日志", func() {
+			l.showJobLog(j)
+		})
+		row.refresh()
+		rows[j.ID] = row
+
+		// 新任务显示在最上面
+		rowsBox.Objects = append([]fyne.CanvasObject{container.NewVBox(
+			row.title,
+			container.NewBorder(nil, nil, nil, container.NewHBox(row.pauseBtn, row.cancelBtn, row.retryBtn, logBtn),
+				container.NewStack(row.bar, row.barBusy)),
+			widget.NewSeparator(),
+		)}, rowsBox.Objects...)
+		rowsBox.Refresh()
+	}
+
+	emptyLabel := widget.NewLabel("暂无任务。安装依赖、清理缓存、事件钩子和定时任务都会在这里显示。")
+	refresh := func() {
+		for _, j := range l.jobs.Jobs() {
+			if row, ok := rows[j.ID]; ok {
+				row.refresh()
+			} else {
+				addRow(j)
+			}
+		}
+		if len(rows) > 0 {
+			emptyLabel.Hide()
+		}
+	}
+
+	var pauseBtn *widget.Button
+	pauseText := func() string {
+		if l.jobs.Paused() {
+			return "▶ 恢复队列"
+		}
+		return "⏸ 暂停队列"
+	}
+	pauseBtn = widget.NewButton(pauseText(), func() {
+		if l.jobs.Paused() {
+			l.jobs.Resume()
+		} else {
+			l.jobs.Pause()
+		}
+		pauseBtn.SetText(pauseText())
+	})
+
+	logPath := l.jobs.LogPath()
+	logBox := container.NewBorder(nil, nil, nil,
+		widget.NewButton("　📋 复制　", func() {
+			l.copyPathToClipboard(logPath, "任务日志路径")
+		}),
+		widget.NewLabel("完整日志: "+logPath),
+	)
+
+	help := widget.NewLabel("暂停队列后，正在执行的任务会继续完成，其余任务等待恢复后再执行。取消执行中的任务时，任务会在当前命令结束后停止。" +
+		"下载依赖的任务可以单独暂停（结束当前的下载），继续时已下载的包和模块从缓存中复用，网络中断时也会自动重试。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(container.NewVBox(emptyLabel, rowsBox))
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(85), l.calcVH(40)))
+
+	content := container.NewBorder(
+		container.NewVBox(help, pauseBtn),
+		logBox,
+		nil, nil,
+		scroll,
+	)
+	refresh()
+
+	// 对话框打开期间订阅任务事件，任务提交、开始、更新进度或结束时刷新
+	unsubscribe := l.subscribe(func(events.Event) { refresh() }, events.JobProgress)
+
+	d := dialog.NewCustom("📋 任务中心", "关闭", content, l.window)
+	d.SetOnClosed(unsubscribe)
+	d.Show()
+}
+
+// showJobLog 显示单个任务的输出（日志文件中以 [#编号 名称] 开头的行）
+func (l *GVALauncher) showJobLog(j *jobs.Job) {
+	output := j.Output()
+	if output == "" {
+		output = "（没有输出）"
+	}
+	if err := j.Err(); err != nil {
+		output += "\n错误: " + err.Error()
+	}
+
+	text := widget.NewMultiLineEntry()
+	text.SetText(output)
+	text.Wrapping = fyne.TextWrapWord
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+
+	content := container.NewBorder(
+		widget.NewLabel(fmt.Sprintf("日志文件中对应的行以 [#%d %s] 开头", j.ID, j.Name)),
+		nil, nil, nil,
+		text,
+	)
+	d := dialog.NewCustom(fmt.Sprintf("📄 #%d %s", j.ID, j.Name), "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(80), l.calcVH(50)))
+	d.Show()
+}
+
+// waitJob 显示任务进度对话框（可切换到后台运行），任务结束后在主线程中调用 onDone
+// 任务报告进度时显示进度条，并显示已用时间、按进度或以往耗时估计的剩余时间以及速度
+func (l *GVALauncher) waitJob(j *jobs.Job, title, message string, onDone func(err error)) {
+	bar := widget.NewProgressBar()
+	bar.Hide()
+	busy := widget.NewProgressBarInfinite()
+	detail := widget.NewLabel("排队中...")
+	content := container.NewVBox(widget.NewLabel(message), container.NewStack(bar, busy), detail)
+	progress := dialog.NewCustom(title, "后台运行", content, l.window)
+	progress.Show()
+
+	typical, hasTypical := l.jobs.Typical(j.Name)
+	meter := eta.NewMeter(10 * time.Second)
+	// 已用时间和剩余时间随时间变化，除任务事件外每秒（Tick 事件）刷新一次
+	l.watchJob(j, func() {
+		if j.Status() == jobs.StatusPaused {
+			detail.SetText("⏸️ 已暂停，可在「📋 任务中心」中继续")
+			return
+		}
+		_, started, _ := j.Times()
+		if started.IsZero() {
+			return
+		}
+		now := time.Now()
+		count, unit := j.Count()
+		meter.Observe(now, count)
+		p := j.Progress()
+		if p >= 0 {
+			busy.Stop()
+			busy.Hide()
+			bar.Show()
+			bar.SetValue(p)
+		}
+		detail.SetText(eta.Describe(now.Sub(started), p, typical, hasTypical, meter.Rate(), unit))
+	}, func(err error) {
+		progress.Hide()
+		onDone(err)
+	}, events.Tick)
+}
+
+// watchJob 订阅任务的 JobProgress 事件（以及 topics 中的其他事件，例如 JobOutput、Tick），
+// 任务有变化时在主线程中调用 onChange（可为 nil），任务结束时调用一次 onDone 并取消订阅。在主线程中调用
+func (l *GVALauncher) watchJob(j *jobs.Job, onChange func(), onDone func(err error), topics ...events.Topic) {
+	finished := false
+	var unsubscribe func()
+	finish := func() {
+		finished = true
+		unsubscribe()
+		onDone(j.Err())
+	}
+	unsubscribe = l.subscribe(func(e events.Event) {
+		if finished || (e.Topic != events.Tick && e.Job.ID != j.ID) {
+			return
+		}
+		if j.Status().Finished() {
+			finish()
+			return
+		}
+		if onChange != nil {
+			onChange()
+		}
+	}, append(topics, events.JobProgress)...)
+
+	// 订阅之前已经结束的任务不会再发布事件
+	if j.Status().Finished() {
+		finish()
+	}
+}
@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/kube"
+	"gva-launcher/registry"
+)
+
+// kubeFormats 生成格式（下拉框顺序）
+var kubeFormats = []string{"Kubernetes 清单", "Helm values.yaml"}
+
+// kubeOutputDir 生成的文件保存在项目中的目录
+const kubeOutputDir = "deploy/k8s"
+
+// showKubeDialog K8s 清单：根据项目的端口、镜像仓库设置和环境变量生成 Deployment / Service / Ingress
+// 或 Helm values.yaml，作为部署到集群的起点
+func (l *GVALauncher) showKubeDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg := l.config.Kube
+
+	// 镜像默认使用「🐳 镜像推送」上次推送的标签
+	serverImage, webImage := kube.ServerName+":latest", kube.WebName+":latest"
+	if r := l.config.Registry; r.Namespace != "" {
+		tag := r.LastTag
+		if tag == "" {
+			tag = "latest"
+		}
+		reg := registry.Registry{Kind: r.Kind, Server: r.Server, Namespace: r.Namespace}
+		serverImage, webImage = reg.Reference(kube.ServerName, tag), reg.Reference(kube.WebName, tag)
+	}
+
+	formatSelect := widget.NewSelect(kubeFormats, nil)
+	formatSelect.SetSelected(kubeFormats[0])
+	if cfg.Helm {
+		formatSelect.SetSelected(kubeFormats[1])
+	}
+	namespaceEntry := widget.NewEntry()
+	namespaceEntry.SetPlaceHolder("例如: gva（留空使用 kubectl 的当前命名空间）")
+	namespaceEntry.SetText(cfg.Namespace)
+	hostEntry := widget.NewEntry()
+	hostEntry.SetPlaceHolder("例如: admin.example.com（留空则不生成 Ingress）")
+	hostEntry.SetText(cfg.Host)
+	tlsEntry := widget.NewEntry()
+	tlsEntry.SetPlaceHolder("证书 Secret 名称（留空则不配置 HTTPS）")
+	tlsEntry.SetText(cfg.TLSSecret)
+	replicasEntry := widget.NewEntry()
+	replicasEntry.SetPlaceHolder("1")
+	if cfg.Replicas > 0 {
+		replicasEntry.SetText(strconv.Itoa(cfg.Replicas))
+	}
+	serverImageEntry := widget.NewEntry()
+	serverImageEntry.SetText(serverImage)
+	webImageEntry := widget.NewEntry()
+	webImageEntry.SetText(webImage)
+	envEntry := widget.NewMultiLineEntry()
+	envEntry.SetPlaceHolder("后端容器的环境变量，每行一个 KEY=VALUE，例如 TZ=Asia/Shanghai")
+	envEntry.SetMinRowsVisible(3)
+	envEntry.SetText(cfg.Env)
+
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapOff
+	output.SetMinRowsVisible(14)
+
+	// generate 读取表单并生成内容，同时保存设置
+	generate := func() (string, error) {
+		k := config.Kube{
+			Namespace: strings.TrimSpace(namespaceEntry.Text),
+			Host:      strings.TrimSpace(hostEntry.Text),
+			TLSSecret: strings.TrimSpace(tlsEntry.Text),
+			Env:       strings.TrimSpace(envEntry.Text),
+			Helm:      formatSelect.Selected == kubeFormats[1],
+		}
+		if text := strings.TrimSpace(replicasEntry.Text); text != "" {
+			replicas, err := strconv.Atoi(text)
+			if err != nil || replicas <= 0 {
+				return "", fmt.Errorf("副本数需要是正整数")
+			}
+			k.Replicas = replicas
+		}
+		env, err := kube.ParseEnv(k.Env)
+		if err != nil {
+			return "", err
+		}
+
+		backendPort, _ := l.project.Ports()
+		spec := kube.Spec{
+			Namespace:   k.Namespace,
+			Host:        k.Host,
+			TLSSecret:   k.TLSSecret,
+			ServerImage: strings.TrimSpace(serverImageEntry.Text),
+			WebImage:    strings.TrimSpace(webImageEntry.Text),
+			ServerPort:  backendPort,
+			Replicas:    k.Replicas,
+			APIPrefix:   config.ReadProductionEnv(l.project.Root).BaseAPI,
+			Env:         env,
+		}
+		if gvaConfig, err := l.project.ReadConfig(); err == nil {
+			spec.RouterPrefix = gvaConfig.System.RouterPrefix
+		}
+		var data []byte
+		if k.Helm {
+			data, err = kube.HelmValues(spec)
+		} else {
+			data, err = kube.Manifests(spec)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		l.config.Kube = k
+		if err := l.saveConfig(); err != nil {
+			return "", fmt.Errorf("保存配置失败: %w", err)
+		}
+		return string(data), nil
+	}
+
+	generateBtn := widget.NewButton("⚙️ 生成", func() {
+		text, err := generate()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		output.SetText(text)
+	})
+	copyBtn := widget.NewButton("📋 复制", func() {
+		if output.Text == "" {
+			generateBtn.OnTapped()
+		}
+		if output.Text != "" {
+			l.copyToClipboard(output.Text, "生成的内容")
+		}
+	})
+	saveBtn := widget.NewButton("💾 保存到项目", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		text, err := generate()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		output.SetText(text)
+		name := "gva.yaml"
+		if formatSelect.Selected == kubeFormats[1] {
+			name = "values.yaml"
+		}
+		path := filepath.Join(l.project.Root, filepath.FromSlash(kubeOutputDir), name)
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(text), 0644)
+		}
+		if err != nil {
+			l.showError(fmt.Errorf("保存失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("已保存", "已保存到:\n"+path, l.window)
+	})
+
+	help := widget.NewLabel("根据项目的后端端口、路由前缀、.env.production 的接口前缀和「🐳 镜像推送」的镜像生成前后端的 Deployment、Service 和 Ingress" +
+		"（接口路径按 ingress-nginx 的写法改写），或一份 Helm values.yaml。后端的 config.yaml 从 Secret 挂载，创建命令写在生成内容的开头。生成的内容只是起点，请按集群的实际情况修改。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("格式", formatSelect),
+		widget.NewFormItem("命名空间", namespaceEntry),
+		widget.NewFormItem("域名", hostEntry),
+		widget.NewFormItem("证书 Secret", tlsEntry),
+		widget.NewFormItem("副本数", replicasEntry),
+		widget.NewFormItem("后端镜像", serverImageEntry),
+		widget.NewFormItem("前端镜像", webImageEntry),
+		widget.NewFormItem("环境变量", envEntry),
+	)
+	top := container.NewVBox(help, form, container.NewGridWithColumns(3, generateBtn, copyBtn, saveBtn))
+	d := dialog.NewCustom("☸️ K8s 清单", "关闭", container.NewBorder(top, nil, nil, nil, output), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(65), l.calcVH(85)))
+	d.Show()
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ========================================
+// 响应式按钮容器
+// ========================================
+
+// ResponsiveButton 响应式按钮容器，按钮宽度会根据窗口大小动态调整
+type ResponsiveButton struct {
+	widget.BaseWidget
+	button    *widget.Button
+	widthVW   float32
+	launcher  *GVALauncher
+	container *fyne.Container
+}
+
+// NewResponsiveButton 创建响应式按钮
+func NewResponsiveButton(launcher *GVALauncher, button *widget.Button, widthVW float32) *ResponsiveButton {
+	rb := &ResponsiveButton{
+		button:   button,
+		widthVW:  widthVW,
+		launcher: launcher,
+	}
+	rb.ExtendBaseWidget(rb)
+	rb.updateSize()
+	return rb
+}
+
+// updateSize 更新按钮尺寸
+func (rb *ResponsiveButton) updateSize() {
+	width := rb.launcher.calcVW(rb.widthVW)
+	rb.container = container.NewMax(
+		canvas.NewRectangle(color.Transparent),
+		rb.button,
+	)
+	// 强制设置最小尺寸
+	rb.container.Resize(fyne.NewSize(width, 0))
+}
+
+// CreateRenderer 创建渲染器
+func (rb *ResponsiveButton) CreateRenderer() fyne.WidgetRenderer {
+	rb.updateSize()
+	return widget.NewSimpleRenderer(rb.container)
+}
+
+// Refresh 刷新组件（窗口大小改变时调用）
+func (rb *ResponsiveButton) Refresh() {
+	rb.updateSize()
+	rb.BaseWidget.Refresh()
+}
+
+// resizeLayout 窗口内容的布局：唯一的子元素铺满窗口，尺寸变化（窗口大小改变）时调用 onResize，
+// 代替定时检查窗口大小
+type resizeLayout struct {
+	last     fyne.Size
+	onResize func()
+}
+
+// Layout 铺满子元素，尺寸与上次不同时回调（首次布局不回调）
+func (r *resizeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		o.Move(fyne.NewPos(0, 0))
+		o.Resize(size)
+	}
+	if size == r.last {
+		return
+	}
+	first := r.last.IsZero()
+	r.last = size
+	if !first && r.onResize != nil {
+		r.onResize()
+	}
+}
+
+// MinSize 子元素的最小尺寸
+func (r *resizeLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var size fyne.Size
+	for _, o := range objects {
+		size = size.Max(o.MinSize())
+	}
+	return size
+}
+
+// ========================================
+// vh/vw 视口单位辅助函数（类似 CSS）
+// ========================================
+
+// vh 创建垂直间距 - 基于窗口高度的百分比
+// 参数 v 相当于 CSS 中的 vh 单位
+// 例如：vh(2) 相当于 CSS 的 2vh
+func (l *GVALauncher) vh(v float32) *canvas.Rectangle {
+	height := l.windowHeight * (v / 100)
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(1, height))
+	return spacer
+}
+
+// vw 创建水平间距 - 基于窗口宽度的百分比
+// 参数 v 相当于 CSS 中的 vw 单位
+// 例如：vw(2) 相当于 CSS 的 2vw
+func (l *GVALauncher) vw(v float32) *canvas.Rectangle {
+	width := l.windowWidth * (v / 100)
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(width, 1))
+	return spacer
+}
+
+// calcVH 计算 vh 值（用于需要数值的地方）
+func (l *GVALauncher) calcVH(v float32) float32 {
+	return l.windowHeight * (v / 100)
+}
+
+// calcVW 计算 vw 值（用于需要数值的地方）
+func (l *GVALauncher) calcVW(v float32) float32 {
+	return l.windowWidth * (v / 100)
+}
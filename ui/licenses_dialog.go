@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/jobs"
+	"gva-launcher/licenses"
+	"gva-launcher/sbom"
+)
+
+// showLicensesDialog 许可证清单：扫描前后端依赖的许可证，标出著佐权和没有识别出许可证的依赖，可导出 CSV
+func (l *GVALauncher) showLicensesDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+
+	var report licenses.Report
+	summary := widget.NewLabel("点击「扫描」生成清单。前端读取 web/node_modules 中各包的 package.json，后端用 go list 找出编译进后端的模块并识别许可证文件。")
+	summary.Wrapping = fyne.TextWrapWord
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapOff
+	output.SetMinRowsVisible(16)
+
+	csvText := func() (string, error) {
+		var buf bytes.Buffer
+		err := licenses.WriteCSV(&buf, report.Dependencies)
+		return buf.String(), err
+	}
+	exportBtn := widget.NewButton("💾 导出 CSV", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		text, err := csvText()
+		path := filepath.Join(l.project.Root, licenses.ReportName)
+		if err == nil {
+			err = os.WriteFile(path, []byte(text), 0644)
+		}
+		if err != nil {
+			l.showError(fmt.Errorf("导出失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("已导出", "已导出到:\n"+path, l.window)
+	})
+	copyBtn := widget.NewButton("📋 复制 CSV", func() {
+		if text, err := csvText(); err == nil {
+			l.copyToClipboard(text, "许可证清单")
+		}
+	})
+	exportBtn.Disable()
+	copyBtn.Disable()
+
+	var scanBtn *widget.Button
+	scanBtn = widget.NewButton("🔍 扫描", func() {
+		scanBtn.Disable()
+		root := l.project.Root
+		goFlags := deps.GoBuildFlags(l.project.ServerDir())
+		var result licenses.Report
+		job := l.jobs.Submit("扫描依赖许可证", func(ctx context.Context, j *jobs.Job) error {
+			var err error
+			result, err = licenses.Scan(ctx, root, goFlags)
+			return err
+		})
+		l.waitJob(job, "📜 许可证清单", "正在扫描前后端依赖的许可证...", func(err error) {
+			scanBtn.Enable()
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+			case err != nil:
+				l.showError(err, nil)
+			default:
+				report = result
+				summary.SetText(licensesSummary(report))
+				output.SetText(licensesText(report))
+				exportBtn.Enable()
+				copyBtn.Enable()
+			}
+		})
+	})
+
+	sbomBtn := widget.NewButton("🧾 生成 SBOM", func() {
+		l.generateSBOM()
+	})
+
+	top := container.NewVBox(summary, container.NewGridWithColumns(4, scanBtn, exportBtn, copyBtn, sbomBtn))
+	d := dialog.NewCustom("📜 许可证清单", "关闭", container.NewBorder(top, nil, nil, nil, output), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(65), l.calcVH(80)))
+	d.Show()
+}
+
+// generateSBOM 生成 CycloneDX 和 SPDX 格式的软件物料清单，写入 server/（与构建产物放在一起）
+func (l *GVALauncher) generateSBOM() {
+	if !l.ensureProjectOwner() {
+		return
+	}
+	dir := l.project.ServerDir()
+	var doc sbom.Document
+	var paths []string
+	job := l.jobs.Submit("生成软件物料清单", func(ctx context.Context, j *jobs.Job) error {
+		var err error
+		if doc, err = l.builds.SBOM(ctx); err != nil {
+			return err
+		}
+		paths, err = doc.WriteFiles(dir)
+		return err
+	})
+	l.waitJob(job, "🧾 软件物料清单", "正在读取前后端的依赖...", func(err error) {
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			text := fmt.Sprintf("已生成 %d 个组件的软件物料清单:\n%s", len(doc.Components), strings.Join(paths, "\n"))
+			for _, w := range doc.Warnings {
+				text += "\n⚠️ " + w
+			}
+			dialog.ShowInformation("🧾 软件物料清单", text, l.window)
+		}
+	})
+}
+
+// licensesSummary 清单的统计
+func licensesSummary(report licenses.Report) string {
+	counts := map[string]int{}
+	var strong, weak, unknown int
+	for _, d := range report.Dependencies {
+		counts[d.Ecosystem]++
+		switch {
+		case d.Copyleft == licenses.Strong:
+			strong++
+		case d.Copyleft == licenses.Weak:
+			weak++
+		case d.License == licenses.Unknown:
+			unknown++
+		}
+	}
+	text := fmt.Sprintf("共 %d 个依赖（前端 %d，后端 %d）：强著佐权 %d，弱著佐权 %d，未识别 %d",
+		len(report.Dependencies), counts[licenses.Npm], counts[licenses.Go], strong, weak, unknown)
+	if strong > 0 {
+		text += "。⚠️ 强著佐权（GPL、AGPL 等）的依赖可能要求以相同许可证开源整个产品，发布前请确认"
+	}
+	for _, w := range report.Warnings {
+		text += "\n⚠️ " + w
+	}
+	return text
+}
+
+// licensesText 需要关注的依赖在前，其后是完整清单
+func licensesText(report licenses.Report) string {
+	var b strings.Builder
+	line := func(d licenses.Dependency) {
+		mark := d.Copyleft.Label()
+		if mark == "" && d.License == licenses.Unknown {
+			mark = "未识别"
+		}
+		fmt.Fprintf(&b, "%-4s %-50s %-14s %-24s %s\n", d.Ecosystem, d.Name, d.Version, d.License, mark)
+	}
+	if flagged := report.Flagged(); len(flagged) > 0 {
+		b.WriteString("===== 需要关注 =====\n")
+		for _, d := range flagged {
+			line(d)
+		}
+		b.WriteString("\n===== 全部依赖 =====\n")
+	}
+	for _, d := range report.Dependencies {
+		line(d)
+	}
+	return b.String()
+}
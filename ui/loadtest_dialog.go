@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apiroutes"
+	"gva-launcher/apperr"
+	"gva-launcher/jobs"
+	"gva-launcher/loadtest"
+)
+
+// 压测参数的默认值
+const (
+	loadTestDefaultPath        = "/user/getUserInfo"
+	loadTestDefaultRequests    = 200
+	loadTestDefaultConcurrency = 10
+)
+
+// loadTestURL 由后端 API 根地址和输入的路径得到完整地址（输入完整地址时原样使用）
+func loadTestURL(baseURL, path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimRight(baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// showLoadTestDialog 显示压测窗口；method、path 不为空时预先填入（从 API 浏览进入时）
+func (l *GVALauncher) showLoadTestDialog(method, path string) {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg, err := l.project.ReadConfig()
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+	backendPort, _ := l.project.Ports()
+	baseURL := apiroutes.BaseURL(backendPort, cfg.System.RouterPrefix)
+
+	// 沿用上一次的参数（token 只保存在内存中）
+	opts := l.loadTestOptions
+	if opts.Requests == 0 {
+		opts = loadtest.Options{Method: "GET", URL: loadTestDefaultPath, Requests: loadTestDefaultRequests, Concurrency: loadTestDefaultConcurrency}
+	}
+	if path != "" {
+		opts.Method, opts.URL, opts.Body = method, path, ""
+	}
+
+	methodSelect := widget.NewSelect([]string{"GET", "POST", "PUT", "DELETE"}, nil)
+	methodSelect.SetSelected(opts.Method)
+	pathEntry := widget.NewEntry()
+	pathEntry.SetPlaceHolder("接口路径（相对后端 API 根地址）或完整地址")
+	pathEntry.SetText(opts.URL)
+	tokenEntry := widget.NewPasswordEntry()
+	tokenEntry.SetPlaceHolder("登录后浏览器 localStorage 中的 token，放在 x-token 请求头中")
+	tokenEntry.SetText(opts.Token)
+	bodyEntry := widget.NewMultiLineEntry()
+	bodyEntry.SetPlaceHolder(`请求体（JSON，可留空），例如 {"page":1,"pageSize":10}`)
+	bodyEntry.SetText(opts.Body)
+	bodyEntry.SetMinRowsVisible(2)
+	requestsEntry := widget.NewEntry()
+	requestsEntry.SetText(strconv.Itoa(opts.Requests))
+	concurrencyEntry := widget.NewEntry()
+	concurrencyEntry.SetText(strconv.Itoa(opts.Concurrency))
+
+	result := widget.NewLabel("还没有执行过压测")
+	if l.loadTestReport != nil {
+		result.SetText("上一次结果:\n" + l.loadTestReport.Summary())
+	}
+	result.Wrapping = fyne.TextWrapWord
+	copyBtn := widget.NewButton("📋 复制结果", func() {
+		l.copyToClipboard(result.Text, "压测结果")
+	})
+
+	var runBtn *widget.Button
+	runBtn = widget.NewButton("▶️ 开始压测", func() {
+		requests, err1 := strconv.Atoi(strings.TrimSpace(requestsEntry.Text))
+		concurrency, err2 := strconv.Atoi(strings.TrimSpace(concurrencyEntry.Text))
+		if err1 != nil || err2 != nil || requests <= 0 || concurrency <= 0 {
+			l.showError(fmt.Errorf("请求数和并发数必须是正整数"), nil)
+			return
+		}
+		edited := loadtest.Options{
+			Method:      methodSelect.Selected,
+			URL:         strings.TrimSpace(pathEntry.Text),
+			Body:        strings.TrimSpace(bodyEntry.Text),
+			Token:       strings.TrimSpace(tokenEntry.Text),
+			Requests:    requests,
+			Concurrency: concurrency,
+		}
+		l.loadTestOptions = edited
+		run := edited
+		run.URL = loadTestURL(baseURL, edited.URL)
+
+		runBtn.Disable()
+		l.runLoadTest(run, func(report loadtest.Report) {
+			runBtn.Enable()
+			text := report.Summary()
+			if prev := l.loadTestReport; prev != nil && prev.URL == report.URL && prev.Method == report.Method {
+				text += "\n\n与上一次相比:\n" + loadtest.Compare(*prev, report)
+			}
+			l.loadTestReport = &report
+			result.SetText(text)
+		}, runBtn.Enable)
+	})
+
+	status := "⚠️ 后端未运行，请先启动服务"
+	if l.services.IsRunning() {
+		status = "后端地址: " + baseURL
+	}
+	help := widget.NewLabel("以设定的并发数向一个接口发送请求，统计延迟分位数（p50 / p90 / p99）和错误率；" +
+		"网络错误、HTTP 4xx / 5xx 和 GVA 业务错误码不为 0 的响应都计为错误。" +
+		"调整中间件（限流、操作记录、Casbin 等）前后各压测一次，结果会与上一次同一接口的压测对比。" +
+		"压测会写入操作记录等数据，请勿对生产环境使用。\n" + status)
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("接口", container.NewBorder(nil, nil, methodSelect, nil, pathEntry)),
+		widget.NewFormItem("x-token", tokenEntry),
+		widget.NewFormItem("请求体", bodyEntry),
+		widget.NewFormItem("请求数", requestsEntry),
+		widget.NewFormItem("并发数", concurrencyEntry),
+	)
+	content := container.NewVBox(
+		help,
+		form,
+		container.NewHBox(runBtn, copyBtn),
+		widget.NewSeparator(),
+		result,
+	)
+
+	d := dialog.NewCustom("⏱️ 接口压测", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(70), 0))
+	d.Show()
+}
+
+// runLoadTest 作为任务立即执行压测并显示进度，可中途停止；完成（包括停止）后在界面线程调用 onDone，出错时调用 onFail
+func (l *GVALauncher) runLoadTest(opts loadtest.Options, onDone func(loadtest.Report), onFail func()) {
+	bar := widget.NewProgressBar()
+	status := widget.NewLabel(fmt.Sprintf("%s %s（并发 %d）", opts.Method, opts.URL, opts.Concurrency))
+
+	// 进度最多刷新 100 次；停止时压测返回已完成部分的报告
+	step := max(opts.Requests/100, 1)
+	var report loadtest.Report
+	job := l.jobs.Go("接口压测", func(ctx context.Context, j *jobs.Job) error {
+		var err error
+		report, err = loadtest.Run(ctx, opts, func(done int) {
+			if done%step == 0 || done == opts.Requests {
+				j.SetProgress(float64(done) / float64(opts.Requests))
+			}
+		})
+		return err
+	})
+
+	stopBtn := widget.NewButton("⏹️ 停止", func() { job.Cancel() })
+	progress := dialog.NewCustomWithoutButtons("⏱️ 正在压测", container.NewVBox(status, bar, container.NewHBox(stopBtn)), l.window)
+	progress.Resize(fyne.NewSize(l.calcVW(50), 0))
+	progress.Show()
+
+	l.watchJob(job, func() {
+		if p := job.Progress(); p >= 0 {
+			bar.SetValue(p)
+		}
+	}, func(err error) {
+		progress.Hide()
+		if err != nil && !errors.Is(err, jobs.ErrCanceled) {
+			l.showError(err, nil)
+			onFail()
+			return
+		}
+		onDone(report)
+	})
+}
@@ -0,0 +1,354 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/ansi"
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/launcher"
+	"gva-launcher/logfilter"
+	"gva-launcher/outputbuf"
+	"gva-launcher/supervisor"
+)
+
+// logRefreshInterval 两次刷新日志面板的最短间隔（输出频繁时合并刷新）
+const logRefreshInterval = 200 * time.Millisecond
+
+// logLimitOptions 日志面板可选的保留行数
+var logLimitOptions = []int{500, config.DefaultLogLines, 10000}
+
+// viteReadyPattern Vite 开发服务器就绪时输出的耗时（VITE v5.x  ready in 812 ms）
+var viteReadyPattern = regexp.MustCompile(`ready in\s+([\d.]+)\s*ms`)
+
+// ansiColorNames 输出中的颜色对应的主题颜色（随深浅色和高对比度主题变化），未列出的颜色使用前景色
+var ansiColorNames = map[ansi.Color]fyne.ThemeColorName{
+	ansi.Red:     theme.ColorNameError,
+	ansi.Green:   theme.ColorNameSuccess,
+	ansi.Yellow:  theme.ColorNameWarning,
+	ansi.Blue:    theme.ColorNamePrimary,
+	ansi.Magenta: theme.ColorNamePrimary,
+	ansi.Cyan:    theme.ColorNameHyperlink,
+	ansi.Gray:    theme.ColorNamePlaceHolder,
+}
+
+// logPanel 下方面板中的服务输出标签页：实时跟踪一个服务进程的输出，按输出中的颜色控制码着色显示，
+// 可按关键字（或正则表达式）和级别筛选
+type logPanel struct {
+	tab       *container.TabItem
+	list      *widget.List
+	status    *widget.Label
+	limit     *widget.Select
+	search    *widget.Entry
+	regex     *widget.Check
+	level     *widget.Select
+	service   string
+	lines     []string // 原始输出（含颜色控制码）
+	view      []int    // 符合筛选条件的行在 lines 中的下标（没有筛选条件时为 nil，显示全部）
+	filterErr error    // 筛选条件无效（正则表达式写错）时显示全部行并提示
+	seq       int      // 已读取到的输出序号（见 outputbuf.Buffer.Since）
+	paused    bool     // 暂停时不刷新，恢复后补上暂停期间的输出（已被挤出内存的部分除外）
+	follow    bool     // 新输出到达时滚动到最后一行
+	ready     string   // 最近一次 Vite 就绪的耗时（只用于前端）
+	cancel    context.CancelFunc
+}
+
+// visible 面板中显示的行（按筛选结果）
+func (p *logPanel) visible() []string {
+	if p.view == nil {
+		return p.lines
+	}
+	lines := make([]string, len(p.view))
+	for i, index := range p.view {
+		lines[i] = p.lines[index]
+	}
+	return lines
+}
+
+// visibleLine 面板中第 id 个显示的行
+func (p *logPanel) visibleLine(id int) (string, bool) {
+	if p.view != nil {
+		if id >= len(p.view) {
+			return "", false
+		}
+		id = p.view[id]
+	}
+	if id >= len(p.lines) {
+		return "", false
+	}
+	return p.lines[id], true
+}
+
+// logPanels 后端和前端的输出标签页
+func (l *GVALauncher) logPanels() []*logPanel {
+	return []*logPanel{l.logs, l.webLogs}
+}
+
+// createLogArea 创建后端和前端的服务输出标签页（所在的下方面板由「📜 日志」「🎨 前端输出」按钮切换）
+func (l *GVALauncher) createLogArea() []*container.TabItem {
+	l.logs = l.newLogPanel(launcher.ServiceBackend, "📜 后端输出")
+	l.webLogs = l.newLogPanel(launcher.ServiceFrontend, "🎨 前端输出")
+	return []*container.TabItem{l.logs.tab, l.webLogs.tab}
+}
+
+// newLogPanel 创建一个服务的输出标签页
+func (l *GVALauncher) newLogPanel(service, title string) *logPanel {
+	p := &logPanel{service: service, follow: true}
+
+	p.list = widget.NewList(
+		func() int {
+			if p.view != nil {
+				return len(p.view)
+			}
+			return len(p.lines)
+		},
+		func() fyne.CanvasObject {
+			text := widget.NewRichText()
+			text.Truncation = fyne.TextTruncateEllipsis
+			return text
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			if line, ok := p.visibleLine(id); ok {
+				text := o.(*widget.RichText)
+				text.Segments = logSegments(line)
+				text.Refresh()
+			}
+		},
+	)
+	p.status = widget.NewLabel("")
+
+	// 筛选：关键字或正则表达式（不区分大小写），以及最低级别
+	p.search = widget.NewEntry()
+	p.search.SetPlaceHolder("🔍 搜索，例如 panic 或 /api/user/login（勾选「正则」后按正则表达式匹配）")
+	p.search.OnChanged = func(string) { l.filterLogs(p) }
+	p.regex = widget.NewCheck("正则", func(bool) { l.filterLogs(p) })
+	var levelLabels []string
+	for _, level := range logfilter.Levels {
+		levelLabels = append(levelLabels, level.Label())
+	}
+	p.level = widget.NewSelect(levelLabels, func(string) { l.filterLogs(p) })
+	p.level.SetSelected(levelLabels[0])
+
+	followCheck := widget.NewCheck("自动滚动", func(on bool) {
+		p.follow = on
+		if on {
+			p.list.ScrollToBottom()
+		}
+	})
+	followCheck.SetChecked(true)
+	pauseCheck := widget.NewCheck("暂停", func(on bool) {
+		p.paused = on
+		if !on {
+			l.readLogs(p)
+		}
+		l.renderLogStatus(p)
+	})
+
+	// 保留行数是两个标签页共用的设置
+	var limitLabels []string
+	for _, n := range logLimitOptions {
+		limitLabels = append(limitLabels, fmt.Sprintf("保留 %d 行", n))
+	}
+	p.limit = widget.NewSelect(limitLabels, func(label string) {
+		limit := logLimitOptions[slices.Index(limitLabels, label)]
+		if limit != l.config.EffectiveLogLines() {
+			l.config.LogLines = limit
+			if err := l.saveConfig(); err != nil {
+				l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			}
+		}
+		for _, other := range l.logPanels() {
+			if other != nil && other != p && other.limit.Selected != label {
+				other.limit.SetSelected(label)
+			}
+		}
+		l.trimLogs(p)
+	})
+	if i := slices.Index(logLimitOptions, l.config.EffectiveLogLines()); i >= 0 {
+		p.limit.SetSelected(limitLabels[i])
+	}
+
+	clearBtn := widget.NewButton("🧹 清空", func() {
+		p.lines = nil
+		l.filterLogs(p)
+	})
+	// 有筛选条件时只复制筛选出的行
+	copyBtn := widget.NewButton("📋 复制", func() {
+		lines := p.visible()
+		for i, line := range lines {
+			lines[i] = ansi.Strip(line)
+		}
+		l.copyToClipboard(strings.Join(lines, "\n"), "日志")
+	})
+	header := container.NewVBox(
+		container.NewHBox(
+			p.status,
+			layout.NewSpacer(),
+			followCheck,
+			pauseCheck,
+			p.limit,
+			clearBtn,
+			copyBtn,
+		),
+		container.NewBorder(nil, nil, nil, container.NewHBox(p.regex, p.level), p.search),
+	)
+	p.tab = container.NewTabItem(title, container.NewBorder(header, nil, nil, nil, p.list))
+	return p
+}
+
+// logSegments 把一行输出按颜色控制码转换为 RichText 的片段（等宽字体，加粗、斜体、下划线保留，暗色显示为占位符颜色）
+func logSegments(line string) []widget.RichTextSegment {
+	parsed := ansi.Parse(line)
+	if len(parsed) == 0 {
+		return []widget.RichTextSegment{&widget.TextSegment{Style: widget.RichTextStyleCodeInline}}
+	}
+	segments := make([]widget.RichTextSegment, len(parsed))
+	for i, s := range parsed {
+		color, ok := ansiColorNames[s.Style.Color]
+		if !ok {
+			color = theme.ColorNameForeground
+			if s.Style.Dim {
+				color = theme.ColorNamePlaceHolder
+			}
+		}
+		segments[i] = &widget.TextSegment{
+			Text: s.Text,
+			Style: widget.RichTextStyle{
+				Inline:    true,
+				ColorName: color,
+				TextStyle: fyne.TextStyle{Monospace: true, Bold: s.Style.Bold, Italic: s.Style.Italic, Underline: s.Style.Underline},
+			},
+		}
+	}
+	return segments
+}
+
+// stopFollowingLogs 停止跟踪服务输出（下方面板隐藏时）
+func (l *GVALauncher) stopFollowingLogs() {
+	for _, p := range l.logPanels() {
+		if p.cancel != nil {
+			p.cancel()
+			p.cancel = nil
+		}
+	}
+}
+
+// followLogs 跟踪后端和前端的输出，有新输出时刷新对应的标签页
+func (l *GVALauncher) followLogs() {
+	for _, p := range l.logPanels() {
+		if p.cancel != nil {
+			p.cancel()
+		}
+		ctx, cancel := context.WithCancel(l.supervisor.Context())
+		p.cancel = cancel
+		l.watchOutput(ctx, launcher.ServiceLabel(p.service)+"日志", l.services.Output(p.service), func() { l.readLogs(p) })
+	}
+}
+
+// watchOutput 立即调用一次 refresh，之后每当 buf 有新输出时发布 OutputChanged 事件（Source 为 name，
+// logRefreshInterval 内的多次写入合并为一次），订阅者在界面线程中调用 refresh，直到 ctx 取消。
+// name 用来区分不同的输出，同时监听的输出不能同名
+func (l *GVALauncher) watchOutput(ctx context.Context, name string, buf *outputbuf.Buffer, refresh func()) {
+	changed, unwatch := buf.Watch()
+	refresh()
+	unsubscribe := l.subscribe(func(e events.Event) {
+		if e.Output.Source == name && ctx.Err() == nil {
+			refresh()
+		}
+	}, events.OutputChanged)
+	l.supervisor.Go(name, func(context.Context) {
+		defer unwatch()
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			}
+			l.bus.Publish(events.Event{Topic: events.OutputChanged, Output: events.OutputState{Source: name}})
+			if !supervisor.Sleep(ctx, logRefreshInterval) {
+				return
+			}
+		}
+	})
+}
+
+// readLogs 读取上次之后的新输出追加到面板（暂停时不读取），前端记下最近一次就绪的耗时
+func (l *GVALauncher) readLogs(p *logPanel) {
+	if p.paused {
+		return
+	}
+	lines, seq := l.services.Output(p.service).Since(p.seq)
+	p.seq = seq
+	if len(lines) == 0 {
+		return
+	}
+	if p.service == launcher.ServiceFrontend {
+		for _, line := range lines {
+			if m := viteReadyPattern.FindStringSubmatch(ansi.Strip(line)); m != nil {
+				p.ready = m[1]
+			}
+		}
+	}
+	p.lines = append(p.lines, lines...)
+	l.trimLogs(p)
+}
+
+// trimLogs 只保留最近的若干行，并按需滚动到最后
+func (l *GVALauncher) trimLogs(p *logPanel) {
+	if limit := l.config.EffectiveLogLines(); len(p.lines) > limit {
+		p.lines = append([]string(nil), p.lines[len(p.lines)-limit:]...)
+	}
+	l.filterLogs(p)
+	if p.follow {
+		p.list.ScrollToBottom()
+	}
+}
+
+// filterLogs 按搜索框和级别重新筛选面板中的行（筛选条件变化或有新输出时）
+func (l *GVALauncher) filterLogs(p *logPanel) {
+	filter := logfilter.Filter{
+		Query: p.search.Text,
+		Regex: p.regex.Checked,
+		Level: logfilter.Levels[max(p.level.SelectedIndex(), 0)],
+	}
+	m, err := filter.Compile()
+	p.view, p.filterErr = nil, err
+	if err == nil && m.Active() {
+		p.view = m.Match(p.lines)
+		if p.view == nil {
+			p.view = []int{}
+		}
+	}
+	p.list.Refresh()
+	l.renderLogStatus(p)
+}
+
+// renderLogStatus 显示面板中的行数（有筛选条件时为筛选出的行数）、暂停状态和前端最近一次就绪的耗时
+func (l *GVALauncher) renderLogStatus(p *logPanel) {
+	text := fmt.Sprintf("%d 行", len(p.lines))
+	switch {
+	case p.filterErr != nil:
+		text += "（⚠️ " + p.filterErr.Error() + "）"
+	case p.view != nil:
+		text = fmt.Sprintf("筛选出 %d / %d 行", len(p.view), len(p.lines))
+	}
+	if p.ready != "" {
+		text += fmt.Sprintf("　⚡ 就绪耗时 %s ms", p.ready)
+	}
+	if p.paused {
+		text += "（⏸ 已暂停）"
+	}
+	p.status.SetText(text)
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/mdns"
+	"gva-launcher/services"
+)
+
+// mdnsName 广播的主机名（未设置时使用默认值）
+func (l *GVALauncher) mdnsName() string {
+	if name := l.config.MDNS.Name; name != "" {
+		return name
+	}
+	return mdns.DefaultName
+}
+
+// mdnsURL 通过 mDNS 主机名访问前端的地址
+func (l *GVALauncher) mdnsURL() string {
+	scheme := "http"
+	if l.httpsEnabled {
+		scheme = "https"
+	}
+	return services.HostURL(scheme, l.mdnsName()+".local", l.frontendPort)
+}
+
+// startMDNS 按配置（重新）启动 mDNS 广播，未开启时只关闭旧的应答器
+func (l *GVALauncher) startMDNS() error {
+	if l.mdns != nil {
+		l.mdns.Close()
+		l.mdns = nil
+	}
+	if !l.config.MDNS.Enabled {
+		return nil
+	}
+
+	responder, err := mdns.Start(mdns.Service{
+		Name:     l.mdnsName(),
+		Instance: "GVA Panel",
+		// 在应答协程中读取，端口变化后无需重启广播
+		Port: func() int { return l.frontendPort },
+		Addrs: func() []net.IP {
+			ipv4, ipv6 := services.LocalIPs()
+			var ips []net.IP
+			for _, s := range append(ipv4, ipv6...) {
+				if ip := net.ParseIP(s); ip != nil {
+					ips = append(ips, ip)
+				}
+			}
+			return ips
+		},
+	})
+	if err != nil {
+		return err
+	}
+	l.mdns = responder
+	return nil
+}
+
+// showMDNSDialog 显示 mDNS 广播设置
+func (l *GVALauncher) showMDNSDialog() {
+	enableCheck := widget.NewCheck("在局域网中广播前端地址", nil)
+	enableCheck.SetChecked(l.config.MDNS.Enabled)
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder(mdns.DefaultName)
+	nameEntry.SetText(l.config.MDNS.Name)
+
+	status := "广播未开启"
+	if l.mdns != nil {
+		status = "正在广播: " + l.mdnsURL()
+	}
+
+	help := widget.NewLabel("开启后面板通过 mDNS（Bonjour）响应 <名称>.local 的地址查询，并以 _http._tcp 服务发布前端，" +
+		"同一局域网内的手机、平板可以直接访问 http://<名称>.local:端口，或在支持服务发现的浏览器 / App 中找到 “GVA Panel”。" +
+		"iOS、macOS 和大多数 Linux 桌面原生支持 .local；Windows 10 1803 及以上支持，旧版本需安装 Bonjour；部分 Android 浏览器不支持。")
+	help.Wrapping = fyne.TextWrapWord
+
+	copyBtn := widget.NewButton("📋 复制地址", func() {
+		l.copyToClipboard(l.mdnsURL(), "mDNS 地址")
+	})
+
+	content := container.NewVBox(
+		help,
+		enableCheck,
+		widget.NewForm(widget.NewFormItem("名称", container.NewBorder(nil, nil, nil, widget.NewLabel(".local"), nameEntry))),
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, nil, copyBtn, widget.NewLabel(status)),
+	)
+
+	dialog.ShowCustomConfirm("📣 局域网发现", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		name := strings.ToLower(strings.TrimSpace(nameEntry.Text))
+		if name != "" {
+			if err := mdns.ValidName(name); err != nil {
+				l.showError(err, nil)
+				return
+			}
+		}
+		l.config.MDNS.Enabled = enableCheck.Checked
+		l.config.MDNS.Name = name
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		if err := l.startMDNS(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if l.mdns == nil {
+			dialog.ShowInformation("成功", "mDNS 广播已关闭", l.window)
+			return
+		}
+		dialog.ShowInformation("成功", "mDNS 广播已开启\n\n"+l.mdnsURL(), l.window)
+	}, l.window)
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/menus"
+)
+
+// menuAllRoles 角色筛选中表示不按角色查看的选项
+const menuAllRoles = "全部角色"
+
+// menuNodeText 菜单树中一个节点的显示文字；role 不为全部角色时标出该角色能否看到
+func menuNodeText(m *menus.Menu, role string) string {
+	text := fmt.Sprintf("%s  /%s", m.Title, strings.TrimPrefix(m.Path, "/"))
+	if role != menuAllRoles {
+		if m.VisibleTo(role) {
+			text = "✅ " + text
+		} else {
+			text = "🚫 " + text
+		}
+	}
+	if m.Hidden {
+		text += "  [隐藏]"
+	}
+	if len(m.Issues) > 0 {
+		text += fmt.Sprintf("  ⚠️ %d", len(m.Issues))
+	}
+	return text
+}
+
+// menuDetailText 选中菜单的详细信息
+func menuDetailText(m *menus.Menu) string {
+	roles := strings.Join(m.Roles, "、")
+	if roles == "" {
+		roles = "（无）"
+	}
+	text := fmt.Sprintf("ID %d  父菜单 %d  排序 %d\n路由: %s  名称: %s\n组件: %s\n角色: %s",
+		m.ID, m.ParentID, m.Sort, m.Path, m.Name, m.Component, roles)
+	for _, issue := range m.Issues {
+		text += "\n⚠️ " + issue
+	}
+	return text
+}
+
+// showMenuDialog 在后台读取项目数据库中的菜单和角色分配，完成后显示菜单树
+func (l *GVALauncher) showMenuDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg, err := l.project.ReadConfig()
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+	root := l.project.Root
+
+	progress := dialog.NewCustomWithoutButtons("🗂️ 菜单检查", widget.NewLabel("正在读取 sys_base_menus ..."), l.window)
+	progress.Show()
+	l.background("读取菜单", func(context.Context) func() {
+		tree, err := menus.Load(root, cfg)
+		return func() {
+			progress.Hide()
+			if err != nil {
+				l.showError(err, nil)
+				return
+			}
+			l.showMenuTree(tree)
+		}
+	})
+}
+
+// showMenuTree 显示菜单树，可切换角色查看每个菜单对该角色是否可见
+func (l *GVALauncher) showMenuTree(roots []*menus.Menu) {
+	byID := map[string]*menus.Menu{}
+	issues := 0
+	menus.Walk(roots, func(m *menus.Menu, _ int) {
+		byID[strconv.Itoa(m.ID)] = m
+		if len(m.Issues) > 0 {
+			issues++
+		}
+	})
+	ids := func(list []*menus.Menu) []string {
+		result := make([]string, len(list))
+		for i, m := range list {
+			result[i] = strconv.Itoa(m.ID)
+		}
+		return result
+	}
+
+	role := menuAllRoles
+	tree := widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			if id == "" {
+				return ids(roots)
+			}
+			if m := byID[id]; m != nil {
+				return ids(m.Children)
+			}
+			return nil
+		},
+		func(id widget.TreeNodeID) bool {
+			return id == "" || (byID[id] != nil && len(byID[id].Children) > 0)
+		},
+		func(bool) fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TreeNodeID, _ bool, o fyne.CanvasObject) {
+			if m := byID[id]; m != nil {
+				o.(*widget.Label).SetText(menuNodeText(m, role))
+			}
+		},
+	)
+	tree.OpenAllBranches()
+
+	detail := widget.NewLabel("选择一个菜单查看详细信息")
+	detail.Wrapping = fyne.TextWrapWord
+	tree.OnSelected = func(id widget.TreeNodeID) {
+		if m := byID[id]; m != nil {
+			detail.SetText(menuDetailText(m))
+		}
+	}
+
+	roleSelect := widget.NewSelect(append([]string{menuAllRoles}, menus.Roles(roots)...), func(selected string) {
+		role = selected
+		tree.Refresh()
+	})
+	roleSelect.SetSelected(menuAllRoles)
+
+	summary := fmt.Sprintf("共 %d 个菜单，%d 个可能存在问题", len(byID), issues)
+	help := widget.NewLabel("菜单来自数据库的 sys_base_menus 表，角色分配来自 sys_authority_menus，与 GVA 生成侧边栏使用的数据一致。" +
+		"菜单不显示时依次检查：是否隐藏、当前角色是否分配了该菜单及其所有父菜单、组件文件是否存在。" +
+		"修改角色菜单后需要重新登录才会生效。\n" + summary)
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(help, widget.NewForm(widget.NewFormItem("按角色查看", roleSelect)))
+	bottom := container.NewVBox(widget.NewSeparator(), detail)
+	content := container.NewBorder(top, bottom, nil, nil, tree)
+
+	d := dialog.NewCustom("🗂️ 菜单检查", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(80), l.calcVH(75)))
+	d.Show()
+}
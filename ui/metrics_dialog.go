@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/metrics"
+)
+
+// startMetrics 按配置（重新）启动状态导出接口，地址为空时只关闭旧的接口
+func (l *GVALauncher) startMetrics() error {
+	if l.metricsServer != nil {
+		l.metricsServer.Close()
+		l.metricsServer = nil
+	}
+
+	addr := strings.TrimSpace(l.config.Metrics.Addr)
+	if addr == "" {
+		return nil
+	}
+	server, err := metrics.Serve(addr, metrics.Handler(func() metrics.Snapshot {
+		return metrics.Collect(metrics.ModePanel, l.project, l.services)
+	}))
+	if err != nil {
+		return err
+	}
+	l.metricsServer = server
+	return nil
+}
+
+// showMetricsDialog 显示状态导出接口设置
+func (l *GVALauncher) showMetricsDialog() {
+	enableCheck := widget.NewCheck("开启状态导出接口", nil)
+	enableCheck.SetChecked(l.config.Metrics.Addr != "")
+
+	addrEntry := widget.NewEntry()
+	addrEntry.SetPlaceHolder(metrics.DefaultAddr)
+	addrEntry.SetText(l.config.Metrics.Addr)
+
+	status := "接口未开启"
+	if l.metricsServer != nil {
+		base := l.serverURL(l.metricsServer.Addr)
+		status = fmt.Sprintf("Prometheus: %s/metrics\nJSON: %s/status", base, base)
+	}
+
+	help := widget.NewLabel("开启后可通过 HTTP 读取前后端服务状态、端口和最近的事件（启动、崩溃、安装、构建），" +
+		"供 Prometheus 等监控系统抓取。接口只读；监听 0.0.0.0 时局域网内的其他机器也能访问，请注意防火墙设置。" +
+		"看守模式运行时会使用同一地址导出状态。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		enableCheck,
+		widget.NewForm(widget.NewFormItem("监听地址", addrEntry)),
+		widget.NewSeparator(),
+		widget.NewLabel(status),
+	)
+
+	dialog.ShowCustomConfirm("📈 状态导出", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		addr := ""
+		if enableCheck.Checked {
+			addr = strings.TrimSpace(addrEntry.Text)
+			if addr == "" {
+				addr = metrics.DefaultAddr
+			}
+		}
+		l.config.Metrics.Addr = addr
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		if err := l.startMetrics(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if addr == "" {
+			dialog.ShowInformation("成功", "状态导出接口已关闭", l.window)
+			return
+		}
+		base := l.serverURL(l.metricsServer.Addr)
+		dialog.ShowInformation("成功", fmt.Sprintf("状态导出接口已开启\n\n%s/metrics\n%s/status", base, base), l.window)
+	}, l.window)
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/internal/sysutil"
+)
+
+// createMirrorArea 创建镜像源配置区域
+func (l *GVALauncher) createMirrorArea() *fyne.Container {
+	// 11. 标题装箱 + 上下边界线
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("🔧 镜像源配置", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	// 12. 前后端镜像源装箱（2个盒子）
+	// 前端镜像源
+	l.frontendMirrorEntry = widget.NewEntry()
+	l.frontendMirrorEntry.SetPlaceHolder("例如: https://registry.npmmirror.com")
+
+	l.frontendMirrorBtn = widget.NewButton("　✅ 更新　", func() {
+		mirrorURL := strings.TrimSpace(l.frontendMirrorEntry.Text)
+		if !l.project.IsSet() {
+			l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+			return
+		}
+		if !l.ensureProjectOwner() {
+			return
+		}
+		err := deps.SetNpmRegistry(l.project.WebDir(), mirrorURL)
+		if err != nil {
+			l.showError(err, nil)
+		} else {
+			dialog.ShowInformation("成功", "前端镜像源已更新", l.window)
+		}
+	})
+
+	// 用 Border 布局：左边标签，右边按钮，中间输入框自动填充
+	frontendBox := container.NewBorder(
+		nil, nil, // 上下不限制
+		widget.NewLabel("📦 前端镜像源:"), // 左边：标签
+		l.frontendMirrorBtn,         // 右边：按钮
+		l.frontendMirrorEntry,       // 中间：输入框（自动填充）
+	)
+
+	// 后端镜像源
+	l.backendMirrorEntry = widget.NewEntry()
+	l.backendMirrorEntry.SetPlaceHolder("例如: https://goproxy.cn,direct")
+
+	l.backendMirrorBtn = widget.NewButton("　✅ 更新　", func() {
+		proxyURL := strings.TrimSpace(l.backendMirrorEntry.Text)
+		err := deps.SetGoProxy(proxyURL)
+		if err != nil {
+			l.showError(err, nil)
+		} else {
+			dialog.ShowInformation("成功", "后端镜像源已更新", l.window)
+		}
+	})
+
+	// 用 Border 布局：左边标签，右边按钮，中间输入框自动填充
+	backendBox := container.NewBorder(
+		nil, nil, // 上下不限制
+		widget.NewLabel("⚙️ 后端镜像源:"), // 左边：标签
+		l.backendMirrorBtn,           // 右边：按钮
+		l.backendMirrorEntry,         // 中间：输入框（自动填充）
+	)
+
+	// 13. 镜像源父容器
+	mirrorParentBox := container.NewVBox(
+		frontendBox,
+		backendBox,
+	)
+
+	return container.NewVBox(
+		titleBox,
+		mirrorParentBox,
+	)
+}
+
+// loadMirrorConfig 加载镜像源配置到输入框
+// 先显示上次缓存的值（启动时无需等待 npm/go 命令），再在后台执行命令获取最新值
+func (l *GVALauncher) loadMirrorConfig() {
+	if l.frontendMirrorEntry == nil || l.backendMirrorEntry == nil {
+		return
+	}
+
+	// 未设置目录或 web/server 目录不存在时不读取
+	webDir := l.project.WebDir()
+	readFrontend := l.project.IsSet() && sysutil.DirExists(webDir)
+	readBackend := l.project.IsSet() && sysutil.DirExists(l.project.ServerDir())
+
+	var frontendMirror, backendMirror string
+	if readFrontend {
+		frontendMirror, _ = deps.CachedNpmRegistry(webDir)
+	}
+	if readBackend {
+		backendMirror, _ = deps.CachedGoProxy()
+	}
+	l.frontendMirrorEntry.SetText(frontendMirror)
+	l.backendMirrorEntry.SetText(backendMirror)
+
+	if !readFrontend && !readBackend {
+		return
+	}
+	l.background("读取镜像源", func(context.Context) func() {
+		var frontend, backend string
+		if readFrontend {
+			frontend = deps.ReadNpmRegistry(webDir)
+		}
+		if readBackend {
+			backend = deps.ReadGoProxy()
+		}
+		return func() {
+			l.frontendMirrorEntry.SetText(frontend)
+			l.backendMirrorEntry.SetText(backend)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/download"
+	"gva-launcher/updater"
+)
+
+// showNetworkDialog 显示网络设置：面板发起的下载使用的 HTTP 代理和 GitHub 镜像
+func (l *GVALauncher) showNetworkDialog() {
+	proxyEntry := widget.NewEntry()
+	proxyEntry.SetPlaceHolder("例如: http://127.0.0.1:7890（留空使用系统环境变量）")
+	proxyEntry.SetText(l.config.Network.HTTPProxy)
+
+	mirrorsEntry := widget.NewMultiLineEntry()
+	mirrorsEntry.SetPlaceHolder("每行一个镜像前缀，例如:\nhttps://ghfast.top/")
+	mirrorsEntry.SetText(strings.Join(l.config.Network.Mirrors, "\n"))
+	mirrorsEntry.SetMinRowsVisible(3)
+
+	// read 读取表单
+	read := func() config.Network {
+		var mirrors []string
+		for _, line := range strings.Split(mirrorsEntry.Text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				mirrors = append(mirrors, line)
+			}
+		}
+		return config.Network{HTTPProxy: strings.TrimSpace(proxyEntry.Text), Mirrors: mirrors}
+	}
+
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+	testBtn := widget.NewButton("🧪 测试连接", nil)
+	testBtn.OnTapped = func() {
+		cfg := read()
+		if _, err := download.ParseProxy(cfg.HTTPProxy); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		testBtn.Disable()
+		status.SetText("正在连接 GitHub...")
+
+		l.background("测试网络设置", func(context.Context) func() {
+			elapsed, err := download.Probe(cfg, updater.DefaultSources[0].APIURL)
+			return func() {
+				testBtn.Enable()
+				if err != nil {
+					status.SetText("❌ " + err.Error())
+					return
+				}
+				status.SetText(fmt.Sprintf("✅ 连接成功（%d ms）", elapsed.Milliseconds()))
+			}
+		})
+	}
+
+	help := widget.NewLabel("面板自更新等下载使用这里的代理；留空时使用 HTTPS_PROXY / HTTP_PROXY 环境变量。" +
+		"GitHub 下载失败时依次尝试镜像前缀（镜像地址 + 原始 GitHub 地址），最后尝试 Gitee 上的同名发布附件。" +
+		"不影响 npm / Go 的镜像源，请在依赖管理中设置。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		widget.NewForm(
+			widget.NewFormItem("HTTP 代理", proxyEntry),
+			widget.NewFormItem("GitHub 镜像", mirrorsEntry),
+		),
+		testBtn,
+		status,
+	)
+
+	d := dialog.NewCustomConfirm("🌐 网络设置", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		cfg := read()
+		if err := download.Configure(cfg); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.config.Network = cfg
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(70), 0))
+	d.Show()
+}
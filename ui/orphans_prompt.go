@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+	"gva-launcher/supervisor"
+)
+
+// checkOrphans 打开面板时查找上次遗留的 go run / node 进程（仍监听项目端口），询问接管还是结束，
+// 避免遗留进程占着端口导致无法重新启动。项目正被其他用户使用时端口上是对方的服务，不检查
+func (l *GVALauncher) checkOrphans() {
+	if !l.project.IsValid() || l.projectHolder != nil {
+		return
+	}
+	l.background("检查遗留进程", func(context.Context) func() {
+		orphans := l.services.FindOrphans()
+		if len(orphans) == 0 {
+			return nil
+		}
+		return func() { l.showOrphansPrompt(orphans) }
+	})
+}
+
+// showOrphansPrompt 列出遗留进程，由用户选择接管、结束或暂不处理
+func (l *GVALauncher) showOrphansPrompt(orphans []launcher.Orphan) {
+	var lines []string
+	for _, o := range orphans {
+		lines = append(lines, "• "+o.String())
+	}
+	info := widget.NewLabel(fmt.Sprintf("发现 %d 个上次遗留的开发服务进程仍在监听项目端口（可能是面板上次崩溃或被强制结束）:\n\n%s\n\n"+
+		"接管后面板照常显示状态并可以停止和重启（之前的输出无法显示）；结束后可以重新启动服务。",
+		len(orphans), strings.Join(lines, "\n")))
+	info.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	adoptBtn := widget.NewButton("🔗 接管", func() {
+		d.Hide()
+		for _, o := range orphans {
+			l.services.Adopt(o)
+		}
+		l.checkServiceStatus()
+	})
+	killBtn := widget.NewButton("⛔ 结束进程", func() {
+		d.Hide()
+		l.killOrphans(orphans)
+	})
+	ignoreBtn := widget.NewButton("暂不处理", func() { d.Hide() })
+
+	content := container.NewVBox(info, container.NewGridWithColumns(3, adoptBtn, killBtn, ignoreBtn))
+	d = dialog.NewCustomWithoutButtons("⚠️ 遗留进程", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
+
+// killOrphans 结束遗留进程，等端口释放后刷新服务状态
+func (l *GVALauncher) killOrphans(orphans []launcher.Orphan) {
+	l.background("结束遗留进程", func(ctx context.Context) func() {
+		l.services.KillOrphans(orphans)
+		deadline := time.Now().Add(l.config.EffectiveTimeouts().StopWait())
+		for _, o := range orphans {
+			for services.IsPortInUse(o.Port) && time.Now().Before(deadline) {
+				if !supervisor.Sleep(ctx, 200*time.Millisecond) {
+					return nil
+				}
+			}
+		}
+		return l.checkServiceStatus
+	})
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+	"gva-launcher/supervisor"
+)
+
+// showPortConflict 启动时端口被占用：查出占用端口的进程和下一个空闲端口后，让用户选择结束进程、换端口或取消。
+// 处理完成后重新调用 startGVA（前后端端口都被占用时依次处理）
+func (l *GVALauncher) showPortConflict(c launcher.PortConflict) {
+	name, otherPort := "前端", l.backendPort
+	if c.Service == launcher.ServiceBackend {
+		name, otherPort = "后端", l.frontendPort
+	}
+	// 换端口时避开另一个服务、面板自身服务和其他项目登记的端口
+	avoid := append([]int{otherPort}, l.panelPorts()...)
+	avoid = append(avoid, config.OtherProjectPorts(l.config.Projects, l.config.GVARootPath)...)
+
+	l.background("检查端口占用", func(ctx context.Context) func() {
+		procs := services.PortProcesses(c.Port)
+		next, nextErr := services.NextFreePort(c.Port, avoid...)
+		return func() {
+			var owners []string
+			for _, p := range procs {
+				owners = append(owners, p.String())
+			}
+			owner := "未知进程（可能需要管理员权限才能查看）"
+			if len(owners) > 0 {
+				owner = strings.Join(owners, "、")
+			}
+			info := widget.NewLabel(fmt.Sprintf("%s端口 %d 已被占用，直接启动会失败或连到别的程序。\n\n占用进程: %s", name, c.Port, owner))
+			info.Wrapping = fyne.TextWrapWord
+
+			var d dialog.Dialog
+			killBtn := widget.NewButton("⛔ 结束占用进程", func() {
+				d.Hide()
+				l.freePortAndStart(c.Port)
+			})
+			if len(procs) == 0 {
+				killBtn.SetText("⛔ 尝试结束占用进程")
+			}
+			nextBtn := widget.NewButton(fmt.Sprintf("🔀 改用端口 %d", next), func() {
+				d.Hide()
+				l.switchPortAndStart(c.Service, next)
+			})
+			if nextErr != nil {
+				nextBtn.SetText("🔀 没有可用的空闲端口")
+				nextBtn.Disable()
+			}
+			cancelBtn := widget.NewButton("取消", func() { d.Hide() })
+
+			content := container.NewVBox(info, container.NewGridWithColumns(3, killBtn, nextBtn, cancelBtn))
+			d = dialog.NewCustomWithoutButtons("⚠️ 端口被占用", content, l.window)
+			d.Resize(fyne.NewSize(l.calcVW(55), 0))
+			d.Show()
+		}
+	})
+}
+
+// freePortAndStart 结束占用端口的进程，等端口释放后重新启动服务
+func (l *GVALauncher) freePortAndStart(port int) {
+	l.background("释放端口", func(ctx context.Context) func() {
+		services.KillProcessByPort(port)
+		deadline := time.Now().Add(l.config.EffectiveTimeouts().StopWait())
+		for services.IsPortInUse(port) && time.Now().Before(deadline) {
+			if !supervisor.Sleep(ctx, 200*time.Millisecond) {
+				return nil
+			}
+		}
+		return func() {
+			if err := services.CheckPortFree(port); err != nil {
+				l.showError(err, nil)
+				return
+			}
+			l.startGVA()
+		}
+	})
+}
+
+// switchPortAndStart 把服务的端口改为 port 并写入配置（后端端口同时更新前端的代理配置），然后重新启动服务
+func (l *GVALauncher) switchPortAndStart(service string, port int) {
+	if service == launcher.ServiceBackend {
+		if err := l.project.SetBackendPort(port); err != nil {
+			l.showError(fmt.Errorf("写入后端配置文件失败: %w", err), nil)
+			return
+		}
+		l.backendPort = port
+	} else {
+		if err := l.project.SetFrontendPort(port); err != nil {
+			l.showError(fmt.Errorf("写入前端配置文件失败: %w", err), nil)
+			return
+		}
+		l.frontendPort = port
+	}
+	l.updateServiceStatus()
+	l.registerProjectPorts()
+	l.startGVA()
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/services"
+	"gva-launcher/supervisor"
+)
+
+// showPortDialog 显示端口修改对话框
+func (l *GVALauncher) showPortDialog(isBackend bool) {
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	title := "修改前端端口"
+	currentPort := l.frontendPort
+	if isBackend {
+		title = "修改后端端口"
+		currentPort = l.backendPort
+	}
+
+	currentLabel := widget.NewLabel(fmt.Sprintf("当前端口: %d", currentPort))
+	currentLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	portEntry := widget.NewEntry()
+	portEntry.SetPlaceHolder("输入新端口号...")
+
+	statusLabel := widget.NewLabel("")
+	statusLabel.Wrapping = fyne.TextWrapWord
+
+	checkBtn := widget.NewButton("🔍 检查占用", func() {
+		portStr := portEntry.Text
+		if portStr == "" {
+			statusLabel.SetText("⚠️ 请输入端口号")
+			return
+		}
+
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			statusLabel.SetText("⚠️ 端口无效 (范围: 1-65535)")
+			return
+		}
+
+		statusLabel.SetText("⏳ 正在检查端口占用情况...")
+		owner := l.portOwner(port)
+
+		l.background("检查端口", func(ctx context.Context) func() {
+			if !supervisor.Sleep(ctx, 300*time.Millisecond) {
+				return nil
+			}
+			text := fmt.Sprintf("✅ 端口 %d 可用", port)
+			if services.IsPortInUse(port) {
+				text = fmt.Sprintf("❌ 端口 %d 已被占用", port)
+			} else if owner != "" {
+				text = fmt.Sprintf("⚠️ 端口 %d 当前空闲，但已被项目 %s 使用，两个项目不能同时运行", port, owner)
+			}
+			return func() { statusLabel.SetText(text) }
+		})
+	})
+
+	portRow := container.NewBorder(nil, nil, widget.NewLabel("新端口:"), checkBtn, portEntry)
+
+	content := container.NewVBox(
+		currentLabel,
+		widget.NewSeparator(),
+		portRow,
+		widget.NewSeparator(),
+		statusLabel,
+	)
+
+	d := dialog.NewCustomConfirm(title, "确定", "取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		// 记录当前端口（用于关闭旧服务）
+		oldBackendPort := l.backendPort
+		oldFrontendPort := l.frontendPort
+
+		portStr := portEntry.Text
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			dialog.ShowError(fmt.Errorf("端口号无效"), l.window)
+			return
+		}
+
+		// 记录服务是否正在运行（用于提示信息）
+		wasRunning := l.services.IsRunning()
+
+		// 如果服务器正在运行，关闭整个服务
+		if wasRunning {
+			// 关闭前后端所有服务并清理所有服务状态
+			l.services.StopPorts(oldBackendPort, oldFrontendPort)
+			l.enableStartButton()
+			l.stopButton.Disable()
+		}
+
+		if isBackend {
+			// 修改后端端口需要写入GVA配置文件
+			err := l.project.SetBackendPort(port)
+			if err != nil {
+				l.showError(fmt.Errorf("写入后端配置文件失败: %w", err), nil)
+				return
+			}
+			l.backendPort = port
+		} else {
+			// 修改前端端口需要特殊处理（避免Vue热重载导致的状态错误）
+
+			// 1. 修改前端配置文件（会触发Vue热重载）
+			err := l.project.SetFrontendPort(port)
+			if err != nil {
+				l.showError(fmt.Errorf("写入前端配置文件失败: %w", err), nil)
+				return
+			}
+			l.frontendPort = port
+
+			// 2. 后台处理Vue重启
+			l.supervisor.Go("前端端口切换", func(ctx context.Context) {
+				// 等待Vue重启完成（默认 4 秒，可在配置中调整）
+				if !supervisor.Sleep(ctx, l.config.EffectiveTimeouts().VueRestartWait()) {
+					return
+				}
+
+				// 杀死新启动的Vue进程
+				services.KillProcessByPort(port)
+
+				// 等待进程完全停止
+				if !supervisor.Sleep(ctx, 1*time.Second) {
+					return
+				}
+
+				// 3. 标记前端已停止，界面收到 ServiceChanged 事件后刷新
+				l.services.Frontend.SetRunning(false)
+				l.services.Publish()
+			})
+		}
+
+		l.updateServiceStatus()
+		l.registerProjectPorts()
+
+		// 根据服务状态显示不同的提示信息
+		var message string
+		if wasRunning {
+			message = fmt.Sprintf("端口已修改为 %d\n\n服务已自动关闭，请重新启动", port)
+		} else {
+			message = fmt.Sprintf("端口已修改为 %d", port)
+		}
+		dialog.ShowInformation("成功", message, l.window)
+	}, l.window)
+
+	// ========================================
+	// 【响应式对话框尺寸】使用 vw/vh 单位 - 正方形
+	// ========================================
+	// 对话框设置为正方形，使用窗口宽度的 45%
+	dialogSize := l.calcVW(45) // ⭐ 正方形尺寸
+	d.Resize(fyne.NewSize(dialogSize, dialogSize))
+	d.Show()
+}
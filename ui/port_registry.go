@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+
+	"gva-launcher/config"
+)
+
+// currentProjectPorts 当前项目在登记表中的记录
+func (l *GVALauncher) currentProjectPorts() config.ProjectPorts {
+	return config.ProjectPorts{Root: l.config.GVARootPath, BackendPort: l.backendPort, FrontendPort: l.frontendPort}
+}
+
+// registerProjectPorts 把当前项目的端口登记到项目端口表，与其他项目冲突时提示（同样的冲突只提示一次）
+func (l *GVALauncher) registerProjectPorts() {
+	current := l.currentProjectPorts()
+	if current.Root == "" || (current.BackendPort == 0 && current.FrontendPort == 0) {
+		return
+	}
+
+	if list, changed := config.RegisterProject(l.config.Projects, current); changed {
+		l.config.Projects = list
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+	}
+
+	conflicts := config.PortConflicts(l.config.Projects, current)
+	if len(conflicts) == 0 {
+		l.warnedConflicts = ""
+		return
+	}
+	key := fmt.Sprint(current, conflicts)
+	if key == l.warnedConflicts {
+		return
+	}
+	l.warnedConflicts = key
+
+	lines := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		lines[i] = fmt.Sprintf("• 端口 %d 也被项目 %s 使用", c.Port, c.Project.Root)
+	}
+	message := "当前项目的端口与其他项目冲突，两个项目不能同时运行：\n\n" + strings.Join(lines, "\n") +
+		"\n\n是否自动分配新的端口？（会避开其他项目的端口）"
+	dialog.ShowConfirm("⚠️ 端口冲突", message, func(ok bool) {
+		if ok {
+			l.showAllocPortsDialog()
+		}
+	}, l.window)
+}
+
+// portOwner 使用 port 的其他项目（没有时返回空字符串）
+func (l *GVALauncher) portOwner(port int) string {
+	for _, c := range config.PortConflicts(l.config.Projects, config.ProjectPorts{Root: l.config.GVARootPath, BackendPort: port}) {
+		return c.Project.Root
+	}
+	return ""
+}
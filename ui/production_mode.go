@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+)
+
+// toggleProductionMode 切换生产模式：开启时先把前端构建到 server/dist 并启用后端的静态文件路由，
+// 构建成功才保存设置（失败时取消勾选）；关闭时恢复为前后端开发服务器
+func (l *GVALauncher) toggleProductionMode(check *widget.Check, on bool) {
+	if on == l.config.ProductionMode {
+		return
+	}
+	if !on {
+		l.config.ProductionMode = false
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+		if l.services.IsRunning() {
+			dialog.ShowInformation("生产模式", "已关闭，重新启动 GVA 后使用前端开发服务器", l.window)
+		}
+		return
+	}
+
+	if !l.ensureProjectOwner() || !l.requireServiceTool(launcher.ServiceFrontend) {
+		check.SetChecked(false)
+		return
+	}
+	message := "将执行 npm run build 把前端构建到 server/dist，并取消 server/initialize/router.go 中上游预留的静态文件路由的注释" +
+		"（不修改 .env.production）。\n之后「启动 GVA」只启动后端，页面和接口都由后端端口提供，不需要 Vite 开发服务器。\n\n继续？"
+	dialog.ShowConfirm("🏭 生产模式", message, func(ok bool) {
+		if !ok {
+			check.SetChecked(false)
+			return
+		}
+		job := l.jobs.Submit("生产模式构建", func(ctx context.Context, j *jobs.Job) error {
+			return l.builds.BuildProductionMode(j)
+		})
+		l.waitJob(job, "🏭 生产模式", "正在构建前端到 server/dist...", func(err error) {
+			if err == nil {
+				l.config.ProductionMode = true
+				err = l.saveConfig()
+			}
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+				check.SetChecked(false)
+			case err != nil:
+				check.SetChecked(false)
+				l.showError(err, nil)
+			default:
+				text := "前端已构建到 " + l.builds.StaticDistDir() + "\n启动 GVA 后访问: " + l.getBackendURL()
+				if l.services.IsRunning() {
+					text += "\n\n请重新启动 GVA 生效（后端需要重启才会加载静态文件路由）"
+				}
+				dialog.ShowInformation("生产模式已开启", text, l.window)
+			}
+		})
+	}, l.window)
+}
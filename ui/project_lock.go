@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2/dialog"
+)
+
+// lockProject 获取当前项目的锁（切换根目录时调用，先释放旧项目的锁）
+// 项目正被其他用户使用时提示持有者，之后启动服务、安装依赖和修改项目配置都会被拒绝
+func (l *GVALauncher) lockProject() {
+	l.releaseProject()
+	if !l.project.IsValid() {
+		return
+	}
+
+	lock, holder, err := l.project.Lock()
+	if err != nil {
+		// 根目录不可写等情况下不阻止使用，只是失去多用户保护
+		return
+	}
+	l.projectLock = lock
+	l.projectHolder = holder
+
+	if holder != nil {
+		dialog.ShowInformation("⚠️ 项目正在被其他用户使用",
+			"项目 "+l.project.Root+" 正在被 "+holder.Owner()+" 使用。\n\n"+
+				"为避免同时修改配置或重复启动服务，在对方关闭面板之前，\n"+
+				"启动服务、安装依赖、清理缓存和修改项目配置将被拒绝。",
+			l.window)
+	}
+}
+
+// releaseProject 释放当前项目的锁
+func (l *GVALauncher) releaseProject() {
+	if l.projectLock != nil {
+		l.projectLock.Release()
+		l.projectLock = nil
+	}
+	l.projectHolder = nil
+}
+
+// ensureProjectOwner 修改项目前检查项目是否被其他用户占用（对方已退出时重新获取锁）
+// 返回 false 时已显示错误，调用方应直接返回
+func (l *GVALauncher) ensureProjectOwner() bool {
+	if l.projectHolder == nil {
+		return true
+	}
+
+	lock, holder, err := l.project.Lock()
+	if err == nil && holder == nil {
+		l.projectLock = lock
+		l.projectHolder = nil
+		return true
+	}
+	if holder != nil {
+		l.projectHolder = holder
+	}
+	l.showError(l.project.LockedError(l.projectHolder), nil)
+	return false
+}
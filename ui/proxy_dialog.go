@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/idle"
+	"gva-launcher/proxy"
+)
+
+// startProxy 按配置（重新）启动单端口代理，地址为空时只关闭旧的代理
+func (l *GVALauncher) startProxy() error {
+	if l.proxyServer != nil {
+		l.proxyServer.Close()
+		l.proxyServer = nil
+	}
+
+	addr := strings.TrimSpace(l.config.Proxy.Addr)
+	if addr == "" {
+		return nil
+	}
+	auth := proxy.Auth{
+		Mode:     l.config.Proxy.Auth,
+		Username: l.config.Proxy.Username,
+		Password: l.config.Proxy.Password,
+		Token:    l.config.Proxy.Token,
+	}
+	server, err := proxy.Serve(addr, idle.Middleware(l.activity, proxy.Protect(proxy.Handler(l.project.ProxyTargets()), auth)))
+	if err != nil {
+		return err
+	}
+	l.proxyServer = server
+	return nil
+}
+
+// proxyURL 局域网访问单端口代理的地址（未开启时为空）；使用访问令牌时附带令牌，作为分享链接
+func (l *GVALauncher) proxyURL() string {
+	if l.proxyServer == nil {
+		return ""
+	}
+	url := l.serverURL(l.proxyServer.Addr)
+	if url != "" && l.config.Proxy.Auth == proxy.AuthToken {
+		url += "/?" + proxy.TokenParam + "=" + l.config.Proxy.Token
+	}
+	return url
+}
+
+// proxyAuthLabels 访问保护方式的显示名称（按下拉框顺序）
+var proxyAuthLabels = []struct{ mode, label string }{
+	{proxy.AuthNone, "不保护"},
+	{proxy.AuthBasic, "用户名密码"},
+	{proxy.AuthToken, "访问令牌（分享链接）"},
+}
+
+// showProxyDialog 显示单端口访问设置
+func (l *GVALauncher) showProxyDialog() {
+	enableCheck := widget.NewCheck("开启单端口访问", nil)
+	enableCheck.SetChecked(l.config.Proxy.Addr != "")
+
+	addrEntry := widget.NewEntry()
+	addrEntry.SetPlaceHolder(proxy.DefaultAddr)
+	addrEntry.SetText(l.config.Proxy.Addr)
+
+	status := "代理未开启"
+	if url := l.proxyURL(); url != "" {
+		status = "访问地址: " + url
+	}
+
+	// 访问保护
+	userEntry := widget.NewEntry()
+	userEntry.SetPlaceHolder("用户名")
+	userEntry.SetText(l.config.Proxy.Username)
+	passEntry := widget.NewPasswordEntry()
+	passEntry.SetPlaceHolder("密码")
+	passEntry.SetText(l.config.Proxy.Password)
+
+	token := l.config.Proxy.Token
+	if token == "" {
+		token = proxy.NewToken()
+	}
+	tokenLabel := widget.NewLabel(token)
+	resetTokenBtn := widget.NewButton("🔄 重新生成", func() {
+		token = proxy.NewToken()
+		tokenLabel.SetText(token)
+	})
+	tokenBox := container.NewBorder(nil, nil, nil, resetTokenBtn, tokenLabel)
+
+	labels := make([]string, len(proxyAuthLabels))
+	for i, a := range proxyAuthLabels {
+		labels[i] = a.label
+	}
+	authMode := func(label string) string {
+		for _, a := range proxyAuthLabels {
+			if a.label == label {
+				return a.mode
+			}
+		}
+		return proxy.AuthNone
+	}
+	authSelect := widget.NewSelect(labels, func(label string) {
+		if authMode(label) == proxy.AuthBasic {
+			userEntry.Enable()
+			passEntry.Enable()
+		} else {
+			userEntry.Disable()
+			passEntry.Disable()
+		}
+		if authMode(label) == proxy.AuthToken {
+			resetTokenBtn.Enable()
+		} else {
+			resetTokenBtn.Disable()
+		}
+	})
+	authSelect.SetSelected(labels[0])
+	for _, a := range proxyAuthLabels {
+		if a.mode == l.config.Proxy.Auth {
+			authSelect.SetSelected(a.label)
+		}
+	}
+
+	help := widget.NewLabel("开启后面板在一个端口上同时提供前端页面和后端接口：API 前缀（.env.development 中的 VITE_BASE_API，默认 /api）" +
+		"转发到后端，其余请求转发到前端开发服务器。局域网用户只需访问这一个端口，演示时不会遇到跨域问题。" +
+		"监听 0.0.0.0 时局域网内的其他机器都能访问，请注意防火墙设置。\n\n" +
+		"在共享的办公网络中可开启访问保护：用户名密码由浏览器弹窗输入；访问令牌附加在分享链接中，首次打开后保存到浏览器 Cookie，" +
+		"重新生成令牌后旧链接失效。访问保护只作用于代理端口，前后端自身的端口仍可直接访问。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		enableCheck,
+		widget.NewForm(
+			widget.NewFormItem("监听地址", addrEntry),
+			widget.NewFormItem("访问保护", authSelect),
+			widget.NewFormItem("用户名", userEntry),
+			widget.NewFormItem("密码", passEntry),
+			widget.NewFormItem("访问令牌", tokenBox),
+		),
+		widget.NewSeparator(),
+		widget.NewLabel(status),
+	)
+
+	dialog.ShowCustomConfirm("🔀 单端口访问", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		addr := ""
+		if enableCheck.Checked {
+			addr = strings.TrimSpace(addrEntry.Text)
+			if addr == "" {
+				addr = proxy.DefaultAddr
+			}
+		}
+		mode := authMode(authSelect.Selected)
+		if mode == proxy.AuthBasic && (strings.TrimSpace(userEntry.Text) == "" || passEntry.Text == "") {
+			dialog.ShowError(fmt.Errorf("请填写用户名和密码"), l.window)
+			return
+		}
+		l.config.Proxy = config.Proxy{
+			Addr:     addr,
+			Auth:     mode,
+			Username: strings.TrimSpace(userEntry.Text),
+			Password: passEntry.Text,
+			Token:    token,
+		}
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		if err := l.startProxy(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if addr == "" {
+			dialog.ShowInformation("成功", "单端口访问已关闭", l.window)
+			return
+		}
+		dialog.ShowInformation("成功", "单端口访问已开启\n\n"+l.proxyURL(), l.window)
+	}, l.window)
+}
@@ -0,0 +1,391 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/dsn"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/redisx"
+)
+
+// createRedisArea 创建 Redis 对接配置区域
+func (l *GVALauncher) createRedisArea() *fyne.Container {
+	// 14. Redis 对接标题装箱 + 上下边界线
+	l.redisSwitch = widget.NewCheck("启用 Redis", func(checked bool) {
+		l.saveRedisSwitch(checked)
+		l.updateRedisFieldsState(checked)
+	})
+
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("🔌 Redis 对接", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			layout.NewSpacer(),
+			l.redisSwitch,
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	// 15. Redis 配置项装箱（3个盒子，用Border让输入框填充）
+	// Redis 地址
+	l.redisAddrEntry = widget.NewEntry()
+	l.redisAddrEntry.SetPlaceHolder("例如: 127.0.0.1:6379")
+	redisCopyBtn := widget.NewButton("　📋 复制　", func() {
+		addr := strings.TrimSpace(l.redisAddrEntry.Text)
+		if addr == "" {
+			dialog.ShowInformation("提示", "Redis 地址为空，无法复制", l.window)
+			return
+		}
+		l.copyToClipboard(addr, "Redis 地址")
+	})
+	redisDSNBtn := widget.NewButton("📋 复制 DSN", func() {
+		l.copyRedisDSN()
+	})
+	addrBox := container.NewBorder(
+		nil, nil, // 上下不限制
+		widget.NewLabel("Redis 地址:"),                 // 左边：标签
+		container.NewHBox(redisCopyBtn, redisDSNBtn), // 右边：复制按钮
+		l.redisAddrEntry,                             // 中间：输入框自动填充
+	)
+
+	// Redis 密码
+	l.redisPassEntry = widget.NewEntry()
+	l.redisPassEntry.SetPlaceHolder("没有密码可留空")
+	l.redisPassEntry.Password = true
+	passBox := container.NewBorder(
+		nil, nil, // 上下不限制
+		widget.NewLabel("Redis 密码:"), // 左边：标签
+		nil,                          // 右边不限制
+		l.redisPassEntry,             // 中间：输入框自动填充
+	)
+
+	// 数据库编号
+	l.redisDBEntry = widget.NewEntry()
+	l.redisDBEntry.SetPlaceHolder("0-15")
+	// 地址框中粘贴 redis://:密码@主机:端口/库号 时拆分到三个输入框
+	acceptDSN(l.redisAddrEntry, func(info dsn.Info) {
+		l.redisAddrEntry.SetText(info.Addr())
+		l.redisPassEntry.SetText(info.Password)
+		db := info.Database
+		if db == "" {
+			db = "0"
+		}
+		l.redisDBEntry.SetText(db)
+	}, dsn.Redis)
+
+	dbBox := container.NewBorder(
+		nil, nil, // 上下不限制
+		widget.NewLabel("数据库编号:"), // 左边：标签
+		nil,                       // 右边不限制
+		l.redisDBEntry,            // 中间：输入框自动填充
+	)
+
+	// 连接测试按钮行（30vw + 4个Spacer）
+	l.redisTestBtn = widget.NewButton("🔍 测试连接", func() {
+		l.testRedisConnection()
+	})
+	l.redisSaveBtn = widget.NewButton("💾 保存", func() {
+		l.saveRedisConfig()
+	})
+	l.redisCancelBtn = widget.NewButton("❌ 取消", func() {
+		l.cancelRedisConfig()
+	})
+
+	// 使用 GridWithColumns 让按钮平均分配宽度
+	buttonBox := container.NewGridWithColumns(3,
+		l.redisTestBtn,
+		l.redisSaveBtn,
+		l.redisCancelBtn,
+	)
+
+	// 16. Redis 对接父容器
+	redisParentBox := container.NewVBox(
+		addrBox,
+		passBox,
+		dbBox,
+		buttonBox,
+	)
+
+	return container.NewVBox(
+		titleBox,
+		redisParentBox,
+	)
+}
+
+// ========================================
+// Redis 配置管理
+// ========================================
+
+// resetRedisFields 禁用所有 Redis 控件并清空内容
+func (l *GVALauncher) resetRedisFields() {
+	l.updateRedisFieldsState(false)
+	if l.redisSwitch != nil {
+		l.redisSwitch.Disable()
+		l.redisSwitch.SetChecked(false)
+	}
+	if l.redisAddrEntry != nil {
+		l.redisAddrEntry.SetText("")
+	}
+	if l.redisPassEntry != nil {
+		l.redisPassEntry.SetText("")
+	}
+	if l.redisDBEntry != nil {
+		l.redisDBEntry.SetText("")
+	}
+}
+
+// loadRedisConfig 加载 Redis 配置到输入框
+func (l *GVALauncher) loadRedisConfig() {
+	// 未设置目录或 server 目录不存在，禁用所有 Redis 控件并清空内容
+	if !l.project.IsSet() || !sysutil.DirExists(l.project.ServerDir()) {
+		l.resetRedisFields()
+		return
+	}
+
+	// 读取 GVA 配置
+	gvaConfig, err := l.project.ReadConfig()
+	if err != nil {
+		// 读取失败，禁用所有 Redis 控件并清空内容
+		l.resetRedisFields()
+		return
+	}
+
+	// 启用 Redis 开关
+	if l.redisSwitch != nil {
+		l.redisSwitch.Enable()
+		l.redisSwitch.SetChecked(gvaConfig.System.UseRedis)
+	}
+
+	// 加载 Redis 配置到输入框
+	if l.redisAddrEntry != nil {
+		l.redisAddrEntry.SetText(gvaConfig.Redis.Addr)
+	}
+	if l.redisPassEntry != nil {
+		l.redisPassEntry.SetText(gvaConfig.Redis.Password)
+	}
+	if l.redisDBEntry != nil {
+		l.redisDBEntry.SetText(fmt.Sprintf("%d", gvaConfig.Redis.DB))
+	}
+
+	// 缓存当前配置（用于取消操作）
+	l.cachedRedisConfig.UseRedis = gvaConfig.System.UseRedis
+	l.cachedRedisConfig.Addr = gvaConfig.Redis.Addr
+	l.cachedRedisConfig.Password = gvaConfig.Redis.Password
+	l.cachedRedisConfig.DB = gvaConfig.Redis.DB
+
+	// 更新输入框状态
+	l.updateRedisFieldsState(gvaConfig.System.UseRedis)
+}
+
+// saveRedisSwitch 立即保存 Redis 开关状态到配置文件（只写 use-redis 字段）
+func (l *GVALauncher) saveRedisSwitch(useRedis bool) {
+	if !l.project.IsSet() {
+		return // 没有设置目录，静默返回
+	}
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	if err := config.WriteUseRedis(l.project.Root, useRedis); err != nil {
+		return // 写入失败，静默返回
+	}
+
+	// 更新缓存
+	l.cachedRedisConfig.UseRedis = useRedis
+}
+
+// updateRedisFieldsState 更新 Redis 输入框和按钮的启用/禁用状态
+func (l *GVALauncher) updateRedisFieldsState(enabled bool) {
+	if l.redisAddrEntry != nil {
+		if enabled {
+			l.redisAddrEntry.Enable()
+		} else {
+			l.redisAddrEntry.Disable()
+		}
+	}
+
+	if l.redisPassEntry != nil {
+		if enabled {
+			l.redisPassEntry.Enable()
+		} else {
+			l.redisPassEntry.Disable()
+		}
+	}
+
+	if l.redisDBEntry != nil {
+		if enabled {
+			l.redisDBEntry.Enable()
+		} else {
+			l.redisDBEntry.Disable()
+		}
+	}
+
+	if l.redisTestBtn != nil {
+		if enabled {
+			l.redisTestBtn.Enable()
+		} else {
+			l.redisTestBtn.Disable()
+		}
+	}
+
+	if l.redisSaveBtn != nil {
+		if enabled {
+			l.redisSaveBtn.Enable()
+		} else {
+			l.redisSaveBtn.Disable()
+		}
+	}
+
+	if l.redisCancelBtn != nil {
+		if enabled {
+			l.redisCancelBtn.Enable()
+		} else {
+			l.redisCancelBtn.Disable()
+		}
+	}
+}
+
+// saveRedisConfig 保存 Redis 配置到 config.yaml
+func (l *GVALauncher) saveRedisConfig() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	// 验证数据库编号
+	dbStr := strings.TrimSpace(l.redisDBEntry.Text)
+	db, err := strconv.Atoi(dbStr)
+	if err != nil || db < 0 || db > 15 {
+		dialog.ShowError(fmt.Errorf("数据库编号无效，范围: 0-15"), l.window)
+		return
+	}
+
+	// 记录服务是否正在运行（用于提示信息）
+	wasRunning := l.services.IsRunning()
+
+	// 如果服务器正在运行，先关闭前后端服务器
+	if wasRunning {
+		l.stopGVA()
+	}
+
+	addr := strings.TrimSpace(l.redisAddrEntry.Text)
+	err = config.WriteRedis(l.project.Root, l.redisSwitch.Checked, addr, l.redisPassEntry.Text, db)
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+
+	// 更新缓存
+	l.cachedRedisConfig.UseRedis = l.redisSwitch.Checked
+	l.cachedRedisConfig.Addr = addr
+	l.cachedRedisConfig.Password = l.redisPassEntry.Text
+	l.cachedRedisConfig.DB = db
+
+	// 根据服务状态显示不同的提示信息
+	var message string
+	if wasRunning {
+		message = "Redis 配置已保存\n\n服务已自动关闭，请重新启动"
+	} else {
+		message = "Redis 配置已保存"
+	}
+	dialog.ShowInformation("成功", message, l.window)
+}
+
+// cancelRedisConfig 取消 Redis 配置修改（恢复缓存的值）
+func (l *GVALauncher) cancelRedisConfig() {
+	// 恢复开关状态
+	if l.redisSwitch != nil {
+		l.redisSwitch.SetChecked(l.cachedRedisConfig.UseRedis)
+	}
+
+	// 恢复输入框内容
+	if l.redisAddrEntry != nil {
+		l.redisAddrEntry.SetText(l.cachedRedisConfig.Addr)
+	}
+	if l.redisPassEntry != nil {
+		l.redisPassEntry.SetText(l.cachedRedisConfig.Password)
+	}
+	if l.redisDBEntry != nil {
+		l.redisDBEntry.SetText(fmt.Sprintf("%d", l.cachedRedisConfig.DB))
+	}
+
+	// 更新输入框状态
+	l.updateRedisFieldsState(l.cachedRedisConfig.UseRedis)
+
+	dialog.ShowInformation("提示", "已恢复原配置", l.window)
+}
+
+// testRedisConnection 测试 Redis 连接（包含完整的认证和功能测试）
+func (l *GVALauncher) testRedisConnection() {
+	addr := strings.TrimSpace(l.redisAddrEntry.Text)
+	password := l.redisPassEntry.Text
+	dbStr := strings.TrimSpace(l.redisDBEntry.Text)
+
+	if addr == "" {
+		dialog.ShowError(fmt.Errorf("请输入 Redis 地址"), l.window)
+		return
+	}
+
+	db, err := strconv.Atoi(dbStr)
+	if err != nil || db < 0 || db > 15 {
+		dialog.ShowError(fmt.Errorf("数据库编号无效，范围: 0-15"), l.window)
+		return
+	}
+
+	// 显示进度对话框
+	progress := dialog.NewProgressInfinite("测试连接", "正在进行详细的 Redis 连接测试...", l.window)
+	progress.Show()
+
+	l.background("Redis 连接测试", func(context.Context) func() {
+		testResults, err := redisx.TestConnection(addr, password, db, l.config.EffectiveTimeouts().RedisDial())
+		if err != nil {
+			return func() {
+				progress.Hide()
+				l.showError(err, nil)
+			}
+		}
+
+		// 所有测试通过，显示详细结果
+		resultMsg := strings.Join(testResults, "\n")
+
+		auth := "无密码模式"
+		if password != "" {
+			auth = "✓ 密码验证通过"
+		}
+		summaryMsg := fmt.Sprintf("✅ Redis连接测试完成！\n\n📋 测试详情:\n%s\n\n📊 配置摘要:\n• 地址: %s\n• 认证: %s\n• 数据库: %d\n• 功能: ✓ 读写正常\n\n🚀 配置无误，可以安全使用！", resultMsg, addr, auth, db)
+
+		// 先隐藏进度对话框，再显示成功对话框
+		return func() {
+			progress.Hide()
+			dialog.ShowInformation("测试成功", summaryMsg, l.window)
+		}
+	})
+}
+
+// copyRedisDSN 由输入框中的地址、密码和库号生成 redis:// 连接串并复制
+func (l *GVALauncher) copyRedisDSN() {
+	addr := strings.TrimSpace(l.redisAddrEntry.Text)
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		dialog.ShowInformation("提示", "Redis 地址需要是 主机:端口 的形式", l.window)
+		return
+	}
+	info := dsn.Info{Scheme: dsn.Redis, Host: host, Password: l.redisPassEntry.Text, Database: strings.TrimSpace(l.redisDBEntry.Text)}
+	info.Port, _ = strconv.Atoi(port)
+	l.copyToClipboard(info.String(), "Redis DSN")
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/jobs"
+	"gva-launcher/registry"
+	"gva-launcher/secretscan"
+)
+
+// showRegistryDialog 镜像推送：用项目中的 Dockerfile 构建 server / web 镜像，打上标签后推送到镜像仓库
+func (l *GVALauncher) showRegistryDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	cfg := l.config.Registry
+	images := registry.ProjectImages(l.project.Root)
+
+	var kindLabels []string
+	for _, kind := range registry.Kinds {
+		kindLabels = append(kindLabels, registry.KindLabel(kind))
+	}
+	serverEntry := widget.NewEntry()
+	kindSelect := widget.NewSelect(kindLabels, func(label string) {
+		kind := registry.Kinds[slices.Index(kindLabels, label)]
+		if server := registry.DefaultServer(kind); server != "" {
+			serverEntry.SetPlaceHolder(server + "（留空使用默认地址）")
+		} else {
+			serverEntry.SetPlaceHolder("例如: harbor.example.com")
+		}
+	})
+	kindSelect.SetSelected(kindLabels[0])
+	if i := slices.Index(registry.Kinds, cfg.Kind); i >= 0 {
+		kindSelect.SetSelected(kindLabels[i])
+	}
+	serverEntry.SetText(cfg.Server)
+	namespaceEntry := widget.NewEntry()
+	namespaceEntry.SetPlaceHolder("Docker Hub 的用户名或组织、ACR 的命名空间、Harbor 的项目")
+	namespaceEntry.SetText(cfg.Namespace)
+	userEntry := widget.NewEntry()
+	userEntry.SetText(cfg.Username)
+	passEntry := widget.NewPasswordEntry()
+	passEntry.SetPlaceHolder("密码或访问令牌")
+	passEntry.SetText(cfg.Password)
+	tagEntry := widget.NewEntry()
+	tagEntry.SetPlaceHolder("同时会打上 latest 标签")
+	tagEntry.SetText(time.Now().Format("20060102-150405"))
+
+	imageChecks := container.NewVBox()
+	checks := make([]*widget.Check, len(images))
+	for i, image := range images {
+		checks[i] = widget.NewCheck(fmt.Sprintf("%s（%s）", image.Name, image.Dockerfile), nil)
+		checks[i].SetChecked(!slices.Contains(cfg.Skip, image.Name))
+		imageChecks.Add(checks[i])
+	}
+	if len(images) == 0 {
+		imageChecks.Add(widget.NewLabel("⚠️ 项目中没有找到 server/Dockerfile 或 web/Dockerfile"))
+	}
+
+	pushBtn := widget.NewButton("🐳 构建并推送", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		r := config.Registry{
+			Kind:      registry.Kinds[slices.Index(kindLabels, kindSelect.Selected)],
+			Server:    strings.TrimSpace(serverEntry.Text),
+			Namespace: strings.TrimSpace(namespaceEntry.Text),
+			Username:  strings.TrimSpace(userEntry.Text),
+			Password:  passEntry.Text,
+			LastTag:   strings.TrimSpace(tagEntry.Text),
+		}
+		var selected []registry.Image
+		for i, image := range images {
+			if checks[i].Checked {
+				selected = append(selected, image)
+			} else {
+				r.Skip = append(r.Skip, image.Name)
+			}
+		}
+		target := registry.Registry{Kind: r.Kind, Server: r.Server, Namespace: r.Namespace, Username: r.Username, Password: r.Password}
+		if err := target.Validate(); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if len(selected) == 0 {
+			l.showError(fmt.Errorf("请至少选择一个镜像"), nil)
+			return
+		}
+		l.config.Registry = r
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		// 镜像的构建目录中有配置文件（config.yaml、.env.production 等）时先检查其中的密钥
+		var findings []secretscan.Finding
+		for _, image := range selected {
+			found, _ := secretscan.ScanConfigs(image.Context)
+			findings = append(findings, found...)
+		}
+		if len(findings) == 0 {
+			l.pushImages(target, selected, r.LastTag)
+			return
+		}
+		l.showSecretsConfirm(findings, func(ok bool) {
+			if ok {
+				l.pushImages(target, selected, r.LastTag)
+			}
+		})
+	})
+
+	help := widget.NewLabel("使用系统的 docker 命令构建镜像（需已安装并启动 Docker），每个镜像打上填写的标签和 latest 后推送。" +
+		"登录凭据只写入推送时的临时配置目录，不会修改 ~/.docker/config.json。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("仓库类型", kindSelect),
+		widget.NewFormItem("仓库地址", serverEntry),
+		widget.NewFormItem("命名空间", namespaceEntry),
+		widget.NewFormItem("用户名", userEntry),
+		widget.NewFormItem("密码", passEntry),
+		widget.NewFormItem("标签", tagEntry),
+		widget.NewFormItem("镜像", imageChecks),
+	)
+	d := dialog.NewCustom("🐳 镜像推送", "关闭", container.NewVBox(help, form, pushBtn), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
+
+// pushImages 在任务中构建并推送选中的镜像，完成后显示结果
+func (l *GVALauncher) pushImages(target registry.Registry, selected []registry.Image, tag string) {
+	var results []registry.Result
+	job := l.jobs.Submit("推送镜像", func(ctx context.Context, j *jobs.Job) error {
+		var err error
+		results, err = registry.Push(ctx, target, selected, tag, j, func(image int, p registry.Progress) {
+			if p.Total == 0 {
+				return
+			}
+			j.SetCount(float64(p.Done), "层")
+			j.SetProgress((float64(image) + float64(p.Done)/float64(p.Total)) / float64(len(selected)))
+		})
+		return err
+	})
+	l.waitJob(job, "🐳 镜像推送", "正在构建并推送 "+tag+"...", func(err error) {
+		switch {
+		case errors.Is(err, jobs.ErrCanceled):
+		case err != nil:
+			l.showError(err, nil)
+		default:
+			l.showPushResults(results)
+		}
+	})
+}
+
+// showPushResults 显示推送完成的镜像引用和摘要（可复制）
+func (l *GVALauncher) showPushResults(results []registry.Result) {
+	var lines []string
+	for _, r := range results {
+		line := r.Reference
+		if r.Digest != "" {
+			line += "\n  " + r.Digest
+		}
+		lines = append(lines, line)
+	}
+	output := widget.NewMultiLineEntry()
+	output.SetText(strings.Join(lines, "\n"))
+	output.Wrapping = fyne.TextWrapOff
+	output.SetMinRowsVisible(max(len(lines)*2, 3))
+	content := container.NewVBox(widget.NewLabel(fmt.Sprintf("✅ 已推送 %d 个镜像:", len(results))), output)
+	d := dialog.NewCustom("推送完成", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(50), 0))
+	d.Show()
+}
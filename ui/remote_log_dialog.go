@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/dsn"
+	"gva-launcher/events"
+	"gva-launcher/remotelog"
+	"gva-launcher/sshkey"
+)
+
+// 远程日志的起始时间选项
+const (
+	remoteSinceCache = "只看新日志（接着本地缓存）"
+	remoteSinceHour  = "最近 1 小时"
+	remoteSinceDay   = "最近 24 小时"
+	remoteSinceAll   = "不限（最近若干行）"
+)
+
+// remoteDefaultKey 不使用面板管理的密钥时的选项
+const remoteDefaultKey = "系统默认（~/.ssh 与 ssh-agent）"
+
+// remoteLogSource 远程日志的 OutputChanged 事件来源
+const remoteLogSource = "远程日志"
+
+// showRemoteLogDialog 显示远程日志：通过 SSH 跟踪服务器上的日志文件，
+// 关键字和起始时间在服务器端过滤，收到的日志缓存在本地
+func (l *GVALauncher) showRemoteLogDialog() {
+	if l.remoteLog == nil {
+		l.remoteLog = &remotelog.Stream{}
+	}
+	stream := l.remoteLog
+	cfg := l.config.RemoteLog
+
+	hostEntry := widget.NewEntry()
+	hostEntry.SetPlaceHolder("例如: 192.168.1.20 或 ssh 配置中的主机别名")
+	hostEntry.SetText(cfg.Host)
+	portEntry := widget.NewEntry()
+	portEntry.SetPlaceHolder("22")
+	if cfg.Port > 0 {
+		portEntry.SetText(strconv.Itoa(cfg.Port))
+	}
+	userEntry := widget.NewEntry()
+	userEntry.SetPlaceHolder("留空则使用 ssh 配置中的用户")
+	userEntry.SetText(cfg.User)
+	acceptDSN(hostEntry, func(info dsn.Info) {
+		fillSSHForm(info, hostEntry, portEntry, userEntry)
+	}, dsn.SSH)
+	pathEntry := widget.NewEntry()
+	pathEntry.SetPlaceHolder("例如: /opt/gva/server/log/server.log")
+	pathEntry.SetText(cfg.Path)
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder("例如: ERROR|panic（留空不过滤）")
+	patternEntry.SetText(cfg.Pattern)
+	// 面板管理的 SSH 密钥（第一项使用 ssh 的默认密钥）
+	keyOptions := []string{remoteDefaultKey}
+	for _, key := range sshkey.List(config.SSHKeyDir()) {
+		keyOptions = append(keyOptions, key.Name)
+	}
+	keySelect := widget.NewSelect(keyOptions, nil)
+	keySelect.SetSelected(remoteDefaultKey)
+	for _, option := range keyOptions {
+		if option == cfg.Key {
+			keySelect.SetSelected(option)
+		}
+	}
+
+	// 从登记的远程服务器填入连接信息
+	var serverNames []string
+	for _, s := range l.config.Servers {
+		serverNames = append(serverNames, s.Name)
+	}
+	serverSelect := widget.NewSelect(serverNames, func(name string) {
+		s, ok := config.FindServer(l.config.Servers, name)
+		if !ok {
+			return
+		}
+		hostEntry.SetText(s.Host)
+		portEntry.SetText("")
+		if s.Port > 0 {
+			portEntry.SetText(strconv.Itoa(s.Port))
+		}
+		userEntry.SetText(s.User)
+		keySelect.SetSelected(remoteDefaultKey)
+		for _, option := range keyOptions {
+			if option == s.Key {
+				keySelect.SetSelected(option)
+			}
+		}
+	})
+	serverSelect.PlaceHolder = "从远程服务器中选择（可选）"
+
+	sinceSelect := widget.NewSelect([]string{remoteSinceCache, remoteSinceHour, remoteSinceDay, remoteSinceAll}, nil)
+	sinceSelect.SetSelected(remoteSinceCache)
+
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapWord
+	output.SetPlaceHolder("收到的日志显示在这里")
+	output.SetText(stream.Output())
+
+	status := widget.NewLabel("未连接")
+	var startBtn, stopBtn *widget.Button
+
+	setRunning := func(running bool) {
+		if running {
+			startBtn.Disable()
+			stopBtn.Enable()
+		} else {
+			startBtn.Enable()
+			stopBtn.Disable()
+		}
+	}
+
+	// target 读取表单中的服务器和文件
+	target := func() (remotelog.Target, error) {
+		port := 0
+		if text := strings.TrimSpace(portEntry.Text); text != "" {
+			p, err := strconv.Atoi(text)
+			if err != nil {
+				return remotelog.Target{}, fmt.Errorf("SSH 端口无效: %s", text)
+			}
+			port = p
+		}
+		t := remotelog.Target{
+			Host: strings.TrimSpace(hostEntry.Text),
+			Port: port,
+			User: strings.TrimSpace(userEntry.Text),
+			Path: strings.TrimSpace(pathEntry.Text),
+		}
+		return t, t.Validate()
+	}
+
+	// ssh 在自己的协程中回调，只发布 OutputChanged 事件，对话框打开期间订阅并刷新
+	stream.OnLines = func([]string) {
+		l.bus.Publish(events.Event{Topic: events.OutputChanged, Output: events.OutputState{Source: remoteLogSource}})
+	}
+	stream.OnExit = func(err error) {
+		state := events.OutputState{Source: remoteLogSource, Exited: true}
+		if err != nil {
+			state.Error = err.Error()
+		}
+		l.bus.Publish(events.Event{Topic: events.OutputChanged, Output: state})
+	}
+	unsubscribe := l.subscribe(func(e events.Event) {
+		switch {
+		case e.Output.Source != remoteLogSource:
+		case !e.Output.Exited:
+			output.SetText(stream.Output())
+			output.CursorRow = len(output.Text)
+		default:
+			setRunning(false)
+			if e.Output.Error != "" {
+				status.SetText("❌ " + e.Output.Error)
+			} else {
+				status.SetText("已停止")
+			}
+		}
+	}, events.OutputChanged)
+
+	startBtn = widget.NewButton("▶ 开始跟踪", func() {
+		t, err := target()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		filter := remotelog.Filter{Pattern: strings.TrimSpace(patternEntry.Text)}
+		switch sinceSelect.Selected {
+		case remoteSinceCache:
+			filter.Resume = true
+		case remoteSinceHour:
+			filter.Since = time.Now().Add(-time.Hour)
+		case remoteSinceDay:
+			filter.Since = time.Now().Add(-24 * time.Hour)
+		}
+
+		key := ""
+		if keySelect.Selected != remoteDefaultKey {
+			key = keySelect.Selected
+		}
+
+		l.config.RemoteLog = config.RemoteLog{Host: t.Host, Port: t.Port, User: t.User, Path: t.Path, Pattern: filter.Pattern, Key: key}
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		start := func(release func()) {
+			output.SetText("")
+			if err := stream.Start(t, filter, remotelog.NewCache(config.RemoteLogDir(), t), release); err != nil {
+				l.showError(err, nil)
+				return
+			}
+			setRunning(true)
+			status.SetText(l.statusBadge(statusRunning, fmt.Sprintf("正在跟踪 %s:%s", t.Host, t.Path)))
+		}
+		if key == "" {
+			start(nil)
+			return
+		}
+		l.unlockSSHKey(key, func(identity string, release func()) {
+			t.Identity = identity
+			start(release)
+		})
+	})
+
+	stopBtn = widget.NewButton("⛔ 停止", func() {
+		stream.Stop()
+	})
+
+	clearBtn := widget.NewButton("🧹 清除本地缓存", func() {
+		t, err := target()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if err := remotelog.NewCache(config.RemoteLogDir(), t).Clear(); err != nil {
+			l.showError(fmt.Errorf("清除缓存失败: %w", err), nil)
+			return
+		}
+		status.SetText("已清除本地缓存，下次将重新传输最近的日志")
+	})
+
+	setRunning(stream.Running())
+	if stream.Running() {
+		status.SetText(l.statusBadge(statusRunning, "正在跟踪"))
+	}
+
+	help := widget.NewLabel("通过系统的 ssh 命令跟踪服务器上的日志文件（需已配置 SSH 密钥登录，面板不会输入密码，可在「🔑 SSH 密钥」中生成）。" +
+		"关键字和起始时间在服务器端过滤后才传输，并启用 ssh 压缩；收到的日志以 gzip 缓存在本地，" +
+		"再次查看时只传输缓存之后的新日志，适合较慢的 VPN 链路。起始时间按本机时间比较，服务器时区不同时请选择“不限”。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("登记的服务器", serverSelect),
+		widget.NewFormItem("服务器", hostEntry),
+		widget.NewFormItem("SSH 端口", portEntry),
+		widget.NewFormItem("用户名", userEntry),
+		widget.NewFormItem("SSH 密钥", keySelect),
+		widget.NewFormItem("日志文件", pathEntry),
+		widget.NewFormItem("关键字", patternEntry),
+		widget.NewFormItem("起始时间", sinceSelect),
+	)
+
+	content := container.NewBorder(
+		container.NewVBox(help, form, container.NewGridWithColumns(3, startBtn, stopBtn, clearBtn), status),
+		nil, nil, nil,
+		output,
+	)
+
+	d := dialog.NewCustom("📡 远程日志", "关闭", content, l.window)
+	// 关闭窗口时停止跟踪，避免 ssh 在后台继续传输
+	d.SetOnClosed(func() {
+		unsubscribe()
+		stream.Stop()
+	})
+	d.Resize(fyne.NewSize(l.calcVW(90), l.calcVH(80)))
+	d.Show()
+}
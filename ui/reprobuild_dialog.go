@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/jobs"
+	"gva-launcher/reprobuild"
+)
+
+// reproReportName 验证报告保存在 server/ 中的文件名（与构建产物和物料清单放在一起）
+const reproReportName = "reproducible-build.txt"
+
+// showReproBuildDialog 可复现构建：以固定参数构建两次后端并比较哈希，报告构建是否可复现以及不同的构建输入
+func (l *GVALauncher) showReproBuildDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapOff
+	output.SetPlaceHolder("点击「开始验证」后在这里显示报告")
+	output.SetMinRowsVisible(14)
+	status := widget.NewLabel("")
+
+	verifyBtn := widget.NewButton("▶️ 开始验证", func() {
+		var result reprobuild.Result
+		job := l.jobs.Submit("验证可复现构建", func(ctx context.Context, j *jobs.Job) error {
+			var err error
+			result, err = l.builds.VerifyReproducible(ctx, j)
+			return err
+		})
+		l.waitJob(job, "🔁 可复现构建", "正在构建两次后端并比较结果（第二次使用全新的构建缓存，耗时较长）...", func(err error) {
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+			case err != nil:
+				l.showError(err, nil)
+			default:
+				output.SetText(reprobuild.Report(result))
+				if result.Reproducible {
+					status.SetText("✅ 可复现")
+				} else {
+					status.SetText(fmt.Sprintf("❌ 不可复现（%d 项输入不同）", len(result.Differences)))
+				}
+			}
+		})
+	})
+	copyBtn := widget.NewButton("📋 复制报告", func() {
+		if output.Text != "" {
+			l.copyToClipboard(output.Text, "验证报告")
+		}
+	})
+	saveBtn := widget.NewButton("💾 保存报告", func() {
+		if output.Text == "" || !l.ensureProjectOwner() {
+			return
+		}
+		path := filepath.Join(l.project.ServerDir(), reproReportName)
+		if err := os.WriteFile(path, []byte(output.Text), 0644); err != nil {
+			l.showError(fmt.Errorf("保存失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("已保存", "已保存到:\n"+path, l.window)
+	})
+
+	help := widget.NewLabel("以固定参数（CGO_ENABLED=0 go build -trimpath -ldflags=-buildid=）构建两次后端，第二次使用全新的构建缓存，" +
+		"比较两个可执行文件的 SHA-256；不同时从可执行文件嵌入的构建信息中列出不同的输入（Go 版本、依赖、构建参数、Git 提交）。" +
+		"验证在临时目录中进行，不会覆盖 server/gva-server。")
+	help.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(help, container.NewGridWithColumns(3, verifyBtn, copyBtn, saveBtn), status)
+	d := dialog.NewCustom("🔁 可复现构建", "关闭", container.NewBorder(top, nil, nil, nil, output), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), l.calcVH(75)))
+	d.Show()
+}
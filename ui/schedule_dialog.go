@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/jobs"
+	"gva-launcher/scheduler"
+)
+
+// scheduleRow 定时任务对话框中的一个任务
+type scheduleRow struct {
+	nameEntry    *widget.Entry
+	cronEntry    *widget.Entry
+	actionSelect *widget.Select
+	commandEntry *widget.Entry
+	enabledCheck *widget.Check
+	statusLabel  *widget.Label
+}
+
+// task 从输入框读取任务配置
+func (r *scheduleRow) task(actionByLabel map[string]string) config.ScheduledTask {
+	return config.ScheduledTask{
+		Name:    strings.TrimSpace(r.nameEntry.Text),
+		Cron:    strings.TrimSpace(r.cronEntry.Text),
+		Action:  actionByLabel[r.actionSelect.Selected],
+		Command: strings.TrimSpace(r.commandEntry.Text),
+		Enabled: r.enabledCheck.Checked,
+	}
+}
+
+// showScheduleDialog 显示定时任务列表（下次执行时间、上次结果），支持编辑和立即执行
+func (l *GVALauncher) showScheduleDialog() {
+	var labels []string
+	actionByLabel := make(map[string]string)
+	labelByAction := make(map[string]string)
+	for _, action := range l.scheduler.Actions() {
+		labels = append(labels, action.Label)
+		actionByLabel[action.Label] = action.ID
+		labelByAction[action.ID] = action.Label
+	}
+
+	var rows []*scheduleRow
+	rowsBox := container.NewVBox()
+
+	// refreshStatus 刷新所有任务的状态文字
+	refreshStatus := func() {
+		for _, row := range rows {
+			row.statusLabel.SetText(l.scheduleStatusText(row.task(actionByLabel)))
+		}
+	}
+
+	addRow := func(task config.ScheduledTask) {
+		row := &scheduleRow{
+			nameEntry:    widget.NewEntry(),
+			cronEntry:    widget.NewEntry(),
+			actionSelect: widget.NewSelect(labels, nil),
+			commandEntry: widget.NewEntry(),
+			statusLabel:  widget.NewLabel(""),
+		}
+		row.nameEntry.SetPlaceHolder("任务名")
+		row.nameEntry.SetText(task.Name)
+		row.cronEntry.SetPlaceHolder("0 2 * * *（分 时 日 月 周）")
+		row.cronEntry.SetText(task.Cron)
+		row.commandEntry.SetPlaceHolder("自定义命令（仅\"自定义命令\"动作使用）")
+		row.commandEntry.SetText(task.Command)
+		row.enabledCheck = widget.NewCheck("启用", nil)
+		row.enabledCheck.SetChecked(task.Enabled)
+		if label, ok := labelByAction[task.Action]; ok {
+			row.actionSelect.SetSelected(label)
+		} else if len(labels) > 0 {
+			row.actionSelect.SetSelected(labels[0])
+		}
+
+		// 编辑后立即刷新下次执行时间
+		row.cronEntry.OnChanged = func(string) { refreshStatus() }
+		row.enabledCheck.OnChanged = func(bool) { refreshStatus() }
+
+		rows = append(rows, row)
+
+		var rowBox *fyne.Container
+		runBtn := widget.NewButton("▶ 运行", func() {
+			if l.scheduler.RunNow(row.task(actionByLabel)) == nil {
+				dialog.ShowInformation("提示", "该任务正在执行中", l.window)
+			}
+		})
+		deleteBtn := widget.NewButton("🗑️", func() {
+			for i, r := range rows {
+				if r == row {
+					rows = append(rows[:i], rows[i+1:]...)
+					break
+				}
+			}
+			rowsBox.Remove(rowBox)
+		})
+
+		rowBox = container.NewVBox(
+			container.NewGridWithColumns(3, row.nameEntry, row.cronEntry, row.actionSelect),
+			container.NewBorder(nil, nil, row.enabledCheck, container.NewHBox(runBtn, deleteBtn), row.commandEntry),
+			row.statusLabel,
+			widget.NewSeparator(),
+		)
+		rowsBox.Add(rowBox)
+	}
+
+	for _, task := range l.config.Schedules {
+		addRow(task)
+	}
+	refreshStatus()
+
+	addBtn := widget.NewButton("➕ 添加任务", func() {
+		addRow(config.ScheduledTask{Cron: "@nightly", Enabled: true})
+		refreshStatus()
+	})
+
+	help := widget.NewLabel("支持标准 cron 表达式（如 \"0 2 * * *\" 每天 2 点、\"0 3 * * 0\" 每周日 3 点）\n" +
+		"以及 @hourly、@daily、@nightly（每天 2 点）、@weekly、@monthly；面板运行期间才会按时执行")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(rowsBox)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(85), l.calcVH(40)))
+
+	content := container.NewBorder(help, addBtn, nil, nil, scroll)
+
+	// 对话框打开期间订阅定时任务事件，任务状态变化时刷新
+	unsubscribe := l.subscribe(func(events.Event) { refreshStatus() }, events.ScheduleChanged)
+
+	d := dialog.NewCustomConfirm("⏰ 定时任务", "💾 保存", "❌ 关闭", content, func(ok bool) {
+		unsubscribe()
+		if !ok {
+			return
+		}
+
+		var list []config.ScheduledTask
+		names := make(map[string]bool)
+		for _, row := range rows {
+			task := row.task(actionByLabel)
+			if task.Name == "" {
+				dialog.ShowError(fmt.Errorf("任务名不能为空"), l.window)
+				return
+			}
+			if names[task.Name] {
+				dialog.ShowError(fmt.Errorf("任务名重复: %s", task.Name), l.window)
+				return
+			}
+			names[task.Name] = true
+			if _, err := scheduler.ParseCron(task.Cron); err != nil {
+				dialog.ShowError(fmt.Errorf("任务 %s: %v", task.Name, err), l.window)
+				return
+			}
+			list = append(list, task)
+		}
+
+		l.config.Schedules = list
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("成功", fmt.Sprintf("已保存 %d 个定时任务", len(list)), l.window)
+	}, l.window)
+	d.Show()
+}
+
+// scheduleStatusText 任务的下次执行时间和上次结果
+func (l *GVALauncher) scheduleStatusText(task config.ScheduledTask) string {
+	next := "下次: "
+	if t, err := scheduler.NextRun(task, time.Now()); err != nil {
+		next += err.Error()
+	} else if t.IsZero() {
+		next += "一年内不会执行"
+	} else {
+		next += t.Format("2006-01-02 15:04")
+	}
+
+	state := l.scheduler.State(task.Name)
+	last := "上次: 未执行"
+	switch {
+	case state.Running:
+		last = "上次: ⏳ 执行中..."
+	case state.LastStatus == jobs.StatusSucceeded:
+		last = fmt.Sprintf("上次: ✅ %s 成功", state.LastRun.Format("01-02 15:04"))
+	case state.LastStatus == jobs.StatusFailed:
+		last = fmt.Sprintf("上次: ❌ %s 失败（%s）", state.LastRun.Format("01-02 15:04"), firstLine(state.LastError))
+	case state.LastStatus == jobs.StatusCanceled:
+		last = fmt.Sprintf("上次: ⛔ %s 已取消", state.LastRun.Format("01-02 15:04"))
+	}
+	return "　" + next + "　　" + last
+}
+
+// firstLine 取错误信息的第一行（避免多行错误撑开列表）
+func firstLine(s string) string {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
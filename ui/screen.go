@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"gva-launcher/envcache"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/session"
+)
+
+// ========================================
+// 屏幕分辨率检测
+// ========================================
+
+// factScreen 屏幕分辨率的缓存键（值为 "宽x高"）；按图形会话类型区分，SSH 转发时显示器与本机登录时不同
+func factScreen() string {
+	return "screen:" + string(session.Detect().Kind)
+}
+
+// detectScreenSize 获取屏幕分辨率：优先使用缓存（envcache.ScreenTTL 内有效），过期后重新检测
+// 检测需要执行 powershell / system_profiler / xrandr，耗时可达数秒，缓存后启动时窗口可以立即显示
+func (l *GVALauncher) detectScreenSize() {
+	size, _ := l.facts.Get(factScreen(), envcache.ScreenTTL, func() (string, error) {
+		l.probeScreenSize()
+		return fmt.Sprintf("%.0fx%.0f", l.screenWidth, l.screenHeight), nil
+	})
+
+	var width, height float32
+	if n, _ := fmt.Sscanf(size, "%fx%f", &width, &height); n == 2 && width > 0 && height > 0 {
+		l.screenWidth, l.screenHeight = width, height
+	} else {
+		l.probeScreenSize()
+	}
+}
+
+// probeScreenSize 跨平台检测屏幕分辨率（逻辑分辨率）
+func (l *GVALauncher) probeScreenSize() {
+	// 默认值（适用于大多数屏幕）
+	l.screenWidth = 1920
+	l.screenHeight = 1080
+
+	switch runtime.GOOS {
+	case "windows":
+		l.detectScreenSizeWindows()
+	case "darwin": // macOS
+		l.detectScreenSizeMacOS()
+	case "linux":
+		l.detectScreenSizeLinux()
+	default:
+		// 其他系统使用默认值
+		// 未知操作系统，使用默认分辨率
+	}
+}
+
+// detectScreenSizeWindows Windows 平台屏幕检测
+func (l *GVALauncher) detectScreenSizeWindows() {
+	output, err := sysutil.Runner.Output("", "powershell", "-Command",
+		"Add-Type -AssemblyName System.Windows.Forms; "+
+			"$screen = [System.Windows.Forms.Screen]::PrimaryScreen.Bounds; "+
+			"Write-Output \"$($screen.Width)x$($screen.Height)\"")
+	if err == nil {
+		resolution := strings.TrimSpace(string(output))
+		parts := strings.Split(resolution, "x")
+		if len(parts) == 2 {
+			if width, err := strconv.Atoi(parts[0]); err == nil && width > 0 {
+				l.screenWidth = float32(width)
+			}
+			if height, err := strconv.Atoi(parts[1]); err == nil && height > 0 {
+				l.screenHeight = float32(height)
+			}
+		}
+	}
+}
+
+// detectScreenSizeMacOS macOS 平台屏幕检测
+func (l *GVALauncher) detectScreenSizeMacOS() {
+	// 方法1：使用 system_profiler（推荐）
+	output, err := sysutil.Runner.Output("", "system_profiler", "SPDisplaysDataType")
+	if err == nil {
+		outputStr := string(output)
+		// 查找 "Resolution:" 行
+		// 格式示例：Resolution: 2560 x 1440
+		lines := strings.Split(outputStr, "\n")
+		for _, line := range lines {
+			if strings.Contains(line, "Resolution:") {
+				// 提取分辨率
+				parts := strings.Fields(line)
+				for i, part := range parts {
+					if part == "Resolution:" && i+3 < len(parts) {
+						if width, err := strconv.Atoi(parts[i+1]); err == nil && width > 0 {
+							l.screenWidth = float32(width)
+						}
+						if height, err := strconv.Atoi(parts[i+3]); err == nil && height > 0 {
+							l.screenHeight = float32(height)
+						}
+						return
+					}
+				}
+			}
+		}
+	}
+
+	// 方法2：使用 osascript 作为备用
+	output, err = sysutil.Runner.Output("", "osascript", "-e",
+		"tell application \"Finder\" to get bounds of window of desktop")
+	if err == nil {
+		// 输出格式：0, 0, 2560, 1440
+		outputStr := strings.TrimSpace(string(output))
+		parts := strings.Split(outputStr, ", ")
+		if len(parts) == 4 {
+			if width, err := strconv.Atoi(parts[2]); err == nil && width > 0 {
+				l.screenWidth = float32(width)
+			}
+			if height, err := strconv.Atoi(parts[3]); err == nil && height > 0 {
+				l.screenHeight = float32(height)
+			}
+		}
+	}
+}
+
+// detectScreenSizeLinux Linux 平台屏幕检测
+func (l *GVALauncher) detectScreenSizeLinux() {
+	// Wayland 下 XWayland 的 xrandr 可能报告物理分辨率（没有按缩放换算），wlroots 合成器先用 wlr-randr 读取逻辑分辨率
+	if session.Detect().Kind == session.Wayland {
+		if output, err := sysutil.Runner.Output("", "wlr-randr"); err == nil {
+			if width, height, ok := session.ParseWlrRandr(string(output)); ok {
+				l.screenWidth, l.screenHeight = width, height
+				return
+			}
+		}
+	}
+
+	// 方法1：使用 xrandr（最常见）
+	output, err := sysutil.Runner.Output("", "xrandr")
+	if err == nil {
+		outputStr := string(output)
+		lines := strings.Split(outputStr, "\n")
+		for _, line := range lines {
+			// 查找当前活动分辨率（带 * 号的行）
+			// 格式示例：   1920x1080     60.00*+
+			if strings.Contains(line, "*") {
+				fields := strings.Fields(line)
+				if len(fields) > 0 {
+					resolution := fields[0]
+					parts := strings.Split(resolution, "x")
+					if len(parts) == 2 {
+						if width, err := strconv.Atoi(parts[0]); err == nil && width > 0 {
+							l.screenWidth = float32(width)
+						}
+						if height, err := strconv.Atoi(parts[1]); err == nil && height > 0 {
+							l.screenHeight = float32(height)
+						}
+						return
+					}
+				}
+			}
+		}
+	}
+
+	// 方法2：使用 xdpyinfo 作为备用
+	output, err = sysutil.Runner.Output("", "xdpyinfo")
+	if err == nil {
+		outputStr := string(output)
+		lines := strings.Split(outputStr, "\n")
+		for _, line := range lines {
+			// 查找 "dimensions:" 行
+			// 格式示例：  dimensions:    1920x1080 pixels (508x285 millimeters)
+			if strings.Contains(line, "dimensions:") {
+				fields := strings.Fields(line)
+				for i, field := range fields {
+					if field == "dimensions:" && i+1 < len(fields) {
+						resolution := fields[i+1]
+						parts := strings.Split(resolution, "x")
+						if len(parts) == 2 {
+							if width, err := strconv.Atoi(parts[0]); err == nil && width > 0 {
+								l.screenWidth = float32(width)
+							}
+							if height, err := strconv.Atoi(parts[1]); err == nil && height > 0 {
+								l.screenHeight = float32(height)
+							}
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// 方法3：尝试读取 /sys/class/graphics/fb0/virtual_size（直接帧缓冲）
+	data, err := os.ReadFile("/sys/class/graphics/fb0/virtual_size")
+	if err == nil {
+		resolution := strings.TrimSpace(string(data))
+		parts := strings.Split(resolution, ",")
+		if len(parts) == 2 {
+			if width, err := strconv.Atoi(strings.TrimSpace(parts[0])); err == nil && width > 0 {
+				l.screenWidth = float32(width)
+			}
+			if height, err := strconv.Atoi(strings.TrimSpace(parts[1])); err == nil && height > 0 {
+				l.screenHeight = float32(height)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/events"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+	"gva-launcher/script"
+)
+
+// defaultScript 控制台首次打开时的示例脚本（Starlark，Python 语法的子集）
+const defaultScript = `# 依赖缺失时先安装，再启动服务并等待后端端口
+if not deps_ok():
+    install()
+if not running():
+    start()
+port = backend_port()
+ok = wait_port(port, timeout=60)
+print("后端端口", port, "已监听:", ok)
+`
+
+// showScriptConsole 显示脚本控制台：编写并执行针对当前项目的一次性自动化脚本
+func (l *GVALauncher) showScriptConsole() {
+	editor := widget.NewMultiLineEntry()
+	editor.TextStyle = fyne.TextStyle{Monospace: true}
+	if l.scriptSource == "" {
+		l.scriptSource = defaultScript
+	}
+	editor.SetText(l.scriptSource)
+	editor.OnChanged = func(text string) { l.scriptSource = text }
+
+	output := widget.NewMultiLineEntry()
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Wrapping = fyne.TextWrapWord
+	output.SetPlaceHolder("print 的输出和构建日志显示在这里")
+
+	var running *jobs.Job
+	var runBtn, stopBtn *widget.Button
+	stopBtn = widget.NewButton("⛔ 停止", func() {
+		if running != nil {
+			running.Cancel()
+		}
+	})
+	stopBtn.Disable()
+
+	runBtn = widget.NewButton("▶ 运行", func() {
+		if !l.project.IsSet() {
+			l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+			return
+		}
+		if !l.ensureProjectOwner() {
+			return
+		}
+
+		source := editor.Text
+		output.SetText("")
+		runBtn.Disable()
+		stopBtn.Enable()
+
+		// 通过任务队列执行，可在任务中心查看日志；脚本输出写入任务日志，
+		// 控制台订阅任务的 JobOutput 事件显示输出
+		running = l.jobs.Submit("脚本控制台", func(ctx context.Context, j *jobs.Job) error {
+			funcs := launcher.ScriptFuncs(l.project, l.services, l.deps, l.builds, j)
+			return script.New(j, funcs).Run(ctx, source)
+		})
+
+		job := running
+		l.watchJob(job, func() { output.SetText(job.Output()) }, func(err error) {
+			runBtn.Enable()
+			stopBtn.Disable()
+			text := job.Output()
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+				text += "\n⛔ 已停止"
+			case err != nil:
+				text += "\n❌ " + err.Error()
+			default:
+				text += "\n✅ 执行完成"
+			}
+			output.SetText(text)
+			// 脚本可能启动或停止了服务
+			l.checkServiceStatus()
+		}, events.JobOutput)
+	})
+
+	help := widget.NewLabel("脚本使用 Starlark（Python 语法的子集）：支持变量、函数定义（def）、if / for / while、列表和字典，# 开头为注释，按缩进分块。\n" +
+		"可用函数：" + strings.Join(launcher.ScriptUsage, "；"))
+	help.Wrapping = fyne.TextWrapWord
+
+	split := container.NewVSplit(editor, output)
+	split.SetOffset(0.55)
+
+	content := container.NewBorder(
+		help,
+		container.NewGridWithColumns(2, runBtn, stopBtn),
+		nil, nil,
+		split,
+	)
+
+	d := dialog.NewCustom("🧪 脚本控制台", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(90), l.calcVH(70)))
+	d.Show()
+}
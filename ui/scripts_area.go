@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/ansi"
+	"gva-launcher/apperr"
+	"gva-launcher/npmscript"
+)
+
+// scriptsPanel 下方面板中的 npm 脚本标签页：列出 web/package.json 的全部脚本，运行并实时显示所选脚本的输出
+type scriptsPanel struct {
+	tab      *container.TabItem
+	list     *widget.List
+	output   *widget.List
+	title    *widget.Label
+	runBtn   *widget.Button
+	stopBtn  *widget.Button
+	runner   *npmscript.Runner // 按 web 目录创建（切换根目录后重新创建）
+	scripts  []npmscript.Script
+	selected string   // 当前显示输出的脚本
+	lines    []string // 所选脚本的输出（含颜色控制码）
+	seq      int      // 已读取到的输出序号（见 outputbuf.Buffer.Since）
+	cancel   context.CancelFunc
+}
+
+// createScriptsArea 创建 npm 脚本标签页
+func (l *GVALauncher) createScriptsArea() *container.TabItem {
+	p := &scriptsPanel{}
+	l.scripts = p
+
+	p.list = widget.NewList(
+		func() int { return len(p.scripts) },
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("")
+			name.TextStyle = fyne.TextStyle{Bold: true}
+			command := widget.NewLabel("")
+			command.TextStyle = fyne.TextStyle{Monospace: true}
+			command.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, name, nil, command)
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			if id >= len(p.scripts) {
+				return
+			}
+			s := p.scripts[id]
+			row := o.(*fyne.Container)
+			row.Objects[1].(*widget.Label).SetText(l.scriptState(s.Name).Icon() + " " + s.Name)
+			row.Objects[0].(*widget.Label).SetText(s.Command)
+		},
+	)
+	p.list.OnSelected = func(id widget.ListItemID) {
+		if id < len(p.scripts) {
+			l.selectScript(p.scripts[id].Name)
+		}
+	}
+
+	p.output = widget.NewList(
+		func() int { return len(p.lines) },
+		func() fyne.CanvasObject {
+			text := widget.NewRichText()
+			text.Truncation = fyne.TextTruncateEllipsis
+			return text
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			if id < len(p.lines) {
+				text := o.(*widget.RichText)
+				text.Segments = logSegments(p.lines[id])
+				text.Refresh()
+			}
+		},
+	)
+	p.title = widget.NewLabel("选择左侧的脚本")
+
+	p.runBtn = widget.NewButton("▶ 运行", func() {
+		l.runScript(p.selected)
+	})
+	p.stopBtn = widget.NewButton("⏹ 结束", func() {
+		if p.runner != nil {
+			p.runner.Stop(p.selected)
+		}
+	})
+	clearBtn := widget.NewButton("🧹 清空", func() {
+		if p.runner != nil && p.selected != "" {
+			p.runner.Output(p.selected).Clear()
+		}
+	})
+	copyBtn := widget.NewButton("📋 复制", func() {
+		lines := make([]string, len(p.lines))
+		for i, line := range p.lines {
+			lines[i] = ansi.Strip(line)
+		}
+		l.copyToClipboard(strings.Join(lines, "\n"), "脚本输出")
+	})
+	reloadBtn := widget.NewButton("🔄 重新读取", l.loadScripts)
+
+	header := container.NewBorder(nil, nil, p.title, container.NewHBox(p.runBtn, p.stopBtn, clearBtn, copyBtn))
+	left := container.NewBorder(nil, reloadBtn, nil, nil, p.list)
+	split := container.NewHSplit(left, container.NewBorder(header, nil, nil, nil, p.output))
+	split.Offset = 0.3
+	p.tab = container.NewTabItem("📦 npm 脚本", split)
+	l.renderScript()
+	return p.tab
+}
+
+// scriptState 脚本最近一次运行的状态
+func (l *GVALauncher) scriptState(name string) npmscript.State {
+	if p := l.scripts; p.runner != nil {
+		return p.runner.State(name)
+	}
+	return npmscript.Idle
+}
+
+// loadScripts 重新读取 web/package.json 的脚本列表（切换了根目录时结束上一个项目中运行的脚本）
+func (l *GVALauncher) loadScripts() {
+	p := l.scripts
+	if !l.project.IsSet() {
+		p.scripts = nil
+		p.list.Refresh()
+		l.selectScript("")
+		return
+	}
+	webDir := l.project.WebDir()
+	if p.runner == nil || p.runner.Dir != webDir {
+		if p.runner != nil {
+			p.runner.StopAll()
+		}
+		p.runner = npmscript.NewRunner(webDir, l.terminalEnv())
+		p.selected = ""
+	}
+	scripts, err := npmscript.List(webDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.showError(err, nil)
+	}
+	p.scripts = scripts
+	p.list.UnselectAll()
+	p.list.Refresh()
+
+	// 保持之前选择的脚本，没有时选择第一个
+	selected := -1
+	for i, s := range scripts {
+		if s.Name == p.selected {
+			selected = i
+		}
+	}
+	if selected < 0 && len(scripts) > 0 {
+		selected = 0
+	}
+	if selected < 0 {
+		l.selectScript("")
+		return
+	}
+	p.list.Select(selected)
+}
+
+// selectScript 切换显示输出的脚本，下方面板显示时开始跟踪它的输出
+func (l *GVALauncher) selectScript(name string) {
+	p := l.scripts
+	if name != p.selected {
+		p.selected, p.lines, p.seq = name, nil, 0
+		p.output.Refresh()
+	}
+	l.renderScript()
+	if l.bottom.box.Visible() {
+		l.followScript()
+	}
+}
+
+// followScripts 读取脚本列表并跟踪所选脚本的输出（下方面板显示时）
+func (l *GVALauncher) followScripts() {
+	p := l.scripts
+	if p.runner == nil || p.runner.Dir != l.project.WebDir() || len(p.scripts) == 0 {
+		l.loadScripts()
+		return
+	}
+	l.followScript()
+}
+
+// followScript 跟踪所选脚本的输出，有新输出时刷新输出和状态
+func (l *GVALauncher) followScript() {
+	p := l.scripts
+	l.stopFollowingScripts()
+	if p.runner == nil || p.selected == "" {
+		return
+	}
+	ctx, cancel := context.WithCancel(l.supervisor.Context())
+	p.cancel = cancel
+	l.watchOutput(ctx, "npm 脚本输出", p.runner.Output(p.selected), l.readScript)
+}
+
+// stopFollowingScripts 停止跟踪脚本输出（下方面板隐藏或切换脚本时，脚本本身继续运行）
+func (l *GVALauncher) stopFollowingScripts() {
+	if p := l.scripts; p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// readScript 读取所选脚本的新输出（清空后从头显示），并刷新运行状态
+func (l *GVALauncher) readScript() {
+	p := l.scripts
+	if p.runner == nil || p.selected == "" {
+		return
+	}
+	lines, seq := p.runner.Output(p.selected).Since(p.seq)
+	if seq < p.seq {
+		p.lines = nil
+	}
+	p.seq = seq
+	p.lines = append(p.lines, lines...)
+	if limit := l.config.EffectiveLogLines(); len(p.lines) > limit {
+		p.lines = append([]string(nil), p.lines[len(p.lines)-limit:]...)
+	}
+	p.output.Refresh()
+	p.output.ScrollToBottom()
+	p.list.Refresh()
+	l.renderScript()
+}
+
+// renderScript 刷新标题和运行、结束按钮；serve 由「启动」作为前端服务运行，这里不重复运行
+func (l *GVALauncher) renderScript() {
+	p := l.scripts
+	switch {
+	case p.selected == "":
+		p.title.SetText("选择左侧的脚本")
+	case p.selected == npmscript.ServeScript:
+		p.title.SetText("npm run serve（由「启动」作为前端服务运行，输出见「🎨 前端输出」）")
+	default:
+		p.title.SetText(l.scriptState(p.selected).Icon() + " npm run " + p.selected)
+	}
+	running := l.scriptState(p.selected) == npmscript.Running
+	if p.selected == "" || p.selected == npmscript.ServeScript || running {
+		p.runBtn.Disable()
+	} else {
+		p.runBtn.Enable()
+	}
+	if running {
+		p.stopBtn.Enable()
+	} else {
+		p.stopBtn.Disable()
+	}
+}
+
+// runScript 在后台运行脚本（面板退出时结束），输出显示在标签页中
+func (l *GVALauncher) runScript(name string) {
+	p := l.scripts
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if p.runner == nil || name == "" {
+		return
+	}
+	r := p.runner
+	// 与终端相同的环境变量；镜像源和代理可能已在面板中修改，每次运行使用最新的设置
+	r.Env = l.terminalEnv()
+	l.background("npm 脚本", func(ctx context.Context) func() {
+		err := r.Run(ctx, name)
+		return func() {
+			if errors.Is(err, npmscript.ErrRunning) {
+				dialog.ShowInformation("📦 npm 脚本", err.Error(), l.window)
+			}
+			p.list.Refresh()
+			l.renderScript()
+		}
+	})
+	p.runBtn.Disable()
+}
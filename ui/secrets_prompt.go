@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/secretscan"
+)
+
+// confirmSecrets 在任务中发现疑似密钥时切换到主线程询问是否继续发布，阻塞到用户选择或任务取消
+func (l *GVALauncher) confirmSecrets(ctx context.Context, findings []secretscan.Finding) bool {
+	answer := make(chan bool, 1)
+	l.post("确认发布内容", func() {
+		l.showSecretsConfirm(findings, func(ok bool) { answer <- ok })
+	})
+	select {
+	case ok := <-answer:
+		return ok
+	case <-ctx.Done():
+		return false
+	case <-l.supervisor.Context().Done():
+		return false
+	}
+}
+
+// showSecretsConfirm 列出发布内容中的疑似密钥（值已打码），由用户决定取消还是仍然继续
+func (l *GVALauncher) showSecretsConfirm(findings []secretscan.Finding, onDone func(ok bool)) {
+	list := widget.NewMultiLineEntry()
+	list.SetText(secretscan.Summary(findings, 50))
+	list.Wrapping = fyne.TextWrapOff
+	list.SetMinRowsVisible(min(len(findings), 10))
+	list.Disable()
+
+	info := widget.NewLabel(fmt.Sprintf("要发布的内容中有 %d 处疑似密钥（密码、AK/SK、JWT 密钥等）。前端代码、发布包和镜像可能被他人获取，"+
+		"请确认这些值可以公开，或先从发布内容中移除后再发布。", len(findings)))
+	info.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm("🔑 发现疑似密钥", "仍然继续", "取消", container.NewVBox(info, list), onDone, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
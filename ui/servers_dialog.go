@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/dsn"
+	"gva-launcher/sshkey"
+	"gva-launcher/wol"
+)
+
+// wakeTimeout 发送唤醒包后等待服务器可连接的最长时间
+const wakeTimeout = 3 * time.Minute
+
+// serverSummary 服务器的连接说明，例如 root@192.168.1.20:22
+func serverSummary(s config.Server) string {
+	text := s.SSHAddr()
+	if s.User != "" {
+		text = s.User + "@" + text
+	}
+	return text
+}
+
+// checkServer 检测服务器 SSH 端口是否可连接
+func (l *GVALauncher) checkServer(s config.Server) {
+	progress := dialog.NewCustomWithoutButtons("📶 检测连接", widget.NewLabel("正在连接 "+s.SSHAddr()+"..."), l.window)
+	progress.Show()
+
+	l.background("检测服务器连接", func(context.Context) func() {
+		elapsed, err := wol.Reachable(s.SSHAddr(), 5*time.Second)
+		return func() {
+			progress.Hide()
+			if err != nil {
+				l.showError(fmt.Errorf("无法连接 %s: %v", s.SSHAddr(), err), nil)
+				return
+			}
+			dialog.ShowInformation("📶 检测连接", fmt.Sprintf("%s 可连接（%d ms）", s.Name, elapsed.Milliseconds()), l.window)
+		}
+	})
+}
+
+// wakeServer 发送唤醒包并等待服务器 SSH 端口可连接
+func (l *GVALauncher) wakeServer(s config.Server) {
+	if err := wol.Send(s.MAC, s.Broadcast); err != nil {
+		l.showError(err, nil)
+		return
+	}
+
+	status := widget.NewLabel(fmt.Sprintf("已向 %s 发送唤醒包，正在等待 %s 可连接（最多 %d 分钟）...", s.MAC, s.SSHAddr(), int(wakeTimeout.Minutes())))
+	status.Wrapping = fyne.TextWrapWord
+	content := container.NewVBox(status, widget.NewProgressBarInfinite())
+
+	ctx, cancel := context.WithTimeout(l.supervisor.Context(), wakeTimeout)
+	progress := dialog.NewCustom("⏰ 网络唤醒", "取消等待", content, l.window)
+	progress.SetOnClosed(cancel)
+	progress.Resize(fyne.NewSize(l.calcVW(60), 0))
+	progress.Show()
+
+	l.background("等待服务器唤醒", func(context.Context) func() {
+		defer cancel()
+		start := time.Now()
+		err := wol.WaitReachable(ctx, s.SSHAddr(), 3*time.Second)
+		return func() {
+			progress.Hide()
+			if err != nil {
+				if time.Since(start) < wakeTimeout {
+					return // 用户取消等待
+				}
+				l.showError(fmt.Errorf("%v，请确认服务器已开启网络唤醒，且与本机在同一局域网（跨网段时填写定向广播地址）", err), nil)
+				return
+			}
+			dialog.ShowInformation("⏰ 网络唤醒", fmt.Sprintf("%s 已启动（用时 %d 秒）", s.Name, int(time.Since(start).Seconds())), l.window)
+		}
+	})
+}
+
+// showServerEditDialog 添加或编辑登记的服务器（index 为 -1 时添加）
+func (l *GVALauncher) showServerEditDialog(index int, done func()) {
+	var s config.Server
+	if index >= 0 {
+		s = l.config.Servers[index]
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("例如: 家里的 NAS")
+	nameEntry.SetText(s.Name)
+	hostEntry := widget.NewEntry()
+	hostEntry.SetPlaceHolder("例如: 192.168.1.20 或 ssh 配置中的主机别名")
+	hostEntry.SetText(s.Host)
+	portEntry := widget.NewEntry()
+	portEntry.SetPlaceHolder("22")
+	if s.Port > 0 {
+		portEntry.SetText(strconv.Itoa(s.Port))
+	}
+	userEntry := widget.NewEntry()
+	userEntry.SetPlaceHolder("留空则使用 ssh 配置中的用户")
+	userEntry.SetText(s.User)
+	// 地址框中粘贴 ssh://用户@主机:端口 时拆分到地址、端口和用户名
+	acceptDSN(hostEntry, func(info dsn.Info) {
+		fillSSHForm(info, hostEntry, portEntry, userEntry)
+	}, dsn.SSH)
+	dsnBtn := widget.NewButton("📋 复制 DSN", func() {
+		if host := strings.TrimSpace(hostEntry.Text); host != "" {
+			l.copyToClipboard(sshDSN(host, strings.TrimSpace(portEntry.Text), strings.TrimSpace(userEntry.Text)), "SSH 连接串")
+		}
+	})
+
+	keyOptions := []string{remoteDefaultKey}
+	for _, key := range sshkey.List(config.SSHKeyDir()) {
+		keyOptions = append(keyOptions, key.Name)
+	}
+	keySelect := widget.NewSelect(keyOptions, nil)
+	keySelect.SetSelected(remoteDefaultKey)
+	for _, option := range keyOptions {
+		if option == s.Key {
+			keySelect.SetSelected(option)
+		}
+	}
+
+	macEntry := widget.NewEntry()
+	macEntry.SetPlaceHolder("例如: aa:bb:cc:dd:ee:ff（留空不使用网络唤醒）")
+	macEntry.SetText(s.MAC)
+	broadcastEntry := widget.NewEntry()
+	broadcastEntry.SetPlaceHolder("留空使用 255.255.255.255，跨网段时填写例如 192.168.1.255")
+	broadcastEntry.SetText(s.Broadcast)
+
+	form := widget.NewForm(
+		widget.NewFormItem("名称", nameEntry),
+		widget.NewFormItem("地址", container.NewBorder(nil, nil, nil, dsnBtn, hostEntry)),
+		widget.NewFormItem("SSH 端口", portEntry),
+		widget.NewFormItem("用户名", userEntry),
+		widget.NewFormItem("SSH 密钥", keySelect),
+		widget.NewFormItem("MAC 地址", macEntry),
+		widget.NewFormItem("广播地址", broadcastEntry),
+	)
+
+	title := "➕ 添加服务器"
+	if index >= 0 {
+		title = "✏️ 编辑服务器"
+	}
+	d := dialog.NewCustomConfirm(title, "💾 保存", "❌ 取消", form, func(ok bool) {
+		if !ok {
+			return
+		}
+		edited := config.Server{
+			Name:      strings.TrimSpace(nameEntry.Text),
+			Host:      strings.TrimSpace(hostEntry.Text),
+			User:      strings.TrimSpace(userEntry.Text),
+			MAC:       strings.TrimSpace(macEntry.Text),
+			Broadcast: strings.TrimSpace(broadcastEntry.Text),
+		}
+		if keySelect.Selected != remoteDefaultKey {
+			edited.Key = keySelect.Selected
+		}
+		if text := strings.TrimSpace(portEntry.Text); text != "" {
+			port, err := strconv.Atoi(text)
+			if err != nil || port < 1 || port > 65535 {
+				l.showError(fmt.Errorf("SSH 端口无效: %s", text), nil)
+				return
+			}
+			edited.Port = port
+		}
+		if edited.Name == "" || edited.Host == "" {
+			l.showError(fmt.Errorf("请填写名称和地址"), nil)
+			return
+		}
+		for i, other := range l.config.Servers {
+			if i != index && other.Name == edited.Name {
+				l.showError(fmt.Errorf("已有名为 %s 的服务器", edited.Name), nil)
+				return
+			}
+		}
+		if edited.MAC != "" {
+			mac, err := wol.ParseMAC(edited.MAC)
+			if err != nil {
+				l.showError(err, nil)
+				return
+			}
+			edited.MAC = mac.String()
+		}
+
+		servers := append([]config.Server(nil), l.config.Servers...)
+		if index >= 0 {
+			servers[index] = edited
+		} else {
+			servers = append(servers, edited)
+		}
+		l.config.Servers = servers
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		done()
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
+
+// showServersDialog 显示登记的远程服务器：添加、编辑、检测连接、网络唤醒
+func (l *GVALauncher) showServersDialog() {
+	list := container.NewVBox()
+
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		if len(l.config.Servers) == 0 {
+			list.Add(widget.NewLabel("还没有登记服务器"))
+		}
+		for i, s := range l.config.Servers {
+			title := widget.NewLabelWithStyle(s.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			detail := serverSummary(s)
+			if s.MAC != "" {
+				detail += "　MAC: " + s.MAC
+			}
+
+			wakeBtn := widget.NewButton("⏰ 唤醒", func() { l.wakeServer(s) })
+			if s.MAC == "" {
+				wakeBtn.Disable()
+			}
+			buttons := container.NewHBox(
+				layout.NewSpacer(),
+				widget.NewButton("📶 检测", func() { l.checkServer(s) }),
+				wakeBtn,
+				widget.NewButton("✏️", func() { l.showServerEditDialog(i, refresh) }),
+				widget.NewButton("🗑️", func() {
+					dialog.ShowConfirm("删除服务器", fmt.Sprintf("确定删除服务器 %s？", s.Name), func(ok bool) {
+						if !ok {
+							return
+						}
+						servers := append([]config.Server(nil), l.config.Servers[:i]...)
+						l.config.Servers = append(servers, l.config.Servers[i+1:]...)
+						if err := l.saveConfig(); err != nil {
+							l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+							return
+						}
+						refresh()
+					}, l.window)
+				}),
+			)
+			list.Add(container.NewBorder(nil, nil, title, buttons))
+			list.Add(widget.NewLabel(detail))
+			list.Add(widget.NewSeparator())
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	addBtn := widget.NewButton("➕ 添加服务器", func() {
+		l.showServerEditDialog(-1, refresh)
+	})
+
+	help := widget.NewLabel("登记常用的远程服务器，远程日志可直接选择。填写 MAC 地址后可发送网络唤醒（Wake-on-LAN）包，" +
+		"并等待服务器的 SSH 端口可连接；服务器需要在 BIOS 和系统中开启网络唤醒，且与本机在同一局域网（跨网段时填写目标网段的定向广播地址）。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(30)))
+
+	content := container.NewVBox(help, scroll, addBtn)
+	dialog.ShowCustom("🖥️ 远程服务器", "关闭", content, l.window)
+}
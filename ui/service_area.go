@@ -0,0 +1,495 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/events"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+	"gva-launcher/supervisor"
+)
+
+// createServiceArea 创建服务控制区域
+func (l *GVALauncher) createServiceArea() *fyne.Container {
+	// 5. 标题装箱 + 上下边界线
+	autoStartCheck := widget.NewCheck("开机自动启动服务", func(on bool) {
+		if on == l.config.AutoStart {
+			return
+		}
+		l.config.AutoStart = on
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+	})
+	autoStartCheck.SetChecked(l.config.AutoStart)
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("🚀 服务控制", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			layout.NewSpacer(),
+			autoStartCheck,
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	// 6. 启动关闭按钮装箱（45vw + 3个Spacer）
+	l.startButton = widget.NewButton("🚀 启动 GVA", func() {
+		l.startGVA()
+	})
+	l.stopButton = widget.NewButton("🔴 关闭 GVA", func() {
+		l.stopGVA()
+	})
+	l.stopButton.Disable()
+
+	// 使用 GridWithColumns 让按钮平均分配宽度
+	buttonBox := container.NewGridWithColumns(2,
+		l.startButton,
+		l.stopButton,
+	)
+
+	// 7. 状态信息装箱（5个盒子）
+	// 运行状态标题
+	allocPortsBtn := widget.NewButton("　🎲 自动分配端口　", func() {
+		l.showAllocPortsDialog()
+	})
+	diagnoseBtn := widget.NewButton("　🧠 日志诊断　", func() {
+		l.diagnoseServiceLogs()
+	})
+	logsBtn := widget.NewButton("　📜 日志　", func() {
+		l.toggleBottomPanel(l.logs.tab)
+	})
+	webLogsBtn := widget.NewButton("　🎨 前端输出　", func() {
+		l.toggleBottomPanel(l.webLogs.tab)
+	})
+	autoRestartCheck := widget.NewCheck("意外退出后自动重启", func(on bool) {
+		if on == l.config.AutoRestart {
+			return
+		}
+		l.config.AutoRestart = on
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+	})
+	autoRestartCheck.SetChecked(l.config.AutoRestart)
+	detachedCheck := widget.NewCheck("退出面板后保持运行", func(on bool) {
+		if on == l.config.Detached {
+			return
+		}
+		l.config.Detached = on
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		if l.services.Backend.IsRunning() || l.services.Frontend.IsRunning() {
+			dialog.ShowInformation("退出面板后保持运行", "之后启动的服务生效，正在运行的服务需要重启", l.window)
+		}
+	})
+	detachedCheck.SetChecked(l.config.Detached)
+	compiledRunCheck := widget.NewCheck("后端编译后运行", func(on bool) {
+		if on == l.config.CompiledRun {
+			return
+		}
+		l.config.CompiledRun = on
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		if l.services.Backend.IsRunning() {
+			dialog.ShowInformation("后端编译后运行", "重启后端后生效", l.window)
+		}
+	})
+	compiledRunCheck.SetChecked(l.config.CompiledRun)
+	var productionCheck *widget.Check
+	productionCheck = widget.NewCheck("生产模式", func(on bool) {
+		l.toggleProductionMode(productionCheck, on)
+	})
+	productionCheck.SetChecked(l.config.ProductionMode)
+	statusTitleBox := container.NewHBox(
+		widget.NewLabel("运行状态:"),
+		layout.NewSpacer(),
+		productionCheck,
+		compiledRunCheck,
+		autoRestartCheck,
+		detachedCheck,
+		logsBtn,
+		webLogsBtn,
+		diagnoseBtn,
+		allocPortsBtn,
+	)
+
+	// 后端服务状态
+	l.backendStatusLabel = widget.NewLabel("　• 后端服务: " + l.statusBadge(statusStopped, "已停止") + " 端口: 8888")
+	backendPortBtn := widget.NewButton("　⚙️ 修改　", func() {
+		l.showPortDialog(true)
+	})
+	backendStatusBox := container.NewHBox(
+		l.backendStatusLabel,
+		layout.NewSpacer(),
+		l.createServiceControls(launcher.ServiceBackend, &l.backendControls),
+		backendPortBtn,
+	)
+
+	// 前端服务状态
+	l.frontendStatusLabel = widget.NewLabel("　• 前端服务: " + l.statusBadge(statusStopped, "已停止") + " 端口: 8080")
+	frontendPortBtn := widget.NewButton("　⚙️ 修改　", func() {
+		l.showPortDialog(false)
+	})
+	frontendStatusBox := container.NewHBox(
+		l.frontendStatusLabel,
+		layout.NewSpacer(),
+		l.createServiceControls(launcher.ServiceFrontend, &l.frontendControls),
+		frontendPortBtn,
+	)
+
+	// 访问地址标题
+	urlTitleBox := container.NewHBox(
+		widget.NewLabel("访问地址:"),
+	)
+
+	// 前端地址
+	l.urlLabel = widget.NewLabel("　• 前端: 未配置")
+	copyBtn := widget.NewButton("　📋 复制链接　", func() {
+		if l.frontendPort > 0 {
+			l.copyToClipboard(l.getFrontendURL(), "链接")
+		} else {
+			dialog.ShowInformation("提示", "端口未配置，无法复制链接", l.window)
+		}
+	})
+	copyBtnContainer := container.NewMax(copyBtn)
+	copyBtnContainer.Resize(fyne.NewSize(l.calcVW(15), 0))
+
+	urlBox := container.NewHBox(
+		l.urlLabel,
+		layout.NewSpacer(),
+		copyBtnContainer,
+	)
+
+	// 后端地址
+	l.backendURLLabel = widget.NewLabel("　• 后端: 未配置")
+	backendCopyBtn := widget.NewButton("　📋 复制链接　", func() {
+		if l.backendPort > 0 {
+			l.copyToClipboard(l.getBackendURL(), "后端地址")
+		} else {
+			dialog.ShowInformation("提示", "端口未配置，无法复制链接", l.window)
+		}
+	})
+
+	backendURLBox := container.NewHBox(
+		l.backendURLLabel,
+		layout.NewSpacer(),
+		backendCopyBtn,
+	)
+
+	// 冒烟测试结果
+	l.smokeLabel = widget.NewLabel("　• 冒烟测试: 未执行")
+	smokeBtn := widget.NewButton("　🧪 详情　", func() {
+		l.showSmokeDialog()
+	})
+	smokeBox := container.NewHBox(
+		l.smokeLabel,
+		layout.NewSpacer(),
+		smokeBtn,
+	)
+
+	// 8. 运行状态父容器（用GridWithRows均匀分配7行）
+	statusParentBox := container.NewGridWithRows(7,
+		statusTitleBox,    // 第1行：运行状态标题
+		backendStatusBox,  // 第2行：后端服务状态
+		frontendStatusBox, // 第3行：前端服务状态
+		urlTitleBox,       // 第4行：访问地址标题
+		urlBox,            // 第5行：前端地址
+		backendURLBox,     // 第6行：后端地址
+		smokeBox,          // 第7行：冒烟测试结果
+	)
+
+	return container.NewVBox(
+		titleBox,
+		buttonBox,
+		statusParentBox,
+		l.createInstancesArea(),
+	)
+}
+
+// startGVA 启动 GVA 服务
+func (l *GVALauncher) startGVA() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.ensureProjectOwner() || !l.requireToolchain() {
+		return
+	}
+	if conflicts := l.services.PortConflicts(); len(conflicts) > 0 {
+		l.showPortConflict(conflicts[0])
+		return
+	}
+
+	l.startButton.Disable()
+	l.stopButton.Enable()
+
+	// 在后台启动（后端就绪后再启动前端，避免阻塞 UI），启动后执行冒烟测试
+	l.background("启动服务", func(context.Context) func() {
+		l.services.Start()
+		if l.config.SmokeTest.Disabled {
+			return nil
+		}
+		return l.runSmokeTest
+	})
+}
+
+// autoStartServices 开机自动启动服务：打开面板时的依赖检测完成后调用，依赖齐全（depsOK）则启动前后端服务；
+// 服务已在后台运行（重新连接）或项目正被其他用户使用时不启动
+func (l *GVALauncher) autoStartServices(depsOK bool) {
+	if !l.config.AutoStart || !l.project.IsValid() || l.projectHolder != nil || l.services.IsRunning() {
+		return
+	}
+	if !depsOK {
+		dialog.ShowInformation("开机自动启动服务", "依赖检测未通过，没有自动启动服务。请先安装依赖或按提示安装缺少的工具", l.window)
+		return
+	}
+	l.startGVA()
+}
+
+// serviceControls 单个服务的启动、停止、重启按钮
+type serviceControls struct {
+	start, stop, restart *widget.Button
+	busy                 bool // 正在启动或重启（服务标记为运行前不允许重复操作）
+}
+
+// render 按服务是否运行更新按钮状态
+func (c *serviceControls) render(running bool) {
+	if c.busy || running {
+		c.start.Disable()
+	} else {
+		c.start.Enable()
+	}
+	if !c.busy && running {
+		c.stop.Enable()
+		c.restart.Enable()
+	} else {
+		c.stop.Disable()
+		c.restart.Disable()
+	}
+}
+
+// createServiceControls 创建单个服务的启动、停止、重启按钮（修改后端代码后可以只重启后端，前端开发服务器保持运行）
+func (l *GVALauncher) createServiceControls(service string, c *serviceControls) *fyne.Container {
+	c.start = widget.NewButton("▶ 启动", func() { l.startService(service, c) })
+	c.stop = widget.NewButton("⏹ 停止", func() { l.stopService(service) })
+	c.restart = widget.NewButton("🔄 重启", func() { l.restartService(service, c) })
+	c.render(false)
+	return container.NewHBox(c.start, c.stop, c.restart)
+}
+
+// startService 单独启动后端或前端
+func (l *GVALauncher) startService(service string, c *serviceControls) {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !l.ensureProjectOwner() || !l.requireServiceTool(service) {
+		return
+	}
+	l.runServiceAction("启动"+launcher.ServiceLabel(service), c, func() error {
+		return l.services.StartService(service)
+	})
+}
+
+// restartService 单独重启后端或前端，另一个服务不受影响
+func (l *GVALauncher) restartService(service string, c *serviceControls) {
+	if !l.requireServiceTool(service) {
+		return
+	}
+	l.runServiceAction("重启"+launcher.ServiceLabel(service), c, func() error {
+		return l.services.RestartService(service)
+	})
+}
+
+// runServiceAction 在后台启动或重启单个服务，期间禁用该服务的按钮，并启动状态监控
+func (l *GVALauncher) runServiceAction(name string, c *serviceControls, action func() error) {
+	c.busy = true
+	c.render(false)
+	l.startButton.Disable()
+	l.stopButton.Enable()
+
+	l.background(name, func(context.Context) func() {
+		err := action()
+		return func() {
+			c.busy = false
+			if err != nil {
+				l.showError(err, nil)
+			}
+			l.checkServiceStatus()
+		}
+	})
+}
+
+// stopService 单独停止后端或前端，另一个服务继续运行
+func (l *GVALauncher) stopService(service string) {
+	l.services.StopService(service)
+	l.background("停止"+launcher.ServiceLabel(service), func(ctx context.Context) func() {
+		if !supervisor.Sleep(ctx, l.config.EffectiveTimeouts().StopWait()) {
+			return nil
+		}
+		return l.checkServiceStatus
+	})
+}
+
+// stopGVA 停止 GVA 服务
+func (l *GVALauncher) stopGVA() {
+	// 通过端口杀死进程（更可靠），并清理进程信息
+	l.services.StopPorts(l.backendPort, l.frontendPort)
+
+	l.enableStartButton()
+	l.stopButton.Disable()
+
+	// 等待一下再更新状态
+	time.Sleep(l.config.EffectiveTimeouts().StopWait())
+	l.updateServiceStatus()
+}
+
+// onServicesIdle 服务长时间没有访问、已自动停止：发送系统通知，并显示一键恢复
+func (l *GVALauncher) onServicesIdle() {
+	l.enableStartButton()
+	l.stopButton.Disable()
+
+	message := fmt.Sprintf("已连续 %d 分钟没有访问，前后端服务已自动停止以节省电量和内存", l.config.IdleMinutes)
+	fyne.CurrentApp().SendNotification(fyne.NewNotification("GVA 服务已自动停止", message))
+	var d dialog.Dialog
+	resumeBtn := widget.NewButton("▶️ 恢复运行", func() {
+		d.Hide()
+		l.startGVA()
+	})
+	label := widget.NewLabel(message + "。可在「⏱️ 等待时间」中修改或关闭空闲自动停止。")
+	label.Wrapping = fyne.TextWrapWord
+	d = dialog.NewCustom("💤 服务已自动停止", "关闭", container.NewVBox(label, resumeBtn), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(40), 0))
+	d.Show()
+}
+
+// updateServiceStatus 更新服务状态显示（发布当前状态，由事件订阅统一刷新界面）
+func (l *GVALauncher) updateServiceStatus() {
+	l.services.Publish()
+}
+
+// renderServiceStatus 按服务状态刷新状态文字和访问地址（在主线程中调用）
+func (l *GVALauncher) renderServiceStatus(state events.ServiceState) {
+	backendStatus := l.statusBadge(statusStopped, "已停止")
+	frontendStatus := l.statusBadge(statusStopped, "已停止")
+
+	if state.BackendRunning {
+		backendStatus = l.statusBadge(statusRunning, runningText(state.BackendStarted))
+	} else if state.BackendFailure != "" {
+		backendStatus = l.statusBadge(statusFailed, "启动失败") + "（" + state.BackendFailure + "）"
+	}
+	if state.FrontendRunning {
+		frontendStatus = l.statusBadge(statusRunning, runningText(state.FrontendStarted))
+	} else if state.FrontendFailure != "" {
+		frontendStatus = l.statusBadge(statusFailed, "启动失败") + "（" + state.FrontendFailure + "）"
+	}
+
+	// 显示端口信息
+	backendPortStr := "未配置"
+	if state.BackendPort > 0 {
+		backendPortStr = fmt.Sprintf("%d", state.BackendPort)
+	}
+
+	frontendPortStr := "未配置"
+	if state.FrontendPort > 0 {
+		frontendPortStr = fmt.Sprintf("%d", state.FrontendPort)
+	}
+
+	l.backendStatusLabel.SetText(fmt.Sprintf("　• 后端服务: %s 端口: %s%s%s", backendStatus, backendPortStr, restartsText(state.BackendRestarts), priorityText(state.BackendPriority)))
+	l.frontendStatusLabel.SetText(fmt.Sprintf("　• 前端服务: %s 端口: %s%s%s", frontendStatus, frontendPortStr, restartsText(state.FrontendRestarts), priorityText(state.FrontendPriority)))
+	l.backendControls.render(state.BackendRunning)
+	l.frontendControls.render(state.FrontendRunning)
+
+	// 更新访问地址 - 使用本机IP地址
+	if state.FrontendPort > 0 && l.config.GVARootPath != "" {
+		l.urlLabel.SetText("　• 前端: " + l.getFrontendURL())
+	} else {
+		l.urlLabel.SetText("　• 前端: 未配置")
+	}
+	if state.BackendPort > 0 && l.config.GVARootPath != "" {
+		l.backendURLLabel.SetText("　• 后端: " + l.getBackendURL())
+	} else {
+		l.backendURLLabel.SetText("　• 后端: 未配置")
+	}
+}
+
+// runningText 运行中的状态文字，知道启动时间时附带运行时长，例如「运行中 1h 23m」
+func runningText(started time.Time) string {
+	if started.IsZero() {
+		return "运行中"
+	}
+	return "运行中 " + services.FormatUptime(time.Since(started))
+}
+
+// renderUptime 服务运行期间每 30 秒刷新一次状态栏中的运行时长（收到 Tick 事件时调用；状态没有变化时不会发布 ServiceChanged 事件）
+func (l *GVALauncher) renderUptime(now time.Time) {
+	if now.Sub(l.uptimeRendered) < 30*time.Second {
+		return
+	}
+	l.uptimeRendered = now
+	if l.services.IsRunning() {
+		l.renderServiceStatus(l.services.State())
+	}
+}
+
+// restartsText 状态栏中自动重启次数的说明（没有自动重启过时为空）
+func restartsText(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" · 已自动重启 %d 次", n)
+}
+
+// priorityText 状态栏中进程优先级的说明（正常优先级时为空）
+func priorityText(priority string) string {
+	if priority == "" {
+		return ""
+	}
+	return " · 优先级: " + sysutil.PriorityLabel(sysutil.Priority(priority))
+}
+
+// checkServiceStatus 检查服务状态
+func (l *GVALauncher) checkServiceStatus() {
+	// 从GVA配置文件读取端口
+	l.updatePortsFromGVAConfig()
+
+	// 检查前后端端口
+	l.services.Refresh(l.backendPort, l.frontendPort)
+
+	l.updateServiceStatus()
+
+	if l.services.IsRunning() {
+		l.startButton.Disable()
+		l.stopButton.Enable()
+	} else {
+		l.enableStartButton()
+		l.stopButton.Disable()
+	}
+}
+
+// updatePortsFromGVAConfig 从GVA配置文件更新端口
+func (l *GVALauncher) updatePortsFromGVAConfig() {
+	// 未设置目录或读取失败（选错目录）时端口为 0，显示未配置
+	l.backendPort, l.frontendPort = l.project.Ports()
+	l.httpsEnabled = l.project.HTTPSEnabled()
+
+	// 登记到项目端口表，与其他项目冲突时提示
+	l.registerProjectPorts()
+
+	// 更新显示
+	l.updateServiceStatus()
+}
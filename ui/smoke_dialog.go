@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/smoketest"
+)
+
+// runSmokeTest 在后台执行冒烟测试并刷新服务区域的结果（服务启动后自动执行）
+func (l *GVALauncher) runSmokeTest() {
+	l.smokeLabel.SetText("　• 冒烟测试: ⏳ 检查中...")
+	cfg := l.config.SmokeTest
+	l.background("冒烟测试", func(ctx context.Context) func() {
+		results := l.services.SmokeTest(ctx, cfg)
+		if ctx.Err() != nil {
+			return nil
+		}
+		return func() {
+			l.smokeResults = results
+			l.renderSmokeResults()
+		}
+	})
+}
+
+// renderSmokeResults 在服务区域显示最近一次冒烟测试的摘要
+func (l *GVALauncher) renderSmokeResults() {
+	switch {
+	case len(l.smokeResults) == 0:
+		l.smokeLabel.SetText("　• 冒烟测试: 没有需要执行的检查")
+	case smoketest.Passed(l.smokeResults):
+		l.smokeLabel.SetText("　• 冒烟测试: " + smoketest.Summary(l.smokeResults))
+	default:
+		failed := 0
+		for _, r := range l.smokeResults {
+			if !r.OK {
+				failed++
+			}
+		}
+		l.smokeLabel.SetText(fmt.Sprintf("　• 冒烟测试: ❌ %d 项未通过，点击详情查看", failed))
+	}
+}
+
+// parseSmokeChecks 解析自定义检查，每行一项：名称 | 地址 | 状态码（名称和状态码可省略）
+func parseSmokeChecks(text string) ([]config.SmokeCheck, error) {
+	var checks []config.SmokeCheck
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, "|")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		var c config.SmokeCheck
+		switch len(parts) {
+		case 1:
+			c.URL = parts[0]
+		case 2:
+			c.Name, c.URL = parts[0], parts[1]
+		case 3:
+			c.Name, c.URL = parts[0], parts[1]
+			status, err := strconv.Atoi(parts[2])
+			if err != nil || status < 100 || status > 599 {
+				return nil, fmt.Errorf("状态码无效: %s", line)
+			}
+			c.Status = status
+		default:
+			return nil, fmt.Errorf("格式应为 名称 | 地址 | 状态码: %s", line)
+		}
+		if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") && !strings.HasPrefix(c.URL, "{") {
+			return nil, fmt.Errorf("地址应以 http://、https://、{backend} 或 {frontend} 开头: %s", line)
+		}
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+// formatSmokeChecks 自定义检查的文字形式（与 parseSmokeChecks 对应）
+func formatSmokeChecks(checks []config.SmokeCheck) string {
+	lines := make([]string, len(checks))
+	for i, c := range checks {
+		lines[i] = c.Name + " | " + c.URL
+		if c.Status != 0 {
+			lines[i] += " | " + strconv.Itoa(c.Status)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showSmokeDialog 显示最近一次冒烟测试的结果，可重新检查并设置检查项
+func (l *GVALauncher) showSmokeDialog() {
+	results := container.NewVBox()
+	for _, r := range l.smokeResults {
+		text := fmt.Sprintf("✅ %s（%s）", r.Name, r.Elapsed.Round(100*time.Millisecond))
+		if !r.OK {
+			text = fmt.Sprintf("❌ %s: %s", r.Name, r.Detail)
+		}
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapWord
+		results.Add(label)
+	}
+	if len(l.smokeResults) == 0 {
+		results.Add(widget.NewLabel("还没有执行过冒烟测试"))
+	}
+
+	var d dialog.Dialog
+	rerunBtn := widget.NewButton("🔄 立即检查", func() {
+		if !l.project.IsSet() {
+			l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+			return
+		}
+		d.Hide()
+		l.runSmokeTest()
+	})
+
+	cfg := l.config.SmokeTest
+	autoCheck := widget.NewCheck("启动后自动执行", nil)
+	autoCheck.SetChecked(!cfg.Disabled)
+	builtinChecks := make([]*widget.Check, len(smoketest.Builtin))
+	builtinBox := container.NewHBox()
+	for i, b := range smoketest.Builtin {
+		builtinChecks[i] = widget.NewCheck(b.Name, nil)
+		builtinChecks[i].SetChecked(!slices.Contains(cfg.Skip, b.ID))
+		builtinBox.Add(builtinChecks[i])
+	}
+	customEntry := widget.NewMultiLineEntry()
+	customEntry.SetPlaceHolder("每行一项：名称 | 地址 | 状态码，例如\n健康检查 | {backend}/health | 200")
+	customEntry.SetText(formatSmokeChecks(cfg.Custom))
+	customEntry.SetMinRowsVisible(3)
+
+	help := widget.NewLabel("服务启动后依次确认：登录接口返回 200、验证码接口正常返回、前端返回首页 HTML、前端 WebSocket（Vite 热更新）可以握手。" +
+		"每项在等待时长内反复尝试（可在「⏱️ 等待时间」中调整），比只检测端口是否打开更能说明服务真正可用。" +
+		"{backend} 为后端 API 根地址（含 router-prefix），{frontend} 为前端地址。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		results,
+		container.NewHBox(rerunBtn),
+		widget.NewSeparator(),
+		autoCheck,
+		builtinBox,
+		widget.NewLabel("自定义检查:"),
+		customEntry,
+	)
+
+	d = dialog.NewCustomConfirm("🧪 冒烟测试", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		custom, err := parseSmokeChecks(customEntry.Text)
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		edited := config.SmokeTest{Disabled: !autoCheck.Checked, Custom: custom}
+		for i, b := range smoketest.Builtin {
+			if !builtinChecks[i].Checked {
+				edited.Skip = append(edited.Skip, b.ID)
+			}
+		}
+		l.config.SmokeTest = edited
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
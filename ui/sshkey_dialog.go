@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/sshkey"
+)
+
+// unlockSSHKey 解锁面板管理的 SSH 密钥，为这次 ssh 操作写入临时私钥文件后回调其路径。
+// 本次运行中已解锁的密钥（只保存在内存中）直接使用，否则先询问口令；
+// 调用方在 ssh 完成认证或操作结束后调用 release 删除临时文件
+func (l *GVALauncher) unlockSSHKey(name string, done func(identity string, release func())) {
+	use := func(private []byte) {
+		path, release, err := sshkey.TempIdentity("", name, private)
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		done(path, release)
+	}
+	if private, ok := l.sshKeys[name]; ok {
+		use(private)
+		return
+	}
+
+	passEntry := widget.NewPasswordEntry()
+	passEntry.SetPlaceHolder("生成密钥时设置的口令")
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("请输入 SSH 密钥 %s 的口令，本次运行期间不再询问", name)),
+		passEntry,
+	)
+	dialog.ShowCustomConfirm("🔑 解锁 SSH 密钥", "🔓 解锁", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		private, err := sshkey.Unlock(config.SSHKeyDir(), name, passEntry.Text)
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if l.sshKeys == nil {
+			l.sshKeys = make(map[string][]byte)
+		}
+		l.sshKeys[name] = private
+		use(private)
+	}, l.window)
+}
+
+// forgetSSHKey 忘记已解锁的密钥（删除密钥后需要重新输入口令）
+func (l *GVALauncher) forgetSSHKey(name string) {
+	delete(l.sshKeys, name)
+}
+
+// showSSHKeysDialog 显示 SSH 密钥管理：生成密钥对、复制公钥、删除
+func (l *GVALauncher) showSSHKeysDialog() {
+	dir := config.SSHKeyDir()
+	list := container.NewVBox()
+
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		keys := sshkey.List(dir)
+		if len(keys) == 0 {
+			list.Add(widget.NewLabel("还没有生成密钥"))
+		}
+		for _, key := range keys {
+			title := widget.NewLabelWithStyle(key.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			detail := widget.NewLabel(fmt.Sprintf("指纹: %s\n生成时间: %s", key.Fingerprint, key.Created.Format("2006-01-02 15:04")))
+			public := widget.NewLabelWithStyle(key.PublicKey, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+			public.Wrapping = fyne.TextWrapBreak
+
+			copyBtn := widget.NewButton("📋 复制公钥", func() {
+				l.copyToClipboard(key.PublicKey, "公钥")
+			})
+			deleteBtn := widget.NewButton("🗑️ 删除", func() {
+				dialog.ShowConfirm("删除密钥", fmt.Sprintf("确定删除密钥 %s？\n删除后使用该密钥的服务器将无法连接，需要重新生成并加入公钥。", key.Name), func(ok bool) {
+					if !ok {
+						return
+					}
+					if err := sshkey.Remove(dir, key.Name); err != nil {
+						l.showError(err, nil)
+						return
+					}
+					l.forgetSSHKey(key.Name)
+					refresh()
+				}, l.window)
+			})
+			list.Add(container.NewBorder(nil, nil, title, container.NewHBox(layout.NewSpacer(), copyBtn, deleteBtn)))
+			list.Add(detail)
+			list.Add(public)
+			list.Add(widget.NewSeparator())
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	// 生成
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("例如: prod-server")
+	commentEntry := widget.NewEntry()
+	commentEntry.SetPlaceHolder("公钥末尾的注释，便于在服务器上识别")
+	if host, err := os.Hostname(); err == nil {
+		commentEntry.SetText("gvapanel@" + host)
+	}
+	passEntry := widget.NewPasswordEntry()
+	passEntry.SetPlaceHolder(fmt.Sprintf("用于加密私钥，至少 %d 个字符", sshkey.MinPassphrase))
+	confirmEntry := widget.NewPasswordEntry()
+	confirmEntry.SetPlaceHolder("再次输入口令")
+
+	generateBtn := widget.NewButton("🔑 生成密钥", func() {
+		if passEntry.Text != confirmEntry.Text {
+			l.showError(fmt.Errorf("两次输入的口令不一致"), nil)
+			return
+		}
+		key, err := sshkey.Generate(dir, strings.TrimSpace(nameEntry.Text), commentEntry.Text, passEntry.Text)
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		nameEntry.SetText("")
+		passEntry.SetText("")
+		confirmEntry.SetText("")
+		refresh()
+		l.copyToClipboard(key.PublicKey, "公钥")
+	})
+
+	generateForm := widget.NewForm(
+		widget.NewFormItem("名称", nameEntry),
+		widget.NewFormItem("注释", commentEntry),
+		widget.NewFormItem("口令", passEntry),
+		widget.NewFormItem("确认口令", confirmEntry),
+	)
+
+	help := widget.NewLabel("生成 ed25519 密钥对供远程日志等 SSH 功能使用。把公钥加入服务器的 ~/.ssh/authorized_keys 后即可免密码登录。" +
+		"私钥用口令加密保存在面板数据目录中，使用时输入一次口令，本次运行期间有效（解密后的私钥只保存在内存中，ssh 连接时写入临时文件，认证完成后立即删除）；口令无法找回，忘记后只能重新生成。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(30)))
+
+	content := container.NewVBox(
+		help,
+		scroll,
+		widget.NewLabelWithStyle("生成密钥", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		generateForm,
+		generateBtn,
+	)
+	dialog.ShowCustom("🔑 SSH 密钥", "关闭", content, l.window)
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+	"gva-launcher/jobs"
+	"gva-launcher/sysservice"
+)
+
+// showSysServiceDialog 安装为系统服务：把构建好的后端注册为 systemd 单元 / launchd 守护进程 / Windows 服务，
+// 开机运行，不依赖面板窗口；也可以卸载已注册的服务
+func (l *GVALauncher) showSysServiceDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if !sysservice.Supported(runtime.GOOS) {
+		l.showError(apperr.Errorf(apperr.SysServiceFailed, "当前系统（%s）不支持注册系统服务", runtime.GOOS), nil)
+		return
+	}
+	cfg := l.config.SysService
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder(sysservice.DefaultName(l.project.Root))
+	nameEntry.SetText(cfg.Name)
+	userEntry := widget.NewEntry()
+	if u, err := user.Current(); err == nil {
+		userEntry.SetPlaceHolder(u.Username + "（当前用户）")
+	}
+	userEntry.SetText(cfg.User)
+	buildCheck := widget.NewCheck("注册前重新构建后端（go build 到 server/"+filepath.Base(l.builds.BinaryPath())+"）", nil)
+	buildCheck.SetChecked(!sysutil.FileExists(l.builds.BinaryPath()))
+
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+	// spec 读取表单，保存设置
+	spec := func() (sysservice.Spec, error) {
+		c := config.SysService{Name: strings.TrimSpace(nameEntry.Text), User: strings.TrimSpace(userEntry.Text)}
+		l.config.SysService = c
+		if err := l.saveConfig(); err != nil {
+			return sysservice.Spec{}, fmt.Errorf("保存配置失败: %w", err)
+		}
+		s := sysservice.Spec{
+			Name:    c.Name,
+			Binary:  l.builds.BinaryPath(),
+			WorkDir: l.project.ServerDir(),
+			User:    c.User,
+		}
+		if s.Name == "" {
+			s.Name = nameEntry.PlaceHolder
+		}
+		if s.User == "" && runtime.GOOS != "windows" {
+			if u, err := user.Current(); err == nil {
+				s.User = u.Username
+			}
+		}
+		return s, s.Validate()
+	}
+	refresh := func() {
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" {
+			name = nameEntry.PlaceHolder
+		}
+		kind := sysservice.Kind(runtime.GOOS, sysservice.NSSMPath() != "")
+		l.background("查询系统服务", func(context.Context) func() {
+			installed := sysservice.Installed(name)
+			return func() {
+				text := fmt.Sprintf("%s %s: ", kind, name)
+				if installed {
+					text += l.statusBadge(statusOK, "已注册")
+				} else {
+					text += l.statusBadge(statusIdle, "未注册")
+				}
+				if path := sysservice.UnitPath(runtime.GOOS, name); path != "" {
+					text += "\n配置文件: " + path
+				}
+				status.SetText(text)
+			}
+		})
+	}
+	nameEntry.OnChanged = func(string) { refresh() }
+	refresh()
+
+	installBtn := widget.NewButton("🖥️ 注册并启动", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		s, err := spec()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		build := buildCheck.Checked
+		job := l.jobs.Submit("注册系统服务", func(ctx context.Context, j *jobs.Job) error {
+			if build {
+				if err := l.builds.BuildBackend(j); err != nil {
+					return err
+				}
+			}
+			j.Logf("注册 %s（系统会请求管理员授权）", s.Name)
+			return sysservice.Install(s)
+		})
+		l.waitJob(job, "🖥️ 安装为系统服务", "正在注册 "+s.Name+"...", func(err error) {
+			refresh()
+			switch {
+			case errors.Is(err, jobs.ErrCanceled):
+			case err != nil:
+				l.showError(err, nil)
+			default:
+				buildCheck.SetChecked(false)
+				dialog.ShowInformation("已注册", s.Name+" 已注册并启动，开机时自动运行。\n"+sysServiceHint(s), l.window)
+			}
+		})
+	})
+	uninstallBtn := widget.NewButton("🗑️ 停止并卸载", func() {
+		s, err := spec()
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		dialog.ShowConfirm("卸载系统服务", "停止并删除服务 "+s.Name+"？（不会删除后端可执行文件）", func(ok bool) {
+			if !ok {
+				return
+			}
+			l.background("卸载系统服务", func(context.Context) func() {
+				err := sysservice.Uninstall(s.Name)
+				return func() {
+					refresh()
+					if err != nil {
+						l.showError(err, nil)
+					}
+				}
+			})
+		}, l.window)
+	})
+
+	help := widget.NewLabel("把 server/ 中构建好的后端注册为系统服务，开机自动运行、异常退出后重启，不需要打开面板。" +
+		"Linux 使用 systemd，macOS 使用 launchd，Windows 安装了 NSSM 时注册为 Windows 服务，否则注册为开机运行的计划任务。" +
+		"注册和卸载需要管理员授权。开启「生产模式」并构建过前端时，后端同时提供页面。" +
+		"注册后请关闭面板中运行的后端，避免端口冲突。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("服务名", nameEntry),
+		widget.NewFormItem("运行用户", userEntry),
+	)
+	if runtime.GOOS == "windows" {
+		// Windows 服务和计划任务以 SYSTEM 身份运行
+		userEntry.Disable()
+		userEntry.SetPlaceHolder("SYSTEM")
+	}
+	content := container.NewVBox(help, form, buildCheck, status, container.NewGridWithColumns(2, installBtn, uninstallBtn))
+	d := dialog.NewCustom("🖥️ 安装为系统服务", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
+
+// sysServiceHint 注册后查看服务状态和日志的方法
+func sysServiceHint(s sysservice.Spec) string {
+	switch runtime.GOOS {
+	case "linux":
+		return fmt.Sprintf("查看状态: systemctl status %s\n查看日志: journalctl -u %s -f", s.Name, s.Name)
+	case "darwin":
+		return fmt.Sprintf("查看状态: sudo launchctl print system/%s\n日志文件: %s", sysservice.Label(s.Name), s.LogPath())
+	default:
+		if sysservice.NSSMPath() != "" {
+			return "可在「服务」管理工具中查看状态"
+		}
+		return "可在任务计划程序的 GVAPanel 文件夹中查看状态"
+	}
+}
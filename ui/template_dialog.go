@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/internal/sysutil"
+)
+
+// showConfigTemplateDialog 配置模板：维护者标记「必须本地填写」的键（数据库密码、密钥等），
+// 生成清空这些值的 config.template.yaml 提交到仓库；队友打开项目时只需要填写这些值
+func (l *GVALauncher) showConfigTemplateDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	keys, err := config.LoadLocalKeys(l.project.Root)
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+	if len(keys) == 0 {
+		keys = config.SuggestLocalKeys(l.project.Root)
+	}
+
+	keysEntry := widget.NewMultiLineEntry()
+	keysEntry.SetPlaceHolder("每行一个键，后面可以跟给队友的说明，例如:\nmysql.password 本地 MySQL 的密码\njwt.signing-key")
+	keysEntry.SetMinRowsVisible(8)
+	keysEntry.SetText(config.FormatLocalKeys(keys))
+
+	saveBtn := widget.NewButton("💾 生成模板", func() {
+		if !l.ensureProjectOwner() {
+			return
+		}
+		keys, err := config.ParseLocalKeys(keysEntry.Text)
+		if err == nil {
+			err = config.SaveConfigTemplate(l.project.Root, keys)
+		}
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+		if len(keys) == 0 {
+			dialog.ShowInformation("配置模板", "已删除配置模板", l.window)
+			return
+		}
+		dialog.ShowInformation("配置模板", fmt.Sprintf("已生成（%d 个键需要本地填写）:\n%s\n\n请把模板提交到仓库，并把 server/config.yaml 加入 .gitignore",
+			len(keys), config.ConfigTemplatePath(l.project.Root)), l.window)
+	})
+	fillBtn := widget.NewButton("📝 按模板填写 config.yaml", func() {
+		l.showLocalConfigPrompt()
+	})
+
+	help := widget.NewLabel("列出每个人需要在本地填写的 config.yaml 键（用 . 连接层级，例如 mysql.password）。生成模板时这些值被清空，" +
+		"其余配置与当前的 config.yaml 相同，保存为 server/" + config.ConfigTemplateFile + "，键列表写在模板开头的注释中。" +
+		"队友打开没有 config.yaml 的项目时，面板只提示填写这些值，其余从模板中复制。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(help, keysEntry, container.NewGridWithColumns(2, saveBtn, fillBtn))
+	d := dialog.NewCustom("🧩 配置模板", "关闭", content, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
+
+// checkConfigTemplate 项目提供了配置模板但本地还没有 config.yaml 时提示填写
+func (l *GVALauncher) checkConfigTemplate() {
+	if !config.NeedsLocalConfig(l.project.Root) {
+		return
+	}
+	dialog.ShowConfirm("🧩 配置模板", "项目提供了配置模板，但本地还没有 server/config.yaml。\n\n是否现在填写本地配置（数据库密码、密钥等）并生成 config.yaml？", func(ok bool) {
+		if ok {
+			l.showLocalConfigPrompt()
+		}
+	}, l.window)
+}
+
+// showLocalConfigPrompt 只提示填写模板中标记为必须本地填写的键，其余从模板复制，生成 config.yaml
+func (l *GVALauncher) showLocalConfigPrompt() {
+	root := l.project.Root
+	if !sysutil.FileExists(config.ConfigTemplatePath(root)) {
+		l.showError(apperr.Errorf(apperr.CfgReadFailed, "项目中没有 server/%s，请先让维护者生成配置模板", config.ConfigTemplateFile), nil)
+		return
+	}
+	keys, err := config.LoadLocalKeys(root)
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+
+	// 重新填写时预填当前 config.yaml 中的值
+	values := config.LocalValues(root, keys)
+	entries := make([]*widget.Entry, len(keys))
+	form := widget.NewForm()
+	for i, k := range keys {
+		if isSecretKey(k.Key) {
+			entries[i] = widget.NewPasswordEntry()
+		} else {
+			entries[i] = widget.NewEntry()
+		}
+		entries[i].SetPlaceHolder(k.Note)
+		entries[i].SetText(values[k.Key])
+		form.Append(k.Key, entries[i])
+	}
+	if len(keys) == 0 {
+		form.Append("", widget.NewLabel("模板中没有需要本地填写的键，将直接复制模板"))
+	}
+
+	write := func() {
+		filled := map[string]string{}
+		for i, k := range keys {
+			filled[k.Key] = entries[i].Text
+		}
+		if err := config.WriteConfigFromTemplate(root, keys, filled); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.updatePortsFromGVAConfig()
+		l.loadRedisConfig()
+		dialog.ShowInformation("配置模板", "已生成 "+config.GVAConfigPath(root), l.window)
+	}
+
+	d := dialog.NewCustomConfirm("📝 填写本地配置", "生成 config.yaml", "取消", form, func(ok bool) {
+		if !ok || !l.ensureProjectOwner() {
+			return
+		}
+		if sysutil.FileExists(config.GVAConfigPath(root)) {
+			dialog.ShowConfirm("覆盖 config.yaml", "server/config.yaml 已存在，将用模板和填写的值重新生成，模板之外的本地修改会丢失。是否继续？", func(ok bool) {
+				if ok {
+					write()
+				}
+			}, l.window)
+			return
+		}
+		write()
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(50), 0))
+	d.Show()
+}
+
+// isSecretKey 键名看起来是密码或密钥时用密码输入框
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	return strings.Contains(key, "password") || strings.Contains(key, "secret") || strings.Contains(key, "token")
+}
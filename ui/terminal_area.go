@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/console"
+	"gva-launcher/deps"
+)
+
+// 终端可打开的目录（下拉框顺序）
+var terminalDirs = []string{"后端 server/", "前端 web/", "项目根目录"}
+
+// terminalPanel 下方面板中的终端标签页：在 server/、web/ 或根目录中执行命令（每个目录一个终端，切换时保留）
+type terminalPanel struct {
+	tab       *container.TabItem
+	dirSelect *widget.Select
+	list      *widget.List
+	prompt    *widget.Label
+	input     *historyEntry
+	stopBtn   *widget.Button
+	sessions  map[string]*console.Session // 按初始目录区分（切换根目录后重新打开）
+	session   *console.Session            // 当前显示的终端（未指定根目录时为 nil）
+	lines     []string
+	seq       int // 已读取到的输出序号（见 outputbuf.Buffer.Since）
+	history   int // 浏览历史命令的位置（等于历史条数时为新输入）
+	cancel    context.CancelFunc
+}
+
+// historyEntry 单行输入框，↑ / ↓ 浏览历史命令
+type historyEntry struct {
+	widget.Entry
+	onUp, onDown func()
+}
+
+// newHistoryEntry 创建输入框
+func newHistoryEntry() *historyEntry {
+	e := &historyEntry{}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// TypedKey 拦截 ↑ / ↓，其余按键交给输入框
+func (e *historyEntry) TypedKey(key *fyne.KeyEvent) {
+	switch {
+	case key.Name == fyne.KeyUp && e.onUp != nil:
+		e.onUp()
+	case key.Name == fyne.KeyDown && e.onDown != nil:
+		e.onDown()
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+// createTerminalArea 创建终端标签页
+func (l *GVALauncher) createTerminalArea() *container.TabItem {
+	p := &terminalPanel{sessions: make(map[string]*console.Session)}
+	l.terminal = p
+
+	p.list = widget.NewList(
+		func() int { return len(p.lines) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.TextStyle = fyne.TextStyle{Monospace: true}
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			if id < len(p.lines) {
+				o.(*widget.Label).SetText(p.lines[id])
+			}
+		},
+	)
+	p.prompt = widget.NewLabel("")
+	p.prompt.TextStyle = fyne.TextStyle{Monospace: true}
+
+	p.input = newHistoryEntry()
+	p.input.SetPlaceHolder("输入命令后回车执行，↑ / ↓ 浏览历史命令，例如 go mod tidy、npm run lint")
+	p.input.OnSubmitted = l.runTerminalCommand
+	p.input.onUp = func() { l.browseTerminalHistory(-1) }
+	p.input.onDown = func() { l.browseTerminalHistory(1) }
+
+	p.dirSelect = widget.NewSelect(terminalDirs, func(string) {
+		l.openTerminal()
+	})
+	p.stopBtn = widget.NewButton("⛔ 结束", func() {
+		if p.session != nil {
+			p.session.Interrupt()
+		}
+	})
+	p.stopBtn.Disable()
+	clearBtn := widget.NewButton("🧹 清屏", func() {
+		if p.session != nil {
+			p.session.Output.Clear()
+		}
+	})
+	copyBtn := widget.NewButton("📋 复制", func() {
+		l.copyToClipboard(strings.Join(p.lines, "\n"), "终端输出")
+	})
+	envBtn := widget.NewButton("ℹ️ 环境变量", l.showTerminalEnv)
+
+	header := container.NewHBox(p.dirSelect, p.stopBtn, clearBtn, copyBtn, envBtn)
+	inputRow := container.NewBorder(nil, nil, p.prompt, nil, p.input)
+	p.tab = container.NewTabItem("💻 终端", container.NewBorder(header, inputRow, nil, nil, p.list))
+	return p.tab
+}
+
+// terminalEnv 终端追加的环境变量：项目信息（与钩子脚本相同的 GVA_ 变量）、面板的代理设置和当前的镜像源
+func (l *GVALauncher) terminalEnv() []string {
+	registry, _ := deps.CachedNpmRegistry(l.project.WebDir())
+	goProxy, _ := deps.CachedGoProxy()
+	return append(l.project.HookVars().Env(), console.Env(l.config.Network.HTTPProxy, registry, goProxy)...)
+}
+
+// openTerminal 切换到下拉框选择的目录的终端（没有时新建），并开始跟踪它的输出
+func (l *GVALauncher) openTerminal() {
+	p := l.terminal
+	if !l.project.IsSet() {
+		p.session = nil
+		p.prompt.SetText("")
+		return
+	}
+	home := l.project.Root
+	switch p.dirSelect.Selected {
+	case terminalDirs[0]:
+		home = l.project.ServerDir()
+	case terminalDirs[1]:
+		home = l.project.WebDir()
+	}
+	s, ok := p.sessions[home]
+	if !ok {
+		s = console.New(l.project.Root, home, l.terminalEnv())
+		p.sessions[home] = s
+	}
+	if s == p.session {
+		return
+	}
+	p.session, p.lines, p.seq = s, nil, 0
+	p.history = len(s.History())
+	p.list.Refresh()
+	l.renderTerminal()
+	if l.bottom.box.Visible() {
+		l.followTerminal()
+	}
+}
+
+// followTerminal 跟踪当前终端的输出（下方面板显示时）
+func (l *GVALauncher) followTerminal() {
+	p := l.terminal
+	l.stopFollowingTerminal()
+	if p.dirSelect.Selected == "" {
+		// 第一次显示时打开后端目录的终端（选择回调中会再次调用本方法）
+		p.dirSelect.SetSelected(terminalDirs[0])
+		return
+	}
+	if p.session == nil {
+		l.openTerminal()
+		if p.session == nil {
+			return
+		}
+	}
+	ctx, cancel := context.WithCancel(l.supervisor.Context())
+	p.cancel = cancel
+	l.watchOutput(ctx, "终端", p.session.Output, l.readTerminal)
+}
+
+// stopFollowingTerminal 停止跟踪终端输出（下方面板隐藏或切换终端时）
+func (l *GVALauncher) stopFollowingTerminal() {
+	if p := l.terminal; p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// readTerminal 读取当前终端的新输出（清屏后从头显示）
+func (l *GVALauncher) readTerminal() {
+	p := l.terminal
+	if p.session == nil {
+		return
+	}
+	lines, seq := p.session.Output.Since(p.seq)
+	if seq < p.seq {
+		p.lines = nil
+	}
+	p.seq = seq
+	p.lines = append(p.lines, lines...)
+	p.list.Refresh()
+	p.list.ScrollToBottom()
+	l.renderTerminal()
+}
+
+// renderTerminal 刷新提示符和结束按钮
+func (l *GVALauncher) renderTerminal() {
+	p := l.terminal
+	if p.session == nil {
+		p.prompt.SetText("")
+		p.stopBtn.Disable()
+		return
+	}
+	p.prompt.SetText(p.session.Prompt())
+	if p.session.Running() {
+		p.stopBtn.Enable()
+	} else {
+		p.stopBtn.Disable()
+	}
+}
+
+// focusTerminal 把键盘焦点交给终端的输入框
+func (l *GVALauncher) focusTerminal() {
+	l.window.Canvas().Focus(l.terminal.input)
+}
+
+// runTerminalCommand 在当前终端中执行输入的命令（在后台执行，面板退出时结束）
+func (l *GVALauncher) runTerminalCommand(line string) {
+	p := l.terminal
+	if p.session == nil {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	s := p.session
+	if s.Running() {
+		dialog.ShowInformation("💻 终端", console.ErrBusy.Error(), l.window)
+		return
+	}
+	p.input.SetText("")
+	// 镜像源和代理可能已在面板中修改，每条命令使用最新的设置
+	s.Env = l.terminalEnv()
+	l.background("终端命令", func(ctx context.Context) func() {
+		stop := context.AfterFunc(ctx, s.Interrupt)
+		defer stop()
+		s.Run(line)
+		return func() {
+			p.history = len(s.History())
+			l.renderTerminal()
+		}
+	})
+	p.stopBtn.Enable()
+}
+
+// browseTerminalHistory 在输入框中显示上一条（step 为 -1）或下一条（step 为 1）历史命令
+func (l *GVALauncher) browseTerminalHistory(step int) {
+	p := l.terminal
+	if p.session == nil {
+		return
+	}
+	history := p.session.History()
+	p.history = min(max(p.history+step, 0), len(history))
+	if p.history == len(history) {
+		p.input.SetText("")
+		return
+	}
+	p.input.SetText(history[p.history])
+	p.input.CursorColumn = len([]rune(history[p.history]))
+	p.input.Refresh()
+}
+
+// showTerminalEnv 显示终端在系统环境变量之外追加的变量
+func (l *GVALauncher) showTerminalEnv() {
+	env := l.terminalEnv()
+	slices.Sort(env)
+	text := widget.NewLabel(strings.Join(env, "\n"))
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	help := widget.NewLabel("终端在面板的环境变量基础上追加以下变量：项目信息、「🌐 网络设置」中的代理和当前的 npm / Go 镜像源。" +
+		"命令通过系统 shell 逐条执行（没有伪终端），需要交互输入的程序无法使用；cd 在之后的命令中保持。")
+	help.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("ℹ️ 终端环境变量", "关闭", container.NewVBox(help, text), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(55), 0))
+	d.Show()
+}
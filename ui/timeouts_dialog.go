@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/deps"
+	"gva-launcher/internal/sysutil"
+)
+
+// timeoutField 等待时间对话框中的一项（值为毫秒，留空表示使用默认值）
+type timeoutField struct {
+	label string
+	value *int
+	def   time.Duration
+	entry *widget.Entry
+}
+
+// showTimeoutsDialog 显示等待时间与超时设置对话框
+func (l *GVALauncher) showTimeoutsDialog() {
+	t := l.config.Timeouts
+	fields := []*timeoutField{
+		{label: "等待服务就绪", value: &t.StartReadyMs, def: config.DefaultStartReady},
+		{label: "Vue 重启等待", value: &t.VueRestartWaitMs, def: config.DefaultVueRestartWait},
+		{label: "停止后等待", value: &t.StopWaitMs, def: config.DefaultStopWait},
+		{label: "启动宽限时长", value: &t.MonitorWindowMs, def: config.DefaultMonitorWindow},
+		{label: "Redis 连接超时", value: &t.RedisDialMs, def: config.DefaultRedisDial},
+		{label: "冒烟测试等待", value: &t.SmokeTestMs, def: config.DefaultSmokeTest},
+	}
+
+	form := widget.NewForm()
+	for _, f := range fields {
+		f.entry = widget.NewEntry()
+		f.entry.SetPlaceHolder(fmt.Sprintf("默认 %d", f.def.Milliseconds()))
+		if *f.value > 0 {
+			f.entry.SetText(strconv.Itoa(*f.value))
+		}
+		form.Append(f.label+"（毫秒）", f.entry)
+	}
+
+	help := widget.NewLabel("较慢的机器上服务启动和 Vue 重启耗时更长，状态显示不准确时可适当调大。留空使用默认值。")
+	help.Wrapping = fyne.TextWrapWord
+
+	// 低资源模式（树莓派等 ARM 单板机）
+	lowResourceCheck := widget.NewCheck(fmt.Sprintf("低资源模式：以上时间延长为 %d 倍，看守模式降低检查频率，优先运行预编译的后端", config.LowResourceFactor), nil)
+	lowResourceCheck.SetChecked(l.config.LowResource)
+	lowResourceHelp := widget.NewLabel("预编译的后端（server/gva-server）可通过定时任务「构建项目」生成，比后端源码旧时仍使用 go run。")
+	if hint := deps.LowResourceHint(); hint != "" {
+		lowResourceHelp.SetText("检测到 " + hint + "，建议开启。" + lowResourceHelp.Text)
+	}
+	lowResourceHelp.Wrapping = fyne.TextWrapWord
+
+	// 进程优先级：大型编译时保持电脑响应
+	var priorityLabels []string
+	for _, p := range sysutil.Priorities {
+		priorityLabels = append(priorityLabels, sysutil.PriorityLabel(p))
+	}
+	priorityIndex := func(p string) int {
+		return max(slices.Index(sysutil.Priorities, sysutil.Priority(p)), 0)
+	}
+	serviceSelect := widget.NewSelect(priorityLabels, nil)
+	serviceSelect.SetSelectedIndex(priorityIndex(l.config.Priority.Services))
+	buildSelect := widget.NewSelect(priorityLabels, nil)
+	buildSelect.SetSelectedIndex(priorityIndex(l.config.Priority.Builds))
+	// 空闲自动停止
+	idleEntry := widget.NewEntry()
+	idleEntry.SetPlaceHolder("留空不自动停止")
+	if l.config.IdleMinutes > 0 {
+		idleEntry.SetText(strconv.Itoa(l.config.IdleMinutes))
+	}
+	priorityForm := widget.NewForm(
+		widget.NewFormItem("服务优先级", serviceSelect),
+		widget.NewFormItem("构建优先级", buildSelect),
+		widget.NewFormItem("空闲自动停止（分钟）", idleEntry),
+	)
+	priorityHelp := widget.NewLabel("降低前后端服务（建议「低于正常」）和构建命令（建议「低」）的进程优先级，编译大型项目时电脑仍保持响应。下次启动或构建时生效。" +
+		"空闲自动停止：服务端口上没有连接（浏览器页面全部关闭）且单端口代理没有请求超过设置的分钟数时停止服务，之后可一键恢复。")
+	priorityHelp.Wrapping = fyne.TextWrapWord
+
+	bottom := container.NewVBox(widget.NewSeparator(), lowResourceCheck, lowResourceHelp, widget.NewSeparator(), priorityForm, priorityHelp)
+	content := container.NewBorder(help, bottom, nil, nil, form)
+
+	dialog.ShowCustomConfirm("⏱️ 等待时间", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		for _, f := range fields {
+			text := strings.TrimSpace(f.entry.Text)
+			if text == "" {
+				*f.value = 0
+				continue
+			}
+			ms, err := strconv.Atoi(text)
+			if err != nil || ms <= 0 {
+				dialog.ShowError(fmt.Errorf("%s必须是正整数（毫秒）: %s", f.label, text), l.window)
+				return
+			}
+			*f.value = ms
+		}
+		idleMinutes := 0
+		if text := strings.TrimSpace(idleEntry.Text); text != "" {
+			n, err := strconv.Atoi(text)
+			if err != nil || n <= 0 {
+				dialog.ShowError(fmt.Errorf("空闲自动停止必须是正整数（分钟）: %s", text), l.window)
+				return
+			}
+			idleMinutes = n
+		}
+
+		l.config.Timeouts = t
+		l.config.LowResource = lowResourceCheck.Checked
+		l.config.IdleMinutes = idleMinutes
+		l.config.Priority.Services = string(sysutil.Priorities[serviceSelect.SelectedIndex()])
+		l.config.Priority.Builds = string(sysutil.Priorities[buildSelect.SelectedIndex()])
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("成功", "等待时间已保存", l.window)
+	}, l.window)
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/launcher"
+)
+
+// detectToolchain 在后台检测 go / npm，完成后按结果启用或禁用相关功能，再调用 done（可为 nil）。
+func (l *GVALauncher) detectToolchain(done func(tc deps.Toolchain)) {
+	lowResource := l.config.LowResource
+	l.background("检测工具链", func(context.Context) func() {
+		notes := toolchainNotes(lowResource)
+		tc := deps.DetectToolchain()
+		return func() {
+			l.toolchain = tc
+			l.toolchainKnown = true
+			l.toolchainNotes = notes
+			l.applyToolchain()
+			if done != nil {
+				done(tc)
+			}
+		}
+	})
+}
+
+// toolchainNotes 工具链的提示：Apple Silicon 上先检查工具链架构，已安装原生版本时优先使用，只有 Rosetta 转译版本时提示；
+// 树莓派等 ARM 单板机上未开启低资源模式时建议开启
+func toolchainNotes(lowResource bool) []string {
+	arch := deps.DetectArch()
+	var notes []string
+	for _, s := range arch.PreferNative() {
+		notes = append(notes, "ℹ️ "+s)
+	}
+	for _, w := range arch.Warnings() {
+		notes = append(notes, "⚠️ "+w)
+	}
+	if hint := deps.LowResourceHint(); hint != "" && !lowResource {
+		notes = append(notes, "ℹ️ 检测到 "+hint+"，建议在「⏱️ 等待时间」中开启低资源模式（延长等待时间、优先运行预编译的后端）")
+	}
+	return notes
+}
+
+// applyToolchain 缺少 go 或 npm 时禁用依赖它们的按钮，并在依赖管理区域说明原因
+// Fyne 没有悬停提示，说明以文字形式显示在依赖管理区域顶部
+func (l *GVALauncher) applyToolchain() {
+	distro := l.project.WSLDistro()
+	var info []string
+	if distro != "" {
+		info = append(info, "ℹ️ 项目位于 WSL 发行版 "+distro+" 中，go / npm 命令通过 wsl.exe 在该发行版中执行。")
+	}
+	info = append(info, l.toolchainNotes...)
+	if !l.toolchainKnown || l.toolchain.Complete() {
+		if len(info) > 0 {
+			l.toolchainLabel.SetText(strings.Join(info, "\n"))
+			l.toolchainLabel.Show()
+		} else {
+			l.toolchainLabel.Hide()
+		}
+		l.installDepsButton.Enable()
+		l.frontendMirrorBtn.Enable()
+		l.backendMirrorBtn.Enable()
+		if !l.services.IsRunning() {
+			l.startButton.Enable()
+		}
+		return
+	}
+
+	var disabled []string
+	if !l.toolchain.HasGo() {
+		disabled = append(disabled, "后端启动、后端依赖和 GOPROXY 设置需要 go")
+		l.backendMirrorBtn.Disable()
+	} else {
+		l.backendMirrorBtn.Enable()
+	}
+	if !l.toolchain.HasNpm() {
+		disabled = append(disabled, "前端启动、前端依赖和 npm 镜像源设置需要 npm")
+		l.frontendMirrorBtn.Disable()
+	} else {
+		l.frontendMirrorBtn.Enable()
+	}
+	l.startButton.Disable()
+	l.installDepsButton.Disable()
+
+	where := ""
+	if distro != "" {
+		where = "（WSL 发行版 " + distro + " 中，需在登录 shell 的 PATH 中）"
+	}
+	l.toolchainLabel.SetText("⚠️ 未检测到 " + strings.Join(l.toolchain.Missing(), "、") + where + "：" +
+		strings.Join(disabled, "；") + "。\n　　安装并加入 PATH 后点击「🔍 检查依赖状态」重新检测，端口、Redis 等配置不受影响。" +
+		strings.Join(append([]string{""}, info...), "\n"))
+	l.toolchainLabel.Show()
+}
+
+// enableStartButton 启用启动按钮（缺少 go 或 npm 时保持禁用）
+func (l *GVALauncher) enableStartButton() {
+	if l.toolchainKnown && !l.toolchain.Complete() {
+		l.startButton.Disable()
+		return
+	}
+	l.startButton.Enable()
+}
+
+// requireServiceTool 单独启动一个服务前检查所需的工具（后端需要 go，前端需要 npm），缺失时显示 TOOL_MISSING 错误并返回 false
+func (l *GVALauncher) requireServiceTool(service string) bool {
+	if !l.toolchainKnown {
+		return true
+	}
+	if service == launcher.ServiceBackend && !l.toolchain.HasGo() {
+		l.showError(apperr.Errorf(apperr.ToolMissing, "未检测到 go，无法启动后端，请安装后重新打开面板"), nil)
+		return false
+	}
+	if service == launcher.ServiceFrontend && !l.toolchain.HasNpm() {
+		l.showError(apperr.Errorf(apperr.ToolMissing, "未检测到 npm，无法启动前端，请安装后重新打开面板"), nil)
+		return false
+	}
+	return true
+}
+
+// requireToolchain 执行需要 go 和 npm 的操作前检查，缺失时显示 TOOL_MISSING 错误并返回 false
+func (l *GVALauncher) requireToolchain() bool {
+	if !l.toolchainKnown || l.toolchain.Complete() {
+		return true
+	}
+	l.showError(apperr.Errorf(apperr.ToolMissing, "未检测到 %s，请安装后重新打开面板", strings.Join(l.toolchain.Missing(), "、")), nil)
+	return false
+}
@@ -0,0 +1,352 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+	"gva-launcher/updater"
+)
+
+// createToolsArea 创建面板工具区域（面板自身的维护功能）
+func (l *GVALauncher) createToolsArea() *fyne.Container {
+	versionText := "当前版本: " + launcher.Version
+	if config.Portable() {
+		versionText += "（便携模式）"
+	}
+
+	// 高对比度：黑底白字的主题，状态用形状符号和文字徽标表示，不依赖颜色区分
+	contrastCheck := widget.NewCheck("高对比度", func(on bool) {
+		if on == l.config.HighContrast {
+			return
+		}
+		if err := l.setHighContrast(on); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+		}
+	})
+	contrastCheck.SetChecked(l.config.HighContrast)
+
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("🧰 面板工具", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabel(versionText),
+			layout.NewSpacer(),
+			contrastCheck,
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	updateBtn := widget.NewButton("🔄 检查面板更新", func() {
+		l.checkPanelUpdate()
+	})
+
+	hooksBtn := widget.NewButton("🪝 事件钩子", func() {
+		l.showHooksDialog()
+	})
+
+	scheduleBtn := widget.NewButton("⏰ 定时任务", func() {
+		l.showScheduleDialog()
+	})
+
+	timeoutsBtn := widget.NewButton("⏱️ 等待时间", func() {
+		l.showTimeoutsDialog()
+	})
+
+	jobsBtn := widget.NewButton("📋 任务中心", func() {
+		l.showJobsDialog()
+	})
+
+	watchdogBtn := widget.NewButton("🐕 看守模式", func() {
+		l.showWatchdogDialog()
+	})
+
+	metricsBtn := widget.NewButton("📈 状态导出", func() {
+		l.showMetricsDialog()
+	})
+
+	proxyBtn := widget.NewButton("🔀 单端口访问", func() {
+		l.showProxyDialog()
+	})
+
+	httpsBtn := widget.NewButton("🔒 本地 HTTPS", func() {
+		l.showHTTPSDialog()
+	})
+
+	hostsBtn := widget.NewButton("🏷️ 局域网主机名", func() {
+		l.showHostsDialog()
+	})
+
+	mdnsBtn := widget.NewButton("📣 局域网发现", func() {
+		l.showMDNSDialog()
+	})
+
+	certsBtn := widget.NewButton("📜 证书管理", func() {
+		l.showCertsDialog()
+	})
+
+	networkBtn := widget.NewButton("🌐 网络设置", func() {
+		l.showNetworkDialog()
+	})
+
+	serversBtn := widget.NewButton("🖥️ 远程服务器", func() {
+		l.showServersDialog()
+	})
+
+	sshKeysBtn := widget.NewButton("🔑 SSH 密钥", func() {
+		l.showSSHKeysDialog()
+	})
+
+	remoteLogBtn := widget.NewButton("📡 远程日志", func() {
+		l.showRemoteLogDialog()
+	})
+
+	workspaceBtn := widget.NewButton("🧩 Go 工作区", func() {
+		l.showWorkspaceDialog()
+	})
+
+	envDiffBtn := widget.NewButton("🧾 前端 env 对比", func() {
+		l.showEnvDiffDialog()
+	})
+
+	apiBtn := widget.NewButton("🧭 API 浏览", func() {
+		l.showAPIDialog()
+	})
+
+	menuBtn := widget.NewButton("🗂️ 菜单检查", func() {
+		l.showMenuDialog()
+	})
+
+	loadTestBtn := widget.NewButton("⏱️ 接口压测", func() {
+		l.showLoadTestDialog("", "")
+	})
+
+	trashBtn := widget.NewButton("♻️ 回收站", func() {
+		l.showTrashDialog()
+	})
+
+	fingerprintBtn := widget.NewButton("🧬 环境指纹", func() {
+		l.showFingerprintDialog()
+	})
+
+	troubleshootBtn := widget.NewButton("🔎 故障排查", func() {
+		l.showTroubleshootDialog()
+	})
+
+	buildBtn := widget.NewButton("🏗️ 生产构建", func() {
+		l.showProductionBuildDialog()
+	})
+
+	deployBtn := widget.NewButton("🚢 远程部署", func() {
+		l.showDeployDialog()
+	})
+
+	registryBtn := widget.NewButton("🐳 镜像推送", func() {
+		l.showRegistryDialog()
+	})
+
+	kubeBtn := widget.NewButton("☸️ K8s 清单", func() {
+		l.showKubeDialog()
+	})
+
+	composeBtn := widget.NewButton("🐬 本地依赖", func() {
+		l.showComposeDialog()
+	})
+
+	licensesBtn := widget.NewButton("📜 许可证清单", func() {
+		l.showLicensesDialog()
+	})
+
+	sysServiceBtn := widget.NewButton("🖥️ 系统服务", func() {
+		l.showSysServiceDialog()
+	})
+
+	reproBtn := widget.NewButton("🔁 可复现构建", func() {
+		l.showReproBuildDialog()
+	})
+
+	templateBtn := widget.NewButton("🧩 配置模板", func() {
+		l.showConfigTemplateDialog()
+	})
+
+	i18nBtn := widget.NewButton("🌐 多语言检查", func() {
+		l.showI18nDialog()
+	})
+
+	contractBtn := widget.NewButton("🤝 接口契约", func() {
+		l.showContractDialog()
+	})
+
+	terminalBtn := widget.NewButton("💻 终端", func() {
+		l.toggleBottomPanel(l.terminal.tab)
+	})
+
+	cleanupBtn := widget.NewButton("🧹 空间清理", func() {
+		l.showCleanupDialog()
+	})
+
+	scriptsBtn := widget.NewButton("📦 npm 脚本", func() {
+		l.toggleBottomPanel(l.scripts.tab)
+	})
+
+	reportBtn := widget.NewButton("📰 依赖周报", func() {
+		l.showDepReportDialog()
+	})
+
+	auditBtn := widget.NewButton("🕰️ 配置审计", func() {
+		l.showAuditDialog()
+	})
+
+	demoBtn := widget.NewButton("🎓 演示模式", func() {
+		l.showDemoDialog()
+	})
+
+	consoleBtn := widget.NewButton("🧪 脚本控制台", func() {
+		l.showScriptConsole()
+	})
+
+	// 使用 GridWithColumns 让按钮平均分配宽度
+	buttonBox := container.NewGridWithColumns(3,
+		jobsBtn,
+		updateBtn,
+		networkBtn,
+		hooksBtn,
+		scheduleBtn,
+		timeoutsBtn,
+		watchdogBtn,
+		metricsBtn,
+		consoleBtn,
+		httpsBtn,
+		proxyBtn,
+		hostsBtn,
+		mdnsBtn,
+		certsBtn,
+		sshKeysBtn,
+		serversBtn,
+		remoteLogBtn,
+		demoBtn,
+		auditBtn,
+		workspaceBtn,
+		envDiffBtn,
+		apiBtn,
+		menuBtn,
+		loadTestBtn,
+		trashBtn,
+		fingerprintBtn,
+		troubleshootBtn,
+		buildBtn,
+		deployBtn,
+		registryBtn,
+		kubeBtn,
+		composeBtn,
+		licensesBtn,
+		sysServiceBtn,
+		reproBtn,
+		templateBtn,
+		i18nBtn,
+		contractBtn,
+		reportBtn,
+		terminalBtn,
+		scriptsBtn,
+		cleanupBtn,
+	)
+
+	return container.NewVBox(
+		titleBox,
+		buttonBox,
+	)
+}
+
+// ========================================
+// 面板自更新
+// ========================================
+
+// checkPanelUpdate 查询最新发布，有新版本时询问是否更新
+func (l *GVALauncher) checkPanelUpdate() {
+	progress := dialog.NewProgressInfinite("检查更新", "正在查询最新版本...", l.window)
+	progress.Show()
+
+	l.background("检查面板更新", func(context.Context) func() {
+		rel, err := updater.LatestRelease(updater.DefaultSources)
+
+		return func() {
+			progress.Hide()
+
+			if err != nil {
+				l.showError(err, nil)
+				return
+			}
+			if !updater.IsNewer(rel.Tag, launcher.Version) {
+				dialog.ShowInformation("检查更新", fmt.Sprintf("当前已是最新版本（%s）", launcher.Version), l.window)
+				return
+			}
+
+			notes := rel.Notes
+			if notes == "" {
+				notes = "（无更新说明）"
+			}
+			message := fmt.Sprintf("发现新版本 %s（当前 %s，来源 %s）\n\n%s\n\n是否立即下载并更新？", rel.Tag, launcher.Version, rel.Source, notes)
+			dialog.ShowConfirm("发现新版本", message, func(ok bool) {
+				if ok {
+					l.applyPanelUpdate(rel)
+				}
+			}, l.window)
+		}
+	})
+}
+
+// applyPanelUpdate 作为任务立即下载、校验并替换可执行文件，完成后重新启动面板
+func (l *GVALauncher) applyPanelUpdate(rel *updater.Release) {
+	bar := widget.NewProgressBar()
+	status := widget.NewLabel("正在下载 " + rel.Tag + "...")
+	progress := dialog.NewCustomWithoutButtons("更新面板", container.NewVBox(status, bar), l.window)
+	progress.Resize(fyne.NewSize(l.calcVW(80), 0))
+	progress.Show()
+
+	job := l.jobs.Go("下载面板更新", func(ctx context.Context, j *jobs.Job) error {
+		// 进度按百分比发布，已下载的大小每秒（Tick 事件）刷新一次
+		percent := -1
+		return updater.Update(rel, func(downloaded, total int64) {
+			j.SetCount(float64(downloaded)/1024/1024, "MB")
+			if total > 0 && int(downloaded*100/total) != percent {
+				percent = int(downloaded * 100 / total)
+				j.SetProgress(float64(downloaded) / float64(total))
+			}
+		})
+	})
+
+	l.watchJob(job, func() {
+		if p := job.Progress(); p >= 0 {
+			bar.SetValue(p)
+		}
+		mb, _ := job.Count()
+		status.SetText(fmt.Sprintf("正在下载 %s... %.1f MB", rel.Tag, mb))
+	}, func(err error) {
+		progress.Hide()
+
+		if err != nil {
+			l.showError(err, nil)
+			return
+		}
+
+		dialog.ShowConfirm("更新完成", "新版本已安装，是否立即重启面板？\n运行中的 GVA 服务不会受影响。", func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := updater.Relaunch(); err != nil {
+				l.showError(err, nil)
+				return
+			}
+			fyne.CurrentApp().Quit()
+		}, l.window)
+	}, events.Tick)
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/trash"
+)
+
+// trashBatchText 回收站批次的说明：node_modules 显示路径，Go 模块只显示数量
+func trashBatchText(b trash.Batch) string {
+	var lines []string
+	modules := 0
+	for _, item := range b.Items {
+		if filepath.Base(item.Original) == "node_modules" {
+			lines = append(lines, "• "+item.Original)
+		} else {
+			modules++
+		}
+	}
+	if modules > 0 {
+		lines = append(lines, fmt.Sprintf("• Go 模块缓存 %d 个", modules))
+	}
+	expires := b.Created.Add(trash.Retention).Format("2006-01-02 15:04")
+	return strings.Join(lines, "\n") + "\n将于 " + expires + " 后自动彻底删除"
+}
+
+// showTrashDialog 显示回收站：清理缓存时移入的内容可以恢复到原位置或彻底删除
+func (l *GVALauncher) showTrashDialog() {
+	list := container.NewVBox()
+
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		batches, err := l.trash.List()
+		if err != nil {
+			list.Add(widget.NewLabel("❌ 读取回收站失败: " + err.Error()))
+		} else if len(batches) == 0 {
+			list.Add(widget.NewLabel("回收站是空的"))
+		}
+		for _, b := range batches {
+			title := widget.NewLabelWithStyle(b.Label+"  "+b.Created.Format(time.DateTime), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			detail := widget.NewLabel(trashBatchText(b))
+			detail.Wrapping = fyne.TextWrapWord
+
+			restoreBtn := widget.NewButton("↩️ 恢复", func() {
+				if l.services.IsRunning() {
+					l.showError(fmt.Errorf("请先停止服务再恢复"), nil)
+					return
+				}
+				restored, skipped, err := l.trash.Restore(b.ID)
+				refresh()
+				if err != nil {
+					l.showError(err, nil)
+					return
+				}
+				dialog.ShowInformation("已恢复", fmt.Sprintf("已恢复 %d 项（跳过 %d 项）", restored, skipped), l.window)
+				l.checkDependencies(nil)
+			})
+			purgeBtn := widget.NewButton("🗑️ 彻底删除", func() {
+				dialog.ShowConfirm("彻底删除", "彻底删除后无法恢复，确定删除这次清理的内容？", func(ok bool) {
+					if !ok {
+						return
+					}
+					l.background("清空回收站", func(context.Context) func() {
+						err := l.trash.Purge(b.ID)
+						return func() {
+							refresh()
+							if err != nil {
+								l.showError(err, nil)
+							}
+						}
+					})
+				}, l.window)
+			})
+			list.Add(container.NewBorder(nil, nil, title, container.NewHBox(layout.NewSpacer(), restoreBtn, purgeBtn)))
+			list.Add(detail)
+			list.Add(widget.NewSeparator())
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	help := widget.NewLabel(fmt.Sprintf("「🗑️ 清理缓存」时选择移入回收站的 node_modules 和 Go 模块缓存保存在这里，%d 天后自动彻底删除。"+
+		"移入的内容保存在原位置所在磁盘上的 %s 目录中，仍占用磁盘空间；原位置已存在的内容（例如已重新安装依赖）恢复时不会覆盖。",
+		int(trash.Retention.Hours()/24), trash.DirName))
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(0, l.calcVH(40)))
+	d := dialog.NewCustom("♻️ 回收站", "关闭", container.NewBorder(help, nil, nil, nil, scroll), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/config"
+	"gva-launcher/download"
+	"gva-launcher/redisx"
+	"gva-launcher/services"
+	"gva-launcher/troubleshoot"
+)
+
+// troubleshootEnv 当前项目的排查环境
+func (l *GVALauncher) troubleshootEnv() troubleshoot.Env {
+	backendPort, frontendPort := l.project.Ports()
+	targets := l.project.SmokeTargets()
+	dialTimeout := l.config.EffectiveTimeouts().RedisDial()
+	return troubleshoot.Env{
+		Root:         l.project.Root,
+		BackendPort:  backendPort,
+		FrontendPort: frontendPort,
+		BackendURL:   targets.BackendURL,
+		FrontendURL:  targets.FrontendURL,
+		PortInUse:    services.IsPortInUse,
+		Redis: func(addr, password string, db int) error {
+			_, err := redisx.TestConnection(addr, password, db, dialTimeout)
+			return err
+		},
+		NetworkTime: func(ctx context.Context) (time.Time, error) {
+			// 使用面板的代理设置，只能通过代理访问外网的机器上也能取得网络时间
+			return troubleshoot.HTTPDate(ctx, download.Client(15*time.Second), troubleshoot.TimeSources...)
+		},
+	}
+}
+
+// findingIcon 检查结论的图标
+func findingIcon(s troubleshoot.Status) string {
+	switch s {
+	case troubleshoot.StatusOK:
+		return "✅"
+	case troubleshoot.StatusWarning:
+		return "⚠️"
+	default:
+		return "❌"
+	}
+}
+
+// showTroubleshootDialog 引导式排查：选择遇到的问题，依次执行相关检查，给出最可能的原因和一键修复
+func (l *GVALauncher) showTroubleshootDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+
+	culpritBox := container.NewVBox()
+	findings := container.NewVBox()
+	var symptomBtns []fyne.Disableable
+
+	var run func(symptom troubleshoot.Symptom)
+	run = func(symptom troubleshoot.Symptom) {
+		for _, b := range symptomBtns {
+			b.Disable()
+		}
+		culpritBox.Objects = []fyne.CanvasObject{widget.NewLabel("⏳ 正在排查「" + symptom.Label() + "」...")}
+		culpritBox.Refresh()
+		findings.Objects = nil
+		findings.Refresh()
+
+		env := l.troubleshootEnv()
+		l.background("故障排查", func(ctx context.Context) func() {
+			report := troubleshoot.Run(ctx, symptom, env)
+			return func() {
+				for _, b := range symptomBtns {
+					b.Enable()
+				}
+				culpritBox.Objects = nil
+				if culprit := report.Culprit(); culprit == nil {
+					culpritBox.Add(widget.NewLabel("✅ 没有发现问题。如果问题仍然存在，请在服务输出和浏览器开发者工具中查看报错"))
+				} else {
+					title := widget.NewLabelWithStyle("最可能的原因: "+culprit.Check, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+					detail := widget.NewLabel(culprit.Detail)
+					detail.Wrapping = fyne.TextWrapWord
+					culpritBox.Add(title)
+					culpritBox.Add(detail)
+					if culprit.Fix != nil {
+						fix := *culprit.Fix
+						culpritBox.Add(widget.NewButton("🛠️ "+fix.Title, func() {
+							l.applyTroubleshootFix(fix, func() { run(symptom) })
+						}))
+					}
+				}
+				culpritBox.Refresh()
+
+				for _, f := range report.Findings {
+					label := widget.NewLabel(fmt.Sprintf("%s %s: %s", findingIcon(f.Status), f.Check, f.Detail))
+					label.Wrapping = fyne.TextWrapWord
+					findings.Add(label)
+				}
+				findings.Refresh()
+			}
+		})
+	}
+
+	buttons := container.NewGridWithColumns(len(troubleshoot.Symptoms))
+	for _, s := range troubleshoot.Symptoms {
+		btn := widget.NewButton(s.Label(), func() { run(s) })
+		symptomBtns = append(symptomBtns, btn)
+		buttons.Add(btn)
+	}
+
+	help := widget.NewLabel("选择遇到的问题，面板会依次检查服务端口、前端代理配置、Redis、JWT 配置和系统时钟等，" +
+		"把第一个发现的问题作为最可能的原因，可以一键修复的会显示修复按钮。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(container.NewVBox(culpritBox, widget.NewSeparator(), findings))
+	scroll.SetMinSize(fyne.NewSize(0, l.calcVH(40)))
+	d := dialog.NewCustom("🔎 故障排查", "关闭", container.NewBorder(container.NewVBox(help, buttons), nil, nil, nil, scroll), l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
+
+// applyTroubleshootFix 执行排查给出的修复操作，完成后调用 rerun 重新排查
+func (l *GVALauncher) applyTroubleshootFix(fix troubleshoot.Fix, rerun func()) {
+	switch fix.Action {
+	case troubleshoot.FixStartServices:
+		l.startGVA()
+	case troubleshoot.FixSyncProxyPort:
+		if err := config.WriteFrontendBackendPort(l.project.Root, fix.Port); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		dialog.ShowInformation("已修改", fmt.Sprintf("前端代理端口已改为 %d，重启前端后生效", fix.Port), l.window)
+	case troubleshoot.FixDisableRedis:
+		if err := config.WriteUseRedis(l.project.Root, false); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.loadRedisConfig()
+		dialog.ShowInformation("已修改", "已关闭 use-redis，重启后端后生效", l.window)
+	case troubleshoot.FixFreePort:
+		dialog.ShowConfirm("结束进程", fmt.Sprintf("确定结束占用端口 %d 的进程？", fix.Port), func(ok bool) {
+			if !ok {
+				return
+			}
+			l.background("释放端口", func(ctx context.Context) func() {
+				killed := services.KillProcessByPort(fix.Port)
+				return func() {
+					dialog.ShowInformation("已结束", fmt.Sprintf("已结束 %d 个进程", killed), l.window)
+					rerun()
+				}
+			})
+		}, l.window)
+		return
+	}
+	rerun()
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/config"
+	"gva-launcher/events"
+	"gva-launcher/tunnel"
+)
+
+// createTunnelArea 创建外网穿透区域
+func (l *GVALauncher) createTunnelArea() *fyne.Container {
+	// 客户端在自己的协程中回调，只发布 TunnelChanged 事件，由 onTunnelChanged 在主线程中刷新
+	l.tunnel = &tunnel.Tunnel{
+		OnURL: func(url string) {
+			l.bus.Publish(events.Event{Topic: events.TunnelChanged, Tunnel: events.TunnelState{URL: url}})
+		},
+		OnExit: func(err error) {
+			state := events.TunnelState{Exited: true}
+			if err != nil {
+				state.Error = err.Error()
+			}
+			l.bus.Publish(events.Event{Topic: events.TunnelChanged, Tunnel: state})
+		},
+	}
+
+	settingsBtn := widget.NewButton("⚙️ 设置", l.showTunnelDialog)
+	titleBox := container.NewVBox(
+		widget.NewSeparator(), // 上边界线
+		container.NewHBox(
+			widget.NewLabelWithStyle("🌐 外网穿透", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			layout.NewSpacer(),
+			settingsBtn,
+		),
+		widget.NewSeparator(), // 下边界线
+	)
+
+	l.tunnelStatusLabel = widget.NewLabel("")
+	l.tunnelURLLabel = widget.NewLabel("")
+	copyBtn := widget.NewButton("　📋 复制链接　", func() {
+		url := l.tunnel.URL()
+		if url == "" {
+			dialog.ShowInformation("提示", "还没有公网地址，请先启动穿透", l.window)
+			return
+		}
+		l.copyToClipboard(url, "公网地址")
+	})
+	urlBox := container.NewHBox(l.tunnelURLLabel, layout.NewSpacer(), copyBtn)
+
+	l.tunnelStartBtn = widget.NewButton("▶️ 启动穿透", l.startTunnel)
+	l.tunnelStopBtn = widget.NewButton("⏹️ 停止穿透", l.tunnel.Stop)
+	buttonBox := container.NewGridWithColumns(2, l.tunnelStartBtn, l.tunnelStopBtn)
+
+	l.renderTunnelStatus()
+	return container.NewVBox(
+		titleBox,
+		l.tunnelStatusLabel,
+		urlBox,
+		buttonBox,
+	)
+}
+
+// renderTunnelStatus 按穿透客户端的运行状态刷新区域
+func (l *GVALauncher) renderTunnelStatus() {
+	cfg := l.config.Tunnel
+	running := l.tunnel.Running()
+	switch {
+	case cfg.Provider == "":
+		l.tunnelStatusLabel.SetText("　未配置穿透客户端，点击右上角 ⚙️ 设置")
+	case running:
+		l.tunnelStatusLabel.SetText("　" + l.statusBadge(statusRunning, tunnel.Label(cfg.Provider)+" 运行中"))
+	default:
+		l.tunnelStatusLabel.SetText("　" + l.statusBadge(statusIdle, tunnel.Label(cfg.Provider)+" 未运行"))
+	}
+
+	switch url := l.tunnel.URL(); {
+	case url != "":
+		l.tunnelURLLabel.SetText("　• 公网: " + url)
+	case running:
+		l.tunnelURLLabel.SetText("　• 公网: 等待客户端输出地址...")
+	default:
+		l.tunnelURLLabel.SetText("　• 公网: 未开启")
+	}
+
+	if running {
+		l.tunnelStartBtn.Disable()
+		l.tunnelStopBtn.Enable()
+	} else {
+		l.tunnelStartBtn.Enable()
+		l.tunnelStopBtn.Disable()
+	}
+}
+
+// onTunnelChanged 处理 TunnelChanged 事件：刷新区域，意外退出时显示最近的输出
+func (l *GVALauncher) onTunnelChanged(state events.TunnelState) {
+	l.renderTunnelStatus()
+	if state.Error != "" {
+		l.showTunnelExit(state.Error)
+	}
+}
+
+// startTunnel 启动穿透客户端，转发到本机前端服务
+func (l *GVALauncher) startTunnel() {
+	if l.frontendPort <= 0 {
+		dialog.ShowInformation("提示", "前端端口未配置，请先选择 GVA 根目录", l.window)
+		return
+	}
+	scheme := "http"
+	if l.httpsEnabled {
+		scheme = "https"
+	}
+	target := fmt.Sprintf("%s://localhost:%d", scheme, l.frontendPort)
+
+	if err := l.tunnel.Start(l.config.Tunnel, target); err != nil {
+		l.showError(err, nil)
+		return
+	}
+	l.renderTunnelStatus()
+}
+
+// showTunnelExit 穿透客户端意外退出时显示最近的输出
+func (l *GVALauncher) showTunnelExit(reason string) {
+	output := l.tunnel.Output()
+	if output == "" {
+		output = "（客户端没有输出）"
+	}
+	detail := widget.NewLabel(output)
+	detail.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(detail)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(30)))
+
+	dialog.ShowCustom("⚠️ "+reason, "关闭", scroll, l.window)
+}
+
+// followFrontend 前端服务启动或停止时同步启停穿透（需在设置中开启）
+func (l *GVALauncher) followFrontend(state events.ServiceState) {
+	up := state.FrontendRunning
+	changed := up != l.tunnelFrontendUp
+	l.tunnelFrontendUp = up
+	if !changed || !l.config.Tunnel.FollowFrontend || l.config.Tunnel.Provider == "" {
+		return
+	}
+
+	if up && !l.tunnel.Running() {
+		l.startTunnel()
+	} else if !up {
+		l.tunnel.Stop()
+	}
+}
+
+// showTunnelDialog 显示外网穿透设置
+func (l *GVALauncher) showTunnelDialog() {
+	cfg := l.config.Tunnel
+
+	labels := make([]string, len(tunnel.Providers))
+	for i, p := range tunnel.Providers {
+		labels[i] = tunnel.Label(p)
+	}
+
+	commandEntry := widget.NewEntry()
+	commandEntry.SetPlaceHolder("例如: bore local {port} --to bore.pub")
+	commandEntry.SetText(cfg.Command)
+
+	frpEntry := widget.NewEntry()
+	frpEntry.SetPlaceHolder("frpc.toml 的路径")
+	frpEntry.SetText(cfg.FrpConfig)
+	frpBrowse := widget.NewButton("📁", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			frpEntry.SetText(reader.URI().Path())
+		}, l.window)
+	})
+	frpBox := container.NewBorder(nil, nil, nil, frpBrowse, frpEntry)
+
+	publicEntry := widget.NewEntry()
+	publicEntry.SetPlaceHolder("frp 等不输出地址的客户端需手动填写，例如 https://gva.example.com")
+	publicEntry.SetText(cfg.PublicURL)
+
+	followCheck := widget.NewCheck("随前端服务启动和停止", nil)
+	followCheck.SetChecked(cfg.FollowFrontend)
+
+	// 只启用所选客户端需要的设置项
+	providerSelect := widget.NewSelect(labels, func(label string) {
+		provider := providerOf(label)
+		if provider == tunnel.Custom {
+			commandEntry.Enable()
+		} else {
+			commandEntry.Disable()
+		}
+		if provider == tunnel.Frp {
+			frpEntry.Enable()
+			frpBrowse.Enable()
+		} else {
+			frpEntry.Disable()
+			frpBrowse.Disable()
+		}
+	})
+	providerSelect.PlaceHolder = "选择穿透客户端"
+	if cfg.Provider != "" {
+		providerSelect.SetSelected(tunnel.Label(cfg.Provider))
+	} else {
+		providerSelect.SetSelected(tunnel.Label(tunnel.Cloudflared))
+	}
+
+	help := widget.NewLabel("把本机的前端服务暴露到公网，方便给远程的同事或客户演示。" +
+		"Cloudflare 快速隧道无需账号即可使用；ngrok 需先执行 ngrok config add-authtoken；" +
+		"frp 需要自己的 frps 服务器。客户端程序需已安装并加入 PATH。" +
+		"自定义命令中的 {port}、{url} 会替换为前端端口和本地地址。")
+	help.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("客户端", providerSelect),
+		widget.NewFormItem("自定义命令", commandEntry),
+		widget.NewFormItem("frpc 配置", frpBox),
+		widget.NewFormItem("公网地址", publicEntry),
+	)
+	content := container.NewVBox(help, form, followCheck)
+
+	d := dialog.NewCustomConfirm("🌐 外网穿透", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		l.config.Tunnel = config.Tunnel{
+			Provider:       providerOf(providerSelect.Selected),
+			Command:        strings.TrimSpace(commandEntry.Text),
+			FrpConfig:      strings.TrimSpace(frpEntry.Text),
+			PublicURL:      strings.TrimSpace(publicEntry.Text),
+			FollowFrontend: followCheck.Checked,
+		}
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+		l.renderTunnelStatus()
+		if l.tunnel.Running() {
+			dialog.ShowInformation("提示", "设置已保存，重新启动穿透后生效", l.window)
+		}
+	}, l.window)
+	d.Resize(fyne.NewSize(l.calcVW(60), 0))
+	d.Show()
+}
+
+// providerOf 下拉框显示名称对应的客户端
+func providerOf(label string) string {
+	for _, p := range tunnel.Providers {
+		if tunnel.Label(p) == label {
+			return p
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/autostart"
+	"gva-launcher/config"
+	"gva-launcher/instance"
+	"gva-launcher/watchdog"
+)
+
+// showWatchdogDialog 显示看守模式设置：登录自启动以及需要保持运行的服务
+func (l *GVALauncher) showWatchdogDialog() {
+	autostartCheck := widget.NewCheck("登录时自动启动看守模式（不显示窗口）", nil)
+	autostartCheck.SetChecked(autostart.Enabled())
+	backendCheck := widget.NewCheck("保持后端运行", nil)
+	backendCheck.SetChecked(l.config.Watchdog.Backend)
+	frontendCheck := widget.NewCheck("保持前端运行", nil)
+	frontendCheck.SetChecked(l.config.Watchdog.Frontend)
+
+	status := "看守模式未运行"
+	if instance.Running(config.WatchdogLockPath()) {
+		status = "看守模式正在运行（面板窗口打开期间暂停）"
+	}
+
+	entryText := "自启动入口: 当前系统不支持"
+	if path, err := autostart.Path(); err == nil {
+		entryText = "自启动入口: " + path
+	}
+
+	help := widget.NewLabel("看守模式在后台运行，拉起勾选的服务，服务退出后自动重新启动。" +
+		"面板窗口打开期间由窗口管理服务，看守模式暂停；关闭窗口后，勾选的服务即使是手动停止的也会被重新启动。")
+	help.Wrapping = fyne.TextWrapWord
+
+	logPath := filepath.Join(config.LogDir(), watchdog.LogName)
+	logBox := container.NewBorder(nil, nil, nil,
+		widget.NewButton("　📋 复制　", func() {
+			l.copyPathToClipboard(logPath, "看守日志路径")
+		}),
+		widget.NewLabel("看守日志: "+logPath),
+	)
+
+	content := container.NewVBox(
+		help,
+		autostartCheck,
+		backendCheck,
+		frontendCheck,
+		widget.NewSeparator(),
+		widget.NewLabel(status),
+		widget.NewLabel(entryText),
+		logBox,
+	)
+
+	dialog.ShowCustomConfirm("🐕 看守模式", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		l.config.Watchdog = config.Watchdog{
+			Backend:  backendCheck.Checked,
+			Frontend: frontendCheck.Checked,
+		}
+		if err := l.saveConfig(); err != nil {
+			l.showError(fmt.Errorf("保存配置失败: %w", err), nil)
+			return
+		}
+
+		if err := l.setAutostart(autostartCheck.Checked); err != nil {
+			l.showError(fmt.Errorf("设置登录自启动失败: %w", err), nil)
+			return
+		}
+		dialog.ShowInformation("成功", "看守模式设置已保存", l.window)
+	}, l.window)
+}
+
+// setAutostart 注册或取消登录自启动（以看守模式运行当前程序，便携模式保留 --portable）
+func (l *GVALauncher) setAutostart(enabled bool) error {
+	if !enabled {
+		return autostart.Disable()
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("无法获取程序路径: %v", err)
+	}
+	args := []string{"--watchdog"}
+	if config.Portable() {
+		args = append(args, "--portable")
+	}
+	return autostart.Enable(exe, args...)
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"gva-launcher/apperr"
+	"gva-launcher/deps"
+	"gva-launcher/internal/sysutil"
+)
+
+// workspaceSummary 依赖区域中后端一行附加的说明：go.mod 中的本地 replace 优先提示（其他机器上通常无法构建）
+func workspaceSummary(w deps.Workspace, replaces []deps.Replace) string {
+	local := 0
+	for _, r := range replaces {
+		if r.Local() {
+			local++
+		}
+	}
+	switch {
+	case local > 0:
+		return fmt.Sprintf("（⚠️ go.mod 有 %d 个本地 replace）", local)
+	case w.Active():
+		return fmt.Sprintf("（🧩 go.work，%d 个本地模块）", len(w.Local))
+	case len(w.Local) > 0:
+		return fmt.Sprintf("（🧩 %d 个本地替换）", len(w.Local))
+	}
+	return ""
+}
+
+// showWorkspaceDialog 显示后端使用的 go.work、由本地目录提供的模块和 go.mod 中的 replace
+func (l *GVALauncher) showWorkspaceDialog() {
+	if !l.project.IsSet() {
+		l.showError(apperr.Errorf(apperr.ProjectNotSet, "请先指定 GVA 根目录"), nil)
+		return
+	}
+	w := l.deps.Workspace()
+	replaces, err := l.deps.Replaces()
+	if err != nil {
+		l.showError(err, nil)
+		return
+	}
+
+	status := "未使用 Go 工作区（没有找到 go.work，或设置了 GOWORK=off）"
+	if w.Active() {
+		status = "工作区: " + w.GoWork
+	}
+	statusLabel := widget.NewLabel(status)
+	statusLabel.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	list := container.NewVBox(widget.NewLabelWithStyle("本地提供的模块", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, m := range w.Local {
+		mark := "📁"
+		if !sysutil.DirExists(m.Dir) {
+			mark = "⚠️ 目录不存在"
+		}
+		dir := widget.NewLabel(fmt.Sprintf("%s %s\n%s %s", m.Path, m.Source, mark, m.Dir))
+		dir.Wrapping = fyne.TextWrapWord
+		list.Add(dir)
+	}
+	if len(w.Local) == 0 {
+		list.Add(widget.NewLabel("所有依赖都来自模块缓存"))
+	}
+
+	list.Add(widget.NewSeparator())
+	list.Add(widget.NewLabelWithStyle("go.mod 中的 replace", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, r := range replaces {
+		r := r
+		text := r.String()
+		if r.Local() {
+			text = "⚠️ " + text + "\n本地路径在其他机器上通常不存在，提交前请确认"
+		}
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapWord
+		removeBtn := widget.NewButton("🗑️ 删除", func() {
+			if !l.ensureProjectOwner() {
+				return
+			}
+			dialog.ShowConfirm("删除 replace", "从 go.mod 中删除:\n"+r.String(), func(ok bool) {
+				if !ok {
+					return
+				}
+				if err := l.deps.DropReplace(r); err != nil {
+					l.showError(err, nil)
+					return
+				}
+				d.Hide()
+				l.showWorkspaceDialog()
+				l.checkDependencies(nil)
+			}, l.window)
+		})
+		list.Add(container.NewBorder(nil, nil, nil, removeBtn, label))
+	}
+	if len(replaces) == 0 {
+		list.Add(widget.NewLabel("没有 replace 指令"))
+	}
+
+	addBtn := widget.NewButton("➕ 替换为本地目录", func() {
+		d.Hide()
+		l.showAddReplaceDialog()
+	})
+
+	help := widget.NewLabel("面板在 server 目录执行 go 命令，按 go 的规则从 server 向上查找 go.work。" +
+		"本地提供的模块不要求出现在模块缓存中，清理缓存也不会涉及它们；" +
+		"工作区模式下 GOFLAGS 中的 -mod=mod 会导致 go 命令报错，面板启动、构建时自动改用 -mod=readonly。")
+	help.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(l.calcVW(60), l.calcVH(40)))
+
+	content := container.NewBorder(container.NewVBox(statusLabel, widget.NewSeparator()),
+		container.NewVBox(help, container.NewHBox(addBtn)), nil, nil, scroll)
+	d = dialog.NewCustom("🧩 Go 工作区", "关闭", content, l.window)
+	d.Show()
+}
+
+// showAddReplaceDialog 在 go.mod 中把一个依赖替换为本地检出的目录
+func (l *GVALauncher) showAddReplaceDialog() {
+	if !l.ensureProjectOwner() {
+		return
+	}
+
+	var modules []string
+	if list, err := deps.ReadGoModDependencies(l.project.ServerDir()); err == nil {
+		for _, dep := range list {
+			path, _, _ := strings.Cut(dep, "@")
+			modules = append(modules, path)
+		}
+	}
+	moduleEntry := widget.NewSelectEntry(modules)
+	moduleEntry.SetPlaceHolder("例如: github.com/gin-gonic/gin")
+
+	dirEntry := widget.NewEntry()
+	dirEntry.SetPlaceHolder("本地检出的目录（其中有同名模块的 go.mod）")
+	browseBtn := widget.NewButton("📂", func() {
+		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err == nil && uri != nil {
+				dirEntry.SetText(uri.Path())
+			}
+		}, l.window)
+	})
+
+	help := widget.NewLabel("通过 go mod edit -replace 写入 go.mod，目录在 server 之下或旁边时写入相对路径。" +
+		"本地 replace 只适合临时调试，提交代码前记得删除。")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		widget.NewForm(
+			widget.NewFormItem("模块", moduleEntry),
+			widget.NewFormItem("本地目录", container.NewBorder(nil, nil, nil, browseBtn, dirEntry)),
+		),
+	)
+
+	dialog.ShowCustomConfirm("➕ 替换为本地目录", "💾 保存", "❌ 取消", content, func(ok bool) {
+		if !ok {
+			l.showWorkspaceDialog()
+			return
+		}
+		if _, err := l.deps.AddLocalReplace(moduleEntry.Text, strings.TrimSpace(dirEntry.Text)); err != nil {
+			l.showError(err, nil)
+			return
+		}
+		l.showWorkspaceDialog()
+		l.checkDependencies(nil)
+	}, l.window)
+}
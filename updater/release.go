@@ -0,0 +1,204 @@
+// Package updater 负责面板自更新：查询 GitHub/Gitee 的最新发布、下载对应平台的可执行文件、
+// 校验 SHA256 后替换当前程序并重新启动
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/download"
+)
+
+// Source 发布源
+type Source struct {
+	Name   string // 显示名称
+	APIURL string // 最新发布查询地址（返回 GitHub/Gitee 兼容的 release JSON）
+}
+
+// 默认发布源（GitHub 优先，国内网络访问失败时回退到 Gitee）
+var DefaultSources = []Source{
+	{Name: "GitHub", APIURL: "https://api.github.com/repos/XiaoafengClub/GVAPanel/releases/latest"},
+	{Name: "Gitee", APIURL: "https://gitee.com/api/v5/repos/XiaoafengClub/GVAPanel/releases/latest"},
+}
+
+// Release 一个发布版本
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Name   string  `json:"name"`
+	Notes  string  `json:"body"`
+	Assets []Asset `json:"assets"`
+	Source string  `json:"-"` // 来自哪个发布源
+}
+
+// Asset 发布附件
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// LatestRelease 依次查询发布源，返回第一个成功的结果
+func LatestRelease(sources []Source) (*Release, error) {
+	var errs []string
+	for _, src := range sources {
+		rel, err := fetchRelease(src)
+		if err == nil {
+			return rel, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", src.Name, err))
+	}
+	return nil, apperr.Errorf(apperr.UpdateCheckFailed, "查询最新版本失败:\n%s", strings.Join(errs, "\n"))
+}
+
+// fetchRelease 查询单个发布源
+func fetchRelease(src Source) (*Release, error) {
+	req, err := http.NewRequest(http.MethodGet, src.APIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "GVAPanel")
+
+	resp, err := download.Client(15 * time.Second).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var rel Release
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("解析发布信息失败: %v", err)
+	}
+	if rel.Tag == "" {
+		return nil, fmt.Errorf("发布信息缺少版本号")
+	}
+	rel.Source = src.Name
+	return &rel, nil
+}
+
+// IsNewer 判断 latest 是否比 current 新（按 vX.Y.Z 逐段比较，无法解析的段视为 0）
+func IsNewer(latest, current string) bool {
+	a, b := versionParts(latest), versionParts(current)
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+// versionParts 将 v1.2.3-beta 拆分为 [1 2 3]
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// osAliases 附件名中各系统可能使用的写法
+var osAliases = map[string][]string{
+	"windows": {"windows", ".exe"},
+	"darwin":  {"darwin", "macos", "mac"},
+	"linux":   {"linux"},
+}
+
+// archAliases 附件名中各架构可能使用的写法
+var archAliases = map[string][]string{
+	"amd64": {"amd64", "x86_64", "x64"},
+	"arm64": {"arm64", "aarch64"},
+	"386":   {"386", "i686"},
+}
+
+// MatchAsset 查找与系统/架构匹配的可执行文件附件（只支持直接发布的可执行文件，跳过压缩包）
+// 系统名必须匹配；附件名带架构时必须匹配，不带架构的视为 amd64 通用包（优先选择带架构的）
+func (r *Release) MatchAsset(goos, goarch string) (*Asset, bool) {
+	var fallback *Asset
+	for i := range r.Assets {
+		asset := &r.Assets[i]
+		name := strings.ToLower(asset.Name)
+		if isChecksumFile(name) || isArchive(name) || !containsAny(name, osAliases[goos]) {
+			continue
+		}
+
+		if containsAny(name, archAliases[goarch]) {
+			return asset, true
+		}
+		if fallback == nil && goarch == "amd64" && !containsAnyArch(name) {
+			fallback = asset
+		}
+	}
+	return fallback, fallback != nil
+}
+
+// ChecksumAsset 查找校验文件（checksums.txt / SHA256SUMS，或 <附件名>.sha256）
+func (r *Release) ChecksumAsset(asset *Asset) (*Asset, bool) {
+	for i := range r.Assets {
+		if strings.EqualFold(r.Assets[i].Name, asset.Name+".sha256") {
+			return &r.Assets[i], true
+		}
+	}
+	for i := range r.Assets {
+		name := strings.ToLower(r.Assets[i].Name)
+		if name == "checksums.txt" || name == "sha256sums" || name == "sha256sums.txt" {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// isChecksumFile 是否为校验文件
+func isChecksumFile(name string) bool {
+	return strings.HasSuffix(name, ".sha256") || strings.Contains(name, "checksum") || strings.Contains(name, "sha256sums")
+}
+
+// isArchive 是否为压缩包
+func isArchive(name string) bool {
+	for _, ext := range []string{".zip", ".tar.gz", ".tgz", ".7z", ".dmg"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny name 是否包含 words 中任意一个
+func containsAny(name string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnyArch name 是否带有任意架构标识
+func containsAnyArch(name string) bool {
+	for _, words := range archAliases {
+		if containsAny(name, words) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package updater
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gva-launcher/apperr"
+	"gva-launcher/download"
+	"gva-launcher/internal/sysutil"
+)
+
+// ProgressFunc 下载进度回调（total 未知时为 -1）
+type ProgressFunc func(downloaded, total int64)
+
+// Update 下载当前平台的新版本，校验 SHA256 后替换正在运行的可执行文件
+// 替换成功后需要调用 Relaunch 重新启动
+func Update(rel *Release, progress ProgressFunc) error {
+	asset, ok := rel.MatchAsset(runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return apperr.Errorf(apperr.UpdateNoAsset, "版本 %s 没有适用于 %s/%s 的安装包", rel.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	sumAsset, ok := rel.ChecksumAsset(asset)
+	if !ok {
+		return apperr.Errorf(apperr.UpdateNoAsset, "版本 %s 没有提供校验文件，已取消更新", rel.Tag)
+	}
+
+	exePath, err := executablePath()
+	if err != nil {
+		return err
+	}
+
+	// 1. 读取期望的校验值
+	sums, err := downloadText(sumAsset.URL)
+	if err != nil {
+		return apperr.Errorf(apperr.UpdateDownloadFailed, "下载校验文件失败: %v", err)
+	}
+	expected, ok := parseChecksum(sums, asset.Name)
+	if !ok {
+		return apperr.Errorf(apperr.UpdateChecksum, "校验文件中没有 %s 的 SHA256", asset.Name)
+	}
+
+	// 2. 下载到可执行文件同目录（保证后续 rename 不跨磁盘）
+	newPath := exePath + ".new"
+	if err := downloadFile(asset.URL, newPath, progress); err != nil {
+		os.Remove(newPath)
+		return apperr.Errorf(apperr.UpdateDownloadFailed, "下载更新失败: %v", err)
+	}
+
+	// 3. 校验
+	if err := verifyFile(newPath, expected); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+
+	// 4. 替换
+	if err := replaceExecutable(exePath, newPath); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+	return nil
+}
+
+// Relaunch 以相同参数启动新的可执行文件（调用方随后应退出当前进程）
+func Relaunch() error {
+	exePath, err := executablePath()
+	if err != nil {
+		return err
+	}
+	if _, err := sysutil.Runner.Start(filepath.Dir(exePath), exePath, os.Args[1:]...); err != nil {
+		return fmt.Errorf("重新启动失败: %v", err)
+	}
+	return nil
+}
+
+// CleanupOld 删除上次更新留下的旧版本文件（启动时调用）
+func CleanupOld() {
+	if exePath, err := executablePath(); err == nil {
+		os.Remove(exePath + ".old")
+	}
+}
+
+// executablePath 当前可执行文件的真实路径
+func executablePath() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("获取程序路径失败: %v", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+	return exePath, nil
+}
+
+// replaceExecutable 用 newPath 替换 exePath（正在运行的程序先改名为 .old，Windows 下也允许）
+func replaceExecutable(exePath, newPath string) error {
+	if err := os.Chmod(newPath, 0755); err != nil {
+		return apperr.Errorf(apperr.UpdateReplaceFailed, "设置可执行权限失败: %v", err)
+	}
+
+	oldPath := exePath + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return apperr.Errorf(apperr.UpdateReplaceFailed, "备份当前程序失败: %v", err)
+	}
+	if err := os.Rename(newPath, exePath); err != nil {
+		// 还原旧版本
+		os.Rename(oldPath, exePath)
+		return apperr.Errorf(apperr.UpdateReplaceFailed, "替换程序失败: %v", err)
+	}
+	return nil
+}
+
+// downloadText 下载小文本文件
+func downloadText(url string) (string, error) {
+	resp, err := get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// downloadFile 下载文件到 dest
+func downloadFile(url, dest string, progress ProgressFunc) error {
+	resp, err := get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = resp.Body
+	if progress != nil {
+		reader = &progressReader{r: resp.Body, total: resp.ContentLength, fn: progress}
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// get 发送 GET 请求并检查状态码（使用配置的代理，GitHub 地址失败时改用镜像）
+func get(url string) (*http.Response, error) {
+	return download.Get(url, 10*time.Minute)
+}
+
+// progressReader 统计已读取字节数
+type progressReader struct {
+	r          io.Reader
+	downloaded int64
+	total      int64
+	fn         ProgressFunc
+}
+
+// Read 读取并回调进度
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.downloaded += int64(n)
+	p.fn(p.downloaded, p.total)
+	return n, err
+}
+
+// parseChecksum 从 sha256sum 格式（<hash>  <文件名>）或单独的哈希值中找到 name 的校验值
+func parseChecksum(content, name string) (string, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	var single string
+	lines := 0
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		lines++
+		if len(fields) == 1 {
+			single = fields[0]
+			continue
+		}
+		// 二进制模式下文件名前带 *
+		if strings.TrimPrefix(fields[len(fields)-1], "*") == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+
+	// <附件名>.sha256 文件可能只有一个哈希值
+	if lines == 1 && single != "" {
+		return strings.ToLower(single), true
+	}
+	return "", false
+}
+
+// verifyFile 校验文件的 SHA256
+func verifyFile(path, expected string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("计算校验值失败: %v", err)
+	}
+
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if actual != expected {
+		return apperr.Errorf(apperr.UpdateChecksum, "校验失败，安装包可能已损坏或被篡改\n期望: %s\n实际: %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package updater
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.0.1", "v1.0.0", true},
+		{"v1.1.0", "v1.0.9", true},
+		{"v2.0.0", "v1.10.0", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"v1.0.0", "v1.0.0", false},
+		{"1.0.0", "v1.0.0", false},
+		{"v1.0", "v1.0.1", false},
+		{"v1.0.1-beta", "v1.0.0", true},
+		{"v0.9.9", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestMatchAsset(t *testing.T) {
+	rel := &Release{Assets: []Asset{
+		{Name: "checksums.txt"},
+		{Name: "GVAPanel_for_windows.exe"},
+		{Name: "GVAPanel_darwin_arm64"},
+		{Name: "GVAPanel_darwin_amd64"},
+		{Name: "GVAPanel_linux_amd64.tar.gz"},
+		{Name: "GVAPanel_linux_amd64"},
+		{Name: "GVAPanel_linux_amd64.sha256"},
+	}}
+
+	tests := []struct {
+		goos, goarch, want string
+	}{
+		{"windows", "amd64", "GVAPanel_for_windows.exe"},
+		{"darwin", "arm64", "GVAPanel_darwin_arm64"},
+		{"darwin", "amd64", "GVAPanel_darwin_amd64"},
+		{"linux", "amd64", "GVAPanel_linux_amd64"},
+		{"linux", "arm64", ""},
+		{"windows", "arm64", ""},
+	}
+	for _, tt := range tests {
+		asset, ok := rel.MatchAsset(tt.goos, tt.goarch)
+		got := ""
+		if ok {
+			got = asset.Name
+		}
+		if got != tt.want {
+			t.Errorf("MatchAsset(%s, %s) = %q, want %q", tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+
+	// 优先使用单独的 .sha256 文件
+	linux, _ := rel.MatchAsset("linux", "amd64")
+	if sum, ok := rel.ChecksumAsset(linux); !ok || sum.Name != "GVAPanel_linux_amd64.sha256" {
+		t.Errorf("ChecksumAsset(linux) = %v", sum)
+	}
+	win, _ := rel.MatchAsset("windows", "amd64")
+	if sum, ok := rel.ChecksumAsset(win); !ok || sum.Name != "checksums.txt" {
+		t.Errorf("ChecksumAsset(windows) = %v", sum)
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	content := "aaa  GVAPanel_darwin_arm64\nBBB *GVAPanel_for_windows.exe\n"
+	if got, ok := parseChecksum(content, "GVAPanel_for_windows.exe"); !ok || got != "bbb" {
+		t.Errorf("got %q, %v", got, ok)
+	}
+	if _, ok := parseChecksum(content, "missing"); ok {
+		t.Error("不存在的文件不应找到校验值")
+	}
+	if got, ok := parseChecksum("ccc\n", "anything"); !ok || got != "ccc" {
+		t.Errorf("单独的哈希值: got %q, %v", got, ok)
+	}
+}
+
+func TestLatestReleaseFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			http.Error(w, "rate limited", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"tag_name":"v1.2.0","body":"notes","assets":[{"name":"a","browser_download_url":"http://x/a"}]}`)
+	}))
+	defer server.Close()
+
+	rel, err := LatestRelease([]Source{
+		{Name: "GitHub", APIURL: server.URL + "/down"},
+		{Name: "Gitee", APIURL: server.URL + "/ok"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Tag != "v1.2.0" || rel.Source != "Gitee" || len(rel.Assets) != 1 || rel.Assets[0].URL != "http://x/a" {
+		t.Errorf("解析结果不正确: %+v", rel)
+	}
+
+	if _, err := LatestRelease([]Source{{Name: "GitHub", APIURL: server.URL + "/down"}}); err == nil {
+		t.Error("所有发布源失败时应返回错误")
+	}
+}
+
+func TestDownloadAndVerify(t *testing.T) {
+	payload := []byte("new binary")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "GVAPanel.new")
+	var lastDownloaded int64
+	if err := downloadFile(server.URL, dest, func(downloaded, total int64) { lastDownloaded = downloaded }); err != nil {
+		t.Fatal(err)
+	}
+	if lastDownloaded != int64(len(payload)) {
+		t.Errorf("进度回调 = %d, want %d", lastDownloaded, len(payload))
+	}
+
+	sum := sha256.Sum256(payload)
+	if err := verifyFile(dest, hex.EncodeToString(sum[:])); err != nil {
+		t.Errorf("校验应通过: %v", err)
+	}
+	if err := verifyFile(dest, "deadbeef"); err == nil {
+		t.Error("校验值不符时应返回错误")
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "GVAPanel")
+	newPath := exe + ".new"
+	os.WriteFile(exe, []byte("old"), 0755)
+	os.WriteFile(newPath, []byte("new"), 0644)
+
+	if err := replaceExecutable(exe, newPath); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new" {
+		t.Errorf("替换后内容 = %q", data)
+	}
+	if data, _ := os.ReadFile(exe + ".old"); string(data) != "old" {
+		t.Errorf("备份内容 = %q", data)
+	}
+}
@@ -0,0 +1,140 @@
+package watchdog
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"syscall"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/crash"
+	"gva-launcher/hooks"
+	"gva-launcher/instance"
+	"gva-launcher/jobs"
+	"gva-launcher/launcher"
+	"gva-launcher/metrics"
+	"gva-launcher/supervisor"
+)
+
+// LogName 看守模式的日志文件名（位于面板日志目录）
+const LogName = "watchdog.log"
+
+// Main 看守模式入口：运行到收到退出信号为止，返回进程退出码
+// 已有看守进程在运行时直接退出（登录自启动和手动运行不会重复看守）
+func Main() int {
+	return run(nil)
+}
+
+// Fallback 没有图形会话时的命令行模式：先在终端中说明原因，再以看守模式运行，
+// 日志同时输出到标准错误（Ctrl+C 退出，服务继续运行）
+func Fallback(reason string) int {
+	fmt.Fprintln(os.Stderr, reason)
+	fmt.Fprintf(os.Stderr, "无法显示面板窗口，改为命令行模式：按「看守模式」的设置启动并保持服务运行，日志同时写入 %s\n",
+		filepath.Join(config.LogDir(), LogName))
+	fmt.Fprintf(os.Stderr, "需要保持运行的服务在配置文件 %s 的 watchdog 中设置，例如 \"watchdog\": {\"backend\": true, \"frontend\": true}\n", config.Path())
+	return run(os.Stderr)
+}
+
+// run 运行看守模式；console 不为 nil 时日志同时写入 console
+func run(console io.Writer) int {
+	crash.Setup(config.CrashDir(), launcher.Version)
+	defer crash.Recover("看守模式")
+
+	logger, closeLog := openLog(console)
+	defer closeLog()
+
+	lock, existing, err := instance.Acquire(config.WatchdogLockPath())
+	if existing != nil {
+		logger.Printf("看守模式已在运行（PID %d），本次退出", existing.PID)
+		return 0
+	}
+	if err != nil {
+		logger.Printf("获取看守锁失败: %v", err)
+		return 1
+	}
+	defer lock.Release()
+
+	sup := supervisor.New()
+	sup.OnPanic = func(name string, recovered interface{}) {
+		crash.Report(name, recovered, debug.Stack())
+	}
+	ctx, stop := signal.NotifyContext(sup.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// 其他实例请求接管时退出
+	lock.OnQuit = stop
+
+	var mu sync.Mutex
+	cfg := config.Load()
+	current := func() config.Config {
+		mu.Lock()
+		defer mu.Unlock()
+		return cfg
+	}
+
+	project := launcher.NewProject(cfg.GVARootPath)
+	serviceManager := launcher.NewServiceManager(project)
+	queue := jobs.NewQueue(config.LogDir())
+	serviceManager.Hooks = hooks.NewDispatcher(queue, func() []config.Hook { return current().Hooks })
+	serviceManager.Timeouts = func() config.Timeouts { return current().EffectiveTimeouts() }
+	serviceManager.PreferBinary = func() bool { return current().LowResource }
+	serviceManager.CompiledRun = func() bool { return current().CompiledRun }
+	serviceManager.ProductionMode = func() bool { return current().ProductionMode }
+
+	w := New(project, serviceManager, func() config.Config {
+		loaded := config.Load()
+		mu.Lock()
+		cfg = loaded
+		mu.Unlock()
+		return loaded
+	})
+	defer w.Close()
+	w.PanelRunning = func() bool { return instance.Running(config.LockPath()) }
+	w.Logf = logger.Printf
+
+	logger.Printf("看守模式启动（版本 %s，PID %d）", launcher.Version, os.Getpid())
+
+	// 状态导出接口（地址在启动时读取，修改后需重新启动看守模式）
+	if addr := cfg.Metrics.Addr; addr != "" {
+		server, err := metrics.Serve(addr, metrics.Handler(func() metrics.Snapshot {
+			return metrics.Collect(metrics.ModeWatchdog, project, serviceManager)
+		}))
+		if err != nil {
+			logger.Printf("%v", err)
+		} else {
+			logger.Printf("状态导出接口: http://%s/metrics", server.Addr)
+			defer server.Close()
+		}
+	}
+
+	sup.Go("任务队列", queue.Run)
+	sup.Go("看守服务", w.Run)
+
+	<-ctx.Done()
+	logger.Printf("看守模式退出（服务继续运行）")
+	sup.Cancel()
+	sup.Shutdown(3 * time.Second)
+	return 0
+}
+
+// openLog 打开看守日志（追加写入，console 不为 nil 时同时写入 console；无法打开时输出到标准错误）
+func openLog(console io.Writer) (*log.Logger, func()) {
+	dir := config.LogDir()
+	if err := os.MkdirAll(dir, 0755); err == nil {
+		file, err := os.OpenFile(filepath.Join(dir, LogName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err == nil {
+			var out io.Writer = file
+			if console != nil {
+				out = io.MultiWriter(file, console)
+			}
+			return log.New(out, "", log.LstdFlags), func() { file.Close() }
+		}
+	}
+	fmt.Fprintln(os.Stderr, "无法打开看守日志，输出到标准错误")
+	return log.New(os.Stderr, "", log.LstdFlags), func() {}
+}
@@ -0,0 +1,175 @@
+// Package watchdog 是面板的看守模式（--watchdog）：不显示窗口，通常由登录自启动入口运行，
+// 拉起配置中需要保持运行的前后端服务，并在服务退出后重新启动。
+// 面板窗口打开期间由窗口管理服务（用户可能主动停止服务），看守模式暂停检查。
+package watchdog
+
+import (
+	"context"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/instance"
+	"gva-launcher/launcher"
+	"gva-launcher/services"
+	"gva-launcher/supervisor"
+)
+
+// Interval 两次检查之间的间隔
+var Interval = 10 * time.Second
+
+// serviceLabels 日志中显示的服务名
+var serviceLabels = map[string]string{
+	"backend":  "后端",
+	"frontend": "前端",
+}
+
+// Watchdog 定期检查服务端口，未运行的服务自动启动
+type Watchdog struct {
+	Project  *launcher.Project
+	Services *launcher.ServiceManager
+
+	// Config 每次检查时调用，读取最新的面板配置
+	Config func() config.Config
+	// PanelRunning 面板窗口是否在运行（为 nil 时视为未运行）
+	PanelRunning func() bool
+	// Logf 输出日志（为 nil 时不输出）
+	Logf func(format string, args ...interface{})
+
+	portInUse func(port int) bool
+	start     func(service string, port int)
+	started   map[string]time.Time // 各服务最近一次由看守模式启动的时间
+	lastNote  string
+	lock      *instance.Lock // 项目锁（同一用户的面板窗口持有时为 nil）
+	lockRoot  string
+}
+
+// New 创建看守者（cfg 在每次检查时调用）
+func New(project *launcher.Project, serviceManager *launcher.ServiceManager, cfg func() config.Config) *Watchdog {
+	w := &Watchdog{
+		Project:   project,
+		Services:  serviceManager,
+		Config:    cfg,
+		portInUse: services.IsPortInUse,
+		started:   make(map[string]time.Time),
+	}
+	w.start = w.startService
+	return w
+}
+
+// Run 每隔 Interval 检查一次（低资源模式下间隔延长），直到 ctx 取消
+func (w *Watchdog) Run(ctx context.Context) {
+	for {
+		w.Check()
+		interval := Interval
+		if w.Config().LowResource {
+			interval *= config.LowResourceFactor
+		}
+		if !supervisor.Sleep(ctx, interval) {
+			return
+		}
+	}
+}
+
+// Check 检查一次：配置为保持运行、但端口未被监听的服务会被启动
+// 刚启动的服务在「启动宽限时长」内不重复启动（go run 编译、npm 打包期间端口尚未监听）
+func (w *Watchdog) Check() {
+	if w.PanelRunning != nil && w.PanelRunning() {
+		w.note("面板窗口已打开，由窗口管理服务，看守暂停")
+		return
+	}
+
+	cfg := w.Config()
+	if !cfg.Watchdog.Backend && !cfg.Watchdog.Frontend {
+		w.note("配置中没有需要保持运行的服务")
+		return
+	}
+	w.Project.Root = cfg.GVARootPath
+	w.Project.UseWSL()
+	if !w.Project.IsValid() {
+		w.note("GVA 根目录未设置或无效: " + cfg.GVARootPath)
+		return
+	}
+	if !w.ownProject() {
+		return
+	}
+	w.note("正在看守 " + cfg.GVARootPath)
+
+	backendPort, frontendPort := w.Project.Ports()
+	grace := cfg.EffectiveTimeouts().MonitorWindow()
+	w.ensure("backend", cfg.Watchdog.Backend, backendPort, grace)
+	// 生产模式下页面由后端提供，不需要前端开发服务器
+	w.ensure("frontend", cfg.Watchdog.Frontend && !cfg.ProductionMode, frontendPort, grace)
+}
+
+// ensure 服务需要保持运行且端口空闲时启动服务
+func (w *Watchdog) ensure(service string, wanted bool, port int, grace time.Duration) {
+	if !wanted || port <= 0 || w.portInUse(port) {
+		return
+	}
+	if last, ok := w.started[service]; ok && time.Since(last) < grace {
+		return
+	}
+
+	if _, ok := w.started[service]; ok {
+		w.logf("%s已停止（端口 %d 未监听），重新启动", serviceLabels[service], port)
+	} else {
+		w.logf("启动%s（端口 %d）", serviceLabels[service], port)
+	}
+	w.started[service] = time.Now()
+	w.start(service, port)
+}
+
+// ownProject 获取项目锁（根目录变化时先释放旧项目的锁），项目正被其他用户使用时返回 false
+func (w *Watchdog) ownProject() bool {
+	if w.lockRoot != w.Project.Root {
+		w.Close()
+	}
+	if w.lock != nil {
+		return true
+	}
+
+	lock, holder, err := w.Project.Lock()
+	if holder != nil {
+		w.note("项目正在被 " + holder.Owner() + " 使用，看守暂停")
+		return false
+	}
+	if err == nil && lock != nil {
+		w.lock, w.lockRoot = lock, w.Project.Root
+	}
+	return true
+}
+
+// Close 释放项目锁
+func (w *Watchdog) Close() {
+	if w.lock != nil {
+		w.lock.Release()
+		w.lock = nil
+	}
+	w.lockRoot = ""
+}
+
+// startService 通过服务管理器在后台启动服务（退出时照常触发 on-crash 钩子）
+func (w *Watchdog) startService(service string, port int) {
+	switch service {
+	case "backend":
+		go w.Services.StartBackend(port)
+	case "frontend":
+		go w.Services.StartFrontend(port)
+	}
+}
+
+// note 记录状态变化（与上一次相同的状态不重复输出）
+func (w *Watchdog) note(message string) {
+	if message == w.lastNote {
+		return
+	}
+	w.lastNote = message
+	w.logf("%s", message)
+}
+
+// logf 输出日志
+func (w *Watchdog) logf(format string, args ...interface{}) {
+	if w.Logf != nil {
+		w.Logf(format, args...)
+	}
+}
@@ -0,0 +1,120 @@
+package watchdog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gva-launcher/config"
+	"gva-launcher/launcher"
+)
+
+// newTestProject 创建包含 server/config.yaml（后端 8888）和 web/（前端默认 8080）的项目
+func newTestProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "web"), 0755)
+	os.MkdirAll(filepath.Join(root, "server"), 0755)
+	if err := os.WriteFile(filepath.Join(root, "server", "config.yaml"), []byte("system:\n  addr: 8888\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// newTestWatchdog 创建不真正启动进程的看守者，返回已启动的服务记录
+func newTestWatchdog(cfg *config.Config, inUse map[int]bool) (*Watchdog, *[]string) {
+	project := launcher.NewProject("")
+	w := New(project, launcher.NewServiceManager(project), func() config.Config { return *cfg })
+	var started []string
+	w.portInUse = func(port int) bool { return inUse[port] }
+	w.start = func(service string, port int) { started = append(started, service) }
+	return w, &started
+}
+
+func TestCheckSkipsProjectLockedByOthers(t *testing.T) {
+	root := newTestProject(t)
+	// 其他主机上的用户正在使用该项目
+	lockInfo := `{"pid":42,"addr":"127.0.0.1:1","user":"alice","host":"another-host"}`
+	if err := os.WriteFile(filepath.Join(root, launcher.LockName), []byte(lockInfo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{GVARootPath: root, Watchdog: config.Watchdog{Backend: true}}
+	w, started := newTestWatchdog(&cfg, map[int]bool{})
+	defer w.Close()
+
+	w.Check()
+	if len(*started) != 0 {
+		t.Errorf("项目被其他用户占用时不应启动服务: %v", *started)
+	}
+}
+
+func TestCheckStartsConfiguredServices(t *testing.T) {
+	cfg := config.Config{GVARootPath: newTestProject(t), Watchdog: config.Watchdog{Backend: true}}
+	w, started := newTestWatchdog(&cfg, map[int]bool{})
+	defer w.Close()
+
+	w.Check()
+	if want := []string{"backend"}; !reflect.DeepEqual(*started, want) {
+		t.Fatalf("started = %v, want %v", *started, want)
+	}
+
+	// 启动宽限时长内不重复启动
+	w.Check()
+	if len(*started) != 1 {
+		t.Errorf("启动后立即检查不应重复启动: %v", *started)
+	}
+
+	// 超过监控时长仍未监听则重新启动
+	w.started["backend"] = time.Now().Add(-time.Hour)
+	w.Check()
+	if len(*started) != 2 {
+		t.Errorf("服务停止后应重新启动: %v", *started)
+	}
+}
+
+func TestCheckSkipsRunningServices(t *testing.T) {
+	cfg := config.Config{GVARootPath: newTestProject(t), Watchdog: config.Watchdog{Backend: true, Frontend: true}}
+	w, started := newTestWatchdog(&cfg, map[int]bool{8888: true})
+	defer w.Close()
+
+	w.Check()
+	if want := []string{"frontend"}; !reflect.DeepEqual(*started, want) {
+		t.Fatalf("started = %v, want %v", *started, want)
+	}
+}
+
+func TestCheckPausedOrInvalid(t *testing.T) {
+	cfg := config.Config{GVARootPath: newTestProject(t), Watchdog: config.Watchdog{Backend: true, Frontend: true}}
+	w, started := newTestWatchdog(&cfg, map[int]bool{})
+
+	// 面板窗口打开时暂停
+	w.PanelRunning = func() bool { return true }
+	w.Check()
+	if len(*started) != 0 {
+		t.Errorf("面板运行时不应启动服务: %v", *started)
+	}
+
+	// 根目录无效时不启动
+	w.PanelRunning = nil
+	cfg.GVARootPath = filepath.Join(t.TempDir(), "missing")
+	w.Check()
+	if len(*started) != 0 {
+		t.Errorf("根目录无效时不应启动服务: %v", *started)
+	}
+}
+
+func TestNoteLogsChangesOnly(t *testing.T) {
+	cfg := config.Config{}
+	w, _ := newTestWatchdog(&cfg, nil)
+	var logs []string
+	w.Logf = func(format string, args ...interface{}) { logs = append(logs, format) }
+
+	w.Check()
+	w.Check()
+	if len(logs) != 1 {
+		t.Errorf("相同状态应只记录一次: %v", logs)
+	}
+}
@@ -0,0 +1,87 @@
+// Package wol 远程服务器的网络唤醒（Wake-on-LAN 魔术包）和连通性检测
+package wol
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultBroadcast 未指定广播地址时发送唤醒包的地址（UDP 9 端口，discard）
+const DefaultBroadcast = "255.255.255.255:9"
+
+// ParseMAC 解析 MAC 地址（支持 aa:bb:cc:dd:ee:ff、aa-bb-cc-dd-ee-ff 和 aabb.ccdd.eeff）
+func ParseMAC(s string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(strings.TrimSpace(s))
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("MAC 地址 %q 无效，例如 aa:bb:cc:dd:ee:ff", s)
+	}
+	return mac, nil
+}
+
+// MagicPacket 唤醒包：6 个 0xFF 之后重复 16 次 MAC 地址
+func MagicPacket(mac net.HardwareAddr) []byte {
+	return append(bytes.Repeat([]byte{0xff}, 6), bytes.Repeat(mac, 16)...)
+}
+
+// broadcastAddr 补全广播地址（省略端口时使用 9）
+func broadcastAddr(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return DefaultBroadcast
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return net.JoinHostPort(strings.Trim(addr, "[]"), "9")
+	}
+	return addr
+}
+
+// Send 向广播地址发送 mac 的唤醒包（broadcast 为空时使用 DefaultBroadcast，
+// 跨网段唤醒时填写目标网段的定向广播地址，例如 192.168.1.255）
+func Send(mac, broadcast string) error {
+	hw, err := ParseMAC(mac)
+	if err != nil {
+		return err
+	}
+	addr := broadcastAddr(broadcast)
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return fmt.Errorf("发送唤醒包到 %s 失败: %v", addr, err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write(MagicPacket(hw)); err != nil {
+		return fmt.Errorf("发送唤醒包到 %s 失败: %v", addr, err)
+	}
+	return nil
+}
+
+// Reachable 检测 addr（host:port，通常是 SSH 端口）能否建立 TCP 连接，返回连接耗时。
+// 不使用 ICMP ping，无需管理员权限，也能确认服务已经启动
+func Reachable(addr string, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return 0, err
+	}
+	conn.Close()
+	return time.Since(start), nil
+}
+
+// WaitReachable 每隔 interval 检测一次，直到 addr 可连接或 ctx 结束
+func WaitReachable(ctx context.Context, addr string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := Reachable(addr, interval); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待 %s 可连接超时", addr)
+		case <-ticker.C:
+		}
+	}
+}
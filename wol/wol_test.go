@@ -0,0 +1,96 @@
+package wol
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParseMAC(t *testing.T) {
+	for _, s := range []string{"aa:bb:cc:dd:ee:ff", "AA-BB-CC-DD-EE-FF", "aabb.ccdd.eeff", " aa:bb:cc:dd:ee:ff "} {
+		if mac, err := ParseMAC(s); err != nil || mac.String() != "aa:bb:cc:dd:ee:ff" {
+			t.Errorf("%q: mac = %v, err = %v", s, mac, err)
+		}
+	}
+	for _, s := range []string{"", "aa:bb:cc", "00:00:5e:00:53:01:02:03"} {
+		if _, err := ParseMAC(s); err == nil {
+			t.Errorf("%q 应报错", s)
+		}
+	}
+}
+
+func TestMagicPacket(t *testing.T) {
+	mac, _ := ParseMAC("01:02:03:04:05:06")
+	packet := MagicPacket(mac)
+	if len(packet) != 102 || !bytes.Equal(packet[:6], bytes.Repeat([]byte{0xff}, 6)) {
+		t.Fatalf("packet = %x", packet)
+	}
+	for i := 0; i < 16; i++ {
+		if !bytes.Equal(packet[6+i*6:12+i*6], mac) {
+			t.Fatalf("第 %d 段 = %x", i, packet[6+i*6:12+i*6])
+		}
+	}
+}
+
+func TestBroadcastAddr(t *testing.T) {
+	cases := map[string]string{
+		"":                DefaultBroadcast,
+		"192.168.1.255":   "192.168.1.255:9",
+		"192.168.1.255:7": "192.168.1.255:7",
+		"ff02::1":         "[ff02::1]:9",
+		"[ff02::1]:7":     "[ff02::1]:7",
+	}
+	for in, want := range cases {
+		if got := broadcastAddr(in); got != want {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := Send("01:02:03:04:05:06", conn.LocalAddr().String()); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 200)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac, _ := ParseMAC("01:02:03:04:05:06")
+	if !bytes.Equal(buf[:n], MagicPacket(mac)) {
+		t.Errorf("received = %x", buf[:n])
+	}
+}
+
+func TestReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	if _, err := Reachable(addr, time.Second); err != nil {
+		t.Errorf("监听中的端口应可连接: %v", err)
+	}
+	if err := WaitReachable(context.Background(), addr, 50*time.Millisecond); err != nil {
+		t.Error(err)
+	}
+
+	listener.Close()
+	if _, err := Reachable(addr, time.Second); err == nil {
+		t.Error("已关闭的端口不应可连接")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if err := WaitReachable(ctx, addr, 50*time.Millisecond); err == nil {
+		t.Error("超时后应返回错误")
+	}
+}